@@ -0,0 +1,5 @@
+// Package mediatranscriberv1 holds the Go messages and gRPC stubs generated
+// from api/proto/mediatranscriber/v1/media_transcriber.proto.
+package mediatranscriberv1
+
+//go:generate protoc -I ../../../proto --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative mediatranscriber/v1/media_transcriber.proto
@@ -2,11 +2,39 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"media-transcriber/internal/bootstrap"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			if err := bootstrap.Serve(os.Args[2:]); err != nil {
+				log.Fatalf("serve: %v", err)
+			}
+			return
+		case "transcribe":
+			if err := bootstrap.TranscribeCommand(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+				log.Printf("transcribe: %v", err)
+				os.Exit(bootstrap.ExitCode(err))
+			}
+			return
+		case "doctor":
+			if err := bootstrap.DoctorCommand(os.Args[2:], os.Stdout); err != nil {
+				log.Printf("doctor: %v", err)
+				os.Exit(bootstrap.ExitCode(err))
+			}
+			return
+		case "token":
+			if err := bootstrap.TokenCommand(os.Args[2:], os.Stdout); err != nil {
+				log.Fatalf("token: %v", err)
+			}
+			return
+		}
+	}
+
 	app, err := bootstrap.New()
 	if err != nil {
 		log.Fatalf("bootstrap app: %v", err)
@@ -0,0 +1,23 @@
+// Command openapi writes the OpenAPI document of the server-mode API.
+package main
+
+import (
+	"log"
+	"os"
+
+	"media-transcriber/internal/bootstrap"
+)
+
+func main() {
+	path := "api/openapi.json"
+	if len(os.Args) > 1 {
+		path = os.Args[1]
+	}
+	data, err := bootstrap.OpenAPIDocument()
+	if err != nil {
+		log.Fatalf("render openapi: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		log.Fatalf("write openapi: %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"media-transcriber/internal/llm"
+)
+
+const (
+	// maxPerKind caps keywords, names, and topics each.
+	maxPerKind = 10
+	// llmInputChars bounds the transcript excerpt sent to an LLM.
+	llmInputChars = 12000
+)
+
+// Analysis holds what a transcript is about.
+type Analysis struct {
+	Keywords []string `json:"keywords"`
+	Names    []string `json:"names"`
+	Topics   []string `json:"topics"`
+}
+
+// Tags merges topics, names, and keywords into one list without
+// case-insensitive duplicates, in that order.
+func (a Analysis) Tags() []string {
+	seen := map[string]bool{}
+	var tags []string
+	for _, group := range [][]string{a.Topics, a.Names, a.Keywords} {
+		for _, tag := range group {
+			tag = strings.TrimSpace(tag)
+			key := strings.ToLower(tag)
+			if tag == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Local extracts keywords, names, and topics with frequency rules only:
+// keywords are repeated non-stopwords, names are capitalized runs inside
+// sentences, and topics are repeated two-word phrases.
+func Local(text string) Analysis {
+	keywords := map[string]int{}
+	names := map[string]int{}
+	topics := map[string]int{}
+
+	for _, sentence := range splitSentences(text) {
+		words := splitWords(sentence)
+		var run []string
+		flushRun := func() {
+			if len(run) > 0 {
+				names[strings.Join(run, " ")]++
+				run = nil
+			}
+		}
+		for i, word := range words {
+			lower := strings.ToLower(word)
+			if isCapitalized(word) && !stopwords[lower] && (i > 0 || len(run) > 0) {
+				run = append(run, word)
+			} else {
+				flushRun()
+			}
+			if !isContentWord(lower) {
+				continue
+			}
+			keywords[lower]++
+			if i > 0 {
+				if prev := strings.ToLower(words[i-1]); isContentWord(prev) {
+					topics[prev+" "+lower]++
+				}
+			}
+		}
+		flushRun()
+	}
+
+	return Analysis{
+		Keywords: top(keywords, 2),
+		Names:    top(names, 1),
+		Topics:   top(topics, 2),
+	}
+}
+
+// excerpt returns at most limit bytes from the start of text, cut on a rune
+// boundary.
+func excerpt(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit]
+}
+
+// WithLLM asks an LLM for the analysis of the start of the transcript.
+func WithLLM(ctx context.Context, client *llm.Client, text string) (Analysis, error) {
+	text = excerpt(text, llmInputChars)
+	prompt := fmt.Sprintf("Extract from this transcript up to %d keywords, the names of people, organizations, "+
+		"and places mentioned, and up to %d short topics. Reply with JSON only: "+
+		`{"keywords": [], "names": [], "topics": []}`+"\n\n%s", maxPerKind, maxPerKind, text)
+	reply, err := client.Complete(ctx, "You index transcripts for search.", prompt)
+	if err != nil {
+		return Analysis{}, err
+	}
+	raw, err := llm.ExtractJSON(reply, '{', '}')
+	if err != nil {
+		return Analysis{}, err
+	}
+	var analysis Analysis
+	if err := json.Unmarshal([]byte(raw), &analysis); err != nil {
+		return Analysis{}, fmt.Errorf("decode LLM analysis: %w", err)
+	}
+	analysis.Keywords = capList(analysis.Keywords)
+	analysis.Names = capList(analysis.Names)
+	analysis.Topics = capList(analysis.Topics)
+	return analysis, nil
+}
+
+// splitSentences cuts text at sentence punctuation and line breaks.
+func splitSentences(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == '.' || r == '!' || r == '?' || r == '…' || r == '\n'
+	})
+}
+
+// splitWords returns letter/digit runs, keeping inner apostrophes and hyphens.
+func splitWords(sentence string) []string {
+	fields := strings.FieldsFunc(sentence, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-' && r != '’'
+	})
+	words := fields[:0]
+	for _, field := range fields {
+		if field = strings.Trim(field, "'-’"); field != "" {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+// isCapitalized reports an upper-case first letter.
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// isContentWord reports a lower-cased word worth counting as a keyword.
+func isContentWord(lower string) bool {
+	if len([]rune(lower)) < 3 || stopwords[lower] {
+		return false
+	}
+	for _, r := range lower {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// top returns up to maxPerKind keys seen at least minCount times, most frequent first.
+func top(counts map[string]int, minCount int) []string {
+	keys := make([]string, 0, len(counts))
+	for key, count := range counts {
+		if count >= minCount {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return capList(keys)
+}
+
+// capList trims a list to maxPerKind entries.
+func capList(items []string) []string {
+	if len(items) > maxPerKind {
+		return items[:maxPerKind]
+	}
+	return items
+}
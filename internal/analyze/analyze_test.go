@@ -0,0 +1,75 @@
+package analyze
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleTranscript = `Today we talk about climate policy with Anna Petrova from the Green Institute.
+Anna Petrova says climate policy needs carbon pricing. Carbon pricing works in Sweden.
+The Green Institute studied carbon pricing for ten years. And we also talk about the weather.`
+
+// TestLocalExtractsKeywordsNamesAndTopics checks frequency rules on a sample transcript.
+func TestLocalExtractsKeywordsNamesAndTopics(t *testing.T) {
+	got := Local(sampleTranscript)
+
+	contains := func(list []string, want string) bool {
+		for _, item := range list {
+			if item == want {
+				return true
+			}
+		}
+		return false
+	}
+	for _, want := range []string{"carbon", "pricing", "climate"} {
+		if !contains(got.Keywords, want) {
+			t.Fatalf("keywords = %v, missing %q", got.Keywords, want)
+		}
+	}
+	if contains(got.Keywords, "talk") && contains(got.Keywords, "the") {
+		t.Fatalf("keywords contain stopwords: %v", got.Keywords)
+	}
+	for _, want := range []string{"Anna Petrova", "Green Institute", "Sweden"} {
+		if !contains(got.Names, want) {
+			t.Fatalf("names = %v, missing %q", got.Names, want)
+		}
+	}
+	if contains(got.Names, "Today") || contains(got.Names, "Carbon") {
+		t.Fatalf("names include sentence-initial words: %v", got.Names)
+	}
+	if len(got.Topics) == 0 || got.Topics[0] != "carbon pricing" {
+		t.Fatalf("topics = %v, want carbon pricing first", got.Topics)
+	}
+}
+
+// TestTagsMergesWithoutDuplicates dedupes case-insensitively across groups.
+func TestTagsMergesWithoutDuplicates(t *testing.T) {
+	analysis := Analysis{
+		Keywords: []string{"sweden", "carbon"},
+		Names:    []string{"Sweden"},
+		Topics:   []string{"carbon pricing", " "},
+	}
+	if got := strings.Join(analysis.Tags(), ","); got != "carbon pricing,Sweden,carbon" {
+		t.Fatalf("tags = %s", got)
+	}
+}
+
+// TestExcerptKeepsRunesWhole checks the LLM excerpt never splits a character.
+func TestExcerptKeepsRunesWhole(t *testing.T) {
+	tests := []struct {
+		text  string
+		limit int
+		want  string
+	}{
+		{text: "short", limit: 10, want: "short"},
+		{text: "abcdef", limit: 3, want: "abc"},
+		{text: "при", limit: 3, want: "п"},
+		{text: "при", limit: 4, want: "пр"},
+		{text: "日本", limit: 2, want: ""},
+	}
+	for _, tt := range tests {
+		if got := excerpt(tt.text, tt.limit); got != tt.want {
+			t.Fatalf("excerpt(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package analyze
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"media-transcriber/internal/subtitle"
+)
+
+const (
+	// chapterBlockMs is the span of one block of speech compared for topic shifts.
+	chapterBlockMs = 30_000
+	// chapterWindowBlocks is how many blocks on each side of a gap are compared.
+	chapterWindowBlocks = 3
+	// minChapterMs keeps chapters long enough to be worth navigating to.
+	minChapterMs = 120_000
+	maxChapters  = 20
+)
+
+// Chapter is a titled span of the recording.
+type Chapter struct {
+	StartMs int64  `json:"startMs"`
+	EndMs   int64  `json:"endMs"`
+	Title   string `json:"title"`
+}
+
+// Chapters splits timed speech at topic shifts. The speech is cut into 30 s
+// blocks; at each block boundary the vocabulary of the blocks before and after
+// is compared, and the deepest dips in similarity become chapter starts, at
+// least two minutes apart. Each chapter is titled by its main topic or keywords.
+func Chapters(cues []subtitle.Cue) []Chapter {
+	if len(cues) == 0 {
+		return nil
+	}
+	blocks := blockCues(cues)
+	boundaries := topicBoundaries(blocks)
+
+	starts := append([]int{0}, boundaries...)
+	chapters := make([]Chapter, 0, len(starts))
+	for i, first := range starts {
+		last := len(blocks)
+		if i+1 < len(starts) {
+			last = starts[i+1]
+		}
+		var text []string
+		for _, block := range blocks[first:last] {
+			text = append(text, block.text)
+		}
+		chapter := Chapter{StartMs: blocks[first].startMs, EndMs: blocks[last-1].endMs}
+		if i == 0 {
+			chapter.StartMs = 0
+		}
+		chapter.Title = chapterTitle(strings.Join(text, " "), i+1)
+		chapters = append(chapters, chapter)
+	}
+	return chapters
+}
+
+// speechBlock is the text of the cues starting within one block span.
+type speechBlock struct {
+	startMs int64
+	endMs   int64
+	text    string
+	words   map[string]int
+}
+
+// blockCues groups cues into consecutive blocks by start time.
+func blockCues(cues []subtitle.Cue) []speechBlock {
+	var blocks []speechBlock
+	for _, cue := range cues {
+		n := len(blocks)
+		if n == 0 || cue.StartMs-blocks[n-1].startMs >= chapterBlockMs {
+			blocks = append(blocks, speechBlock{startMs: cue.StartMs, words: map[string]int{}})
+			n++
+		}
+		block := &blocks[n-1]
+		block.endMs = max(block.endMs, cue.EndMs)
+		block.text = strings.TrimSpace(block.text + " " + cue.Text)
+		for _, word := range splitWords(cue.Text) {
+			if lower := strings.ToLower(word); isContentWord(lower) {
+				block.words[lower]++
+			}
+		}
+	}
+	return blocks
+}
+
+// topicBoundaries returns block indexes where a new chapter starts, in order.
+func topicBoundaries(blocks []speechBlock) []int {
+	if len(blocks) < 2*chapterWindowBlocks {
+		return nil
+	}
+
+	// similarity[g] compares the windows around the gap before block g.
+	similarity := make([]float64, len(blocks))
+	for g := 1; g < len(blocks); g++ {
+		similarity[g] = cosine(mergeWords(blocks[max(0, g-chapterWindowBlocks):g]), mergeWords(blocks[g:min(len(blocks), g+chapterWindowBlocks)]))
+	}
+
+	// Depth: how far the similarity dips below the nearest peaks on both sides.
+	type gap struct {
+		index int
+		depth float64
+	}
+	var gaps []gap
+	var sum, sumSquares float64
+	for g := 1; g < len(blocks); g++ {
+		left, right := similarity[g], similarity[g]
+		for k := g - 1; k >= 1 && similarity[k] >= left; k-- {
+			left = similarity[k]
+		}
+		for k := g + 1; k < len(blocks) && similarity[k] >= right; k++ {
+			right = similarity[k]
+		}
+		depth := (left - similarity[g]) + (right - similarity[g])
+		gaps = append(gaps, gap{index: g, depth: depth})
+		sum += depth
+		sumSquares += depth * depth
+	}
+	mean := sum / float64(len(gaps))
+	std := math.Sqrt(math.Max(0, sumSquares/float64(len(gaps))-mean*mean))
+	cutoff := mean + std/2
+
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].depth > gaps[j].depth })
+	var chosen []int
+	for _, candidate := range gaps {
+		if candidate.depth <= 0 || candidate.depth < cutoff || len(chosen) >= maxChapters-1 {
+			break
+		}
+		start := blocks[candidate.index].startMs
+		if start < minChapterMs || blocks[len(blocks)-1].endMs-start < minChapterMs {
+			continue
+		}
+		tooClose := false
+		for _, other := range chosen {
+			if abs(blocks[other].startMs-start) < minChapterMs {
+				tooClose = true
+				break
+			}
+		}
+		if !tooClose {
+			chosen = append(chosen, candidate.index)
+		}
+	}
+	sort.Ints(chosen)
+	return chosen
+}
+
+// mergeWords sums the word counts of blocks.
+func mergeWords(blocks []speechBlock) map[string]int {
+	merged := map[string]int{}
+	for _, block := range blocks {
+		for word, count := range block.words {
+			merged[word] += count
+		}
+	}
+	return merged
+}
+
+// cosine is the cosine similarity of two word-count vectors.
+func cosine(a, b map[string]int) float64 {
+	var dot, normA, normB float64
+	for word, count := range a {
+		dot += float64(count * b[word])
+		normA += float64(count * count)
+	}
+	for _, count := range b {
+		normB += float64(count * count)
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// chapterTitle names a chapter after its most repeated phrase, preferring one
+// with the top keyword, or after its keywords.
+func chapterTitle(text string, number int) string {
+	analysis := Local(text)
+	title := ""
+	switch {
+	case len(analysis.Topics) > 0:
+		title = analysis.Topics[0]
+		// Among equally frequent phrases prefer one with the top keyword.
+		for _, topic := range analysis.Topics {
+			if len(analysis.Keywords) > 0 && strings.Contains(" "+topic+" ", " "+analysis.Keywords[0]+" ") {
+				title = topic
+				break
+			}
+		}
+	case len(analysis.Keywords) > 1:
+		title = analysis.Keywords[0] + " and " + analysis.Keywords[1]
+	case len(analysis.Keywords) == 1:
+		title = analysis.Keywords[0]
+	default:
+		return fmt.Sprintf("Chapter %d", number)
+	}
+	runes := []rune(title)
+	return strings.ToUpper(string(runes[0])) + string(runes[1:])
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// FormatYouTubeChapters renders "0:00 Title" lines for a video description.
+func FormatYouTubeChapters(chapters []Chapter) string {
+	hours := len(chapters) > 0 && chapters[len(chapters)-1].StartMs >= 3_600_000
+	var b strings.Builder
+	for _, chapter := range chapters {
+		seconds := chapter.StartMs / 1000
+		if hours {
+			fmt.Fprintf(&b, "%d:%02d:%02d %s\n", seconds/3600, seconds/60%60, seconds%60, chapter.Title)
+		} else {
+			fmt.Fprintf(&b, "%d:%02d %s\n", seconds/60, seconds%60, chapter.Title)
+		}
+	}
+	return b.String()
+}
+
+// FormatFFMetadata renders an ffmpeg FFMETADATA1 file that muxes the chapters
+// into a media file with: ffmpeg -i in -i chapters.txt -map_metadata 1 -codec copy out.
+func FormatFFMetadata(chapters []Chapter) string {
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+	for _, chapter := range chapters {
+		fmt.Fprintf(&b, "\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n", chapter.StartMs, chapter.EndMs, EscapeFFMetadata(chapter.Title))
+	}
+	return b.String()
+}
+
+// EscapeFFMetadata backslash-escapes the characters FFMETADATA treats specially.
+func EscapeFFMetadata(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(`=;#\`, r) || r == '\n' {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// FormatVTTChapters renders a WebVTT chapters track (kind="chapters").
+func FormatVTTChapters(chapters []Chapter) string {
+	cues := make([]subtitle.Cue, 0, len(chapters))
+	for i, chapter := range chapters {
+		cues = append(cues, subtitle.Cue{ID: fmt.Sprintf("chapter-%d", i+1), StartMs: chapter.StartMs, EndMs: chapter.EndMs, Text: chapter.Title})
+	}
+	return subtitle.FormatVTT(cues)
+}
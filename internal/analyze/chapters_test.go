@@ -0,0 +1,70 @@
+package analyze
+
+import (
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/subtitle"
+)
+
+// topicCues produces one cue every 10 s repeating text for the given span.
+func topicCues(startMs, endMs int64, text string) []subtitle.Cue {
+	var cues []subtitle.Cue
+	for at := startMs; at < endMs; at += 10_000 {
+		cues = append(cues, subtitle.Cue{StartMs: at, EndMs: at + 9_000, Text: text})
+	}
+	return cues
+}
+
+// TestChaptersSplitAtTopicShift finds the change between two unrelated topics.
+func TestChaptersSplitAtTopicShift(t *testing.T) {
+	cues := append(
+		topicCues(0, 360_000, "Carbon pricing lowers emissions. Carbon pricing needs a fair tax on emissions."),
+		topicCues(360_000, 720_000, "The football team scored a late goal. Football fans loved the team and the goal.")...,
+	)
+
+	chapters := Chapters(cues)
+	if len(chapters) != 2 {
+		t.Fatalf("chapters = %+v, want 2", chapters)
+	}
+	if chapters[0].StartMs != 0 || chapters[1].StartMs != 360_000 || chapters[1].EndMs != 719_000 {
+		t.Fatalf("chapter times = %+v", chapters)
+	}
+	if chapters[0].Title != "Carbon pricing" || !strings.Contains(strings.ToLower(chapters[1].Title), "football") {
+		t.Fatalf("titles = %q / %q", chapters[0].Title, chapters[1].Title)
+	}
+}
+
+// TestChaptersShortRecordingIsOneChapter keeps short speech in a single chapter.
+func TestChaptersShortRecordingIsOneChapter(t *testing.T) {
+	chapters := Chapters(topicCues(0, 60_000, "hello hello world"))
+	if len(chapters) != 1 || chapters[0].StartMs != 0 {
+		t.Fatalf("chapters = %+v", chapters)
+	}
+	if Chapters(nil) != nil {
+		t.Fatal("expected no chapters without speech")
+	}
+}
+
+// TestChapterFormats checks YouTube, FFMETADATA, and WebVTT renderings.
+func TestChapterFormats(t *testing.T) {
+	chapters := []Chapter{
+		{StartMs: 0, EndMs: 65_000, Title: "Intro"},
+		{StartMs: 65_000, EndMs: 3_700_000, Title: "Q=A; #1"},
+		{StartMs: 3_700_000, EndMs: 3_800_000, Title: "Outro"},
+	}
+
+	if got := FormatYouTubeChapters(chapters); got != "0:00:00 Intro\n0:01:05 Q=A; #1\n1:01:40 Outro\n" {
+		t.Fatalf("youtube =\n%s", got)
+	}
+	if got := FormatYouTubeChapters(chapters[:2]); got != "0:00 Intro\n1:05 Q=A; #1\n" {
+		t.Fatalf("youtube short =\n%s", got)
+	}
+	meta := FormatFFMetadata(chapters)
+	if !strings.HasPrefix(meta, ";FFMETADATA1\n") || !strings.Contains(meta, "START=65000\nEND=3700000\ntitle=Q\\=A\\; \\#1\n") {
+		t.Fatalf("ffmetadata =\n%s", meta)
+	}
+	if vtt := FormatVTTChapters(chapters); !strings.Contains(vtt, "chapter-2\n00:01:05.000 --> 01:01:40.000\nQ=A; #1\n") {
+		t.Fatalf("vtt =\n%s", vtt)
+	}
+}
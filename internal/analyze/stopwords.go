@@ -0,0 +1,37 @@
+package analyze
+
+import "strings"
+
+// stopwords are common English and Russian function words and speech fillers
+// that never make useful tags.
+var stopwords = makeSet(`
+a about above after again against all also am an and any are as at be because been before being
+below between both but by can could did do does doing down during each else even ever every few
+for from further get gets got had has have having he her here hers herself him himself his how i
+if in into is it its itself just know let like me more most much my myself no nor not now of off
+on once only or other our ours ourselves out over own really right same say said she should so
+some such than that the their theirs them themselves then there these they thing things think
+this those through to too under until up us very was we well were what when where which while
+who whom why will with would yeah yes you your yours yourself yourselves okay oh um uh gonna
+going want one two also still
+а без более бы был была были было быть в вам вас весь во вот все всего всех вы где да даже для
+до его ее её если есть еще ещё же за здесь и из или им их к как какой когда кто ли либо мне может
+мы на над надо наш не него нее неё нет ни них но ну о об однако он она они оно от очень по под
+при с со так также такой там те тем то того тоже той только том ты у уже хотя чего чей чем что
+чтобы эта эти это этого этой этом этот я вообще просто значит вот
+`)
+
+// IsStopword reports whether word, in any casing, is a common function word
+// or filler.
+func IsStopword(word string) bool {
+	return stopwords[strings.ToLower(word)]
+}
+
+// makeSet splits whitespace-separated words into a lookup set.
+func makeSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"media-transcriber/internal/domain"
+)
+
+// maxScriptBytes bounds prepared scripts read for alignment.
+const maxScriptBytes = 4 << 20
+
+// StartAlignment transcribes inputPath and times the prepared script at
+// scriptPath against it, exporting .srt and .vtt next to the transcript.
+// Use it to subtitle scripted videos with the exact wording of the script.
+func (a *App) StartAlignment(inputPath, scriptPath string) (domain.Job, error) {
+	script, err := readScript(scriptPath)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("load settings: %w", err)
+	}
+	a.mu.Lock()
+	a.Settings = settings
+	a.mu.Unlock()
+	settings, source, err := a.applySourcePreset(inputPath, settings)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return a.startTranscription(inputPath, settings, jobOptions{scriptText: script, source: source})
+}
+
+// readScript loads a UTF-8 script file, rejecting empty and oversized files.
+func readScript(scriptPath string) (string, error) {
+	scriptPath = strings.TrimSpace(scriptPath)
+	if scriptPath == "" {
+		return "", fmt.Errorf("script path is required")
+	}
+	info, err := os.Stat(scriptPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot access script: %w", err)
+	}
+	if info.Size() > maxScriptBytes {
+		return "", fmt.Errorf("script is larger than %d MiB", maxScriptBytes>>20)
+	}
+	data, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return "", fmt.Errorf("read script: %w", err)
+	}
+	script := strings.TrimPrefix(string(data), "\ufeff")
+	if strings.TrimSpace(script) == "" {
+		return "", fmt.Errorf("script is empty: %s", scriptPath)
+	}
+	return script, nil
+}
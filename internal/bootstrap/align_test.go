@@ -0,0 +1,38 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestReadScript strips a UTF-8 BOM and rejects missing or blank scripts.
+func TestReadScript(t *testing.T) {
+	root := t.TempDir()
+	withBOM := filepath.Join(root, "speech.txt")
+	mustWrite(t, withBOM, "\ufeffGood evening.\n")
+	blank := filepath.Join(root, "blank.txt")
+	mustWrite(t, blank, " \n\t")
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "bom", path: withBOM, want: "Good evening.\n"},
+		{name: "blank", path: blank, wantErr: true},
+		{name: "missing", path: filepath.Join(root, "missing.txt"), wantErr: true},
+		{name: "empty path", path: " ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readScript(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("script = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/subtitle"
+)
+
+// ExportAnkiDeck writes an Anki deck for a finished job: one note per segment
+// pairing its audio clip with its text and, when translationLang names a
+// translation made by TranslateSubtitles, the translated line. The deck goes
+// to a <name>.anki folder next to the transcript as cards.txt, in Anki's text
+// import format, and the clips it plays. It returns the cards.txt path.
+func (a *App) ExportAnkiDeck(jobID, translationLang string) (string, error) {
+	entry, err := a.findHistoryEntry(jobID)
+	if err != nil {
+		return "", err
+	}
+	base, _, segments, err := readJobCues(entry)
+	if err != nil {
+		return "", err
+	}
+	cues, _, err := selectCues(segments, nil)
+	if err != nil {
+		return "", err
+	}
+	var translations map[int64]string
+	if lang := strings.ToLower(strings.TrimSpace(translationLang)); lang != "" {
+		translations, err = readTranslation(base, lang)
+		if err != nil {
+			return "", err
+		}
+	}
+	if _, err := os.Stat(entry.InputPath); err != nil {
+		return "", fmt.Errorf("cannot access input: %w", err)
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return "", fmt.Errorf("load settings: %w", err)
+	}
+
+	dir := base + ".anki"
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create deck folder: %w", err)
+	}
+	deck := sanitizeFileName(filepath.Base(base))
+	notes := make([]ankiNote, 0, len(cues))
+	for i, cue := range cues {
+		// Anki keeps all media in one folder, so clip names carry the deck name.
+		media := fmt.Sprintf("%s-%03d.wav", deck, i+1)
+		if err := cutClip(settings.FFmpegPath, entry.InputPath, filepath.Join(dir, media), cue); err != nil {
+			return "", err
+		}
+		notes = append(notes, ankiNote{media: media, text: cue.Text, translation: translations[cue.StartMs]})
+	}
+
+	cardsPath := filepath.Join(dir, "cards.txt")
+	if err := os.WriteFile(cardsPath, []byte(formatAnkiNotes(deck, notes)), 0o644); err != nil {
+		return "", fmt.Errorf("write %s: %w", filepath.Base(cardsPath), err)
+	}
+	a.publishEvent(jobs.Event{
+		JobID:    jobID,
+		Type:     jobs.EventTypeLog,
+		Message:  fmt.Sprintf("Anki deck with %d cards exported", len(notes)),
+		TextPath: cardsPath,
+	})
+	return cardsPath, nil
+}
+
+// ankiNote is one flashcard: a segment's clip, its text, and its translation.
+type ankiNote struct {
+	media       string
+	text        string
+	translation string
+}
+
+// readTranslation reads the <base>.<lang>.srt (or .vtt) subtitles written by
+// TranslateSubtitles and returns their lines by cue start, which translation
+// keeps unchanged.
+func readTranslation(base, lang string) (map[int64]string, error) {
+	path := base + "." + lang + ".srt"
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		path = base + "." + lang + ".vtt"
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s translation: %w", lang, err)
+	}
+	file, err := subtitle.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	translations := make(map[int64]string, len(file.Cues))
+	for _, cue := range file.Cues {
+		translations[cue.StartMs] = cue.Text
+	}
+	return translations, nil
+}
+
+// formatAnkiNotes renders notes in Anki's text import format with headers
+// naming the deck and the Basic note type. Without a translation the front
+// only plays the clip, a listening card; with one the front also shows the
+// text and the back the translation.
+func formatAnkiNotes(deck string, notes []ankiNote) string {
+	var b strings.Builder
+	b.WriteString("#separator:tab\n#html:true\n#notetype:Basic\n")
+	fmt.Fprintf(&b, "#deck:%s\n#columns:Front\tBack\n", deck)
+	for _, note := range notes {
+		front, back := "[sound:"+note.media+"]", ankiField(note.text)
+		if note.translation != "" {
+			front, back = ankiField(note.text)+"<br>"+front, ankiField(note.translation)
+		}
+		fmt.Fprintf(&b, "%s\t%s\n", front, back)
+	}
+	return b.String()
+}
+
+// ankiField escapes text for an HTML field of a tab-separated note, turning
+// cue line breaks into <br>.
+func ankiField(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = html.EscapeString(strings.ReplaceAll(strings.TrimSpace(line), "\t", " "))
+	}
+	return strings.Join(lines, "<br>")
+}
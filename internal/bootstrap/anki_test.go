@@ -0,0 +1,62 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+)
+
+// TestFormatAnkiNotes writes listening cards without a translation and
+// text-to-translation cards with one, escaping HTML.
+func TestFormatAnkiNotes(t *testing.T) {
+	got := formatAnkiNotes("Q&A lesson", []ankiNote{
+		{media: "Q&A lesson-001.wav", text: "¿Dónde está\nla <b>estación</b>?", translation: "Where is\nthe station?"},
+		{media: "Q&A lesson-002.wav", text: "Gracias.\t"},
+	})
+	want := "#separator:tab\n#html:true\n#notetype:Basic\n#deck:Q&A lesson\n#columns:Front\tBack\n" +
+		"¿Dónde está<br>la &lt;b&gt;estación&lt;/b&gt;?<br>[sound:Q&A lesson-001.wav]\tWhere is<br>the station?\n" +
+		"[sound:Q&A lesson-002.wav]\tGracias.\n"
+	if got != want {
+		t.Fatalf("notes =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestReadTranslationMatchesCueStarts keys translated lines by cue start.
+func TestReadTranslationMatchesCueStarts(t *testing.T) {
+	root := t.TempDir()
+	base := filepath.Join(root, "lesson")
+	mustWrite(t, filepath.Join(root, "lesson.en.srt"), "1\n00:00:00,500 --> 00:00:02,000\nThank you.\n\n2\n00:00:03,000 --> 00:00:04,000\nBye.\n")
+
+	translations, err := readTranslation(base, "en")
+	if err != nil {
+		t.Fatalf("read translation: %v", err)
+	}
+	if translations[500] != "Thank you." || translations[3000] != "Bye." {
+		t.Fatalf("translations = %v", translations)
+	}
+	if _, err := readTranslation(base, "de"); err == nil {
+		t.Fatal("missing translation should fail")
+	}
+}
+
+// TestExportAnkiDeckRequiresTranslation fails before cutting clips when the
+// requested translation was never made.
+func TestExportAnkiDeckRequiresTranslation(t *testing.T) {
+	root := t.TempDir()
+	input := filepath.Join(root, "lesson.mp3")
+	srt := filepath.Join(root, "lesson.srt")
+	mustWrite(t, input, "audio")
+	mustWrite(t, srt, "1\n00:00:00,500 --> 00:00:02,000\nGracias.\n")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{{ID: "job-1", InputPath: input, SubtitlePaths: []string{srt}}}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	app := &App{Store: &fakeStore{}, History: history}
+
+	if _, err := app.ExportAnkiDeck("job-1", "en"); err == nil || !strings.Contains(err.Error(), "en translation") {
+		t.Fatalf("err = %v, want missing translation", err)
+	}
+}
@@ -0,0 +1,394 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// sseKeepAlive is how often an idle event stream sends a comment line so
+// proxies do not close the connection.
+const sseKeepAlive = 15 * time.Second
+
+// apiRoute is one server-mode endpoint. The same table drives routing and
+// the OpenAPI document, so the published spec cannot drift from the handlers.
+type apiRoute struct {
+	method, path string
+	// scope is the token scope required; empty leaves the endpoint open.
+	scope   domain.TokenScope
+	summary string
+	// query lists the documented query parameters.
+	query []apiParam
+	// request and response are zero values of the JSON bodies, nil for none.
+	request, response any
+	// status is the success status code.
+	status int
+	// contentType overrides application/json for non-JSON responses.
+	contentType string
+	handler     http.HandlerFunc
+}
+
+// apiParam documents one query parameter.
+type apiParam struct {
+	name, description string
+}
+
+// apiRoutes lists every server-mode endpoint. Reads need the read scope,
+// changes to jobs the submit scope, and token management the admin scope.
+func (a *App) apiRoutes() []apiRoute {
+	return []apiRoute{
+		{method: "GET", path: "/healthz", summary: "Liveness probe", response: map[string]string{}, status: http.StatusOK, handler: a.handleHealthz},
+		{method: "GET", path: "/readyz", summary: "Readiness probe backed by local diagnostics", response: readiness{}, status: http.StatusOK, handler: a.handleReadyz},
+		{method: "GET", path: "/metrics", scope: domain.TokenScopeRead, summary: "Prometheus metrics", status: http.StatusOK, contentType: "text/plain", handler: a.handleMetrics},
+		{method: "GET", path: "/api/openapi.json", summary: "This OpenAPI document", response: map[string]any{}, status: http.StatusOK, handler: a.handleOpenAPI},
+
+		{method: "GET", path: "/api/jobs/current", scope: domain.TokenScopeRead, summary: "Current or last job", response: domain.Job{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusOK, a.CurrentJob())
+			}},
+		{method: "POST", path: "/api/jobs", scope: domain.TokenScopeSubmit, summary: "Queue a file that exists on the server", request: submitJobRequest{}, response: domain.QueuedJob{}, status: http.StatusAccepted, handler: a.handleSubmitJob},
+		{method: "POST", path: "/api/uploads", scope: domain.TokenScopeSubmit, summary: "Upload a media file as the request body and queue it",
+			query:    []apiParam{{"name", "media file name"}, {"priority", "true to run before normal queued files"}},
+			response: domain.QueuedJob{}, status: http.StatusAccepted, handler: a.handleUpload},
+		{method: "POST", path: "/api/jobs/current/cancel", scope: domain.TokenScopeSubmit, summary: "Cancel the running job", status: http.StatusNoContent,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				if err := a.CancelTranscription(); err != nil {
+					writeError(w, apiErrorStatus(err), err)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}},
+		{method: "GET", path: "/api/jobs/{id}/artifacts", scope: domain.TokenScopeRead, summary: "Output file names of a finished job", response: []string{}, status: http.StatusOK, handler: a.handleArtifacts},
+		{method: "GET", path: "/api/jobs/{id}/artifacts/{name}", scope: domain.TokenScopeRead, summary: "Download one output file", status: http.StatusOK, contentType: "application/octet-stream", handler: a.handleArtifact},
+		{method: "GET", path: "/api/queue", scope: domain.TokenScopeRead, summary: "Queued files in run order", response: []domain.QueuedJob{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusOK, a.GetQueue())
+			}},
+		{method: "DELETE", path: "/api/queue/{id}", scope: domain.TokenScopeSubmit, summary: "Remove a queued file", status: http.StatusNoContent,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if err := a.RemoveFromQueue(r.PathValue("id")); err != nil {
+					writeError(w, apiErrorStatus(err), err)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}},
+		{method: "POST", path: "/api/history/{id}/resubmit", scope: domain.TokenScopeSubmit, summary: "Queue a finished job's input again with changed parameters",
+			request: domain.JobOverrides{}, response: domain.QueuedJob{}, status: http.StatusAccepted, handler: a.handleResubmit},
+		{method: "GET", path: "/api/history", scope: domain.TokenScopeRead, summary: "Finished jobs, newest first",
+			query:    []apiParam{{"tag", "exact tag"}, {"q", "substring of the file name or a tag"}},
+			response: []domain.HistoryEntry{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				entries, err := a.ListHistory(domain.HistoryFilter{Tag: query.Get("tag"), Query: query.Get("q")})
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, err)
+					return
+				}
+				writeJSON(w, http.StatusOK, entries)
+			}},
+		{method: "GET", path: "/api/transcripts", scope: domain.TokenScopeRead, summary: "Transcript library: completed jobs with transcript previews",
+			query: []apiParam{
+				{"tag", "exact tag"}, {"q", "substring of the file name or a tag"},
+				{"sort", "newest (default), oldest, name, or duration"},
+				{"page", "page number from 1"}, {"size", "items per page, at most 200"},
+			},
+			response: domain.TranscriptPage{}, status: http.StatusOK, handler: a.handleTranscripts},
+		{method: "GET", path: "/api/stats", scope: domain.TokenScopeRead, summary: "Usage statistics",
+			query:    []apiParam{{"period", "day, week, month, or all"}},
+			response: domain.UsageStats{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				stats, err := a.GetStats(r.URL.Query().Get("period"))
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err)
+					return
+				}
+				writeJSON(w, http.StatusOK, stats)
+			}},
+		{method: "GET", path: "/api/storage", scope: domain.TokenScopeRead, summary: "Disk space used by history, cache, transcripts, and uploads", response: domain.StorageUsage{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				usage, err := a.GetStorageUsage()
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, err)
+					return
+				}
+				writeJSON(w, http.StatusOK, usage)
+			}},
+		{method: "POST", path: "/api/storage/cleanup", scope: domain.TokenScopeAdmin, summary: "Apply the retention policy now", response: domain.CleanupReport{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				report, err := a.ApplyRetention()
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, err)
+					return
+				}
+				writeJSON(w, http.StatusOK, report)
+			}},
+		{method: "GET", path: "/api/telemetry/preview", scope: domain.TokenScopeRead, summary: "Anonymous usage report that telemetry would send next", response: domain.TelemetryReport{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				report, err := a.PreviewTelemetry()
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, err)
+					return
+				}
+				writeJSON(w, http.StatusOK, report)
+			}},
+		{method: "GET", path: "/api/diagnostics", scope: domain.TokenScopeRead, summary: "Latest diagnostics report", response: domain.DiagnosticReport{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusOK, a.Diagnostics)
+			}},
+		{method: "GET", path: "/api/events", scope: domain.TokenScopeRead, summary: "Buffered events after a sequence number",
+			query:    eventParams,
+			response: []jobs.Event{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				since, filter, err := eventQuery(r, "")
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err)
+					return
+				}
+				events := a.events.Query(since, filter)
+				if events == nil {
+					events = []jobs.Event{}
+				}
+				writeJSON(w, http.StatusOK, events)
+			}},
+		{method: "GET", path: "/api/events/stream", scope: domain.TokenScopeRead, summary: "Server-sent events; reconnects resume from Last-Event-ID",
+			query: eventParams, status: http.StatusOK, contentType: "text/event-stream", handler: a.handleEventStream},
+		{method: "GET", path: "/api/tokens", scope: domain.TokenScopeAdmin, summary: "API tokens without secrets", response: []domain.APIToken{}, status: http.StatusOK,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				tokens, err := a.ListAPITokens()
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, err)
+					return
+				}
+				if tokens == nil {
+					tokens = []domain.APIToken{}
+				}
+				writeJSON(w, http.StatusOK, tokens)
+			}},
+		{method: "POST", path: "/api/tokens", scope: domain.TokenScopeAdmin, summary: "Create a token; the secret is returned once", request: createTokenRequest{}, response: domain.CreatedAPIToken{}, status: http.StatusCreated,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				var body createTokenRequest
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					writeError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+					return
+				}
+				created, err := a.CreateAPIToken(body.Name, body.Scopes)
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err)
+					return
+				}
+				writeJSON(w, http.StatusCreated, created)
+			}},
+		{method: "DELETE", path: "/api/tokens/{id}", scope: domain.TokenScopeAdmin, summary: "Revoke a token by ID or name", status: http.StatusNoContent,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if err := a.RevokeAPIToken(r.PathValue("id")); err != nil {
+					writeError(w, http.StatusNotFound, err)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}},
+	}
+}
+
+// handleTranscripts serves one page of the transcript library.
+func (a *App) handleTranscripts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	pageNumber, err := queryInt(query.Get("page"), "page")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	size, err := queryInt(query.Get("size"), "size")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	filter := domain.HistoryFilter{Tag: query.Get("tag"), Query: query.Get("q")}
+	page, err := a.ListTranscripts(filter, domain.TranscriptSort(query.Get("sort")), domain.PageRequest{Page: pageNumber, Size: size})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// queryInt parses an optional integer query parameter; empty is zero.
+func queryInt(raw, name string) (int, error) {
+	if strings.TrimSpace(raw) == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	return value, nil
+}
+
+// eventParams documents the query parameters of the event endpoints.
+var eventParams = []apiParam{
+	{"since", "return events with a greater sequence number"},
+	{"jobId", "only events of this job"},
+	{"type", "only events of this type; repeatable"},
+}
+
+// createTokenRequest is the POST /api/tokens body.
+type createTokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// submitJobRequest is the POST /api/jobs body; InputPath is on the server.
+type submitJobRequest struct {
+	InputPath string `json:"inputPath"`
+	Priority  bool   `json:"priority"`
+}
+
+// handleSubmitJob queues a file that already exists on the server.
+func (a *App) handleSubmitJob(w http.ResponseWriter, r *http.Request) {
+	if a.draining.Load() {
+		writeError(w, http.StatusServiceUnavailable, errors.New("server is shutting down"))
+		return
+	}
+	var body submitJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+		return
+	}
+	queued, err := a.EnqueueFile(body.InputPath, body.Priority)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, queued)
+}
+
+// handleResubmit queues the input of the history entry {id} again with the
+// overrides in the body.
+func (a *App) handleResubmit(w http.ResponseWriter, r *http.Request) {
+	if a.draining.Load() {
+		writeError(w, http.StatusServiceUnavailable, errors.New("server is shutting down"))
+		return
+	}
+	var overrides domain.JobOverrides
+	if err := json.NewDecoder(r.Body).Decode(&overrides); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decode request: %w", err))
+		return
+	}
+	queued, err := a.ResubmitJob(r.PathValue("id"), overrides)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, queued)
+}
+
+// handleEventStream replays events after ?since= (or the Last-Event-ID
+// header on reconnect) and then streams new ones as server-sent events.
+// ?jobId= and repeated ?type= narrow the stream like JobEvents filters.
+func (a *App) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	since, filter, err := eventQuery(r, r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	// Subscribe before replaying so no event falls between the two.
+	live := a.events.Subscribe(r.Context())
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	last := since
+	send := func(event jobs.Event) bool {
+		if event.Seq <= last {
+			return true
+		}
+		last = event.Seq
+		data, err := json.Marshal(event)
+		if err != nil {
+			return true
+		}
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+		return err == nil
+	}
+	for _, event := range a.events.Query(since, filter) {
+		if !send(event) {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-live:
+			if !ok {
+				// The subscriber fell too far behind; the client reconnects with Last-Event-ID.
+				return
+			}
+			if filter.Matches(event) && !send(event) {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// eventQuery reads the since sequence and filter of an events request.
+// lastEventID, when set, wins over ?since=: a reconnecting EventSource sends
+// its original URL again, and only the header says what it already has.
+func eventQuery(r *http.Request, lastEventID string) (int64, jobs.EventFilter, error) {
+	query := r.URL.Query()
+	raw := strings.TrimSpace(lastEventID)
+	if raw == "" {
+		raw = query.Get("since")
+	}
+	var since int64
+	if raw != "" {
+		parsed, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return 0, jobs.EventFilter{}, fmt.Errorf("invalid since %q", raw)
+		}
+		since = parsed
+	}
+	filter := jobs.EventFilter{JobID: query.Get("jobId")}
+	for _, eventType := range query["type"] {
+		filter.Types = append(filter.Types, jobs.EventType(eventType))
+	}
+	return since, filter, nil
+}
+
+// apiErrorStatus maps App errors to HTTP status codes.
+func apiErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, jobs.ErrQueuedJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, jobs.ErrNoRunningJob), errors.Is(err, jobs.ErrJobAlreadyRunning):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError sends err as a JSON {"error": ...} body.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
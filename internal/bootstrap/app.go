@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	goruntime "runtime"
+	"runtime/debug"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/wailsapp/wails/v2"
@@ -22,11 +25,28 @@ import (
 	"media-transcriber/internal/diagnostics"
 	"media-transcriber/internal/domain"
 	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/metrics"
+	"media-transcriber/internal/notify"
+	"media-transcriber/internal/sysinfo"
 	"media-transcriber/internal/transcribe"
 
 	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+const (
+	// smokeTestTimeout bounds the end-to-end toolchain check.
+	smokeTestTimeout = 5 * time.Minute
+	// orphanWorkspaceAge is how old a leftover workspace must be before the startup
+	// sweep removes it, so a second running instance keeps its live workspace.
+	orphanWorkspaceAge = 24 * time.Hour
+)
+
+// stageProgressRanges maps each pipeline stage's own fraction onto overall job progress.
+var stageProgressRanges = map[string][2]float64{
+	"preprocessing": {0, 0.1},
+	"transcribing":  {0.1, 0.95},
+}
+
 var mediaDialogFilter = []wailsruntime.FileFilter{
 	{
 		DisplayName: "Media files",
@@ -53,17 +73,62 @@ var modelDialogFilter = []wailsruntime.FileFilter{
 type App struct {
 	Settings    domain.Settings
 	Store       config.Store
+	Secrets     config.SecretStore
+	ModelStore  config.ModelStore
+	Benchmarks  config.BenchmarkStore
+	Speeds      config.SpeedStore
+	JobState    config.JobStateStore
+	History     config.HistoryStore
+	Profiles    config.ProfileStore
+	Sources     config.SourcePresetStore
+	Tokens      config.TokenStore
+	Telemetry   config.TelemetryStore
 	Jobs        *jobs.Manager
+	Queue       *jobs.Queue
 	Pipeline    pipelineRunner
 	Diagnostics domain.DiagnosticReport
 	assets      fs.FS
 	checker     *diagnostics.Checker
+	eventLog    *jobs.EventLog
+	// metrics aggregates published events for the server-mode /metrics endpoint.
+	metrics *metrics.Collector
+	// uploadDir holds media uploaded by remote clients in server mode.
+	uploadDir string
+	// cacheDir holds the preprocessed audio cache; empty disables caching.
+	cacheDir string
+	// updateDir holds downloaded app installers, one directory per release.
+	updateDir string
+	// crashDir holds crash reports of recovered panics.
+	crashDir string
+	// pluginDir holds the post-processing plugins run after completed jobs.
+	pluginDir string
+	// whisperServer keeps the model loaded between jobs for the server engine.
+	whisperServer *transcribe.WhisperServer
+
+	// queueMu serializes starting queued jobs so two finishers cannot pop at once.
+	queueMu sync.Mutex
+	// batchMu guards batches, the folder batches waiting to merge their transcripts.
+	batchMu sync.Mutex
+	batches []*mergeBatch
+	// historyMu serializes read-modify-write cycles of the history store.
+	historyMu sync.Mutex
+	// tokensMu serializes read-modify-write cycles of the token store.
+	tokensMu sync.Mutex
+	// draining is set once server mode received SIGTERM; new submissions are refused.
+	draining atomic.Bool
+	// followUps counts job runners and the deliveries of finished jobs they
+	// hand off to the background.
+	followUps sync.WaitGroup
 
 	mu          sync.Mutex
 	activeJobID string
-	cancel      context.CancelFunc
-	events      *jobs.EventBus
-	runtimeCtx  context.Context
+	// activeRecord mirrors the persisted JobState entry of the running job.
+	activeRecord domain.JobRecord
+	cancel       context.CancelFunc
+	// remoteCancels cancels jobs dispatched to remote workers, by dispatch ID.
+	remoteCancels map[string]context.CancelFunc
+	events        *jobs.EventBus
+	runtimeCtx    context.Context
 }
 
 // pipelineRunner isolates the transcription pipeline behind an interface.
@@ -78,15 +143,28 @@ func New() (*App, error) {
 
 // NewWithAssets builds the application and optionally configures embedded frontend assets.
 func NewWithAssets(assets fs.FS) (*App, error) {
+	overrides, err := config.LoadOverrides(os.Args[1:], os.Getenv)
+	if err != nil {
+		return nil, fmt.Errorf("parse command line: %w", err)
+	}
+	return newApp(assets, overrides)
+}
+
+// newApp wires stores, diagnostics, and the pipeline with setting overrides
+// applied on top of the persisted settings.
+func newApp(assets fs.FS, overrides config.Overrides) (*App, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("resolve user home: %w", err)
 	}
+	// A failed migration keeps the legacy layout in place; report it once the UI is up.
+	_, migrateErr := config.MigrateLegacyLayout(homeDir)
+	paths := config.ResolvePaths(homeDir)
 	if err := ensureLocalBinOnPATH(homeDir); err != nil {
 		return nil, fmt.Errorf("prepare local tool path: %w", err)
 	}
 
-	store := config.NewJSONStore(filepath.Join(homeDir, ".media-transcriber", "settings.json"))
+	store := config.NewOverrideStore(config.NewJSONStore(filepath.Join(paths.Config, "settings.json")), overrides)
 	settings, err := store.Load()
 	if err != nil {
 		return nil, fmt.Errorf("load settings: %w", err)
@@ -94,21 +172,60 @@ func NewWithAssets(assets fs.FS) (*App, error) {
 
 	checker := diagnostics.NewChecker()
 	report := checker.Run(settings)
+	applyDownloadLimit(settings)
+
+	app := &App{
+		Settings:      settings,
+		Store:         store,
+		Secrets:       config.NewOSSecretStore(filepath.Join(paths.Config, "secrets.json")),
+		ModelStore:    config.NewJSONModelStore(filepath.Join(paths.Config, "custom-models.json")),
+		Benchmarks:    config.NewJSONBenchmarkStore(filepath.Join(paths.Config, "benchmarks.json")),
+		Speeds:        config.NewJSONSpeedStore(filepath.Join(paths.Config, "model-speeds.json")),
+		JobState:      config.NewJSONJobStateStore(filepath.Join(paths.Config, "running-jobs.json")),
+		History:       config.NewJSONHistoryStore(filepath.Join(paths.Data, config.HistoryFile)),
+		Profiles:      config.NewJSONProfileStore(filepath.Join(paths.Config, "profiles.json")),
+		Sources:       config.NewJSONSourcePresetStore(filepath.Join(paths.Config, "source-presets.json")),
+		Tokens:        config.NewJSONTokenStore(filepath.Join(paths.Config, "api-tokens.json")),
+		Telemetry:     config.NewJSONTelemetryStore(filepath.Join(paths.Config, "telemetry.json")),
+		Jobs:          jobs.NewManager(),
+		Queue:         jobs.NewQueue(),
+		Pipeline:      transcribe.NewPipeline(),
+		Diagnostics:   report,
+		assets:        assets,
+		checker:       checker,
+		events:        jobs.NewEventBus(1000),
+		eventLog:      jobs.NewEventLog(filepath.Join(paths.Data, "history")),
+		uploadDir:     filepath.Join(paths.Data, "uploads"),
+		cacheDir:      filepath.Join(paths.Cache, "preprocess"),
+		updateDir:     filepath.Join(paths.Cache, "updates"),
+		crashDir:      filepath.Join(paths.Data, "crashes"),
+		pluginDir:     filepath.Join(paths.Data, "plugins"),
+		whisperServer: transcribe.NewWhisperServer(transcribe.DefaultServerIdleTimeout),
+	}
+	app.metrics = metrics.NewCollector(metrics.Gauges{
+		QueueDepth: func() int { return len(app.Queue.List()) },
+		JobRunning: app.Jobs.IsRunning,
+	})
+	downloadManager.OnUpdate(app.publishDownloadEvent)
+	if migrateErr != nil {
+		app.publishEvent(jobs.Event{
+			Type:    jobs.EventTypeError,
+			Message: fmt.Sprintf("move data to XDG directories: %v", migrateErr),
+		})
+	}
 
-	return &App{
-		Settings:    settings,
-		Store:       store,
-		Jobs:        jobs.NewManager(),
-		Pipeline:    transcribe.NewPipeline(),
-		Diagnostics: report,
-		assets:      assets,
-		checker:     checker,
-		events:      jobs.NewEventBus(1000),
-	}, nil
+	return app, nil
 }
 
-// Run starts the Wails desktop application and binds backend methods.
-func (a *App) Run() error {
+// Run starts the Wails desktop application and binds backend methods. A
+// panic on the UI thread is saved as a crash report and returned as an error.
+func (a *App) Run() (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			path := a.reportCrash("app", "", value, debug.Stack())
+			err = fmt.Errorf("internal error: %v (crash report: %s)", value, path)
+		}
+	}()
 	assetOptions := &assetserver.Options{}
 	if a.assets != nil {
 		assetOptions.Assets = a.assets
@@ -123,24 +240,70 @@ func (a *App) Run() error {
 		AssetServer: assetOptions,
 		OnStartup:   a.Startup,
 		OnShutdown: func(ctx context.Context) {
+			a.stopWhisperServer()
 			a.mu.Lock()
 			defer a.mu.Unlock()
 			a.runtimeCtx = nil
 		},
-		Bind: []interface{}{a},
+		Menu:           a.applicationMenu(),
+		Bind:           []interface{}{a},
+		ErrorFormatter: a.localizeError,
 	})
 }
 
-// Startup stores Wails runtime context for push events.
+// Startup stores Wails runtime context for push events and, when enabled,
+// checks for a newer app release.
 func (a *App) Startup(ctx context.Context) {
 	a.mu.Lock()
-	defer a.mu.Unlock()
 	a.runtimeCtx = ctx
+	a.mu.Unlock()
+	a.startBackgroundTasks()
+	go a.guard("update check", a.checkUpdatesOnStartup)
+}
+
+// startBackgroundTasks recovers jobs interrupted by an unclean shutdown, sweeps
+// stale temp workspaces, and schedules the retention policy and telemetry, on
+// desktop startup and in server mode.
+func (a *App) startBackgroundTasks() {
+	a.mu.Lock()
+	tempDir := a.Settings.TempDir
+	keepIntermediates := a.Settings.KeepIntermediates
+	a.mu.Unlock()
+
+	a.recoverInterruptedJobs(keepIntermediates)
+
+	go func() {
+		_, _ = transcribe.SweepOrphanedWorkspaces(tempDir, orphanWorkspaceAge, time.Now())
+	}()
+	go a.retentionLoop()
+	go a.telemetryLoop()
+}
+
+// CleanTempWorkspaces removes leftover media-transcriber-* workspaces in the configured
+// temp directory, including retained intermediates, and returns how many were removed.
+// It refuses while a job runs so the active workspace is never deleted.
+func (a *App) CleanTempWorkspaces() (int, error) {
+	a.mu.Lock()
+	running := a.activeJobID != ""
+	a.mu.Unlock()
+	if running {
+		return 0, jobs.ErrJobAlreadyRunning
+	}
+
+	settings, err := a.Store.Load()
+	if err != nil {
+		return 0, fmt.Errorf("load settings: %w", err)
+	}
+	removed, err := transcribe.SweepOrphanedWorkspaces(settings.TempDir, 0, time.Now())
+	if err != nil {
+		return len(removed), fmt.Errorf("clean temp workspaces: %w", err)
+	}
+	return len(removed), nil
 }
 
-// GetDiagnostics returns the latest cached diagnostics report.
+// GetDiagnostics returns the latest cached diagnostics report in the settings locale.
 func (a *App) GetDiagnostics() domain.DiagnosticReport {
-	return a.Diagnostics
+	return localizeReport(a.Diagnostics, a.locale())
 }
 
 // GetSettings loads and returns the latest persisted settings.
@@ -160,16 +323,28 @@ func (a *App) GetSettings() (domain.Settings, error) {
 // SaveSettings normalizes and persists settings, then refreshes diagnostics.
 func (a *App) SaveSettings(settings domain.Settings) (domain.Settings, error) {
 	normalized := normalizeSettings(settings)
+	if _, _, err := notify.ParseQuietHours(normalized.NotifyQuietHours); err != nil {
+		return domain.Settings{}, fmt.Errorf("invalid settings: %w", err)
+	}
+	if err := validateNameTemplate(normalized.OutputNameTemplate); err != nil {
+		return domain.Settings{}, fmt.Errorf("invalid settings: %w", err)
+	}
 	if err := a.Store.Save(normalized); err != nil {
 		return domain.Settings{}, fmt.Errorf("save settings: %w", err)
 	}
 
+	applyDownloadLimit(normalized)
+	if normalized.WhisperEngine != domain.WhisperEngineServer {
+		a.stopWhisperServer()
+	}
+
 	a.mu.Lock()
 	a.Settings = normalized
 	if a.checker != nil {
 		a.Diagnostics = a.checker.Run(normalized)
 	}
 	a.mu.Unlock()
+	a.refreshMenu()
 
 	return normalized, nil
 }
@@ -244,7 +419,8 @@ func (a *App) PickOutputDirectory() (string, error) {
 	return strings.TrimSpace(path), nil
 }
 
-// OpenOutputFolder opens the given path (or configured output dir) in file manager.
+// OpenOutputFolder opens the given path (or configured output dir) in file
+// manager. A file is revealed selected in its folder where the platform allows.
 func (a *App) OpenOutputFolder(path string) error {
 	target := strings.TrimSpace(path)
 	if target == "" {
@@ -261,12 +437,34 @@ func (a *App) OpenOutputFolder(path string) error {
 		return fmt.Errorf("resolve output path: %w", err)
 	}
 
-	openPath := target
-	if !info.IsDir() {
-		openPath = filepath.Dir(target)
+	if info.IsDir() {
+		return openInFileManager(target)
+	}
+	if abs, err := filepath.Abs(target); err == nil {
+		target = abs
 	}
+	if err := revealInFileManager(target); err != nil {
+		return openInFileManager(filepath.Dir(target))
+	}
+	return nil
+}
 
-	return openInFileManager(openPath)
+// OpenTranscript opens an output file of a finished job in the OS default
+// application: the transcript when format is empty, otherwise the first output
+// with that extension ("srt", "vtt", "md"...).
+func (a *App) OpenTranscript(jobID, format string) error {
+	entry, err := a.findHistoryEntry(jobID)
+	if err != nil {
+		return err
+	}
+	path, err := jobOutputPath(entry, format)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("resolve transcript: %w", err)
+	}
+	return openWithDefaultApp(path)
 }
 
 // RefreshDiagnostics reloads settings and reruns dependency checks.
@@ -276,36 +474,156 @@ func (a *App) RefreshDiagnostics() (domain.DiagnosticReport, error) {
 		return domain.DiagnosticReport{}, fmt.Errorf("load settings: %w", err)
 	}
 
+	report := a.checker.Run(settings)
+	a.mu.Lock()
 	a.Settings = settings
-	a.Diagnostics = a.checker.Run(settings)
-	return a.Diagnostics, nil
+	a.Diagnostics = report
+	a.mu.Unlock()
+	return localizeReport(report, settings.Locale), nil
+}
+
+// RunSmokeTest transcribes a generated test tone and merges the outcome into diagnostics.
+func (a *App) RunSmokeTest() (domain.DiagnosticReport, error) {
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.DiagnosticReport{}, fmt.Errorf("load settings: %w", err)
+	}
+	settings = normalizeSettings(settings)
+
+	ctx, cancel := context.WithTimeout(context.Background(), smokeTestTimeout)
+	defer cancel()
+	item := diagnostics.NewSmokeTester(a.Pipeline).Run(ctx, settings)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Diagnostics = mergeDiagnosticItem(a.Diagnostics, item)
+	return localizeReport(a.Diagnostics, settings.Locale), nil
 }
 
-// StartTranscription creates a job and runs it asynchronously.
+// StartTranscription creates a job and runs it asynchronously, with the
+// output directory and profile of the source preset matching inputPath.
 func (a *App) StartTranscription(inputPath string) (domain.Job, error) {
+	return a.startStoredSettings(inputPath, "")
+}
+
+// startStoredSettings is StartTranscription writing the outputs to subdir of
+// the output directory, as for files of a folder scan.
+func (a *App) startStoredSettings(inputPath, subdir string) (domain.Job, error) {
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("load settings: %w", err)
+	}
+	a.mu.Lock()
+	a.Settings = settings
+	a.mu.Unlock()
+	settings, source, err := a.applySourcePreset(inputPath, settings)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	settings.OutputDir = outputSubdir(strings.TrimSpace(settings.OutputDir), subdir)
+	return a.startTranscription(inputPath, settings, jobOptions{source: source})
+}
+
+// StartTranscriptionMode is StartTranscription running only part of the
+// pipeline: "preprocess" exports the 16 kHz WAV, "transcribe-wav" transcribes
+// such a WAV without ffmpeg, and "reformat" regenerates subtitles from the
+// <name>.segments.json given as inputPath.
+func (a *App) StartTranscriptionMode(inputPath string, mode domain.PipelineMode) (domain.Job, error) {
+	switch mode {
+	case domain.PipelineModeFull, domain.PipelineModePreprocess, domain.PipelineModeTranscribeWAV, domain.PipelineModeReformat:
+	default:
+		return domain.Job{}, fmt.Errorf("unknown pipeline mode %q", mode)
+	}
 	settings, err := a.Store.Load()
 	if err != nil {
 		return domain.Job{}, fmt.Errorf("load settings: %w", err)
 	}
+	a.mu.Lock()
+	a.Settings = settings
+	a.mu.Unlock()
+	settings, source, err := a.applySourcePreset(inputPath, settings)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return a.startTranscription(inputPath, settings, jobOptions{source: source, mode: mode})
+}
 
+// jobOptions carries per-job choices that are not settings.
+type jobOptions struct {
+	// scriptText turns the job into forced alignment of a prepared script.
+	scriptText string
+	// source is the prefix of the source preset applied to the job, if any.
+	source string
+	// resubmitOf is the history ID of the job this one re-runs, if any.
+	resubmitOf string
+	// mode limits the job to part of the pipeline; empty runs all of it.
+	mode domain.PipelineMode
+	// inputHash is the content hash of the input, recorded in history.
+	inputHash string
+	// gpuDevice is the whisper.cpp device resolved from the GPUDevice setting.
+	gpuDevice string
+}
+
+// runsWhisper reports whether jobs in mode load a model and transcribe.
+func runsWhisper(mode domain.PipelineMode) bool {
+	return mode != domain.PipelineModePreprocess && mode != domain.PipelineModeReformat
+}
+
+// startTranscription creates a job with the given settings and runs it asynchronously.
+func (a *App) startTranscription(inputPath string, settings domain.Settings, opts jobOptions) (domain.Job, error) {
+	var memoryErr error
+	if runsWhisper(opts.mode) {
+		device, err := whisperDevice(settings.GPUDevice, sysinfo.DetectGPUs)
+		if err != nil {
+			return domain.Job{}, err
+		}
+		opts.gpuDevice = device
+		memoryErr = checkMemory(settings)
+	}
+	if memoryErr != nil && settings.MemoryGuard != domain.MemoryGuardWarn {
+		return domain.Job{}, memoryErr
+	}
 	jobID := fmt.Sprintf("job-%d", time.Now().UnixNano())
-	if err := a.Jobs.Start(jobID); err != nil {
+	if err := a.Jobs.Start(jobID, inputPath); err != nil {
 		return domain.Job{}, err
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := jobContext(settings)
 	a.mu.Lock()
 	a.activeJobID = jobID
 	a.cancel = cancel
 	a.mu.Unlock()
 
-	a.Settings = settings
+	a.recordJob(func(record *domain.JobRecord) {
+		*record = domain.JobRecord{
+			ID:        jobID,
+			InputPath: inputPath,
+			Status:    domain.JobStatusPreprocessing,
+			StartedAt: time.Now().UTC(),
+		}
+	})
 	a.publishStatus(jobID, domain.JobStatusPreprocessing, "Job started")
+	if memoryErr != nil {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: "Warning: " + memoryErr.Error()})
+	}
+	if opts.source != "" {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: "Using source preset " + opts.source})
+	}
 
-	go a.runTranscriptionJob(ctx, jobID, inputPath, settings)
+	a.followUps.Add(1)
+	go a.runTranscriptionJob(ctx, jobID, inputPath, settings, opts)
 	return a.Jobs.Current(), nil
 }
 
+// jobContext returns the context a job runs under, bounded by JobTimeoutMinutes
+// when it is set.
+func jobContext(settings domain.Settings) (context.Context, context.CancelFunc) {
+	if settings.JobTimeoutMinutes > 0 {
+		return context.WithTimeout(context.Background(), minutes(settings.JobTimeoutMinutes))
+	}
+	return context.WithCancel(context.Background())
+}
+
 // CancelTranscription cancels the currently running job, if any.
 func (a *App) CancelTranscription() error {
 	a.mu.Lock()
@@ -333,27 +651,125 @@ func (a *App) CurrentJob() domain.Job {
 	return a.Jobs.Current()
 }
 
-// JobEvents returns all events with sequence greater than sinceSeq.
-func (a *App) JobEvents(sinceSeq int64) []jobs.Event {
-	return a.events.Since(sinceSeq)
+// JobEvents returns events with sequence greater than sinceSeq that match the filter.
+// Filtering by job replays that job's own history, e.g. after a UI reload.
+// Messages are in the settings locale.
+func (a *App) JobEvents(sinceSeq int64, filter jobs.EventFilter) []jobs.Event {
+	events := a.events.Query(sinceSeq, filter)
+	locale := a.locale()
+	for i, event := range events {
+		events[i] = localizeEvent(event, locale)
+	}
+	return events
 }
 
 // runTranscriptionJob executes pipeline and maps outcomes to job events.
-func (a *App) runTranscriptionJob(ctx context.Context, jobID, inputPath string, settings domain.Settings) {
+func (a *App) runTranscriptionJob(ctx context.Context, jobID, inputPath string, settings domain.Settings, opts jobOptions) {
+	// Held until the runner returns, so afterJob work is counted before a
+	// drain can see the job gone.
+	defer a.followUps.Done()
+	// Runs after clearActiveJob on every exit path, freeing the slot first.
+	defer a.startNextQueued()
+	defer a.removeUpload(inputPath)
+	defer a.recoverJobPanic(jobID, inputPath, settings, opts)
+	if !a.checkDuplicate(ctx, jobID, inputPath, settings, &opts) {
+		return
+	}
+	lastPercent := 0
+	var rec recording
+	var chapters []transcribe.Chapter
+	splitChannels := false
+	if runsWhisper(opts.mode) {
+		a.estimateJob(ctx, jobID, inputPath, settings)
+	}
+	if opts.mode != domain.PipelineModeReformat {
+		// The input of a reformat is a segments file, not a recording.
+		rec = a.describeRecording(ctx, jobID, inputPath, settings)
+		chapters = a.inputChapters(ctx, jobID, inputPath, settings)
+		splitChannels = a.splitsChannels(ctx, jobID, inputPath, settings)
+	}
+	var transforms []transcribe.Transform
+	if opts.mode != domain.PipelineModePreprocess {
+		transforms = a.enabledTransforms(jobID, settings)
+	}
 	req := transcribe.Request{
-		InputPath: inputPath,
-		ModelPath: settings.ModelPath,
-		Language:  settings.Language,
-		OutputDir: settings.OutputDir,
+		InputPath:           inputPath,
+		ModelPath:           settings.ModelPath,
+		Language:            settings.Language,
+		OutputDir:           settings.OutputDir,
+		FFmpegPath:          settings.FFmpegPath,
+		WhisperPath:         settings.WhisperPath,
+		PreprocessTimeout:   minutes(settings.PreprocessTimeoutMinutes),
+		TranscribeTimeout:   minutes(settings.TranscribeTimeoutMinutes),
+		LowPriority:         settings.ProcessPriority == domain.ProcessPriorityResponsive,
+		HWAccel:             string(settings.FFmpegHWAccel),
+		Tempo:               settings.AudioTempo,
+		SplitChannels:       splitChannels,
+		SkipNonSpeech:       settings.SkipNonSpeech,
+		HallucinationFilter: settings.HallucinationFilter,
+		SilenceMarkers:      settings.SilenceMarkers,
+		SilenceMap:          settings.SilenceMap,
+		SilenceMinGap:       time.Duration(settings.SilenceMinSeconds) * time.Second,
+		InputHash:           opts.inputHash,
+		Terms:               settings.Terms,
+		Grammar:             whisperGrammar(settings),
+		Subtitles:           settings.ExportSubtitles || len(settings.SubtitleLanguages) > 0,
+		LRC:                 settings.ExportLRC,
+		Mode:                opts.mode,
+		Cache:               a.preprocessCache(settings),
+		DraftModelPath:      draftModelPath(settings),
+		Server:              a.whisperServerFor(settings),
+		WhisperServerPath:   settings.WhisperServerPath,
+		GPUDevice:           opts.gpuDevice,
+		TempDir:             settings.TempDir,
+		KeepIntermediates:   settings.KeepIntermediates,
+		ScriptText:          opts.scriptText,
+		OutputName:          outputName(settings.OutputNameTemplate, inputPath, rec),
+		Chapters:            chapters,
+		TextEncoding:        settings.TextEncoding,
+		LineEnding:          settings.LineEnding,
+		TextTimestamps:      settings.TextTimestamps,
+		TimestampInterval:   time.Duration(settings.TextTimestampSeconds) * time.Second,
+		TextLayout:          settings.TextLayout,
+		Transforms:          transforms,
 		OnStage: func(stage string) {
 			status, ok := mapStageToStatus(stage)
 			if !ok {
 				return
 			}
 			if err := a.Jobs.Transition(status); err == nil {
+				a.recordJob(func(record *domain.JobRecord) { record.Status = status })
 				a.publishStatus(jobID, status, "Running "+stage+" stage")
 			}
 		},
+		OnProgress: func(stage string, fraction float64) {
+			span, ok := stageProgressRanges[stage]
+			if !ok {
+				return
+			}
+			a.Jobs.SetProgress(span[0] + fraction*(span[1]-span[0]))
+			job := a.Jobs.Current()
+			if percent := int(job.Progress * 100); percent > lastPercent && job.ID == jobID {
+				lastPercent = percent
+				a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeProgress, Job: &job})
+			}
+		},
+		OnSegment: func(segment transcribe.TranscriptSegment) {
+			a.publishEvent(jobs.Event{
+				JobID:   jobID,
+				Type:    jobs.EventTypeSegment,
+				Text:    segment.Text,
+				StartMs: segment.StartMs,
+				EndMs:   segment.EndMs,
+				Speaker: segment.Speaker,
+			})
+		},
+		OnDraft: func(text string) {
+			a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeDraft, Text: text})
+		},
+		OnWorkspace: func(dir string) {
+			a.recordJob(func(record *domain.JobRecord) { record.Workspace = dir })
+		},
 		OnLog: func(log transcribe.CommandLog) {
 			a.publishEvent(jobs.Event{
 				JobID:    jobID,
@@ -366,6 +782,19 @@ func (a *App) runTranscriptionJob(ctx context.Context, jobID, inputPath string,
 				Stderr:   log.Stderr,
 			})
 		},
+		OnOutput: func(line transcribe.OutputLine) {
+			event := jobs.Event{
+				JobID:   jobID,
+				Type:    jobs.EventTypeOutput,
+				Command: line.Command,
+			}
+			if line.Stream == "stderr" {
+				event.Stderr = line.Text
+			} else {
+				event.Stdout = line.Text
+			}
+			a.publishEvent(event)
+		},
 	}
 
 	result, err := a.Pipeline.Run(ctx, req)
@@ -373,12 +802,17 @@ func (a *App) runTranscriptionJob(ctx context.Context, jobID, inputPath string,
 		if errors.Is(err, context.Canceled) {
 			_ = a.Jobs.Transition(domain.JobStatusCancelled)
 			a.publishStatus(jobID, domain.JobStatusCancelled, "Job cancelled")
+			a.recordHistory(a.historyEntry(jobID, inputPath, settings, domain.JobStatusCancelled, opts))
 			a.clearActiveJob(jobID)
 			return
 		}
 
 		_ = a.Jobs.Transition(domain.JobStatusFailed)
-		a.publishStatus(jobID, domain.JobStatusFailed, "Job failed")
+		if errors.Is(err, transcribe.ErrTimeout) {
+			a.publishStatus(jobID, domain.JobStatusFailed, "Job timed out")
+		} else {
+			a.publishStatus(jobID, domain.JobStatusFailed, "Job failed")
+		}
 		a.publishEvent(jobs.Event{
 			JobID:   jobID,
 			Type:    jobs.EventTypeError,
@@ -399,11 +833,33 @@ func (a *App) runTranscriptionJob(ctx context.Context, jobID, inputPath string,
 				Stderr:   pipelineErr.CommandLog.Stderr,
 			})
 		}
+		if errors.As(err, &pipelineErr) && pipelineErr.Workspace != "" {
+			a.publishWorkspaceKept(jobID, pipelineErr.Workspace)
+		}
 
+		entry := a.historyEntry(jobID, inputPath, settings, domain.JobStatusFailed, opts)
+		entry.Error = err.Error()
+		if errors.As(err, &pipelineErr) {
+			entry.Failure = pipelineErr.Failure()
+		}
+		a.recordHistory(entry)
 		a.clearActiveJob(jobID)
+		a.afterJob(jobID, func(context.Context) { a.notifyJob(entry, settings, nil) })
 		return
 	}
 
+	audio := audioSeconds(result)
+	a.recordSpeed(settings.ModelPath, result.Stages, audio)
+	if result.Cached {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: "Preprocessed audio reused from cache"})
+	}
+	if len(result.Skipped) > 0 {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: skippedMessage(result.Skipped)})
+	}
+	// From a WAV the preprocessed audio is the input itself, not a workspace file.
+	if settings.KeepIntermediates && result.PreprocessedAudioPath != "" && result.PreprocessedAudioPath != inputPath {
+		a.publishWorkspaceKept(jobID, filepath.Dir(result.PreprocessedAudioPath))
+	}
 	if cleanupErr := result.Cleanup(); cleanupErr != nil {
 		a.publishEvent(jobs.Event{
 			JobID:   jobID,
@@ -412,43 +868,194 @@ func (a *App) runTranscriptionJob(ctx context.Context, jobID, inputPath string,
 		})
 	}
 
+	tags := meetingTags(a.tagTranscript(settings, result.Transcript), rec.meeting)
+
+	// History comes first so the artifacts are listed by the time clients see "done".
+	entry := a.historyEntry(jobID, inputPath, settings, domain.JobStatusDone, opts)
+	entry.TextPath = result.TextPath
+	entry.SubtitlePaths = result.SubtitlePaths
+	entry.ChapterPaths = result.ChapterPaths
+	entry.SegmentsPath = result.SegmentsPath
+	entry.LyricsPath = result.LyricsPath
+	entry.ReviewPath = result.ReviewPath
+	entry.HallucinationPath = result.HallucinationPath
+	entry.SilenceMapPath = result.SilenceMapPath
+	entry.PluginArtifacts = result.TransformPaths
+	entry.Tags = tags
+	entry.AudioSeconds = audio
+	entry.Stages = result.Stages
+	if rec.meeting != nil {
+		entry.Meeting = rec.meeting
+		if path, err := writeMetadataSidecar(result.TextPath, rec, entry); err != nil {
+			a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeError, Message: fmt.Sprintf("write metadata sidecar: %v", err)})
+		} else {
+			entry.MetadataPath = path
+		}
+	}
+	a.recordHistory(entry)
 	if err := a.Jobs.Transition(domain.JobStatusDone); err == nil {
 		a.publishStatus(jobID, domain.JobStatusDone, "Job completed")
 	}
-	a.publishEvent(jobs.Event{
+	resultEvent := jobs.Event{
 		JobID:    jobID,
 		Type:     jobs.EventTypeResult,
 		Status:   domain.JobStatusDone,
 		Message:  "Transcript exported",
 		TextPath: result.TextPath,
-	})
+		Stages:   result.Stages,
+	}
+	switch {
+	case result.AudioPath != "":
+		resultEvent.Message, resultEvent.TextPath = "Preprocessed audio exported", result.AudioPath
+	case opts.mode == domain.PipelineModeReformat:
+		resultEvent.Message = "Subtitles regenerated"
+	}
+	a.publishEvent(resultEvent)
+	for _, path := range result.SubtitlePaths {
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  "Subtitles exported",
+			TextPath: path,
+		})
+	}
+	if n := len(result.ChapterPaths); n > 0 {
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  fmt.Sprintf("%d chapter transcripts exported", n-1),
+			TextPath: result.ChapterPaths[n-1],
+		})
+	}
+	if result.ReviewPath != "" {
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  "Low-confidence passages need review",
+			TextPath: result.ReviewPath,
+		})
+	}
+	if result.HallucinationPath != "" {
+		message := "%d suspected hallucinations flagged"
+		if settings.HallucinationFilter == domain.HallucinationFilterRemove {
+			message = "%d suspected hallucinations removed"
+		}
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  fmt.Sprintf(message, len(result.Hallucinations)),
+			TextPath: result.HallucinationPath,
+		})
+	}
+	if len(result.Silence) > 0 {
+		path := result.SilenceMapPath
+		if path == "" {
+			path = result.TextPath
+		}
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  fmt.Sprintf("%d gaps without speech marked", len(result.Silence)),
+			TextPath: path,
+		})
+	}
+	if result.LyricsPath != "" {
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  "Lyrics exported",
+			TextPath: result.LyricsPath,
+		})
+	}
+	for _, path := range result.TransformPaths {
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  "Transform output exported",
+			TextPath: path,
+		})
+	}
+	a.embedLyrics(jobID, inputPath, result, settings)
 	a.clearActiveJob(jobID)
+	transcriptLanguage := result.Language
+	if transcriptLanguage == "" {
+		transcriptLanguage = settings.Language
+	}
+	a.afterJob(jobID, func(ctx context.Context) {
+		// Translations are delivered too, so they are written first.
+		if translated := a.translateJobSubtitles(ctx, jobID, result.SubtitlePaths, transcriptLanguage, settings); len(translated) > 0 {
+			entry.TranslatedSubtitlePaths = translated
+			a.updateHistory(jobID, func(recorded *domain.HistoryEntry) { recorded.TranslatedSubtitlePaths = translated })
+		}
+		entry := a.llmTagJob(ctx, entry, rec, settings, result.Transcript)
+		// Plugin artifacts are delivered with the job's own outputs.
+		a.deliverJob(ctx, a.runPlugins(ctx, entry, settings), settings)
+	})
 }
 
-// publishStatus sends a normalized status event.
-func (a *App) publishStatus(jobID string, status domain.JobStatus, message string) {
+// publishWorkspaceKept tells the user where retained intermediates live.
+func (a *App) publishWorkspaceKept(jobID, dir string) {
 	a.publishEvent(jobs.Event{
+		JobID:   jobID,
+		Type:    jobs.EventTypeLog,
+		Message: "Intermediate files kept in " + dir,
+	})
+}
+
+// GetJobEventLog returns the complete persisted event stream of a job, including
+// events already trimmed from the in-memory buffer.
+func (a *App) GetJobEventLog(jobID string) ([]jobs.Event, error) {
+	if a.eventLog == nil {
+		return nil, fmt.Errorf("event log is not configured")
+	}
+	events, err := a.eventLog.Read(strings.TrimSpace(jobID))
+	if err != nil {
+		return events, fmt.Errorf("read event log: %w", err)
+	}
+	return events, nil
+}
+
+// publishStatus sends a normalized status event with a snapshot of the job.
+func (a *App) publishStatus(jobID string, status domain.JobStatus, message string) {
+	event := jobs.Event{
 		JobID:   jobID,
 		Type:    jobs.EventTypeStatus,
 		Status:  status,
 		Message: message,
-	})
+	}
+	if job := a.Jobs.Current(); job.ID == jobID {
+		event.Job = &job
+	}
+	a.publishEvent(event)
 }
 
 // publishEvent stores event history and emits runtime push notifications.
 func (a *App) publishEvent(event jobs.Event) {
 	published := a.events.Publish(event)
+	if a.metrics != nil {
+		a.metrics.Observe(published)
+	}
+	if a.eventLog != nil {
+		// Best effort: a full disk must not break the live event stream.
+		_ = a.eventLog.Append(published)
+	}
 
 	a.mu.Lock()
 	ctx := a.runtimeCtx
+	locale := a.Settings.Locale
 	a.mu.Unlock()
 	if ctx != nil {
-		wailsruntime.EventsEmit(ctx, "job:event", published)
+		wailsruntime.EventsEmit(ctx, "job:event", localizeEvent(published, locale))
 	}
 }
 
-// clearActiveJob clears cancellation handles for completed job IDs.
+// clearActiveJob clears cancellation handles and the persisted record for completed job IDs.
 func (a *App) clearActiveJob(jobID string) {
+	a.forgetJob(jobID)
+	if a.eventLog != nil {
+		_ = a.eventLog.Close(jobID)
+	}
+
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	if a.activeJobID == jobID {
@@ -481,17 +1088,196 @@ func (a *App) runtimeContext() (context.Context, error) {
 	return a.runtimeCtx, nil
 }
 
+// mergeDiagnosticItem replaces or appends one item and recomputes the failure flag.
+func mergeDiagnosticItem(report domain.DiagnosticReport, item domain.DiagnosticItem) domain.DiagnosticReport {
+	items := make([]domain.DiagnosticItem, 0, len(report.Items)+1)
+	replaced := false
+	for _, existing := range report.Items {
+		if existing.ID == item.ID {
+			existing = item
+			replaced = true
+		}
+		items = append(items, existing)
+	}
+	if !replaced {
+		items = append(items, item)
+	}
+
+	report.Items = items
+	report.HasFailures = false
+	for _, existing := range items {
+		if existing.Status == domain.DiagnosticStatusFail {
+			report.HasFailures = true
+			break
+		}
+	}
+	report.GeneratedAt = time.Now().UTC()
+	return report
+}
+
 // normalizeSettings trims user inputs and applies default language when empty.
 func normalizeSettings(settings domain.Settings) domain.Settings {
 	settings.ModelPath = strings.TrimSpace(settings.ModelPath)
+	settings.DraftModelPath = strings.TrimSpace(settings.DraftModelPath)
 	settings.OutputDir = strings.TrimSpace(settings.OutputDir)
 	settings.Language = strings.TrimSpace(settings.Language)
+	settings.FFmpegPath = strings.TrimSpace(settings.FFmpegPath)
+	settings.WhisperPath = strings.TrimSpace(settings.WhisperPath)
+	settings.WhisperServerPath = strings.TrimSpace(settings.WhisperServerPath)
+	settings.TempDir = strings.TrimSpace(settings.TempDir)
+	settings.TranslationProvider = strings.ToLower(strings.TrimSpace(settings.TranslationProvider))
+	settings.TranslationEndpoint = strings.TrimSpace(settings.TranslationEndpoint)
+	settings.TranslationModel = strings.TrimSpace(settings.TranslationModel)
+	settings.RemoteWorkers = strings.TrimSpace(settings.RemoteWorkers)
+	settings.S3Endpoint = strings.TrimSpace(settings.S3Endpoint)
+	settings.S3Region = strings.TrimSpace(settings.S3Region)
+	settings.S3Bucket = strings.TrimSpace(settings.S3Bucket)
+	settings.S3Prefix = strings.Trim(strings.TrimSpace(settings.S3Prefix), "/")
+	settings.S3AccessKeyID = strings.TrimSpace(settings.S3AccessKeyID)
+	settings.WebDAVURL = strings.TrimSpace(settings.WebDAVURL)
+	settings.WebDAVUser = strings.TrimSpace(settings.WebDAVUser)
+	settings.WebDAVPath = strings.Trim(strings.TrimSpace(settings.WebDAVPath), "/")
+	settings.ObsidianFolder = strings.TrimSpace(settings.ObsidianFolder)
+	settings.NotionParentPageID = strings.TrimSpace(settings.NotionParentPageID)
+	settings.SlackWebhookURL = strings.TrimSpace(settings.SlackWebhookURL)
+	settings.DiscordWebhookURL = strings.TrimSpace(settings.DiscordWebhookURL)
+	settings.NotifyQuietHours = strings.TrimSpace(settings.NotifyQuietHours)
+	settings.SMTPHost = strings.TrimSpace(settings.SMTPHost)
+	settings.SMTPUser = strings.TrimSpace(settings.SMTPUser)
+	settings.SMTPFrom = strings.TrimSpace(settings.SMTPFrom)
+	settings.EmailRecipients = strings.TrimSpace(settings.EmailRecipients)
+	settings.EmailAttachFormats = strings.TrimSpace(settings.EmailAttachFormats)
+	settings.CalendarICS = strings.TrimSpace(settings.CalendarICS)
+	settings.CalDAVURL = strings.TrimSpace(settings.CalDAVURL)
+	settings.CalDAVUser = strings.TrimSpace(settings.CalDAVUser)
+	settings.OutputNameTemplate = strings.TrimSpace(settings.OutputNameTemplate)
+	settings.Terms = normalizeTerms(settings.Terms)
+	switch settings.Grammar {
+	case domain.GrammarDigits, domain.GrammarCommands, domain.GrammarFile:
+	default:
+		settings.Grammar = domain.GrammarOff
+	}
+	settings.GrammarVocabulary = normalizeTerms(settings.GrammarVocabulary)
+	settings.GrammarPath = strings.TrimSpace(settings.GrammarPath)
+	settings.GrammarRule = strings.TrimSpace(settings.GrammarRule)
+	settings.GrammarPenalty = max(settings.GrammarPenalty, 0)
+	settings.SilenceMinSeconds = max(settings.SilenceMinSeconds, 0)
+	settings.SubtitleLanguages = normalizeSubtitleLanguages(settings.SubtitleLanguages)
+	settings.EnabledPlugins = normalizePlugins(settings.EnabledPlugins)
+	if settings.PreprocessCacheMB < 0 {
+		settings.PreprocessCacheMB = 0
+	}
+	if settings.RetentionDays < 0 {
+		settings.RetentionDays = 0
+	}
+	if settings.RetentionGB < 0 {
+		settings.RetentionGB = 0
+	}
+	if settings.SMTPPort < 0 || settings.SMTPPort > 65535 {
+		settings.SMTPPort = 0
+	}
+	if settings.AudioTempo <= 1 {
+		settings.AudioTempo = 0
+	}
+	settings.AudioTempo = min(settings.AudioTempo, transcribe.MaxTempo)
+	if settings.HallucinationFilter != domain.HallucinationFilterFlag && settings.HallucinationFilter != domain.HallucinationFilterRemove {
+		settings.HallucinationFilter = domain.HallucinationFilterOff
+	}
+	switch settings.FFmpegHWAccel {
+	case domain.HWAccelAuto, domain.HWAccelCUDA, domain.HWAccelVideoToolbox, domain.HWAccelQSV, domain.HWAccelVAAPI:
+	default:
+		settings.FFmpegHWAccel = domain.HWAccelOff
+	}
+	settings.GPUDevice = strings.TrimSpace(settings.GPUDevice)
+	if strings.EqualFold(settings.GPUDevice, domain.GPUDeviceCPU) {
+		settings.GPUDevice = domain.GPUDeviceCPU
+	}
+	if settings.TextEncoding != domain.TextEncodingUTF8BOM && settings.TextEncoding != domain.TextEncodingUTF16LE {
+		settings.TextEncoding = domain.TextEncodingUTF8
+	}
+	if settings.LineEnding != domain.LineEndingCRLF {
+		settings.LineEnding = domain.LineEndingLF
+	}
+	if settings.TextTimestamps != domain.TextTimestampsSegment && settings.TextTimestamps != domain.TextTimestampsInterval {
+		settings.TextTimestamps = domain.TextTimestampsNone
+	}
+	settings.TextTimestampSeconds = max(settings.TextTimestampSeconds, 0)
+	if settings.TextLayout != domain.TextLayoutSentences && settings.TextLayout != domain.TextLayoutParagraphs {
+		settings.TextLayout = domain.TextLayoutSegments
+	}
+	if settings.Locale != domain.LocaleRussian {
+		settings.Locale = domain.LocaleEnglish
+	}
+	if settings.MemoryGuard != domain.MemoryGuardWarn && settings.MemoryGuard != domain.MemoryGuardOff {
+		settings.MemoryGuard = domain.MemoryGuardBlock
+	}
+	if settings.Duplicates != domain.DuplicateSkip && settings.Duplicates != domain.DuplicateOff {
+		settings.Duplicates = domain.DuplicateWarn
+	}
+	if settings.Tagging != domain.TaggingLLM && settings.Tagging != domain.TaggingOff {
+		settings.Tagging = domain.TaggingLocal
+	}
 	if settings.Language == "" {
 		settings.Language = "auto"
 	}
+	if settings.ProcessPriority != domain.ProcessPriorityResponsive {
+		settings.ProcessPriority = domain.ProcessPriorityPerformance
+	}
+	if settings.WhisperEngine != domain.WhisperEngineServer {
+		settings.WhisperEngine = domain.WhisperEngineCLI
+	}
+	if settings.DownloadLimitKBps < 0 {
+		settings.DownloadLimitKBps = 0
+	}
+	for _, timeout := range []*int{&settings.PreprocessTimeoutMinutes, &settings.TranscribeTimeoutMinutes, &settings.JobTimeoutMinutes} {
+		if *timeout < 0 {
+			*timeout = 0
+		}
+	}
 	return settings
 }
 
+// minutes converts a minutes setting to a duration; zero stays zero (no limit).
+func minutes(value int) time.Duration {
+	return time.Duration(value) * time.Minute
+}
+
+// jobOutputPath picks the output of entry with the given extension, the
+// transcript text when format is empty.
+func jobOutputPath(entry domain.HistoryEntry, format string) (string, error) {
+	format = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(format)), ".")
+	if format == "" {
+		if entry.TextPath == "" {
+			return "", fmt.Errorf("job %s has no transcript", entry.ID)
+		}
+		return entry.TextPath, nil
+	}
+	for _, path := range jobArtifacts(entry) {
+		if strings.EqualFold(filepath.Ext(path), "."+format) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("job %s has no %s output", entry.ID, format)
+}
+
+// openWithDefaultApp launches the application the OS associates with the file.
+func openWithDefaultApp(path string) error {
+	var cmd *exec.Cmd
+	switch goruntime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", filepath.Clean(path))
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("launch default application: %w", err)
+	}
+	return nil
+}
+
 // openInFileManager launches the platform file explorer for the provided path.
 func openInFileManager(path string) error {
 	var cmd *exec.Cmd
@@ -509,3 +1295,39 @@ func openInFileManager(path string) error {
 	}
 	return nil
 }
+
+// revealInFileManager opens the folder of path with the file selected. On
+// Linux the file manager is asked over D-Bus, so the call waits for its reply
+// and fails when no file manager implements org.freedesktop.FileManager1.
+func revealInFileManager(path string) error {
+	name, args := revealCommand(goruntime.GOOS, path)
+	cmd := exec.Command(name, args...)
+	if goruntime.GOOS == "darwin" || goruntime.GOOS == "windows" {
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("launch file manager: %w", err)
+		}
+		return nil
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("reveal in file manager: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// revealCommand builds the platform command that selects path in the file manager.
+func revealCommand(goos, path string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{"-R", path}
+	case "windows":
+		return "explorer", []string{"/select," + filepath.Clean(path)}
+	default:
+		// dbus-send separates array items with commas, so they are escaped in the URI.
+		uri := strings.ReplaceAll((&url.URL{Scheme: "file", Path: path}).String(), ",", "%2C")
+		return "dbus-send", []string{
+			"--session", "--print-reply", "--dest=org.freedesktop.FileManager1", "--type=method_call",
+			"/org/freedesktop/FileManager1", "org.freedesktop.FileManager1.ShowItems",
+			"array:string:" + uri, "string:",
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -119,7 +121,7 @@ func TestStartTranscriptionPublishesProgressAndResultEvents(t *testing.T) {
 	}
 
 	waitForStatus(t, app, domain.JobStatusDone)
-	events := app.JobEvents(0)
+	events := app.JobEvents(0, jobs.EventFilter{})
 	if len(events) == 0 {
 		t.Fatal("expected events")
 	}
@@ -164,7 +166,7 @@ func TestStartTranscriptionPublishesFailureEvents(t *testing.T) {
 	}
 
 	waitForStatus(t, app, domain.JobStatusFailed)
-	events := app.JobEvents(0)
+	events := app.JobEvents(0, jobs.EventFilter{})
 	if len(events) == 0 {
 		t.Fatal("expected events")
 	}
@@ -197,3 +199,258 @@ func assertEventTypeExists(t *testing.T, events []jobs.Event, want jobs.EventTyp
 	}
 	t.Fatalf("event type %s not found", want)
 }
+
+// TestMergeDiagnosticItemReplacesAndRecomputesFailures checks smoke result merging.
+func TestMergeDiagnosticItemReplacesAndRecomputesFailures(t *testing.T) {
+	report := domain.DiagnosticReport{
+		Items: []domain.DiagnosticItem{
+			{ID: "tool_ffmpeg", Status: domain.DiagnosticStatusPass},
+			{ID: "smoke_test", Status: domain.DiagnosticStatusFail},
+		},
+		HasFailures: true,
+	}
+
+	merged := mergeDiagnosticItem(report, domain.DiagnosticItem{ID: "smoke_test", Status: domain.DiagnosticStatusPass})
+	if len(merged.Items) != 2 {
+		t.Fatalf("items = %d, want 2", len(merged.Items))
+	}
+	if merged.HasFailures {
+		t.Fatal("expected no failures after passing smoke test")
+	}
+}
+
+// TestStartTranscriptionPublishesProgressSnapshots checks stage progress maps onto job progress.
+func TestStartTranscriptionPublishesProgressSnapshots(t *testing.T) {
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{OutputDir: t.TempDir()}},
+		Jobs:  jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnProgress("transcribing", 0.5)
+			req.OnProgress("transcribing", 0.5)
+			req.OnStage("exporting")
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	job, err := app.StartTranscription("/media/clip.mp4")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if job.InputPath != "/media/clip.mp4" || job.StartedAt.IsZero() {
+		t.Fatalf("started job = %+v", job)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+
+	progress := app.JobEvents(0, jobs.EventFilter{Types: []jobs.EventType{jobs.EventTypeProgress}})
+	if len(progress) != 1 || progress[0].Job == nil {
+		t.Fatalf("progress events = %+v, want one with a job snapshot", progress)
+	}
+	if got := progress[0].Job.Progress; got < 0.52 || got > 0.53 {
+		t.Fatalf("progress = %v, want 0.525", got)
+	}
+
+	final := app.CurrentJob()
+	if final.Progress != 1 || final.FinishedAt == nil {
+		t.Fatalf("final job = %+v", final)
+	}
+}
+
+// TestStartTranscriptionPublishesSegments streams whisper's segments as
+// segment events of the job, untranslated.
+func TestStartTranscriptionPublishesSegments(t *testing.T) {
+	app := &App{
+		Settings: domain.Settings{Locale: domain.LocaleRussian},
+		Store:    &fakeStore{settings: domain.Settings{OutputDir: t.TempDir(), Locale: domain.LocaleRussian}},
+		Jobs:     jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnSegment(transcribe.TranscriptSegment{StartMs: 0, EndMs: 1500, Text: "Job started"})
+			req.OnSegment(transcribe.TranscriptSegment{StartMs: 1500, EndMs: 4000, Text: "and then some", Speaker: "Speaker 2"})
+			req.OnStage("exporting")
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	job, err := app.StartTranscription("/media/clip.mp4")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+
+	segments := app.JobEvents(0, jobs.EventFilter{JobID: job.ID, Types: []jobs.EventType{jobs.EventTypeSegment}})
+	if len(segments) != 2 {
+		t.Fatalf("segment events = %+v, want 2", segments)
+	}
+	if segments[0].Text != "Job started" || segments[1].StartMs != 1500 || segments[1].EndMs != 4000 || segments[1].Speaker != "Speaker 2" {
+		t.Fatalf("segment events = %+v", segments)
+	}
+}
+
+// TestStartTranscriptionPublishesDraft passes the draft model to the
+// pipeline and publishes the provisional transcript as a draft event.
+func TestStartTranscriptionPublishesDraft(t *testing.T) {
+	tests := []struct {
+		name      string
+		draft     string
+		wantDraft string
+	}{
+		{name: "tiny draft", draft: "/m/tiny.bin", wantDraft: "/m/tiny.bin"},
+		{name: "same as model", draft: "/m/base.bin"},
+		{name: "off"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := make(chan transcribe.Request, 1)
+			app := &App{
+				Store: &fakeStore{settings: domain.Settings{ModelPath: "/m/base.bin", DraftModelPath: tt.draft, OutputDir: t.TempDir()}},
+				Jobs:  jobs.NewManager(),
+				Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+					requests <- req
+					req.OnStage("transcribing")
+					if req.DraftModelPath != "" {
+						req.OnDraft("rough words")
+					}
+					req.OnStage("exporting")
+					return transcribe.Result{}, nil
+				}},
+				events: jobs.NewEventBus(100),
+			}
+
+			job, err := app.StartTranscription("/media/clip.mp4")
+			if err != nil {
+				t.Fatalf("start: %v", err)
+			}
+			if req := <-requests; req.DraftModelPath != tt.wantDraft {
+				t.Fatalf("draft model = %q, want %q", req.DraftModelPath, tt.wantDraft)
+			}
+			waitForStatus(t, app, domain.JobStatusDone)
+
+			drafts := app.JobEvents(0, jobs.EventFilter{JobID: job.ID, Types: []jobs.EventType{jobs.EventTypeDraft}})
+			if tt.wantDraft == "" && len(drafts) != 0 {
+				t.Fatalf("draft events = %+v, want none", drafts)
+			}
+			if tt.wantDraft != "" && (len(drafts) != 1 || drafts[0].Text != "rough words") {
+				t.Fatalf("draft events = %+v", drafts)
+			}
+		})
+	}
+}
+
+// TestJobOutputPathSelectsFormat resolves the file OpenTranscript launches.
+func TestJobOutputPathSelectsFormat(t *testing.T) {
+	entry := domain.HistoryEntry{
+		ID:            "job-1",
+		TextPath:      "/out/talk.txt",
+		SubtitlePaths: []string{"/out/talk.srt", "/out/talk.vtt", "/out/talk.de.srt"},
+		LyricsPath:    "/out/talk.lrc",
+	}
+	tests := []struct {
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{format: "", want: "/out/talk.txt"},
+		{format: "SRT", want: "/out/talk.srt"},
+		{format: ".vtt", want: "/out/talk.vtt"},
+		{format: "lrc", want: "/out/talk.lrc"},
+		{format: "md", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := jobOutputPath(entry, tt.format)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Fatalf("jobOutputPath(%q) = %q, %v; want %q", tt.format, got, err, tt.want)
+		}
+	}
+	if _, err := jobOutputPath(domain.HistoryEntry{ID: "failed"}, ""); err == nil {
+		t.Fatal("expected error for a job without transcript")
+	}
+}
+
+// TestJobArtifactsListsReports lists the reports a job wrote next to its
+// transcript, so the API serves them and retention deletes them.
+func TestJobArtifactsListsReports(t *testing.T) {
+	entry := domain.HistoryEntry{
+		TextPath:          "/out/talk.txt",
+		ReviewPath:        "/out/talk.review.txt",
+		HallucinationPath: "/out/talk.hallucinations.txt",
+		SilenceMapPath:    "/out/talk.silence.json",
+	}
+	got := jobArtifacts(entry)
+	want := []string{"/out/talk.txt", "/out/talk.review.txt", "/out/talk.hallucinations.txt", "/out/talk.silence.json"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("artifacts = %v, want %v", got, want)
+	}
+}
+
+// TestRevealCommand checks the per-platform commands that select a file.
+func TestRevealCommand(t *testing.T) {
+	tests := []struct {
+		goos string
+		path string
+		want string
+	}{
+		{goos: "darwin", path: "/out/talk.txt", want: "open -R /out/talk.txt"},
+		{goos: "linux", path: "/out/a, b.txt", want: "dbus-send --session --print-reply --dest=org.freedesktop.FileManager1 --type=method_call " +
+			"/org/freedesktop/FileManager1 org.freedesktop.FileManager1.ShowItems array:string:file:///out/a%2C%20b.txt string:"},
+	}
+	for _, tt := range tests {
+		name, args := revealCommand(tt.goos, tt.path)
+		if got := strings.Join(append([]string{name}, args...), " "); got != tt.want {
+			t.Fatalf("%s: got %q, want %q", tt.goos, got, tt.want)
+		}
+	}
+	if name, args := revealCommand("windows", "out/talk.txt"); name != "explorer" || len(args) != 1 || !strings.HasPrefix(args[0], "/select,") {
+		t.Fatalf("windows: %s %v", name, args)
+	}
+}
+
+// TestNormalizeSettingsClampsAudioTempo keeps the speed-up between normal
+// speed and transcribe.MaxTempo.
+func TestNormalizeSettingsClampsAudioTempo(t *testing.T) {
+	for tempo, want := range map[float64]float64{-1: 0, 0: 0, 1: 0, 1.25: 1.25, 5: transcribe.MaxTempo} {
+		if got := normalizeSettings(domain.Settings{AudioTempo: tempo}).AudioTempo; got != want {
+			t.Errorf("normalize(%v) = %v, want %v", tempo, got, want)
+		}
+	}
+}
+
+// TestNormalizeSettingsGrammar drops unknown grammars and tidies the phrases.
+func TestNormalizeSettingsGrammar(t *testing.T) {
+	got := normalizeSettings(domain.Settings{
+		Grammar:           domain.GrammarCommands,
+		GrammarVocabulary: []string{" yes ", "Yes", "", "speak  to agent"},
+		GrammarPath:       " ivr.gbnf ",
+		GrammarPenalty:    -5,
+	})
+	if got.Grammar != domain.GrammarCommands || !reflect.DeepEqual(got.GrammarVocabulary, []string{"yes", "speak to agent"}) {
+		t.Fatalf("grammar = %q %q", got.Grammar, got.GrammarVocabulary)
+	}
+	if got.GrammarPath != "ivr.gbnf" || got.GrammarPenalty != 0 {
+		t.Fatalf("path = %q, penalty = %v", got.GrammarPath, got.GrammarPenalty)
+	}
+	if got := normalizeSettings(domain.Settings{Grammar: "regex"}).Grammar; got != domain.GrammarOff {
+		t.Fatalf("unknown grammar normalized to %q", got)
+	}
+}
+
+// TestJobContextAppliesTimeout checks the job deadline follows JobTimeoutMinutes.
+func TestJobContextAppliesTimeout(t *testing.T) {
+	ctx, cancel := jobContext(domain.Settings{})
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("job without a timeout has a deadline")
+	}
+	cancel()
+	if ctx.Err() == nil {
+		t.Fatal("cancel did not stop the job context")
+	}
+
+	ctx, cancel = jobContext(domain.Settings{JobTimeoutMinutes: 5})
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > 5*time.Minute {
+		t.Fatalf("deadline = %v, %v", deadline, ok)
+	}
+}
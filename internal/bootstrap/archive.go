@@ -0,0 +1,112 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/storage"
+)
+
+// archiveTarget is one configured destination for finished transcripts.
+type archiveTarget struct {
+	// name prefixes error messages, e.g. "s3".
+	name string
+	// newUploader connects lazily so a misconfigured target is reported per job.
+	newUploader func() (storage.Uploader, error)
+	// dir is the key prefix the files are stored under.
+	dir string
+	// source also uploads the input media.
+	source bool
+}
+
+// archiveTargets lists the destinations enabled in settings.
+func (a *App) archiveTargets(entry domain.HistoryEntry, settings domain.Settings) []archiveTarget {
+	var targets []archiveTarget
+	if target, ok := a.s3Target(entry, settings); ok {
+		targets = append(targets, target)
+	}
+	if target, ok := a.webDAVTarget(settings); ok {
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// deliveryTimeout bounds translating, tagging, and running the plugins of
+// one finished job and delivering it to every integration.
+const deliveryTimeout = 30 * time.Minute
+
+// afterJob runs work on a finished job in the background, once its slot is
+// free, so a slow plugin or remote holds up neither the queue nor the next
+// job. Server mode waits for it while draining. It must be called from the
+// job's runner. The events work publishes reopen the job's event log, which
+// is closed again once it returns.
+func (a *App) afterJob(jobID string, work func(ctx context.Context)) {
+	a.followUps.Add(1)
+	go func() {
+		defer a.followUps.Done()
+		if a.eventLog != nil {
+			defer a.eventLog.Close(jobID)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+		defer cancel()
+		a.guard("job delivery", func() { work(ctx) })
+	}()
+}
+
+// waitFollowUps blocks until every afterJob work has finished, reporting
+// false when ctx ended first.
+func (a *App) waitFollowUps(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		a.followUps.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// deliverJob runs the integrations that receive a finished job's results,
+// then notifies chat webhooks with a link to the first published copy.
+func (a *App) deliverJob(ctx context.Context, entry domain.HistoryEntry, settings domain.Settings) {
+	links := a.archiveJob(ctx, entry, settings)
+	links = append(links, a.exportNotes(ctx, entry, settings)...)
+	a.emailJob(entry, settings)
+	a.notifyJob(entry, settings, links)
+}
+
+// archiveJob uploads the artifacts of a finished job to every configured
+// target, reporting each file as an event, and returns the uploaded locations.
+// Failures are reported but never fail the job: the local files stay authoritative.
+func (a *App) archiveJob(ctx context.Context, entry domain.HistoryEntry, settings domain.Settings) []string {
+	var locations []string
+	for _, target := range a.archiveTargets(entry, settings) {
+		uploader, err := target.newUploader()
+		if err != nil {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("%s upload: %v", target.name, err)})
+			continue
+		}
+		files := jobArtifacts(entry)
+		if target.source {
+			files = append(files, entry.InputPath)
+		}
+		for _, file := range files {
+			location, err := uploader.Upload(ctx, path.Join(target.dir, filepath.Base(file)), file)
+			if err != nil {
+				a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("%s upload %s: %v", target.name, filepath.Base(file), err)})
+				continue
+			}
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: "Uploaded to " + location, TextPath: file})
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
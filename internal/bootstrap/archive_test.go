@@ -0,0 +1,105 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// TestArchiveJobS3UploadsArtifactsAndSource puts every artifact under prefix/jobID and reports it.
+func TestArchiveJobS3UploadsArtifactsAndSource(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	secrets := config.NewFileSecretStore(filepath.Join(root, "secrets.json"))
+	app := &App{Secrets: secrets, events: jobs.NewEventBus(10)}
+	if err := app.SetS3SecretKey("secret"); err != nil {
+		t.Fatalf("set key: %v", err)
+	}
+	entry := domain.HistoryEntry{
+		ID:                      "job-1",
+		InputPath:               filepath.Join(root, "talk.mp3"),
+		TextPath:                filepath.Join(root, "talk.txt"),
+		SubtitlePaths:           []string{filepath.Join(root, "talk.srt")},
+		TranslatedSubtitlePaths: []string{filepath.Join(root, "talk.de.srt")},
+	}
+	for _, path := range []string{entry.InputPath, entry.TextPath, entry.SubtitlePaths[0], entry.TranslatedSubtitlePaths[0]} {
+		mustWrite(t, path, "data")
+	}
+	settings := domain.Settings{
+		S3Endpoint: server.URL, S3Bucket: "archive", S3Prefix: "team",
+		S3AccessKeyID: "id", S3UploadSource: true,
+	}
+
+	app.archiveJob(context.Background(), entry, settings)
+
+	sort.Strings(paths)
+	want := []string{"/archive/team/job-1/talk.de.srt", "/archive/team/job-1/talk.mp3", "/archive/team/job-1/talk.srt", "/archive/team/job-1/talk.txt"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	uploaded := 0
+	for _, event := range app.JobEvents(0, jobs.EventFilter{JobID: "job-1"}) {
+		if strings.HasPrefix(event.Message, "Uploaded to s3://archive/team/job-1/") {
+			uploaded++
+		}
+	}
+	if uploaded != 4 {
+		t.Fatalf("upload events = %d, want 4", uploaded)
+	}
+}
+
+// TestArchiveJobS3ReportsMissingCredentials publishes an error instead of failing the job.
+func TestArchiveJobS3ReportsMissingCredentials(t *testing.T) {
+	app := &App{events: jobs.NewEventBus(10)}
+	app.archiveJob(context.Background(), domain.HistoryEntry{ID: "job-1"}, domain.Settings{
+		S3Endpoint: "http://minio:9000", S3Bucket: "archive", S3AccessKeyID: "id",
+	})
+
+	events := app.JobEvents(0, jobs.EventFilter{JobID: "job-1"})
+	if len(events) != 1 || events[0].Type != jobs.EventTypeError || !strings.Contains(events[0].Message, "credentials") {
+		t.Fatalf("events = %+v", events)
+	}
+}
+
+// TestArchiveJobExportsToWebDAVPath writes artifacts into the configured project folder.
+func TestArchiveJobExportsToWebDAVPath(t *testing.T) {
+	var mu sync.Mutex
+	var puts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			puts = append(puts, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	entry := domain.HistoryEntry{ID: "job-1", InputPath: filepath.Join(root, "talk.mp3"), TextPath: filepath.Join(root, "talk.txt")}
+	mustWrite(t, entry.TextPath, "hello")
+	app := &App{events: jobs.NewEventBus(10)}
+
+	app.archiveJob(context.Background(), entry, normalizeSettings(domain.Settings{WebDAVURL: server.URL + "/dav", WebDAVPath: "/Clients/Acme/"}))
+
+	if len(puts) != 1 || puts[0] != "/dav/Clients/Acme/talk.txt" {
+		t.Fatalf("puts = %v", puts)
+	}
+}
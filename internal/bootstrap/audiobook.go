@@ -0,0 +1,48 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// inputChapters returns the chapter markers of a chaptered input (m4b, mka,
+// mkv...) when splitting by chapter is enabled, and always of concatenated
+// folder batches. Probe errors are reported and leave the transcript unsplit.
+func (a *App) inputChapters(ctx context.Context, jobID, inputPath string, settings domain.Settings) []transcribe.Chapter {
+	if !settings.SplitChapters && !a.isMergeInput(inputPath) {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	chapters, err := transcribe.ProbeChapters(ctx, transcribe.FFprobePath(settings.FFmpegPath), inputPath)
+	if err != nil {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeError, Message: fmt.Sprintf("read chapters: %v", err)})
+		return nil
+	}
+	if len(chapters) < 2 {
+		return nil
+	}
+	a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: fmt.Sprintf("Splitting transcript into %d chapters", len(chapters))})
+	return chapters
+}
+
+// splitsChannels reports whether a channel split job should really split:
+// a mono input would only be transcribed twice as two identical speakers.
+// Probe errors keep the split, so the pipeline reports what is wrong.
+func (a *App) splitsChannels(ctx context.Context, jobID, inputPath string, settings domain.Settings) bool {
+	if !settings.SplitChannels {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+	channels, err := transcribe.ProbeChannels(ctx, transcribe.FFprobePath(settings.FFmpegPath), inputPath)
+	if err != nil || channels >= 2 {
+		return true
+	}
+	a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: "Input is mono; channels are not split"})
+	return false
+}
@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// TestSplitsChannelsSkipsMonoInput keeps a mono input from being split into two identical speakers.
+func TestSplitsChannelsSkipsMonoInput(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("the fake ffprobe is a shell script")
+	}
+	tests := []struct {
+		name     string
+		channels string
+		want     bool
+	}{
+		{name: "stereo", channels: "2", want: true},
+		{name: "mono", channels: "1", want: false},
+		{name: "probe fails", channels: "", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			script := "#!/bin/sh\nexit 1\n"
+			if tt.channels != "" {
+				script = "#!/bin/sh\necho " + tt.channels + "\n"
+			}
+			ffprobe := filepath.Join(dir, "ffprobe")
+			mustWrite(t, ffprobe, script)
+			if err := os.Chmod(ffprobe, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			app := &App{events: jobs.NewEventBus(100)}
+			settings := domain.Settings{SplitChannels: true, FFmpegPath: filepath.Join(dir, "ffmpeg")}
+
+			if got := app.splitsChannels(context.Background(), "job-1", filepath.Join(dir, "call.wav"), settings); got != tt.want {
+				t.Fatalf("splitsChannels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	app := &App{events: jobs.NewEventBus(100)}
+	if app.splitsChannels(context.Background(), "job-1", "call.wav", domain.Settings{}) {
+		t.Fatal("split without SplitChannels")
+	}
+}
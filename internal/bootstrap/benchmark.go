@@ -0,0 +1,246 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/sysinfo"
+	"media-transcriber/internal/transcribe"
+)
+
+const (
+	benchmarkSampleSeconds = 30
+	benchmarkTimeout       = 30 * time.Minute
+	// fastEnoughRealTimeFactor is the slowest benchmark still recommended (2x real time).
+	fastEnoughRealTimeFactor = 0.5
+)
+
+// modelTier ranks built-in models by quality and approximate whisper.cpp memory use.
+type modelTier struct {
+	id          string
+	rank        int
+	memoryBytes uint64
+}
+
+var modelTiers = []modelTier{
+	{id: "tiny", rank: 1, memoryBytes: 273 << 20},
+	{id: "base", rank: 2, memoryBytes: 388 << 20},
+	{id: "small", rank: 3, memoryBytes: 852 << 20},
+	{id: "medium", rank: 4, memoryBytes: 2100 << 20},
+	{id: "large-v3-turbo", rank: 5, memoryBytes: 2200 << 20},
+	{id: "large-v3", rank: 6, memoryBytes: 3900 << 20},
+}
+
+// BenchmarkModel transcribes a generated sample clip with a downloaded model and stores RTF and memory.
+// The clip is synthesized with ffmpeg because no speech sample ships with the app; whisper still
+// processes the full 30-second window, so encoder cost dominates and the timing is representative.
+func (a *App) BenchmarkModel(modelID string) (domain.BenchmarkResult, error) {
+	if a.Jobs != nil && a.Jobs.IsRunning() {
+		return domain.BenchmarkResult{}, jobs.ErrJobAlreadyRunning
+	}
+
+	id := strings.TrimSpace(modelID)
+	models := a.GetWhisperModels()
+	var model domain.WhisperModelOption
+	for _, candidate := range models {
+		if candidate.ID == id {
+			model = candidate
+			break
+		}
+	}
+	if model.ID == "" {
+		return domain.BenchmarkResult{}, fmt.Errorf("unknown model id: %s", id)
+	}
+	if !model.Downloaded {
+		return domain.BenchmarkResult{}, fmt.Errorf("model %s is not downloaded", model.Name)
+	}
+
+	workDir, err := os.MkdirTemp("", "media-transcriber-benchmark-*")
+	if err != nil {
+		return domain.BenchmarkResult{}, fmt.Errorf("create benchmark workspace: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), benchmarkTimeout)
+	defer cancel()
+
+	settings, _ := a.loadSettingsForModelCatalog()
+	ffmpegPath := "ffmpeg"
+	if settings.FFmpegPath != "" {
+		ffmpegPath = settings.FFmpegPath
+	}
+
+	samplePath := filepath.Join(workDir, "benchmark-sample.wav")
+	if output, err := exec.CommandContext(ctx, ffmpegPath, buildBenchmarkSampleArgs(samplePath)...).CombinedOutput(); err != nil {
+		return domain.BenchmarkResult{}, fmt.Errorf("generate benchmark sample: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+
+	var transcribeStart, transcribeEnd time.Time
+	var whisperLog transcribe.CommandLog
+	result, err := a.Pipeline.Run(ctx, transcribe.Request{
+		InputPath:   samplePath,
+		ModelPath:   model.LocalPath,
+		Language:    "auto",
+		OutputDir:   workDir,
+		FFmpegPath:  settings.FFmpegPath,
+		WhisperPath: settings.WhisperPath,
+		OnStage: func(stage string) {
+			switch stage {
+			case "transcribing":
+				transcribeStart = time.Now()
+			case "exporting":
+				transcribeEnd = time.Now()
+			}
+		},
+		OnLog: func(log transcribe.CommandLog) {
+			if !transcribeStart.IsZero() {
+				whisperLog = log
+			}
+		},
+	})
+	defer func() { _ = result.Cleanup() }()
+	if err != nil {
+		return domain.BenchmarkResult{}, fmt.Errorf("benchmark model %s: %w", model.Name, err)
+	}
+	if transcribeEnd.IsZero() {
+		transcribeEnd = time.Now()
+	}
+
+	elapsed := transcribeEnd.Sub(transcribeStart).Seconds()
+	benchmark := domain.BenchmarkResult{
+		ModelID:         model.ID,
+		ModelPath:       model.LocalPath,
+		AudioSeconds:    benchmarkSampleSeconds,
+		ElapsedSeconds:  elapsed,
+		RealTimeFactor:  elapsed / benchmarkSampleSeconds,
+		PeakMemoryBytes: whisperLog.PeakMemoryBytes,
+		MeasuredAt:      time.Now().UTC(),
+	}
+
+	if a.Benchmarks != nil {
+		results, err := a.Benchmarks.Load()
+		if err != nil {
+			return benchmark, fmt.Errorf("load benchmarks: %w", err)
+		}
+		results[model.ID] = benchmark
+		if err := a.Benchmarks.Save(results); err != nil {
+			return benchmark, fmt.Errorf("save benchmarks: %w", err)
+		}
+	}
+	return benchmark, nil
+}
+
+// GetBenchmarks returns stored benchmark results ordered by model ID.
+func (a *App) GetBenchmarks() ([]domain.BenchmarkResult, error) {
+	if a.Benchmarks == nil {
+		return nil, nil
+	}
+	results, err := a.Benchmarks.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load benchmarks: %w", err)
+	}
+
+	out := make([]domain.BenchmarkResult, 0, len(results))
+	for _, result := range results {
+		out = append(out, result)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ModelID < out[j].ModelID })
+	return out, nil
+}
+
+// RecommendModel picks a model for this machine from benchmarks or detected CPU/GPU/RAM.
+func (a *App) RecommendModel() (domain.ModelRecommendation, error) {
+	settings, _ := a.loadSettingsForModelCatalog()
+
+	var benchmarks map[string]domain.BenchmarkResult
+	if a.Benchmarks != nil {
+		loaded, err := a.Benchmarks.Load()
+		if err != nil {
+			return domain.ModelRecommendation{}, fmt.Errorf("load benchmarks: %w", err)
+		}
+		benchmarks = loaded
+	}
+
+	recommendation := recommendModel(sysinfo.Detect(), settings.Language, benchmarks)
+	if model, found := a.lookupWhisperModel(recommendation.ModelID); found {
+		recommendation.Name = model.Name
+	}
+	return recommendation, nil
+}
+
+// recommendModel prefers the best benchmarked model running at least 2x real time,
+// falling back to a hardware heuristic when no benchmark qualifies.
+func recommendModel(info sysinfo.Info, language string, benchmarks map[string]domain.BenchmarkResult) domain.ModelRecommendation {
+	memory := info.AvailableMemoryBytes
+	if memory == 0 {
+		memory = info.TotalMemoryBytes
+	}
+
+	best := modelTier{}
+	var bestResult domain.BenchmarkResult
+	for _, tier := range modelTiers {
+		for _, id := range []string{tier.id, tier.id + ".en"} {
+			result, ok := benchmarks[id]
+			if !ok || result.RealTimeFactor <= 0 || result.RealTimeFactor > fastEnoughRealTimeFactor {
+				continue
+			}
+			if memory > 0 && tier.memoryBytes > memory {
+				continue
+			}
+			if tier.rank > best.rank {
+				best = tier
+				bestResult = result
+			}
+		}
+	}
+	if best.id != "" {
+		return domain.ModelRecommendation{
+			ModelID: bestResult.ModelID,
+			Reason:  fmt.Sprintf("Benchmarked at %.1fx real time on this machine.", 1/bestResult.RealTimeFactor),
+		}
+	}
+
+	const gb = uint64(1) << 30
+	var id, reason string
+	switch {
+	case info.HasGPU() && (memory == 0 || memory >= 8*gb):
+		id, reason = "large-v3-turbo", "GPU acceleration detected; large-v3 turbo runs fast with top accuracy."
+	case info.CPUs >= 8 && memory >= 8*gb:
+		id, reason = "small", fmt.Sprintf("%d CPU cores and enough memory for the small model without a GPU.", info.CPUs)
+	case memory == 0 || memory >= 4*gb:
+		id, reason = "base", "Balanced choice for CPU-only transcription."
+	default:
+		id, reason = "tiny", "Limited memory available; the tiny model is the safest choice."
+	}
+
+	if strings.EqualFold(strings.TrimSpace(language), "en") && id != "large-v3-turbo" {
+		id += ".en"
+		reason += " English-only variant matches the configured language."
+	}
+	return domain.ModelRecommendation{ModelID: id, Reason: reason}
+}
+
+// buildBenchmarkSampleArgs synthesizes a 30-second mono 16 kHz clip of tones over pink noise.
+func buildBenchmarkSampleArgs(outPath string) []string {
+	return []string{
+		"-hide_banner",
+		"-nostdin",
+		"-y",
+		"-f", "lavfi",
+		"-i", fmt.Sprintf("sine=frequency=220:duration=%d", benchmarkSampleSeconds),
+		"-f", "lavfi",
+		"-i", fmt.Sprintf("anoisesrc=color=pink:amplitude=0.05:duration=%d", benchmarkSampleSeconds),
+		"-filter_complex", "amix=inputs=2:duration=shortest",
+		"-ac", "1",
+		"-ar", "16000",
+		outPath,
+	}
+}
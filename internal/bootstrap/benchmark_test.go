@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
+)
+
+// TestRecommendModel covers benchmark-driven and hardware heuristic recommendations.
+func TestRecommendModel(t *testing.T) {
+	const gb = uint64(1) << 30
+	tests := []struct {
+		name       string
+		info       sysinfo.Info
+		language   string
+		benchmarks map[string]domain.BenchmarkResult
+		want       string
+	}{
+		{
+			name: "gpu",
+			info: sysinfo.Info{CPUs: 8, TotalMemoryBytes: 32 * gb, GPUs: []sysinfo.GPU{{Name: "RTX 3090"}}},
+			want: "large-v3-turbo",
+		},
+		{
+			name:     "cpu english",
+			info:     sysinfo.Info{CPUs: 12, TotalMemoryBytes: 16 * gb},
+			language: "en",
+			want:     "small.en",
+		},
+		{
+			name: "low memory",
+			info: sysinfo.Info{CPUs: 2, AvailableMemoryBytes: 2 * gb},
+			want: "tiny",
+		},
+		{
+			name: "benchmarks pick best fast model",
+			info: sysinfo.Info{CPUs: 4, TotalMemoryBytes: 16 * gb},
+			benchmarks: map[string]domain.BenchmarkResult{
+				"base":   {ModelID: "base", RealTimeFactor: 0.1},
+				"small":  {ModelID: "small", RealTimeFactor: 0.4},
+				"medium": {ModelID: "medium", RealTimeFactor: 1.2},
+			},
+			want: "small",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := recommendModel(tt.info, tt.language, tt.benchmarks)
+			if got.ModelID != tt.want {
+				t.Fatalf("model = %s, want %s (%s)", got.ModelID, tt.want, got.Reason)
+			}
+			if got.Reason == "" {
+				t.Fatal("expected recommendation reason")
+			}
+		})
+	}
+}
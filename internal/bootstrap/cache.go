@@ -0,0 +1,28 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// preprocessCache returns the preprocessed audio cache for a job, or nil when
+// it is disabled in settings.
+func (a *App) preprocessCache(settings domain.Settings) *transcribe.PreprocessCache {
+	if a.cacheDir == "" || settings.PreprocessCacheMB <= 0 {
+		return nil
+	}
+	return transcribe.NewPreprocessCache(a.cacheDir, int64(settings.PreprocessCacheMB)<<20)
+}
+
+// ClearPreprocessCache deletes every cached preprocessed WAV.
+func (a *App) ClearPreprocessCache() error {
+	if a.cacheDir == "" {
+		return nil
+	}
+	if err := transcribe.NewPreprocessCache(a.cacheDir, 0).Clear(); err != nil {
+		return fmt.Errorf("clear preprocess cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestPreprocessCacheFollowsSettings enables the cache only with a size limit
+// and clears it on request.
+func TestPreprocessCacheFollowsSettings(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "preprocess")
+	app := &App{cacheDir: dir}
+
+	if cache := app.preprocessCache(domain.Settings{}); cache != nil {
+		t.Fatal("cache should be disabled without a size limit")
+	}
+	if cache := app.preprocessCache(domain.Settings{PreprocessCacheMB: 512}); cache == nil {
+		t.Fatal("cache should be enabled with a size limit")
+	}
+
+	mustWrite(t, filepath.Join(dir, "abc.wav"), "wav")
+	if err := app.ClearPreprocessCache(); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("cache dir still exists: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"media-transcriber/internal/calendar"
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// calendarTimeout bounds probing the recording and loading the calendars.
+const calendarTimeout = 15 * time.Second
+
+// recording describes when an input was recorded and the meeting it belongs to.
+type recording struct {
+	start, end time.Time
+	meeting    *domain.Meeting
+}
+
+// describeRecording dates the input by its modification time (the end of the
+// recording) minus its duration, and matches it against the configured
+// calendars. Calendar errors are reported and never fail the job.
+func (a *App) describeRecording(ctx context.Context, jobID, inputPath string, settings domain.Settings) recording {
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return recording{}
+	}
+	rec := recording{start: info.ModTime(), end: info.ModTime()}
+	source := a.calendarSource(settings)
+	if !source.Enabled() {
+		return rec
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, calendarTimeout)
+	defer cancel()
+	if seconds, err := transcribe.ProbeDuration(ctx, transcribe.FFprobePath(settings.FFmpegPath), inputPath); err == nil {
+		rec.start = rec.end.Add(-time.Duration(seconds * float64(time.Second)))
+	}
+	events, err := source.Events(ctx, &http.Client{}, rec.start.Add(-24*time.Hour), rec.end.Add(time.Hour))
+	if err != nil {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeError, Message: fmt.Sprintf("calendar: %v", err)})
+		return rec
+	}
+	if meeting, ok := calendar.Match(events, rec.start, rec.end); ok {
+		rec.meeting = &meeting
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: fmt.Sprintf("Recorded during %q", meeting.Title)})
+	}
+	return rec
+}
+
+// calendarSource returns the calendars configured in settings.
+func (a *App) calendarSource(settings domain.Settings) calendar.Source {
+	return calendar.Source{
+		ICS:       settings.CalendarICS,
+		CalDAVURL: settings.CalDAVURL,
+		User:      settings.CalDAVUser,
+		Password:  a.secret(config.SecretCalDAVPassword),
+	}
+}
+
+// SetCalDAVPassword stores the password of the CalDAV account (or ICS feed).
+func (a *App) SetCalDAVPassword(password string) error {
+	return a.storeSecret(config.SecretCalDAVPassword, "CalDAV password", password)
+}
+
+// HasCalDAVPassword reports whether a CalDAV password is stored, without exposing it.
+func (a *App) HasCalDAVPassword() bool {
+	return a.secret(config.SecretCalDAVPassword) != ""
+}
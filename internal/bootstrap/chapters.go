@@ -0,0 +1,91 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/analyze"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/subtitle"
+	"media-transcriber/internal/textenc"
+	"media-transcriber/internal/transcribe"
+)
+
+// GenerateChapters detects topic shifts in a finished job's segments and
+// writes YouTube chapter text (<name>.chapters.txt), an FFMETADATA file for
+// muxing (<name>.ffmetadata.txt), and WebVTT chapters (<name>.chapters.vtt).
+func (a *App) GenerateChapters(jobID string) ([]analyze.Chapter, error) {
+	entry, err := a.findHistoryEntry(jobID)
+	if err != nil {
+		return nil, err
+	}
+	base, data, cues, err := readJobCues(entry)
+	if err != nil {
+		return nil, err
+	}
+	chapters := analyze.Chapters(cues)
+	if len(chapters) < 2 {
+		return nil, fmt.Errorf("no topic shifts found in %s", filepath.Base(entry.InputPath))
+	}
+
+	// The chapter files follow the encoding the segments were read in.
+	encoding, lineEnding := textenc.Detect(data)
+	outputs := map[string]string{
+		base + ".chapters.txt":   analyze.FormatYouTubeChapters(chapters),
+		base + ".ffmetadata.txt": analyze.FormatFFMetadata(chapters),
+		base + ".chapters.vtt":   analyze.FormatVTTChapters(chapters),
+	}
+	for path, content := range outputs {
+		if err := os.WriteFile(path, textenc.Encode(content, encoding, lineEnding), 0o644); err != nil {
+			return nil, fmt.Errorf("write %s: %w", filepath.Base(path), err)
+		}
+	}
+	a.publishEvent(jobs.Event{
+		JobID:    jobID,
+		Type:     jobs.EventTypeLog,
+		Message:  fmt.Sprintf("%d chapters exported", len(chapters)),
+		TextPath: base + ".chapters.txt",
+	})
+	return chapters, nil
+}
+
+// readJobCues reads the timed text of a finished job and returns it as cues,
+// with the raw bytes read and the base path, without extension, that files
+// derived from it are named after. Every job writes <name>.segments.json, so
+// that is read; entries from before it fall back to the exported subtitles,
+// preferring SRT.
+func readJobCues(entry domain.HistoryEntry) (string, []byte, []subtitle.Cue, error) {
+	if entry.SegmentsPath != "" {
+		data, err := os.ReadFile(entry.SegmentsPath)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("read segments: %w", err)
+		}
+		_, segments, err := transcribe.ParseSegments(data)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("parse %s: %w", filepath.Base(entry.SegmentsPath), err)
+		}
+		return strings.TrimSuffix(entry.SegmentsPath, transcribe.SegmentsSuffix), data, transcribe.SegmentCues(segments), nil
+	}
+
+	source := ""
+	for _, path := range entry.SubtitlePaths {
+		if source == "" || strings.EqualFold(filepath.Ext(path), ".srt") {
+			source = path
+		}
+	}
+	if source == "" {
+		return "", nil, nil, fmt.Errorf("job %s recorded no segments or subtitles", entry.ID)
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("read subtitles: %w", err)
+	}
+	file, err := subtitle.Parse(data)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("parse %s: %w", filepath.Base(source), err)
+	}
+	return strings.TrimSuffix(source, filepath.Ext(source)), data, file.Cues, nil
+}
@@ -0,0 +1,127 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/subtitle"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestGenerateChaptersWritesExports splits a two-topic job and writes all chapter files.
+func TestGenerateChaptersWritesExports(t *testing.T) {
+	root := t.TempDir()
+	var cues []subtitle.Cue
+	for at := int64(0); at < 720_000; at += 10_000 {
+		text := "Carbon pricing lowers emissions with a fair carbon tax."
+		if at >= 360_000 {
+			text = "The football team scored a late goal for football fans."
+		}
+		cues = append(cues, subtitle.Cue{StartMs: at, EndMs: at + 9_000, Text: text})
+	}
+	srtPath := filepath.Join(root, "talk.srt")
+	mustWrite(t, srtPath, subtitle.FormatSRT(cues))
+
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{
+		{ID: "job-1", InputPath: filepath.Join(root, "talk.mp3"), SubtitlePaths: []string{filepath.Join(root, "talk.vtt"), srtPath}},
+		{ID: "job-2", InputPath: filepath.Join(root, "mute.mp3")},
+	}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	app := &App{History: history, events: jobs.NewEventBus(10)}
+
+	chapters, err := app.GenerateChapters("job-1")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(chapters) != 2 || chapters[1].StartMs != 360_000 {
+		t.Fatalf("chapters = %+v", chapters)
+	}
+	for _, name := range []string{"talk.chapters.txt", "talk.ffmetadata.txt", "talk.chapters.vtt"} {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if !strings.Contains(string(data), "Carbon") {
+			t.Fatalf("%s missing first chapter title:\n%s", name, data)
+		}
+	}
+
+	if _, err := app.GenerateChapters("job-2"); err == nil {
+		t.Fatal("expected error for job without subtitles")
+	}
+	if _, err := app.GenerateChapters("missing"); err == nil {
+		t.Fatal("expected error for unknown job")
+	}
+}
+
+// TestGenerateChaptersOnDefaultJob reads the segments of a job run with
+// default settings, which export no subtitles.
+func TestGenerateChaptersOnDefaultJob(t *testing.T) {
+	root := t.TempDir()
+	settings := config.DefaultSettings()
+	settings.OutputDir = root
+	app := &App{
+		Store:   &fakeStore{settings: settings},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			if req.Subtitles {
+				t.Error("default settings asked for subtitles")
+			}
+			for _, stage := range []string{"preprocessing", "transcribing", "exporting"} {
+				req.OnStage(stage)
+			}
+			var segments []transcribe.TranscriptSegment
+			for at := int64(0); at < 720_000; at += 10_000 {
+				text := "Carbon pricing lowers emissions with a fair carbon tax."
+				if at >= 360_000 {
+					text = "The football team scored a late goal for football fans."
+				}
+				segments = append(segments, transcribe.TranscriptSegment{StartMs: at, EndMs: at + 9_000, Text: text})
+			}
+			data, err := json.Marshal(map[string]any{"segments": segments})
+			if err != nil {
+				return transcribe.Result{}, err
+			}
+			textPath := filepath.Join(root, "talk.txt")
+			segmentsPath := filepath.Join(root, "talk"+transcribe.SegmentsSuffix)
+			if err := os.WriteFile(segmentsPath, data, 0o644); err != nil {
+				return transcribe.Result{}, err
+			}
+			return transcribe.Result{TextPath: textPath, SegmentsPath: segmentsPath}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	input := filepath.Join(root, "talk.mp3")
+	mustWrite(t, input, "audio")
+	job, err := app.StartTranscription(input)
+	if err != nil {
+		t.Fatalf("start job: %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+	waitFor(t, func() bool {
+		_, err := app.findHistoryEntry(job.ID)
+		return err == nil
+	})
+
+	chapters, err := app.GenerateChapters(job.ID)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(chapters) != 2 || chapters[1].StartMs != 360_000 {
+		t.Fatalf("chapters = %+v", chapters)
+	}
+	if _, err := os.Stat(filepath.Join(root, "talk.chapters.txt")); err != nil {
+		t.Fatalf("chapters file: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/subtitle"
+)
+
+// clipExcerptWords is how many words of a segment go into its clip name.
+const clipExcerptWords = 8
+
+// clipExcerptRunes caps the excerpt in clip names to keep paths short.
+const clipExcerptRunes = 60
+
+// clipTimeout bounds one ffmpeg cut, so an input that hangs ffmpeg (a stalled
+// network share, a broken container) cannot block the export forever.
+const clipTimeout = 2 * time.Minute
+
+// ExportSegmentsAudio cuts the audio of chosen segments of a finished job out
+// of its input into WAV clips named with their time and a text excerpt, in a
+// <name>.clips folder next to the transcript. selection holds zero-based
+// segment (subtitle cue) indexes; an empty selection exports every segment.
+// It returns the clip paths in segment order.
+func (a *App) ExportSegmentsAudio(jobID string, selection []int) ([]string, error) {
+	entry, err := a.findHistoryEntry(jobID)
+	if err != nil {
+		return nil, err
+	}
+	base, _, segments, err := readJobCues(entry)
+	if err != nil {
+		return nil, err
+	}
+	cues, indexes, err := selectCues(segments, selection)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(entry.InputPath); err != nil {
+		return nil, fmt.Errorf("cannot access input: %w", err)
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load settings: %w", err)
+	}
+
+	dir := base + ".clips"
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create clip folder: %w", err)
+	}
+	paths := make([]string, 0, len(cues))
+	for i, cue := range cues {
+		outPath := filepath.Join(dir, clipName(indexes[i], cue))
+		if err := cutClip(settings.FFmpegPath, entry.InputPath, outPath, cue); err != nil {
+			return paths, err
+		}
+		paths = append(paths, outPath)
+	}
+	a.publishEvent(jobs.Event{
+		JobID:   jobID,
+		Type:    jobs.EventTypeLog,
+		Message: fmt.Sprintf("%d audio clips exported to %s", len(paths), dir),
+	})
+	return paths, nil
+}
+
+// selectCues returns the cues at the selected indexes in timeline order,
+// skipping repeats, with their indexes; no selection returns every cue.
+func selectCues(cues []subtitle.Cue, selection []int) ([]subtitle.Cue, []int, error) {
+	if len(cues) == 0 {
+		return nil, nil, fmt.Errorf("no segments to export")
+	}
+	chosen := make([]bool, len(cues))
+	for _, index := range selection {
+		if index < 0 || index >= len(cues) {
+			return nil, nil, fmt.Errorf("segment %d is out of range (0-%d)", index, len(cues)-1)
+		}
+		chosen[index] = true
+	}
+	var selected []subtitle.Cue
+	var indexes []int
+	for i, cue := range cues {
+		if len(selection) == 0 || chosen[i] {
+			selected = append(selected, cue)
+			indexes = append(indexes, i)
+		}
+	}
+	return selected, indexes, nil
+}
+
+// clipName names a clip by segment number, start time, and the first words of
+// its text, e.g. "003 00-01-12.500 We moved to Kubernetes.wav", so clips sort
+// in timeline order and can be found by what is said.
+func clipName(index int, cue subtitle.Cue) string {
+	words := strings.Fields(cue.Text)
+	if len(words) > clipExcerptWords {
+		words = words[:clipExcerptWords]
+	}
+	excerpt := []rune(sanitizeFileName(strings.Join(words, " ")))
+	if len(excerpt) > clipExcerptRunes {
+		excerpt = excerpt[:clipExcerptRunes]
+	}
+	ms := cue.StartMs
+	name := fmt.Sprintf("%03d %02d-%02d-%02d.%03d", index+1, ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+	if text := strings.TrimRight(string(excerpt), " .-_"); text != "" {
+		name += " " + text
+	}
+	return name + ".wav"
+}
+
+// cutClip writes the audio of cue in inputPath to outPath.
+func cutClip(ffmpegPath, inputPath, outPath string, cue subtitle.Cue) error {
+	ffmpegPath = strings.TrimSpace(ffmpegPath)
+	if ffmpegPath == "" {
+		ffmpegPath = "ffmpeg"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clipTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, ffmpegPath, clipArgs(inputPath, outPath, cue.StartMs, cue.EndMs)...).CombinedOutput()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("ffmpeg did not finish within %s", clipTimeout)
+	}
+	if err != nil {
+		return fmt.Errorf("ffmpeg: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// clipArgs builds the ffmpeg command that cuts startMs-endMs of inputPath
+// into a WAV at the input's own sample rate and channels. Seeking before -i
+// is fast and, for audio, exact.
+func clipArgs(inputPath, outPath string, startMs, endMs int64) []string {
+	return []string{
+		"-hide_banner", "-nostdin", "-y", "-v", "error",
+		"-ss", formatClipSeconds(startMs),
+		"-i", inputPath,
+		"-t", formatClipSeconds(max(endMs-startMs, 1)),
+		"-vn", "-c:a", "pcm_s16le",
+		outPath,
+	}
+}
+
+// formatClipSeconds renders milliseconds as the seconds ffmpeg expects.
+func formatClipSeconds(ms int64) string {
+	return strconv.FormatFloat(float64(ms)/1000, 'f', 3, 64)
+}
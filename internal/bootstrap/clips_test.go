@@ -0,0 +1,105 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/subtitle"
+)
+
+// TestClipName numbers clips, stamps their start, and keeps a file-safe excerpt.
+func TestClipName(t *testing.T) {
+	tests := []struct {
+		index int
+		cue   subtitle.Cue
+		want  string
+	}{
+		{index: 2, cue: subtitle.Cue{StartMs: 72500, Text: "We moved to Kubernetes."}, want: "003 00-01-12.500 We moved to Kubernetes.wav"},
+		{index: 0, cue: subtitle.Cue{StartMs: 3723004, Text: "Is it 50/50?\nMaybe: yes"}, want: "001 01-02-03.004 Is it 50-50- Maybe- yes.wav"},
+		{index: 9, cue: subtitle.Cue{Text: "one two three four five six seven eight nine ten"}, want: "010 00-00-00.000 one two three four five six seven eight.wav"},
+		{index: 0, cue: subtitle.Cue{Text: "..."}, want: "001 00-00-00.000.wav"},
+	}
+	for _, tt := range tests {
+		if got := clipName(tt.index, tt.cue); got != tt.want {
+			t.Errorf("clipName(%d, %q) = %q, want %q", tt.index, tt.cue.Text, got, tt.want)
+		}
+	}
+}
+
+// TestClipArgs seeks to the segment start and cuts its duration to WAV.
+func TestClipArgs(t *testing.T) {
+	args := clipArgs("/in/talk.mp4", "/out/clip.wav", 72500, 75250)
+	if mergeArgValue(args, "-ss") != "72.500" || mergeArgValue(args, "-t") != "2.750" || mergeArgValue(args, "-i") != "/in/talk.mp4" {
+		t.Fatalf("args = %v", args)
+	}
+	if joined := strings.Join(args, " "); !strings.HasSuffix(joined, "-vn -c:a pcm_s16le /out/clip.wav") {
+		t.Fatalf("args = %v", args)
+	}
+}
+
+// TestSelectCues keeps the selected cues in timeline order and rejects
+// unknown indexes.
+func TestSelectCues(t *testing.T) {
+	cues := []subtitle.Cue{{Text: "a"}, {Text: "b"}, {Text: "c"}}
+	tests := []struct {
+		name      string
+		selection []int
+		want      []int
+		wantErr   bool
+	}{
+		{name: "all", want: []int{0, 1, 2}},
+		{name: "chosen", selection: []int{2, 0, 2}, want: []int{0, 2}},
+		{name: "out of range", selection: []int{3}, wantErr: true},
+		{name: "negative", selection: []int{-1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, indexes, err := selectCues(cues, tt.selection)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (!reflect.DeepEqual(indexes, tt.want) || len(selected) != len(tt.want)) {
+				t.Fatalf("indexes = %v, want %v", indexes, tt.want)
+			}
+		})
+	}
+}
+
+// TestExportSegmentsAudioValidatesJob fails for jobs without subtitles,
+// missing inputs, and bad selections before running ffmpeg.
+func TestExportSegmentsAudioValidatesJob(t *testing.T) {
+	root := t.TempDir()
+	input := filepath.Join(root, "talk.mp3")
+	srt := filepath.Join(root, "talk.srt")
+	mustWrite(t, input, "audio")
+	mustWrite(t, srt, "1\n00:00:00,500 --> 00:00:02,000\nHello.\n")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{
+		{ID: "done", InputPath: input, SubtitlePaths: []string{srt}},
+		{ID: "no-subtitles", InputPath: input},
+		{ID: "gone", InputPath: filepath.Join(root, "gone.mp3"), SubtitlePaths: []string{srt}},
+	}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	app := &App{Store: &fakeStore{}, History: history}
+
+	tests := []struct {
+		jobID     string
+		selection []int
+		want      string
+	}{
+		{jobID: "missing", want: "not found"},
+		{jobID: "no-subtitles", want: "no segments or subtitles"},
+		{jobID: "done", selection: []int{1}, want: "out of range"},
+		{jobID: "gone", want: "cannot access input"},
+	}
+	for _, tt := range tests {
+		if _, err := app.ExportSegmentsAudio(tt.jobID, tt.selection); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ExportSegmentsAudio(%s) err = %v, want %q", tt.jobID, err, tt.want)
+		}
+	}
+}
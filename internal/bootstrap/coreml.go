@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/modelfile"
+)
+
+// coreMLSupported reports whether whisper.cpp can use CoreML encoders on this OS.
+func coreMLSupported() bool {
+	return goruntime.GOOS == "darwin"
+}
+
+// downloadCoreMLEncoder fetches and unpacks the model's CoreML encoder beside modelPath.
+// It is a no-op off macOS, for models without an encoder, or when the encoder already exists.
+func downloadCoreMLEncoder(model domain.WhisperModelOption, modelPath string) error {
+	if !coreMLSupported() || strings.TrimSpace(model.CoreMLURL) == "" {
+		return nil
+	}
+
+	encoderDir := modelfile.CoreMLEncoderPath(modelPath)
+	if info, err := os.Stat(encoderDir); err == nil && info.IsDir() {
+		return nil
+	}
+
+	zipPath := encoderDir + ".zip"
+	if err := downloadURLToFile(zipPath, model.CoreMLURL, modelDownloadTimeout); err != nil {
+		return fmt.Errorf("download CoreML encoder: %w", err)
+	}
+	defer os.Remove(zipPath)
+
+	if _, err := extractZip(zipPath, filepath.Dir(modelPath)); err != nil {
+		return fmt.Errorf("extract CoreML encoder: %w", err)
+	}
+	if info, err := os.Stat(encoderDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("CoreML archive did not contain %s", filepath.Base(encoderDir))
+	}
+	return nil
+}
+
+// markCoreMLReady flags downloaded models whose CoreML encoder sits beside the model file.
+func markCoreMLReady(models []domain.WhisperModelOption) {
+	if !coreMLSupported() {
+		return
+	}
+	for i := range models {
+		if !models[i].Downloaded {
+			continue
+		}
+		info, err := os.Stat(modelfile.CoreMLEncoderPath(models[i].LocalPath))
+		models[i].CoreMLReady = err == nil && info.IsDir()
+	}
+}
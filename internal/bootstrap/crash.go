@@ -0,0 +1,160 @@
+package bootstrap
+
+import (
+	"fmt"
+	goruntime "runtime"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"media-transcriber/internal/crash"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/telemetry"
+)
+
+// crashEventCount is how many recent events a crash report keeps.
+const crashEventCount = 50
+
+// guard runs fn and turns a panic into a crash report instead of letting it
+// take the whole app down.
+func (a *App) guard(where string, fn func()) {
+	defer a.recoverPanic(where)
+	fn()
+}
+
+// recoverPanic reports a panic in where; it must be deferred directly.
+func (a *App) recoverPanic(where string) {
+	if value := recover(); value != nil {
+		a.reportCrash(where, "", value, debug.Stack())
+	}
+}
+
+// recoverJobPanic fails the running job when its runner panics, so the queue
+// moves on and the UI sees a failed job instead of a vanished app. It must be
+// deferred directly in runTranscriptionJob.
+func (a *App) recoverJobPanic(jobID, inputPath string, settings domain.Settings, opts jobOptions) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	a.reportCrash("job", jobID, value, debug.Stack())
+
+	_ = a.Jobs.Transition(domain.JobStatusFailed)
+	a.publishStatus(jobID, domain.JobStatusFailed, "Job failed")
+	entry := a.historyEntry(jobID, inputPath, settings, domain.JobStatusFailed, opts)
+	entry.Error = fmt.Sprintf("internal error: %v", value)
+	a.recordHistory(entry)
+	a.clearActiveJob(jobID)
+}
+
+// reportCrash writes a crash report with the recent events and a settings
+// summary, then tells the UI where it is. It returns the report path, or ""
+// when it could not be written.
+func (a *App) reportCrash(where, jobID string, value any, stack []byte) string {
+	a.mu.Lock()
+	settings := a.Settings
+	a.mu.Unlock()
+
+	report := &crash.Report{
+		Time:     time.Now(),
+		Version:  Version,
+		OS:       goruntime.GOOS,
+		Arch:     goruntime.GOARCH,
+		Where:    where,
+		JobID:    jobID,
+		Panic:    fmt.Sprint(value),
+		Stack:    string(stack),
+		Settings: settingsSummary(settings),
+	}
+	if a.events != nil {
+		events := a.events.Since(0)
+		if len(events) > crashEventCount {
+			events = events[len(events)-crashEventCount:]
+		}
+		report.Events = events
+	}
+
+	var path string
+	if a.crashDir != "" {
+		path, _ = crash.Write(a.crashDir, report)
+	}
+	message := fmt.Sprintf("Internal error in %s: %v", where, value)
+	if path != "" {
+		message = fmt.Sprintf("Internal error in %s, crash report saved to %s", where, path)
+	}
+	if a.events != nil {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeCrash, Message: message, TextPath: path})
+	}
+	return path
+}
+
+// ListCrashReports returns saved crash reports, newest first.
+func (a *App) ListCrashReports() ([]crash.Report, error) {
+	reports, err := crash.List(a.crashDir)
+	if err != nil {
+		return nil, fmt.Errorf("list crash reports: %w", err)
+	}
+	return reports, nil
+}
+
+// ReportProblem opens a prefilled issue for the crash report with id and
+// reveals the report file so the user can review and attach it. It returns
+// the issue URL.
+func (a *App) ReportProblem(id string) (string, error) {
+	report, err := crash.Read(a.crashDir, id)
+	if err != nil {
+		return "", fmt.Errorf("read crash report: %w", err)
+	}
+	issueURL := crash.IssueURL(report)
+
+	a.mu.Lock()
+	ctx := a.runtimeCtx
+	a.mu.Unlock()
+	if ctx != nil {
+		wailsruntime.BrowserOpenURL(ctx, issueURL)
+		_ = revealInFileManager(crash.Path(a.crashDir, id))
+	}
+	return issueURL, nil
+}
+
+// settingsSummary describes settings for a crash report without paths, URLs,
+// names, or credentials: enums and numbers as set, integrations as on/off.
+func settingsSummary(settings domain.Settings) map[string]string {
+	onOff := func(on bool) string {
+		if on {
+			return "on"
+		}
+		return "off"
+	}
+	return map[string]string{
+		"modelClass":          telemetry.ModelClass(settings.ModelPath),
+		"language":            settings.Language,
+		"profile":             onOff(settings.ActiveProfile != ""),
+		"customFFmpeg":        onOff(settings.FFmpegPath != ""),
+		"customWhisper":       onOff(settings.WhisperPath != ""),
+		"preprocessTimeout":   strconv.Itoa(settings.PreprocessTimeoutMinutes),
+		"transcribeTimeout":   strconv.Itoa(settings.TranscribeTimeoutMinutes),
+		"jobTimeout":          strconv.Itoa(settings.JobTimeoutMinutes),
+		"processPriority":     string(settings.ProcessPriority),
+		"memoryGuard":         string(settings.MemoryGuard),
+		"ffmpegHWAccel":       string(settings.FFmpegHWAccel),
+		"keepIntermediates":   onOff(settings.KeepIntermediates),
+		"preprocessCacheMB":   strconv.Itoa(settings.PreprocessCacheMB),
+		"translationProvider": settings.TranslationProvider,
+		"tagging":             string(settings.Tagging),
+		"remoteWorkers":       onOff(settings.RemoteWorkers != ""),
+		"webdav":              onOff(settings.WebDAVURL != ""),
+		"obsidian":            onOff(settings.ObsidianFolder != ""),
+		"notion":              onOff(settings.NotionParentPageID != ""),
+		"chatNotifications":   onOff(settings.SlackWebhookURL != "" || settings.DiscordWebhookURL != ""),
+		"email":               onOff(settings.SMTPHost != ""),
+		"calendar":            onOff(settings.CalendarICS != "" || settings.CalDAVURL != ""),
+		"textEncoding":        string(settings.TextEncoding),
+		"lineEnding":          string(settings.LineEnding),
+		"locale":              string(settings.Locale),
+		"splitChapters":       onOff(settings.SplitChapters),
+	}
+}
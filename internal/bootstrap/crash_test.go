@@ -0,0 +1,70 @@
+package bootstrap
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestJobPanicWritesCrashReport fails a job whose runner panics, saves a crash
+// report with the recent events, and keeps the app usable.
+func TestJobPanicWritesCrashReport(t *testing.T) {
+	root := t.TempDir()
+	app := &App{
+		Store:    &fakeStore{settings: domain.Settings{ModelPath: "/models/ggml-base.bin", OutputDir: filepath.Join(root, "out")}},
+		History:  config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:     jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(context.Context, transcribe.Request) (transcribe.Result, error) { panic("boom") }},
+		events:   jobs.NewEventBus(100),
+		crashDir: filepath.Join(root, "crashes"),
+	}
+
+	if _, err := app.StartTranscription(filepath.Join(root, "clip.mp4")); err != nil {
+		t.Fatalf("start job: %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusFailed)
+	waitFor(t, func() bool {
+		entries, _ := app.History.Load()
+		return len(entries) == 1
+	})
+
+	reports, err := app.ListCrashReports()
+	if err != nil || len(reports) != 1 {
+		t.Fatalf("reports = %+v, err = %v", reports, err)
+	}
+	report := reports[0]
+	if report.Where != "job" || report.Panic != "boom" || !strings.Contains(report.Stack, "runTranscriptionJob") {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.Settings["modelClass"] != "base" || len(report.Events) == 0 {
+		t.Fatalf("settings = %v, events = %d", report.Settings, len(report.Events))
+	}
+	entries, _ := app.History.Load()
+	if entries[0].Status != domain.JobStatusFailed || entries[0].Error != "internal error: boom" {
+		t.Fatalf("history = %+v", entries[0])
+	}
+	assertEventTypeExists(t, app.JobEvents(0, jobs.EventFilter{}), jobs.EventTypeCrash)
+
+	issueURL, err := app.ReportProblem(report.ID)
+	if err != nil || !strings.Contains(issueURL, "Crash+in+job") {
+		t.Fatalf("issue url = %q, err = %v", issueURL, err)
+	}
+}
+
+// TestGuardRecoversBackgroundPanic keeps a background task's panic from
+// crashing the process.
+func TestGuardRecoversBackgroundPanic(t *testing.T) {
+	app := &App{events: jobs.NewEventBus(10), crashDir: t.TempDir()}
+	app.guard("retention", func() { panic("nil map") })
+
+	reports, err := app.ListCrashReports()
+	if err != nil || len(reports) != 1 || reports[0].Where != "retention" {
+		t.Fatalf("reports = %+v, err = %v", reports, err)
+	}
+}
@@ -0,0 +1,163 @@
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"media-transcriber/internal/domain"
+)
+
+var sha256Pattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// AddCustomModel registers a user-supplied model so it can be downloaded like built-in presets.
+func (a *App) AddCustomModel(name, rawURL, fileName, checksum string) (domain.WhisperModelOption, error) {
+	if a.ModelStore == nil {
+		return domain.WhisperModelOption{}, fmt.Errorf("custom model store is not configured")
+	}
+
+	model, err := buildCustomModel(name, rawURL, fileName, checksum)
+	if err != nil {
+		return domain.WhisperModelOption{}, err
+	}
+
+	custom, err := a.ModelStore.Load()
+	if err != nil {
+		return domain.WhisperModelOption{}, fmt.Errorf("load custom models: %w", err)
+	}
+
+	for _, existing := range append(append([]domain.WhisperModelOption{}, whisperModelCatalog...), custom...) {
+		if existing.ID == model.ID {
+			return domain.WhisperModelOption{}, fmt.Errorf("model id already exists: %s", model.ID)
+		}
+		if strings.EqualFold(existing.FileName, model.FileName) {
+			return domain.WhisperModelOption{}, fmt.Errorf("model file name already used by %s", existing.Name)
+		}
+	}
+
+	custom = append(custom, model)
+	if err := a.ModelStore.Save(custom); err != nil {
+		return domain.WhisperModelOption{}, fmt.Errorf("save custom models: %w", err)
+	}
+	return model, nil
+}
+
+// RemoveCustomModel deletes a user-defined catalog entry; downloaded files are kept.
+func (a *App) RemoveCustomModel(modelID string) error {
+	if a.ModelStore == nil {
+		return fmt.Errorf("custom model store is not configured")
+	}
+
+	id := strings.TrimSpace(modelID)
+	custom, err := a.ModelStore.Load()
+	if err != nil {
+		return fmt.Errorf("load custom models: %w", err)
+	}
+
+	kept := make([]domain.WhisperModelOption, 0, len(custom))
+	for _, model := range custom {
+		if model.ID != id {
+			kept = append(kept, model)
+		}
+	}
+	if len(kept) == len(custom) {
+		return fmt.Errorf("unknown custom model id: %s", id)
+	}
+
+	if err := a.ModelStore.Save(kept); err != nil {
+		return fmt.Errorf("save custom models: %w", err)
+	}
+	return nil
+}
+
+// modelCatalog returns built-in presets followed by user-defined entries.
+func (a *App) modelCatalog() []domain.WhisperModelOption {
+	models := make([]domain.WhisperModelOption, len(whisperModelCatalog))
+	copy(models, whisperModelCatalog)
+
+	if a.ModelStore == nil {
+		return models
+	}
+	custom, err := a.ModelStore.Load()
+	if err != nil {
+		return models
+	}
+	return append(models, custom...)
+}
+
+// lookupWhisperModel finds a built-in or custom model by ID.
+func (a *App) lookupWhisperModel(id string) (domain.WhisperModelOption, bool) {
+	for _, model := range a.modelCatalog() {
+		if model.ID == id {
+			return model, true
+		}
+	}
+	return domain.WhisperModelOption{}, false
+}
+
+// buildCustomModel validates user input and derives a stable model ID.
+func buildCustomModel(name, rawURL, fileName, checksum string) (domain.WhisperModelOption, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.WhisperModelOption{}, fmt.Errorf("model name is required")
+	}
+
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return domain.WhisperModelOption{}, fmt.Errorf("model URL must be an http(s) URL: %s", rawURL)
+	}
+
+	fileName = strings.TrimSpace(fileName)
+	if fileName == "" {
+		fileName = filepath.Base(parsed.Path)
+	}
+	if fileName != filepath.Base(fileName) || strings.ContainsAny(fileName, `/\`) {
+		return domain.WhisperModelOption{}, fmt.Errorf("model file name must not contain directories: %s", fileName)
+	}
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext != ".bin" && ext != ".gguf" {
+		return domain.WhisperModelOption{}, fmt.Errorf("model file name must end with .bin or .gguf: %s", fileName)
+	}
+
+	checksum = strings.ToLower(strings.TrimSpace(checksum))
+	if checksum != "" && !sha256Pattern.MatchString(checksum) {
+		return domain.WhisperModelOption{}, fmt.Errorf("sha256 must be 64 hex characters")
+	}
+
+	return domain.WhisperModelOption{
+		ID:          "custom-" + strings.TrimSuffix(strings.ToLower(fileName), ext),
+		Name:        name,
+		FileName:    fileName,
+		URL:         rawURL,
+		Description: "User-defined model.",
+		SHA256:      checksum,
+		Custom:      true,
+	}, nil
+}
+
+// verifyFileSHA256 compares a file digest against the expected hex checksum.
+func verifyFileSHA256(path, expected string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("sha256 mismatch: got %s, want %s", actual, expected)
+	}
+	return nil
+}
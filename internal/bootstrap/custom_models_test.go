@@ -0,0 +1,94 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// fakeModelStore keeps custom model entries in memory.
+type fakeModelStore struct {
+	models []domain.WhisperModelOption
+}
+
+// Load returns stored entries.
+func (s *fakeModelStore) Load() ([]domain.WhisperModelOption, error) {
+	return append([]domain.WhisperModelOption(nil), s.models...), nil
+}
+
+// Save replaces stored entries.
+func (s *fakeModelStore) Save(models []domain.WhisperModelOption) error {
+	s.models = append([]domain.WhisperModelOption(nil), models...)
+	return nil
+}
+
+// TestAddCustomModelPersistsAndJoinsCatalog checks custom entries become downloadable models.
+func TestAddCustomModelPersistsAndJoinsCatalog(t *testing.T) {
+	app := &App{ModelStore: &fakeModelStore{}}
+
+	model, err := app.AddCustomModel("My fine-tune", "https://example.com/models/ggml-finetune.gguf", "", "")
+	if err != nil {
+		t.Fatalf("add custom model: %v", err)
+	}
+	if model.ID != "custom-ggml-finetune" || model.FileName != "ggml-finetune.gguf" {
+		t.Fatalf("unexpected model: %+v", model)
+	}
+
+	found, ok := app.lookupWhisperModel(model.ID)
+	if !ok || !found.Custom {
+		t.Fatalf("custom model not found in catalog: %+v", found)
+	}
+
+	if _, err := app.AddCustomModel("Duplicate", "https://example.com/ggml-finetune.gguf", "", ""); err == nil {
+		t.Fatal("expected duplicate file name error")
+	}
+
+	if err := app.RemoveCustomModel(model.ID); err != nil {
+		t.Fatalf("remove custom model: %v", err)
+	}
+	if _, ok := app.lookupWhisperModel(model.ID); ok {
+		t.Fatal("expected custom model to be removed")
+	}
+}
+
+// TestBuildCustomModelValidation rejects invalid input.
+func TestBuildCustomModelValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    string
+		url      string
+		fileName string
+		checksum string
+	}{
+		{name: "missing name", url: "https://example.com/m.bin"},
+		{name: "bad scheme", model: "m", url: "ftp://example.com/m.bin"},
+		{name: "bad extension", model: "m", url: "https://example.com/m.zip"},
+		{name: "path in file name", model: "m", url: "https://example.com/m.bin", fileName: "../m.bin"},
+		{name: "bad checksum", model: "m", url: "https://example.com/m.bin", checksum: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildCustomModel(tt.model, tt.url, tt.fileName, tt.checksum); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+}
+
+// TestVerifyFileSHA256 checks digest comparison.
+func TestVerifyFileSHA256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.bin")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if err := verifyFileSHA256(path, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := verifyFileSHA256(path, "0000000000000000000000000000000000000000000000000000000000000000"); err == nil {
+		t.Fatal("expected checksum mismatch")
+	}
+}
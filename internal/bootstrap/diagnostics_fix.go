@@ -1,9 +1,10 @@
 package bootstrap
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -17,6 +18,8 @@ import (
 
 	"media-transcriber/internal/config"
 	"media-transcriber/internal/domain"
+	"media-transcriber/internal/downloads"
+	"media-transcriber/internal/jobs"
 )
 
 const (
@@ -38,6 +41,17 @@ type modelDownloadPlan struct {
 	settingsPath string
 }
 
+var errUnsupportedDiagnosticFix = errors.New("unsupported diagnostic item id")
+
+// diagnosticFixOrder lists fixable items in dependency order: tools, then model, then output dir.
+var diagnosticFixOrder = []string{
+	"tool_ffmpeg",
+	"tool_ffprobe",
+	"tool_whisper.cpp",
+	"model_path",
+	"output_dir",
+}
+
 // InstallOrFixDiagnostic applies an OS-specific remediation for one failed diagnostic item.
 func (a *App) InstallOrFixDiagnostic(itemID string) (domain.DiagnosticReport, error) {
 	if a.Store == nil {
@@ -55,20 +69,9 @@ func (a *App) InstallOrFixDiagnostic(itemID string) (domain.DiagnosticReport, er
 	}
 	settings = normalizeSettings(settings)
 
-	settingsChanged := false
-	var fixErr error
-
-	switch id {
-	case "tool_ffmpeg", "tool_ffprobe":
-		fixErr = installFFmpegForCurrentOS()
-	case "tool_whisper.cpp":
-		fixErr = installWhisperForCurrentOS()
-	case "model_path":
-		settings, settingsChanged, fixErr = installOrFixModelPath(settings)
-	case "output_dir":
-		settings, settingsChanged, fixErr = installOrFixOutputDir(settings)
-	default:
-		return domain.DiagnosticReport{}, fmt.Errorf("unsupported diagnostic item id: %s", id)
+	settings, settingsChanged, fixErr := a.applyDiagnosticFix(id, settings, a.fixReporter(id))
+	if errors.Is(fixErr, errUnsupportedDiagnosticFix) {
+		return domain.DiagnosticReport{}, fixErr
 	}
 
 	if settingsChanged {
@@ -85,6 +88,124 @@ func (a *App) InstallOrFixDiagnostic(itemID string) (domain.DiagnosticReport, er
 	return report, nil
 }
 
+// InstallOrFixAll applies remediations for every failed diagnostic item in dependency order.
+func (a *App) InstallOrFixAll() (domain.DiagnosticReport, error) {
+	if a.Store == nil {
+		return domain.DiagnosticReport{}, fmt.Errorf("settings store is not configured")
+	}
+
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.DiagnosticReport{}, fmt.Errorf("load settings: %w", err)
+	}
+	settings = normalizeSettings(settings)
+
+	report := a.refreshDiagnosticsFromSettings(settings)
+	failed := failedDiagnosticIDs(report)
+	if len(failed) == 0 {
+		return report, nil
+	}
+
+	fixErrors := make([]error, 0, len(failed))
+	attempted := map[string]bool{}
+	for i, id := range failed {
+		a.publishFixProgress(id, fmt.Sprintf("Fixing %s (%d/%d)", id, i+1, len(failed)), "")
+
+		// ffmpeg and ffprobe share one package, so install it once.
+		if id == "tool_ffprobe" && attempted["tool_ffmpeg"] {
+			a.publishFixProgress(id, "Handled together with ffmpeg", "")
+			continue
+		}
+		attempted[id] = true
+
+		var changed bool
+		var fixErr error
+		settings, changed, fixErr = a.applyDiagnosticFix(id, settings, a.fixReporter(id))
+		if changed {
+			if saveErr := a.Store.Save(settings); saveErr != nil {
+				fixErr = errors.Join(fixErr, fmt.Errorf("save settings after fix: %w", saveErr))
+			}
+		}
+
+		if fixErr != nil {
+			fixErrors = append(fixErrors, fmt.Errorf("%s: %w", id, fixErr))
+			a.publishFixProgress(id, "Fix failed", fixErr.Error())
+			continue
+		}
+		a.publishFixProgress(id, "Fix applied", "")
+	}
+
+	report = a.refreshDiagnosticsFromSettings(settings)
+	return report, errors.Join(fixErrors...)
+}
+
+// applyDiagnosticFix runs the remediation for one diagnostic item ID.
+func (a *App) applyDiagnosticFix(id string, settings domain.Settings, reporter installReporter) (domain.Settings, bool, error) {
+	switch id {
+	case "tool_ffmpeg", "tool_ffprobe":
+		return settings, false, installFFmpegForCurrentOS(reporter)
+	case "tool_whisper.cpp":
+		return settings, false, installWhisperForCurrentOS(reporter, a.githubToken())
+	case "model_path":
+		return installOrFixModelPath(settings)
+	case "output_dir":
+		return installOrFixOutputDir(settings)
+	default:
+		return settings, false, fmt.Errorf("%w: %s", errUnsupportedDiagnosticFix, id)
+	}
+}
+
+// failedDiagnosticIDs returns failed fixable item IDs in remediation order.
+func failedDiagnosticIDs(report domain.DiagnosticReport) []string {
+	failed := map[string]bool{}
+	for _, item := range report.Items {
+		if item.Status == domain.DiagnosticStatusFail {
+			failed[item.ID] = true
+		}
+	}
+
+	ids := make([]string, 0, len(failed))
+	for _, id := range diagnosticFixOrder {
+		if failed[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// publishFixProgress emits one remediation progress event.
+func (a *App) publishFixProgress(diagnosticID, message, errText string) {
+	if a.events == nil {
+		return
+	}
+	a.publishEvent(jobs.Event{
+		Type:         jobs.EventTypeRemediation,
+		DiagnosticID: diagnosticID,
+		Message:      message,
+		Stderr:       errText,
+	})
+}
+
+// fixReporter publishes the output of one fix's commands as remediation
+// events and announces authorization prompts.
+func (a *App) fixReporter(diagnosticID string) installReporter {
+	return installReporter{
+		output: func(line string) { a.publishFixProgress(diagnosticID, line, "") },
+		authorizing: func(elevation elevation, command []string) {
+			if a.events == nil {
+				return
+			}
+			a.publishEvent(jobs.Event{
+				Type:         jobs.EventTypeAuthorization,
+				DiagnosticID: diagnosticID,
+				Message:      fmt.Sprintf("Authorization required to run %s: %s", formatCommand(command[0], command[1:]), elevation.prompt),
+				Command:      elevation.tool,
+				Args:         command,
+			})
+		},
+	}
+}
+
 func (a *App) refreshDiagnosticsFromSettings(settings domain.Settings) domain.DiagnosticReport {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -92,7 +213,7 @@ func (a *App) refreshDiagnosticsFromSettings(settings domain.Settings) domain.Di
 	if a.checker != nil {
 		a.Diagnostics = a.checker.Run(settings)
 	}
-	return a.Diagnostics
+	return localizeReport(a.Diagnostics, settings.Locale)
 }
 
 func ensureLocalBinOnPATH(homeDir string) error {
@@ -116,14 +237,18 @@ func ensureLocalBinOnPATH(homeDir string) error {
 }
 
 func localBinDir(homeDir string) string {
-	return filepath.Join(homeDir, ".media-transcriber", "bin")
+	return filepath.Join(config.ResolvePaths(homeDir).Data, "bin")
 }
 
 func localModelsDir(homeDir string) string {
-	return filepath.Join(homeDir, ".media-transcriber", "models")
+	return filepath.Join(config.ResolvePaths(homeDir).Data, "models")
 }
 
-func installFFmpegForCurrentOS() error {
+func localToolsDir(homeDir string) string {
+	return filepath.Join(config.ResolvePaths(homeDir).Data, "tools")
+}
+
+func installFFmpegForCurrentOS(reporter installReporter) error {
 	options := []installOption{}
 
 	switch goruntime.GOOS {
@@ -193,7 +318,7 @@ func installFFmpegForCurrentOS() error {
 		}
 	}
 
-	if err := runFirstSuccessfulInstall(options); err != nil {
+	if err := runFirstSuccessfulInstall(options, reporter); err != nil {
 		return fmt.Errorf("install ffmpeg/ffprobe: %w", err)
 	}
 	if err := requireToolsOnPath("ffmpeg", "ffprobe"); err != nil {
@@ -202,7 +327,10 @@ func installFFmpegForCurrentOS() error {
 	return nil
 }
 
-func installWhisperForCurrentOS() error {
+// installWhisperForCurrentOS tries package managers, release binaries, existing executables,
+// and finally a source build; reporter receives install and build output lines.
+// githubToken authenticates the release metadata request.
+func installWhisperForCurrentOS(reporter installReporter, githubToken string) error {
 	if err := requireToolsOnPath("whisper.cpp"); err == nil {
 		return nil
 	}
@@ -294,15 +422,15 @@ func installWhisperForCurrentOS() error {
 		}
 	}
 
-	installErr := runFirstSuccessfulInstall(options)
+	installErr := runFirstSuccessfulInstall(options, reporter)
 	if installErr == nil {
 		if err := requireToolsOnPath("whisper.cpp"); err == nil {
 			return nil
 		}
 	}
 
-	if goruntime.GOOS == "windows" {
-		if err := installWhisperWindowsFromGithubRelease(); err == nil {
+	if goruntime.GOOS == "windows" || goruntime.GOOS == "linux" {
+		if err := installWhisperFromGithubRelease(githubToken); err == nil {
 			if err := requireToolsOnPath("whisper.cpp"); err == nil {
 				return nil
 			}
@@ -314,10 +442,14 @@ func installWhisperForCurrentOS() error {
 	}
 
 	if err := createWhisperAlias(); err != nil {
+		buildErr := buildWhisperFromSource(reporter.output)
+		if buildErr == nil {
+			return requireToolsOnPath("whisper.cpp")
+		}
 		if installErr != nil {
-			return fmt.Errorf("install whisper.cpp failed: %v | alias creation failed: %w", installErr, err)
+			return fmt.Errorf("install whisper.cpp failed: %v | alias creation failed: %v | source build: %w", installErr, err, buildErr)
 		}
-		return fmt.Errorf("create whisper.cpp command alias: %w", err)
+		return fmt.Errorf("create whisper.cpp command alias: %v | source build: %w", err, buildErr)
 	}
 
 	if err := requireToolsOnPath("whisper.cpp"); err != nil {
@@ -329,7 +461,7 @@ func installWhisperForCurrentOS() error {
 	return nil
 }
 
-func runFirstSuccessfulInstall(options []installOption) error {
+func runFirstSuccessfulInstall(options []installOption, reporter installReporter) error {
 	if len(options) == 0 {
 		return fmt.Errorf("no install commands configured for OS %s", goruntime.GOOS)
 	}
@@ -342,7 +474,7 @@ func runFirstSuccessfulInstall(options []installOption) error {
 			continue
 		}
 		atLeastOneManager = true
-		if err := runInstallCommands(option.commands); err == nil {
+		if err := runInstallCommands(option.commands, reporter); err == nil {
 			return nil
 		} else {
 			errorsByManager = append(errorsByManager, fmt.Sprintf("%s: %v", option.manager, err))
@@ -355,66 +487,15 @@ func runFirstSuccessfulInstall(options []installOption) error {
 	return fmt.Errorf(strings.Join(errorsByManager, " | "))
 }
 
-func runInstallCommands(commands [][]string) error {
+func runInstallCommands(commands [][]string, reporter installReporter) error {
 	for _, command := range commands {
-		if err := runCommandWithPossibleElevation(command); err != nil {
+		if err := runCommandWithElevation(command, reporter); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func runCommandWithPossibleElevation(command []string) error {
-	if len(command) == 0 {
-		return fmt.Errorf("empty command")
-	}
-
-	candidates := [][]string{command}
-	if goruntime.GOOS == "linux" && requiresElevation(command[0]) {
-		if commandAvailable("pkexec") {
-			candidates = append(candidates, append([]string{"pkexec"}, command...))
-		}
-		if commandAvailable("sudo") {
-			candidates = append(candidates, append([]string{"sudo", "-n"}, command...))
-		}
-	}
-
-	attemptErrors := make([]string, 0, len(candidates))
-	for _, candidate := range candidates {
-		if err := runCommand(candidate[0], candidate[1:]...); err == nil {
-			return nil
-		} else {
-			attemptErrors = append(attemptErrors, err.Error())
-		}
-	}
-
-	return fmt.Errorf(strings.Join(attemptErrors, " | "))
-}
-
-func runCommand(name string, args ...string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), installCommandTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, name, args...)
-	output, err := cmd.CombinedOutput()
-	if err == nil {
-		return nil
-	}
-
-	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
-		return fmt.Errorf("%s timed out after %s", formatCommand(name, args), installCommandTimeout)
-	}
-
-	trimmed := strings.TrimSpace(string(output))
-	if len(trimmed) > 500 {
-		trimmed = trimmed[:500] + "..."
-	}
-	if trimmed == "" {
-		return fmt.Errorf("%s failed: %w", formatCommand(name, args), err)
-	}
-	return fmt.Errorf("%s failed: %w (%s)", formatCommand(name, args), err, trimmed)
-}
-
 func formatCommand(name string, args []string) string {
 	parts := append([]string{name}, args...)
 	return strings.Join(parts, " ")
@@ -493,6 +574,9 @@ func createWhisperAliasFromExecutable(sourcePath string) error {
 		if err := os.WriteFile(aliasPath, []byte(content), 0o644); err != nil {
 			return fmt.Errorf("write whisper alias file: %w", err)
 		}
+		// Other terminals only find the alias once its directory is on the
+		// user PATH; ToolPathSetup reports when that could not be done.
+		_, _ = persistUserPath(binDir)
 		return nil
 	}
 
@@ -513,13 +597,14 @@ type githubRelease struct {
 	} `json:"assets"`
 }
 
-func installWhisperWindowsFromGithubRelease() error {
-	release, err := fetchLatestWhisperRelease()
+// installWhisperFromGithubRelease installs the prebuilt release binary matching GOOS and GOARCH.
+func installWhisperFromGithubRelease(githubToken string) error {
+	release, err := fetchLatestWhisperRelease(githubToken)
 	if err != nil {
 		return err
 	}
 
-	assetURL, assetName, err := selectWhisperWindowsAsset(release)
+	assetURL, assetName, err := selectWhisperReleaseAsset(release, goruntime.GOOS, goruntime.GOARCH)
 	if err != nil {
 		return err
 	}
@@ -529,17 +614,17 @@ func installWhisperWindowsFromGithubRelease() error {
 		return fmt.Errorf("resolve user home: %w", err)
 	}
 
-	installDir := filepath.Join(homeDir, ".media-transcriber", "tools", "whisper.cpp", release.TagName)
+	installDir := filepath.Join(localToolsDir(homeDir), "whisper.cpp", release.TagName)
 	if err := os.MkdirAll(installDir, 0o755); err != nil {
 		return fmt.Errorf("create whisper install directory: %w", err)
 	}
 
-	zipPath := filepath.Join(installDir, assetName)
-	if err := downloadURLToFile(zipPath, assetURL, downloadToolTimeout); err != nil {
+	archivePath := filepath.Join(installDir, assetName)
+	if err := downloadURLToFile(archivePath, assetURL, downloadToolTimeout); err != nil {
 		return fmt.Errorf("download release asset: %w", err)
 	}
 
-	executablePath, err := extractWhisperWindowsZip(zipPath, installDir)
+	executablePath, err := extractWhisperArchive(archivePath, installDir)
 	if err != nil {
 		return fmt.Errorf("extract whisper release asset: %w", err)
 	}
@@ -550,7 +635,7 @@ func installWhisperWindowsFromGithubRelease() error {
 	return nil
 }
 
-func fetchLatestWhisperRelease() (githubRelease, error) {
+func fetchLatestWhisperRelease(githubToken string) (githubRelease, error) {
 	urls := []string{
 		"https://api.github.com/repos/ggml-org/whisper.cpp/releases/latest",
 		"https://api.github.com/repos/ggerganov/whisper.cpp/releases/latest",
@@ -558,7 +643,7 @@ func fetchLatestWhisperRelease() (githubRelease, error) {
 
 	var lastErr error
 	for _, url := range urls {
-		release, err := fetchGithubRelease(url)
+		release, err := fetchGithubRelease(url, githubToken)
 		if err == nil {
 			return release, nil
 		}
@@ -571,42 +656,25 @@ func fetchLatestWhisperRelease() (githubRelease, error) {
 	return githubRelease{}, fmt.Errorf("fetch latest whisper.cpp release metadata: %w", lastErr)
 }
 
-func fetchGithubRelease(url string) (githubRelease, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), downloadToolTimeout)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return githubRelease{}, fmt.Errorf("build release metadata request: %w", err)
-	}
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("User-Agent", "media-transcriber")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return githubRelease{}, fmt.Errorf("request release metadata: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return githubRelease{}, fmt.Errorf("release metadata request returned %s", resp.Status)
-	}
-
-	var release githubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return githubRelease{}, fmt.Errorf("decode release metadata: %w", err)
-	}
-	if strings.TrimSpace(release.TagName) == "" {
-		return githubRelease{}, fmt.Errorf("release metadata did not include a tag name")
-	}
-	return release, nil
+// releaseArchTokens lists asset name fragments used for each GOARCH in whisper.cpp releases.
+var releaseArchTokens = map[string][]string{
+	"amd64": {"x64", "x86_64", "amd64"},
+	"arm64": {"arm64", "aarch64"},
+	"386":   {"win32", "x86", "i386"},
 }
 
-func selectWhisperWindowsAsset(release githubRelease) (url string, name string, err error) {
+// selectWhisperReleaseAsset picks the release archive for goos/goarch, preferring the
+// official whisper-bin-<arch>.zip naming before generic OS and architecture matches.
+func selectWhisperReleaseAsset(release githubRelease, goos, goarch string) (url string, name string, err error) {
 	if len(release.Assets) == 0 {
 		return "", "", fmt.Errorf("release %s has no assets", release.TagName)
 	}
 
+	archTokens := releaseArchTokens[goarch]
+	if len(archTokens) == 0 {
+		return "", "", fmt.Errorf("no release assets are published for architecture %s", goarch)
+	}
+
 	selectByPredicate := func(predicate func(string) bool) (string, string, bool) {
 		for _, asset := range release.Assets {
 			assetName := strings.ToLower(strings.TrimSpace(asset.Name))
@@ -620,90 +688,187 @@ func selectWhisperWindowsAsset(release githubRelease) (url string, name string,
 		}
 		return "", "", false
 	}
-
-	if url, name, ok := selectByPredicate(func(assetName string) bool {
-		return strings.Contains(assetName, "whisper-bin-x64.zip")
-	}); ok {
-		return url, name, nil
+	containsAny := func(assetName string, tokens []string) bool {
+		for _, token := range tokens {
+			if containsToken(assetName, token) {
+				return true
+			}
+		}
+		return false
 	}
 
-	if url, name, ok := selectByPredicate(func(assetName string) bool {
-		return strings.HasSuffix(assetName, ".zip") &&
-			(strings.Contains(assetName, "win") || strings.Contains(assetName, "windows")) &&
-			strings.Contains(assetName, "x64")
-	}); ok {
-		return url, name, nil
+	switch goos {
+	case "windows":
+		for _, token := range archTokens {
+			if url, name, ok := selectByPredicate(func(assetName string) bool {
+				return strings.Contains(assetName, "whisper-bin-"+token+".zip")
+			}); ok {
+				return url, name, nil
+			}
+		}
+
+		if url, name, ok := selectByPredicate(func(assetName string) bool {
+			return strings.HasSuffix(assetName, ".zip") &&
+				containsAny(assetName, []string{"win", "windows"}) &&
+				containsAny(assetName, archTokens)
+		}); ok {
+			return url, name, nil
+		}
+	case "linux":
+		if url, name, ok := selectByPredicate(func(assetName string) bool {
+			return isSupportedArchive(assetName) &&
+				strings.Contains(assetName, "linux") &&
+				containsAny(assetName, archTokens)
+		}); ok {
+			return url, name, nil
+		}
+	default:
+		return "", "", fmt.Errorf("no release assets are published for %s", goos)
 	}
 
-	return "", "", fmt.Errorf("release %s does not contain a supported Windows x64 zip asset", release.TagName)
+	return "", "", fmt.Errorf("release %s does not contain a supported %s %s asset", release.TagName, goos, goarch)
 }
 
-func downloadURLToFile(destinationPath string, sourceURL string, timeout time.Duration) error {
-	if err := os.MkdirAll(filepath.Dir(destinationPath), 0o755); err != nil {
-		return fmt.Errorf("prepare destination directory: %w", err)
+// containsToken reports whether token appears in name as a whole word, so "x86"
+// does not match inside "x86_64".
+func containsToken(name, token string) bool {
+	isWordByte := func(b byte) bool {
+		return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
 	}
-
-	tmpPath := destinationPath + ".download"
-	if err := os.Remove(tmpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("remove stale temp file: %w", err)
+	for start := 0; ; {
+		i := strings.Index(name[start:], token)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(token)
+		if (i == 0 || !isWordByte(name[i-1])) && (end == len(name) || !isWordByte(name[end])) {
+			return true
+		}
+		start = i + 1
 	}
+}
+
+// isSupportedArchive reports whether the asset is a zip or gzipped tarball.
+func isSupportedArchive(name string) bool {
+	return strings.HasSuffix(name, ".zip") || strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func downloadURLToFile(destinationPath string, sourceURL string, timeout time.Duration) error {
+	return downloadURLToFileWithHeaders(destinationPath, sourceURL, timeout, nil)
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+func downloadURLToFileWithHeaders(destinationPath string, sourceURL string, timeout time.Duration, headers http.Header) error {
+	return downloadManager.Download(context.Background(), downloads.Request{
+		URL:         sourceURL,
+		Destination: destinationPath,
+		Header:      headers,
+		Timeout:     timeout,
+	})
+}
+
+// extractWhisperArchive unpacks a release archive and returns the whisper CLI executable.
+func extractWhisperArchive(archivePath string, extractDir string) (string, error) {
+	var extracted []string
+	var err error
+	lower := strings.ToLower(archivePath)
+	if strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") {
+		extracted, err = extractTarGz(archivePath, extractDir)
+	} else {
+		extracted, err = extractZip(archivePath, extractDir)
+	}
 	if err != nil {
-		return fmt.Errorf("build request: %w", err)
+		return "", err
 	}
-	req.Header.Set("User-Agent", "media-transcriber")
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request download: %w", err)
+	var executablePath string
+	for _, path := range extracted {
+		baseName := strings.TrimSuffix(strings.ToLower(filepath.Base(path)), ".exe")
+		if baseName == "whisper-cli" || baseName == "main" || baseName == "whisper.cpp" {
+			executablePath = path
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	if strings.TrimSpace(executablePath) == "" {
+		return "", fmt.Errorf("extracted archive does not contain whisper executable (whisper-cli/main)")
 	}
+	if goruntime.GOOS != "windows" {
+		if err := os.Chmod(executablePath, 0o755); err != nil {
+			return "", fmt.Errorf("mark whisper executable: %w", err)
+		}
+	}
+	return executablePath, nil
+}
 
-	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+// extractTarGz unpacks regular files and directories of a .tar.gz archive into extractDir.
+func extractTarGz(archivePath string, extractDir string) ([]string, error) {
+	file, err := os.Open(archivePath)
 	if err != nil {
-		return fmt.Errorf("create temporary file: %w", err)
+		return nil, err
 	}
+	defer file.Close()
 
-	_, copyErr := io.Copy(file, resp.Body)
-	closeErr := file.Close()
-	if copyErr != nil {
-		_ = os.Remove(tmpPath)
-		return fmt.Errorf("write destination file: %w", copyErr)
-	}
-	if closeErr != nil {
-		_ = os.Remove(tmpPath)
-		return fmt.Errorf("close destination file: %w", closeErr)
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
 	}
+	defer gz.Close()
 
-	if err := os.Remove(destinationPath); err != nil && !errors.Is(err, os.ErrNotExist) {
-		_ = os.Remove(tmpPath)
-		return fmt.Errorf("remove old destination file: %w", err)
-	}
-	if err := os.Rename(tmpPath, destinationPath); err != nil {
-		_ = os.Remove(tmpPath)
-		return fmt.Errorf("move downloaded file into place: %w", err)
-	}
+	extracted := []string{}
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return extracted, nil
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	return nil
+		cleanName := filepath.Clean(header.Name)
+		if cleanName == "." || cleanName == "" {
+			continue
+		}
+		targetPath := filepath.Join(extractDir, cleanName)
+		if !isWithinBaseDir(extractDir, targetPath) {
+			return nil, fmt.Errorf("archive contains invalid path: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(targetPath, 0o755); err != nil {
+				return nil, err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
+				return nil, err
+			}
+			dst, err := os.OpenFile(targetPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(header.Mode)&0o777)
+			if err != nil {
+				return nil, err
+			}
+			_, copyErr := io.Copy(dst, reader)
+			closeErr := dst.Close()
+			if copyErr != nil {
+				return nil, copyErr
+			}
+			if closeErr != nil {
+				return nil, closeErr
+			}
+			extracted = append(extracted, targetPath)
+		}
+	}
 }
 
-func extractWhisperWindowsZip(zipPath string, extractDir string) (string, error) {
+// extractZip unpacks an archive into extractDir and returns the extracted file paths.
+func extractZip(zipPath string, extractDir string) ([]string, error) {
 	reader, err := zip.OpenReader(zipPath)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer reader.Close()
 
-	var executablePath string
-
+	extracted := make([]string, 0, len(reader.File))
 	for _, file := range reader.File {
 		if file == nil {
 			continue
@@ -714,54 +879,47 @@ func extractWhisperWindowsZip(zipPath string, extractDir string) (string, error)
 		}
 		targetPath := filepath.Join(extractDir, cleanName)
 		if !isWithinBaseDir(extractDir, targetPath) {
-			return "", fmt.Errorf("zip contains invalid path: %s", file.Name)
+			return nil, fmt.Errorf("zip contains invalid path: %s", file.Name)
 		}
 
 		if file.FileInfo().IsDir() {
 			if err := os.MkdirAll(targetPath, 0o755); err != nil {
-				return "", err
+				return nil, err
 			}
 			continue
 		}
 
 		if err := os.MkdirAll(filepath.Dir(targetPath), 0o755); err != nil {
-			return "", err
+			return nil, err
 		}
 
 		src, err := file.Open()
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		dst, err := os.OpenFile(targetPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, file.Mode())
 		if err != nil {
 			_ = src.Close()
-			return "", err
+			return nil, err
 		}
 
 		_, copyErr := io.Copy(dst, src)
 		srcCloseErr := src.Close()
 		dstCloseErr := dst.Close()
 		if copyErr != nil {
-			return "", copyErr
+			return nil, copyErr
 		}
 		if srcCloseErr != nil {
-			return "", srcCloseErr
+			return nil, srcCloseErr
 		}
 		if dstCloseErr != nil {
-			return "", dstCloseErr
-		}
-
-		baseName := strings.ToLower(filepath.Base(targetPath))
-		if baseName == "whisper-cli.exe" || baseName == "main.exe" || baseName == "whisper.cpp.exe" {
-			executablePath = targetPath
+			return nil, dstCloseErr
 		}
-	}
 
-	if strings.TrimSpace(executablePath) == "" {
-		return "", fmt.Errorf("extracted archive does not contain whisper executable (whisper-cli.exe/main.exe)")
+		extracted = append(extracted, targetPath)
 	}
-	return executablePath, nil
+	return extracted, nil
 }
 
 func isWithinBaseDir(baseDir string, targetPath string) bool {
@@ -783,6 +941,10 @@ func installOrFixModelPath(settings domain.Settings) (domain.Settings, bool, err
 	if err := downloadFile(plan.targetFile, defaultWhisperModelURL); err != nil {
 		return settings, false, fmt.Errorf("download model: %w", err)
 	}
+	// The CoreML encoder is optional; the coreml diagnostic reports when it is missing.
+	if model, ok := getWhisperModelByID("base.en"); ok {
+		_ = downloadCoreMLEncoder(model, plan.targetFile)
+	}
 
 	changed := strings.TrimSpace(settings.ModelPath) != plan.settingsPath
 	settings.ModelPath = plan.settingsPath
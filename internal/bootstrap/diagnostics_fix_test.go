@@ -1,6 +1,9 @@
 package bootstrap
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
 	"os"
 	"path/filepath"
 	"testing"
@@ -97,7 +100,7 @@ func TestSelectWhisperWindowsAssetPrefersWhisperBinX64(t *testing.T) {
 		},
 	}
 
-	url, name, err := selectWhisperWindowsAsset(release)
+	url, name, err := selectWhisperReleaseAsset(release, "windows", "amd64")
 	if err != nil {
 		t.Fatalf("select asset: %v", err)
 	}
@@ -121,7 +124,7 @@ func TestSelectWhisperWindowsAssetSupportsGenericWindowsPattern(t *testing.T) {
 		},
 	}
 
-	url, _, err := selectWhisperWindowsAsset(release)
+	url, _, err := selectWhisperReleaseAsset(release, "windows", "amd64")
 	if err != nil {
 		t.Fatalf("select asset: %v", err)
 	}
@@ -138,3 +141,147 @@ func TestIsWithinBaseDirRejectsTraversal(t *testing.T) {
 		t.Fatal("expected traversal target to be rejected")
 	}
 }
+
+// TestFailedDiagnosticIDsUsesDependencyOrder ensures tools are fixed before model and output dir.
+func TestFailedDiagnosticIDsUsesDependencyOrder(t *testing.T) {
+	report := domain.DiagnosticReport{
+		Items: []domain.DiagnosticItem{
+			{ID: "output_dir", Status: domain.DiagnosticStatusFail},
+			{ID: "model_path", Status: domain.DiagnosticStatusFail},
+			{ID: "tool_ffprobe", Status: domain.DiagnosticStatusPass},
+			{ID: "tool_whisper.cpp", Status: domain.DiagnosticStatusFail},
+			{ID: "tool_ffmpeg", Status: domain.DiagnosticStatusFail},
+		},
+	}
+
+	got := failedDiagnosticIDs(report)
+	want := []string{"tool_ffmpeg", "tool_whisper.cpp", "model_path", "output_dir"}
+	if len(got) != len(want) {
+		t.Fatalf("ids = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestExtractZipUnpacksNestedDirectories covers CoreML encoder archives.
+func TestExtractZipUnpacksNestedDirectories(t *testing.T) {
+	root := t.TempDir()
+	zipPath := filepath.Join(root, "encoder.zip")
+	file, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatalf("create zip: %v", err)
+	}
+	writer := zip.NewWriter(file)
+	entry, err := writer.Create("ggml-base-encoder.mlmodelc/weights/weight.bin")
+	if err != nil {
+		t.Fatalf("create entry: %v", err)
+	}
+	_, _ = entry.Write([]byte("weights"))
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close zip writer: %v", err)
+	}
+	_ = file.Close()
+
+	extracted, err := extractZip(zipPath, root)
+	if err != nil {
+		t.Fatalf("extract zip: %v", err)
+	}
+	want := filepath.Join(root, "ggml-base-encoder.mlmodelc", "weights", "weight.bin")
+	if len(extracted) != 1 || extracted[0] != want {
+		t.Fatalf("extracted = %v, want [%s]", extracted, want)
+	}
+}
+
+// TestSelectWhisperReleaseAssetMatchesPlatform covers ARM64 and Linux asset selection.
+func TestSelectWhisperReleaseAssetMatchesPlatform(t *testing.T) {
+	release := githubRelease{
+		TagName: "v1.0.0",
+		Assets: []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		}{
+			{Name: "whisper-bin-x64.zip", URL: "https://example.com/win-x64.zip"},
+			{Name: "whisper-bin-arm64.zip", URL: "https://example.com/win-arm64.zip"},
+			{Name: "whisper-linux-x86_64.tar.gz", URL: "https://example.com/linux-x64.tar.gz"},
+			{Name: "whisper-linux-aarch64.tar.gz", URL: "https://example.com/linux-arm64.tar.gz"},
+		},
+	}
+
+	tests := []struct {
+		goos    string
+		goarch  string
+		wantURL string
+	}{
+		{goos: "windows", goarch: "arm64", wantURL: "https://example.com/win-arm64.zip"},
+		{goos: "linux", goarch: "amd64", wantURL: "https://example.com/linux-x64.tar.gz"},
+		{goos: "linux", goarch: "arm64", wantURL: "https://example.com/linux-arm64.tar.gz"},
+	}
+	for _, tt := range tests {
+		url, _, err := selectWhisperReleaseAsset(release, tt.goos, tt.goarch)
+		if err != nil {
+			t.Fatalf("%s/%s: select asset: %v", tt.goos, tt.goarch, err)
+		}
+		if url != tt.wantURL {
+			t.Fatalf("%s/%s: url = %s, want %s", tt.goos, tt.goarch, url, tt.wantURL)
+		}
+	}
+
+	if _, _, err := selectWhisperReleaseAsset(release, "linux", "riscv64"); err == nil {
+		t.Fatal("expected error for architecture without assets")
+	}
+}
+
+// TestSelectWhisperReleaseAssetMatchesWholeArchTokens keeps "x86" from matching "x86_64".
+func TestSelectWhisperReleaseAssetMatchesWholeArchTokens(t *testing.T) {
+	release := githubRelease{
+		TagName: "v1.0.0",
+		Assets: []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		}{
+			{Name: "whisper-linux-x86_64.tar.gz", URL: "https://example.com/linux-x64.tar.gz"},
+			{Name: "whisper-linux-x86.tar.gz", URL: "https://example.com/linux-x86.tar.gz"},
+		},
+	}
+	url, _, err := selectWhisperReleaseAsset(release, "linux", "386")
+	if err != nil {
+		t.Fatalf("select asset: %v", err)
+	}
+	if url != "https://example.com/linux-x86.tar.gz" {
+		t.Fatalf("url = %s, want the 32-bit archive", url)
+	}
+
+	release.Assets = release.Assets[:1]
+	if _, _, err := selectWhisperReleaseAsset(release, "linux", "386"); err == nil {
+		t.Fatal("expected no 32-bit match for an x86_64-only release")
+	}
+}
+
+// TestExtractWhisperArchiveFindsCLIInTarball validates Linux release extraction.
+func TestExtractWhisperArchiveFindsCLIInTarball(t *testing.T) {
+	root := t.TempDir()
+	archivePath := filepath.Join(root, "whisper-linux-x86_64.tar.gz")
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("create archive: %v", err)
+	}
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	content := []byte("#!/bin/sh\n")
+	_ = tw.WriteHeader(&tar.Header{Name: "build/bin/whisper-cli", Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	_ = gz.Close()
+	_ = file.Close()
+
+	executable, err := extractWhisperArchive(archivePath, root)
+	if err != nil {
+		t.Fatalf("extract archive: %v", err)
+	}
+	if executable != filepath.Join(root, "build", "bin", "whisper-cli") {
+		t.Fatalf("executable = %s", executable)
+	}
+}
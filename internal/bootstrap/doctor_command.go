@@ -0,0 +1,122 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// doctorUsage describes the `doctor` command.
+const doctorUsage = `usage:
+  media-transcriber doctor [-json] [-fix] [flags]`
+
+// DoctorCommand runs the startup diagnostics without the window and prints
+// the report, or with -json the report as JSON, to out. With -fix it first
+// applies the same remediations as InstallOrFixAll. Failed checks make it
+// return an error, so ExitCode reports them as ExitFailure.
+func DoctorCommand(args []string, out io.Writer) error {
+	options, err := config.LoadDoctorOptions(args, os.Getenv)
+	if err != nil {
+		return &exitError{code: ExitUsage, err: fmt.Errorf("%v\n%s", err, doctorUsage)}
+	}
+	app, err := newApp(nil, options.Overrides)
+	if err != nil {
+		return err
+	}
+	return app.runDoctor(options, out)
+}
+
+// runDoctor checks, and with options.Fix fixes, the setup and writes the report.
+func (a *App) runDoctor(options config.DoctorOptions, out io.Writer) error {
+	var report domain.DiagnosticReport
+	var fixErr error
+	if options.Fix {
+		report, fixErr = a.InstallOrFixAll()
+		if !options.JSON {
+			writeFixProgress(out, a.events)
+		}
+	} else {
+		var err error
+		if report, err = a.RefreshDiagnostics(); err != nil {
+			return err
+		}
+	}
+
+	if options.JSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else if err := writeDoctorReport(out, report, options.Fix); err != nil {
+		return err
+	}
+
+	if failed := failedChecks(report); failed > 0 {
+		return errors.Join(fixErr, fmt.Errorf("%d diagnostic checks failed", failed))
+	}
+	return fixErr
+}
+
+// failedChecks counts the failed items of report.
+func failedChecks(report domain.DiagnosticReport) int {
+	failed := 0
+	for _, item := range report.Items {
+		if item.Status == domain.DiagnosticStatusFail {
+			failed++
+		}
+	}
+	return failed
+}
+
+// writeFixProgress prints the remediation and authorization events, all of
+// which belong to this run of the command.
+func writeFixProgress(out io.Writer, events *jobs.EventBus) {
+	if events == nil {
+		return
+	}
+	for _, event := range events.Query(0, jobs.EventFilter{Types: []jobs.EventType{jobs.EventTypeRemediation, jobs.EventTypeAuthorization}}) {
+		line := event.DiagnosticID + ": " + event.Message
+		if event.Stderr != "" {
+			line += ": " + event.Stderr
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+// writeDoctorReport prints one line per check, with the hint of failed ones,
+// and a closing summary.
+func writeDoctorReport(out io.Writer, report domain.DiagnosticReport, fixed bool) error {
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, item := range report.Items {
+		status := "ok"
+		if item.Status == domain.DiagnosticStatusFail {
+			status = "FAIL"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", status, item.Name, item.Message)
+		if item.Status == domain.DiagnosticStatusFail && strings.TrimSpace(item.Hint) != "" {
+			fmt.Fprintf(writer, "\t\thint: %s\n", item.Hint)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	switch failed := failedChecks(report); {
+	case failed == 0:
+		fmt.Fprintln(out, "All checks passed.")
+	case fixed:
+		fmt.Fprintf(out, "%d checks still fail after fixing.\n", failed)
+	default:
+		fmt.Fprintf(out, "%d checks failed; `media-transcriber doctor -fix` installs missing tools and fixes what it can.\n", failed)
+	}
+	return nil
+}
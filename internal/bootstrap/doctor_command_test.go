@@ -0,0 +1,91 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/diagnostics"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// newDoctorApp returns an app whose tools and model pass the checks and whose
+// output directory passes only once it exists.
+func newDoctorApp(t *testing.T) (*App, string) {
+	t.Helper()
+	root := t.TempDir()
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	mustWrite(t, modelPath, "stub")
+	outputDir := filepath.Join(root, "out")
+	checker := diagnostics.NewCheckerForTests(
+		func(name string) (string, error) { return "/usr/bin/" + name, nil },
+		os.Stat, os.ReadDir,
+		func(path string, perm os.FileMode) error {
+			_, err := os.Stat(path)
+			return err
+		},
+		os.CreateTemp, os.Remove,
+	)
+	store := config.NewJSONStore(filepath.Join(root, "settings.json"))
+	if err := store.Save(domain.Settings{ModelPath: modelPath, OutputDir: outputDir}); err != nil {
+		t.Fatalf("save settings: %v", err)
+	}
+	return &App{Store: store, checker: checker, events: jobs.NewEventBus(100)}, outputDir
+}
+
+// TestDoctorReportsFailures prints every check and a hint to fix, and fails
+// without changing anything.
+func TestDoctorReportsFailures(t *testing.T) {
+	app, outputDir := newDoctorApp(t)
+
+	var out strings.Builder
+	err := app.runDoctor(config.DoctorOptions{}, &out)
+	if err == nil || ExitCode(err) != ExitFailure {
+		t.Fatalf("runDoctor() error = %v, want failed checks", err)
+	}
+	text := out.String()
+	for _, want := range []string{"FAIL  Output directory", "hint: ", "doctor -fix"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("report lacks %q:\n%s", want, text)
+		}
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("output directory created without -fix: %v", err)
+	}
+
+	out.Reset()
+	if err := app.runDoctor(config.DoctorOptions{JSON: true}, &out); err == nil {
+		t.Fatal("runDoctor(-json) error = nil, want failed checks")
+	}
+	var report domain.DiagnosticReport
+	if err := json.Unmarshal([]byte(out.String()), &report); err != nil {
+		t.Fatalf("output is not a JSON report: %v\n%s", err, out.String())
+	}
+	if !report.HasFailures || len(report.Items) == 0 {
+		t.Fatalf("report = %+v, want failures", report)
+	}
+}
+
+// TestDoctorFix applies the remediation of the failed check and reports the
+// setup healthy afterwards.
+func TestDoctorFix(t *testing.T) {
+	app, outputDir := newDoctorApp(t)
+
+	var out strings.Builder
+	if err := app.runDoctor(config.DoctorOptions{Fix: true}, &out); err != nil {
+		t.Fatalf("runDoctor(-fix) error = %v\n%s", err, out.String())
+	}
+	if _, err := os.Stat(outputDir); err != nil {
+		t.Fatalf("output directory not created: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{"output_dir: Fix applied", "All checks passed."} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("output lacks %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/downloads"
+	"media-transcriber/internal/jobs"
+)
+
+// maxConcurrentDownloads bounds parallel model and tool downloads.
+const maxConcurrentDownloads = 2
+
+// downloadManager is shared by model downloads and tool installers.
+var downloadManager = downloads.NewManager(maxConcurrentDownloads)
+
+// ListDownloads returns queued, running, and finished downloads.
+func (a *App) ListDownloads() []downloads.Download {
+	return downloadManager.List()
+}
+
+// CancelDownload stops a queued or running download by ID.
+func (a *App) CancelDownload(id string) error {
+	return downloadManager.Cancel(id)
+}
+
+// applyDownloadLimit pushes the configured bandwidth cap to the download manager.
+func applyDownloadLimit(settings domain.Settings) {
+	downloadManager.SetBandwidthLimit(int64(settings.DownloadLimitKBps) * 1024)
+}
+
+// publishDownloadEvent forwards download manager updates to UI subscribers.
+func (a *App) publishDownloadEvent(download downloads.Download) {
+	if a.events == nil {
+		return
+	}
+
+	message := fmt.Sprintf("%s %s", filepath.Base(download.Destination), download.Status)
+	if download.Error != "" {
+		message = fmt.Sprintf("%s: %s", message, download.Error)
+	}
+
+	a.publishEvent(jobs.Event{
+		Type:       jobs.EventTypeDownload,
+		Message:    message,
+		DownloadID: download.ID,
+		BytesDone:  download.BytesDone,
+		BytesTotal: download.BytesTotal,
+	})
+}
@@ -0,0 +1,97 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// checkDuplicate hashes the input in the job's runner, where a multi-gigabyte
+// file does not stall the caller, records the hash in opts, and reports an
+// earlier job with the same content. In skip mode the job then fails and
+// checkDuplicate returns false.
+func (a *App) checkDuplicate(ctx context.Context, jobID, inputPath string, settings domain.Settings, opts *jobOptions) bool {
+	if !runsWhisper(opts.mode) || settings.Duplicates == domain.DuplicateOff {
+		return true
+	}
+	var duplicate *domain.HistoryEntry
+	opts.inputHash, duplicate = a.findDuplicate(ctx, inputPath, settings, *opts)
+	if duplicate == nil {
+		return true
+	}
+	if settings.Duplicates != domain.DuplicateSkip {
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  fmt.Sprintf("Same content already transcribed with this model and language in job %s", duplicate.ID),
+			TextPath: duplicate.TextPath,
+		})
+		return true
+	}
+
+	message := fmt.Sprintf("already transcribed with this model and language in job %s: %s", duplicate.ID, duplicate.TextPath)
+	_ = a.Jobs.Transition(domain.JobStatusFailed)
+	a.publishStatus(jobID, domain.JobStatusFailed, "Duplicate skipped")
+	a.publishEvent(jobs.Event{
+		JobID:    jobID,
+		Type:     jobs.EventTypeError,
+		Status:   domain.JobStatusFailed,
+		Message:  message,
+		TextPath: duplicate.TextPath,
+	})
+	entry := a.historyEntry(jobID, inputPath, settings, domain.JobStatusFailed, *opts)
+	entry.Error = message
+	a.recordHistory(entry)
+	a.clearActiveJob(jobID)
+	return false
+}
+
+// findDuplicate hashes the input and looks for the newest completed job with
+// the same content, model, and language whose transcript still exists. The
+// hash is returned for the history and the preprocess cache either way; it
+// is empty when the input cannot be read or ctx ends, which the pipeline
+// then reports. Resubmitted jobs re-run an input on purpose and are never
+// treated as duplicates.
+func (a *App) findDuplicate(ctx context.Context, inputPath string, settings domain.Settings, opts jobOptions) (string, *domain.HistoryEntry) {
+	hash, err := transcribe.ContentHash(ctx, inputPath)
+	if err != nil || a.History == nil || opts.resubmitOf != "" {
+		return hash, nil
+	}
+	entries, err := a.History.Load()
+	if err != nil {
+		return hash, nil
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].FinishedAt.After(entries[j].FinishedAt) })
+	for _, entry := range entries {
+		if entry.Status != domain.JobStatusDone || entry.InputHash != hash || entry.TextPath == "" {
+			continue
+		}
+		if entry.ModelPath != settings.ModelPath || !sameLanguage(entry.Language, settings.Language) {
+			continue
+		}
+		if _, err := os.Stat(entry.TextPath); err != nil {
+			continue
+		}
+		return hash, &entry
+	}
+	return hash, nil
+}
+
+// sameLanguage compares language settings, treating empty and "auto" as the
+// same automatic detection.
+func sameLanguage(a, b string) bool {
+	normalize := func(lang string) string {
+		lang = strings.TrimSpace(lang)
+		if strings.EqualFold(lang, "auto") {
+			return ""
+		}
+		return strings.ToLower(lang)
+	}
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,150 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// newDuplicateApp returns an app whose history holds one completed job of
+// talk.mp4 with the base model and automatic language, and a copy of that
+// input under another name.
+func newDuplicateApp(t *testing.T, settings domain.Settings, run func(ctx context.Context, req transcribe.Request) (transcribe.Result, error)) (*App, string, domain.HistoryEntry) {
+	t.Helper()
+	root := t.TempDir()
+	original := filepath.Join(root, "talk.mp4")
+	renamed := filepath.Join(root, "talk (1).mp4")
+	textPath := filepath.Join(root, "talk.txt")
+	mustWrite(t, original, "same media")
+	mustWrite(t, renamed, "same media")
+	mustWrite(t, textPath, "hello")
+	hash, err := transcribe.ContentHash(context.Background(), original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := domain.HistoryEntry{
+		ID:         "job-1",
+		InputPath:  original,
+		Status:     domain.JobStatusDone,
+		ModelPath:  "/models/ggml-base.bin",
+		TextPath:   textPath,
+		FinishedAt: time.Now().UTC(),
+		InputHash:  hash,
+	}
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{done}); err != nil {
+		t.Fatal(err)
+	}
+	settings.OutputDir = root
+	app := &App{
+		Store:    &fakeStore{settings: settings},
+		History:  history,
+		Jobs:     jobs.NewManager(),
+		Pipeline: &fakePipeline{run: run},
+		events:   jobs.NewEventBus(100),
+	}
+	return app, renamed, done
+}
+
+// TestFindDuplicate matches completed jobs by content, model, and language
+// whose transcript still exists.
+func TestFindDuplicate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings domain.Settings
+		opts     jobOptions
+		change   func(t *testing.T, entry domain.HistoryEntry)
+		want     bool
+	}{
+		{name: "renamed copy", settings: domain.Settings{ModelPath: "/models/ggml-base.bin", Language: "auto"}, want: true},
+		{name: "other model", settings: domain.Settings{ModelPath: "/models/ggml-large.bin"}},
+		{name: "other language", settings: domain.Settings{ModelPath: "/models/ggml-base.bin", Language: "de"}},
+		{name: "resubmit", settings: domain.Settings{ModelPath: "/models/ggml-base.bin"}, opts: jobOptions{resubmitOf: "job-1"}},
+		{
+			name:     "transcript deleted",
+			settings: domain.Settings{ModelPath: "/models/ggml-base.bin"},
+			change: func(t *testing.T, entry domain.HistoryEntry) {
+				if err := os.Remove(entry.TextPath); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, input, done := newDuplicateApp(t, tt.settings, nil)
+			if tt.change != nil {
+				tt.change(t, done)
+			}
+
+			hash, duplicate := app.findDuplicate(context.Background(), input, tt.settings, tt.opts)
+			if hash != done.InputHash {
+				t.Fatalf("hash = %q, want %q", hash, done.InputHash)
+			}
+			if got := duplicate != nil; got != tt.want {
+				t.Fatalf("duplicate = %+v, want found %v", duplicate, tt.want)
+			}
+			if tt.want && duplicate.TextPath != done.TextPath {
+				t.Fatalf("duplicate transcript = %q, want %q", duplicate.TextPath, done.TextPath)
+			}
+		})
+	}
+}
+
+// TestStartTranscriptionDuplicates warns about an already transcribed input
+// and links its transcript, or refuses to start it in skip mode.
+func TestStartTranscriptionDuplicates(t *testing.T) {
+	ran := false
+	var inputHash string
+	run := func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+		ran = true
+		inputHash = req.InputHash
+		req.OnStage("transcribing")
+		req.OnStage("exporting")
+		return transcribe.Result{}, nil
+	}
+	settings := domain.Settings{ModelPath: "/models/ggml-base.bin", Duplicates: domain.DuplicateSkip}
+	app, input, done := newDuplicateApp(t, settings, run)
+	job, err := app.StartTranscription(input)
+	if err != nil {
+		t.Fatalf("StartTranscription(skip) error = %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusFailed)
+	if ran {
+		t.Fatal("skipped duplicate was transcribed")
+	}
+	entry, err := app.findHistoryEntry(job.ID)
+	if err != nil || !strings.Contains(entry.Error, "job-1") || entry.InputHash != done.InputHash {
+		t.Fatalf("history entry = %+v, %v, want the earlier job", entry, err)
+	}
+
+	settings.Duplicates = domain.DuplicateWarn
+	app, input, done = newDuplicateApp(t, settings, run)
+	job, err = app.StartTranscription(input)
+	if err != nil {
+		t.Fatalf("StartTranscription(warn) error = %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+	var warned bool
+	for _, event := range app.JobEvents(0, jobs.EventFilter{JobID: job.ID}) {
+		warned = warned || (strings.Contains(event.Message, "already transcribed") && event.TextPath == done.TextPath)
+	}
+	if !warned || !ran {
+		t.Fatalf("warned = %v, ran = %v, want both", warned, ran)
+	}
+	if inputHash != done.InputHash {
+		t.Fatalf("pipeline input hash = %q, want the duplicate check's %q", inputHash, done.InputHash)
+	}
+	entry, err = app.findHistoryEntry(job.ID)
+	if err != nil || entry.InputHash != done.InputHash {
+		t.Fatalf("history entry = %+v, %v, want the input hash", entry, err)
+	}
+}
@@ -0,0 +1,147 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// installReporter receives what install commands do while a diagnostic fix
+// runs; nil funcs are skipped.
+type installReporter struct {
+	// output receives each line the commands print.
+	output func(string)
+	// authorizing announces that elevation is about to ask the user to let
+	// command run as root.
+	authorizing func(elevation elevation, command []string)
+}
+
+// line forwards one output line.
+func (r installReporter) line(line string) {
+	emitProgress(r.output, line)
+}
+
+// elevation is how an install command that needs root is run.
+type elevation struct {
+	// tool is the escalation command, empty when the app already runs as root.
+	tool   string
+	prefix []string
+	// prompt tells the user what authorizing the command takes.
+	prompt string
+}
+
+// elevationEnv is what choosing an escalation tool depends on.
+type elevationEnv struct {
+	root bool
+	// graphical is set in a desktop session, where pkexec shows a dialog.
+	graphical bool
+	// terminal is set when stdin is a terminal sudo and doas can prompt on.
+	terminal  bool
+	available func(name string) bool
+}
+
+// currentElevationEnv describes the running process.
+func currentElevationEnv() elevationEnv {
+	env := elevationEnv{
+		root:      os.Geteuid() == 0,
+		graphical: os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != "",
+		available: commandAvailable,
+	}
+	if info, err := os.Stdin.Stat(); err == nil {
+		env.terminal = info.Mode()&os.ModeCharDevice != 0
+	}
+	return env
+}
+
+// chooseElevation picks how to get root: directly as root, pkexec's dialog in
+// a desktop session, or sudo or doas, which prompt in a terminal and
+// otherwise only work when they need no password.
+func chooseElevation(env elevationEnv) (elevation, error) {
+	if env.root {
+		return elevation{}, nil
+	}
+	if env.graphical && env.available("pkexec") {
+		return elevation{tool: "pkexec", prefix: []string{"pkexec"}, prompt: "confirm the system authorization dialog"}, nil
+	}
+	for _, tool := range []string{"sudo", "doas"} {
+		if !env.available(tool) {
+			continue
+		}
+		if env.terminal {
+			return elevation{tool: tool, prefix: []string{tool}, prompt: "enter your password in the terminal"}, nil
+		}
+		return elevation{tool: tool, prefix: []string{tool, "-n"}, prompt: tool + " must be allowed to run it without a password"}, nil
+	}
+	if env.available("pkexec") {
+		return elevation{tool: "pkexec", prefix: []string{"pkexec"}, prompt: "authorize it with a polkit agent"}, nil
+	}
+	return elevation{}, fmt.Errorf("installing needs root, but none of pkexec, sudo, or doas is available")
+}
+
+// wrap returns command behind the escalation tool.
+func (e elevation) wrap(command []string) []string {
+	return append(append([]string{}, e.prefix...), command...)
+}
+
+// explain turns a failed elevated run into the reason authorization failed,
+// when it did; other failures are returned unchanged.
+func (e elevation) explain(command []string, err error) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	name := formatCommand(command[0], command[1:])
+	switch {
+	case e.tool == "pkexec" && exitErr.ExitCode() == 126:
+		return fmt.Errorf("authorization to run %s was dismissed", name)
+	case e.tool == "pkexec" && exitErr.ExitCode() == 127:
+		return fmt.Errorf("not authorized to run %s: %w", name, err)
+	case len(e.prefix) > 1 && e.prefix[1] == "-n" && strings.Contains(strings.ToLower(err.Error()), "password"):
+		return fmt.Errorf("%s needs a password to run %s; run media-transcriber doctor -fix in a terminal or install pkexec", e.tool, name)
+	}
+	return err
+}
+
+// runCommandWithElevation runs command, through an escalation tool when it
+// needs root. The user is told before being asked to authorize, and the
+// command's output is streamed to reporter as it runs.
+func runCommandWithElevation(command []string, reporter installReporter) error {
+	return runElevated(command, reporter, currentElevationEnv(), runInstallCommand)
+}
+
+// runElevated is runCommandWithElevation with the environment and the
+// command runner supplied.
+func runElevated(command []string, reporter installReporter, env elevationEnv, run func([]string, func(string)) error) error {
+	if len(command) == 0 {
+		return fmt.Errorf("empty command")
+	}
+	if !requiresElevation(command[0]) {
+		return run(command, reporter.output)
+	}
+	elevation, err := chooseElevation(env)
+	if err != nil {
+		return fmt.Errorf("%s: %w", formatCommand(command[0], command[1:]), err)
+	}
+	if elevation.tool == "" {
+		return run(command, reporter.output)
+	}
+	if reporter.authorizing != nil {
+		reporter.authorizing(elevation, command)
+	}
+	if err := run(elevation.wrap(command), reporter.output); err != nil {
+		return elevation.explain(command, err)
+	}
+	return nil
+}
+
+// runInstallCommand runs one install command, echoing it and streaming its
+// output to output.
+func runInstallCommand(command []string, output func(string)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), installCommandTimeout)
+	defer cancel()
+	emitProgress(output, "$ "+formatCommand(command[0], command[1:]))
+	return runStreamingCommand(ctx, output, command[0], command[1:]...)
+}
@@ -0,0 +1,129 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os/exec"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+	"testing"
+)
+
+// TestChooseElevation prefers pkexec's dialog on a desktop and sudo or doas
+// in a terminal, and runs directly as root.
+func TestChooseElevation(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        elevationEnv
+		tools      []string
+		wantPrefix []string
+		wantErr    bool
+	}{
+		{name: "root", env: elevationEnv{root: true}, tools: []string{"pkexec", "sudo"}},
+		{name: "desktop", env: elevationEnv{graphical: true, terminal: true}, tools: []string{"pkexec", "sudo"}, wantPrefix: []string{"pkexec"}},
+		{name: "terminal", env: elevationEnv{terminal: true}, tools: []string{"pkexec", "sudo"}, wantPrefix: []string{"sudo"}},
+		{name: "desktop without pkexec", env: elevationEnv{graphical: true}, tools: []string{"sudo"}, wantPrefix: []string{"sudo", "-n"}},
+		{name: "doas", env: elevationEnv{terminal: true}, tools: []string{"doas"}, wantPrefix: []string{"doas"}},
+		{name: "headless pkexec", env: elevationEnv{}, tools: []string{"pkexec"}, wantPrefix: []string{"pkexec"}},
+		{name: "none", env: elevationEnv{graphical: true, terminal: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.env.available = func(name string) bool { return containsString(tt.tools, name) }
+			got, err := chooseElevation(tt.env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("chooseElevation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got.prefix, tt.wantPrefix) {
+				t.Fatalf("prefix = %v, want %v", got.prefix, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+// TestRunElevated announces authorization before running package managers
+// behind the escalation tool and streams their output.
+func TestRunElevated(t *testing.T) {
+	tests := []struct {
+		name          string
+		command       []string
+		env           elevationEnv
+		wantRun       []string
+		wantAuthorize bool
+		wantErr       string
+	}{
+		{name: "elevated", command: []string{"apt-get", "install", "-y", "ffmpeg"}, env: elevationEnv{graphical: true}, wantRun: []string{"pkexec", "apt-get", "install", "-y", "ffmpeg"}, wantAuthorize: true},
+		{name: "as root", command: []string{"apt-get", "update"}, env: elevationEnv{root: true}, wantRun: []string{"apt-get", "update"}},
+		{name: "no root needed", command: []string{"brew", "install", "ffmpeg"}, env: elevationEnv{}, wantRun: []string{"brew", "install", "ffmpeg"}},
+		{name: "no escalation tool", command: []string{"dnf", "install", "-y", "ffmpeg"}, env: elevationEnv{graphical: true}, wantErr: "none of pkexec, sudo, or doas"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.env.available = func(name string) bool { return name == "pkexec" && tt.wantAuthorize }
+			var ran []string
+			var lines []string
+			announced := false
+			reporter := installReporter{
+				output: func(line string) { lines = append(lines, line) },
+				authorizing: func(elevation elevation, command []string) {
+					announced = true
+					if ran != nil {
+						t.Error("authorization announced after the command ran")
+					}
+					if elevation.tool != "pkexec" || !reflect.DeepEqual(command, tt.command) {
+						t.Errorf("authorizing(%q, %v)", elevation.tool, command)
+					}
+				},
+			}
+			err := runElevated(tt.command, reporter, tt.env, func(command []string, output func(string)) error {
+				ran = command
+				output("Reading package lists...")
+				return nil
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("runElevated() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runElevated() error = %v", err)
+			}
+			if !reflect.DeepEqual(ran, tt.wantRun) || announced != tt.wantAuthorize {
+				t.Fatalf("ran %v, announced %v; want %v, %v", ran, announced, tt.wantRun, tt.wantAuthorize)
+			}
+			if !reflect.DeepEqual(lines, []string{"Reading package lists..."}) {
+				t.Fatalf("output = %v", lines)
+			}
+		})
+	}
+}
+
+// TestElevationExplain names dismissed and refused authorizations.
+func TestElevationExplain(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	exitErr := func(code string) error {
+		return exec.Command("sh", "-c", "exit "+code).Run()
+	}
+	command := []string{"apt-get", "update"}
+	tests := []struct {
+		name      string
+		elevation elevation
+		err       error
+		want      string
+	}{
+		{name: "dismissed", elevation: elevation{tool: "pkexec", prefix: []string{"pkexec"}}, err: exitErr("126"), want: "authorization to run apt-get update was dismissed"},
+		{name: "refused", elevation: elevation{tool: "pkexec", prefix: []string{"pkexec"}}, err: exitErr("127"), want: "not authorized to run apt-get update"},
+		{name: "sudo password", elevation: elevation{tool: "sudo", prefix: []string{"sudo", "-n"}}, err: fmt.Errorf("sudo -n apt-get update failed: %w (sudo: a password is required)", exitErr("1")), want: "sudo needs a password to run apt-get update"},
+		{name: "package failure", elevation: elevation{tool: "pkexec", prefix: []string{"pkexec"}}, err: exitErr("100"), want: "exit status 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.elevation.explain(command, tt.err); !strings.Contains(got.Error(), tt.want) {
+				t.Fatalf("explain() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/mail"
+	"media-transcriber/internal/textenc"
+)
+
+const (
+	// emailTimeout bounds one SMTP delivery including attachments.
+	emailTimeout = time.Minute
+	// defaultSMTPPort is the message submission port (STARTTLS).
+	defaultSMTPPort = 587
+)
+
+// emailJob sends the transcript of a finished job to the configured recipients:
+// inline in the body, or as attachments in the formats of EmailAttachFormats.
+func (a *App) emailJob(entry domain.HistoryEntry, settings domain.Settings) {
+	recipients := splitList(settings.EmailRecipients)
+	if settings.SMTPHost == "" || len(recipients) == 0 {
+		return
+	}
+	msg, err := transcriptEmail(entry, settings)
+	if err == nil {
+		msg.To = recipients
+		port := settings.SMTPPort
+		if port == 0 {
+			port = defaultSMTPPort
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), emailTimeout)
+		defer cancel()
+		err = mail.Send(ctx, mail.Config{
+			Host:     settings.SMTPHost,
+			Port:     port,
+			Username: settings.SMTPUser,
+			Password: a.secret(config.SecretSMTPPassword),
+		}, msg)
+	}
+	if err != nil {
+		a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("email: %v", err)})
+		return
+	}
+	a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: "Emailed to " + strings.Join(recipients, ", ")})
+}
+
+// transcriptEmail builds the message without recipients.
+func transcriptEmail(entry domain.HistoryEntry, settings domain.Settings) (mail.Message, error) {
+	name := filepath.Base(entry.InputPath)
+	msg := mail.Message{From: settings.SMTPFrom, Subject: "Transcript: " + name}
+	if msg.From == "" {
+		msg.From = settings.SMTPUser
+	}
+
+	formats := splitList(strings.ToLower(settings.EmailAttachFormats))
+	if len(formats) == 0 {
+		text, err := os.ReadFile(entry.TextPath)
+		if err != nil {
+			return mail.Message{}, fmt.Errorf("read transcript: %w", err)
+		}
+		msg.Body = textenc.Decode(text)
+		return msg, nil
+	}
+
+	var names []string
+	for _, path := range jobArtifacts(entry) {
+		if !containsFold(formats, strings.TrimPrefix(filepath.Ext(path), ".")) {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return mail.Message{}, fmt.Errorf("read %s: %w", filepath.Base(path), err)
+		}
+		msg.Attachments = append(msg.Attachments, mail.Attachment{Name: filepath.Base(path), Data: data})
+		names = append(names, filepath.Base(path))
+	}
+	if len(names) == 0 {
+		return mail.Message{}, fmt.Errorf("job has no %s output to attach", strings.Join(formats, "/"))
+	}
+	msg.Body = fmt.Sprintf("Transcript of %s attached: %s.\n", name, strings.Join(names, ", "))
+	return msg, nil
+}
+
+// splitList splits a comma-separated setting, dropping blanks.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// containsFold reports whether items contains value, ignoring case.
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSMTPPassword stores the password of the SMTP account that sends transcripts.
+func (a *App) SetSMTPPassword(password string) error {
+	return a.storeSecret(config.SecretSMTPPassword, "SMTP password", password)
+}
+
+// HasSMTPPassword reports whether an SMTP password is stored, without exposing it.
+func (a *App) HasSMTPPassword() bool {
+	return a.secret(config.SecretSMTPPassword) != ""
+}
@@ -0,0 +1,73 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// TestTranscriptEmail sends the text inline or attaches the chosen formats.
+func TestTranscriptEmail(t *testing.T) {
+	root := t.TempDir()
+	entry := domain.HistoryEntry{
+		ID:            "job-1",
+		InputPath:     filepath.Join(root, "ep12.mp3"),
+		TextPath:      filepath.Join(root, "ep12.txt"),
+		SubtitlePaths: []string{filepath.Join(root, "ep12.srt"), filepath.Join(root, "ep12.vtt")},
+	}
+	mustWrite(t, entry.TextPath, "Hello there.")
+	mustWrite(t, entry.SubtitlePaths[0], "1\n")
+	mustWrite(t, entry.SubtitlePaths[1], "WEBVTT\n")
+
+	tests := []struct {
+		name        string
+		formats     string
+		wantBody    string
+		wantAttach  []string
+		wantErrText string
+	}{
+		{name: "inline", wantBody: "Hello there."},
+		{name: "attached", formats: "SRT, txt", wantBody: "Transcript of ep12.mp3 attached: ep12.txt, ep12.srt.\n", wantAttach: []string{"ep12.txt", "ep12.srt"}},
+		{name: "missing format", formats: "docx", wantErrText: "no docx output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := transcriptEmail(entry, domain.Settings{SMTPUser: "bot@example.com", EmailAttachFormats: tt.formats})
+			if tt.wantErrText != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrText) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErrText)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("email: %v", err)
+			}
+			if msg.From != "bot@example.com" || msg.Subject != "Transcript: ep12.mp3" || msg.Body != tt.wantBody {
+				t.Fatalf("msg = %+v", msg)
+			}
+			var names []string
+			for _, attachment := range msg.Attachments {
+				names = append(names, attachment.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantAttach, ",") {
+				t.Fatalf("attachments = %v, want %v", names, tt.wantAttach)
+			}
+		})
+	}
+}
+
+// TestEmailJobReportsFailure publishes delivery errors as job events.
+func TestEmailJobReportsFailure(t *testing.T) {
+	app := &App{events: jobs.NewEventBus(10)}
+	entry := domain.HistoryEntry{ID: "job-1", InputPath: "/tmp/a.mp3", TextPath: filepath.Join(t.TempDir(), "missing.txt")}
+
+	app.emailJob(entry, domain.Settings{SMTPHost: "localhost", EmailRecipients: "client@example.com"})
+
+	events := app.JobEvents(0, jobs.EventFilter{JobID: "job-1"})
+	if len(events) != 1 || events[0].Type != jobs.EventTypeError || !strings.Contains(events[0].Message, "read transcript") {
+		t.Fatalf("events = %+v", events)
+	}
+}
@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// whisperServerFor returns the warm whisper.cpp server for jobs with
+// settings, or nil when they start whisper.cpp per job.
+func (a *App) whisperServerFor(settings domain.Settings) *transcribe.WhisperServer {
+	if settings.WhisperEngine != domain.WhisperEngineServer {
+		return nil
+	}
+	return a.whisperServer
+}
+
+// stopWhisperServer frees the warm server's model memory, e.g. on shutdown
+// or when settings switch back to per-job runs.
+func (a *App) stopWhisperServer() {
+	if a.whisperServer != nil {
+		a.whisperServer.Close()
+	}
+}
+
+// draftModelPath returns the model of the draft pre-pass, or "" when drafts
+// are off or would only repeat the configured model.
+func draftModelPath(settings domain.Settings) string {
+	if settings.DraftModelPath == settings.ModelPath {
+		return ""
+	}
+	return settings.DraftModelPath
+}
+
+// whisperGrammar returns the grammar settings in the form the pipeline takes.
+func whisperGrammar(settings domain.Settings) transcribe.Grammar {
+	return transcribe.Grammar{
+		Mode:       settings.Grammar,
+		Vocabulary: settings.GrammarVocabulary,
+		Path:       settings.GrammarPath,
+		Rule:       settings.GrammarRule,
+		Penalty:    settings.GrammarPenalty,
+	}
+}
+
+// skippedMessage summarizes the silence and music cut from a job's audio.
+func skippedMessage(skipped []transcribe.SkippedRegion) string {
+	var total int64
+	for _, region := range skipped {
+		total += region.EndMs - region.StartMs
+	}
+	return fmt.Sprintf("Skipped %d silence or music regions totalling %s", len(skipped), time.Duration(total)*time.Millisecond)
+}
@@ -0,0 +1,32 @@
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestWhisperServerForFollowsEngineSetting hands the warm server only to
+// jobs configured for the server engine.
+func TestWhisperServerForFollowsEngineSetting(t *testing.T) {
+	server := transcribe.NewWhisperServer(time.Minute)
+	defer server.Close()
+	app := &App{whisperServer: server}
+
+	if got := app.whisperServerFor(domain.Settings{WhisperEngine: domain.WhisperEngineServer}); got != server {
+		t.Fatalf("server engine got %v, want the warm server", got)
+	}
+	if got := app.whisperServerFor(normalizeSettings(domain.Settings{})); got != nil {
+		t.Fatalf("default engine got %v, want nil", got)
+	}
+}
+
+// TestSkippedMessageTotalsRegions reports how much audio was cut.
+func TestSkippedMessageTotalsRegions(t *testing.T) {
+	got := skippedMessage([]transcribe.SkippedRegion{{StartMs: 0, EndMs: 19500}, {StartMs: 60000, EndMs: 90500}})
+	if want := "Skipped 2 silence or music regions totalling 50s"; got != want {
+		t.Fatalf("message = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+const (
+	// defaultRealTimeFactor is assumed for models without a benchmark (real time).
+	defaultRealTimeFactor = 1.0
+	probeTimeout          = 30 * time.Second
+)
+
+// EstimateQueue probes each input with ffprobe and predicts total transcription
+// time and temp disk use with the configured model, without queueing anything.
+func (a *App) EstimateQueue(inputPaths []string) (domain.QueueEstimate, error) {
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.QueueEstimate{}, fmt.Errorf("load settings: %w", err)
+	}
+	return a.estimateQueue(inputPaths, normalizeSettings(settings))
+}
+
+// estimateQueue probes inputPaths one by one and folds them into an estimate.
+func (a *App) estimateQueue(inputPaths []string, settings domain.Settings) (domain.QueueEstimate, error) {
+	var benchmarks map[string]domain.BenchmarkResult
+	if a.Benchmarks != nil {
+		loaded, err := a.Benchmarks.Load()
+		if err != nil {
+			return domain.QueueEstimate{}, fmt.Errorf("load benchmarks: %w", err)
+		}
+		benchmarks = loaded
+	}
+
+	ffprobePath := transcribe.FFprobePath(settings.FFmpegPath)
+	files := make([]domain.FileEstimate, 0, len(inputPaths))
+	for _, inputPath := range inputPaths {
+		file := domain.FileEstimate{InputPath: inputPath}
+		info, err := os.Stat(inputPath)
+		if err != nil {
+			file.Error = err.Error()
+			files = append(files, file)
+			continue
+		}
+		file.SizeBytes = info.Size()
+
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		duration, err := transcribe.ProbeDuration(ctx, ffprobePath, inputPath)
+		cancel()
+		if err != nil {
+			file.Error = err.Error()
+		} else {
+			file.DurationSeconds = duration
+		}
+		files = append(files, file)
+	}
+
+	result, measured := benchmarkForModel(settings.ModelPath, benchmarks)
+	return buildQueueEstimate(files, settings.ModelPath, result.RealTimeFactor, measured, settings.KeepIntermediates), nil
+}
+
+// benchmarkForModel finds the stored benchmark for modelPath, matching the exact
+// path first and then the catalog model with the same file name.
+func benchmarkForModel(modelPath string, benchmarks map[string]domain.BenchmarkResult) (domain.BenchmarkResult, bool) {
+	modelPath = strings.TrimSpace(modelPath)
+	if modelPath == "" {
+		return domain.BenchmarkResult{}, false
+	}
+	for _, result := range benchmarks {
+		if result.RealTimeFactor > 0 && result.ModelPath == modelPath {
+			return result, true
+		}
+	}
+	fileName := filepath.Base(modelPath)
+	for _, model := range whisperModelCatalog {
+		if model.FileName != fileName {
+			continue
+		}
+		if result, ok := benchmarks[model.ID]; ok && result.RealTimeFactor > 0 {
+			return result, true
+		}
+	}
+	return domain.BenchmarkResult{}, false
+}
+
+// buildQueueEstimate totals probed files. Files with a probe error still count
+// toward input size but are left out of duration, time, and workspace totals.
+func buildQueueEstimate(files []domain.FileEstimate, modelPath string, realTimeFactor float64, measured, keepIntermediates bool) domain.QueueEstimate {
+	if !measured || realTimeFactor <= 0 {
+		realTimeFactor = defaultRealTimeFactor
+		measured = false
+	}
+
+	estimate := domain.QueueEstimate{
+		ModelPath:      modelPath,
+		RealTimeFactor: realTimeFactor,
+		Measured:       measured,
+		Files:          files,
+	}
+	for i := range estimate.Files {
+		file := &estimate.Files[i]
+		estimate.InputBytes += file.SizeBytes
+		if file.Error != "" {
+			estimate.Unprobed++
+			continue
+		}
+		file.EstimatedSeconds = file.DurationSeconds * realTimeFactor
+		estimate.TotalDurationSeconds += file.DurationSeconds
+		estimate.EstimatedSeconds += file.EstimatedSeconds
+
+		workspace := int64(file.DurationSeconds * transcribe.PreprocessedBytesPerSecond)
+		if keepIntermediates {
+			estimate.WorkspaceBytes += workspace
+		} else if workspace > estimate.WorkspaceBytes {
+			estimate.WorkspaceBytes = workspace
+		}
+	}
+	return estimate
+}
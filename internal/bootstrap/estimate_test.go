@@ -0,0 +1,66 @@
+package bootstrap
+
+import (
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestBenchmarkForModel matches benchmarks by exact path, then by catalog file name.
+func TestBenchmarkForModel(t *testing.T) {
+	benchmarks := map[string]domain.BenchmarkResult{
+		"base":  {ModelID: "base", ModelPath: "/models/ggml-base.bin", RealTimeFactor: 0.2},
+		"small": {ModelID: "small", ModelPath: "/old/ggml-small.bin", RealTimeFactor: 0.4},
+	}
+	tests := []struct {
+		name      string
+		modelPath string
+		wantRTF   float64
+		wantFound bool
+	}{
+		{name: "exact path", modelPath: "/models/ggml-base.bin", wantRTF: 0.2, wantFound: true},
+		{name: "moved catalog file", modelPath: "/new/ggml-small.bin", wantRTF: 0.4, wantFound: true},
+		{name: "unbenchmarked", modelPath: "/models/ggml-medium.bin"},
+		{name: "empty", modelPath: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := benchmarkForModel(tt.modelPath, benchmarks)
+			if found != tt.wantFound || got.RealTimeFactor != tt.wantRTF {
+				t.Fatalf("got %+v found=%v, want rtf %v found=%v", got, found, tt.wantRTF, tt.wantFound)
+			}
+		})
+	}
+}
+
+// TestBuildQueueEstimate totals durations, time, and workspace size.
+func TestBuildQueueEstimate(t *testing.T) {
+	files := []domain.FileEstimate{
+		{InputPath: "a.mp3", SizeBytes: 100, DurationSeconds: 60},
+		{InputPath: "b.mp3", SizeBytes: 200, DurationSeconds: 120},
+		{InputPath: "c.mp3", SizeBytes: 50, Error: "media duration is unknown"},
+	}
+
+	estimate := buildQueueEstimate(append([]domain.FileEstimate(nil), files...), "m.bin", 0.5, true, false)
+	if estimate.TotalDurationSeconds != 180 || estimate.EstimatedSeconds != 90 {
+		t.Fatalf("durations = %v/%v, want 180/90", estimate.TotalDurationSeconds, estimate.EstimatedSeconds)
+	}
+	if estimate.InputBytes != 350 || estimate.Unprobed != 1 || !estimate.Measured {
+		t.Fatalf("estimate = %+v", estimate)
+	}
+	if estimate.WorkspaceBytes != 120*32000 {
+		t.Fatalf("workspace = %d, want largest file only", estimate.WorkspaceBytes)
+	}
+	if estimate.Files[1].EstimatedSeconds != 60 {
+		t.Fatalf("file estimate = %v, want 60", estimate.Files[1].EstimatedSeconds)
+	}
+
+	kept := buildQueueEstimate(append([]domain.FileEstimate(nil), files...), "m.bin", 0, false, true)
+	if kept.RealTimeFactor != defaultRealTimeFactor || kept.Measured {
+		t.Fatalf("expected default factor, got %+v", kept)
+	}
+	if kept.WorkspaceBytes != 180*32000 {
+		t.Fatalf("workspace = %d, want every file when intermediates are kept", kept.WorkspaceBytes)
+	}
+}
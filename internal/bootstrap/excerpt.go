@@ -0,0 +1,116 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/subtitle"
+)
+
+// ExportExcerpt quotes the part of a finished job's transcript between
+// startMs and endMs with a citation line naming the file, the time range, and
+// the recording date, for quoting a source. Every segment overlapping the
+// range is quoted whole. The excerpt is written next to the transcript as
+// <name>.excerpt-HH-MM-SS-HH-MM-SS.<format>; with withAudio the matching audio
+// is cut from the input into a WAV of the same name.
+func (a *App) ExportExcerpt(jobID string, startMs, endMs int64, format domain.ExcerptFormat, withAudio bool) (domain.Excerpt, error) {
+	if format == "" {
+		format = domain.ExcerptFormatText
+	}
+	if format != domain.ExcerptFormatText && format != domain.ExcerptFormatMarkdown {
+		return domain.Excerpt{}, fmt.Errorf("unknown excerpt format %q", format)
+	}
+	if startMs < 0 || endMs <= startMs {
+		return domain.Excerpt{}, fmt.Errorf("excerpt end must be after its start")
+	}
+	entry, err := a.findHistoryEntry(jobID)
+	if err != nil {
+		return domain.Excerpt{}, err
+	}
+	source, _, cues, err := readJobCues(entry)
+	if err != nil {
+		return domain.Excerpt{}, err
+	}
+	var quoted []subtitle.Cue
+	for _, cue := range cues {
+		if cue.EndMs > startMs && cue.StartMs < endMs {
+			quoted = append(quoted, cue)
+		}
+	}
+	if len(quoted) == 0 {
+		return domain.Excerpt{}, fmt.Errorf("no speech between %s and %s", citationTime(startMs), citationTime(endMs))
+	}
+
+	excerpt := domain.Excerpt{
+		Text:    excerptText(quoted),
+		StartMs: quoted[0].StartMs,
+		EndMs:   quoted[len(quoted)-1].EndMs,
+	}
+	excerpt.Citation = excerptCitation(entry, excerpt.StartMs, excerpt.EndMs)
+	base := fmt.Sprintf("%s.excerpt-%s-%s", source,
+		strings.ReplaceAll(citationTime(excerpt.StartMs), ":", "-"), strings.ReplaceAll(citationTime(excerpt.EndMs), ":", "-"))
+	excerpt.Path = base + "." + string(format)
+	if err := os.WriteFile(excerpt.Path, []byte(formatExcerpt(excerpt, format)), 0o644); err != nil {
+		return domain.Excerpt{}, fmt.Errorf("write excerpt: %w", err)
+	}
+
+	if withAudio {
+		if _, err := os.Stat(entry.InputPath); err != nil {
+			return excerpt, fmt.Errorf("cannot access input: %w", err)
+		}
+		settings, err := a.Store.Load()
+		if err != nil {
+			return excerpt, fmt.Errorf("load settings: %w", err)
+		}
+		audioPath := base + ".wav"
+		if err := cutClip(settings.FFmpegPath, entry.InputPath, audioPath, subtitle.Cue{StartMs: excerpt.StartMs, EndMs: excerpt.EndMs}); err != nil {
+			return excerpt, err
+		}
+		excerpt.AudioPath = audioPath
+	}
+	a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeLog, Message: "Excerpt exported", TextPath: excerpt.Path})
+	return excerpt, nil
+}
+
+// excerptText joins the quoted segments into one paragraph.
+func excerptText(cues []subtitle.Cue) string {
+	parts := make([]string, 0, len(cues))
+	for _, cue := range cues {
+		if text := strings.Join(strings.Fields(cue.Text), " "); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// excerptCitation names the source of a quote: the input file, the quoted
+// time range, and the date of the recording, which is the meeting's date when
+// one is known and otherwise the date the job finished.
+func excerptCitation(entry domain.HistoryEntry, startMs, endMs int64) string {
+	date := entry.FinishedAt
+	if entry.Meeting != nil && !entry.Meeting.Start.IsZero() {
+		date = entry.Meeting.Start
+	}
+	citation := fmt.Sprintf("%s, %s–%s", filepath.Base(entry.InputPath), citationTime(startMs), citationTime(endMs))
+	if !date.IsZero() {
+		citation += ", " + date.Local().Format("2006-01-02")
+	}
+	return citation
+}
+
+// formatExcerpt renders the quote and its citation in format.
+func formatExcerpt(excerpt domain.Excerpt, format domain.ExcerptFormat) string {
+	if format == domain.ExcerptFormatMarkdown {
+		return "> " + excerpt.Text + "\n>\n> — " + excerpt.Citation + "\n"
+	}
+	return "“" + excerpt.Text + "”\n— " + excerpt.Citation + "\n"
+}
+
+// citationTime renders milliseconds as HH:MM:SS.
+func citationTime(ms int64) string {
+	return fmt.Sprintf("%02d:%02d:%02d", ms/3600000, ms/60000%60, ms/1000%60)
+}
@@ -0,0 +1,140 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// newExcerptApp returns an app with one finished job of talk.mp4 whose
+// subtitles hold three segments.
+func newExcerptApp(t *testing.T, settings domain.Settings) (*App, string) {
+	t.Helper()
+	root := t.TempDir()
+	input := filepath.Join(root, "talk.mp4")
+	srt := filepath.Join(root, "talk.srt")
+	mustWrite(t, input, "media")
+	mustWrite(t, srt, "1\n00:00:01,000 --> 00:00:04,000\nWe moved to\nKubernetes last year.\n\n"+
+		"2\n00:00:04,000 --> 00:00:07,500\nIt took six months.\n\n"+
+		"3\n00:01:10,000 --> 00:01:12,000\nQuestions?\n")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{{
+		ID:            "job-1",
+		InputPath:     input,
+		Status:        domain.JobStatusDone,
+		SubtitlePaths: []string{srt},
+		FinishedAt:    time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	return &App{Store: &fakeStore{settings: settings}, History: history, events: jobs.NewEventBus(100)}, root
+}
+
+// TestExportExcerpt quotes every segment overlapping the range with a
+// citation, in text or Markdown.
+func TestExportExcerpt(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   domain.ExcerptFormat
+		wantFile string
+		want     string
+	}{
+		{
+			name:     "text",
+			wantFile: "talk.excerpt-00-00-01-00-00-07.txt",
+			want:     "“We moved to Kubernetes last year. It took six months.”\n— talk.mp4, 00:00:01–00:00:07, 2026-03-14\n",
+		},
+		{
+			name:     "markdown",
+			format:   domain.ExcerptFormatMarkdown,
+			wantFile: "talk.excerpt-00-00-01-00-00-07.md",
+			want:     "> We moved to Kubernetes last year. It took six months.\n>\n> — talk.mp4, 00:00:01–00:00:07, 2026-03-14\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, root := newExcerptApp(t, domain.Settings{})
+			excerpt, err := app.ExportExcerpt("job-1", 2000, 5000, tt.format, false)
+			if err != nil {
+				t.Fatalf("ExportExcerpt() error = %v", err)
+			}
+			if excerpt.StartMs != 1000 || excerpt.EndMs != 7500 || excerpt.AudioPath != "" {
+				t.Fatalf("excerpt = %+v, want the whole segments and no audio", excerpt)
+			}
+			if excerpt.Path != filepath.Join(root, tt.wantFile) {
+				t.Fatalf("path = %q, want %q", excerpt.Path, tt.wantFile)
+			}
+			data, err := os.ReadFile(excerpt.Path)
+			if err != nil {
+				t.Fatalf("read excerpt: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Fatalf("excerpt file = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
+
+// TestExportExcerptRejects fails on bad ranges, formats, and silent ranges
+// without writing anything.
+func TestExportExcerptRejects(t *testing.T) {
+	tests := []struct {
+		name           string
+		jobID          string
+		startMs, endMs int64
+		format         domain.ExcerptFormat
+		want           string
+	}{
+		{name: "reversed", jobID: "job-1", startMs: 5000, endMs: 2000, want: "after its start"},
+		{name: "format", jobID: "job-1", startMs: 0, endMs: 2000, format: "pdf", want: "unknown excerpt format"},
+		{name: "silence", jobID: "job-1", startMs: 20000, endMs: 60000, want: "no speech between 00:00:20 and 00:01:00"},
+		{name: "missing job", jobID: "job-9", startMs: 0, endMs: 2000, want: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, root := newExcerptApp(t, domain.Settings{})
+			if _, err := app.ExportExcerpt(tt.jobID, tt.startMs, tt.endMs, tt.format, false); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("ExportExcerpt() error = %v, want %q", err, tt.want)
+			}
+			if matches, _ := filepath.Glob(filepath.Join(root, "*.excerpt-*")); len(matches) != 0 {
+				t.Fatalf("files written: %v", matches)
+			}
+		})
+	}
+}
+
+// TestExportExcerptAudio cuts the quoted segments' audio with ffmpeg.
+func TestExportExcerptAudio(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("the fake ffmpeg is a shell script")
+	}
+	ffmpeg := filepath.Join(t.TempDir(), "ffmpeg")
+	// Records its arguments in the output file, its last argument.
+	mustWrite(t, ffmpeg, "#!/bin/sh\nfor last; do :; done\necho \"$@\" > \"$last\"\n")
+	if err := os.Chmod(ffmpeg, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	app, root := newExcerptApp(t, domain.Settings{FFmpegPath: ffmpeg})
+
+	excerpt, err := app.ExportExcerpt("job-1", 70000, 71000, "", true)
+	if err != nil {
+		t.Fatalf("ExportExcerpt() error = %v", err)
+	}
+	if want := filepath.Join(root, "talk.excerpt-00-01-10-00-01-12.wav"); excerpt.AudioPath != want {
+		t.Fatalf("audio path = %q, want %q", excerpt.AudioPath, want)
+	}
+	args, err := os.ReadFile(excerpt.AudioPath)
+	if err != nil {
+		t.Fatalf("read clip: %v", err)
+	}
+	if !strings.Contains(string(args), "-ss 70.000 -i "+filepath.Join(root, "talk.mp4")+" -t 2.000") {
+		t.Fatalf("ffmpeg args = %q, want the segment's range", args)
+	}
+}
@@ -0,0 +1,322 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// defaultMediaExtensions matches the media file dialog filter.
+var defaultMediaExtensions = []string{".mp4", ".mov", ".mkv", ".avi", ".mp3", ".wav", ".m4a", ".flac", ".aac", ".ogg", ".webm"}
+
+// TranscribeFolder walks dir recursively, filters media files by options, skips
+// files whose transcript already exists in the output directory, and queues the
+// rest. Outputs mirror the input's subfolder below dir, so same-named files in
+// different subfolders do not overwrite each other. The discovery summary is published before anything is queued. With
+// options.DryRun nothing is queued and the summary carries a time/disk estimate;
+// with options.Merge the files end up in one transcript.
+func (a *App) TranscribeFolder(dir string, options domain.FolderScanOptions) (domain.FolderScanSummary, error) {
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.FolderScanSummary{}, fmt.Errorf("load settings: %w", err)
+	}
+	settings = normalizeSettings(settings)
+
+	summary, err := scanMediaFolder(strings.TrimSpace(dir), options, func(inputPath, subdir string) bool {
+		_, statErr := os.Stat(transcribe.TranscriptPath(filepath.Join(settings.OutputDir, subdir), inputPath))
+		return statErr == nil
+	})
+	if err != nil {
+		return domain.FolderScanSummary{}, err
+	}
+
+	a.publishEvent(jobs.Event{
+		Type: jobs.EventTypeLog,
+		Message: fmt.Sprintf("Found %d media files in %s: %d to transcribe, %d already transcribed, %d filtered out",
+			len(summary.Matched)+len(summary.SkippedExisting), summary.Root, len(summary.Matched), len(summary.SkippedExisting), summary.FilteredOut),
+	})
+
+	if options.DryRun {
+		estimate, err := a.estimateQueue(summary.Matched, settings)
+		if err != nil {
+			return domain.FolderScanSummary{}, err
+		}
+		summary.Estimate = &estimate
+		return summary, nil
+	}
+
+	if options.Merge != domain.MergeNone {
+		summary.Queued, err = a.mergeFolder(summary, options.Merge, settings)
+		if err != nil {
+			return domain.FolderScanSummary{}, err
+		}
+		return summary, nil
+	}
+	summary.Queued = a.enqueueFolder(summary.Root, summary.Matched)
+	return summary, nil
+}
+
+// EnqueueFile queues one input file behind the running job. Priority files jump
+// ahead of every normal queued file, e.g. an urgent recording during a batch.
+func (a *App) EnqueueFile(inputPath string, priority bool) (domain.QueuedJob, error) {
+	inputPath = strings.TrimSpace(inputPath)
+	if inputPath == "" {
+		return domain.QueuedJob{}, fmt.Errorf("input path is required")
+	}
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return domain.QueuedJob{}, fmt.Errorf("cannot access input file: %w", err)
+	}
+	if info.IsDir() {
+		return domain.QueuedJob{}, fmt.Errorf("input path is a directory: %s", inputPath)
+	}
+	return a.enqueue([]string{inputPath}, priority)[0], nil
+}
+
+// GetQueue returns the files waiting to run, in run order.
+func (a *App) GetQueue() []domain.QueuedJob {
+	return a.Queue.List()
+}
+
+// ReorderQueue moves the listed queued jobs to the front in the given order.
+func (a *App) ReorderQueue(ids []string) error {
+	if err := a.Queue.Reorder(ids); err != nil {
+		return fmt.Errorf("reorder queue: %w", err)
+	}
+	return nil
+}
+
+// RemoveFromQueue drops a queued job before it starts.
+func (a *App) RemoveFromQueue(id string) error {
+	if _, err := a.Queue.Remove(id); err != nil {
+		return fmt.Errorf("remove %s from queue: %w", id, err)
+	}
+	// The removed file may have been the last one a merge batch waited for.
+	a.settleMergeBatches(nil)
+	return nil
+}
+
+// SetQueuePriority flags or unflags a queued job as urgent.
+func (a *App) SetQueuePriority(id string, priority bool) (domain.QueuedJob, error) {
+	job, err := a.Queue.SetPriority(id, priority)
+	if err != nil {
+		return domain.QueuedJob{}, fmt.Errorf("set priority of %s: %w", id, err)
+	}
+	return job, nil
+}
+
+// enqueue queues input files and starts the first one when no job is running.
+func (a *App) enqueue(inputPaths []string, priority bool) []domain.QueuedJob {
+	queued := newQueuedJobs(inputPaths)
+	for i := range queued {
+		queued[i].Priority = priority
+	}
+	return a.pushQueued(queued)
+}
+
+// enqueueFolder queues files found below root, each writing its outputs to
+// the same subfolder of the output directory.
+func (a *App) enqueueFolder(root string, inputPaths []string) []domain.QueuedJob {
+	queued := newQueuedJobs(inputPaths)
+	for i := range queued {
+		queued[i].OutputSubdir = folderOutputSubdir(root, queued[i].InputPath)
+	}
+	return a.pushQueued(queued)
+}
+
+// newQueuedJobs builds queue entries for input files, enqueued now.
+func newQueuedJobs(inputPaths []string) []domain.QueuedJob {
+	now := time.Now().UTC()
+	queued := make([]domain.QueuedJob, 0, len(inputPaths))
+	for i, inputPath := range inputPaths {
+		queued = append(queued, domain.QueuedJob{
+			ID:         fmt.Sprintf("queued-%d-%d", now.UnixNano(), i),
+			InputPath:  inputPath,
+			EnqueuedAt: now,
+		})
+	}
+	return queued
+}
+
+// pushQueued adds jobs to the queue and starts the first one when no job is
+// running.
+func (a *App) pushQueued(queued []domain.QueuedJob) []domain.QueuedJob {
+	if len(queued) == 0 {
+		return nil
+	}
+	a.Queue.Push(queued...)
+	a.startNextQueued()
+	return queued
+}
+
+// folderOutputSubdir returns the folder of inputPath relative to root, or ""
+// for files directly in root.
+func folderOutputSubdir(root, inputPath string) string {
+	relative, err := filepath.Rel(root, filepath.Dir(inputPath))
+	if err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+		return ""
+	}
+	return relative
+}
+
+// startNextQueued starts queued files until one is running or the queue is empty.
+func (a *App) startNextQueued() {
+	if a.Queue == nil {
+		return
+	}
+	a.queueMu.Lock()
+	defer a.queueMu.Unlock()
+	for !a.Jobs.IsRunning() {
+		next, ok := a.Queue.Pop()
+		if !ok {
+			return
+		}
+		var err error
+		if next.Settings != nil {
+			settings := *next.Settings
+			settings.OutputDir = outputSubdir(settings.OutputDir, next.OutputSubdir)
+			_, err = a.startTranscription(next.InputPath, settings, jobOptions{resubmitOf: next.ResubmitOf})
+		} else {
+			_, err = a.startStoredSettings(next.InputPath, next.OutputSubdir)
+		}
+		if err != nil {
+			a.publishEvent(jobs.Event{
+				Type:    jobs.EventTypeError,
+				Message: fmt.Sprintf("start queued file %s: %v", next.InputPath, err),
+			})
+		}
+	}
+}
+
+// outputSubdir returns subdir below outputDir, or outputDir itself when
+// either is empty.
+func outputSubdir(outputDir, subdir string) string {
+	if outputDir == "" || subdir == "" {
+		return outputDir
+	}
+	return filepath.Join(outputDir, subdir)
+}
+
+// scanMediaFolder walks root and sorts every media file into matched, skipped
+// (transcript exists), or filtered out. transcriptExists gets the file's
+// folder relative to root. Results are in walk (lexical) order.
+func scanMediaFolder(root string, options domain.FolderScanOptions, transcriptExists func(inputPath, subdir string) bool) (domain.FolderScanSummary, error) {
+	if root == "" {
+		return domain.FolderScanSummary{}, fmt.Errorf("folder path is required")
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return domain.FolderScanSummary{}, fmt.Errorf("cannot access folder: %w", err)
+	}
+	if !info.IsDir() {
+		return domain.FolderScanSummary{}, fmt.Errorf("not a folder: %s", root)
+	}
+
+	extensions := options.Extensions
+	if len(extensions) == 0 {
+		extensions = defaultMediaExtensions
+	}
+
+	summary := domain.FolderScanSummary{Root: root, Matched: []string{}, SkippedExisting: []string{}}
+	err = filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			// Unreadable subdirectories are skipped rather than failing the scan.
+			if entry != nil && entry.IsDir() && filePath != root {
+				return filepath.SkipDir
+			}
+			return walkErr
+		}
+		if entry.IsDir() || !hasExtension(filePath, extensions) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		relative, err := filepath.Rel(root, filePath)
+		if err != nil {
+			relative = entry.Name()
+		}
+		if !passesFolderFilters(filepath.ToSlash(relative), info, options) {
+			summary.FilteredOut++
+			return nil
+		}
+		if !options.Retranscribe && transcriptExists(filePath, folderOutputSubdir(root, filePath)) {
+			summary.SkippedExisting = append(summary.SkippedExisting, filePath)
+			return nil
+		}
+
+		summary.Matched = append(summary.Matched, filePath)
+		summary.TotalBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return domain.FolderScanSummary{}, fmt.Errorf("scan folder: %w", err)
+	}
+	return summary, nil
+}
+
+// hasExtension reports whether the file has one of the extensions, case-insensitively.
+func hasExtension(filePath string, extensions []string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	for _, candidate := range extensions {
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if !strings.HasPrefix(candidate, ".") {
+			candidate = "." + candidate
+		}
+		if ext == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// passesFolderFilters applies glob, size, and modification-date filters. Globs
+// match either the slash-separated path relative to the root or the file name.
+func passesFolderFilters(relative string, info fs.FileInfo, options domain.FolderScanOptions) bool {
+	if len(options.Include) > 0 && !matchesAnyGlob(relative, options.Include) {
+		return false
+	}
+	if matchesAnyGlob(relative, options.Exclude) {
+		return false
+	}
+	if options.MinSizeBytes > 0 && info.Size() < options.MinSizeBytes {
+		return false
+	}
+	if options.MaxSizeBytes > 0 && info.Size() > options.MaxSizeBytes {
+		return false
+	}
+	if !options.ModifiedAfter.IsZero() && !info.ModTime().After(options.ModifiedAfter) {
+		return false
+	}
+	if !options.ModifiedBefore.IsZero() && !info.ModTime().Before(options.ModifiedBefore) {
+		return false
+	}
+	return true
+}
+
+// matchesAnyGlob reports whether the relative path or its base name matches a pattern.
+func matchesAnyGlob(relative string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, relative); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(relative)); ok {
+			return true
+		}
+	}
+	return false
+}
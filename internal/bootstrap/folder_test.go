@@ -0,0 +1,242 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestScanMediaFolderAppliesFilters checks extension, glob, size, date, and transcript filters.
+func TestScanMediaFolderAppliesFilters(t *testing.T) {
+	root := t.TempDir()
+	old := time.Now().Add(-72 * time.Hour)
+	files := map[string]string{
+		"a/talk.mp3":        "0123456789",
+		"a/b/interview.WAV": "0123456789",
+		"a/done.m4a":        "0123456789",
+		"notes.txt":         "not media",
+		"tiny.mp3":          "1",
+		"drafts/skip.mp3":   "0123456789",
+		"archive/old.flac":  "0123456789",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		mustWrite(t, path, content)
+	}
+	if err := os.Chtimes(filepath.Join(root, "archive", "old.flac"), old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	summary, err := scanMediaFolder(root, domain.FolderScanOptions{
+		Exclude:       []string{"drafts/*"},
+		MinSizeBytes:  5,
+		ModifiedAfter: time.Now().Add(-24 * time.Hour),
+	}, func(inputPath, subdir string) bool {
+		return filepath.Base(inputPath) == "done.m4a" && subdir == "a"
+	})
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	wantMatched := []string{
+		filepath.Join(root, "a", "b", "interview.WAV"),
+		filepath.Join(root, "a", "talk.mp3"),
+	}
+	if len(summary.Matched) != len(wantMatched) {
+		t.Fatalf("matched = %v, want %v", summary.Matched, wantMatched)
+	}
+	for i := range wantMatched {
+		if summary.Matched[i] != wantMatched[i] {
+			t.Fatalf("matched = %v, want %v", summary.Matched, wantMatched)
+		}
+	}
+	if len(summary.SkippedExisting) != 1 || summary.FilteredOut != 3 || summary.TotalBytes != 20 {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	if _, err := scanMediaFolder(filepath.Join(root, "notes.txt"), domain.FolderScanOptions{}, nil); err == nil {
+		t.Fatal("expected error for a file path")
+	}
+}
+
+// TestTranscribeFolderRunsQueuedFilesInOrder checks queued files run one after another.
+func TestTranscribeFolderRunsQueuedFilesInOrder(t *testing.T) {
+	root := t.TempDir()
+	outputDir := t.TempDir()
+	for _, name := range []string{"1.mp3", "2.mp3", "3.mp3"} {
+		mustWrite(t, filepath.Join(root, name), "audio")
+	}
+	mustWrite(t, filepath.Join(outputDir, "2.txt"), "already done")
+
+	var mu sync.Mutex
+	var ran []string
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{OutputDir: outputDir}},
+		Jobs:  jobs.NewManager(),
+		Queue: jobs.NewQueue(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			mu.Lock()
+			ran = append(ran, filepath.Base(req.InputPath))
+			mu.Unlock()
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	summary, err := app.TranscribeFolder(root, domain.FolderScanOptions{})
+	if err != nil {
+		t.Fatalf("transcribe folder: %v", err)
+	}
+	if len(summary.Queued) != 2 || len(summary.SkippedExisting) != 1 {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		done := len(ran) == 2
+		mu.Unlock()
+		if done && !app.Jobs.IsRunning() {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(ran) != 2 || ran[0] != "1.mp3" || ran[1] != "3.mp3" {
+		t.Fatalf("ran = %v, want [1.mp3 3.mp3]", ran)
+	}
+}
+
+// TestTranscribeFolderMirrorsSubfolders checks same-named files in different
+// subfolders get separate transcripts and are skipped only by their own.
+func TestTranscribeFolderMirrorsSubfolders(t *testing.T) {
+	root := t.TempDir()
+	outputDir := t.TempDir()
+	for _, name := range []string{"a/talk.mp3", "b/talk.mp3", "c/talk.mp3", "talk.mp3"} {
+		mustWrite(t, filepath.Join(root, filepath.FromSlash(name)), "audio")
+	}
+	mustWrite(t, filepath.Join(outputDir, "c", "talk.txt"), "already done")
+
+	var mu sync.Mutex
+	var outputs []string
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{OutputDir: outputDir}},
+		Jobs:  jobs.NewManager(),
+		Queue: jobs.NewQueue(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			mu.Lock()
+			outputs = append(outputs, transcribe.TranscriptPath(req.OutputDir, req.InputPath))
+			mu.Unlock()
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	summary, err := app.TranscribeFolder(root, domain.FolderScanOptions{})
+	if err != nil {
+		t.Fatalf("transcribe folder: %v", err)
+	}
+	if len(summary.Queued) != 3 || len(summary.SkippedExisting) != 1 || summary.SkippedExisting[0] != filepath.Join(root, "c", "talk.mp3") {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		done := len(outputs) == 3
+		mu.Unlock()
+		if done && !app.Jobs.IsRunning() {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{
+		filepath.Join(outputDir, "a", "talk.txt"),
+		filepath.Join(outputDir, "b", "talk.txt"),
+		filepath.Join(outputDir, "talk.txt"),
+	}
+	if !reflect.DeepEqual(outputs, want) {
+		t.Fatalf("outputs = %v, want %v", outputs, want)
+	}
+}
+
+// mustWrite creates parent directories and writes content.
+func mustWrite(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// TestQueueManagementAPI covers priority enqueue, reorder, and removal through the App.
+func TestQueueManagementAPI(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.mp3", "b.mp3", "urgent.mp3"} {
+		mustWrite(t, filepath.Join(root, name), "audio")
+	}
+
+	app := &App{Jobs: jobs.NewManager(), Queue: jobs.NewQueue(), events: jobs.NewEventBus(10)}
+	// Occupy the job slot so enqueued files stay queued.
+	if err := app.Jobs.Start("busy", "busy.mp3"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	a, err := app.EnqueueFile(filepath.Join(root, "a.mp3"), false)
+	if err != nil {
+		t.Fatalf("enqueue a: %v", err)
+	}
+	b, _ := app.EnqueueFile(filepath.Join(root, "b.mp3"), false)
+	urgent, _ := app.EnqueueFile(filepath.Join(root, "urgent.mp3"), true)
+	if _, err := app.EnqueueFile(root, false); err == nil {
+		t.Fatal("expected error for a directory")
+	}
+
+	assertIDs := func(want ...string) {
+		t.Helper()
+		queue := app.GetQueue()
+		if len(queue) != len(want) {
+			t.Fatalf("queue = %+v, want %v", queue, want)
+		}
+		for i := range want {
+			if queue[i].ID != want[i] {
+				t.Fatalf("queue = %+v, want %v", queue, want)
+			}
+		}
+	}
+	assertIDs(urgent.ID, a.ID, b.ID)
+
+	if err := app.ReorderQueue([]string{b.ID}); err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+	assertIDs(b.ID, urgent.ID, a.ID)
+
+	if err := app.RemoveFromQueue(urgent.ID); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := app.RemoveFromQueue(urgent.ID); !errors.Is(err, jobs.ErrQueuedJobNotFound) {
+		t.Fatalf("err = %v, want ErrQueuedJobNotFound", err)
+	}
+	if _, err := app.SetQueuePriority(a.ID, true); err != nil {
+		t.Fatalf("set priority: %v", err)
+	}
+	assertIDs(a.ID, b.ID)
+}
@@ -0,0 +1,303 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/config"
+)
+
+const (
+	// githubRetries is how many times a rate-limited or failed release
+	// metadata request is retried.
+	githubRetries = 3
+	// githubRetryDelay is the first retry backoff, doubled for each retry.
+	githubRetryDelay = 2 * time.Second
+	// githubMaxRetryWait caps the wait a Retry-After or rate-limit reset asks
+	// for; longer waits fall back to the cached release instead.
+	githubMaxRetryWait = time.Minute
+)
+
+// githubTokenEnv names the optional token for GitHub API requests, which
+// lifts the 60 requests an hour GitHub allows anonymous users on a shared IP.
+// It is read when no token is stored with SetGitHubToken, and GITHUB_TOKEN
+// when it is unset too.
+const githubTokenEnv = config.EnvPrefix + "GITHUB_TOKEN"
+
+// errGithubRateLimited marks a response refused by GitHub's rate limit.
+var errGithubRateLimited = errors.New("GitHub API rate limit exceeded")
+
+// cachedRelease is the last successful release metadata of one URL, kept so
+// unchanged releases are revalidated with If-None-Match and installs still
+// work while the API refuses requests.
+type cachedRelease struct {
+	ETag    string          `json:"etag,omitempty"`
+	Release json.RawMessage `json:"release"`
+}
+
+// releaseFetcher fetches GitHub release metadata with an optional token,
+// ETag revalidation, and retries with backoff.
+type releaseFetcher struct {
+	client *http.Client
+	token  string
+	// cacheDir holds one cachedRelease per URL; empty disables the cache.
+	cacheDir string
+	sleep    func(time.Duration)
+}
+
+// fetchGithubRelease fetches release metadata from url with the shared
+// GitHub fetcher.
+func fetchGithubRelease(url, token string) (githubRelease, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadToolTimeout)
+	defer cancel()
+	return newReleaseFetcher(token).fetch(ctx, url)
+}
+
+// fetchGithubReleaseJSON returns the raw release metadata at url from the
+// shared GitHub fetcher, for callers that decode it themselves.
+func (a *App) fetchGithubReleaseJSON(ctx context.Context, url string) ([]byte, error) {
+	return newReleaseFetcher(a.githubToken()).fetchJSON(ctx, url)
+}
+
+// newReleaseFetcher returns the fetcher every GitHub release request goes
+// through: token and the release cache in the tools directory.
+func newReleaseFetcher(token string) releaseFetcher {
+	fetcher := releaseFetcher{client: http.DefaultClient, token: token, sleep: time.Sleep}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		fetcher.cacheDir = filepath.Join(localToolsDir(homeDir), "github-releases")
+	}
+	return fetcher
+}
+
+// SetGitHubToken stores the token sent with GitHub release metadata requests.
+func (a *App) SetGitHubToken(token string) error {
+	return a.storeSecret(config.SecretGitHubToken, "GitHub token", token)
+}
+
+// HasGitHubToken reports whether a GitHub token is stored, without exposing it.
+func (a *App) HasGitHubToken() bool {
+	return a.secret(config.SecretGitHubToken) != ""
+}
+
+// githubToken returns the stored GitHub token, or the one from the
+// environment.
+func (a *App) githubToken() string {
+	return resolveGithubToken(a.secret(config.SecretGitHubToken), os.Getenv)
+}
+
+// resolveGithubToken returns stored, else the token from the environment,
+// empty when none is set.
+func resolveGithubToken(stored string, getenv func(string) string) string {
+	if token := strings.TrimSpace(stored); token != "" {
+		return token
+	}
+	if token := strings.TrimSpace(getenv(githubTokenEnv)); token != "" {
+		return token
+	}
+	return strings.TrimSpace(getenv("GITHUB_TOKEN"))
+}
+
+// fetch returns the release at url, decoded.
+func (f releaseFetcher) fetch(ctx context.Context, url string) (githubRelease, error) {
+	body, err := f.fetchJSON(ctx, url)
+	if err != nil {
+		return githubRelease{}, err
+	}
+	return decodeRelease(body)
+}
+
+// fetchJSON returns the release metadata at url. A 304 answer to the cached
+// ETag reuses the cached release; rate limits, server errors, and network
+// failures are retried, and when retries run out the cached release, however
+// old, is used.
+func (f releaseFetcher) fetchJSON(ctx context.Context, url string) ([]byte, error) {
+	cached, hasCache := f.loadCache(url)
+	var lastErr error
+	for attempt := 0; attempt <= githubRetries; attempt++ {
+		if attempt > 0 {
+			f.sleep(retryWait(lastErr, attempt))
+			if ctx.Err() != nil {
+				break
+			}
+		}
+		body, etag, err := f.request(ctx, url, cached.ETag)
+		switch {
+		case err == nil && body == nil:
+			// Not modified: the cached release is current.
+			if _, err := decodeRelease(cached.Release); err == nil {
+				return cached.Release, nil
+			}
+			cached, hasCache = cachedRelease{}, false
+			lastErr = fmt.Errorf("cached release metadata is invalid")
+			continue
+		case err == nil:
+			f.saveCache(url, cachedRelease{ETag: etag, Release: body})
+			return body, nil
+		}
+		lastErr = err
+		var retry *retryableError
+		if !errors.As(err, &retry) {
+			break
+		}
+	}
+	if hasCache {
+		if _, err := decodeRelease(cached.Release); err == nil {
+			return cached.Release, nil
+		}
+	}
+	if errors.Is(lastErr, errGithubRateLimited) && f.token == "" {
+		return nil, fmt.Errorf("%w; store a GitHub token with SetGitHubToken or set %s to raise the limit", lastErr, githubTokenEnv)
+	}
+	return nil, lastErr
+}
+
+// retryableError is a failed request worth repeating after wait, or after
+// the backoff when wait is zero.
+type retryableError struct {
+	err  error
+	wait time.Duration
+}
+
+// Error returns the message of the failed request.
+func (e *retryableError) Error() string { return e.err.Error() }
+
+// Unwrap returns the error of the failed request.
+func (e *retryableError) Unwrap() error { return e.err }
+
+// retryWait is the wait before retry attempt: what the server asked for, up
+// to githubMaxRetryWait, or the doubling backoff.
+func retryWait(err error, attempt int) time.Duration {
+	var retry *retryableError
+	if errors.As(err, &retry) && retry.wait > 0 {
+		return min(retry.wait, githubMaxRetryWait)
+	}
+	return githubRetryDelay << (attempt - 1)
+}
+
+// request performs one conditional request. A 304 returns a nil body; the
+// body of a 200, checked to be release metadata, is returned with its ETag.
+func (f releaseFetcher) request(ctx context.Context, url, etag string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("build release metadata request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "media-transcriber")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, "", &retryableError{err: fmt.Errorf("request release metadata: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return nil, "", nil
+	case resp.StatusCode == http.StatusOK:
+	case isRateLimited(resp):
+		return nil, "", &retryableError{err: errGithubRateLimited, wait: rateLimitWait(resp.Header, time.Now())}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, "", &retryableError{err: fmt.Errorf("release metadata request returned %s", resp.Status)}
+	default:
+		return nil, "", fmt.Errorf("release metadata request returned %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", &retryableError{err: fmt.Errorf("read release metadata: %w", err)}
+	}
+	if _, err := decodeRelease(body); err != nil {
+		return nil, "", err
+	}
+	return body, resp.Header.Get("ETag"), nil
+}
+
+// isRateLimited reports whether GitHub refused resp for its rate limit,
+// primary (no requests remaining) or secondary (Retry-After).
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode == http.StatusForbidden &&
+		(resp.Header.Get("X-RateLimit-Remaining") == "0" || resp.Header.Get("Retry-After") != "")
+}
+
+// rateLimitWait reads how long GitHub asks to wait from Retry-After seconds
+// or the X-RateLimit-Reset epoch; zero when neither is present.
+func rateLimitWait(header http.Header, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if wait := time.Unix(reset, 0).Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// decodeRelease parses release metadata that names a tag.
+func decodeRelease(data []byte) (githubRelease, error) {
+	var release githubRelease
+	if err := json.Unmarshal(data, &release); err != nil {
+		return githubRelease{}, fmt.Errorf("decode release metadata: %w", err)
+	}
+	if strings.TrimSpace(release.TagName) == "" {
+		return githubRelease{}, fmt.Errorf("release metadata did not include a tag name")
+	}
+	return release, nil
+}
+
+// cachePath is the cache file of url, named after its path.
+func (f releaseFetcher) cachePath(rawURL string) string {
+	name := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil {
+		name = parsed.Host + parsed.Path
+	}
+	return filepath.Join(f.cacheDir, sanitizeFileName(strings.ReplaceAll(strings.Trim(name, "/"), "/", "_"))+".json")
+}
+
+// loadCache returns the cached release of url, if any.
+func (f releaseFetcher) loadCache(url string) (cachedRelease, bool) {
+	if f.cacheDir == "" {
+		return cachedRelease{}, false
+	}
+	data, err := os.ReadFile(f.cachePath(url))
+	if err != nil {
+		return cachedRelease{}, false
+	}
+	var cached cachedRelease
+	if err := json.Unmarshal(data, &cached); err != nil || len(cached.Release) == 0 {
+		return cachedRelease{}, false
+	}
+	return cached, true
+}
+
+// saveCache stores the release of url. The cache only spares requests, so
+// failing to write it is not an error.
+func (f releaseFetcher) saveCache(url string, cached cachedRelease) {
+	if f.cacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(cached)
+	if err != nil || os.MkdirAll(f.cacheDir, 0o755) != nil {
+		return
+	}
+	_ = os.WriteFile(f.cachePath(url), data, 0o644)
+}
@@ -0,0 +1,212 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+)
+
+// githubServer serves the scripted responses in order and records the
+// requests it received.
+func githubServer(t *testing.T, responses ...func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, *[]*http.Request) {
+	t.Helper()
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if len(requests) > len(responses) {
+			t.Errorf("unexpected request %d", len(requests))
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		responses[len(requests)-1](w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// releaseOK answers with release tag and etag.
+func releaseOK(tag, etag string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(`{"tag_name":"` + tag + `","assets":[{"name":"whisper.zip","browser_download_url":"https://example.com/whisper.zip"}]}`))
+	}
+}
+
+// releaseStatus answers with status and header key/value pairs.
+func releaseStatus(status int, header ...string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i+1 < len(header); i += 2 {
+			w.Header().Set(header[i], header[i+1])
+		}
+		w.WriteHeader(status)
+	}
+}
+
+// TestReleaseFetcher revalidates cached releases, retries failures with
+// backoff, and falls back to the cache when GitHub keeps refusing.
+func TestReleaseFetcher(t *testing.T) {
+	rateLimited := releaseStatus(http.StatusForbidden, "X-RateLimit-Remaining", "0")
+	tests := []struct {
+		name      string
+		token     string
+		warm      bool
+		responses []func(w http.ResponseWriter, r *http.Request)
+		wantTag   string
+		wantErr   string
+		wantWaits []time.Duration
+	}{
+		{name: "fresh", responses: []func(w http.ResponseWriter, r *http.Request){releaseOK("v1.7.4", `"b"`)}, wantTag: "v1.7.4"},
+		{name: "not modified", warm: true, responses: []func(w http.ResponseWriter, r *http.Request){releaseStatus(http.StatusNotModified)}, wantTag: "v1.7.3"},
+		{
+			name:      "server error retried",
+			responses: []func(w http.ResponseWriter, r *http.Request){releaseStatus(http.StatusBadGateway), releaseStatus(http.StatusBadGateway), releaseOK("v1.7.4", `"b"`)},
+			wantTag:   "v1.7.4",
+			wantWaits: []time.Duration{2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:      "retry after honored",
+			responses: []func(w http.ResponseWriter, r *http.Request){releaseStatus(http.StatusTooManyRequests, "Retry-After", "7"), releaseOK("v1.7.4", `"b"`)},
+			wantTag:   "v1.7.4",
+			wantWaits: []time.Duration{7 * time.Second},
+		},
+		{
+			name:      "rate limited uses cache",
+			warm:      true,
+			responses: []func(w http.ResponseWriter, r *http.Request){rateLimited, rateLimited, rateLimited, rateLimited},
+			wantTag:   "v1.7.3",
+			wantWaits: []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{
+			name:      "rate limited without cache",
+			responses: []func(w http.ResponseWriter, r *http.Request){rateLimited, rateLimited, rateLimited, rateLimited},
+			wantErr:   "set MEDIA_TRANSCRIBER_GITHUB_TOKEN",
+			wantWaits: []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{
+			name:      "rate limited with token",
+			token:     "secret",
+			responses: []func(w http.ResponseWriter, r *http.Request){rateLimited, rateLimited, rateLimited, rateLimited},
+			wantErr:   "rate limit exceeded",
+			wantWaits: []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{name: "not found", responses: []func(w http.ResponseWriter, r *http.Request){releaseStatus(http.StatusNotFound)}, wantErr: "404 Not Found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := githubServer(t, tt.responses...)
+			var waits []time.Duration
+			fetcher := releaseFetcher{
+				client:   server.Client(),
+				token:    tt.token,
+				cacheDir: t.TempDir(),
+				sleep:    func(d time.Duration) { waits = append(waits, d) },
+			}
+			url := server.URL + "/repos/ggml-org/whisper.cpp/releases/latest"
+			if tt.warm {
+				fetcher.saveCache(url, cachedRelease{ETag: `"a"`, Release: []byte(`{"tag_name":"v1.7.3"}`)})
+			}
+
+			release, err := fetcher.fetch(context.Background(), url)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("fetch() error = %v, want %q", err, tt.wantErr)
+				}
+				if tt.token != "" && strings.Contains(err.Error(), githubTokenEnv) {
+					t.Fatalf("fetch() error = %v, suggests a token although one is set", err)
+				}
+			} else if err != nil || release.TagName != tt.wantTag {
+				t.Fatalf("fetch() = %q, %v, want %q", release.TagName, err, tt.wantTag)
+			}
+			if len(*requests) != len(tt.responses) {
+				t.Fatalf("requests = %d, want %d", len(*requests), len(tt.responses))
+			}
+			if strings.Join(durations(waits), " ") != strings.Join(durations(tt.wantWaits), " ") {
+				t.Fatalf("waits = %v, want %v", waits, tt.wantWaits)
+			}
+
+			first := (*requests)[0]
+			if wantETag := map[bool]string{true: `"a"`}[tt.warm]; first.Header.Get("If-None-Match") != wantETag {
+				t.Fatalf("If-None-Match = %q, want %q", first.Header.Get("If-None-Match"), wantETag)
+			}
+			if wantAuth := map[bool]string{true: "Bearer " + tt.token}[tt.token != ""]; first.Header.Get("Authorization") != wantAuth {
+				t.Fatalf("Authorization = %q, want %q", first.Header.Get("Authorization"), wantAuth)
+			}
+		})
+	}
+}
+
+// TestReleaseFetcherCachesSuccess stores a fetched release with its ETag for
+// the next request.
+func TestReleaseFetcherCachesSuccess(t *testing.T) {
+	server, requests := githubServer(t, releaseOK("v1.7.4", `"b"`), releaseStatus(http.StatusNotModified))
+	fetcher := releaseFetcher{client: server.Client(), cacheDir: t.TempDir(), sleep: func(time.Duration) {}}
+	url := server.URL + "/repos/ggml-org/whisper.cpp/releases/latest"
+
+	if _, err := fetcher.fetch(context.Background(), url); err != nil {
+		t.Fatalf("first fetch() error = %v", err)
+	}
+	release, err := fetcher.fetch(context.Background(), url)
+	if err != nil {
+		t.Fatalf("second fetch() error = %v", err)
+	}
+	if release.TagName != "v1.7.4" || len(release.Assets) != 1 || release.Assets[0].Name != "whisper.zip" {
+		t.Fatalf("cached release = %+v", release)
+	}
+	if got := (*requests)[1].Header.Get("If-None-Match"); got != `"b"` {
+		t.Fatalf("If-None-Match = %q, want the stored ETag", got)
+	}
+}
+
+// TestResolveGithubToken prefers the stored token, then the app's variable
+// over GITHUB_TOKEN.
+func TestResolveGithubToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored string
+		env    map[string]string
+		want   string
+	}{
+		{name: "none", env: map[string]string{}, want: ""},
+		{name: "generic", env: map[string]string{"GITHUB_TOKEN": "generic"}, want: "generic"},
+		{name: "app first", env: map[string]string{"GITHUB_TOKEN": "generic", githubTokenEnv: " app "}, want: "app"},
+		{name: "stored first", stored: " stored ", env: map[string]string{"GITHUB_TOKEN": "generic", githubTokenEnv: "app"}, want: "stored"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveGithubToken(tt.stored, func(key string) string { return tt.env[key] }); got != tt.want {
+				t.Fatalf("resolveGithubToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGitHubTokenFromSecretStore reads the token SetGitHubToken stored.
+func TestGitHubTokenFromSecretStore(t *testing.T) {
+	t.Setenv(githubTokenEnv, "")
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	app := &App{Secrets: config.NewFileSecretStore(filepath.Join(t.TempDir(), "secrets.json"))}
+	if app.HasGitHubToken() || app.githubToken() != "from-env" {
+		t.Fatalf("token before store = %q, want the environment's", app.githubToken())
+	}
+	if err := app.SetGitHubToken(" ghp_stored "); err != nil {
+		t.Fatalf("set token: %v", err)
+	}
+	if !app.HasGitHubToken() || app.githubToken() != "ghp_stored" {
+		t.Fatalf("token = %q, want the stored one", app.githubToken())
+	}
+}
+
+// durations renders waits for comparison.
+func durations(waits []time.Duration) []string {
+	out := make([]string, len(waits))
+	for i, wait := range waits {
+		out[i] = wait.String()
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strconv"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
+	"media-transcriber/internal/transcribe"
+)
+
+// whisperDevice resolves the GPUDevice setting to the device passed to
+// whisper.cpp. GPUs are detected only when the setting names one; an index
+// is passed through unchecked when none can be listed, since nvidia-smi does
+// not see every GPU whisper.cpp can use.
+func whisperDevice(setting string, detect func() []sysinfo.GPU) (string, error) {
+	switch setting {
+	case "":
+		return "", nil
+	case domain.GPUDeviceCPU:
+		return transcribe.CPUDevice, nil
+	}
+	gpus := detect()
+	if len(gpus) == 0 {
+		if _, err := strconv.Atoi(setting); err == nil {
+			return setting, nil
+		}
+		return "", fmt.Errorf("GPU %q was not found: no GPUs were detected", setting)
+	}
+	gpu, err := sysinfo.SelectGPU(gpus, setting)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(gpu.Index), nil
+}
+
+// whisperGPUs lists the GPUs whisper.cpp may load the model onto under the
+// GPUDevice setting: none for the CPU, the selected one, or all of them.
+func whisperGPUs(setting string, detect func() []sysinfo.GPU) []sysinfo.GPU {
+	if setting == domain.GPUDeviceCPU {
+		return nil
+	}
+	gpus := detect()
+	if setting == "" {
+		return gpus
+	}
+	gpu, err := sysinfo.SelectGPU(gpus, setting)
+	if err != nil {
+		return nil
+	}
+	return []sysinfo.GPU{gpu}
+}
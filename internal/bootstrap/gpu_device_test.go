@@ -0,0 +1,67 @@
+package bootstrap
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestWhisperDevice resolves the GPU setting to whisper.cpp's device index.
+func TestWhisperDevice(t *testing.T) {
+	twoGPUs := []sysinfo.GPU{{Index: 0, Name: "NVIDIA GeForce RTX 3060"}, {Index: 1, Name: "NVIDIA GeForce RTX 3090"}}
+	tests := []struct {
+		name    string
+		setting string
+		gpus    []sysinfo.GPU
+		want    string
+		wantErr string
+	}{
+		{name: "default", gpus: twoGPUs},
+		{name: "cpu", setting: domain.GPUDeviceCPU, want: transcribe.CPUDevice},
+		{name: "by name", setting: "3090", gpus: twoGPUs, want: "1"},
+		{name: "by index", setting: "0", gpus: twoGPUs, want: "0"},
+		{name: "unlisted index", setting: "2", want: "2"},
+		{name: "unknown", setting: "4090", gpus: twoGPUs, wantErr: `no GPU matches "4090"`},
+		{name: "no gpus", setting: "rtx", wantErr: "no GPUs were detected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detected := false
+			got, err := whisperDevice(tt.setting, func() []sysinfo.GPU {
+				detected = true
+				return tt.gpus
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("whisperDevice() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("whisperDevice() = %q, %v, want %q", got, err, tt.want)
+			}
+			if detected && (tt.setting == "" || tt.setting == domain.GPUDeviceCPU) {
+				t.Fatal("GPUs were detected for a setting that names none")
+			}
+		})
+	}
+}
+
+// TestWhisperGPUs limits the memory guard to the GPUs the setting allows.
+func TestWhisperGPUs(t *testing.T) {
+	gpus := []sysinfo.GPU{{Index: 0, Name: "RTX 3060"}, {Index: 1, Name: "RTX 3090"}}
+	detect := func() []sysinfo.GPU { return gpus }
+	if got := whisperGPUs("", detect); !reflect.DeepEqual(got, gpus) {
+		t.Fatalf("default = %v, want all GPUs", got)
+	}
+	if got := whisperGPUs(domain.GPUDeviceCPU, detect); got != nil {
+		t.Fatalf("cpu = %v, want none", got)
+	}
+	if got := whisperGPUs("3090", detect); len(got) != 1 || got[0].Index != 1 {
+		t.Fatalf("3090 = %v, want GPU 1", got)
+	}
+}
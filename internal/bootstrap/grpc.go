@@ -0,0 +1,502 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "media-transcriber/api/gen/mediatranscriber/v1"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// artifactChunkSize is how many bytes one DownloadArtifact message carries.
+const artifactChunkSize = 64 << 10
+
+// grpcScopes maps every gRPC method to the token scope it needs, like the
+// scope column of apiRoutes. Methods missing here are refused.
+var grpcScopes = map[string]domain.TokenScope{
+	pb.JobService_SubmitJob_FullMethodName:              domain.TokenScopeSubmit,
+	pb.JobService_UploadJob_FullMethodName:              domain.TokenScopeSubmit,
+	pb.JobService_GetCurrentJob_FullMethodName:          domain.TokenScopeRead,
+	pb.JobService_CancelCurrentJob_FullMethodName:       domain.TokenScopeSubmit,
+	pb.JobService_ListQueue_FullMethodName:              domain.TokenScopeRead,
+	pb.JobService_RemoveQueuedJob_FullMethodName:        domain.TokenScopeSubmit,
+	pb.JobService_ListHistory_FullMethodName:            domain.TokenScopeRead,
+	pb.JobService_ListArtifacts_FullMethodName:          domain.TokenScopeRead,
+	pb.JobService_DownloadArtifact_FullMethodName:       domain.TokenScopeRead,
+	pb.JobService_StreamEvents_FullMethodName:           domain.TokenScopeRead,
+	pb.ModelService_ListModels_FullMethodName:           domain.TokenScopeRead,
+	pb.ModelService_ListLocalModels_FullMethodName:      domain.TokenScopeRead,
+	pb.DiagnosticsService_GetDiagnostics_FullMethodName: domain.TokenScopeRead,
+	pb.DiagnosticsService_CheckReadiness_FullMethodName: domain.TokenScopeRead,
+}
+
+// grpcServer returns the gRPC server of serve mode with every service of
+// api/proto registered. Event streams end once streams is done, so a
+// graceful stop does not wait for them.
+func (a *App) grpcServer(streams context.Context) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := a.authorizeGRPC(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := a.authorizeGRPC(stream.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	)
+	pb.RegisterJobServiceServer(server, &grpcJobService{app: a, streams: streams})
+	pb.RegisterModelServiceServer(server, &grpcModelService{app: a})
+	pb.RegisterDiagnosticsServiceServer(server, &grpcDiagnosticsService{app: a})
+	return server
+}
+
+// authorizeGRPC applies the REST token rules to the "authorization" metadata
+// of a call to method.
+func (a *App) authorizeGRPC(ctx context.Context, method string) error {
+	scope, ok := grpcScopes[method]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "method %s has no scope", method)
+	}
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+	var remoteAddr string
+	if client, ok := peer.FromContext(ctx); ok {
+		remoteAddr = client.Addr.String()
+	}
+	err := a.authorize(scope, authorization, remoteAddr)
+	var missing scopeError
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, errUnauthorized):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, errNoTokens), errors.Is(err, errRemoteWithoutTokens), errors.As(err, &missing):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// grpcError maps App errors to gRPC status codes like apiErrorStatus.
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, jobs.ErrQueuedJobNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, jobs.ErrNoRunningJob), errors.Is(err, jobs.ErrJobAlreadyRunning):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// errDrainingGRPC refuses new jobs while the server shuts down.
+var errDrainingGRPC = status.Error(codes.Unavailable, "server is shutting down")
+
+// grpcJobService implements JobService on top of the App.
+type grpcJobService struct {
+	pb.UnimplementedJobServiceServer
+	app *App
+	// streams ends StreamEvents calls on shutdown.
+	streams context.Context
+}
+
+func (s *grpcJobService) SubmitJob(_ context.Context, req *pb.SubmitJobRequest) (*pb.QueuedJob, error) {
+	if s.app.draining.Load() {
+		return nil, errDrainingGRPC
+	}
+	queued, err := s.app.EnqueueFile(req.GetInputPath(), req.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return queuedJobProto(queued), nil
+}
+
+// UploadJob stores the streamed file in the upload directory and queues it,
+// like POST /api/uploads.
+func (s *grpcJobService) UploadJob(stream pb.JobService_UploadJobServer) error {
+	if s.app.draining.Load() {
+		return errDrainingGRPC
+	}
+	if s.app.uploadDir == "" {
+		return status.Error(codes.Unavailable, "uploads are not enabled")
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "receive upload name: %v", err)
+	}
+	name, err := uploadName(first.GetName())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	dir := filepath.Join(s.app.uploadDir, fmt.Sprintf("upload-%d", time.Now().UnixNano()))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return status.Errorf(codes.Internal, "create upload directory: %v", err)
+	}
+	path := filepath.Join(dir, name)
+	if err := writeUpload(path, &uploadReader{stream: stream}); err != nil {
+		_ = os.RemoveAll(dir)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	queued, err := s.app.EnqueueFile(path, first.GetPriority())
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return stream.SendAndClose(queuedJobProto(queued))
+}
+
+// uploadReader reads the data chunks of an UploadJob stream, at most
+// maxUploadBytes in total.
+type uploadReader struct {
+	stream pb.JobService_UploadJobServer
+	buf    []byte
+	read   int64
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	r.read += int64(n)
+	if r.read > maxUploadBytes {
+		return n, fmt.Errorf("upload exceeds %d bytes", int64(maxUploadBytes))
+	}
+	return n, nil
+}
+
+func (s *grpcJobService) GetCurrentJob(context.Context, *pb.GetCurrentJobRequest) (*pb.Job, error) {
+	return jobProto(s.app.CurrentJob()), nil
+}
+
+func (s *grpcJobService) CancelCurrentJob(context.Context, *pb.CancelCurrentJobRequest) (*pb.CancelCurrentJobResponse, error) {
+	if err := s.app.CancelTranscription(); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.CancelCurrentJobResponse{}, nil
+}
+
+func (s *grpcJobService) ListQueue(context.Context, *pb.ListQueueRequest) (*pb.ListQueueResponse, error) {
+	resp := &pb.ListQueueResponse{}
+	for _, queued := range s.app.GetQueue() {
+		resp.Jobs = append(resp.Jobs, queuedJobProto(queued))
+	}
+	return resp, nil
+}
+
+func (s *grpcJobService) RemoveQueuedJob(_ context.Context, req *pb.RemoveQueuedJobRequest) (*pb.RemoveQueuedJobResponse, error) {
+	if err := s.app.RemoveFromQueue(req.GetId()); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.RemoveQueuedJobResponse{}, nil
+}
+
+func (s *grpcJobService) ListHistory(_ context.Context, req *pb.ListHistoryRequest) (*pb.ListHistoryResponse, error) {
+	entries, err := s.app.ListHistory(domain.HistoryFilter{Tag: req.GetTag(), Query: req.GetQuery()})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListHistoryResponse{}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, historyEntryProto(entry))
+	}
+	return resp, nil
+}
+
+func (s *grpcJobService) ListArtifacts(_ context.Context, req *pb.ListArtifactsRequest) (*pb.ListArtifactsResponse, error) {
+	entry, err := s.app.findHistoryEntry(req.GetJobId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	resp := &pb.ListArtifactsResponse{}
+	for _, path := range jobArtifacts(entry) {
+		resp.Names = append(resp.Names, filepath.Base(path))
+	}
+	return resp, nil
+}
+
+func (s *grpcJobService) DownloadArtifact(req *pb.DownloadArtifactRequest, stream pb.JobService_DownloadArtifactServer) error {
+	entry, err := s.app.findHistoryEntry(req.GetJobId())
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	for _, path := range jobArtifacts(entry) {
+		if filepath.Base(path) != req.GetName() {
+			continue
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "open artifact: %v", err)
+		}
+		defer file.Close()
+		buf := make([]byte, artifactChunkSize)
+		for {
+			n, err := file.Read(buf)
+			if n > 0 {
+				if err := stream.Send(&pb.DownloadArtifactResponse{Chunk: buf[:n]}); err != nil {
+					return err
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return status.Errorf(codes.Internal, "read artifact: %v", err)
+			}
+		}
+	}
+	return status.Errorf(codes.NotFound, "job %s has no artifact %q", entry.ID, req.GetName())
+}
+
+// StreamEvents replays buffered events after since_seq and then streams new
+// ones, like GET /api/events/stream. A subscriber that falls too far behind
+// gets Unavailable and resumes with the last seq it saw.
+func (s *grpcJobService) StreamEvents(req *pb.StreamEventsRequest, stream pb.JobService_StreamEventsServer) error {
+	filter := jobs.EventFilter{JobID: req.GetJobId()}
+	for _, eventType := range req.GetTypes() {
+		filter.Types = append(filter.Types, jobs.EventType(eventType))
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	stop := context.AfterFunc(s.streams, cancel)
+	defer stop()
+
+	// Subscribe before replaying so no event falls between the two.
+	live := s.app.events.Subscribe(ctx)
+	// Headers go out now, like the SSE flush, so a client knows the stream
+	// is live before the first event.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	last := req.GetSinceSeq()
+	send := func(event jobs.Event) error {
+		if event.Seq <= last {
+			return nil
+		}
+		last = event.Seq
+		return stream.Send(eventProto(event))
+	}
+	for _, event := range s.app.events.Query(last, filter) {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-live:
+			if !ok {
+				return status.Errorf(codes.Unavailable, "event stream fell behind after seq %d", last)
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// grpcModelService implements ModelService on top of the App.
+type grpcModelService struct {
+	pb.UnimplementedModelServiceServer
+	app *App
+}
+
+func (s *grpcModelService) ListModels(context.Context, *pb.ListModelsRequest) (*pb.ListModelsResponse, error) {
+	resp := &pb.ListModelsResponse{}
+	for _, model := range s.app.GetWhisperModels() {
+		resp.Models = append(resp.Models, &pb.WhisperModel{
+			Id:         model.ID,
+			Name:       model.Name,
+			FileName:   model.FileName,
+			SizeLabel:  model.SizeLabel,
+			Languages:  model.Languages,
+			Downloaded: model.Downloaded,
+			LocalPath:  model.LocalPath,
+		})
+	}
+	return resp, nil
+}
+
+func (s *grpcModelService) ListLocalModels(context.Context, *pb.ListLocalModelsRequest) (*pb.ListLocalModelsResponse, error) {
+	models, err := s.app.ListLocalModels()
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &pb.ListLocalModelsResponse{}
+	for _, model := range models {
+		resp.Models = append(resp.Models, &pb.LocalModel{
+			Path:         model.Path,
+			FileName:     model.FileName,
+			SizeBytes:    model.SizeBytes,
+			ModelType:    model.ModelType,
+			Quantization: model.Quantization,
+			Multilingual: model.Multilingual,
+			InUse:        model.InUse,
+			Error:        model.Error,
+		})
+	}
+	return resp, nil
+}
+
+// grpcDiagnosticsService implements DiagnosticsService on top of the App.
+type grpcDiagnosticsService struct {
+	pb.UnimplementedDiagnosticsServiceServer
+	app *App
+}
+
+func (s *grpcDiagnosticsService) GetDiagnostics(context.Context, *pb.GetDiagnosticsRequest) (*pb.DiagnosticReport, error) {
+	return diagnosticReportProto(s.app.Diagnostics), nil
+}
+
+// CheckReadiness reruns the local checks like GET /readyz; failed checks are
+// reported with ready false rather than as an error.
+func (s *grpcDiagnosticsService) CheckReadiness(context.Context, *pb.CheckReadinessRequest) (*pb.Readiness, error) {
+	if s.app.draining.Load() {
+		return nil, errDrainingGRPC
+	}
+	if s.app.checker == nil {
+		return nil, status.Error(codes.Unavailable, "diagnostics are unavailable")
+	}
+	settings, err := s.app.Store.Load()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "load settings: %v", err)
+	}
+	report := s.app.checker.RunLocal(settings)
+	return &pb.Readiness{Ready: !report.HasFailures, Report: diagnosticReportProto(report)}, nil
+}
+
+// timestampProto converts t, leaving the zero time unset.
+func timestampProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// jobStatusProto maps a domain status to its JOB_STATUS_* value.
+func jobStatusProto(jobStatus domain.JobStatus) pb.JobStatus {
+	return pb.JobStatus(pb.JobStatus_value["JOB_STATUS_"+strings.ToUpper(string(jobStatus))])
+}
+
+func jobProto(job domain.Job) *pb.Job {
+	out := &pb.Job{
+		Id:         job.ID,
+		Status:     jobStatusProto(job.Status),
+		InputPath:  job.InputPath,
+		Stage:      job.Stage,
+		Progress:   job.Progress,
+		EtaSeconds: job.ETASeconds,
+		StartedAt:  timestampProto(job.StartedAt),
+	}
+	if job.FinishedAt != nil {
+		out.FinishedAt = timestampProto(*job.FinishedAt)
+	}
+	return out
+}
+
+func queuedJobProto(queued domain.QueuedJob) *pb.QueuedJob {
+	return &pb.QueuedJob{
+		Id:         queued.ID,
+		InputPath:  queued.InputPath,
+		EnqueuedAt: timestampProto(queued.EnqueuedAt),
+		Priority:   queued.Priority,
+		ResubmitOf: queued.ResubmitOf,
+	}
+}
+
+func historyEntryProto(entry domain.HistoryEntry) *pb.HistoryEntry {
+	return &pb.HistoryEntry{
+		Id:            entry.ID,
+		InputPath:     entry.InputPath,
+		Status:        jobStatusProto(entry.Status),
+		ModelPath:     entry.ModelPath,
+		Language:      entry.Language,
+		TextPath:      entry.TextPath,
+		SubtitlePaths: entry.SubtitlePaths,
+		Error:         entry.Error,
+		StartedAt:     timestampProto(entry.StartedAt),
+		FinishedAt:    timestampProto(entry.FinishedAt),
+		AudioSeconds:  entry.AudioSeconds,
+		Worker:        entry.Worker,
+		Tags:          entry.Tags,
+		ResubmitOf:    entry.ResubmitOf,
+	}
+}
+
+func eventProto(event jobs.Event) *pb.Event {
+	out := &pb.Event{
+		Seq:          event.Seq,
+		Timestamp:    timestampProto(event.Timestamp),
+		JobId:        event.JobID,
+		Type:         string(event.Type),
+		Status:       jobStatusProto(event.Status),
+		Message:      event.Message,
+		Command:      event.Command,
+		Args:         event.Args,
+		ExitCode:     int32(event.ExitCode),
+		Stdout:       event.Stdout,
+		Stderr:       event.Stderr,
+		TextPath:     event.TextPath,
+		DiagnosticId: event.DiagnosticID,
+		DownloadId:   event.DownloadID,
+		BytesDone:    event.BytesDone,
+		BytesTotal:   event.BytesTotal,
+		Text:         event.Text,
+		StartMs:      event.StartMs,
+		EndMs:        event.EndMs,
+		Speaker:      event.Speaker,
+	}
+	if event.Job != nil {
+		out.Job = jobProto(*event.Job)
+	}
+	return out
+}
+
+func diagnosticReportProto(report domain.DiagnosticReport) *pb.DiagnosticReport {
+	out := &pb.DiagnosticReport{
+		GeneratedAt: timestampProto(report.GeneratedAt),
+		HasFailures: report.HasFailures,
+	}
+	for _, item := range report.Items {
+		out.Items = append(out.Items, &pb.DiagnosticItem{
+			Id:      item.ID,
+			Name:    item.Name,
+			Status:  string(item.Status),
+			Message: item.Message,
+			Hint:    item.Hint,
+		})
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+package bootstrap
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "media-transcriber/api/gen/mediatranscriber/v1"
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/jobs"
+)
+
+// dialGRPC serves app's gRPC API over an in-memory listener and returns a
+// client connection to it.
+func dialGRPC(t *testing.T, app *App) *grpc.ClientConn {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := app.grpcServer(context.Background())
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// TestGRPCSubmitQueueAndEvents drives JobService like the REST test does.
+func TestGRPCSubmitQueueAndEvents(t *testing.T) {
+	release := make(chan struct{})
+	app, root := newServeTestApp(t, release)
+	app.uploadDir = filepath.Join(root, "uploads")
+	defer close(release)
+	client := pb.NewJobServiceClient(dialGRPC(t, app))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first := filepath.Join(root, "a.mp3")
+	mustWrite(t, first, "a")
+	if _, err := client.SubmitJob(ctx, &pb.SubmitJobRequest{InputPath: first}); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	_, err := client.SubmitJob(ctx, &pb.SubmitJobRequest{InputPath: filepath.Join(root, "missing.mp3")})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("missing file error = %v, want InvalidArgument", err)
+	}
+
+	upload, err := client.UploadJob(ctx)
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	for _, req := range []*pb.UploadJobRequest{
+		{Payload: &pb.UploadJobRequest_Name{Name: "b.mp3"}},
+		{Payload: &pb.UploadJobRequest_Chunk{Chunk: []byte("au")}},
+		{Payload: &pb.UploadJobRequest_Chunk{Chunk: []byte("dio")}},
+	} {
+		if err := upload.Send(req); err != nil {
+			t.Fatalf("send upload: %v", err)
+		}
+	}
+	uploaded, err := upload.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("close upload: %v", err)
+	}
+	if data, err := os.ReadFile(uploaded.GetInputPath()); err != nil || string(data) != "audio" {
+		t.Fatalf("uploaded file = %q, %v", data, err)
+	}
+
+	queue, err := client.ListQueue(ctx, &pb.ListQueueRequest{})
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	if len(queue.GetJobs()) != 1 || queue.GetJobs()[0].GetId() != uploaded.GetId() {
+		t.Fatalf("queue = %v", queue.GetJobs())
+	}
+	current, err := client.GetCurrentJob(ctx, &pb.GetCurrentJobRequest{})
+	if err != nil {
+		t.Fatalf("current job: %v", err)
+	}
+	if current.GetInputPath() != first {
+		t.Fatalf("current = %v", current)
+	}
+
+	stream, err := client.StreamEvents(ctx, &pb.StreamEventsRequest{JobId: current.GetId(), Types: []string{string(jobs.EventTypeStatus)}})
+	if err != nil {
+		t.Fatalf("stream events: %v", err)
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("receive event: %v", err)
+		}
+		if event.GetJobId() != current.GetId() || event.GetType() != string(jobs.EventTypeStatus) {
+			t.Fatalf("event outside the filter: %v", event)
+		}
+		if event.GetStatus() == pb.JobStatus_JOB_STATUS_TRANSCRIBING {
+			break
+		}
+	}
+
+	if _, err := client.RemoveQueuedJob(ctx, &pb.RemoveQueuedJobRequest{Id: "nope"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("remove unknown = %v, want NotFound", err)
+	}
+}
+
+// TestGRPCRequiresTokenScopes applies the REST token rules to gRPC metadata.
+func TestGRPCRequiresTokenScopes(t *testing.T) {
+	app := &App{
+		Tokens: config.NewJSONTokenStore(filepath.Join(t.TempDir(), "api-tokens.json")),
+		Jobs:   jobs.NewManager(),
+		Queue:  jobs.NewQueue(),
+		events: jobs.NewEventBus(10),
+	}
+	client := pb.NewJobServiceClient(dialGRPC(t, app))
+	call := func(secret string, submit bool) codes.Code {
+		ctx := context.Background()
+		if secret != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+secret)
+		}
+		var err error
+		if submit {
+			_, err = client.SubmitJob(ctx, &pb.SubmitJobRequest{})
+		} else {
+			_, err = client.ListQueue(ctx, &pb.ListQueueRequest{})
+		}
+		return status.Code(err)
+	}
+
+	if got := call("", false); got != codes.OK {
+		t.Fatalf("open server = %s, want OK before any token exists", got)
+	}
+	reader, err := app.CreateAPIToken("dashboard", []string{"read"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	submitter, err := app.CreateAPIToken("ci", []string{"submit"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		submit bool
+		want   codes.Code
+	}{
+		{name: "missing token", want: codes.Unauthenticated},
+		{name: "wrong token", secret: "mt_nope", want: codes.Unauthenticated},
+		{name: "read token reads", secret: reader.Secret, want: codes.OK},
+		{name: "read token cannot submit", secret: reader.Secret, submit: true, want: codes.PermissionDenied},
+		{name: "submit token submits", secret: submitter.Secret, submit: true, want: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := call(tt.secret, tt.submit); got != tt.want {
+				t.Fatalf("code = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestServeStopsGRPCEventStreams ends open gRPC event streams on shutdown
+// instead of waiting for them.
+func TestServeStopsGRPCEventStreams(t *testing.T) {
+	app, _ := newServeTestApp(t, nil)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	stop, requestStop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.serve(stop, context.Background(), listener, grpcListener, 0) }()
+
+	conn, err := grpc.NewClient(grpcListener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	stream, err := pb.NewJobServiceClient(conn).StreamEvents(context.Background(), &pb.StreamEventsRequest{})
+	if err != nil {
+		t.Fatalf("stream events: %v", err)
+	}
+	// A header round trip proves the stream is open before the stop.
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("stream header: %v", err)
+	}
+
+	requestStop()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serve: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return with an open gRPC event stream")
+	}
+	// The shutdown log event may still arrive; then the stream must end.
+	for range 10 {
+		if _, err := stream.Recv(); err != nil {
+			return
+		}
+	}
+	t.Fatal("event stream still open after shutdown")
+}
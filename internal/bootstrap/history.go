@@ -0,0 +1,220 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/analyze"
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/llm"
+	"media-transcriber/internal/translate"
+)
+
+// taggingTimeout bounds the LLM request made while tagging a transcript.
+const taggingTimeout = 2 * time.Minute
+
+// ListHistory returns finished jobs matching filter, newest first.
+func (a *App) ListHistory(filter domain.HistoryFilter) ([]domain.HistoryEntry, error) {
+	if a.History == nil {
+		return nil, nil
+	}
+	entries, err := a.History.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load history: %w", err)
+	}
+
+	out := make([]domain.HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		if matchesHistoryFilter(entry, filter) {
+			out = append(out, entry)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].FinishedAt.After(out[j].FinishedAt) })
+	return out, nil
+}
+
+// matchesHistoryFilter applies the tag and query filters case-insensitively.
+func matchesHistoryFilter(entry domain.HistoryEntry, filter domain.HistoryFilter) bool {
+	if tag := strings.TrimSpace(filter.Tag); tag != "" {
+		found := false
+		for _, candidate := range entry.Tags {
+			if strings.EqualFold(candidate, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+	if query == "" {
+		return true
+	}
+	if strings.Contains(strings.ToLower(filepath.Base(entry.InputPath)), query) {
+		return true
+	}
+	for _, tag := range entry.Tags {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// historyEntry starts the history record of a job that just reached status.
+func (a *App) historyEntry(jobID, inputPath string, settings domain.Settings, status domain.JobStatus, opts jobOptions) domain.HistoryEntry {
+	entry := domain.HistoryEntry{
+		ID:         jobID,
+		InputPath:  inputPath,
+		Status:     status,
+		ModelPath:  settings.ModelPath,
+		Language:   settings.Language,
+		FinishedAt: time.Now().UTC(),
+		ResubmitOf: opts.resubmitOf,
+		InputHash:  opts.inputHash,
+	}
+	if job := a.Jobs.Current(); job.ID == jobID {
+		entry.StartedAt = job.StartedAt
+	}
+	return entry
+}
+
+// recordHistory stores entry, replacing an earlier record with the same ID,
+// then merges any folder batch the job completed. Failures are reported as
+// events; history must never fail a finished job. The Open Recent menu is
+// rebuilt afterwards.
+func (a *App) recordHistory(entry domain.HistoryEntry) {
+	defer a.refreshMenu()
+	defer a.settleMergeBatches(&entry)
+	if a.History == nil {
+		return
+	}
+	a.historyMu.Lock()
+	defer a.historyMu.Unlock()
+
+	entries, err := a.History.Load()
+	if err == nil {
+		replaced := false
+		for i := range entries {
+			if entries[i].ID == entry.ID {
+				entries[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			entries = append(entries, entry)
+		}
+		err = a.History.Save(entries)
+	}
+	if err != nil {
+		a.publishEvent(jobs.Event{
+			JobID:   entry.ID,
+			Type:    jobs.EventTypeError,
+			Message: fmt.Sprintf("save job history: %v", err),
+		})
+	}
+}
+
+// tagTranscript extracts tags with the local rules. In LLM mode they stand in
+// until llmTagJob replaces them after the job.
+func (a *App) tagTranscript(settings domain.Settings, transcript string) []string {
+	if settings.Tagging == domain.TaggingOff || strings.TrimSpace(transcript) == "" {
+		return nil
+	}
+	return analyze.Local(transcript).Tags()
+}
+
+// llmTagJob replaces the local tags of a finished job with LLM tags, updating
+// its history record and metadata sidecar. An LLM failure is reported and keeps
+// the local tags. It returns the entry with the tags it ended up with.
+func (a *App) llmTagJob(ctx context.Context, entry domain.HistoryEntry, rec recording, settings domain.Settings, transcript string) domain.HistoryEntry {
+	if settings.Tagging != domain.TaggingLLM || strings.TrimSpace(transcript) == "" {
+		return entry
+	}
+	analysis, err := a.analyzeWithLLM(ctx, settings, transcript)
+	if err != nil {
+		a.publishEvent(jobs.Event{
+			JobID:   entry.ID,
+			Type:    jobs.EventTypeError,
+			Message: fmt.Sprintf("LLM tagging failed, using local rules: %v", err),
+		})
+		return entry
+	}
+	entry.Tags = meetingTags(analysis.Tags(), rec.meeting)
+	if entry.MetadataPath != "" {
+		if _, err := writeMetadataSidecar(entry.TextPath, rec, entry); err != nil {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("write metadata sidecar: %v", err)})
+		}
+	}
+	tags := entry.Tags
+	a.updateHistory(entry.ID, func(recorded *domain.HistoryEntry) { recorded.Tags = tags })
+	return entry
+}
+
+// updateHistory applies update to the recorded entry of a job, leaving
+// history alone when the entry was deleted in the meantime.
+func (a *App) updateHistory(jobID string, update func(entry *domain.HistoryEntry)) {
+	if a.History == nil {
+		return
+	}
+	a.historyMu.Lock()
+	defer a.historyMu.Unlock()
+
+	entries, err := a.History.Load()
+	if err == nil {
+		index := slices.IndexFunc(entries, func(entry domain.HistoryEntry) bool { return entry.ID == jobID })
+		if index < 0 {
+			return
+		}
+		update(&entries[index])
+		err = a.History.Save(entries)
+	}
+	if err != nil {
+		a.publishEvent(jobs.Event{
+			JobID:   jobID,
+			Type:    jobs.EventTypeError,
+			Message: fmt.Sprintf("save job history: %v", err),
+		})
+	}
+}
+
+// analyzeWithLLM runs the LLM analyzer against the translation backend settings.
+func (a *App) analyzeWithLLM(ctx context.Context, settings domain.Settings, transcript string) (analyze.Analysis, error) {
+	if settings.TranslationProvider != string(translate.ProviderOpenAI) {
+		return analyze.Analysis{}, fmt.Errorf("LLM tagging needs the %q translation provider", translate.ProviderOpenAI)
+	}
+	client, err := llm.NewClient(settings.TranslationEndpoint, a.secret(config.SecretTranslationAPIKey), settings.TranslationModel)
+	if err != nil {
+		return analyze.Analysis{}, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, taggingTimeout)
+	defer cancel()
+	return analyze.WithLLM(ctx, client, transcript)
+}
+
+// findHistoryEntry returns the history record of jobID.
+func (a *App) findHistoryEntry(jobID string) (domain.HistoryEntry, error) {
+	if a.History == nil {
+		return domain.HistoryEntry{}, fmt.Errorf("job history is unavailable")
+	}
+	entries, err := a.History.Load()
+	if err != nil {
+		return domain.HistoryEntry{}, fmt.Errorf("load history: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.ID == jobID {
+			return entry, nil
+		}
+	}
+	return domain.HistoryEntry{}, fmt.Errorf("job %s not found in history", jobID)
+}
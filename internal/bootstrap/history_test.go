@@ -0,0 +1,139 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestMatchesHistoryFilter covers tag and query matching.
+func TestMatchesHistoryFilter(t *testing.T) {
+	entry := domain.HistoryEntry{InputPath: "/rec/Board Meeting.mp3", Tags: []string{"carbon pricing", "Anna Petrova"}}
+	tests := []struct {
+		name   string
+		filter domain.HistoryFilter
+		want   bool
+	}{
+		{name: "empty", want: true},
+		{name: "tag exact", filter: domain.HistoryFilter{Tag: "anna petrova"}, want: true},
+		{name: "tag partial", filter: domain.HistoryFilter{Tag: "anna"}, want: false},
+		{name: "query file name", filter: domain.HistoryFilter{Query: "board"}, want: true},
+		{name: "query tag", filter: domain.HistoryFilter{Query: "pricing"}, want: true},
+		{name: "query directory", filter: domain.HistoryFilter{Query: "rec"}, want: false},
+		{name: "tag and query", filter: domain.HistoryFilter{Tag: "carbon pricing", Query: "zzz"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesHistoryFilter(entry, tt.filter); got != tt.want {
+				t.Fatalf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFinishedJobIsTaggedInHistory runs a job and finds it by an extracted tag.
+func TestFinishedJobIsTaggedInHistory(t *testing.T) {
+	root := t.TempDir()
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: root}},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{
+				TextPath:   filepath.Join(root, "talk.txt"),
+				Transcript: "We met Anna Petrova. Carbon pricing again and carbon pricing forever.",
+			}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	job, err := app.StartTranscription(filepath.Join(root, "talk.mp3"))
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	var entries []domain.HistoryEntry
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(entries) == 0 {
+		entries, err = app.ListHistory(domain.HistoryFilter{Tag: "Anna Petrova"})
+		if err != nil {
+			t.Fatalf("list history: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(entries) != 1 || entries[0].ID != job.ID || entries[0].Status != domain.JobStatusDone {
+		t.Fatalf("history = %+v", entries)
+	}
+	if entries[0].TextPath != filepath.Join(root, "talk.txt") || entries[0].StartedAt.IsZero() {
+		t.Fatalf("entry = %+v", entries[0])
+	}
+	if other, _ := app.ListHistory(domain.HistoryFilter{Tag: "nobody"}); len(other) != 0 {
+		t.Fatalf("unexpected match: %+v", other)
+	}
+}
+
+// TestLLMTaggingRunsAfterJob finishes the job with local tags while the LLM
+// is still answering, then replaces them with the LLM tags.
+func TestLLMTaggingRunsAfterJob(t *testing.T) {
+	root := t.TempDir()
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-release
+		w.Write([]byte(`{"choices":[{"message":{"content":"{\"keywords\":[\"budget\"],\"names\":[],\"topics\":[]}"}}]}`))
+	}))
+	defer server.Close()
+	settings := domain.Settings{
+		OutputDir:           root,
+		Tagging:             domain.TaggingLLM,
+		TranslationProvider: "openai",
+		TranslationEndpoint: server.URL,
+		TranslationModel:    "test",
+	}
+	app := &App{
+		Store:   &fakeStore{settings: settings},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{
+				TextPath:   filepath.Join(root, "talk.txt"),
+				Transcript: "Carbon pricing again and carbon pricing forever.",
+			}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	job, err := app.StartTranscription(filepath.Join(root, "talk.mp3"))
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	<-requested
+	waitForStatus(t, app, domain.JobStatusDone)
+	entry, err := app.findHistoryEntry(job.ID)
+	if err != nil || !slices.Contains(entry.Tags, "carbon pricing") {
+		t.Fatalf("entry before LLM = %+v, %v, want local tags", entry, err)
+	}
+
+	close(release)
+	if !app.waitFollowUps(context.Background()) {
+		t.Fatal("tagging did not finish")
+	}
+	entry, err = app.findHistoryEntry(job.ID)
+	if err != nil || !slices.Equal(entry.Tags, []string{"budget"}) {
+		t.Fatalf("entry after LLM = %+v, %v, want LLM tags", entry, err)
+	}
+}
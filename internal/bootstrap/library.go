@@ -0,0 +1,146 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"media-transcriber/internal/domain"
+)
+
+// previewRunes is the length of a transcript preview in characters.
+const previewRunes = 280
+
+// ListTranscripts returns one page of the transcript library: completed jobs
+// with a transcript, matching filter as in ListHistory, in the given order.
+// Previews are read only for the returned page. The library is read-only;
+// nothing is changed on disk.
+func (a *App) ListTranscripts(filter domain.HistoryFilter, order domain.TranscriptSort, page domain.PageRequest) (domain.TranscriptPage, error) {
+	less, err := transcriptOrder(order)
+	if err != nil {
+		return domain.TranscriptPage{}, err
+	}
+	page = normalizePage(page)
+	result := domain.TranscriptPage{Items: []domain.TranscriptSummary{}, Page: page.Page, Size: page.Size}
+	if a.History == nil {
+		return result, nil
+	}
+	entries, err := a.History.Load()
+	if err != nil {
+		return domain.TranscriptPage{}, fmt.Errorf("load history: %w", err)
+	}
+
+	var matched []domain.HistoryEntry
+	for _, entry := range entries {
+		if entry.Status == domain.JobStatusDone && entry.TextPath != "" && matchesHistoryFilter(entry, filter) {
+			matched = append(matched, entry)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return less(matched[i], matched[j]) })
+	result.Total = len(matched)
+
+	start := (page.Page - 1) * page.Size
+	if start >= len(matched) {
+		return result, nil
+	}
+	end := min(start+page.Size, len(matched))
+	for _, entry := range matched[start:end] {
+		result.Items = append(result.Items, transcriptSummary(entry))
+	}
+	return result, nil
+}
+
+// transcriptOrder returns the comparison for order; newest first breaks ties.
+func transcriptOrder(order domain.TranscriptSort) (func(a, b domain.HistoryEntry) bool, error) {
+	newest := func(a, b domain.HistoryEntry) bool { return a.FinishedAt.After(b.FinishedAt) }
+	switch order {
+	case domain.TranscriptSortNewest, "":
+		return newest, nil
+	case domain.TranscriptSortOldest:
+		return func(a, b domain.HistoryEntry) bool { return a.FinishedAt.Before(b.FinishedAt) }, nil
+	case domain.TranscriptSortName:
+		return func(a, b domain.HistoryEntry) bool {
+			nameA, nameB := strings.ToLower(filepath.Base(a.InputPath)), strings.ToLower(filepath.Base(b.InputPath))
+			if nameA != nameB {
+				return nameA < nameB
+			}
+			return newest(a, b)
+		}, nil
+	case domain.TranscriptSortDuration:
+		return func(a, b domain.HistoryEntry) bool {
+			if a.AudioSeconds != b.AudioSeconds {
+				return a.AudioSeconds > b.AudioSeconds
+			}
+			return newest(a, b)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown transcript sort %q", order)
+	}
+}
+
+// normalizePage fills in the first page and the default size and caps the size.
+func normalizePage(page domain.PageRequest) domain.PageRequest {
+	if page.Page < 1 {
+		page.Page = 1
+	}
+	if page.Size < 1 {
+		page.Size = domain.DefaultPageSize
+	}
+	page.Size = min(page.Size, domain.MaxPageSize)
+	return page
+}
+
+// transcriptSummary describes entry for the library, with a preview of its
+// transcript.
+func transcriptSummary(entry domain.HistoryEntry) domain.TranscriptSummary {
+	summary := domain.TranscriptSummary{
+		ID:            entry.ID,
+		Name:          filepath.Base(entry.InputPath),
+		InputPath:     entry.InputPath,
+		TextPath:      entry.TextPath,
+		SubtitlePaths: entry.SubtitlePaths,
+		AudioSeconds:  entry.AudioSeconds,
+		Tags:          entry.Tags,
+		Language:      entry.Language,
+		ModelPath:     entry.ModelPath,
+		FinishedAt:    entry.FinishedAt,
+	}
+	preview, err := transcriptPreview(entry.TextPath, previewRunes)
+	if err != nil {
+		summary.Missing = errors.Is(err, os.ErrNotExist)
+		return summary
+	}
+	summary.Preview = preview
+	return summary
+}
+
+// transcriptPreview returns the first limit characters of the transcript at
+// path with whitespace collapsed, cut at a word and ending in "…" when the
+// transcript is longer.
+func transcriptPreview(path string, limit int) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	// UTF-8 needs at most four bytes a character; extra room absorbs whitespace.
+	data, err := io.ReadAll(io.LimitReader(file, int64(limit)*8))
+	if err != nil {
+		return "", err
+	}
+	text := strings.Join(strings.Fields(strings.ToValidUTF8(string(data), "")), " ")
+	if utf8.RuneCountInString(text) <= limit {
+		return text, nil
+	}
+	runes := []rune(text)[:limit]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:") + "…", nil
+}
@@ -0,0 +1,157 @@
+package bootstrap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+)
+
+// newLibraryApp returns an app whose history holds three completed jobs, a
+// failed one, and a completed one whose transcript was deleted.
+func newLibraryApp(t *testing.T) (*App, string) {
+	t.Helper()
+	root := t.TempDir()
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	entry := func(id, name string, hours int, seconds float64, tags ...string) domain.HistoryEntry {
+		textPath := filepath.Join(root, name+".txt")
+		mustWrite(t, textPath, "Transcript of "+name+".\n")
+		return domain.HistoryEntry{
+			ID:           id,
+			InputPath:    filepath.Join(root, name+".mp4"),
+			Status:       domain.JobStatusDone,
+			TextPath:     textPath,
+			AudioSeconds: seconds,
+			Tags:         tags,
+			FinishedAt:   at.Add(time.Duration(hours) * time.Hour),
+		}
+	}
+	entries := []domain.HistoryEntry{
+		entry("job-1", "standup", 0, 600, "team"),
+		entry("job-2", "Interview", 1, 3600),
+		entry("job-3", "budget", 2, 1200, "team", "finance"),
+		{ID: "job-4", InputPath: filepath.Join(root, "broken.mp4"), Status: domain.JobStatusFailed, FinishedAt: at.Add(3 * time.Hour)},
+		{ID: "job-5", InputPath: filepath.Join(root, "gone.mp4"), Status: domain.JobStatusDone, TextPath: filepath.Join(root, "gone.txt"), FinishedAt: at.Add(-time.Hour)},
+	}
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save(entries); err != nil {
+		t.Fatal(err)
+	}
+	return &App{History: history}, root
+}
+
+// TestListTranscripts lists completed jobs with a transcript, filtered,
+// sorted, and paged.
+func TestListTranscripts(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    domain.HistoryFilter
+		order     domain.TranscriptSort
+		page      domain.PageRequest
+		wantIDs   []string
+		wantTotal int
+	}{
+		{name: "newest by default", wantIDs: []string{"job-3", "job-2", "job-1", "job-5"}, wantTotal: 4},
+		{name: "oldest", order: domain.TranscriptSortOldest, wantIDs: []string{"job-5", "job-1", "job-2", "job-3"}, wantTotal: 4},
+		{name: "name", order: domain.TranscriptSortName, wantIDs: []string{"job-3", "job-5", "job-2", "job-1"}, wantTotal: 4},
+		{name: "duration", order: domain.TranscriptSortDuration, wantIDs: []string{"job-2", "job-3", "job-1", "job-5"}, wantTotal: 4},
+		{name: "tag", filter: domain.HistoryFilter{Tag: "team"}, wantIDs: []string{"job-3", "job-1"}, wantTotal: 2},
+		{name: "query", filter: domain.HistoryFilter{Query: "inter"}, wantIDs: []string{"job-2"}, wantTotal: 1},
+		{name: "second page", page: domain.PageRequest{Page: 2, Size: 3}, wantIDs: []string{"job-5"}, wantTotal: 4},
+		{name: "past the end", page: domain.PageRequest{Page: 3, Size: 3}, wantIDs: []string{}, wantTotal: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _ := newLibraryApp(t)
+			page, err := app.ListTranscripts(tt.filter, tt.order, tt.page)
+			if err != nil {
+				t.Fatalf("ListTranscripts() error = %v", err)
+			}
+			ids := []string{}
+			for _, item := range page.Items {
+				ids = append(ids, item.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || page.Total != tt.wantTotal {
+				t.Fatalf("ListTranscripts() = %v of %d, want %v of %d", ids, page.Total, tt.wantIDs, tt.wantTotal)
+			}
+		})
+	}
+}
+
+// TestListTranscriptsSummaries carries previews and flags missing
+// transcripts, and rejects unknown sort orders.
+func TestListTranscriptsSummaries(t *testing.T) {
+	app, root := newLibraryApp(t)
+	page, err := app.ListTranscripts(domain.HistoryFilter{}, domain.TranscriptSortOldest, domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("ListTranscripts() error = %v", err)
+	}
+	if page.Page != 1 || page.Size != domain.DefaultPageSize {
+		t.Fatalf("page = %d of size %d, want the first default page", page.Page, page.Size)
+	}
+	gone, standup := page.Items[0], page.Items[1]
+	if !gone.Missing || gone.Preview != "" {
+		t.Fatalf("deleted transcript = %+v, want missing", gone)
+	}
+	if standup.Missing || standup.Preview != "Transcript of standup." || standup.Name != "standup.mp4" || standup.AudioSeconds != 600 {
+		t.Fatalf("summary = %+v", standup)
+	}
+	if standup.TextPath != filepath.Join(root, "standup.txt") || !reflect.DeepEqual(standup.Tags, []string{"team"}) {
+		t.Fatalf("summary paths and tags = %q %v", standup.TextPath, standup.Tags)
+	}
+
+	if _, err := app.ListTranscripts(domain.HistoryFilter{}, "size", domain.PageRequest{}); err == nil {
+		t.Fatal("ListTranscripts(unknown sort) error = nil")
+	}
+}
+
+// TestTranscriptPreview collapses whitespace and cuts long transcripts at a word.
+func TestTranscriptPreview(t *testing.T) {
+	tests := []struct {
+		name, text string
+		want       string
+	}{
+		{name: "short", text: "Hello,\n\n  world.\n", want: "Hello, world."},
+		{name: "long", text: "Привет мир, как дела сегодня", want: "Привет мир, как…"},
+		{name: "exact", text: "twenty characters ok", want: "twenty characters ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "t.txt")
+			mustWrite(t, path, tt.text)
+			got, err := transcriptPreview(path, 20)
+			if err != nil {
+				t.Fatalf("transcriptPreview() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("transcriptPreview() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTranscriptsAPI serves the library over REST and rejects bad paging.
+func TestTranscriptsAPI(t *testing.T) {
+	app, _ := newLibraryApp(t)
+	server := httptest.NewServer(app.serverHandler())
+	defer server.Close()
+
+	var page domain.TranscriptPage
+	getJSON(t, server.URL+"/api/transcripts?tag=team&sort=oldest&size=1&page=2", &page)
+	if page.Total != 2 || len(page.Items) != 1 || page.Items[0].ID != "job-3" {
+		t.Fatalf("page = %+v", page)
+	}
+	resp, err := http.Get(server.URL + "/api/transcripts?page=two")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("bad page status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,105 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/modelfile"
+)
+
+// ListLocalModels scans known model directories and returns files with parsed header metadata.
+func (a *App) ListLocalModels() ([]domain.LocalModel, error) {
+	settings, settingsErr := a.loadSettingsForModelCatalog()
+	dirs := resolveKnownModelDirs(settings, settingsErr == nil)
+	sort.Strings(dirs)
+
+	inUse := ""
+	if settingsErr == nil {
+		inUse = filepath.Clean(strings.TrimSpace(settings.ModelPath))
+	}
+
+	models := make([]domain.LocalModel, 0)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !isModelFileName(entry.Name()) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			model := domain.LocalModel{
+				Path:       path,
+				FileName:   entry.Name(),
+				SizeBytes:  info.Size(),
+				ModifiedAt: info.ModTime().UTC(),
+				InUse:      path == inUse,
+			}
+			if header, err := modelfile.Inspect(path); err != nil {
+				model.Error = err.Error()
+			} else {
+				model.Format = string(header.Format)
+				model.ModelType = header.ModelType
+				model.Quantization = header.Quantization
+				model.Multilingual = header.Multilingual
+			}
+			models = append(models, model)
+		}
+	}
+	return models, nil
+}
+
+// DeleteLocalModel removes one model file located inside a known model directory.
+func (a *App) DeleteLocalModel(path string) error {
+	target := filepath.Clean(strings.TrimSpace(path))
+	if target == "" || target == "." {
+		return fmt.Errorf("model path is required")
+	}
+	if !isModelFileName(target) {
+		return fmt.Errorf("not a model file: %s", target)
+	}
+
+	settings, settingsErr := a.loadSettingsForModelCatalog()
+	allowed := false
+	for _, dir := range resolveKnownModelDirs(settings, settingsErr == nil) {
+		if filepath.Dir(target) == dir && isWithinBaseDir(dir, target) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("model file is outside known model directories: %s", target)
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return fmt.Errorf("resolve model file: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("not a model file: %s", target)
+	}
+	if err := os.Remove(target); err != nil {
+		return fmt.Errorf("delete model file: %w", err)
+	}
+
+	if settingsErr == nil {
+		a.refreshDiagnosticsFromSettings(settings)
+	}
+	return nil
+}
+
+// isModelFileName reports whether a file name has a whisper model extension.
+func isModelFileName(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".bin" || ext == ".gguf"
+}
@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestListAndDeleteLocalModels checks scanning, header parsing, and deletion.
+func TestListAndDeleteLocalModels(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	modelDir := filepath.Join(t.TempDir(), "models")
+	if err := os.MkdirAll(modelDir, 0o755); err != nil {
+		t.Fatalf("mkdir models: %v", err)
+	}
+
+	validPath := filepath.Join(modelDir, "ggml-tiny.bin")
+	header := make([]byte, 0, 48)
+	for _, v := range []uint32{0x67676d6c, 51865, 1500, 384, 6, 4, 448, 384, 6, 4, 80, 1} {
+		header = binary.LittleEndian.AppendUint32(header, v)
+	}
+	if err := os.WriteFile(validPath, header, 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	brokenPath := filepath.Join(modelDir, "broken.gguf")
+	if err := os.WriteFile(brokenPath, []byte("<html>"), 0o644); err != nil {
+		t.Fatalf("write broken model: %v", err)
+	}
+
+	app := &App{Store: &fakeStore{settings: domain.Settings{ModelPath: validPath}}}
+	models, err := app.ListLocalModels()
+	if err != nil {
+		t.Fatalf("list models: %v", err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %d, want 2", len(models))
+	}
+
+	byName := map[string]domain.LocalModel{}
+	for _, model := range models {
+		byName[model.FileName] = model
+	}
+	tiny := byName["ggml-tiny.bin"]
+	if tiny.ModelType != "tiny" || tiny.Quantization != "f16" || !tiny.InUse || tiny.Error != "" {
+		t.Fatalf("unexpected tiny model metadata: %+v", tiny)
+	}
+	if byName["broken.gguf"].Error == "" {
+		t.Fatal("expected header error for broken model")
+	}
+
+	if err := app.DeleteLocalModel(brokenPath); err != nil {
+		t.Fatalf("delete model: %v", err)
+	}
+	if _, err := os.Stat(brokenPath); !os.IsNotExist(err) {
+		t.Fatalf("expected model to be deleted, stat err = %v", err)
+	}
+
+	outside := filepath.Join(t.TempDir(), "other.bin")
+	if err := os.WriteFile(outside, []byte("x"), 0o644); err != nil {
+		t.Fatalf("write outside file: %v", err)
+	}
+	if err := app.DeleteLocalModel(outside); err == nil {
+		t.Fatal("expected error deleting file outside model dirs")
+	}
+}
@@ -0,0 +1,43 @@
+package bootstrap
+
+import (
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/i18n"
+	"media-transcriber/internal/jobs"
+)
+
+// locale returns the message locale from the cached settings.
+func (a *App) locale() domain.Locale {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.Settings.Locale
+}
+
+// localizeError formats errors returned to the desktop UI in the settings locale.
+func (a *App) localizeError(err error) any {
+	return i18n.Translate(a.locale(), err.Error())
+}
+
+// localizeEvent returns a copy of event with its message in locale. The
+// published event itself stays English for the event log, metrics, and API.
+func localizeEvent(event jobs.Event, locale domain.Locale) jobs.Event {
+	event.Message = i18n.Translate(locale, event.Message)
+	return event
+}
+
+// localizeReport returns a copy of report with item names, messages, and
+// hints in locale; the cached report stays English.
+func localizeReport(report domain.DiagnosticReport, locale domain.Locale) domain.DiagnosticReport {
+	if report.Items == nil {
+		return report
+	}
+	items := make([]domain.DiagnosticItem, len(report.Items))
+	for i, item := range report.Items {
+		item.Name = i18n.Translate(locale, item.Name)
+		item.Message = i18n.Translate(locale, item.Message)
+		item.Hint = i18n.Translate(locale, item.Hint)
+		items[i] = item
+	}
+	report.Items = items
+	return report
+}
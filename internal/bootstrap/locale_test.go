@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"errors"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// TestGetDiagnosticsIsLocalized translates the report for the UI while the
+// cached report and the REST API keep English.
+func TestGetDiagnosticsIsLocalized(t *testing.T) {
+	english := domain.DiagnosticReport{Items: []domain.DiagnosticItem{{
+		ID:      "model_path",
+		Name:    "Model path",
+		Status:  domain.DiagnosticStatusFail,
+		Message: "Model path does not exist: /models/base.bin",
+		Hint:    "Set a valid model file path or a directory containing whisper models.",
+	}}}
+	tests := []struct {
+		name   string
+		locale domain.Locale
+		want   domain.DiagnosticItem
+	}{
+		{name: "english", locale: domain.LocaleEnglish, want: english.Items[0]},
+		{name: "russian", locale: domain.LocaleRussian, want: domain.DiagnosticItem{
+			ID:      "model_path",
+			Name:    "Путь к модели",
+			Status:  domain.DiagnosticStatusFail,
+			Message: "Путь к модели не существует: /models/base.bin",
+			Hint:    "Укажите файл модели или каталог с моделями whisper.",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &App{Settings: domain.Settings{Locale: tt.locale}, Diagnostics: english}
+			report := app.GetDiagnostics()
+			if len(report.Items) != 1 || report.Items[0] != tt.want {
+				t.Fatalf("items = %+v, want %+v", report.Items, tt.want)
+			}
+			if app.Diagnostics.Items[0] != english.Items[0] {
+				t.Fatalf("cached report changed: %+v", app.Diagnostics.Items[0])
+			}
+		})
+	}
+}
+
+// TestLocalizeEventAndError covers the messages pushed to and returned to the UI.
+func TestLocalizeEventAndError(t *testing.T) {
+	app := &App{Settings: domain.Settings{Locale: domain.LocaleRussian}}
+	event := localizeEvent(jobs.Event{Type: jobs.EventTypeLog, Message: "Subtitles exported"}, domain.LocaleRussian)
+	if event.Message != "Субтитры сохранены" {
+		t.Fatalf("event message = %q", event.Message)
+	}
+	if got := app.localizeError(errors.New("load settings: settings store is not configured")); got != "загрузка настроек: хранилище настроек не настроено" {
+		t.Fatalf("error = %q", got)
+	}
+}
@@ -0,0 +1,133 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/analyze"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/textenc"
+	"media-transcriber/internal/transcribe"
+)
+
+// lyricsTagFormats are the input formats whose tags take embedded lyrics.
+var lyricsTagFormats = map[string]bool{".mp3": true, ".m4a": true}
+
+// embedLyrics writes a finished job's LRC lyrics, or its transcript when no
+// LRC was exported, into the lyrics tag of an MP3 or M4A input when the
+// settings ask for it. Failures are reported but never fail the job.
+func (a *App) embedLyrics(jobID, inputPath string, result transcribe.Result, settings domain.Settings) {
+	if !settings.EmbedLyrics || !lyricsTagFormats[strings.ToLower(filepath.Ext(inputPath))] {
+		return
+	}
+	lyrics := result.Transcript
+	if result.LyricsPath != "" {
+		if data, err := os.ReadFile(result.LyricsPath); err == nil {
+			lyrics = textenc.Decode(data)
+		}
+	}
+	if strings.TrimSpace(lyrics) == "" {
+		return
+	}
+	if err := writeLyricsTag(settings.FFmpegPath, inputPath, strings.TrimSpace(lyrics)); err != nil {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeError, Message: fmt.Sprintf("embed lyrics: %v", err)})
+		return
+	}
+	a.publishEvent(jobs.Event{
+		JobID:   jobID,
+		Type:    jobs.EventTypeLog,
+		Message: fmt.Sprintf("Lyrics embedded in %s", filepath.Base(inputPath)),
+	})
+}
+
+// writeLyricsTag rewrites inputPath with lyrics in its tags. The existing
+// tags are dumped to an FFMETADATA file, which takes the lyrics and is muxed
+// back in with the streams copied, so no other tag, cover, or chapter is lost
+// and long lyrics stay off the command line. The file is replaced through a
+// sibling temp file.
+func writeLyricsTag(ffmpegPath, inputPath, lyrics string) error {
+	ffmpegPath = strings.TrimSpace(ffmpegPath)
+	if ffmpegPath == "" {
+		ffmpegPath = "ffmpeg"
+	}
+	info, err := os.Stat(inputPath)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "lyrics-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	metadataPath := filepath.Join(dir, "metadata.txt")
+	if output, err := exec.Command(ffmpegPath, "-hide_banner", "-nostdin", "-y", "-v", "error", "-i", inputPath, "-f", "ffmetadata", metadataPath).CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	metadata, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(metadataPath, []byte(withLyricsTag(string(metadata), lyrics)), 0o644); err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(inputPath)
+	tmpPath := filepath.Join(filepath.Dir(inputPath), "."+strings.TrimSuffix(filepath.Base(inputPath), ext)+".lyrics"+ext)
+	if output, err := exec.Command(ffmpegPath, lyricsTagArgs(inputPath, metadataPath, tmpPath)...).CombinedOutput(); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("ffmpeg: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, inputPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// lyricsTagArgs builds the ffmpeg command that copies every stream of
+// inputPath into outPath with the global tags and chapters of metadataPath.
+func lyricsTagArgs(inputPath, metadataPath, outPath string) []string {
+	return []string{
+		"-hide_banner", "-nostdin", "-y", "-v", "error",
+		"-i", inputPath,
+		"-i", metadataPath,
+		"-map", "0", "-map_metadata", "1", "-map_chapters", "1",
+		"-c", "copy",
+		outPath,
+	}
+}
+
+// withLyricsTag adds lyrics to the global tags of an FFMETADATA file, ahead
+// of its first [CHAPTER] or [STREAM] section. Later keys win, so lyrics from
+// an earlier run are replaced.
+func withLyricsTag(metadata, lyrics string) string {
+	if !strings.HasPrefix(metadata, ";FFMETADATA1") {
+		metadata = ";FFMETADATA1\n" + metadata
+	}
+	tag := "lyrics=" + analyze.EscapeFFMetadata(lyrics) + "\n"
+	lines := strings.SplitAfter(metadata, "\n")
+	continued := false
+	for i, line := range lines {
+		if !continued && strings.HasPrefix(line, "[") {
+			return strings.Join(lines[:i], "") + tag + strings.Join(lines[i:], "")
+		}
+		// An odd run of backslashes before the newline escapes it, so the
+		// next line still belongs to this value.
+		trimmed := strings.TrimSuffix(line, "\n")
+		escapes := len(trimmed) - len(strings.TrimRight(trimmed, `\`))
+		continued = strings.HasSuffix(line, "\n") && escapes%2 == 1
+	}
+	if !strings.HasSuffix(metadata, "\n") {
+		metadata += "\n"
+	}
+	return metadata + tag
+}
@@ -0,0 +1,64 @@
+package bootstrap
+
+import (
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestWithLyricsTag adds escaped lyrics to the global tags ahead of the
+// first section, skipping section-like lines inside escaped values.
+func TestWithLyricsTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		want     string
+	}{
+		{
+			name:     "tags only",
+			metadata: ";FFMETADATA1\ntitle=Memo\n",
+			want:     ";FFMETADATA1\ntitle=Memo\nlyrics=[00:01.00]Hi\\\n[00:02.00]\\=\n",
+		},
+		{
+			name:     "chapters",
+			metadata: ";FFMETADATA1\ncomment=verse\\\n[Chorus]\n[CHAPTER]\nTIMEBASE=1/1000\n",
+			want:     ";FFMETADATA1\ncomment=verse\\\n[Chorus]\nlyrics=[00:01.00]Hi\\\n[00:02.00]\\=\n[CHAPTER]\nTIMEBASE=1/1000\n",
+		},
+		{
+			name:     "empty dump",
+			metadata: "",
+			want:     ";FFMETADATA1\nlyrics=[00:01.00]Hi\\\n[00:02.00]\\=\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withLyricsTag(tt.metadata, "[00:01.00]Hi\n[00:02.00]="); got != tt.want {
+				t.Fatalf("metadata =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLyricsTagArgs copies the streams and takes tags and chapters from the
+// metadata file.
+func TestLyricsTagArgs(t *testing.T) {
+	args := strings.Join(lyricsTagArgs("/in/memo.m4a", "/tmp/metadata.txt", "/in/.memo.lyrics.m4a"), " ")
+	if !strings.Contains(args, "-i /in/memo.m4a -i /tmp/metadata.txt -map 0 -map_metadata 1 -map_chapters 1 -c copy /in/.memo.lyrics.m4a") {
+		t.Fatalf("args = %s", args)
+	}
+}
+
+// TestEmbedLyricsSkipsUnsupportedInputs leaves inputs alone when embedding is
+// off or their format has no lyrics tag.
+func TestEmbedLyricsSkipsUnsupportedInputs(t *testing.T) {
+	app := &App{events: jobs.NewEventBus(10)}
+	result := transcribe.Result{Transcript: "Hello."}
+	app.embedLyrics("job-1", "/in/memo.mp3", result, domain.Settings{})
+	app.embedLyrics("job-1", "/in/talk.wav", result, domain.Settings{EmbedLyrics: true})
+	if events := app.JobEvents(0, jobs.EventFilter{JobID: "job-1"}); len(events) != 0 {
+		t.Fatalf("events = %+v, want none", events)
+	}
+}
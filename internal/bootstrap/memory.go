@@ -0,0 +1,81 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
+	"media-transcriber/internal/transcribe"
+)
+
+const (
+	// modelMemoryFactor scales the model file size to whisper.cpp's resident
+	// size: weights plus KV cache and compute buffers.
+	modelMemoryFactor = 1.3
+	// modelMemoryOverheadBytes covers the process itself and audio buffers.
+	modelMemoryOverheadBytes = 256 << 20
+)
+
+// errInsufficientMemory marks a job refused by the memory guard.
+var errInsufficientMemory = errors.New("not enough memory")
+
+// checkMemory estimates the memory the configured model needs and compares it
+// with available RAM, then with free memory on the GPUs the GPUDevice setting
+// allows, which whisper.cpp uses instead when built with CUDA. It returns nil when the model fits, the guard is off,
+// or either side is unknown.
+func checkMemory(settings domain.Settings) error {
+	if settings.MemoryGuard == domain.MemoryGuardOff {
+		return nil
+	}
+	modelPath, err := transcribe.ResolveModelPath(settings.ModelPath)
+	if err != nil {
+		// The pipeline reports a missing model with a better message.
+		return nil
+	}
+	info, err := os.Stat(modelPath)
+	if err != nil {
+		return nil
+	}
+
+	required := requiredModelMemory(info.Size())
+	_, available := sysinfo.Memory()
+	if available == 0 || required <= available {
+		return nil
+	}
+	return memoryShortfall(required, available, whisperGPUs(settings.GPUDevice, sysinfo.DetectGPUs))
+}
+
+// requiredModelMemory estimates whisper.cpp's peak memory for a model file.
+func requiredModelMemory(modelBytes int64) uint64 {
+	return uint64(float64(modelBytes)*modelMemoryFactor) + modelMemoryOverheadBytes
+}
+
+// memoryShortfall reports why required bytes do not fit in available RAM,
+// or nil when a GPU has enough free memory to hold the model instead.
+func memoryShortfall(required, available uint64, gpus []sysinfo.GPU) error {
+	for _, gpu := range gpus {
+		if gpu.FreeMemoryBytes >= required {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: the model needs about %s but only %s is available; close other applications, pick a smaller or quantized model, or set the memory guard to warn",
+		errInsufficientMemory, formatBytes(required), formatBytes(available))
+}
+
+// formatBytes renders a size in binary units with one decimal.
+func formatBytes(n uint64) string {
+	const unit = 1 << 10
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KiB"
+	for _, next := range []string{"MiB", "GiB", "TiB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
@@ -0,0 +1,58 @@
+package bootstrap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
+)
+
+// TestMemoryShortfall refuses models that fit neither RAM nor a GPU.
+func TestMemoryShortfall(t *testing.T) {
+	const gib = uint64(1) << 30
+	required := requiredModelMemory(3 * int64(gib))
+
+	tests := []struct {
+		name    string
+		gpus    []sysinfo.GPU
+		wantErr bool
+	}{
+		{name: "no gpu", wantErr: true},
+		{name: "small gpu", gpus: []sysinfo.GPU{{Name: "GTX 1050", FreeMemoryBytes: 2 * gib}}, wantErr: true},
+		{name: "apple unified memory", gpus: []sysinfo.GPU{{Name: "Apple silicon GPU (Metal)"}}, wantErr: true},
+		{name: "large gpu", gpus: []sysinfo.GPU{{Name: "GTX 1050", FreeMemoryBytes: 2 * gib}, {Name: "RTX 4090", FreeMemoryBytes: 20 * gib}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := memoryShortfall(required, 2*gib, tt.gpus)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && (!errors.Is(err, errInsufficientMemory) || !strings.Contains(err.Error(), "only 2.0 GiB is available")) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestRequiredModelMemory scales the file size and adds process overhead.
+func TestRequiredModelMemory(t *testing.T) {
+	if got := requiredModelMemory(1000 << 20); got != 1300<<20+modelMemoryOverheadBytes {
+		t.Fatalf("required = %d", got)
+	}
+	if got := formatBytes(requiredModelMemory(500 << 20)); got != "906.0 MiB" {
+		t.Fatalf("formatted = %q", got)
+	}
+}
+
+// TestCheckMemorySkipsWhenOffOrUnknown never blocks without a usable estimate.
+func TestCheckMemorySkipsWhenOffOrUnknown(t *testing.T) {
+	if err := checkMemory(domain.Settings{ModelPath: "/missing/model.bin"}); err != nil {
+		t.Fatalf("missing model: %v", err)
+	}
+	if err := checkMemory(domain.Settings{MemoryGuard: domain.MemoryGuardOff}); err != nil {
+		t.Fatalf("guard off: %v", err)
+	}
+}
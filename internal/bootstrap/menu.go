@@ -0,0 +1,189 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"sort"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"media-transcriber/internal/i18n"
+	"media-transcriber/internal/jobs"
+)
+
+// maxRecentInputs bounds the File → Open Recent submenu.
+const maxRecentInputs = 10
+
+// Events emitted to the frontend for menu items whose result it shows.
+const (
+	menuEventDiagnostics = "menu:diagnostics"
+	menuEventUpdates     = "menu:updates"
+)
+
+// applicationMenu builds the native menu with File, Help, and, on macOS, the
+// standard application and edit menus so system shortcuts keep working.
+func (a *App) applicationMenu() *menu.Menu {
+	locale := a.locale()
+	label := func(text string) string { return i18n.Translate(locale, text) }
+
+	appMenu := menu.NewMenu()
+	if goruntime.GOOS == "darwin" {
+		appMenu.Append(menu.AppMenu())
+	}
+
+	file := appMenu.AddSubmenu(label("File"))
+	file.AddText(label("Open Media…"), keys.CmdOrCtrl("o"), a.menuAction(a.menuOpenMedia))
+	recent := file.AddSubmenu(label("Open Recent"))
+	for _, path := range a.recentInputs() {
+		path := path
+		recent.AddText(filepath.Base(path), nil, a.menuAction(func() { a.openMedia(path) }))
+	}
+	if len(recent.Items) == 0 {
+		recent.AddText(label("No Recent Files"), nil, nil).Disabled = true
+	}
+	file.AddSeparator()
+	file.AddText(label("Start Job"), keys.CmdOrCtrl("enter"), a.menuAction(a.menuStartJob))
+	file.AddText(label("Cancel Job"), keys.CmdOrCtrl("."), a.menuAction(func() { _ = a.CancelTranscription() }))
+	if goruntime.GOOS != "darwin" {
+		file.AddSeparator()
+		file.AddText(label("Quit"), keys.CmdOrCtrl("q"), a.menuAction(a.menuQuit))
+	}
+
+	if goruntime.GOOS == "darwin" {
+		appMenu.Append(menu.EditMenu())
+	}
+
+	help := appMenu.AddSubmenu(label("Help"))
+	help.AddText(label("Diagnostics"), keys.Key("f1"), a.menuAction(a.menuDiagnostics))
+	help.AddText(label("Check for Updates…"), nil, a.menuAction(a.menuCheckUpdates))
+	return appMenu
+}
+
+// menuAction runs fn off the menu callback, since dialogs and jobs block,
+// guarding it like any background task.
+func (a *App) menuAction(fn func()) menu.Callback {
+	return func(*menu.CallbackData) {
+		go a.guard("menu", fn)
+	}
+}
+
+// refreshMenu rebuilds the native menu, e.g. after history or locale changes.
+func (a *App) refreshMenu() {
+	a.mu.Lock()
+	ctx := a.runtimeCtx
+	a.mu.Unlock()
+	if ctx == nil {
+		return
+	}
+	wailsruntime.MenuSetApplicationMenu(ctx, a.applicationMenu())
+	wailsruntime.MenuUpdateApplicationMenu(ctx)
+}
+
+// recentInputs lists the input files of the latest jobs that still exist,
+// newest first and without duplicates.
+func (a *App) recentInputs() []string {
+	if a.History == nil {
+		return nil
+	}
+	entries, err := a.History.Load()
+	if err != nil {
+		return nil
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].FinishedAt.After(entries[j].FinishedAt) })
+
+	seen := map[string]bool{}
+	var paths []string
+	for _, entry := range entries {
+		if len(paths) == maxRecentInputs {
+			break
+		}
+		if entry.InputPath == "" || seen[entry.InputPath] {
+			continue
+		}
+		seen[entry.InputPath] = true
+		if _, err := os.Stat(entry.InputPath); err == nil {
+			paths = append(paths, entry.InputPath)
+		}
+	}
+	return paths
+}
+
+// menuOpenMedia picks a media file and transcribes it.
+func (a *App) menuOpenMedia() {
+	path, err := a.PickInputFile()
+	if err != nil {
+		a.publishEvent(jobs.Event{Type: jobs.EventTypeError, Message: err.Error()})
+		return
+	}
+	if path != "" {
+		a.openMedia(path)
+	}
+}
+
+// openMedia starts a job for path, or queues it behind the running job.
+func (a *App) openMedia(path string) {
+	var err error
+	if a.Jobs.IsRunning() {
+		_, err = a.EnqueueFile(path, false)
+	} else {
+		_, err = a.StartTranscription(path)
+	}
+	if err != nil {
+		a.publishEvent(jobs.Event{Type: jobs.EventTypeError, Message: err.Error()})
+	}
+}
+
+// menuStartJob starts the next queued file, or asks for one when the queue is empty.
+func (a *App) menuStartJob() {
+	if a.Jobs.IsRunning() {
+		return
+	}
+	if a.Queue != nil && len(a.Queue.List()) > 0 {
+		a.startNextQueued()
+		return
+	}
+	a.menuOpenMedia()
+}
+
+// menuDiagnostics reruns the checks and sends the report to the frontend.
+func (a *App) menuDiagnostics() {
+	report, err := a.RefreshDiagnostics()
+	if err != nil {
+		a.publishEvent(jobs.Event{Type: jobs.EventTypeError, Message: err.Error()})
+		return
+	}
+	a.emitToFrontend(menuEventDiagnostics, report)
+}
+
+// menuCheckUpdates checks for a newer release and sends the result to the frontend.
+func (a *App) menuCheckUpdates() {
+	info, err := a.CheckForUpdates()
+	if err != nil {
+		a.publishEvent(jobs.Event{Type: jobs.EventTypeError, Message: err.Error()})
+		return
+	}
+	a.emitToFrontend(menuEventUpdates, info)
+}
+
+// menuQuit closes the app.
+func (a *App) menuQuit() {
+	a.mu.Lock()
+	ctx := a.runtimeCtx
+	a.mu.Unlock()
+	if ctx != nil {
+		wailsruntime.Quit(ctx)
+	}
+}
+
+// emitToFrontend sends a Wails event when the desktop runtime is up.
+func (a *App) emitToFrontend(name string, data any) {
+	a.mu.Lock()
+	ctx := a.runtimeCtx
+	a.mu.Unlock()
+	if ctx != nil {
+		wailsruntime.EventsEmit(ctx, name, data)
+	}
+}
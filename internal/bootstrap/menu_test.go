@@ -0,0 +1,103 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+)
+
+// findMenuItem returns the item labelled label in m or its submenus.
+func findMenuItem(m *menu.Menu, label string) *menu.MenuItem {
+	if m == nil {
+		return nil
+	}
+	for _, item := range m.Items {
+		if item.Label == label {
+			return item
+		}
+		if found := findMenuItem(item.SubMenu, label); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// TestRecentInputs lists existing inputs newest first without duplicates.
+func TestRecentInputs(t *testing.T) {
+	root := t.TempDir()
+	older, newer := filepath.Join(root, "older.mp3"), filepath.Join(root, "newer.mp3")
+	mustWrite(t, older, "a")
+	mustWrite(t, newer, "b")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	now := time.Now()
+	if err := history.Save([]domain.HistoryEntry{
+		{ID: "1", InputPath: older, FinishedAt: now.Add(-3 * time.Hour)},
+		{ID: "2", InputPath: newer, FinishedAt: now.Add(-2 * time.Hour)},
+		{ID: "3", InputPath: filepath.Join(root, "deleted.mp3"), FinishedAt: now.Add(-time.Hour)},
+		{ID: "4", InputPath: older, FinishedAt: now},
+	}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	app := &App{History: history}
+	got := app.recentInputs()
+	if len(got) != 2 || got[0] != older || got[1] != newer {
+		t.Fatalf("recent = %v, want [%s %s]", got, older, newer)
+	}
+}
+
+// TestApplicationMenu has the File and Help items with their shortcuts,
+// translated to the locale setting.
+func TestApplicationMenu(t *testing.T) {
+	root := t.TempDir()
+	input := filepath.Join(root, "lecture.mp3")
+	mustWrite(t, input, "a")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{{ID: "1", InputPath: input, FinishedAt: time.Now()}}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	app := &App{History: history}
+	m := app.applicationMenu()
+	tests := []struct {
+		label       string
+		accelerator *keys.Accelerator
+	}{
+		{label: "Open Media…", accelerator: keys.CmdOrCtrl("o")},
+		{label: "Start Job", accelerator: keys.CmdOrCtrl("enter")},
+		{label: "Cancel Job", accelerator: keys.CmdOrCtrl(".")},
+		{label: "Diagnostics", accelerator: keys.Key("f1")},
+		{label: "lecture.mp3"},
+	}
+	for _, tt := range tests {
+		item := findMenuItem(m, tt.label)
+		if item == nil {
+			t.Fatalf("menu has no %q item", tt.label)
+		}
+		if item.Click == nil {
+			t.Errorf("%q has no action", tt.label)
+		}
+		if (tt.accelerator == nil) != (item.Accelerator == nil) ||
+			tt.accelerator != nil && keys.Stringify(tt.accelerator, "linux") != keys.Stringify(item.Accelerator, "linux") {
+			t.Errorf("%q accelerator = %v, want %v", tt.label, item.Accelerator, tt.accelerator)
+		}
+	}
+	if findMenuItem(m, "No Recent Files") != nil {
+		t.Fatal("placeholder shown next to recent files")
+	}
+
+	app = &App{Settings: domain.Settings{Locale: domain.LocaleRussian}}
+	m = app.applicationMenu()
+	if findMenuItem(m, "Открыть медиафайл…") == nil || findMenuItem(m, "Диагностика") == nil {
+		t.Fatal("menu is not translated")
+	}
+	if item := findMenuItem(m, "Нет недавних файлов"); item == nil || !item.Disabled {
+		t.Fatalf("empty recent placeholder = %+v", item)
+	}
+}
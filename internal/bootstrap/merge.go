@@ -0,0 +1,281 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/analyze"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/subtitle"
+	"media-transcriber/internal/textenc"
+	"media-transcriber/internal/transcribe"
+)
+
+// mergeDirPrefix names the upload-area directories holding concatenated
+// batches; their inputs are split back into one chapter per source file.
+const mergeDirPrefix = "merge-"
+
+// mergeBatch tracks the files of a MergeTranscripts batch until each has a
+// history entry or was dropped from the queue.
+type mergeBatch struct {
+	name      string
+	outputDir string
+	inputs    []string
+	entries   map[string]domain.HistoryEntry
+	// encoding and lineEnding are the export settings the merged files follow.
+	encoding   domain.TextEncoding
+	lineEnding domain.LineEnding
+}
+
+// mergeFolder queues the matched files of a folder scan as one transcript.
+func (a *App) mergeFolder(summary domain.FolderScanSummary, mode domain.MergeMode, settings domain.Settings) ([]domain.QueuedJob, error) {
+	if len(summary.Matched) == 0 {
+		return nil, nil
+	}
+	name := filepath.Base(summary.Root)
+	switch mode {
+	case domain.MergeConcat:
+		merged, err := a.concatInputs(name, summary.Matched, settings)
+		if err != nil {
+			return nil, fmt.Errorf("concatenate inputs: %w", err)
+		}
+		return a.enqueue([]string{merged}, false), nil
+	case domain.MergeTranscripts:
+		a.batchMu.Lock()
+		a.batches = append(a.batches, &mergeBatch{
+			name:       name,
+			outputDir:  settings.OutputDir,
+			inputs:     summary.Matched,
+			entries:    map[string]domain.HistoryEntry{},
+			encoding:   settings.TextEncoding,
+			lineEnding: settings.LineEnding,
+		})
+		a.batchMu.Unlock()
+		return a.enqueueFolder(summary.Root, summary.Matched), nil
+	default:
+		return nil, fmt.Errorf("unknown merge mode %q", mode)
+	}
+}
+
+// concatInputs joins inputs with ffmpeg into <name>.mka in a new merge
+// directory of the upload area, with one chapter per input so the transcript
+// gets per-file headings. The directory is removed once the job finishes.
+func (a *App) concatInputs(name string, inputs []string, settings domain.Settings) (string, error) {
+	if a.uploadDir == "" {
+		return "", fmt.Errorf("no working directory for merged audio")
+	}
+	if err := os.MkdirAll(a.uploadDir, 0o755); err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp(a.uploadDir, mergeDirPrefix+"*")
+	if err != nil {
+		return "", err
+	}
+
+	chapters, err := probeInputChapters(inputs, settings)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	metadataPath := filepath.Join(dir, "chapters.txt")
+	if err := os.WriteFile(metadataPath, []byte(analyze.FormatFFMetadata(chapters)), 0o644); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+
+	outPath := filepath.Join(dir, name+".mka")
+	ffmpegPath := strings.TrimSpace(settings.FFmpegPath)
+	if ffmpegPath == "" {
+		ffmpegPath = "ffmpeg"
+	}
+	if output, err := exec.Command(ffmpegPath, concatArgs(inputs, metadataPath, outPath)...).CombinedOutput(); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", fmt.Errorf("ffmpeg: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return outPath, nil
+}
+
+// probeInputChapters lays the inputs end to end as chapters titled with their
+// file names.
+func probeInputChapters(inputs []string, settings domain.Settings) ([]analyze.Chapter, error) {
+	ffprobePath := transcribe.FFprobePath(settings.FFmpegPath)
+	chapters := make([]analyze.Chapter, 0, len(inputs))
+	var startMs int64
+	for _, input := range inputs {
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		seconds, err := transcribe.ProbeDuration(ctx, ffprobePath, input)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		endMs := startMs + int64(seconds*1000)
+		chapters = append(chapters, analyze.Chapter{StartMs: startMs, EndMs: endMs, Title: filepath.Base(input)})
+		startMs = endMs
+	}
+	return chapters, nil
+}
+
+// concatArgs builds the ffmpeg command that resamples every input to 16 kHz
+// mono, joins them with the concat filter, and attaches the chapters from an
+// FFMETADATA file. Resampling first lets inputs of different formats be joined.
+func concatArgs(inputs []string, metadataPath, outPath string) []string {
+	args := []string{"-hide_banner", "-nostdin", "-y", "-v", "error"}
+	var filter strings.Builder
+	for i, input := range inputs {
+		args = append(args, "-i", input)
+		fmt.Fprintf(&filter, "[%d:a]aresample=16000,aformat=sample_fmts=s16:channel_layouts=mono[a%d];", i, i)
+	}
+	for i := range inputs {
+		fmt.Fprintf(&filter, "[a%d]", i)
+	}
+	fmt.Fprintf(&filter, "concat=n=%d:v=0:a=1[out]", len(inputs))
+	return append(args,
+		"-i", metadataPath,
+		"-filter_complex", filter.String(),
+		"-map", "[out]",
+		"-map_chapters", fmt.Sprint(len(inputs)),
+		"-c:a", "pcm_s16le",
+		outPath,
+	)
+}
+
+// isMergeInput reports whether inputPath was produced by concatInputs.
+func (a *App) isMergeInput(inputPath string) bool {
+	if a.uploadDir == "" {
+		return false
+	}
+	dir := filepath.Dir(inputPath)
+	return filepath.Dir(dir) == filepath.Clean(a.uploadDir) && strings.HasPrefix(filepath.Base(dir), mergeDirPrefix)
+}
+
+// settleMergeBatches records entry in the batches waiting for its input and
+// writes the merged transcript of every batch that is now complete.
+func (a *App) settleMergeBatches(entry *domain.HistoryEntry) {
+	a.batchMu.Lock()
+	var ready []*mergeBatch
+	pending := a.batches[:0]
+	for _, batch := range a.batches {
+		if entry != nil && slices.Contains(batch.inputs, entry.InputPath) {
+			batch.entries[entry.InputPath] = *entry
+		}
+		if a.batchSettled(batch) {
+			ready = append(ready, batch)
+		} else {
+			pending = append(pending, batch)
+		}
+	}
+	a.batches = pending
+	a.batchMu.Unlock()
+
+	for _, batch := range ready {
+		textPath, merged, err := writeMergedTranscript(batch)
+		if err != nil {
+			a.publishEvent(jobs.Event{Type: jobs.EventTypeError, Message: fmt.Sprintf("merge transcripts of %s: %v", batch.name, err)})
+			continue
+		}
+		a.publishEvent(jobs.Event{
+			Type:     jobs.EventTypeLog,
+			Message:  fmt.Sprintf("Merged %d of %d transcripts into %s", merged, len(batch.inputs), textPath),
+			TextPath: textPath,
+		})
+	}
+}
+
+// batchSettled reports whether no input of batch is still queued or running.
+func (a *App) batchSettled(batch *mergeBatch) bool {
+	queued := map[string]bool{}
+	if a.Queue != nil {
+		for _, job := range a.Queue.List() {
+			queued[job.InputPath] = true
+		}
+	}
+	running := ""
+	if a.Jobs != nil && a.Jobs.IsRunning() {
+		running = a.Jobs.Current().InputPath
+	}
+	for _, input := range batch.inputs {
+		if _, done := batch.entries[input]; done {
+			continue
+		}
+		if queued[input] || input == running {
+			return false
+		}
+	}
+	return true
+}
+
+// writeMergedTranscript writes <name>.merged.txt with a heading per file and,
+// when the files had subtitles, <name>.merged.srt and .vtt on one continuous
+// timeline. Files that did not complete are left out. It returns the text
+// path and how many files were merged.
+func writeMergedTranscript(batch *mergeBatch) (string, int, error) {
+	var text strings.Builder
+	var cues []subtitle.Cue
+	var offsetMs int64
+	merged := 0
+	for _, input := range batch.inputs {
+		entry, ok := batch.entries[input]
+		if !ok || entry.Status != domain.JobStatusDone {
+			continue
+		}
+		body, err := os.ReadFile(entry.TextPath)
+		if err != nil {
+			return "", 0, fmt.Errorf("read transcript of %s: %w", filepath.Base(input), err)
+		}
+		fileCues := entryCues(entry)
+
+		if merged > 0 {
+			text.WriteString("\n")
+		}
+		fmt.Fprintf(&text, "## %s\n\n%s\n", transcribe.ChapterHeading(filepath.Base(input), offsetMs), strings.TrimSpace(textenc.Decode(body)))
+		cues = append(cues, subtitle.Shift(fileCues, offsetMs)...)
+		merged++
+
+		lengthMs := int64(entry.AudioSeconds * float64(time.Second/time.Millisecond))
+		if lengthMs == 0 && len(fileCues) > 0 {
+			lengthMs = fileCues[len(fileCues)-1].EndMs
+		}
+		offsetMs += lengthMs
+	}
+	if merged == 0 {
+		return "", 0, fmt.Errorf("no file of the batch was transcribed")
+	}
+
+	base := filepath.Join(batch.outputDir, batch.name+".merged")
+	if err := os.MkdirAll(batch.outputDir, 0o755); err != nil {
+		return "", 0, err
+	}
+	outputs := map[string]string{base + ".txt": text.String()}
+	if len(cues) > 0 {
+		outputs[base+".srt"] = subtitle.FormatSRT(cues)
+		outputs[base+".vtt"] = subtitle.FormatVTT(cues)
+	}
+	for path, content := range outputs {
+		if err := os.WriteFile(path, textenc.Encode(content, batch.encoding, batch.lineEnding), 0o644); err != nil {
+			return "", 0, err
+		}
+	}
+	return base + ".txt", merged, nil
+}
+
+// entryCues reads the first subtitle file of a finished job, nil when it has
+// none or it cannot be parsed.
+func entryCues(entry domain.HistoryEntry) []subtitle.Cue {
+	for _, path := range entry.SubtitlePaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if file, err := subtitle.Parse(data); err == nil {
+			return file.Cues
+		}
+	}
+	return nil
+}
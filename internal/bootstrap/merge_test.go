@@ -0,0 +1,121 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestConcatArgs checks every input is resampled before the concat filter and
+// the chapters come from the metadata input.
+func TestConcatArgs(t *testing.T) {
+	args := concatArgs([]string{"/in/a.mp3", "/in/b.m4a"}, "/work/chapters.txt", "/work/lecture.mka")
+	if got := mergeArgValue(args, "-filter_complex"); got != "[0:a]aresample=16000,aformat=sample_fmts=s16:channel_layouts=mono[a0];"+
+		"[1:a]aresample=16000,aformat=sample_fmts=s16:channel_layouts=mono[a1];[a0][a1]concat=n=2:v=0:a=1[out]" {
+		t.Fatalf("filter = %q", got)
+	}
+	if got := mergeArgValue(args, "-map_chapters"); got != "2" {
+		t.Fatalf("-map_chapters = %q, want the metadata input index 2", got)
+	}
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "-i /in/a.mp3 -i /in/b.m4a -i /work/chapters.txt") || !strings.HasSuffix(joined, "/work/lecture.mka") {
+		t.Fatalf("args = %v", args)
+	}
+}
+
+// TestMergeInputsAreSplitAndRemoved covers recognizing and cleaning up
+// concatenated batches in the upload area.
+func TestMergeInputsAreSplitAndRemoved(t *testing.T) {
+	uploads := t.TempDir()
+	app := &App{uploadDir: uploads}
+	merged := filepath.Join(uploads, "merge-123", "lecture.mka")
+	mustWrite(t, merged, "audio")
+	other := filepath.Join(t.TempDir(), "merge-1", "lecture.mka")
+
+	if !app.isMergeInput(merged) || app.isMergeInput(other) {
+		t.Fatal("only batches in the upload area are merge inputs")
+	}
+	app.removeUpload(merged)
+	if _, err := os.Stat(filepath.Dir(merged)); !os.IsNotExist(err) {
+		t.Fatalf("merge directory should be removed, stat err = %v", err)
+	}
+}
+
+// TestTranscribeFolderMergesTranscripts runs a batch in transcripts mode and
+// checks the merged files skip the failed file and keep timestamps continuous.
+func TestTranscribeFolderMergesTranscripts(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "lecture")
+	outputDir := t.TempDir()
+	for _, name := range []string{"1.mp3", "2.mp3", "3.mp3"} {
+		mustWrite(t, filepath.Join(root, name), "audio")
+	}
+
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{OutputDir: outputDir}},
+		Jobs:  jobs.NewManager(),
+		Queue: jobs.NewQueue(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			base := strings.TrimSuffix(filepath.Base(req.InputPath), ".mp3")
+			if base == "2" {
+				return transcribe.Result{}, errors.New("decode failed")
+			}
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			textPath := filepath.Join(outputDir, base+".txt")
+			srtPath := filepath.Join(outputDir, base+".srt")
+			mustWrite(t, textPath, "Part "+base+".\n")
+			mustWrite(t, srtPath, "1\n00:00:00,500 --> 00:00:02,000\nPart "+base+".\n")
+			return transcribe.Result{TextPath: textPath, SubtitlePaths: []string{srtPath}}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	summary, err := app.TranscribeFolder(root, domain.FolderScanOptions{Merge: domain.MergeTranscripts})
+	if err != nil {
+		t.Fatalf("transcribe folder: %v", err)
+	}
+	if len(summary.Queued) != 3 {
+		t.Fatalf("queued = %+v", summary.Queued)
+	}
+
+	mergedText := filepath.Join(outputDir, "lecture.merged.txt")
+	waitFor(t, func() bool {
+		_, err := os.Stat(mergedText)
+		return err == nil
+	})
+	text, err := os.ReadFile(mergedText)
+	if err != nil {
+		t.Fatalf("read merged text: %v", err)
+	}
+	want := "## 1.mp3 [00:00:00.000]\n\nPart 1.\n\n## 3.mp3 [00:00:02.000]\n\nPart 3.\n"
+	if string(text) != want {
+		t.Fatalf("merged text = %q, want %q", text, want)
+	}
+	srt, err := os.ReadFile(filepath.Join(outputDir, "lecture.merged.srt"))
+	if err != nil {
+		t.Fatalf("read merged srt: %v", err)
+	}
+	if !strings.Contains(string(srt), "2\n00:00:02,500 --> 00:00:04,000\nPart 3.") {
+		t.Fatalf("merged srt = %q", srt)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "lecture.merged.vtt")); err != nil {
+		t.Fatalf("merged vtt: %v", err)
+	}
+}
+
+// mergeArgValue returns the value after flag in args, or "".
+func mergeArgValue(args []string, flag string) string {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == flag {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -3,11 +3,15 @@ package bootstrap
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"media-transcriber/internal/config"
 	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
 )
 
 var whisperModelCatalog = []domain.WhisperModelOption{
@@ -18,6 +22,8 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.en.bin",
 		SizeLabel:   "~75 MB",
 		Description: "Fastest, English-only model.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.en-encoder.mlmodelc.zip",
+		Languages:   []string{"en"},
 	},
 	{
 		ID:          "tiny",
@@ -26,6 +32,7 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.bin",
 		SizeLabel:   "~75 MB",
 		Description: "Fastest multilingual model.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny-encoder.mlmodelc.zip",
 	},
 	{
 		ID:          "base.en",
@@ -34,6 +41,8 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.en.bin",
 		SizeLabel:   "~142 MB",
 		Description: "Balanced speed/quality, English-only.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.en-encoder.mlmodelc.zip",
+		Languages:   []string{"en"},
 	},
 	{
 		ID:          "base",
@@ -42,6 +51,7 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.bin",
 		SizeLabel:   "~142 MB",
 		Description: "Balanced speed/quality, multilingual.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base-encoder.mlmodelc.zip",
 	},
 	{
 		ID:          "small.en",
@@ -50,6 +60,8 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.en.bin",
 		SizeLabel:   "~466 MB",
 		Description: "Higher quality, English-only.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.en-encoder.mlmodelc.zip",
+		Languages:   []string{"en"},
 	},
 	{
 		ID:          "small",
@@ -58,6 +70,7 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.bin",
 		SizeLabel:   "~466 MB",
 		Description: "Higher quality multilingual model.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small-encoder.mlmodelc.zip",
 	},
 	{
 		ID:          "medium.en",
@@ -66,6 +79,8 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium.en.bin",
 		SizeLabel:   "~1.5 GB",
 		Description: "High quality, English-only.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium.en-encoder.mlmodelc.zip",
+		Languages:   []string{"en"},
 	},
 	{
 		ID:          "medium",
@@ -74,6 +89,7 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium.bin",
 		SizeLabel:   "~1.5 GB",
 		Description: "High quality multilingual model.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-encoder.mlmodelc.zip",
 	},
 	{
 		ID:          "large-v2",
@@ -82,6 +98,7 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v2.bin",
 		SizeLabel:   "~2.9 GB",
 		Description: "Very high quality multilingual model.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v2-encoder.mlmodelc.zip",
 	},
 	{
 		ID:          "large-v3",
@@ -90,6 +107,7 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3.bin",
 		SizeLabel:   "~2.9 GB",
 		Description: "Latest large multilingual model.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-encoder.mlmodelc.zip",
 	},
 	{
 		ID:          "large-v3-turbo",
@@ -98,17 +116,73 @@ var whisperModelCatalog = []domain.WhisperModelOption{
 		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-turbo.bin",
 		SizeLabel:   "~1.6 GB",
 		Description: "Faster large-v3 variant.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-turbo-encoder.mlmodelc.zip",
+	},
+	{
+		ID:          "large-v3-turbo-q5_0",
+		Name:        "Large v3 Turbo (Q5_0)",
+		FileName:    "ggml-large-v3-turbo-q5_0.bin",
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-turbo-q5_0.bin",
+		SizeLabel:   "~547 MB",
+		Description: "Quantized large-v3 turbo, much smaller with similar quality.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-turbo-encoder.mlmodelc.zip",
+	},
+	{
+		ID:          "medium-q5_0",
+		Name:        "Medium (Q5_0)",
+		FileName:    "ggml-medium-q5_0.bin",
+		URL:         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-q5_0.bin",
+		SizeLabel:   "~514 MB",
+		Description: "Quantized multilingual medium model.",
+		CoreMLURL:   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-encoder.mlmodelc.zip",
+	},
+	{
+		ID:          "distil-large-v3",
+		Name:        "Distil-Whisper Large v3",
+		FileName:    "ggml-distil-large-v3.bin",
+		URL:         "https://huggingface.co/distil-whisper/distil-large-v3-ggml/resolve/main/ggml-distil-large-v3.bin",
+		SizeLabel:   "~1.5 GB",
+		Description: "Distilled large-v3, about 6x faster, English-only.",
+		Languages:   []string{"en"},
+	},
+	{
+		ID:          "distil-medium.en",
+		Name:        "Distil-Whisper Medium (English)",
+		FileName:    "ggml-medium-32-2.en.bin",
+		URL:         "https://huggingface.co/distil-whisper/distil-medium.en/resolve/main/ggml-medium-32-2.en.bin",
+		SizeLabel:   "~800 MB",
+		Description: "Distilled medium model, English-only.",
+		Languages:   []string{"en"},
+	},
+	{
+		ID:          "distil-small.en",
+		Name:        "Distil-Whisper Small (English)",
+		FileName:    "ggml-distil-small.en.bin",
+		URL:         "https://huggingface.co/distil-whisper/distil-small.en/resolve/main/ggml-distil-small.en.bin",
+		SizeLabel:   "~336 MB",
+		Description: "Distilled small model, English-only.",
+		Languages:   []string{"en"},
+	},
+	{
+		ID:          "kotoba-whisper-v2.0",
+		Name:        "Kotoba-Whisper v2.0 (Japanese)",
+		FileName:    "ggml-kotoba-whisper-v2.0.bin",
+		URL:         "https://huggingface.co/kotoba-tech/kotoba-whisper-v2.0-ggml/resolve/main/ggml-kotoba-whisper-v2.0.bin",
+		SizeLabel:   "~1.5 GB",
+		Description: "Distil-Whisper variant fine-tuned for Japanese.",
+		Languages:   []string{"ja"},
 	},
 }
 
 // GetWhisperModels returns built-in whisper.cpp model presets for one-click downloads.
 func (a *App) GetWhisperModels() []domain.WhisperModelOption {
-	models := make([]domain.WhisperModelOption, len(whisperModelCatalog))
-	copy(models, whisperModelCatalog)
+	models := a.modelCatalog()
 
 	settings, settingsErr := a.loadSettingsForModelCatalog()
 	modelDirs := resolveKnownModelDirs(settings, settingsErr == nil)
 	markDownloadedModels(models, modelDirs)
+	markCoreMLReady(models)
+	markLanguageMatch(models, settings.Language)
 	return models
 }
 
@@ -119,7 +193,7 @@ func (a *App) DownloadWhisperModel(modelID string) (domain.Settings, error) {
 		return domain.Settings{}, fmt.Errorf("model id is required")
 	}
 
-	model, found := getWhisperModelByID(id)
+	model, found := a.lookupWhisperModel(id)
 	if !found {
 		return domain.Settings{}, fmt.Errorf("unknown model id: %s", id)
 	}
@@ -139,20 +213,65 @@ func (a *App) DownloadWhisperModel(modelID string) (domain.Settings, error) {
 		return domain.Settings{}, err
 	}
 
+	token := a.secret(config.SecretHuggingFaceToken)
+	if model.RequiresToken && token == "" {
+		return domain.Settings{}, fmt.Errorf("model %s is gated; add a Hugging Face access token first", model.Name)
+	}
+
 	targetPath := filepath.Join(downloadDir, model.FileName)
-	if err := downloadURLToFile(targetPath, model.URL, modelDownloadTimeout); err != nil {
+	if err := downloadURLToFileWithHeaders(targetPath, model.URL, modelDownloadTimeout, huggingFaceAuthHeaders(model.URL, token)); err != nil {
 		return domain.Settings{}, fmt.Errorf("download model %s: %w", model.Name, err)
 	}
+	if model.SHA256 != "" {
+		if err := verifyFileSHA256(targetPath, model.SHA256); err != nil {
+			_ = os.Remove(targetPath)
+			return domain.Settings{}, fmt.Errorf("verify model %s: %w", model.Name, err)
+		}
+	}
 
 	settings.ModelPath = targetPath
 	if err := a.Store.Save(settings); err != nil {
 		return domain.Settings{}, fmt.Errorf("save settings: %w", err)
 	}
 
+	// A missing CoreML encoder only costs speed, so report it without failing the download.
+	if err := downloadCoreMLEncoder(model, targetPath); err != nil && a.events != nil {
+		a.publishEvent(jobs.Event{
+			Type:    jobs.EventTypeError,
+			Message: fmt.Sprintf("model %s downloaded without CoreML acceleration: %v", model.Name, err),
+		})
+	}
+
 	a.refreshDiagnosticsFromSettings(settings)
 	return settings, nil
 }
 
+// SetHuggingFaceToken stores the Hugging Face access token used for gated model downloads.
+func (a *App) SetHuggingFaceToken(token string) error {
+	return a.storeSecret(config.SecretHuggingFaceToken, "Hugging Face token", token)
+}
+
+// HasHuggingFaceToken reports whether a Hugging Face token is stored, without exposing it.
+func (a *App) HasHuggingFaceToken() bool {
+	return a.secret(config.SecretHuggingFaceToken) != ""
+}
+
+// huggingFaceAuthHeaders returns an Authorization header only for Hugging Face URLs.
+func huggingFaceAuthHeaders(rawURL, token string) http.Header {
+	if token == "" {
+		return nil
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host != "huggingface.co" && !strings.HasSuffix(host, ".huggingface.co") {
+		return nil
+	}
+	return http.Header{"Authorization": []string{"Bearer " + token}}
+}
+
 func getWhisperModelByID(id string) (domain.WhisperModelOption, bool) {
 	for _, model := range whisperModelCatalog {
 		if model.ID == id {
@@ -267,3 +386,26 @@ func markDownloadedModels(models []domain.WhisperModelOption, modelDirs []string
 		}
 	}
 }
+
+// markLanguageMatch rates each model against the configured transcription language.
+func markLanguageMatch(models []domain.WhisperModelOption, language string) {
+	lang := strings.ToLower(strings.TrimSpace(language))
+	for i := range models {
+		models[i].LanguageMatch = modelLanguageMatch(models[i], lang)
+	}
+}
+
+func modelLanguageMatch(model domain.WhisperModelOption, lang string) domain.ModelLanguageMatch {
+	if len(model.Languages) == 0 {
+		return domain.ModelLanguageSupported
+	}
+	if lang == "" || lang == "auto" {
+		return domain.ModelLanguageUnsupported
+	}
+	for _, supported := range model.Languages {
+		if strings.EqualFold(supported, lang) {
+			return domain.ModelLanguageSpecialized
+		}
+	}
+	return domain.ModelLanguageUnsupported
+}
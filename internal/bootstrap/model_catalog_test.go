@@ -3,9 +3,9 @@ package bootstrap
 import (
 	"os"
 	"path/filepath"
-	"strings"
 	"testing"
 
+	"media-transcriber/internal/config"
 	"media-transcriber/internal/domain"
 )
 
@@ -26,8 +26,12 @@ func TestResolveModelDownloadDirectoryForEmptyPath(t *testing.T) {
 	if err != nil {
 		t.Fatalf("resolve dir: %v", err)
 	}
-	if !strings.Contains(filepath.ToSlash(dir), "/.media-transcriber/models") {
-		t.Fatalf("dir = %s, expected ~/.media-transcriber/models suffix", dir)
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatalf("home: %v", err)
+	}
+	if want := filepath.Join(config.ResolvePaths(homeDir).Data, "models"); dir != want {
+		t.Fatalf("dir = %s, want %s", dir, want)
 	}
 }
 
@@ -99,3 +103,54 @@ func TestMarkDownloadedModels(t *testing.T) {
 		t.Fatal("expected small to remain not downloaded")
 	}
 }
+
+// TestHuggingFaceAuthHeadersOnlyForHuggingFaceHosts avoids leaking the token to other hosts.
+func TestHuggingFaceAuthHeadersOnlyForHuggingFaceHosts(t *testing.T) {
+	headers := huggingFaceAuthHeaders("https://huggingface.co/org/private/resolve/main/model.bin", "hf_abc")
+	if got := headers.Get("Authorization"); got != "Bearer hf_abc" {
+		t.Fatalf("authorization = %q, want bearer token", got)
+	}
+
+	if headers := huggingFaceAuthHeaders("https://example.com/model.bin", "hf_abc"); headers != nil {
+		t.Fatalf("expected no headers for non-HF host, got %v", headers)
+	}
+	if headers := huggingFaceAuthHeaders("https://huggingface.co/model.bin", ""); headers != nil {
+		t.Fatalf("expected no headers without token, got %v", headers)
+	}
+}
+
+// TestMarkLanguageMatch rates specialized and multilingual models by configured language.
+func TestMarkLanguageMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		model    domain.WhisperModelOption
+		want     domain.ModelLanguageMatch
+	}{
+		{name: "multilingual", language: "de", model: domain.WhisperModelOption{}, want: domain.ModelLanguageSupported},
+		{name: "english model for en", language: "en", model: domain.WhisperModelOption{Languages: []string{"en"}}, want: domain.ModelLanguageSpecialized},
+		{name: "english model for ru", language: "ru", model: domain.WhisperModelOption{Languages: []string{"en"}}, want: domain.ModelLanguageUnsupported},
+		{name: "english model for auto", language: "auto", model: domain.WhisperModelOption{Languages: []string{"en"}}, want: domain.ModelLanguageUnsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			models := []domain.WhisperModelOption{tt.model}
+			markLanguageMatch(models, tt.language)
+			if models[0].LanguageMatch != tt.want {
+				t.Fatalf("match = %s, want %s", models[0].LanguageMatch, tt.want)
+			}
+		})
+	}
+}
+
+// TestCatalogIncludesDistilWhisper verifies distil-large-v3 is available and English-only.
+func TestCatalogIncludesDistilWhisper(t *testing.T) {
+	model, found := getWhisperModelByID("distil-large-v3")
+	if !found {
+		t.Fatal("expected distil-large-v3 model to exist")
+	}
+	if len(model.Languages) != 1 || model.Languages[0] != "en" {
+		t.Fatalf("languages = %v, want [en]", model.Languages)
+	}
+}
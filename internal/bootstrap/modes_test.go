@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestStartTranscriptionModePreprocess passes the mode to the pipeline and
+// reports the exported WAV as the result.
+func TestStartTranscriptionModePreprocess(t *testing.T) {
+	var got domain.PipelineMode
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{OutputDir: t.TempDir()}},
+		Jobs:  jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			got = req.Mode
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{AudioPath: "/out/clip.16k.wav"}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	if _, err := app.StartTranscriptionMode("/media/clip.mp4", domain.PipelineModePreprocess); err != nil {
+		t.Fatalf("StartTranscriptionMode() error = %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+
+	if got != domain.PipelineModePreprocess {
+		t.Fatalf("request mode = %q, want preprocess", got)
+	}
+	results := app.JobEvents(0, jobs.EventFilter{Types: []jobs.EventType{jobs.EventTypeResult}})
+	if len(results) != 1 || results[0].Message != "Preprocessed audio exported" || results[0].TextPath != "/out/clip.16k.wav" {
+		t.Fatalf("result events = %+v, want the exported WAV", results)
+	}
+}
+
+// TestStartTranscriptionModeRejectsUnknown fails before starting a job.
+func TestStartTranscriptionModeRejectsUnknown(t *testing.T) {
+	app := &App{
+		Store:  &fakeStore{settings: domain.Settings{OutputDir: t.TempDir()}},
+		Jobs:   jobs.NewManager(),
+		events: jobs.NewEventBus(100),
+	}
+	if _, err := app.StartTranscriptionMode("/media/clip.mp4", "translate"); err == nil {
+		t.Fatal("StartTranscriptionMode() error = nil, want unknown mode")
+	}
+	if job := app.CurrentJob(); job.ID != "" {
+		t.Fatalf("job %+v started for an unknown mode", job)
+	}
+}
@@ -0,0 +1,130 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// defaultMeetingTemplate names outputs of recordings matched to a meeting
+// when no naming template is configured. The start time keeps several
+// recordings of one meeting, or same-titled meetings of a day, apart.
+const defaultMeetingTemplate = "{date} {time} {meeting}"
+
+// maxNamedAttendees caps {attendees}; the rest are summarized as "+N".
+const maxNamedAttendees = 3
+
+// namePlaceholder matches {placeholders} in naming templates.
+var namePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// validateNameTemplate rejects unknown placeholders.
+func validateNameTemplate(template string) error {
+	for _, placeholder := range namePlaceholder.FindAllString(template, -1) {
+		switch placeholder {
+		case "{name}", "{date}", "{time}", "{meeting}", "{attendees}":
+		default:
+			return fmt.Errorf("unknown placeholder %s in naming template", placeholder)
+		}
+	}
+	return nil
+}
+
+// outputName expands the naming template for a recording. An empty result
+// keeps the default name derived from the input file.
+func outputName(template, inputPath string, rec recording) string {
+	if template == "" {
+		if rec.meeting == nil {
+			return ""
+		}
+		template = defaultMeetingTemplate
+	}
+	base := filepath.Base(inputPath)
+	at := rec.start
+	if at.IsZero() {
+		at = time.Now()
+	}
+	values := map[string]string{
+		"{name}": strings.TrimSuffix(base, filepath.Ext(base)),
+		"{date}": at.Local().Format("2006-01-02"),
+		"{time}": at.Local().Format("15-04"),
+	}
+	if rec.meeting != nil {
+		values["{meeting}"] = rec.meeting.Title
+		values["{attendees}"] = attendeeSummary(rec.meeting.Attendees)
+	}
+	name := namePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		return values[placeholder]
+	})
+	return sanitizeFileName(name)
+}
+
+// attendeeSummary lists the first attendees and counts the rest.
+func attendeeSummary(attendees []string) string {
+	if len(attendees) <= maxNamedAttendees {
+		return strings.Join(attendees, ", ")
+	}
+	return fmt.Sprintf("%s +%d", strings.Join(attendees[:maxNamedAttendees], ", "), len(attendees)-maxNamedAttendees)
+}
+
+// sanitizeFileName replaces characters that are invalid in file names on any
+// platform and trims separators left by empty placeholders.
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	return strings.Trim(strings.Join(strings.Fields(name), " "), " .-_")
+}
+
+// metadataSidecar is the JSON written next to the transcript of a recording
+// matched to a meeting.
+type metadataSidecar struct {
+	Source     string          `json:"source"`
+	RecordedAt time.Time       `json:"recordedAt"`
+	Meeting    *domain.Meeting `json:"meeting,omitempty"`
+	Tags       []string        `json:"tags,omitempty"`
+}
+
+// writeMetadataSidecar writes <transcript base>.meta.json and returns its path.
+func writeMetadataSidecar(textPath string, rec recording, entry domain.HistoryEntry) (string, error) {
+	data, err := json.MarshalIndent(metadataSidecar{
+		Source:     entry.InputPath,
+		RecordedAt: rec.start,
+		Meeting:    rec.meeting,
+		Tags:       entry.Tags,
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimSuffix(textPath, filepath.Ext(textPath)) + ".meta.json"
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// meetingTags adds the meeting title and attendees to the transcript tags.
+func meetingTags(tags []string, meeting *domain.Meeting) []string {
+	if meeting == nil {
+		return tags
+	}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		seen[strings.ToLower(tag)] = true
+	}
+	for _, tag := range append([]string{meeting.Title}, meeting.Attendees...) {
+		if tag = strings.TrimSpace(tag); tag != "" && !seen[strings.ToLower(tag)] {
+			seen[strings.ToLower(tag)] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
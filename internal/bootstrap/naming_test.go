@@ -0,0 +1,108 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestOutputName expands templates and falls back to the meeting template.
+func TestOutputName(t *testing.T) {
+	start := time.Date(2026, 10, 16, 9, 5, 0, 0, time.Local)
+	meeting := &domain.Meeting{Title: "Budget review: Q4", Attendees: []string{"Anna", "Bob", "Chen", "Dana"}}
+
+	tests := []struct {
+		name     string
+		template string
+		rec      recording
+		want     string
+	}{
+		{name: "no template, no meeting", rec: recording{start: start}, want: ""},
+		{name: "no template, meeting", rec: recording{start: start, meeting: meeting}, want: "2026-10-16 09-05 Budget review- Q4"},
+		{name: "custom", template: "{date}_{time} {meeting} ({attendees})", rec: recording{start: start, meeting: meeting}, want: "2026-10-16_09-05 Budget review- Q4 (Anna, Bob, Chen +1)"},
+		{name: "meeting placeholder without meeting", template: "{name} - {meeting}", rec: recording{start: start}, want: "rec-0042"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := outputName(tt.template, "/media/rec-0042.m4a", tt.rec); got != tt.want {
+				t.Fatalf("name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := validateNameTemplate("{date} {meeting}"); err != nil {
+		t.Fatalf("valid template: %v", err)
+	}
+	if err := validateNameTemplate("{date} {title}"); err == nil {
+		t.Fatal("expected error for unknown placeholder")
+	}
+}
+
+// TestMeetingRecordingIsNamedAndTagged matches a recording to an ICS event,
+// names the outputs after it, and writes the metadata sidecar.
+func TestMeetingRecordingIsNamedAndTagged(t *testing.T) {
+	root := t.TempDir()
+	icsPath := filepath.Join(root, "work.ics")
+	mustWrite(t, icsPath, "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Budget review\nDTSTART:20261016T090000Z\nDTEND:20261016T100000Z\n"+
+		"ATTENDEE;CN=Anna Petrova:mailto:anna@example.com\nEND:VEVENT\nEND:VCALENDAR\n")
+	inputPath := filepath.Join(root, "rec-0042.m4a")
+	mustWrite(t, inputPath, "media")
+	recordedAt := time.Date(2026, 10, 16, 9, 50, 0, 0, time.UTC)
+	if err := os.Chtimes(inputPath, recordedAt, recordedAt); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: root, CalendarICS: icsPath, Tagging: domain.TaggingOff}},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			textPath := filepath.Join(req.OutputDir, req.OutputName+".txt")
+			mustWrite(t, textPath, "hello")
+			return transcribe.Result{TextPath: textPath, Transcript: "hello"}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+	job, err := app.StartTranscription(inputPath)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+
+	entry, err := app.findHistoryEntry(job.ID)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	base := filepath.Join(root, recordedAt.Local().Format("2006-01-02 15-04")+" Budget review")
+	if entry.TextPath != base+".txt" || entry.MetadataPath != base+".meta.json" {
+		t.Fatalf("entry paths = %q, %q", entry.TextPath, entry.MetadataPath)
+	}
+	if entry.Meeting == nil || entry.Meeting.Title != "Budget review" {
+		t.Fatalf("meeting = %+v", entry.Meeting)
+	}
+	if len(entry.Tags) != 2 || entry.Tags[0] != "Budget review" || entry.Tags[1] != "Anna Petrova" {
+		t.Fatalf("tags = %v", entry.Tags)
+	}
+
+	data, err := os.ReadFile(entry.MetadataPath)
+	if err != nil {
+		t.Fatalf("read sidecar: %v", err)
+	}
+	var sidecar metadataSidecar
+	if err := json.Unmarshal(data, &sidecar); err != nil {
+		t.Fatalf("decode sidecar: %v", err)
+	}
+	if sidecar.Source != inputPath || sidecar.Meeting == nil || sidecar.Meeting.Attendees[0] != "Anna Petrova" {
+		t.Fatalf("sidecar = %s", data)
+	}
+}
@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/notes"
+	"media-transcriber/internal/textenc"
+)
+
+// exportNotes writes the transcript of a finished job to the Obsidian vault
+// folder and/or as a Notion page, as enabled in settings. The active profile
+// is the project the note links back to, so each profile can target its own
+// folder or parent page. It returns the created Notion page URL, if any.
+func (a *App) exportNotes(ctx context.Context, entry domain.HistoryEntry, settings domain.Settings) []string {
+	if settings.ObsidianFolder == "" && settings.NotionParentPageID == "" {
+		return nil
+	}
+	if entry.TextPath == "" {
+		return nil
+	}
+	text, err := os.ReadFile(entry.TextPath)
+	if err != nil {
+		a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("note export: read transcript: %v", err)})
+		return nil
+	}
+	note := notes.Note{
+		Title:    strings.TrimSuffix(filepath.Base(entry.TextPath), filepath.Ext(entry.TextPath)),
+		Text:     textenc.Decode(text),
+		Tags:     entry.Tags,
+		Source:   entry.InputPath,
+		Language: entry.Language,
+		Date:     entry.FinishedAt,
+		Project:  settings.ActiveProfile,
+	}
+	if entry.ModelPath != "" {
+		note.Model = filepath.Base(entry.ModelPath)
+	}
+
+	if settings.ObsidianFolder != "" {
+		if path, err := notes.WriteObsidian(settings.ObsidianFolder, note); err != nil {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("obsidian export: %v", err)})
+		} else {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: "Obsidian note written", TextPath: path})
+		}
+	}
+	var links []string
+	if settings.NotionParentPageID != "" {
+		if url, err := a.createNotionPage(ctx, settings.NotionParentPageID, note); err != nil {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("notion export: %v", err)})
+		} else {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: "Notion page created: " + url})
+			links = append(links, url)
+		}
+	}
+	return links
+}
+
+// createNotionPage adds note under parentID with the stored token.
+func (a *App) createNotionPage(ctx context.Context, parentID string, note notes.Note) (string, error) {
+	client, err := notes.NewNotionClient(a.secret(config.SecretNotionToken))
+	if err != nil {
+		return "", err
+	}
+	return client.CreatePage(ctx, parentID, note)
+}
+
+// SetNotionToken stores the Notion integration token.
+func (a *App) SetNotionToken(token string) error {
+	return a.storeSecret(config.SecretNotionToken, "Notion token", token)
+}
+
+// HasNotionToken reports whether a Notion token is stored, without exposing it.
+func (a *App) HasNotionToken() bool {
+	return a.secret(config.SecretNotionToken) != ""
+}
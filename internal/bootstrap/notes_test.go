@@ -0,0 +1,60 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// TestExportNotesWritesObsidianNote links the note to the active profile as its project.
+func TestExportNotesWritesObsidianNote(t *testing.T) {
+	root := t.TempDir()
+	vault := filepath.Join(root, "Vault", "Transcripts")
+	entry := domain.HistoryEntry{
+		ID:         "job-1",
+		InputPath:  filepath.Join(root, "ep12.mp3"),
+		TextPath:   filepath.Join(root, "ep12.txt"),
+		ModelPath:  filepath.Join(root, "ggml-base.bin"),
+		Tags:       []string{"climate"},
+		FinishedAt: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+	}
+	mustWrite(t, entry.TextPath, "Hello there.")
+	app := &App{events: jobs.NewEventBus(10)}
+
+	app.deliverJob(context.Background(), entry, domain.Settings{ObsidianFolder: vault, ActiveProfile: "Podcasts"})
+
+	data, err := os.ReadFile(filepath.Join(vault, "ep12.md"))
+	if err != nil {
+		t.Fatalf("read note: %v", err)
+	}
+	for _, want := range []string{"  - climate\n", `model: "ggml-base.bin"`, "[[Podcasts]]", "Hello there."} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("note missing %q:\n%s", want, data)
+		}
+	}
+	events := app.JobEvents(0, jobs.EventFilter{JobID: "job-1"})
+	if len(events) != 1 || events[0].TextPath != filepath.Join(vault, "ep12.md") {
+		t.Fatalf("events = %+v", events)
+	}
+}
+
+// TestExportNotesReportsMissingNotionToken publishes an error instead of failing the job.
+func TestExportNotesReportsMissingNotionToken(t *testing.T) {
+	root := t.TempDir()
+	entry := domain.HistoryEntry{ID: "job-1", TextPath: filepath.Join(root, "a.txt")}
+	mustWrite(t, entry.TextPath, "x")
+	app := &App{events: jobs.NewEventBus(10)}
+
+	app.exportNotes(context.Background(), entry, domain.Settings{NotionParentPageID: "page"})
+
+	events := app.JobEvents(0, jobs.EventFilter{JobID: "job-1"})
+	if len(events) != 1 || events[0].Type != jobs.EventTypeError || !strings.Contains(events[0].Message, "token") {
+		t.Fatalf("events = %+v", events)
+	}
+}
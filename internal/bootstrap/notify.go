@@ -0,0 +1,92 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/notify"
+	"media-transcriber/internal/textenc"
+)
+
+const (
+	// notifyTimeout bounds each webhook call; it does not use the job context,
+	// which may already be done when a job fails on its deadline.
+	notifyTimeout = 10 * time.Second
+	// notifyExcerptRunes caps the transcript excerpt in chat messages.
+	notifyExcerptRunes = 280
+)
+
+// notifyJob posts the outcome of a completed or failed job to the Slack and
+// Discord webhooks in settings, unless the local time is within quiet hours.
+// links are published copies of the transcript; the first web link is shared.
+func (a *App) notifyJob(entry domain.HistoryEntry, settings domain.Settings, links []string) {
+	if settings.SlackWebhookURL == "" && settings.DiscordWebhookURL == "" {
+		return
+	}
+	if hours, ok, _ := notify.ParseQuietHours(settings.NotifyQuietHours); ok && hours.Contains(time.Now()) {
+		a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: "Notifications skipped during quiet hours"})
+		return
+	}
+
+	msg := notify.Message{
+		FileName:  filepath.Base(entry.InputPath),
+		Succeeded: entry.Status == domain.JobStatusDone,
+		Audio:     time.Duration(entry.AudioSeconds * float64(time.Second)),
+		Error:     entry.Error,
+	}
+	if !entry.StartedAt.IsZero() && entry.FinishedAt.After(entry.StartedAt) {
+		msg.Elapsed = entry.FinishedAt.Sub(entry.StartedAt)
+	}
+	for _, link := range links {
+		if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+			msg.Link = link
+			break
+		}
+	}
+	if msg.Succeeded && entry.TextPath != "" {
+		msg.Excerpt = transcriptExcerpt(entry.TextPath)
+	}
+
+	client := &http.Client{Timeout: notifyTimeout}
+	for _, target := range []struct {
+		name string
+		url  string
+		post func(context.Context, *http.Client, string, notify.Message) error
+	}{
+		{name: "Slack", url: settings.SlackWebhookURL, post: notify.Slack},
+		{name: "Discord", url: settings.DiscordWebhookURL, post: notify.Discord},
+	} {
+		if target.url == "" {
+			continue
+		}
+		if err := target.post(context.Background(), client, target.url, msg); err != nil {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("%s notification: %v", strings.ToLower(target.name), err)})
+			continue
+		}
+		a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: target.name + " notified"})
+	}
+}
+
+// transcriptExcerpt returns the start of a transcript, cut at a word boundary.
+func transcriptExcerpt(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	text := []rune(strings.Join(strings.Fields(textenc.Decode(data)), " "))
+	if len(text) <= notifyExcerptRunes {
+		return string(text)
+	}
+	excerpt := string(text[:notifyExcerptRunes])
+	if cut := strings.LastIndex(excerpt, " "); cut > 0 {
+		excerpt = excerpt[:cut]
+	}
+	return excerpt + "…"
+}
@@ -0,0 +1,146 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestFailedJobNotifiesSlack posts the failure reason to the Slack webhook.
+func TestFailedJobNotifiesSlack(t *testing.T) {
+	messages := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		messages <- body["text"]
+	}))
+	defer server.Close()
+
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{ModelPath: "/tmp/model.bin", OutputDir: t.TempDir(), SlackWebhookURL: server.URL}},
+		Jobs:  jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(context.Context, transcribe.Request) (transcribe.Result, error) {
+			return transcribe.Result{}, errors.New("ffmpeg exited with 1")
+		}},
+		events: jobs.NewEventBus(100),
+	}
+	if _, err := app.StartTranscription("/tmp/ep12.mp3"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	select {
+	case text := <-messages:
+		if text != "❌ *ep12.mp3* failed: ffmpeg exited with 1" {
+			t.Fatalf("text = %q", text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no Slack message")
+	}
+}
+
+// TestSlowNotificationDoesNotHoldJobSlot frees the job slot while the
+// webhook is still answering, and drains the delivery afterwards.
+func TestSlowNotificationDoesNotHoldJobSlot(t *testing.T) {
+	received := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		<-release
+	}))
+	defer server.Close()
+
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{ModelPath: "/tmp/model.bin", OutputDir: t.TempDir(), SlackWebhookURL: server.URL}},
+		Jobs:  jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(context.Context, transcribe.Request) (transcribe.Result, error) {
+			return transcribe.Result{}, errors.New("ffmpeg exited with 1")
+		}},
+		events: jobs.NewEventBus(100),
+	}
+	if _, err := app.StartTranscription("/tmp/ep12.mp3"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no Slack request")
+	}
+	if app.Jobs.IsRunning() {
+		t.Fatal("job slot held while the webhook answers")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if app.waitFollowUps(ctx) {
+		t.Fatal("delivery finished before the webhook answered")
+	}
+	close(release)
+	if !app.waitFollowUps(context.Background()) {
+		t.Fatal("delivery did not finish")
+	}
+}
+
+// TestDeliverJobNotifiesWithLinkAndExcerpt shares the first web link and the transcript start.
+func TestDeliverJobNotifiesWithLinkAndExcerpt(t *testing.T) {
+	var content string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		content = body["content"]
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	entry := domain.HistoryEntry{ID: "job-1", Status: domain.JobStatusDone, InputPath: filepath.Join(root, "ep12.mp3"), TextPath: filepath.Join(root, "ep12.txt"), AudioSeconds: 90}
+	mustWrite(t, entry.TextPath, "Welcome   to\nthe show. "+strings.Repeat("More words here. ", 40))
+	app := &App{events: jobs.NewEventBus(10)}
+
+	app.deliverJob(context.Background(), entry, domain.Settings{WebDAVURL: server.URL + "/dav", DiscordWebhookURL: server.URL + "/hook"})
+
+	want := "✅ **ep12.mp3** transcribed (1m30s of audio)\n" + server.URL + "/dav/ep12.txt\n> Welcome to the show. More words"
+	if !strings.HasPrefix(content, want) || !strings.HasSuffix(content, "…") {
+		t.Fatalf("content = %q", content)
+	}
+}
+
+// TestNotifyJobRespectsQuietHours skips webhooks inside the window.
+func TestNotifyJobRespectsQuietHours(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true }))
+	defer server.Close()
+
+	app := &App{events: jobs.NewEventBus(10)}
+	now := time.Now()
+	quiet := now.Add(-time.Minute).Format("15:04") + "-" + now.Add(2*time.Minute).Format("15:04")
+	app.notifyJob(domain.HistoryEntry{ID: "job-1", Status: domain.JobStatusDone}, domain.Settings{SlackWebhookURL: server.URL, NotifyQuietHours: quiet}, nil)
+
+	if called {
+		t.Fatal("webhook called during quiet hours")
+	}
+	events := app.JobEvents(0, jobs.EventFilter{JobID: "job-1"})
+	if len(events) != 1 || !strings.Contains(events[0].Message, "quiet hours") {
+		t.Fatalf("events = %+v", events)
+	}
+}
+
+// TestSaveSettingsRejectsInvalidQuietHours validates the window format.
+func TestSaveSettingsRejectsInvalidQuietHours(t *testing.T) {
+	app := &App{Store: &fakeStore{}}
+	if _, err := app.SaveSettings(domain.Settings{NotifyQuietHours: "late"}); err == nil {
+		t.Fatal("expected error for invalid quiet hours")
+	}
+}
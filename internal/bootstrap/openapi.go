@@ -0,0 +1,208 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// openAPIVersion is the API version published in the OpenAPI document.
+const openAPIVersion = "1.0.0"
+
+//go:generate go run ../../cmd/openapi ../../api/openapi.json
+
+// OpenAPIDocument renders the OpenAPI 3 document of the server-mode API from
+// the route table. `go generate ./...` writes it to api/openapi.json.
+func OpenAPIDocument() ([]byte, error) {
+	data, err := json.MarshalIndent(buildOpenAPI((&App{}).apiRoutes()), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// handleOpenAPI serves the OpenAPI document.
+func (a *App) handleOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	data, err := OpenAPIDocument()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// buildOpenAPI describes routes, collecting named struct schemas as components.
+func buildOpenAPI(routes []apiRoute) map[string]any {
+	schemas := map[string]any{"Error": map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"error": map[string]any{"type": "string"}},
+	}}
+	paths := map[string]any{}
+	for _, route := range routes {
+		operation := map[string]any{
+			"summary":     route.summary,
+			"operationId": operationID(route),
+			"responses":   routeResponses(route, schemas),
+		}
+		if route.scope != "" {
+			operation["security"] = []any{map[string]any{"bearerToken": []string{string(route.scope)}}}
+		}
+		if params := routeParameters(route); len(params) > 0 {
+			operation["parameters"] = params
+		}
+		if route.request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(route.request), schemas)}},
+			}
+		} else if route.path == "/api/uploads" {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/octet-stream": map[string]any{"schema": map[string]any{"type": "string", "format": "binary"}}},
+			}
+		}
+
+		item, _ := paths[route.path].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[route.path] = item
+		}
+		item[strings.ToLower(route.method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "media-transcriber server API",
+			"version":     openAPIVersion,
+			"description": "REST/SSE API of `media-transcriber serve`. Scoped endpoints need `Authorization: Bearer <token>` once any API token exists.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerToken": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+// operationID derives a stable camelCase ID from the method and path.
+func operationID(route apiRoute) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(route.method))
+	for _, part := range strings.FieldsFunc(route.path, func(r rune) bool { return r == '/' || r == '.' || r == '{' || r == '}' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// routeParameters documents path wildcards and query parameters.
+func routeParameters(route apiRoute) []any {
+	var params []any
+	for _, part := range strings.Split(route.path, "/") {
+		if name, ok := strings.CutPrefix(part, "{"); ok {
+			params = append(params, map[string]any{
+				"name": strings.TrimSuffix(name, "}"), "in": "path", "required": true,
+				"schema": map[string]any{"type": "string"},
+			})
+		}
+	}
+	for _, param := range route.query {
+		params = append(params, map[string]any{
+			"name": param.name, "in": "query", "description": param.description,
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+	return params
+}
+
+// routeResponses documents the success response and the JSON error body.
+func routeResponses(route apiRoute, schemas map[string]any) map[string]any {
+	success := map[string]any{"description": http.StatusText(route.status)}
+	switch {
+	case route.contentType != "":
+		success["content"] = map[string]any{route.contentType: map[string]any{"schema": map[string]any{"type": "string"}}}
+	case route.response != nil:
+		success["content"] = map[string]any{"application/json": map[string]any{"schema": schemaFor(reflect.TypeOf(route.response), schemas)}}
+	}
+
+	errorBody := map[string]any{
+		"description": "Error",
+		"content":     map[string]any{"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}}},
+	}
+	return map[string]any{strconv.Itoa(route.status): success, "default": errorBody}
+}
+
+// timeType is encoded as an RFC 3339 string.
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor returns the JSON schema of t, registering named structs in schemas.
+func schemaFor(t reflect.Type, schemas map[string]any) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+		if _, ok := schemas[name]; !ok {
+			schemas[name] = nil // reserve the name first so recursive types terminate
+			schemas[name] = structSchema(t, schemas)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	case t.Kind() == reflect.Struct:
+		return structSchema(t, schemas)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), schemas)}
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema lists the JSON-tagged fields of a struct as object properties.
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = schemaFor(field.Type, schemas)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,74 @@
+package bootstrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestOpenAPIDocumentUpToDate fails when api/openapi.json lags the route table.
+func TestOpenAPIDocumentUpToDate(t *testing.T) {
+	want, err := OpenAPIDocument()
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join("..", "..", "api", "openapi.json"))
+	if err != nil {
+		t.Fatalf("read committed document: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("api/openapi.json is stale; run `go generate ./internal/bootstrap`")
+	}
+}
+
+// TestOpenAPIDocumentCoversRoutes checks every route, its security, and schema refs.
+func TestOpenAPIDocumentCoversRoutes(t *testing.T) {
+	data, err := OpenAPIDocument()
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	var doc struct {
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	for _, route := range (&App{}).apiRoutes() {
+		operation, ok := doc.Paths[route.path][strings.ToLower(route.method)]
+		if !ok {
+			t.Fatalf("missing %s %s", route.method, route.path)
+		}
+		if _, secured := operation["security"]; secured != (route.scope != "") {
+			t.Fatalf("%s %s security = %v, want scope %q", route.method, route.path, secured, route.scope)
+		}
+	}
+
+	for _, ref := range strings.Split(string(data), `"$ref": "#/components/schemas/`)[1:] {
+		name, _, _ := strings.Cut(ref, `"`)
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Fatalf("dangling schema ref %q", name)
+		}
+	}
+}
+
+// TestHandleOpenAPIServesDocument exposes the document without a token.
+func TestHandleOpenAPIServesDocument(t *testing.T) {
+	app := &App{}
+	rec := httptest.NewRecorder()
+	app.serverHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"openapi": "3.0.3"`) {
+		t.Fatalf("unexpected body: %.200s", rec.Body.String())
+	}
+}
@@ -0,0 +1,178 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/plugins"
+	"media-transcriber/internal/transcribe"
+)
+
+// pluginTimeout bounds one run of a post-processing plugin.
+const pluginTimeout = 5 * time.Minute
+
+// ListPlugins returns the executables in the plugin directory and whether
+// each one runs after jobs.
+func (a *App) ListPlugins() ([]domain.PluginInfo, error) {
+	found, err := plugins.Discover(a.pluginDir)
+	if err != nil {
+		return nil, fmt.Errorf("read plugin directory: %w", err)
+	}
+	settings, err := a.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]domain.PluginInfo, 0, len(found))
+	for _, plugin := range found {
+		infos = append(infos, domain.PluginInfo{
+			Name:      plugin.Name,
+			Path:      plugin.Path,
+			Enabled:   slices.Contains(settings.EnabledPlugins, plugin.Name),
+			Transform: plugin.Transform,
+		})
+	}
+	return infos, nil
+}
+
+// SetPluginEnabled turns running the named plugin with jobs on or off.
+// Only plugins in the plugin directory, and of transform scripts only those
+// that parse, can be enabled; any name can be disabled, so a removed plugin
+// can be cleaned out of the settings.
+func (a *App) SetPluginEnabled(name string, enabled bool) ([]domain.PluginInfo, error) {
+	name = strings.TrimSpace(name)
+	settings, err := a.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		found, err := plugins.Discover(a.pluginDir)
+		if err != nil {
+			return nil, fmt.Errorf("read plugin directory: %w", err)
+		}
+		index := slices.IndexFunc(found, func(plugin plugins.Plugin) bool { return plugin.Name == name })
+		if index < 0 {
+			return nil, fmt.Errorf("unknown plugin: %s", name)
+		}
+		if found[index].Transform {
+			if _, err := loadTransform(found[index]); err != nil {
+				return nil, err
+			}
+		}
+		settings.EnabledPlugins = append(settings.EnabledPlugins, name)
+	} else {
+		settings.EnabledPlugins = slices.DeleteFunc(settings.EnabledPlugins, func(enabled string) bool { return enabled == name })
+	}
+	if _, err := a.SaveSettings(settings); err != nil {
+		return nil, err
+	}
+	return a.ListPlugins()
+}
+
+// runPlugins passes a completed job to every enabled plugin in name order
+// and publishes what they report. Artifacts that exist are added to the
+// history entry, which is returned, so they are archived and listed with the
+// job's own outputs. Plugin failures are reported and never fail the job.
+func (a *App) runPlugins(ctx context.Context, entry domain.HistoryEntry, settings domain.Settings) domain.HistoryEntry {
+	if len(settings.EnabledPlugins) == 0 {
+		return entry
+	}
+	found, err := plugins.Discover(a.pluginDir)
+	if err != nil {
+		a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("read plugin directory: %v", err)})
+		return entry
+	}
+	dir := settings.OutputDir
+	if entry.TextPath != "" {
+		dir = filepath.Dir(entry.TextPath)
+	}
+
+	added := false
+	for _, plugin := range found {
+		if plugin.Transform || !slices.Contains(settings.EnabledPlugins, plugin.Name) {
+			continue
+		}
+		runCtx, cancel := context.WithTimeout(ctx, pluginTimeout)
+		output, err := plugins.Run(runCtx, plugin, dir, plugins.Input{Version: plugins.InputVersion, Job: entry})
+		cancel()
+		for _, message := range output.Messages {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: plugin.Name + ": " + message})
+		}
+		for _, message := range output.Errors {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: plugin.Name + ": " + message})
+		}
+		for _, path := range output.Artifacts {
+			if info, statErr := os.Stat(path); statErr != nil || !info.Mode().IsRegular() {
+				a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("Plugin %s reported a missing file: %s", plugin.Name, path)})
+				continue
+			}
+			entry.PluginArtifacts = append(entry.PluginArtifacts, path)
+			added = true
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeLog, Message: fmt.Sprintf("Plugin %s exported a file", plugin.Name), TextPath: path})
+		}
+		if err != nil {
+			a.publishEvent(jobs.Event{JobID: entry.ID, Type: jobs.EventTypeError, Message: fmt.Sprintf("Plugin %s failed: %v", plugin.Name, err)})
+		}
+	}
+	if added {
+		a.recordHistory(entry)
+	}
+	return entry
+}
+
+// enabledTransforms loads the enabled transform scripts of the plugin
+// directory in name order. Scripts that cannot be loaded are reported and
+// left out, so a broken script costs its own output, not the job.
+func (a *App) enabledTransforms(jobID string, settings domain.Settings) []transcribe.Transform {
+	if len(settings.EnabledPlugins) == 0 {
+		return nil
+	}
+	found, err := plugins.Discover(a.pluginDir)
+	if err != nil {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeError, Message: fmt.Sprintf("read plugin directory: %v", err)})
+		return nil
+	}
+	var transforms []transcribe.Transform
+	for _, plugin := range found {
+		if !plugin.Transform || !slices.Contains(settings.EnabledPlugins, plugin.Name) {
+			continue
+		}
+		transform, err := loadTransform(plugin)
+		if err != nil {
+			a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeError, Message: err.Error()})
+			continue
+		}
+		transforms = append(transforms, transform)
+	}
+	return transforms
+}
+
+// loadTransform reads a transform script and checks that it parses.
+func loadTransform(plugin plugins.Plugin) (transcribe.Transform, error) {
+	source, err := os.ReadFile(plugin.Path)
+	if err != nil {
+		return transcribe.Transform{}, fmt.Errorf("read transform %s: %w", plugin.Name, err)
+	}
+	if _, err := transcribe.ParseTransform(plugin.Name, string(source)); err != nil {
+		return transcribe.Transform{}, fmt.Errorf("invalid transform %s: %w", plugin.Name, err)
+	}
+	return transcribe.Transform{Name: plugin.Name, Source: string(source)}, nil
+}
+
+// normalizePlugins trims plugin names and drops blanks and duplicates.
+func normalizePlugins(names []string) []string {
+	var normalized []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,213 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestSetPluginEnabled toggles plugins found in the plugin directory and
+// refuses to enable unknown ones.
+func TestSetPluginEnabled(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("execute bits do not apply on Windows")
+	}
+	root := t.TempDir()
+	pluginDir := filepath.Join(root, "plugins")
+	if err := os.MkdirAll(pluginDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, "zip-outputs"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	app := &App{Store: config.NewJSONStore(filepath.Join(root, "settings.json")), pluginDir: pluginDir}
+
+	infos, err := app.SetPluginEnabled("zip-outputs", true)
+	if err != nil {
+		t.Fatalf("SetPluginEnabled(on) error = %v", err)
+	}
+	want := []domain.PluginInfo{{Name: "zip-outputs", Path: filepath.Join(pluginDir, "zip-outputs"), Enabled: true}}
+	if !reflect.DeepEqual(infos, want) {
+		t.Fatalf("plugins = %+v, want %+v", infos, want)
+	}
+	if settings, _ := app.Store.Load(); !reflect.DeepEqual(settings.EnabledPlugins, []string{"zip-outputs"}) {
+		t.Fatalf("saved plugins = %v", settings.EnabledPlugins)
+	}
+
+	if _, err := app.SetPluginEnabled("missing", true); err == nil {
+		t.Fatal("SetPluginEnabled(missing) error = nil, want unknown plugin")
+	}
+	infos, err = app.SetPluginEnabled("zip-outputs", false)
+	if err != nil || len(infos) != 1 || infos[0].Enabled {
+		t.Fatalf("SetPluginEnabled(off) = %+v, %v", infos, err)
+	}
+}
+
+// TestRunPluginsAddsArtifacts runs only enabled plugins after a job, records
+// the files they report, and reports their messages and failures.
+func TestRunPluginsAddsArtifacts(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	root := t.TempDir()
+	pluginDir := filepath.Join(root, "plugins")
+	outputDir := filepath.Join(root, "out")
+	for _, dir := range []string{pluginDir, outputDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scripts := map[string]string{
+		"a-summary": "cat > talk.job.json\necho '{\"artifact\": \"talk.job.json\"}'\necho '{\"artifact\": \"gone.txt\"}'\necho summarized\n",
+		"b-broken":  "echo 'bad config' >&2\nexit 1\n",
+		"c-off":     "touch off-ran\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(pluginDir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	app := &App{Store: &fakeStore{}, History: history, events: jobs.NewEventBus(100), pluginDir: pluginDir}
+	entry := domain.HistoryEntry{ID: "job-1", Status: domain.JobStatusDone, TextPath: filepath.Join(outputDir, "talk.txt")}
+	app.recordHistory(entry)
+
+	entry = app.runPlugins(context.Background(), entry, domain.Settings{EnabledPlugins: []string{"a-summary", "b-broken"}})
+
+	artifact := filepath.Join(outputDir, "talk.job.json")
+	if !reflect.DeepEqual(entry.PluginArtifacts, []string{artifact}) {
+		t.Fatalf("plugin artifacts = %v, want %v", entry.PluginArtifacts, []string{artifact})
+	}
+	if stored, err := app.findHistoryEntry("job-1"); err != nil || !reflect.DeepEqual(stored.PluginArtifacts, entry.PluginArtifacts) {
+		t.Fatalf("history entry = %+v, %v", stored, err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "off-ran")); !os.IsNotExist(err) {
+		t.Fatalf("disabled plugin ran: %v", err)
+	}
+
+	var messages []string
+	for _, event := range app.JobEvents(0, jobs.EventFilter{JobID: "job-1"}) {
+		messages = append(messages, event.Message)
+	}
+	want := []string{
+		"a-summary: summarized",
+		"Plugin a-summary exported a file",
+		"Plugin a-summary reported a missing file: " + filepath.Join(outputDir, "gone.txt"),
+		"Plugin b-broken failed: exit status 1: bad config",
+	}
+	for _, message := range want {
+		if !containsString(messages, message) {
+			t.Fatalf("events %q lack %q", messages, message)
+		}
+	}
+}
+
+// TestSlowPluginDoesNotHoldJobSlot frees the job slot while a plugin still
+// runs, and drains the plugin afterwards.
+func TestSlowPluginDoesNotHoldJobSlot(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	root := t.TempDir()
+	pluginDir := filepath.Join(root, "plugins")
+	outputDir := filepath.Join(root, "out")
+	for _, dir := range []string{pluginDir, outputDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script := "#!/bin/sh\ntouch started\nwhile [ ! -f release ]; do sleep 0.02; done\ntouch finished\n"
+	if err := os.WriteFile(filepath.Join(pluginDir, "slow"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{ModelPath: "/tmp/model.bin", OutputDir: outputDir, EnabledPlugins: []string{"slow"}}},
+		Jobs:  jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{}, nil
+		}},
+		events:    jobs.NewEventBus(100),
+		pluginDir: pluginDir,
+	}
+	if _, err := app.StartTranscription("/tmp/ep12.mp3"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(filepath.Join(outputDir, "started")); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("plugin did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if app.Jobs.IsRunning() {
+		t.Fatal("job slot held while the plugin runs")
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, "release"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !app.waitFollowUps(context.Background()) {
+		t.Fatal("plugin did not finish")
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "finished")); err != nil {
+		t.Fatalf("plugin was not drained: %v", err)
+	}
+}
+
+// TestEnabledTransforms validates transform scripts when they are enabled
+// and leaves scripts that no longer parse out of jobs, reporting them.
+func TestEnabledTransforms(t *testing.T) {
+	root := t.TempDir()
+	pluginDir := filepath.Join(root, "plugins")
+	mustWrite(t, filepath.Join(pluginDir, "notes.md.lua"), "write(job.transcript)")
+	mustWrite(t, filepath.Join(pluginDir, "broken.lua"), "if then")
+	app := &App{Store: config.NewJSONStore(filepath.Join(root, "settings.json")), events: jobs.NewEventBus(100), pluginDir: pluginDir}
+
+	if _, err := app.SetPluginEnabled("broken.lua", true); err == nil {
+		t.Fatal("SetPluginEnabled(broken.lua) error = nil, want invalid transform")
+	}
+	infos, err := app.SetPluginEnabled("notes.md.lua", true)
+	if err != nil {
+		t.Fatalf("SetPluginEnabled(notes.md.lua) error = %v", err)
+	}
+	if len(infos) != 2 || !infos[1].Transform || !infos[1].Enabled {
+		t.Fatalf("plugins = %+v, want notes.md.lua as an enabled transform", infos)
+	}
+
+	settings := domain.Settings{EnabledPlugins: []string{"broken.lua", "notes.md.lua"}}
+	transforms := app.enabledTransforms("job-1", settings)
+	want := []transcribe.Transform{{Name: "notes.md.lua", Source: "write(job.transcript)"}}
+	if !reflect.DeepEqual(transforms, want) {
+		t.Fatalf("transforms = %+v, want %+v", transforms, want)
+	}
+	events := app.JobEvents(0, jobs.EventFilter{JobID: "job-1", Types: []jobs.EventType{jobs.EventTypeError}})
+	if len(events) != 1 || !strings.Contains(events[0].Message, "invalid transform broken.lua") {
+		t.Fatalf("error events = %+v, want the broken script reported", events)
+	}
+}
+
+// containsString reports whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
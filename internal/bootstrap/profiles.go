@@ -0,0 +1,126 @@
+package bootstrap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"media-transcriber/internal/domain"
+)
+
+// ListProfiles returns saved settings profiles sorted by name.
+func (a *App) ListProfiles() ([]domain.SettingsProfile, error) {
+	if a.Profiles == nil {
+		return nil, fmt.Errorf("profile store is not configured")
+	}
+
+	profiles, err := a.Profiles.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load profiles: %w", err)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return strings.ToLower(profiles[i].Name) < strings.ToLower(profiles[j].Name)
+	})
+	return profiles, nil
+}
+
+// SaveProfile creates or replaces the named profile with normalized settings.
+func (a *App) SaveProfile(name string, settings domain.Settings) (domain.SettingsProfile, error) {
+	if a.Profiles == nil {
+		return domain.SettingsProfile{}, fmt.Errorf("profile store is not configured")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.SettingsProfile{}, fmt.Errorf("profile name is required")
+	}
+
+	profiles, err := a.Profiles.Load()
+	if err != nil {
+		return domain.SettingsProfile{}, fmt.Errorf("load profiles: %w", err)
+	}
+
+	settings = normalizeSettings(settings)
+	settings.ActiveProfile = ""
+	profile := domain.SettingsProfile{Name: name, Settings: settings}
+	if index := findProfile(profiles, name); index >= 0 {
+		profiles[index] = profile
+	} else {
+		profiles = append(profiles, profile)
+	}
+
+	if err := a.Profiles.Save(profiles); err != nil {
+		return domain.SettingsProfile{}, fmt.Errorf("save profiles: %w", err)
+	}
+	return profile, nil
+}
+
+// DeleteProfile removes the named profile; the current settings are kept.
+func (a *App) DeleteProfile(name string) error {
+	if a.Profiles == nil {
+		return fmt.Errorf("profile store is not configured")
+	}
+
+	profiles, err := a.Profiles.Load()
+	if err != nil {
+		return fmt.Errorf("load profiles: %w", err)
+	}
+	index := findProfile(profiles, name)
+	if index < 0 {
+		return fmt.Errorf("unknown profile: %s", strings.TrimSpace(name))
+	}
+
+	profiles = append(profiles[:index], profiles[index+1:]...)
+	if err := a.Profiles.Save(profiles); err != nil {
+		return fmt.Errorf("save profiles: %w", err)
+	}
+	return nil
+}
+
+// SwitchProfile makes the named profile the current settings and remembers it as active.
+func (a *App) SwitchProfile(name string) (domain.Settings, error) {
+	profile, err := a.loadProfile(name)
+	if err != nil {
+		return domain.Settings{}, err
+	}
+
+	settings := profile.Settings
+	settings.ActiveProfile = profile.Name
+	return a.SaveSettings(settings)
+}
+
+// StartTranscriptionWithProfile runs one job with a profile's settings without switching to it.
+func (a *App) StartTranscriptionWithProfile(inputPath, profileName string) (domain.Job, error) {
+	profile, err := a.loadProfile(profileName)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return a.startTranscription(inputPath, normalizeSettings(profile.Settings), jobOptions{})
+}
+
+// loadProfile returns the named profile or an error naming the missing profile.
+func (a *App) loadProfile(name string) (domain.SettingsProfile, error) {
+	if a.Profiles == nil {
+		return domain.SettingsProfile{}, fmt.Errorf("profile store is not configured")
+	}
+
+	profiles, err := a.Profiles.Load()
+	if err != nil {
+		return domain.SettingsProfile{}, fmt.Errorf("load profiles: %w", err)
+	}
+	index := findProfile(profiles, name)
+	if index < 0 {
+		return domain.SettingsProfile{}, fmt.Errorf("unknown profile: %s", strings.TrimSpace(name))
+	}
+	return profiles[index], nil
+}
+
+// findProfile returns the index of a profile by case-insensitive name, or -1.
+func findProfile(profiles []domain.SettingsProfile, name string) int {
+	name = strings.TrimSpace(name)
+	for i, profile := range profiles {
+		if strings.EqualFold(profile.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
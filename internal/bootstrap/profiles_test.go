@@ -0,0 +1,103 @@
+package bootstrap
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// recordingStore keeps the last saved settings for profile tests.
+type recordingStore struct {
+	settings domain.Settings
+}
+
+// Load returns the last saved settings.
+func (s *recordingStore) Load() (domain.Settings, error) {
+	return s.settings, nil
+}
+
+// Save records settings.
+func (s *recordingStore) Save(settings domain.Settings) error {
+	s.settings = settings
+	return nil
+}
+
+// TestProfilesSaveListSwitchAndDelete checks the profile lifecycle.
+func TestProfilesSaveListSwitchAndDelete(t *testing.T) {
+	store := &recordingStore{settings: domain.Settings{Language: "auto"}}
+	app := &App{
+		Store:    store,
+		Profiles: config.NewJSONProfileStore(filepath.Join(t.TempDir(), "profiles.json")),
+	}
+
+	if _, err := app.SaveProfile("  Quick notes ", domain.Settings{ModelPath: "/m/tiny.bin", Language: " en "}); err != nil {
+		t.Fatalf("save quick notes: %v", err)
+	}
+	if _, err := app.SaveProfile("Podcasts", domain.Settings{ModelPath: "/m/large-v3.bin"}); err != nil {
+		t.Fatalf("save podcasts: %v", err)
+	}
+	if _, err := app.SaveProfile("quick NOTES", domain.Settings{ModelPath: "/m/base.bin"}); err != nil {
+		t.Fatalf("replace quick notes: %v", err)
+	}
+	if _, err := app.SaveProfile(" ", domain.Settings{}); err == nil {
+		t.Fatal("expected error for empty profile name")
+	}
+
+	profiles, err := app.ListProfiles()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "Podcasts" || profiles[1].Name != "quick NOTES" {
+		t.Fatalf("profiles = %+v", profiles)
+	}
+	if profiles[0].Settings.Language != "auto" {
+		t.Fatalf("profile settings should be normalized: %+v", profiles[0].Settings)
+	}
+
+	switched, err := app.SwitchProfile("podcasts")
+	if err != nil {
+		t.Fatalf("switch: %v", err)
+	}
+	if switched.ModelPath != "/m/large-v3.bin" || store.settings.ActiveProfile != "Podcasts" {
+		t.Fatalf("switched settings = %+v, stored = %+v", switched, store.settings)
+	}
+
+	if err := app.DeleteProfile("Podcasts"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := app.SwitchProfile("Podcasts"); err == nil {
+		t.Fatal("expected error switching to a deleted profile")
+	}
+}
+
+// TestStartTranscriptionWithProfileUsesProfileSettings checks per-job profile selection.
+func TestStartTranscriptionWithProfileUsesProfileSettings(t *testing.T) {
+	outputDir := t.TempDir()
+	requests := make(chan transcribe.Request, 1)
+	app := &App{
+		Store:    &fakeStore{settings: domain.Settings{ModelPath: "/m/base.bin", OutputDir: outputDir}},
+		Profiles: config.NewJSONProfileStore(filepath.Join(t.TempDir(), "profiles.json")),
+		Jobs:     jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			requests <- req
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+	if _, err := app.SaveProfile("Podcasts", domain.Settings{ModelPath: "/m/large-v3.bin", OutputDir: outputDir, Language: "en"}); err != nil {
+		t.Fatalf("save profile: %v", err)
+	}
+
+	if _, err := app.StartTranscriptionWithProfile("/media/episode.mp3", "Podcasts"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	req := <-requests
+	if req.ModelPath != "/m/large-v3.bin" || req.Language != "en" {
+		t.Fatalf("request = %+v, want profile settings", req)
+	}
+}
@@ -0,0 +1,86 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// recordJob persists the active job so an unclean shutdown can be detected on
+// the next start. Persistence is best effort and never fails a job.
+func (a *App) recordJob(update func(record *domain.JobRecord)) {
+	if a.JobState == nil {
+		return
+	}
+
+	a.mu.Lock()
+	update(&a.activeRecord)
+	record := a.activeRecord
+	a.mu.Unlock()
+
+	_ = a.JobState.Save([]domain.JobRecord{record})
+}
+
+// forgetJob drops the persisted record once the job reached a final state.
+func (a *App) forgetJob(jobID string) {
+	if a.JobState == nil {
+		return
+	}
+
+	a.mu.Lock()
+	if a.activeRecord.ID != jobID {
+		a.mu.Unlock()
+		return
+	}
+	a.activeRecord = domain.JobRecord{}
+	a.mu.Unlock()
+
+	_ = a.JobState.Save(nil)
+}
+
+// recoverInterruptedJobs marks jobs left running by a crash as failed, removes
+// their workspaces unless intermediates are kept, and publishes one recovery
+// event per job. It returns the number of recovered jobs.
+func (a *App) recoverInterruptedJobs(keepIntermediates bool) int {
+	if a.JobState == nil {
+		return 0
+	}
+	records, err := a.JobState.Load()
+	if err != nil {
+		a.publishEvent(jobs.Event{
+			Type:    jobs.EventTypeError,
+			Message: fmt.Sprintf("read interrupted job state: %v", err),
+		})
+		return 0
+	}
+
+	for _, record := range records {
+		message := fmt.Sprintf("Job for %s was interrupted by an unclean shutdown during %s and marked failed.", record.InputPath, record.Status)
+		if workspace := strings.TrimSpace(record.Workspace); workspace != "" {
+			if keepIntermediates {
+				message += " Intermediate files kept in " + workspace + "."
+			} else if err := os.RemoveAll(workspace); err != nil {
+				message += fmt.Sprintf(" Could not remove %s: %v.", workspace, err)
+			} else {
+				message += " Temporary files were removed."
+			}
+		}
+		a.publishEvent(jobs.Event{
+			JobID:   record.ID,
+			Type:    jobs.EventTypeRecovery,
+			Status:  domain.JobStatusFailed,
+			Message: message,
+		})
+		if a.eventLog != nil {
+			_ = a.eventLog.Close(record.ID)
+		}
+	}
+
+	if len(records) > 0 {
+		_ = a.JobState.Save(nil)
+	}
+	return len(records)
+}
@@ -0,0 +1,118 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// fakeJobStateStore keeps running job records in memory.
+type fakeJobStateStore struct {
+	mu      sync.Mutex
+	records []domain.JobRecord
+}
+
+// Load returns the stored records.
+func (s *fakeJobStateStore) Load() ([]domain.JobRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records, nil
+}
+
+// Save replaces the stored records.
+func (s *fakeJobStateStore) Save(records []domain.JobRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = records
+	return nil
+}
+
+// TestRecoverInterruptedJobsMarksFailedAndCleansWorkspace checks crash recovery.
+func TestRecoverInterruptedJobsMarksFailedAndCleansWorkspace(t *testing.T) {
+	tests := []struct {
+		name          string
+		keep          bool
+		wantWorkspace bool
+		wantMessage   string
+	}{
+		{name: "removes workspace", keep: false, wantWorkspace: false, wantMessage: "Temporary files were removed."},
+		{name: "keeps intermediates", keep: true, wantWorkspace: true, wantMessage: "Intermediate files kept in"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace := filepath.Join(t.TempDir(), "media-transcriber-1")
+			if err := os.MkdirAll(workspace, 0o755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			store := &fakeJobStateStore{records: []domain.JobRecord{{
+				ID:        "job-1",
+				InputPath: "/media/clip.mp4",
+				Status:    domain.JobStatusTranscribing,
+				Workspace: workspace,
+			}}}
+			app := &App{JobState: store, events: jobs.NewEventBus(10)}
+
+			if got := app.recoverInterruptedJobs(tt.keep); got != 1 {
+				t.Fatalf("recovered = %d, want 1", got)
+			}
+			if records, _ := store.Load(); len(records) != 0 {
+				t.Fatalf("records = %v, want cleared", records)
+			}
+			if _, err := os.Stat(workspace); (err == nil) != tt.wantWorkspace {
+				t.Fatalf("workspace exists = %v, want %v", err == nil, tt.wantWorkspace)
+			}
+
+			events := app.JobEvents(0, jobs.EventFilter{})
+			if len(events) != 1 || events[0].Type != jobs.EventTypeRecovery || events[0].JobID != "job-1" {
+				t.Fatalf("events = %+v, want one recovery event for job-1", events)
+			}
+			if events[0].Status != domain.JobStatusFailed || !strings.Contains(events[0].Message, tt.wantMessage) {
+				t.Fatalf("event = %+v, want failed status and %q", events[0], tt.wantMessage)
+			}
+		})
+	}
+}
+
+// TestStartTranscriptionRecordsAndForgetsRunningJob checks the job record lifecycle.
+func TestStartTranscriptionRecordsAndForgetsRunningJob(t *testing.T) {
+	store := &fakeJobStateStore{}
+	recorded := make(chan domain.JobRecord, 1)
+	app := &App{
+		Store:    &fakeStore{settings: domain.Settings{OutputDir: t.TempDir()}},
+		JobState: store,
+		Jobs:     jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnWorkspace("/tmp/media-transcriber-42")
+			records, _ := store.Load()
+			recorded <- records[0]
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	if _, err := app.StartTranscription("/media/clip.mp4"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	record := <-recorded
+	if record.InputPath != "/media/clip.mp4" || record.Workspace != "/tmp/media-transcriber-42" {
+		t.Fatalf("record = %+v", record)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if records, _ := store.Load(); len(records) == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("record should be cleared after the job finishes")
+}
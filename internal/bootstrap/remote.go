@@ -0,0 +1,191 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/remote"
+)
+
+// workerProbeTimeout bounds asking one worker for its queue length.
+const workerProbeTimeout = 5 * time.Second
+
+// DispatchRemote sends inputPath to the least busy configured remote worker
+// and returns the dispatch ID that tags the forwarded events. The upload,
+// the job, and the artifact download run in the background; artifacts land
+// in the local output directory and the outcome is recorded in history.
+func (a *App) DispatchRemote(inputPath string) (string, error) {
+	settings, err := a.Store.Load()
+	if err != nil {
+		return "", fmt.Errorf("load settings: %w", err)
+	}
+	settings = normalizeSettings(settings)
+	inputPath = strings.TrimSpace(inputPath)
+	if info, err := os.Stat(inputPath); err != nil {
+		return "", fmt.Errorf("cannot access input file: %w", err)
+	} else if info.IsDir() {
+		return "", fmt.Errorf("input path is a directory: %s", inputPath)
+	}
+
+	client, err := pickWorker(context.Background(), workerURLs(settings.RemoteWorkers), a.secret(config.SecretRemoteWorkerToken))
+	if err != nil {
+		return "", err
+	}
+
+	dispatchID := fmt.Sprintf("remote-%d", time.Now().UnixNano())
+	ctx, cancel := context.WithCancel(context.Background())
+	a.mu.Lock()
+	if a.remoteCancels == nil {
+		a.remoteCancels = map[string]context.CancelFunc{}
+	}
+	a.remoteCancels[dispatchID] = cancel
+	a.mu.Unlock()
+
+	a.publishEvent(jobs.Event{
+		JobID:   dispatchID,
+		Type:    jobs.EventTypeLog,
+		Message: fmt.Sprintf("Sending %s to %s", filepath.Base(inputPath), client),
+	})
+	go a.runRemoteJob(ctx, dispatchID, client, inputPath, settings)
+	return dispatchID, nil
+}
+
+// CancelRemote cancels a dispatched job on its worker.
+func (a *App) CancelRemote(dispatchID string) error {
+	a.mu.Lock()
+	cancel, ok := a.remoteCancels[dispatchID]
+	a.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no remote job %s", dispatchID)
+	}
+	cancel()
+	return nil
+}
+
+// runRemoteJob follows one dispatched job to completion.
+func (a *App) runRemoteJob(ctx context.Context, dispatchID string, client *remote.Client, inputPath string, settings domain.Settings) {
+	defer func() {
+		a.mu.Lock()
+		if cancel, ok := a.remoteCancels[dispatchID]; ok {
+			cancel()
+			delete(a.remoteCancels, dispatchID)
+		}
+		a.mu.Unlock()
+		if a.eventLog != nil {
+			_ = a.eventLog.Close(dispatchID)
+		}
+	}()
+
+	entry := domain.HistoryEntry{
+		ID:        dispatchID,
+		InputPath: inputPath,
+		Language:  settings.Language,
+		Worker:    client.String(),
+		StartedAt: time.Now().UTC(),
+	}
+	outcome, err := client.Transcribe(ctx, inputPath, settings.OutputDir, func(event jobs.Event) {
+		// Re-tag forwarded events so local subscribers follow the dispatch ID.
+		event.JobID = dispatchID
+		event.Seq = 0
+		if event.Type == jobs.EventTypeResult && len(event.Stages) > 0 {
+			entry.Stages = event.Stages
+		}
+		a.publishEvent(event)
+	})
+	entry.FinishedAt = time.Now().UTC()
+	entry.Status = outcome.Status
+	entry.Error = outcome.Error
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		entry.Status = domain.JobStatusCancelled
+		a.publishEvent(jobs.Event{JobID: dispatchID, Type: jobs.EventTypeStatus, Status: domain.JobStatusCancelled, Message: "Remote job cancelled"})
+	case err != nil:
+		entry.Status = domain.JobStatusFailed
+		entry.Error = err.Error()
+		a.publishEvent(jobs.Event{JobID: dispatchID, Type: jobs.EventTypeError, Message: fmt.Sprintf("remote job on %s: %v", client, err)})
+	case outcome.Status == domain.JobStatusDone:
+		for _, path := range outcome.Files {
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".txt":
+				if entry.TextPath == "" {
+					entry.TextPath = path
+				}
+			case ".srt", ".vtt":
+				entry.SubtitlePaths = append(entry.SubtitlePaths, path)
+			}
+		}
+		a.publishEvent(jobs.Event{
+			JobID:    dispatchID,
+			Type:     jobs.EventTypeResult,
+			Status:   domain.JobStatusDone,
+			Message:  fmt.Sprintf("Downloaded %d files from %s", len(outcome.Files), client),
+			TextPath: entry.TextPath,
+		})
+	}
+	a.recordHistory(entry)
+	switch entry.Status {
+	case domain.JobStatusDone:
+		a.deliverJob(ctx, entry, settings)
+	case domain.JobStatusFailed:
+		a.notifyJob(entry, settings, nil)
+	}
+}
+
+// SetRemoteWorkerToken stores the API token sent to remote workers.
+func (a *App) SetRemoteWorkerToken(token string) error {
+	return a.storeSecret(config.SecretRemoteWorkerToken, "remote worker token", token)
+}
+
+// HasRemoteWorkerToken reports whether a remote worker token is stored, without exposing it.
+func (a *App) HasRemoteWorkerToken() bool {
+	return a.secret(config.SecretRemoteWorkerToken) != ""
+}
+
+// workerURLs splits the RemoteWorkers setting into base URLs.
+func workerURLs(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+}
+
+// pickWorker returns the reachable worker with the fewest queued and running jobs.
+func pickWorker(ctx context.Context, urls []string, token string) (*remote.Client, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no remote workers configured")
+	}
+	var (
+		best     *remote.Client
+		bestLoad int
+		errs     []error
+	)
+	for _, raw := range urls {
+		client, err := remote.NewClient(raw, token)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		probeCtx, cancel := context.WithTimeout(ctx, workerProbeTimeout)
+		load, err := client.Load(probeCtx)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", client, err))
+			continue
+		}
+		if best == nil || load < bestLoad {
+			best, bestLoad = client, load
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no remote worker is reachable: %w", errors.Join(errs...))
+	}
+	return best, nil
+}
@@ -0,0 +1,108 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// newWorkerServer starts a serve-mode app whose pipeline writes a transcript.
+func newWorkerServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	root := t.TempDir()
+	worker := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: root}},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Queue:   jobs.NewQueue(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			base := filepath.Join(root, "talk")
+			mustWrite(t, base+".txt", "hello from the worker")
+			mustWrite(t, base+".srt", "1\n00:00:00,000 --> 00:00:01,000\nhello\n")
+			return transcribe.Result{TextPath: base + ".txt", SubtitlePaths: []string{base + ".srt"}}, nil
+		}},
+		events:    jobs.NewEventBus(100),
+		uploadDir: filepath.Join(root, "uploads"),
+	}
+	server := httptest.NewServer(worker.serverHandler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestDispatchRemoteRunsJobOnWorker uploads a file, follows the worker job,
+// and downloads its artifacts below the local output directory.
+func TestDispatchRemoteRunsJobOnWorker(t *testing.T) {
+	server := newWorkerServer(t)
+	local := t.TempDir()
+	input := filepath.Join(local, "talk.mp3")
+	mustWrite(t, input, "audio")
+	outputDir := filepath.Join(local, "out")
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: outputDir, RemoteWorkers: "http://127.0.0.1:1, " + server.URL}},
+		History: config.NewJSONHistoryStore(filepath.Join(local, "history.json")),
+		events:  jobs.NewEventBus(100),
+	}
+
+	dispatchID, err := app.DispatchRemote(input)
+	if err != nil {
+		t.Fatalf("dispatch: %v", err)
+	}
+
+	var entry domain.HistoryEntry
+	waitFor(t, func() bool {
+		entry, err = app.findHistoryEntry(dispatchID)
+		return err == nil
+	})
+	if entry.Status != domain.JobStatusDone || entry.Worker != server.URL {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if filepath.Dir(filepath.Dir(entry.TextPath)) != outputDir || filepath.Base(entry.TextPath) != "talk.txt" || len(entry.SubtitlePaths) != 1 {
+		t.Fatalf("artifacts = %q %v", entry.TextPath, entry.SubtitlePaths)
+	}
+	assertFile(t, entry.TextPath, "hello from the worker")
+
+	forwarded := app.JobEvents(0, jobs.EventFilter{JobID: dispatchID, Types: []jobs.EventType{jobs.EventTypeStatus}})
+	if len(forwarded) == 0 {
+		t.Fatal("expected worker status events forwarded under the dispatch ID")
+	}
+}
+
+// TestDispatchRemoteWithoutReachableWorker fails before starting anything.
+func TestDispatchRemoteWithoutReachableWorker(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "talk.mp3")
+	mustWrite(t, input, "audio")
+	for name, workers := range map[string]string{"none": "", "unreachable": "http://127.0.0.1:1", "invalid": "ftp://box"} {
+		app := &App{Store: &fakeStore{settings: domain.Settings{RemoteWorkers: workers}}, events: jobs.NewEventBus(10)}
+		if _, err := app.DispatchRemote(input); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+// TestUploadIsRemovedAfterJob deletes the per-upload directory only.
+func TestUploadIsRemovedAfterJob(t *testing.T) {
+	root := t.TempDir()
+	app := &App{uploadDir: root}
+	upload := filepath.Join(root, "upload-1", "a.mp3")
+	other := filepath.Join(t.TempDir(), "b.mp3")
+	mustWrite(t, upload, "a")
+	mustWrite(t, other, "b")
+
+	app.removeUpload(other)
+	app.removeUpload(upload)
+	if _, err := os.Stat(filepath.Dir(upload)); !os.IsNotExist(err) {
+		t.Fatalf("upload dir still exists: %v", err)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Fatalf("non-upload input removed: %v", err)
+	}
+}
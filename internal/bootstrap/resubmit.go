@@ -0,0 +1,128 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// ResubmitJob queues the input of a past job again, with that job's model and
+// language on top of the current settings and overrides on top of both. The
+// new job's history entry links back to historyID for comparison, and its
+// outputs get a -resubmit-N suffix so the original's files stay as they were.
+func (a *App) ResubmitJob(historyID string, overrides domain.JobOverrides) (domain.QueuedJob, error) {
+	if a.Queue == nil {
+		return domain.QueuedJob{}, fmt.Errorf("job queue is not available")
+	}
+	original, err := a.findHistoryEntry(historyID)
+	if err != nil {
+		return domain.QueuedJob{}, err
+	}
+	if _, err := os.Stat(original.InputPath); err != nil {
+		return domain.QueuedJob{}, fmt.Errorf("cannot access input file: %w", err)
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.QueuedJob{}, fmt.Errorf("load settings: %w", err)
+	}
+	settings, err = resubmitSettings(settings, original, overrides)
+	if err != nil {
+		return domain.QueuedJob{}, err
+	}
+	settings.OutputNameTemplate = a.resubmitName(original, settings)
+
+	now := time.Now().UTC()
+	queued := domain.QueuedJob{
+		ID:         fmt.Sprintf("queued-%d-0", now.UnixNano()),
+		InputPath:  original.InputPath,
+		EnqueuedAt: now,
+		ResubmitOf: original.ID,
+		Settings:   &settings,
+	}
+	a.Queue.Push(queued)
+	a.startNextQueued()
+	return queued, nil
+}
+
+// resubmitSettings applies the original job's model and language, then the
+// overrides, to settings.
+func resubmitSettings(settings domain.Settings, original domain.HistoryEntry, overrides domain.JobOverrides) (domain.Settings, error) {
+	if original.ModelPath != "" {
+		settings.ModelPath = original.ModelPath
+	}
+	if original.Language != "" {
+		settings.Language = original.Language
+	}
+	if overrides.ModelPath != "" {
+		settings.ModelPath = overrides.ModelPath
+	}
+	if overrides.Language != "" {
+		settings.Language = overrides.Language
+	}
+	if overrides.SplitChapters != nil {
+		settings.SplitChapters = *overrides.SplitChapters
+	}
+	if len(overrides.Formats) > 0 {
+		settings.ExportSubtitles, settings.ExportLRC = false, false
+		for _, format := range overrides.Formats {
+			switch strings.ToLower(strings.TrimSpace(format)) {
+			case "txt":
+			case "srt", "vtt":
+				settings.ExportSubtitles = true
+			case "lrc":
+				settings.ExportLRC = true
+			default:
+				return domain.Settings{}, fmt.Errorf("unknown output format %q", format)
+			}
+		}
+	}
+	return normalizeSettings(settings), nil
+}
+
+// resubmitName returns the output name of the next re-run of original: the
+// original's output name with a -resubmit-N suffix, N counting earlier re-runs
+// in history and the queue and skipping names already taken in the output
+// directory.
+func (a *App) resubmitName(original domain.HistoryEntry, settings domain.Settings) string {
+	base := settings.OutputNameTemplate
+	if original.TextPath != "" {
+		name := filepath.Base(original.TextPath)
+		base = strings.TrimSuffix(name, filepath.Ext(name))
+	} else if base == "" {
+		base = "{name}"
+	}
+
+	n := 1
+	for _, queued := range a.Queue.List() {
+		if queued.ResubmitOf == original.ID {
+			n++
+		}
+	}
+	if a.History != nil {
+		entries, _ := a.History.Load()
+		for _, entry := range entries {
+			if entry.ResubmitOf == original.ID {
+				n++
+			}
+		}
+	}
+	if namePlaceholder.MatchString(base) {
+		// The file name is only known once the template is expanded.
+		return fmt.Sprintf("%s-resubmit-%d", base, n)
+	}
+	dir := settings.OutputDir
+	if dir == "" {
+		dir = filepath.Dir(original.InputPath)
+	}
+	for {
+		name := fmt.Sprintf("%s-resubmit-%d", base, n)
+		if _, err := os.Stat(filepath.Join(dir, name+".txt")); err != nil {
+			return name
+		}
+		n++
+	}
+}
@@ -0,0 +1,185 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestResubmitJobRunsWithOverrides re-runs a past job with a different model,
+// keeping its language, and links the new history entry to the original.
+func TestResubmitJobRunsWithOverrides(t *testing.T) {
+	root := t.TempDir()
+	input := filepath.Join(root, "interview.mp3")
+	mustWrite(t, input, "audio")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{{
+		ID: "job-1", InputPath: input, Status: domain.JobStatusDone,
+		ModelPath: "/m/base.bin", Language: "de", FinishedAt: time.Now().Add(-time.Hour),
+	}}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	requests := make(chan transcribe.Request, 1)
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{ModelPath: "/m/tiny.bin", Language: "auto", OutputDir: root}},
+		History: history,
+		Jobs:    jobs.NewManager(),
+		Queue:   jobs.NewQueue(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			requests <- req
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	queued, err := app.ResubmitJob("job-1", domain.JobOverrides{ModelPath: "/m/large-v3.bin"})
+	if err != nil {
+		t.Fatalf("resubmit: %v", err)
+	}
+	if queued.ResubmitOf != "job-1" || queued.InputPath != input {
+		t.Fatalf("queued = %+v", queued)
+	}
+	if data, _ := json.Marshal(queued); strings.Contains(string(data), "large-v3") {
+		t.Fatalf("queued job serializes its settings: %s", data)
+	}
+
+	req := <-requests
+	if req.ModelPath != "/m/large-v3.bin" || req.Language != "de" {
+		t.Fatalf("request model %q language %q, want /m/large-v3.bin de", req.ModelPath, req.Language)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+
+	var resubmitted []domain.HistoryEntry
+	waitFor(t, func() bool {
+		entries, _ := history.Load()
+		resubmitted = resubmitted[:0]
+		for _, entry := range entries {
+			if entry.ResubmitOf == "job-1" {
+				resubmitted = append(resubmitted, entry)
+			}
+		}
+		return len(resubmitted) == 1
+	})
+	if resubmitted[0].ModelPath != "/m/large-v3.bin" || resubmitted[0].Status != domain.JobStatusDone {
+		t.Fatalf("resubmitted entry = %+v", resubmitted[0])
+	}
+}
+
+// TestResubmitJobKeepsOriginalOutputs writes the re-run next to the original
+// under a numbered name, leaving the original's transcript untouched.
+func TestResubmitJobKeepsOriginalOutputs(t *testing.T) {
+	root := t.TempDir()
+	input := filepath.Join(root, "interview.mp3")
+	original := filepath.Join(root, "interview.txt")
+	mustWrite(t, input, "audio")
+	mustWrite(t, original, "original transcript")
+	mustWrite(t, filepath.Join(root, "interview-resubmit-1.txt"), "earlier re-run")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{{ID: "job-1", InputPath: input, TextPath: original, Status: domain.JobStatusDone}}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: root}},
+		History: history,
+		Jobs:    jobs.NewManager(),
+		Queue:   jobs.NewQueue(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			path := filepath.Join(req.OutputDir, req.OutputName+".txt")
+			mustWrite(t, path, "new transcript")
+			return transcribe.Result{TextPath: path}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	if _, err := app.ResubmitJob("job-1", domain.JobOverrides{}); err != nil {
+		t.Fatalf("resubmit: %v", err)
+	}
+	waitForStatus(t, app, domain.JobStatusDone)
+
+	var rerun domain.HistoryEntry
+	waitFor(t, func() bool {
+		entries, _ := history.Load()
+		for _, entry := range entries {
+			if entry.ResubmitOf == "job-1" {
+				rerun = entry
+				return true
+			}
+		}
+		return false
+	})
+	if want := filepath.Join(root, "interview-resubmit-2.txt"); rerun.TextPath != want {
+		t.Fatalf("re-run transcript = %q, want %q", rerun.TextPath, want)
+	}
+	assertFile(t, rerun.TextPath, "new transcript")
+	assertFile(t, original, "original transcript")
+	assertFile(t, filepath.Join(root, "interview-resubmit-1.txt"), "earlier re-run")
+	if entry, err := app.findHistoryEntry("job-1"); err != nil || entry.TextPath != original {
+		t.Fatalf("original entry = %+v, %v", entry, err)
+	}
+}
+
+// TestResubmitJobRejectsUnknownAndMissingInputs fails for unknown history
+// entries and inputs that no longer exist.
+func TestResubmitJobRejectsUnknownAndMissingInputs(t *testing.T) {
+	root := t.TempDir()
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{{ID: "job-1", InputPath: filepath.Join(root, "gone.mp3")}}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	app := &App{Store: &fakeStore{}, History: history, Jobs: jobs.NewManager()}
+	if _, err := app.ResubmitJob("job-1", domain.JobOverrides{}); err == nil {
+		t.Fatal("resubmit without a queue should fail")
+	}
+	app.Queue = jobs.NewQueue()
+
+	for _, id := range []string{"job-2", "job-1"} {
+		if _, err := app.ResubmitJob(id, domain.JobOverrides{}); err == nil {
+			t.Fatalf("resubmit %s should fail", id)
+		}
+	}
+	if len(app.Queue.List()) != 0 {
+		t.Fatalf("queue = %+v, want empty", app.Queue.List())
+	}
+}
+
+// TestResubmitSettingsAppliesFormats turns exactly the listed outputs on.
+func TestResubmitSettingsAppliesFormats(t *testing.T) {
+	current := domain.Settings{ExportSubtitles: true}
+	tests := []struct {
+		name          string
+		formats       []string
+		wantSubtitles bool
+		wantLRC       bool
+		wantErr       bool
+	}{
+		{name: "unset keeps settings", wantSubtitles: true},
+		{name: "lyrics only", formats: []string{"txt", "LRC"}, wantLRC: true},
+		{name: "subtitles", formats: []string{"vtt"}, wantSubtitles: true},
+		{name: "unknown", formats: []string{"docx"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resubmitSettings(current, domain.HistoryEntry{}, domain.JobOverrides{Formats: tt.formats})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.ExportSubtitles != tt.wantSubtitles || got.ExportLRC != tt.wantLRC) {
+				t.Fatalf("subtitles %v lrc %v, want %v %v", got.ExportSubtitles, got.ExportLRC, tt.wantSubtitles, tt.wantLRC)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package bootstrap
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// retentionInterval is how often the retention policy is applied in the background.
+const retentionInterval = time.Hour
+
+// retentionLoop applies the retention policy now and then every retentionInterval
+// for the lifetime of the process.
+func (a *App) retentionLoop() {
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+	for {
+		a.guard("retention", func() {
+			if _, err := a.ApplyRetention(); err != nil {
+				a.publishEvent(jobs.Event{Type: jobs.EventTypeError, Message: err.Error()})
+			}
+		})
+		<-ticker.C
+	}
+}
+
+// ApplyRetention runs the retention policy from settings once: entries older
+// than RetentionDays are removed first, then the oldest cached audio and, with
+// RetentionTranscripts, the oldest transcripts until they fit in RetentionGB.
+func (a *App) ApplyRetention() (domain.CleanupReport, error) {
+	if a.Store == nil {
+		return domain.CleanupReport{}, nil
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.CleanupReport{}, fmt.Errorf("load settings: %w", err)
+	}
+	settings = normalizeSettings(settings)
+
+	var report domain.CleanupReport
+	var outputDirs []string
+	if settings.RetentionTranscripts {
+		if outputDirs, err = a.retentionOutputDirs(settings); err != nil {
+			return report, fmt.Errorf("apply retention: %w", err)
+		}
+	}
+	if settings.RetentionDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -settings.RetentionDays)
+		if err := a.pruneByAge(cutoff, outputDirs, &report); err != nil {
+			return report, fmt.Errorf("apply retention: %w", err)
+		}
+	}
+	if settings.RetentionGB > 0 {
+		if err := a.pruneBySize(int64(settings.RetentionGB)<<30, outputDirs, &report); err != nil {
+			return report, fmt.Errorf("apply retention: %w", err)
+		}
+	}
+
+	if report != (domain.CleanupReport{}) {
+		a.publishEvent(jobs.Event{
+			Type: jobs.EventTypeLog,
+			Message: fmt.Sprintf("Retention cleanup removed %d history entries, %d cached audio files, and %d transcripts, freeing %s",
+				report.HistoryRemoved, report.CacheRemoved, report.TranscriptsRemoved, formatBytes(uint64(report.FreedBytes))),
+		})
+	}
+	return report, nil
+}
+
+// GetStorageUsage reports the space taken by history, cached audio,
+// transcripts of jobs in history, and pending uploads.
+func (a *App) GetStorageUsage() (domain.StorageUsage, error) {
+	var usage domain.StorageUsage
+	cached, err := a.cacheEntries()
+	if err != nil {
+		return usage, fmt.Errorf("read preprocess cache: %w", err)
+	}
+	usage.CacheEntries = len(cached)
+	for _, entry := range cached {
+		usage.CacheBytes += entry.Bytes
+	}
+
+	if a.History != nil {
+		entries, err := a.History.Load()
+		if err != nil {
+			return usage, fmt.Errorf("load history: %w", err)
+		}
+		usage.HistoryEntries = len(entries)
+		for _, entry := range entries {
+			files, bytes := artifactUsage(entry)
+			usage.TranscriptFiles += files
+			usage.TranscriptBytes += bytes
+		}
+	}
+
+	if a.uploadDir != "" {
+		_ = filepath.WalkDir(a.uploadDir, func(_ string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return nil
+			}
+			if info, err := entry.Info(); err == nil {
+				usage.UploadBytes += info.Size()
+			}
+			return nil
+		})
+	}
+
+	usage.TotalBytes = usage.CacheBytes + usage.TranscriptBytes + usage.UploadBytes
+	return usage, nil
+}
+
+// pruneByAge removes cached audio last used before cutoff and history entries
+// finished before it, deleting their transcripts below outputDirs.
+func (a *App) pruneByAge(cutoff time.Time, outputDirs []string, report *domain.CleanupReport) error {
+	cached, err := a.cacheEntries()
+	if err != nil {
+		return err
+	}
+	for _, entry := range cached {
+		if !entry.LastUsed.Before(cutoff) {
+			break
+		}
+		if err := a.removeCacheEntry(entry, report); err != nil {
+			return err
+		}
+	}
+	return a.pruneHistory(outputDirs, report, func(entry domain.HistoryEntry) bool {
+		return entry.FinishedAt.Before(cutoff)
+	})
+}
+
+// pruneBySize removes the least recently used cached audio, then the oldest
+// jobs with their transcripts below outputDirs, until the total fits in
+// limit. Only files retention may delete count; without outputDirs that is
+// just the cache.
+func (a *App) pruneBySize(limit int64, outputDirs []string, report *domain.CleanupReport) error {
+	cached, err := a.cacheEntries()
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, entry := range cached {
+		total += entry.Bytes
+	}
+	if len(outputDirs) > 0 && a.History != nil {
+		entries, err := a.History.Load()
+		if err != nil {
+			return err
+		}
+		// A file several entries share counts once.
+		counted := map[string]bool{}
+		for _, entry := range entries {
+			for _, path := range prunableArtifacts(entry, outputDirs) {
+				if info, err := os.Stat(path); err == nil && !counted[path] {
+					counted[path] = true
+					total += info.Size()
+				}
+			}
+		}
+	}
+
+	for _, entry := range cached {
+		if total <= limit {
+			return nil
+		}
+		if err := a.removeCacheEntry(entry, report); err != nil {
+			return err
+		}
+		total -= entry.Bytes
+	}
+	if len(outputDirs) == 0 || total <= limit {
+		return nil
+	}
+	// Files a newer entry still refers to stay, so only what pruneHistory
+	// actually freed counts against the total.
+	freed := report.FreedBytes
+	return a.pruneHistory(outputDirs, report, func(domain.HistoryEntry) bool {
+		total -= report.FreedBytes - freed
+		freed = report.FreedBytes
+		return total > limit
+	})
+}
+
+// pruneHistory drops the history entries remove selects, offered oldest
+// first, with their event logs, and deletes their prunableArtifacts below
+// outputDirs, except files an entry that is not removed also refers to.
+// Each removed entry's files are gone before remove sees the next entry.
+func (a *App) pruneHistory(outputDirs []string, report *domain.CleanupReport, remove func(domain.HistoryEntry) bool) error {
+	if a.History == nil {
+		return nil
+	}
+	a.historyMu.Lock()
+	defer a.historyMu.Unlock()
+
+	entries, err := a.History.Load()
+	if err != nil {
+		return err
+	}
+	oldest := make([]domain.HistoryEntry, len(entries))
+	copy(oldest, entries)
+	sort.SliceStable(oldest, func(i, j int) bool { return oldest[i].FinishedAt.Before(oldest[j].FinishedAt) })
+	// A newer job on the same input writes the same files; they stay as long
+	// as an entry that is not removed points to them.
+	refs := map[string]int{}
+	for _, entry := range entries {
+		for _, path := range jobArtifacts(entry) {
+			refs[filepath.Clean(path)]++
+		}
+	}
+	removed := map[string]bool{}
+	for _, entry := range oldest {
+		if !remove(entry) {
+			continue
+		}
+		removed[entry.ID] = true
+		report.HistoryRemoved++
+		for _, path := range jobArtifacts(entry) {
+			refs[filepath.Clean(path)]--
+		}
+		for _, path := range prunableArtifacts(entry, outputDirs) {
+			if refs[path] > 0 {
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			report.TranscriptsRemoved++
+			report.FreedBytes += info.Size()
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	kept := make([]domain.HistoryEntry, 0, len(entries)-len(removed))
+	for _, entry := range entries {
+		if !removed[entry.ID] {
+			kept = append(kept, entry)
+		}
+	}
+	if err := a.History.Save(kept); err != nil {
+		return err
+	}
+	if a.eventLog != nil {
+		for id := range removed {
+			if err := a.eventLog.Remove(id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// retentionOutputDirs lists the directories jobs export to: the output
+// directory of settings and those of the source presets.
+func (a *App) retentionOutputDirs(settings domain.Settings) ([]string, error) {
+	var dirs []string
+	if settings.OutputDir != "" {
+		dirs = append(dirs, settings.OutputDir)
+	}
+	if a.Sources != nil {
+		presets, err := a.Sources.Load()
+		if err != nil {
+			return nil, fmt.Errorf("load source presets: %w", err)
+		}
+		for _, preset := range presets {
+			if preset.OutputDir != "" {
+				dirs = append(dirs, preset.OutputDir)
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// prunableArtifacts lists the outputs of entry that retention may delete:
+// the job's own files whose real path lies below one of outputDirs. Plugin
+// artifacts, which plugins may write anywhere, and files outside the output
+// directories, such as an input the entry points to, are never deleted.
+func prunableArtifacts(entry domain.HistoryEntry, outputDirs []string) []string {
+	plugins := map[string]bool{}
+	for _, path := range entry.PluginArtifacts {
+		plugins[filepath.Clean(path)] = true
+	}
+	var paths []string
+	for _, path := range jobArtifacts(entry) {
+		path = filepath.Clean(path)
+		if !plugins[path] && inOutputDir(path, outputDirs) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// inOutputDir reports whether path, with symlinks resolved, lies below one
+// of dirs. A path that does not resolve is not in any.
+func inOutputDir(path string, dirs []string) bool {
+	resolved, err := realPath(path)
+	if err != nil {
+		return false
+	}
+	for _, dir := range dirs {
+		if resolvedDir, err := realPath(dir); err == nil && resolved != resolvedDir && isWithinBaseDir(resolvedDir, resolved) {
+			return true
+		}
+	}
+	return false
+}
+
+// realPath returns the absolute path of path with symlinks resolved.
+func realPath(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(resolved)
+}
+
+// cacheEntries lists the preprocessed audio cache, least recently used first,
+// whether or not caching is currently enabled.
+func (a *App) cacheEntries() ([]transcribe.CacheEntry, error) {
+	if a.cacheDir == "" {
+		return nil, nil
+	}
+	return transcribe.NewPreprocessCache(a.cacheDir, 0).Entries()
+}
+
+// removeCacheEntry deletes one cached WAV and counts it in report.
+func (a *App) removeCacheEntry(entry transcribe.CacheEntry, report *domain.CleanupReport) error {
+	if err := transcribe.NewPreprocessCache(a.cacheDir, 0).Remove(entry.Key); err != nil {
+		return err
+	}
+	report.CacheRemoved++
+	report.FreedBytes += entry.Bytes
+	return nil
+}
+
+// artifactUsage counts the output files of a job that still exist and their size.
+func artifactUsage(entry domain.HistoryEntry) (int, int64) {
+	var files int
+	var bytes int64
+	for _, path := range jobArtifacts(entry) {
+		if info, err := os.Stat(path); err == nil {
+			files++
+			bytes += info.Size()
+		}
+	}
+	return files, bytes
+}
@@ -0,0 +1,246 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// newRetentionApp builds an App with two finished jobs, ten and one day old,
+// each with a 100-byte transcript in the output directory root/out and an
+// event log, and two cached WAVs last used at the same ages.
+func newRetentionApp(t *testing.T, settings domain.Settings) (*App, string) {
+	t.Helper()
+	root := t.TempDir()
+	settings.OutputDir = filepath.Join(root, "out")
+	app := &App{
+		Store:    &fakeStore{settings: settings},
+		History:  config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		cacheDir: filepath.Join(root, "cache"),
+		events:   jobs.NewEventBus(10),
+		eventLog: jobs.NewEventLog(filepath.Join(root, "history")),
+	}
+	now := time.Now()
+	var entries []domain.HistoryEntry
+	for _, job := range []struct {
+		id  string
+		age time.Duration
+	}{{"old", 10 * 24 * time.Hour}, {"new", 24 * time.Hour}} {
+		textPath := filepath.Join(root, "out", job.id+".txt")
+		mustWrite(t, textPath, strings.Repeat("x", 100))
+		entries = append(entries, domain.HistoryEntry{ID: job.id, Status: domain.JobStatusDone, TextPath: textPath, FinishedAt: now.Add(-job.age)})
+		if err := app.eventLog.Append(jobs.Event{JobID: job.id, Type: jobs.EventTypeStatus}); err != nil {
+			t.Fatalf("append event: %v", err)
+		}
+		_ = app.eventLog.Close(job.id)
+
+		wavPath := filepath.Join(app.cacheDir, job.id+".wav")
+		mustWrite(t, wavPath, strings.Repeat("w", 200))
+		mustWrite(t, filepath.Join(app.cacheDir, job.id+".json"), "{}")
+		if err := os.Chtimes(wavPath, now.Add(-job.age), now.Add(-job.age)); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+	if err := app.History.Save(entries); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+	return app, root
+}
+
+// TestApplyRetentionByAge removes entries older than the retention window.
+func TestApplyRetentionByAge(t *testing.T) {
+	tests := []struct {
+		name        string
+		transcripts bool
+		want        domain.CleanupReport
+	}{
+		{name: "history and cache", want: domain.CleanupReport{HistoryRemoved: 1, CacheRemoved: 1, FreedBytes: 200}},
+		{name: "with transcripts", transcripts: true, want: domain.CleanupReport{HistoryRemoved: 1, CacheRemoved: 1, TranscriptsRemoved: 1, FreedBytes: 300}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, root := newRetentionApp(t, domain.Settings{RetentionDays: 7, RetentionTranscripts: tt.transcripts})
+			report, err := app.ApplyRetention()
+			if err != nil {
+				t.Fatalf("apply retention: %v", err)
+			}
+			if report != tt.want {
+				t.Fatalf("report = %+v, want %+v", report, tt.want)
+			}
+			entries, _ := app.History.Load()
+			if len(entries) != 1 || entries[0].ID != "new" {
+				t.Fatalf("history = %+v", entries)
+			}
+			if _, err := os.Stat(filepath.Join(app.cacheDir, "old.wav")); !os.IsNotExist(err) {
+				t.Fatalf("old cache entry should be removed, stat err = %v", err)
+			}
+			if _, err := app.eventLog.Read("old"); !os.IsNotExist(err) {
+				t.Fatalf("event log of the removed job should be deleted, read err = %v", err)
+			}
+			if _, err := app.eventLog.Read("new"); err != nil {
+				t.Fatalf("event log of the kept job: %v", err)
+			}
+			_, err = os.Stat(filepath.Join(root, "out", "old.txt"))
+			if removed := os.IsNotExist(err); removed != tt.transcripts {
+				t.Fatalf("old transcript removed = %v, want %v", removed, tt.transcripts)
+			}
+		})
+	}
+}
+
+// TestApplyRetentionKeepsFilesOfNewerJobs checks an expired entry's files
+// survive when a kept re-run of the same input still points to them.
+func TestApplyRetentionKeepsFilesOfNewerJobs(t *testing.T) {
+	app, root := newRetentionApp(t, domain.Settings{RetentionDays: 7, RetentionTranscripts: true})
+	shared := filepath.Join(root, "out", "talk.txt")
+	subtitle := filepath.Join(root, "out", "talk.srt")
+	mustWrite(t, shared, "transcript")
+	mustWrite(t, subtitle, "subtitles")
+	entries, _ := app.History.Load()
+	for i := range entries {
+		entries[i].TextPath = shared
+	}
+	entries[0].SubtitlePaths = []string{subtitle}
+	if err := app.History.Save(entries); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	report, err := app.ApplyRetention()
+	if err != nil {
+		t.Fatalf("apply retention: %v", err)
+	}
+	if report.HistoryRemoved != 1 || report.TranscriptsRemoved != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if _, err := os.Stat(shared); err != nil {
+		t.Fatalf("transcript of the kept job was removed: %v", err)
+	}
+	if _, err := os.Stat(subtitle); !os.IsNotExist(err) {
+		t.Fatalf("subtitles only the expired job had should be removed, stat err = %v", err)
+	}
+}
+
+// TestPruneBySizeEvictsCacheBeforeTranscripts frees space from the cache first
+// and only then from the oldest transcripts.
+func TestPruneBySizeEvictsCacheBeforeTranscripts(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       int64
+		transcripts bool
+		want        domain.CleanupReport
+	}{
+		{name: "cache only", limit: 250, want: domain.CleanupReport{CacheRemoved: 1, FreedBytes: 200}},
+		{name: "cache fits", limit: 400, want: domain.CleanupReport{}},
+		{name: "oldest transcript", limit: 150, transcripts: true, want: domain.CleanupReport{CacheRemoved: 2, HistoryRemoved: 1, TranscriptsRemoved: 1, FreedBytes: 500}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, root := newRetentionApp(t, domain.Settings{})
+			var outputDirs []string
+			if tt.transcripts {
+				outputDirs = []string{filepath.Join(root, "out")}
+			}
+			var report domain.CleanupReport
+			if err := app.pruneBySize(tt.limit, outputDirs, &report); err != nil {
+				t.Fatalf("prune: %v", err)
+			}
+			if report != tt.want {
+				t.Fatalf("report = %+v, want %+v", report, tt.want)
+			}
+		})
+	}
+}
+
+// TestApplyRetentionDeletesOnlyOutputs keeps the files of an expired entry
+// that are plugin artifacts or lie outside the output directory.
+func TestApplyRetentionDeletesOnlyOutputs(t *testing.T) {
+	app, root := newRetentionApp(t, domain.Settings{RetentionDays: 7, RetentionTranscripts: true})
+	plugin := filepath.Join(root, "out", "old.summary.md")
+	outside := filepath.Join(root, "elsewhere", "old.srt")
+	escape := filepath.Join(root, "out", "..", "elsewhere", "old.vtt")
+	for _, path := range []string{plugin, outside, escape} {
+		mustWrite(t, path, "keep")
+	}
+	entries, _ := app.History.Load()
+	entries[0].PluginArtifacts = []string{plugin}
+	entries[0].SubtitlePaths = []string{outside, escape}
+	if err := app.History.Save(entries); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	report, err := app.ApplyRetention()
+	if err != nil {
+		t.Fatalf("apply retention: %v", err)
+	}
+	if report.HistoryRemoved != 1 || report.TranscriptsRemoved != 1 {
+		t.Fatalf("report = %+v, want only the transcript removed", report)
+	}
+	for _, path := range []string{plugin, outside, escape} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("%s should be kept: %v", path, err)
+		}
+	}
+}
+
+// TestPruneBySizeCountsOnlyFreedBytes counts a transcript two entries share
+// once, and removing the older entry frees only its own subtitles.
+func TestPruneBySizeCountsOnlyFreedBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int64
+		want  domain.CleanupReport
+	}{
+		{name: "shared transcript counted once", limit: 330, want: domain.CleanupReport{CacheRemoved: 2, FreedBytes: 400}},
+		{name: "older entry frees its subtitles", limit: 315, want: domain.CleanupReport{CacheRemoved: 2, HistoryRemoved: 1, TranscriptsRemoved: 1, FreedBytes: 410}},
+		{name: "both entries", limit: 100, want: domain.CleanupReport{CacheRemoved: 2, HistoryRemoved: 2, TranscriptsRemoved: 3, FreedBytes: 720}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, root := newRetentionApp(t, domain.Settings{})
+			out := filepath.Join(root, "out")
+			shared := filepath.Join(out, "talk.txt")
+			mustWrite(t, shared, strings.Repeat("s", 300))
+			entries, _ := app.History.Load()
+			for i := range entries {
+				os.Remove(entries[i].TextPath)
+				entries[i].TextPath = shared
+				subtitle := filepath.Join(out, entries[i].ID+".srt")
+				mustWrite(t, subtitle, strings.Repeat("o", 10))
+				entries[i].SubtitlePaths = []string{subtitle}
+			}
+			if err := app.History.Save(entries); err != nil {
+				t.Fatalf("save history: %v", err)
+			}
+
+			var report domain.CleanupReport
+			if err := app.pruneBySize(tt.limit, []string{out}, &report); err != nil {
+				t.Fatalf("prune: %v", err)
+			}
+			if report != tt.want {
+				t.Fatalf("report = %+v, want %+v", report, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetStorageUsage sums cache, transcripts, and pending uploads.
+func TestGetStorageUsage(t *testing.T) {
+	app, root := newRetentionApp(t, domain.Settings{})
+	app.uploadDir = filepath.Join(root, "uploads")
+	mustWrite(t, filepath.Join(app.uploadDir, "upload-1", "talk.mp3"), strings.Repeat("u", 50))
+
+	usage, err := app.GetStorageUsage()
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	want := domain.StorageUsage{HistoryEntries: 2, CacheEntries: 2, CacheBytes: 400, TranscriptFiles: 2, TranscriptBytes: 200, UploadBytes: 50, TotalBytes: 650}
+	if usage != want {
+		t.Fatalf("usage = %+v, want %+v", usage, want)
+	}
+}
@@ -0,0 +1,41 @@
+package bootstrap
+
+import (
+	"path"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/storage"
+)
+
+// s3Target returns the S3 archive when a bucket is configured; objects go to
+// <prefix>/<job ID>/ so runs of the same file do not overwrite each other.
+func (a *App) s3Target(entry domain.HistoryEntry, settings domain.Settings) (archiveTarget, bool) {
+	if settings.S3Bucket == "" {
+		return archiveTarget{}, false
+	}
+	return archiveTarget{
+		name: "s3",
+		newUploader: func() (storage.Uploader, error) {
+			return storage.NewS3Uploader(storage.S3Config{
+				Endpoint:        settings.S3Endpoint,
+				Region:          settings.S3Region,
+				Bucket:          settings.S3Bucket,
+				AccessKeyID:     settings.S3AccessKeyID,
+				SecretAccessKey: a.secret(config.SecretS3SecretKey),
+			})
+		},
+		dir:    path.Join(settings.S3Prefix, entry.ID),
+		source: settings.S3UploadSource,
+	}, true
+}
+
+// SetS3SecretKey stores the secret access key of the S3 archive.
+func (a *App) SetS3SecretKey(key string) error {
+	return a.storeSecret(config.SecretS3SecretKey, "S3 secret key", key)
+}
+
+// HasS3SecretKey reports whether an S3 secret key is stored, without exposing it.
+func (a *App) HasS3SecretKey() bool {
+	return a.secret(config.SecretS3SecretKey) != ""
+}
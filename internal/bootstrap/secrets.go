@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// storeSecret saves value, trimmed, under the secret name; label names the
+// secret in errors, e.g. "WebDAV password".
+func (a *App) storeSecret(name, label, value string) error {
+	if a.Secrets == nil {
+		return fmt.Errorf("secret store is not configured")
+	}
+	if err := a.Secrets.Set(name, strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("store %s: %w", label, err)
+	}
+	return nil
+}
+
+// secret returns the stored secret name, or "" when it is missing or the
+// store cannot be read.
+func (a *App) secret(name string) string {
+	if a.Secrets == nil {
+		return ""
+	}
+	value, err := a.Secrets.Get(name)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,179 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/jobs"
+)
+
+const (
+	// drainPollInterval is how often a draining server checks for an idle queue.
+	drainPollInterval = 500 * time.Millisecond
+	// cancelGracePeriod is how long a cancelled job gets to record its outcome.
+	cancelGracePeriod = 10 * time.Second
+	// shutdownTimeout bounds closing HTTP connections after the queue drained.
+	shutdownTimeout = 5 * time.Second
+)
+
+// Serve runs the headless server mode: no Wails window, settings overrides
+// and server options from MEDIA_TRANSCRIBER_* variables and flags in args,
+// and the REST/SSE API with health and metrics endpoints, plus the gRPC API
+// of api/proto when a gRPC address is set. The first SIGTERM
+// or interrupt stops accepting jobs and drains the queue; a second one, or
+// the drain timeout, cancels the running job.
+func Serve(args []string) error {
+	options, err := config.LoadServeOptions(args, os.Getenv)
+	if err != nil {
+		return fmt.Errorf("parse serve options: %w", err)
+	}
+	app, err := newApp(nil, options.Overrides)
+	if err != nil {
+		return err
+	}
+	if tokens, err := app.ListAPITokens(); err == nil && len(tokens) == 0 {
+		log.Printf("warning: no API tokens exist, so only clients on this machine may use the API on %s and admin endpoints are off; create a token with `media-transcriber token create`", options.Listen)
+	}
+	listener, err := net.Listen("tcp", options.Listen)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", options.Listen, err)
+	}
+	var grpcListener net.Listener
+	if options.GRPCListen != "" {
+		grpcListener, err = net.Listen("tcp", options.GRPCListen)
+		if err != nil {
+			listener.Close()
+			return fmt.Errorf("listen on %s: %w", options.GRPCListen, err)
+		}
+		log.Printf("serving gRPC on %s", grpcListener.Addr())
+	}
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(signals)
+	stop, requestStop := context.WithCancel(context.Background())
+	force, requestForce := context.WithCancel(context.Background())
+	defer requestStop()
+	defer requestForce()
+	go func() {
+		<-signals
+		log.Printf("shutdown requested, draining queue")
+		requestStop()
+		<-signals
+		log.Printf("second signal, cancelling running job")
+		requestForce()
+	}()
+
+	log.Printf("serving on %s", listener.Addr())
+	return app.serve(stop, force, listener, grpcListener, options.DrainTimeout)
+}
+
+// serve handles HTTP on listener, and gRPC on grpcListener unless it is nil,
+// until stop is done, then drains the queue until it is empty, force is done,
+// or drainTimeout (when positive) elapses.
+func (a *App) serve(stop, force context.Context, listener, grpcListener net.Listener, drainTimeout time.Duration) error {
+	a.startBackgroundTasks()
+	defer a.stopWhisperServer()
+
+	base, closeStreams := context.WithCancel(context.Background())
+	defer closeStreams()
+	server := &http.Server{
+		Handler:           a.serverHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return base },
+	}
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+	grpcServed := make(chan error, 1)
+	var grpcServer *grpc.Server
+	if grpcListener != nil {
+		grpcServer = a.grpcServer(base)
+		go func() { grpcServed <- grpcServer.Serve(grpcListener) }()
+	}
+
+	select {
+	case err := <-served:
+		return fmt.Errorf("serve http: %w", err)
+	case err := <-grpcServed:
+		return fmt.Errorf("serve grpc: %w", err)
+	case <-stop.Done():
+	}
+
+	a.draining.Store(true)
+	a.publishEvent(jobs.Event{
+		Type:    jobs.EventTypeLog,
+		Message: fmt.Sprintf("Server shutting down, draining %d queued jobs", len(a.GetQueue())),
+	})
+	if drainTimeout > 0 {
+		var cancel context.CancelFunc
+		force, cancel = context.WithTimeout(force, drainTimeout)
+		defer cancel()
+	}
+	if !a.waitIdle(force) {
+		a.abandonQueue()
+	}
+	// Uploads and notifications of finished jobs still run after the queue.
+	a.waitFollowUps(force)
+
+	// Event streams never go idle on their own, so end them before Shutdown.
+	closeStreams()
+	if grpcServer != nil {
+		grpcServer.GracefulStop()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shut down http: %w", err)
+	}
+	if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve http: %w", err)
+	}
+	return nil
+}
+
+// waitIdle blocks until no job runs and the queue is empty, reporting false
+// when ctx ended first.
+func (a *App) waitIdle(ctx context.Context) bool {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		if !a.Jobs.IsRunning() && len(a.GetQueue()) == 0 {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// abandonQueue drops queued jobs and cancels the running one, then gives it
+// cancelGracePeriod to record its cancelled outcome in history.
+func (a *App) abandonQueue() {
+	for _, queued := range a.GetQueue() {
+		if err := a.RemoveFromQueue(queued.ID); err == nil {
+			a.publishEvent(jobs.Event{
+				Type:    jobs.EventTypeLog,
+				Message: "Dropped queued file on shutdown: " + queued.InputPath,
+			})
+		}
+	}
+	if err := a.CancelTranscription(); err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cancelGracePeriod)
+	defer cancel()
+	a.waitIdle(ctx)
+}
@@ -0,0 +1,268 @@
+package bootstrap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// newServeTestApp returns an app whose pipeline blocks until release is closed.
+func newServeTestApp(t *testing.T, release <-chan struct{}) (*App, string) {
+	t.Helper()
+	root := t.TempDir()
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{OutputDir: root}},
+		Jobs:  jobs.NewManager(),
+		Queue: jobs.NewQueue(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return transcribe.Result{}, ctx.Err()
+			}
+			req.OnStage("exporting")
+			return transcribe.Result{TextPath: filepath.Join(root, "out.txt")}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+	return app, root
+}
+
+// TestServerAPISubmitQueueAndEvents drives the REST endpoints and the SSE replay.
+func TestServerAPISubmitQueueAndEvents(t *testing.T) {
+	release := make(chan struct{})
+	app, root := newServeTestApp(t, release)
+	server := httptest.NewServer(app.serverHandler())
+	defer server.Close()
+	defer close(release)
+
+	first, second := filepath.Join(root, "a.mp3"), filepath.Join(root, "b.mp3")
+	mustWrite(t, first, "a")
+	mustWrite(t, second, "b")
+	submit := func(path string) *http.Response {
+		resp, err := http.Post(server.URL+"/api/jobs", "application/json", strings.NewReader(mustJSON(t, submitJobRequest{InputPath: path})))
+		if err != nil {
+			t.Fatalf("submit: %v", err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	if resp := submit(first); resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("submit status = %d", resp.StatusCode)
+	}
+	if resp := submit(second); resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("submit status = %d", resp.StatusCode)
+	}
+	if resp := submit(filepath.Join(root, "missing.mp3")); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("missing file status = %d", resp.StatusCode)
+	}
+
+	var queue []domain.QueuedJob
+	getJSON(t, server.URL+"/api/queue", &queue)
+	if len(queue) != 1 || queue[0].InputPath != second {
+		t.Fatalf("queue = %+v", queue)
+	}
+	var current domain.Job
+	getJSON(t, server.URL+"/api/jobs/current", &current)
+	if current.InputPath != first {
+		t.Fatalf("current = %+v", current)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/api/queue/"+queue[0].ID, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("delete queued = %v %v", resp, err)
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("delete again = %v %v", resp, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	streamReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/events/stream?jobId="+current.ID, nil)
+	stream, err := http.DefaultClient.Do(streamReq)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	defer stream.Body.Close()
+	if got := stream.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("content type = %q", got)
+	}
+	reader := bufio.NewReader(stream.Body)
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "id: ") {
+		t.Fatalf("first stream line = %q, %v", line, err)
+	}
+	event, _ := reader.ReadString('\n')
+	data, _ := reader.ReadString('\n')
+	if event != "event: status\n" || !strings.Contains(data, `"jobId":"`+current.ID+`"`) {
+		t.Fatalf("replayed event = %q %q", event, data)
+	}
+
+	var events []jobs.Event
+	getJSON(t, server.URL+"/api/events?type=status&jobId="+current.ID, &events)
+	if len(events) == 0 {
+		t.Fatal("expected status events for the current job")
+	}
+}
+
+// TestEventStreamResumesFromLastEventID replays only what a reconnecting
+// client missed, even though it repeats its original ?since= URL.
+func TestEventStreamResumesFromLastEventID(t *testing.T) {
+	app, _ := newServeTestApp(t, nil)
+	for i := 0; i < 3; i++ {
+		app.publishEvent(jobs.Event{JobID: "job-1", Type: jobs.EventTypeLog, Message: "line"})
+	}
+	server := httptest.NewServer(app.serverHandler())
+	defer server.Close()
+
+	firstID := func(lastEventID string) string {
+		t.Helper()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/events/stream?since=0", nil)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("stream: %v", err)
+		}
+		defer resp.Body.Close()
+		line, err := bufio.NewReader(resp.Body).ReadString('\n')
+		if err != nil {
+			t.Fatalf("read stream: %v", err)
+		}
+		return strings.TrimSpace(line)
+	}
+
+	if got := firstID(""); got != "id: 1" {
+		t.Fatalf("first connection starts at %q, want id: 1", got)
+	}
+	if got := firstID("2"); got != "id: 3" {
+		t.Fatalf("reconnect starts at %q, want id: 3", got)
+	}
+}
+
+// TestServeDrainsQueueOnStop finishes the running job before returning and
+// refuses submissions meanwhile.
+func TestServeDrainsQueueOnStop(t *testing.T) {
+	release := make(chan struct{})
+	app, root := newServeTestApp(t, release)
+	input := filepath.Join(root, "a.mp3")
+	mustWrite(t, input, "a")
+	if _, err := app.EnqueueFile(input, false); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	stop, requestStop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- app.serve(stop, context.Background(), listener, nil, 0) }()
+
+	requestStop()
+	waitFor(t, app.draining.Load)
+	resp, err := http.Post("http://"+listener.Addr().String()+"/api/jobs", "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("submit while draining: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("submit while draining = %d, want 503", resp.StatusCode)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("serve returned before the job finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("serve: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after the queue drained")
+	}
+	if status := app.Jobs.Current().Status; status != domain.JobStatusDone {
+		t.Fatalf("status = %s, want done", status)
+	}
+}
+
+// TestServeDrainTimeoutCancelsJob cancels the running job once the drain timeout passes.
+func TestServeDrainTimeoutCancelsJob(t *testing.T) {
+	app, root := newServeTestApp(t, make(chan struct{}))
+	input := filepath.Join(root, "a.mp3")
+	mustWrite(t, input, "a")
+	if _, err := app.EnqueueFile(input, false); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	stop, requestStop := context.WithCancel(context.Background())
+	requestStop()
+
+	if err := app.serve(stop, context.Background(), listener, nil, 20*time.Millisecond); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	if status := app.Jobs.Current().Status; status != domain.JobStatusCancelled {
+		t.Fatalf("status = %s, want cancelled", status)
+	}
+}
+
+// mustJSON encodes value as JSON.
+func mustJSON(t *testing.T, value any) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(data)
+}
+
+// getJSON decodes the JSON response of a GET request into target.
+func getJSON(t *testing.T, url string, target any) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("get %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("get %s: status %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		t.Fatalf("decode %s: %v", url, err)
+	}
+}
+
+// waitFor polls cond for up to two seconds.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/metrics"
+)
+
+// serverHandler routes the HTTP endpoints served in headless server mode.
+// Routes without a scope stay open; the rest require a token scope.
+func (a *App) serverHandler() http.Handler {
+	mux := http.NewServeMux()
+	for _, route := range a.apiRoutes() {
+		handler := route.handler
+		if route.scope != "" {
+			handler = a.requireScope(route.scope, handler)
+		}
+		mux.HandleFunc(route.method+" "+route.path, handler)
+	}
+	return mux
+}
+
+// handleMetrics serves the Prometheus metrics collected from events.
+func (a *App) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	collector := a.metrics
+	if collector == nil {
+		collector = metrics.NewCollector(metrics.Gauges{})
+	}
+	collector.Handler().ServeHTTP(w, r)
+}
+
+// handleHealthz reports liveness: the process is up and serving requests.
+func (a *App) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReadyz reruns the local diagnostics (tools present, model readable,
+// output dir writable) and answers 503 with the report when any check fails
+// or the server is draining.
+// Network probes are skipped so an unreachable model host does not take the
+// server out of rotation.
+func (a *App) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	if a.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "server is shutting down"})
+		return
+	}
+	if a.checker == nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "diagnostics are unavailable"})
+		return
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "load settings: " + err.Error()})
+		return
+	}
+
+	report := a.checker.RunLocal(settings)
+	status := http.StatusOK
+	if report.HasFailures {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, readiness{Ready: !report.HasFailures, Report: report})
+}
+
+// readiness is the /readyz response body.
+type readiness struct {
+	Ready  bool                    `json:"ready"`
+	Report domain.DiagnosticReport `json:"report"`
+}
+
+// writeJSON sends value as a JSON response with status.
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
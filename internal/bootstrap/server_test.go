@@ -0,0 +1,78 @@
+package bootstrap
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/diagnostics"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/metrics"
+)
+
+// TestServerMetricsEndpoint counts published job events on /metrics.
+func TestServerMetricsEndpoint(t *testing.T) {
+	app := &App{Jobs: jobs.NewManager(), Queue: jobs.NewQueue(), events: jobs.NewEventBus(10)}
+	app.metrics = metrics.NewCollector(metrics.Gauges{
+		QueueDepth: func() int { return len(app.Queue.List()) },
+		JobRunning: app.Jobs.IsRunning,
+	})
+	app.Queue.Push(domain.QueuedJob{ID: "q1", InputPath: "/a.mp3"})
+	app.publishEvent(jobs.Event{JobID: "job-1", Type: jobs.EventTypeStatus, Status: domain.JobStatusCancelled})
+
+	recorder := httptest.NewRecorder()
+	app.serverHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+	if !strings.Contains(body, `media_transcriber_jobs_finished_total{status="cancelled"} 1`) ||
+		!strings.Contains(body, "media_transcriber_queue_depth 1") {
+		t.Fatalf("unexpected metrics:\n%s", body)
+	}
+}
+
+// TestServerHealthAndReadiness answers liveness always and readiness from local diagnostics.
+func TestServerHealthAndReadiness(t *testing.T) {
+	root := t.TempDir()
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	mustWrite(t, modelPath, "stub")
+	found := true
+	checker := diagnostics.NewCheckerForTests(
+		func(name string) (string, error) {
+			if !found {
+				return "", errors.New("not found")
+			}
+			return "/usr/bin/" + name, nil
+		},
+		os.Stat, os.ReadDir, os.MkdirAll, os.CreateTemp, os.Remove,
+	)
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{ModelPath: modelPath, OutputDir: root}},
+		checker: checker,
+	}
+	handler := app.serverHandler()
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+		return recorder
+	}
+
+	if got := get("/healthz"); got.Code != http.StatusOK {
+		t.Fatalf("healthz = %d", got.Code)
+	}
+	if got := get("/readyz"); got.Code != http.StatusOK || !strings.Contains(got.Body.String(), `"ready":true`) {
+		t.Fatalf("readyz = %d %s", got.Code, got.Body)
+	}
+
+	found = false
+	got := get("/readyz")
+	if got.Code != http.StatusServiceUnavailable || !strings.Contains(got.Body.String(), `"whisper.cpp"`) {
+		t.Fatalf("readyz without tools = %d %s", got.Code, got.Body)
+	}
+	if got := get("/healthz"); got.Code != http.StatusOK {
+		t.Fatalf("healthz without tools = %d", got.Code)
+	}
+}
@@ -0,0 +1,115 @@
+package bootstrap
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"media-transcriber/internal/domain"
+)
+
+// ListSourcePresets returns the per-source output presets sorted by prefix.
+func (a *App) ListSourcePresets() ([]domain.SourcePreset, error) {
+	if a.Sources == nil {
+		return nil, fmt.Errorf("source preset store is not configured")
+	}
+	presets, err := a.Sources.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load source presets: %w", err)
+	}
+	sortSourcePresets(presets)
+	return presets, nil
+}
+
+// SaveSourcePresets replaces all source presets. Prefixes are cleaned and must
+// be unique, each preset must set an output directory or a profile, and
+// profiles must exist.
+func (a *App) SaveSourcePresets(presets []domain.SourcePreset) ([]domain.SourcePreset, error) {
+	if a.Sources == nil {
+		return nil, fmt.Errorf("source preset store is not configured")
+	}
+
+	normalized := make([]domain.SourcePreset, 0, len(presets))
+	seen := map[string]bool{}
+	for _, preset := range presets {
+		preset.Prefix = strings.TrimSpace(preset.Prefix)
+		preset.OutputDir = strings.TrimSpace(preset.OutputDir)
+		preset.Profile = strings.TrimSpace(preset.Profile)
+		if preset.Prefix == "" {
+			return nil, fmt.Errorf("source preset prefix is required")
+		}
+		preset.Prefix = filepath.Clean(preset.Prefix)
+		if preset.OutputDir == "" && preset.Profile == "" {
+			return nil, fmt.Errorf("source preset %s: output directory or profile is required", preset.Prefix)
+		}
+		if seen[preset.Prefix] {
+			return nil, fmt.Errorf("duplicate source preset: %s", preset.Prefix)
+		}
+		seen[preset.Prefix] = true
+		if preset.Profile != "" {
+			profile, err := a.loadProfile(preset.Profile)
+			if err != nil {
+				return nil, fmt.Errorf("source preset %s: %w", preset.Prefix, err)
+			}
+			preset.Profile = profile.Name
+		}
+		normalized = append(normalized, preset)
+	}
+
+	sortSourcePresets(normalized)
+	if err := a.Sources.Save(normalized); err != nil {
+		return nil, fmt.Errorf("save source presets: %w", err)
+	}
+	return normalized, nil
+}
+
+// applySourcePreset returns settings for a job on inputPath: the matching
+// preset's profile, if any, with its output directory on top. It also
+// returns the matched preset prefix, or "" when no preset matches.
+func (a *App) applySourcePreset(inputPath string, settings domain.Settings) (domain.Settings, string, error) {
+	if a.Sources == nil {
+		return settings, "", nil
+	}
+	presets, err := a.Sources.Load()
+	if err != nil {
+		return domain.Settings{}, "", fmt.Errorf("load source presets: %w", err)
+	}
+	preset, ok := matchSourcePreset(presets, inputPath)
+	if !ok {
+		return settings, "", nil
+	}
+	if preset.Profile != "" {
+		profile, err := a.loadProfile(preset.Profile)
+		if err != nil {
+			return domain.Settings{}, "", fmt.Errorf("source preset %s: %w", preset.Prefix, err)
+		}
+		settings = normalizeSettings(profile.Settings)
+		settings.ActiveProfile = profile.Name
+	}
+	if preset.OutputDir != "" {
+		settings.OutputDir = preset.OutputDir
+	}
+	return settings, preset.Prefix, nil
+}
+
+// matchSourcePreset returns the preset with the longest prefix containing
+// inputPath, so a subfolder preset wins over its parent's.
+func matchSourcePreset(presets []domain.SourcePreset, inputPath string) (domain.SourcePreset, bool) {
+	var best domain.SourcePreset
+	found := false
+	for _, preset := range presets {
+		if preset.Prefix == "" || !isWithinBaseDir(preset.Prefix, inputPath) {
+			continue
+		}
+		if !found || len(filepath.Clean(preset.Prefix)) > len(filepath.Clean(best.Prefix)) {
+			best, found = preset, true
+		}
+	}
+	return best, found
+}
+
+// sortSourcePresets orders presets by prefix.
+func sortSourcePresets(presets []domain.SourcePreset) {
+	sort.Slice(presets, func(i, j int) bool { return presets[i].Prefix < presets[j].Prefix })
+}
@@ -0,0 +1,131 @@
+package bootstrap
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestMatchSourcePreset picks the deepest preset containing the input.
+func TestMatchSourcePreset(t *testing.T) {
+	presets := []domain.SourcePreset{
+		{Prefix: filepath.FromSlash("/rec"), OutputDir: "all"},
+		{Prefix: filepath.FromSlash("/rec/podcasts"), OutputDir: "podcasts"},
+		{Prefix: filepath.FromSlash("/clients/acme"), OutputDir: "acme"},
+	}
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "/rec/podcasts/ep1.mp3", want: "podcasts"},
+		{input: "/rec/podcasts-old/ep1.mp3", want: "all"},
+		{input: "/rec/memo.m4a", want: "all"},
+		{input: "/clients/acme/call.wav", want: "acme"},
+		{input: "/clients/acme2/call.wav", want: ""},
+		{input: "/other/file.mp3", want: ""},
+	}
+	for _, tt := range tests {
+		preset, _ := matchSourcePreset(presets, filepath.FromSlash(tt.input))
+		if preset.OutputDir != tt.want {
+			t.Errorf("match(%s) = %q, want %q", tt.input, preset.OutputDir, tt.want)
+		}
+	}
+}
+
+// TestSaveSourcePresetsValidates cleans prefixes and rejects incomplete,
+// duplicate, or dangling presets.
+func TestSaveSourcePresetsValidates(t *testing.T) {
+	root := t.TempDir()
+	app := &App{
+		Profiles: config.NewJSONProfileStore(filepath.Join(root, "profiles.json")),
+		Sources:  config.NewJSONSourcePresetStore(filepath.Join(root, "source-presets.json")),
+	}
+	if _, err := app.SaveProfile("Interviews", domain.Settings{}); err != nil {
+		t.Fatalf("save profile: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		presets []domain.SourcePreset
+		wantErr bool
+	}{
+		{name: "empty prefix", presets: []domain.SourcePreset{{Prefix: " ", OutputDir: "/out"}}, wantErr: true},
+		{name: "no target", presets: []domain.SourcePreset{{Prefix: "/rec"}}, wantErr: true},
+		{name: "unknown profile", presets: []domain.SourcePreset{{Prefix: "/rec", Profile: "Podcasts"}}, wantErr: true},
+		{name: "duplicate", presets: []domain.SourcePreset{{Prefix: "/rec", OutputDir: "/a"}, {Prefix: "/rec/", OutputDir: "/b"}}, wantErr: true},
+		{name: "valid", presets: []domain.SourcePreset{{Prefix: "/rec/", OutputDir: "/out"}, {Prefix: "/clients", Profile: "interviews"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := app.SaveSourcePresets(tt.presets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	presets, err := app.ListSourcePresets()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	want := []domain.SourcePreset{
+		{Prefix: filepath.Clean("/clients"), Profile: "Interviews"},
+		{Prefix: filepath.Clean("/rec"), OutputDir: "/out"},
+	}
+	if len(presets) != len(want) || presets[0] != want[0] || presets[1] != want[1] {
+		t.Fatalf("presets = %+v, want %+v", presets, want)
+	}
+}
+
+// TestStartTranscriptionAppliesSourcePreset runs files from a preset's
+// source with its profile and output directory, and others unchanged.
+func TestStartTranscriptionAppliesSourcePreset(t *testing.T) {
+	root := t.TempDir()
+	defaultDir, interviewsDir := filepath.Join(root, "out"), filepath.Join(root, "interviews")
+	requests := make(chan transcribe.Request, 1)
+	app := &App{
+		Store:    &fakeStore{settings: domain.Settings{ModelPath: "/m/base.bin", OutputDir: defaultDir}},
+		Profiles: config.NewJSONProfileStore(filepath.Join(root, "profiles.json")),
+		Sources:  config.NewJSONSourcePresetStore(filepath.Join(root, "source-presets.json")),
+		Jobs:     jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			requests <- req
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+	if _, err := app.SaveProfile("Interviews", domain.Settings{ModelPath: "/m/large-v3.bin", Language: "de"}); err != nil {
+		t.Fatalf("save profile: %v", err)
+	}
+	clients := filepath.Join(root, "clients")
+	if _, err := app.SaveSourcePresets([]domain.SourcePreset{{Prefix: clients, OutputDir: interviewsDir, Profile: "Interviews"}}); err != nil {
+		t.Fatalf("save presets: %v", err)
+	}
+
+	tests := []struct {
+		input     string
+		model     string
+		outputDir string
+	}{
+		{input: filepath.Join(clients, "acme", "call.wav"), model: "/m/large-v3.bin", outputDir: interviewsDir},
+		{input: filepath.Join(root, "memo.m4a"), model: "/m/base.bin", outputDir: defaultDir},
+	}
+	for _, tt := range tests {
+		job, err := app.StartTranscription(tt.input)
+		if err != nil {
+			t.Fatalf("start %s: %v", tt.input, err)
+		}
+		req := <-requests
+		if req.ModelPath != tt.model || req.OutputDir != tt.outputDir {
+			t.Fatalf("%s: request model %q output %q, want %q %q", tt.input, req.ModelPath, req.OutputDir, tt.model, tt.outputDir)
+		}
+		waitFor(t, func() bool { return !app.Jobs.IsRunning() && app.CurrentJob().ID == job.ID })
+	}
+}
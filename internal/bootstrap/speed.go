@@ -0,0 +1,119 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// speedWeight is how much each finished job moves a model's rolling
+// real-time factor, so the estimate follows hardware and load changes
+// without jumping on one odd file.
+const speedWeight = 0.3
+
+// minSpeedAudioSeconds is the shortest job that counts toward a model's
+// speed; model loading dominates shorter ones.
+const minSpeedAudioSeconds = 10
+
+// estimateJob probes the input's duration and, when the model's speed on
+// this machine is known from earlier jobs or a benchmark, gives the job an
+// expected run time and announces when it should finish. Unknown speeds and
+// probe failures leave the job with the progress-based ETA alone.
+func (a *App) estimateJob(ctx context.Context, jobID, inputPath string, settings domain.Settings) {
+	factor, ok := a.realTimeFactor(settings.ModelPath)
+	if !ok {
+		return
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+	duration, err := transcribe.ProbeDuration(probeCtx, transcribe.FFprobePath(settings.FFmpegPath), inputPath)
+	cancel()
+	if err != nil || duration <= 0 {
+		return
+	}
+
+	seconds := duration * factor
+	a.Jobs.SetEstimate(seconds)
+	job := a.Jobs.Current()
+	if job.ID != jobID {
+		return
+	}
+	finish := job.StartedAt.Add(time.Duration(seconds * float64(time.Second))).Local()
+	a.publishEvent(jobs.Event{
+		JobID: jobID,
+		Type:  jobs.EventTypeLog,
+		Message: fmt.Sprintf("Estimated to finish at %s: %s of audio at %.2fs per audio second",
+			finish.Format("15:04"), roundSeconds(duration), factor),
+	})
+	a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeProgress, Job: &job})
+}
+
+// realTimeFactor returns the model's rolling speed from finished jobs,
+// falling back to its benchmark.
+func (a *App) realTimeFactor(modelPath string) (float64, bool) {
+	modelPath = strings.TrimSpace(modelPath)
+	if a.Speeds != nil {
+		if speeds, err := a.Speeds.Load(); err == nil {
+			if speed, ok := speeds[modelPath]; ok && speed.RealTimeFactor > 0 {
+				return speed.RealTimeFactor, true
+			}
+		}
+	}
+	if a.Benchmarks == nil {
+		return 0, false
+	}
+	benchmarks, err := a.Benchmarks.Load()
+	if err != nil {
+		return 0, false
+	}
+	result, ok := benchmarkForModel(modelPath, benchmarks)
+	return result.RealTimeFactor, ok
+}
+
+// recordSpeed folds a finished job's transcription time per second of audio
+// into the model's rolling real-time factor.
+func (a *App) recordSpeed(modelPath string, stages []domain.StageTiming, audioSeconds float64) {
+	modelPath = strings.TrimSpace(modelPath)
+	if a.Speeds == nil || modelPath == "" || audioSeconds < minSpeedAudioSeconds {
+		return
+	}
+	var compute float64
+	for _, stage := range stages {
+		if stage.Stage == "transcribing" {
+			compute += stage.Seconds
+		}
+	}
+	if compute <= 0 {
+		return
+	}
+
+	// Jobs run one at a time, so this read-modify-write cannot race.
+	speeds, err := a.Speeds.Load()
+	if err != nil {
+		return
+	}
+	speeds[modelPath] = rollSpeed(speeds[modelPath], modelPath, compute/audioSeconds, time.Now().UTC())
+	_ = a.Speeds.Save(speeds)
+}
+
+// rollSpeed adds one measured real-time factor to a model's rolling speed.
+func rollSpeed(speed domain.ModelSpeed, modelPath string, factor float64, now time.Time) domain.ModelSpeed {
+	if speed.Jobs == 0 || speed.RealTimeFactor <= 0 {
+		speed.RealTimeFactor = factor
+	} else {
+		speed.RealTimeFactor += speedWeight * (factor - speed.RealTimeFactor)
+	}
+	speed.ModelPath = modelPath
+	speed.Jobs++
+	speed.UpdatedAt = now
+	return speed
+}
+
+// roundSeconds renders seconds as a duration to the second.
+func roundSeconds(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
@@ -0,0 +1,57 @@
+package bootstrap
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+)
+
+// TestRollSpeed starts from the first measurement and then moves a fraction
+// of the way toward each new one.
+func TestRollSpeed(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	speed := rollSpeed(domain.ModelSpeed{}, "/m/base.bin", 0.5, now)
+	if speed.RealTimeFactor != 0.5 || speed.Jobs != 1 || speed.ModelPath != "/m/base.bin" || !speed.UpdatedAt.Equal(now) {
+		t.Fatalf("first speed = %+v", speed)
+	}
+	speed = rollSpeed(speed, "/m/base.bin", 1.5, now)
+	if math.Abs(speed.RealTimeFactor-0.8) > 1e-9 || speed.Jobs != 2 {
+		t.Fatalf("rolled speed = %+v, want factor 0.8 after 2 jobs", speed)
+	}
+}
+
+// TestRecordSpeedPrefersMeasuredJobs stores the transcription speed of
+// finished jobs, ignores clips too short to measure, and uses it ahead of
+// the model's benchmark.
+func TestRecordSpeedPrefersMeasuredJobs(t *testing.T) {
+	root := t.TempDir()
+	benchmarks := config.NewJSONBenchmarkStore(filepath.Join(root, "benchmarks.json"))
+	if err := benchmarks.Save(map[string]domain.BenchmarkResult{
+		"base": {ModelID: "base", ModelPath: "/m/base.bin", RealTimeFactor: 0.1},
+	}); err != nil {
+		t.Fatalf("save benchmarks: %v", err)
+	}
+	app := &App{Benchmarks: benchmarks, Speeds: config.NewJSONSpeedStore(filepath.Join(root, "model-speeds.json"))}
+
+	if factor, ok := app.realTimeFactor("/m/base.bin"); !ok || factor != 0.1 {
+		t.Fatalf("benchmark factor = %v, %v", factor, ok)
+	}
+	stages := []domain.StageTiming{{Stage: "preprocessing", Seconds: 5}, {Stage: "transcribing", Seconds: 30}}
+	app.recordSpeed("/m/base.bin", stages, 5)
+	app.recordSpeed("/m/base.bin", []domain.StageTiming{{Stage: "preprocessing", Seconds: 5}}, 60)
+	if factor, _ := app.realTimeFactor("/m/base.bin"); factor != 0.1 {
+		t.Fatalf("factor = %v, want the benchmark's until a job is measured", factor)
+	}
+
+	app.recordSpeed("/m/base.bin", stages, 120)
+	if factor, ok := app.realTimeFactor("/m/base.bin"); !ok || factor != 0.25 {
+		t.Fatalf("measured factor = %v, %v, want 0.25", factor, ok)
+	}
+	if _, ok := app.realTimeFactor("/m/large.bin"); ok {
+		t.Fatal("unmeasured model should have no factor")
+	}
+}
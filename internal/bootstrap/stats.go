@@ -0,0 +1,154 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// GetStats aggregates job history over period ("day", "week", "month", or
+// "all"; empty means all) for the usage dashboard.
+func (a *App) GetStats(period string) (domain.UsageStats, error) {
+	var entries []domain.HistoryEntry
+	if a.History != nil {
+		loaded, err := a.History.Load()
+		if err != nil {
+			return domain.UsageStats{}, fmt.Errorf("load history: %w", err)
+		}
+		entries = loaded
+	}
+	return buildUsageStats(entries, domain.StatsPeriod(strings.TrimSpace(period)), time.Now().UTC())
+}
+
+// buildUsageStats aggregates entries finished within period before now.
+func buildUsageStats(entries []domain.HistoryEntry, period domain.StatsPeriod, now time.Time) (domain.UsageStats, error) {
+	stats := domain.UsageStats{Period: period, Models: []domain.ModelUsage{}, Languages: []domain.LanguageUsage{}}
+	switch period {
+	case domain.StatsPeriodDay:
+		stats.Since = now.Add(-24 * time.Hour)
+	case domain.StatsPeriodWeek:
+		stats.Since = now.AddDate(0, 0, -7)
+	case domain.StatsPeriodMonth:
+		stats.Since = now.AddDate(0, 0, -30)
+	case domain.StatsPeriodAll, "":
+		stats.Period = domain.StatsPeriodAll
+	default:
+		return domain.UsageStats{}, fmt.Errorf("unknown stats period %q", period)
+	}
+
+	models := map[string]*domain.ModelUsage{}
+	modelSeconds := map[string][2]float64{}
+	languages := map[string]*domain.LanguageUsage{}
+	for _, entry := range entries {
+		if entry.FinishedAt.Before(stats.Since) {
+			continue
+		}
+		stats.Jobs++
+		switch entry.Status {
+		case domain.JobStatusDone:
+			stats.Succeeded++
+		case domain.JobStatusFailed:
+			stats.Failed++
+		case domain.JobStatusCancelled:
+			stats.Cancelled++
+		}
+		if entry.Status != domain.JobStatusDone {
+			continue
+		}
+
+		audioHours := entry.AudioSeconds / 3600
+		processing := 0.0
+		if !entry.StartedAt.IsZero() && entry.FinishedAt.After(entry.StartedAt) {
+			processing = entry.FinishedAt.Sub(entry.StartedAt).Seconds()
+		}
+		stats.AudioHours += audioHours
+		stats.ProcessingHours += processing / 3600
+
+		model := models[entry.ModelPath]
+		if model == nil {
+			model = &domain.ModelUsage{ModelPath: entry.ModelPath}
+			models[entry.ModelPath] = model
+		}
+		model.Jobs++
+		model.AudioHours += audioHours
+		if entry.AudioSeconds > 0 && processing > 0 {
+			seconds := modelSeconds[entry.ModelPath]
+			modelSeconds[entry.ModelPath] = [2]float64{seconds[0] + processing, seconds[1] + entry.AudioSeconds}
+		}
+		model.Stages = addStageTimings(model.Stages, entry.Stages)
+
+		language := entry.Language
+		if language == "" {
+			language = "auto"
+		}
+		usage := languages[language]
+		if usage == nil {
+			usage = &domain.LanguageUsage{Language: language}
+			languages[language] = usage
+		}
+		usage.Jobs++
+		usage.AudioHours += audioHours
+	}
+
+	for path, model := range models {
+		if seconds := modelSeconds[path]; seconds[1] > 0 {
+			model.AverageRealTimeFactor = seconds[0] / seconds[1]
+		}
+		for i := range model.Stages {
+			model.Stages[i].AverageSeconds /= float64(model.Stages[i].Jobs)
+		}
+		stats.Models = append(stats.Models, *model)
+	}
+	sort.Slice(stats.Models, func(i, j int) bool {
+		if stats.Models[i].Jobs != stats.Models[j].Jobs {
+			return stats.Models[i].Jobs > stats.Models[j].Jobs
+		}
+		return stats.Models[i].ModelPath < stats.Models[j].ModelPath
+	})
+	for _, language := range languages {
+		stats.Languages = append(stats.Languages, *language)
+	}
+	sort.Slice(stats.Languages, func(i, j int) bool {
+		if stats.Languages[i].Jobs != stats.Languages[j].Jobs {
+			return stats.Languages[i].Jobs > stats.Languages[j].Jobs
+		}
+		return stats.Languages[i].Language < stats.Languages[j].Language
+	})
+	return stats, nil
+}
+
+// addStageTimings adds one job's stage timings to usage, which holds total
+// seconds until buildUsageStats turns them into averages. Stages keep the
+// order they first ran in.
+func addStageTimings(usage []domain.StageUsage, timings []domain.StageTiming) []domain.StageUsage {
+	for _, timing := range timings {
+		i := slices.IndexFunc(usage, func(u domain.StageUsage) bool { return u.Stage == timing.Stage })
+		if i < 0 {
+			usage = append(usage, domain.StageUsage{Stage: timing.Stage})
+			i = len(usage) - 1
+		}
+		usage[i].Jobs++
+		usage[i].AverageSeconds += timing.Seconds
+		usage[i].PeakMemoryBytes = max(usage[i].PeakMemoryBytes, timing.PeakMemoryBytes)
+	}
+	return usage
+}
+
+// audioSeconds measures the transcribed audio from the preprocessed WAV,
+// undoing any speed-up, and falls back to the end of the last segment once
+// the WAV is gone.
+func audioSeconds(result transcribe.Result) float64 {
+	if info, err := os.Stat(result.PreprocessedAudioPath); err == nil && info.Size() > transcribe.PreprocessedHeaderBytes {
+		return float64(info.Size()-transcribe.PreprocessedHeaderBytes) / transcribe.PreprocessedBytesPerSecond * max(result.Tempo, 1)
+	}
+	if n := len(result.Segments); n > 0 {
+		return float64(result.Segments[n-1].EndMs) / 1000
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package bootstrap
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestBuildUsageStats aggregates counts, hours, and per-model real-time factors.
+func TestBuildUsageStats(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	entry := func(status domain.JobStatus, model, language string, audio float64, ago, took time.Duration) domain.HistoryEntry {
+		end := now.Add(-ago)
+		return domain.HistoryEntry{Status: status, ModelPath: model, Language: language, AudioSeconds: audio, StartedAt: end.Add(-took), FinishedAt: end}
+	}
+	entries := []domain.HistoryEntry{
+		entry(domain.JobStatusDone, "small.bin", "en", 3600, time.Hour, 30*time.Minute),
+		entry(domain.JobStatusDone, "small.bin", "", 1800, 2*time.Hour, 30*time.Minute),
+		entry(domain.JobStatusDone, "large.bin", "ru", 3600, 3*24*time.Hour, 2*time.Hour),
+		entry(domain.JobStatusFailed, "small.bin", "en", 0, 5*time.Hour, time.Minute),
+		entry(domain.JobStatusCancelled, "large.bin", "en", 0, 40*24*time.Hour, time.Minute),
+	}
+
+	day, err := buildUsageStats(entries, domain.StatsPeriodDay, now)
+	if err != nil {
+		t.Fatalf("day stats: %v", err)
+	}
+	if day.Jobs != 3 || day.Succeeded != 2 || day.Failed != 1 || day.Cancelled != 0 {
+		t.Fatalf("day counts = %+v", day)
+	}
+	if day.AudioHours != 1.5 || day.ProcessingHours != 1 {
+		t.Fatalf("day hours = %v audio, %v processing", day.AudioHours, day.ProcessingHours)
+	}
+	if len(day.Models) != 1 || math.Abs(day.Models[0].AverageRealTimeFactor-2.0/3) > 1e-9 {
+		t.Fatalf("day models = %+v", day.Models)
+	}
+	if len(day.Languages) != 2 || day.Languages[0].Language != "auto" || day.Languages[1].Language != "en" {
+		t.Fatalf("day languages = %+v", day.Languages)
+	}
+
+	all, err := buildUsageStats(entries, "", now)
+	if err != nil {
+		t.Fatalf("all stats: %v", err)
+	}
+	if all.Period != domain.StatsPeriodAll || all.Jobs != 5 || all.Cancelled != 1 || len(all.Models) != 2 {
+		t.Fatalf("all stats = %+v", all)
+	}
+	if all.Models[1].ModelPath != "large.bin" || all.Models[1].AverageRealTimeFactor != 2 {
+		t.Fatalf("large model = %+v", all.Models[1])
+	}
+
+	if _, err := buildUsageStats(entries, "year", now); err == nil {
+		t.Fatal("expected error for unknown period")
+	}
+}
+
+// TestAudioSeconds measures the preprocessed WAV, undoing any speed-up, and
+// falls back to segments.
+func TestAudioSeconds(t *testing.T) {
+	wav := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(wav, make([]byte, transcribe.PreprocessedHeaderBytes+2*transcribe.PreprocessedBytesPerSecond), 0o644); err != nil {
+		t.Fatalf("write wav: %v", err)
+	}
+	if got := audioSeconds(transcribe.Result{PreprocessedAudioPath: wav}); got != 2 {
+		t.Fatalf("wav seconds = %v, want 2", got)
+	}
+	if got := audioSeconds(transcribe.Result{PreprocessedAudioPath: wav, Tempo: 1.5}); got != 3 {
+		t.Fatalf("sped-up wav seconds = %v, want 3", got)
+	}
+
+	result := transcribe.Result{
+		PreprocessedAudioPath: filepath.Join(t.TempDir(), "gone.wav"),
+		Segments:              []transcribe.TranscriptSegment{{EndMs: 1500}, {EndMs: 4250}},
+	}
+	if got := audioSeconds(result); got != 4.25 {
+		t.Fatalf("segment seconds = %v, want 4.25", got)
+	}
+}
+
+// TestBuildUsageStatsAveragesStageTimings compares stages per model.
+func TestBuildUsageStatsAveragesStageTimings(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	entries := []domain.HistoryEntry{
+		{Status: domain.JobStatusDone, ModelPath: "small.bin", FinishedAt: now, Stages: []domain.StageTiming{
+			{Stage: "preprocessing", Seconds: 4},
+			{Stage: "transcribing", Seconds: 60, PeakMemoryBytes: 900},
+		}},
+		{Status: domain.JobStatusDone, ModelPath: "small.bin", FinishedAt: now, Stages: []domain.StageTiming{
+			{Stage: "preprocessing", Seconds: 2},
+			{Stage: "transcribing", Seconds: 30, PeakMemoryBytes: 700},
+			{Stage: "exporting", Seconds: 1},
+		}},
+		{Status: domain.JobStatusFailed, ModelPath: "small.bin", FinishedAt: now, Stages: []domain.StageTiming{
+			{Stage: "transcribing", Seconds: 999},
+		}},
+	}
+
+	stats, err := buildUsageStats(entries, domain.StatsPeriodAll, now)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	want := []domain.StageUsage{
+		{Stage: "preprocessing", Jobs: 2, AverageSeconds: 3},
+		{Stage: "transcribing", Jobs: 2, AverageSeconds: 45, PeakMemoryBytes: 900},
+		{Stage: "exporting", Jobs: 1, AverageSeconds: 1},
+	}
+	if len(stats.Models) != 1 || !reflect.DeepEqual(stats.Models[0].Stages, want) {
+		t.Fatalf("models = %+v, want stages %+v", stats.Models, want)
+	}
+}
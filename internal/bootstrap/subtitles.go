@@ -0,0 +1,81 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/subtitle"
+	"media-transcriber/internal/textenc"
+)
+
+// ShiftSubtitles moves every cue of an SRT or WebVTT file by offsetMs
+// (negative is earlier) and rewrites the file in place.
+func (a *App) ShiftSubtitles(path string, offsetMs int64) error {
+	return rewriteSubtitles(path, func(file *subtitle.File) error {
+		file.Cues = subtitle.Shift(file.Cues, offsetMs)
+		return nil
+	})
+}
+
+// ResyncSubtitles linearly stretches an SRT or WebVTT file so two cue times
+// land where they are heard, fixing offset and drift together.
+func (a *App) ResyncSubtitles(path string, first, second subtitle.SyncPoint) error {
+	return rewriteSubtitles(path, func(file *subtitle.File) error {
+		cues, err := subtitle.Stretch(file.Cues, first, second)
+		if err != nil {
+			return err
+		}
+		file.Cues = cues
+		return nil
+	})
+}
+
+// rewriteSubtitles parses path, applies edit, and replaces the file through a
+// temp file so a failed write never leaves half a subtitle file behind.
+func rewriteSubtitles(path string, edit func(file *subtitle.File) error) error {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return fmt.Errorf("subtitle path is required")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("cannot access subtitles: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read subtitles: %w", err)
+	}
+	file, err := subtitle.Parse(data)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	if err := edit(&file); err != nil {
+		return fmt.Errorf("resync %s: %w", filepath.Base(path), err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("write subtitles: %w", err)
+	}
+	tmpPath := tmp.Name()
+	// Keep the byte order mark, UTF-16, and line endings the file had.
+	encoding, lineEnding := textenc.Detect(data)
+	_, writeErr := tmp.Write(textenc.Encode(string(file.Bytes()), encoding, lineEnding))
+	closeErr := tmp.Close()
+	if writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr == nil {
+		writeErr = os.Chmod(tmpPath, info.Mode().Perm())
+	}
+	if writeErr == nil {
+		writeErr = os.Rename(tmpPath, path)
+	}
+	if writeErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("write subtitles: %w", writeErr)
+	}
+	return nil
+}
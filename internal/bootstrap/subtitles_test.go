@@ -0,0 +1,49 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/subtitle"
+)
+
+// TestShiftAndResyncSubtitlesRewriteFile edits an SRT file in place and leaves
+// it untouched when the edit is invalid.
+func TestShiftAndResyncSubtitlesRewriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talk.srt")
+	mustWrite(t, path, "1\n00:00:01,000 --> 00:00:02,000\nHello\n\n2\n00:00:11,000 --> 00:00:12,000\nBye\n")
+	app := &App{}
+
+	if err := app.ShiftSubtitles(path, 500); err != nil {
+		t.Fatalf("shift: %v", err)
+	}
+	assertFile(t, path, "1\n00:00:01,500 --> 00:00:02,500\nHello\n\n2\n00:00:11,500 --> 00:00:12,500\nBye\n")
+
+	if err := app.ResyncSubtitles(path, subtitle.SyncPoint{FromMs: 1500, ToMs: 1000}, subtitle.SyncPoint{FromMs: 11500, ToMs: 21000}); err != nil {
+		t.Fatalf("resync: %v", err)
+	}
+	assertFile(t, path, "1\n00:00:01,000 --> 00:00:03,000\nHello\n\n2\n00:00:21,000 --> 00:00:23,000\nBye\n")
+
+	if err := app.ResyncSubtitles(path, subtitle.SyncPoint{FromMs: 1000}, subtitle.SyncPoint{FromMs: 1000}); err == nil {
+		t.Fatal("expected error for identical sync points")
+	}
+	assertFile(t, path, "1\n00:00:01,000 --> 00:00:03,000\nHello\n\n2\n00:00:21,000 --> 00:00:23,000\nBye\n")
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Fatalf("expected no leftover temp files, got %d entries", len(entries))
+	}
+}
+
+// assertFile compares a file's content with want.
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(data) != want {
+		t.Fatalf("%s =\n%s\nwant\n%s", filepath.Base(path), data, want)
+	}
+}
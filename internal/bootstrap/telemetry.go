@@ -0,0 +1,120 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	goruntime "runtime"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/telemetry"
+)
+
+// telemetryCheckInterval is how often the loop looks whether a report is due.
+const telemetryCheckInterval = time.Hour
+
+// telemetryPeriod is the time covered by one report; one is sent per period.
+const telemetryPeriod = 7 * 24 * time.Hour
+
+// telemetrySendTimeout bounds posting one report.
+const telemetrySendTimeout = 30 * time.Second
+
+// telemetryLoop sends a report whenever one is due, for the lifetime of the
+// process. Failures are retried on the next check and never surface in the UI.
+func (a *App) telemetryLoop() {
+	ticker := time.NewTicker(telemetryCheckInterval)
+	defer ticker.Stop()
+	for {
+		a.guard("telemetry", func() { _ = a.sendTelemetry(time.Now()) })
+		<-ticker.C
+	}
+}
+
+// PreviewTelemetry returns the report that would be sent next, whether or not
+// telemetry is enabled.
+func (a *App) PreviewTelemetry() (domain.TelemetryReport, error) {
+	if a.Store == nil {
+		return domain.TelemetryReport{}, fmt.Errorf("settings store is not configured")
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.TelemetryReport{}, fmt.Errorf("load settings: %w", err)
+	}
+	state, err := a.telemetryState()
+	if err != nil {
+		return domain.TelemetryReport{}, err
+	}
+	return a.telemetryReport(settings, state, time.Now())
+}
+
+// sendTelemetry posts a report when telemetry is enabled with an endpoint
+// and a full period has passed since the last one, then records the time.
+func (a *App) sendTelemetry(now time.Time) error {
+	if a.Store == nil || a.Telemetry == nil {
+		return nil
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return fmt.Errorf("load settings: %w", err)
+	}
+	if !settings.Telemetry || settings.TelemetryURL == "" {
+		return nil
+	}
+	state, err := a.telemetryState()
+	if err != nil {
+		return err
+	}
+	if !state.LastSent.IsZero() && now.Sub(state.LastSent) < telemetryPeriod {
+		return nil
+	}
+
+	report, err := a.telemetryReport(settings, state, now)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), telemetrySendTimeout)
+	defer cancel()
+	if err := telemetry.Send(ctx, nil, settings.TelemetryURL, report); err != nil {
+		return fmt.Errorf("send telemetry: %w", err)
+	}
+	if err := a.Telemetry.Save(domain.TelemetryState{LastSent: now}); err != nil {
+		return fmt.Errorf("save telemetry state: %w", err)
+	}
+	return nil
+}
+
+// telemetryState loads when the last report was sent; the zero state when
+// no store is configured.
+func (a *App) telemetryState() (domain.TelemetryState, error) {
+	if a.Telemetry == nil {
+		return domain.TelemetryState{}, nil
+	}
+	state, err := a.Telemetry.Load()
+	if err != nil {
+		return domain.TelemetryState{}, fmt.Errorf("load telemetry state: %w", err)
+	}
+	return state, nil
+}
+
+// telemetryReport summarizes jobs finished since the last report, or during
+// the last period when none was sent yet.
+func (a *App) telemetryReport(settings domain.Settings, state domain.TelemetryState, now time.Time) (domain.TelemetryReport, error) {
+	since := state.LastSent
+	if since.IsZero() {
+		since = now.Add(-telemetryPeriod)
+	}
+	var entries []domain.HistoryEntry
+	if a.History != nil {
+		loaded, err := a.History.Load()
+		if err != nil {
+			return domain.TelemetryReport{}, fmt.Errorf("load history: %w", err)
+		}
+		entries = loaded
+	}
+	return telemetry.Build(entries, since, now, telemetry.Environment{
+		AppVersion: Version,
+		OS:         goruntime.GOOS,
+		Arch:       goruntime.GOARCH,
+		ModelPath:  settings.ModelPath,
+	}), nil
+}
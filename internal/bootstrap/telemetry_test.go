@@ -0,0 +1,72 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+)
+
+// TestSendTelemetryIsOptInAndWeekly sends only when enabled, at most once per
+// period, and the body matches the preview.
+func TestSendTelemetryIsOptInAndWeekly(t *testing.T) {
+	var bodies []domain.TelemetryReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report domain.TelemetryReport
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &report)
+		bodies = append(bodies, report)
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	now := time.Now()
+	store := &fakeStore{settings: domain.Settings{TelemetryURL: server.URL, ModelPath: "/models/ggml-small.bin"}}
+	app := &App{
+		Store:     store,
+		History:   config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Telemetry: config.NewJSONTelemetryStore(filepath.Join(root, "telemetry.json")),
+	}
+	if err := app.History.Save([]domain.HistoryEntry{
+		{ID: "a", InputPath: "/private/a.mp3", Status: domain.JobStatusDone, FinishedAt: now.Add(-time.Hour)},
+		{ID: "b", Status: domain.JobStatusFailed, Error: "exporting: failed to write chapter transcripts", FinishedAt: now.Add(-2 * time.Hour)},
+	}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	if err := app.sendTelemetry(now); err != nil || len(bodies) != 0 {
+		t.Fatalf("disabled telemetry sent %d reports, err = %v", len(bodies), err)
+	}
+
+	store.settings.Telemetry = true
+	preview, err := app.PreviewTelemetry()
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if preview.ModelClass != "small" || preview.JobsDone != 1 || preview.Failures["exporting"] != 1 {
+		t.Fatalf("preview = %+v", preview)
+	}
+	if err := app.sendTelemetry(now); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if err := app.sendTelemetry(now.Add(time.Hour)); err != nil {
+		t.Fatalf("second send: %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Fatalf("sent %d reports, want 1 per period", len(bodies))
+	}
+	got, _ := json.Marshal(bodies[0])
+	want, _ := json.Marshal(preview)
+	if string(got) != string(want) {
+		t.Fatalf("sent %s, preview %s", got, want)
+	}
+	if err := app.sendTelemetry(now.Add(telemetryPeriod)); err != nil || len(bodies) != 2 {
+		t.Fatalf("next period: %d reports, err = %v", len(bodies), err)
+	}
+}
@@ -0,0 +1,73 @@
+package bootstrap
+
+import (
+	"strings"
+)
+
+// GetTerms returns the terminology list of the named profile, or of the
+// current settings when profile is empty.
+func (a *App) GetTerms(profile string) ([]string, error) {
+	if strings.TrimSpace(profile) == "" {
+		settings, err := a.GetSettings()
+		if err != nil {
+			return nil, err
+		}
+		return normalizeTerms(settings.Terms), nil
+	}
+	loaded, err := a.loadProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeTerms(loaded.Settings.Terms), nil
+}
+
+// SaveTerms replaces the terminology list of the named profile, or of the
+// current settings when profile is empty, and returns the normalized list.
+// Saving the active profile's terms updates the current settings too.
+func (a *App) SaveTerms(profile string, terms []string) ([]string, error) {
+	terms = normalizeTerms(terms)
+	current, err := a.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(profile) == "" {
+		current.Terms = terms
+		if _, err := a.SaveSettings(current); err != nil {
+			return nil, err
+		}
+		return terms, nil
+	}
+
+	loaded, err := a.loadProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	loaded.Settings.Terms = terms
+	if _, err := a.SaveProfile(loaded.Name, loaded.Settings); err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(current.ActiveProfile, loaded.Name) {
+		current.Terms = terms
+		if _, err := a.SaveSettings(current); err != nil {
+			return nil, err
+		}
+	}
+	return terms, nil
+}
+
+// normalizeTerms collapses whitespace in terms and drops blanks and
+// case-insensitive duplicates, keeping the first spelling.
+func normalizeTerms(terms []string) []string {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, term := range terms {
+		term = strings.Join(strings.Fields(term), " ")
+		key := strings.ToLower(term)
+		if term == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, term)
+	}
+	return normalized
+}
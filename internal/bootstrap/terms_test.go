@@ -0,0 +1,52 @@
+package bootstrap
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+)
+
+// TestSaveTermsUpdatesProfileAndActiveSettings stores a cleaned term list on
+// a profile and mirrors it into the current settings when that profile is
+// active.
+func TestSaveTermsUpdatesProfileAndActiveSettings(t *testing.T) {
+	root := t.TempDir()
+	store := config.NewJSONStore(filepath.Join(root, "settings.json"))
+	if err := store.Save(domain.Settings{ActiveProfile: "Podcasts"}); err != nil {
+		t.Fatalf("save settings: %v", err)
+	}
+	app := &App{Store: store, Profiles: config.NewJSONProfileStore(filepath.Join(root, "profiles.json"))}
+	for _, name := range []string{"Podcasts", "Interviews"} {
+		if _, err := app.SaveProfile(name, domain.Settings{}); err != nil {
+			t.Fatalf("save profile: %v", err)
+		}
+	}
+
+	terms, err := app.SaveTerms("podcasts", []string{" Kubernetes ", "", "kubernetes", "New  York"})
+	if err != nil {
+		t.Fatalf("save terms: %v", err)
+	}
+	want := []string{"Kubernetes", "New York"}
+	if !reflect.DeepEqual(terms, want) {
+		t.Fatalf("terms = %v, want %v", terms, want)
+	}
+	for _, profile := range []string{"Podcasts", ""} {
+		got, err := app.GetTerms(profile)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Fatalf("GetTerms(%q) = %v, %v, want %v", profile, got, err, want)
+		}
+	}
+
+	if _, err := app.SaveTerms("Interviews", []string{"gRPC"}); err != nil {
+		t.Fatalf("save terms: %v", err)
+	}
+	if got, _ := app.GetTerms(""); !reflect.DeepEqual(got, want) {
+		t.Fatalf("inactive profile changed current terms to %v", got)
+	}
+	if _, err := app.SaveTerms("Lectures", nil); err == nil {
+		t.Fatal("saving terms of an unknown profile should fail")
+	}
+}
@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"media-transcriber/internal/config"
+)
+
+// tokenUsage describes the `token` command.
+const tokenUsage = `usage:
+  media-transcriber token create -name NAME -scope read,submit,admin
+  media-transcriber token list
+  media-transcriber token revoke ID|NAME`
+
+// TokenCommand manages server-mode API tokens from the command line without
+// starting the app: `token create`, `token list`, and `token revoke`.
+func TokenCommand(args []string, out io.Writer) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("resolve user home: %w", err)
+	}
+	paths := config.ResolvePaths(homeDir)
+	app := &App{Tokens: config.NewJSONTokenStore(filepath.Join(paths.Config, "api-tokens.json"))}
+	return app.runTokenCommand(args, out)
+}
+
+// runTokenCommand executes one token subcommand against the app's token store.
+func (a *App) runTokenCommand(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%s", tokenUsage)
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("token create", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		name := flags.String("name", "", "token name")
+		scopes := flags.String("scope", "read", "comma-separated scopes")
+		if err := flags.Parse(args[1:]); err != nil {
+			return fmt.Errorf("%v\n%s", err, tokenUsage)
+		}
+		created, err := a.CreateAPIToken(*name, strings.Split(*scopes, ","))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Created token %s (%s) with scopes %s.\n", created.Token.ID, created.Token.Name, *scopes)
+		fmt.Fprintf(out, "Secret (shown only once): %s\n", created.Secret)
+		return nil
+	case "list":
+		tokens, err := a.ListAPITokens()
+		if err != nil {
+			return err
+		}
+		writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "ID\tNAME\tSCOPES\tCREATED")
+		for _, token := range tokens {
+			scopes := make([]string, 0, len(token.Scopes))
+			for _, scope := range token.Scopes {
+				scopes = append(scopes, string(scope))
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", token.ID, token.Name, strings.Join(scopes, ","), token.CreatedAt.Format("2006-01-02 15:04"))
+		}
+		return writer.Flush()
+	case "revoke":
+		if len(args) != 2 {
+			return fmt.Errorf("%s", tokenUsage)
+		}
+		if err := a.RevokeAPIToken(args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Revoked token %s.\n", args[1])
+		return nil
+	default:
+		return fmt.Errorf("unknown token command %q\n%s", args[0], tokenUsage)
+	}
+}
@@ -0,0 +1,238 @@
+package bootstrap
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// tokenSecretPrefix marks media-transcriber API token secrets.
+const tokenSecretPrefix = "mt_"
+
+// errUnauthorized is returned when a request carries no valid token.
+var errUnauthorized = errors.New("missing or invalid API token")
+
+// errNoTokens is returned for admin endpoints before any token exists.
+var errNoTokens = errors.New("admin endpoints are disabled until a token is created with `media-transcriber token create`")
+
+// errRemoteWithoutTokens is returned for clients on other hosts before any
+// token exists.
+var errRemoteWithoutTokens = errors.New("only local clients may use the API until a token is created with `media-transcriber token create`")
+
+// CreateAPIToken creates a server-mode API token with the given scopes
+// ("read", "submit", "admin"). The secret is returned only this once.
+func (a *App) CreateAPIToken(name string, scopes []string) (domain.CreatedAPIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.CreatedAPIToken{}, fmt.Errorf("token name is required")
+	}
+	parsed, err := parseTokenScopes(scopes)
+	if err != nil {
+		return domain.CreatedAPIToken{}, err
+	}
+	if a.Tokens == nil {
+		return domain.CreatedAPIToken{}, fmt.Errorf("token store is not configured")
+	}
+
+	// The ID is listed and logged, so it shares no bytes with the secret.
+	random, id := make([]byte, 32), make([]byte, 4)
+	if _, err := rand.Read(random); err != nil {
+		return domain.CreatedAPIToken{}, fmt.Errorf("generate token: %w", err)
+	}
+	if _, err := rand.Read(id); err != nil {
+		return domain.CreatedAPIToken{}, fmt.Errorf("generate token ID: %w", err)
+	}
+	secret := tokenSecretPrefix + hex.EncodeToString(random)
+	token := domain.APIToken{
+		ID:        "tok-" + hex.EncodeToString(id),
+		Name:      name,
+		Scopes:    parsed,
+		Hash:      hashTokenSecret(secret),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	a.tokensMu.Lock()
+	defer a.tokensMu.Unlock()
+	tokens, err := a.Tokens.Load()
+	if err != nil {
+		return domain.CreatedAPIToken{}, fmt.Errorf("load tokens: %w", err)
+	}
+	for _, existing := range tokens {
+		if strings.EqualFold(existing.Name, name) {
+			return domain.CreatedAPIToken{}, fmt.Errorf("a token named %q already exists", name)
+		}
+	}
+	if err := a.Tokens.Save(append(tokens, token)); err != nil {
+		return domain.CreatedAPIToken{}, fmt.Errorf("save tokens: %w", err)
+	}
+	token.Hash = ""
+	return domain.CreatedAPIToken{Token: token, Secret: secret}, nil
+}
+
+// ListAPITokens returns the stored tokens; neither secrets nor their hashes
+// are included.
+func (a *App) ListAPITokens() ([]domain.APIToken, error) {
+	if a.Tokens == nil {
+		return nil, nil
+	}
+	tokens, err := a.Tokens.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load tokens: %w", err)
+	}
+	for i := range tokens {
+		tokens[i].Hash = ""
+	}
+	return tokens, nil
+}
+
+// RevokeAPIToken deletes the token with the given ID or name.
+func (a *App) RevokeAPIToken(idOrName string) error {
+	if a.Tokens == nil {
+		return fmt.Errorf("token store is not configured")
+	}
+	a.tokensMu.Lock()
+	defer a.tokensMu.Unlock()
+	tokens, err := a.Tokens.Load()
+	if err != nil {
+		return fmt.Errorf("load tokens: %w", err)
+	}
+	for i, token := range tokens {
+		if token.ID == idOrName || strings.EqualFold(token.Name, idOrName) {
+			if err := a.Tokens.Save(append(tokens[:i], tokens[i+1:]...)); err != nil {
+				return fmt.Errorf("save tokens: %w", err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no API token %q", idOrName)
+}
+
+// parseTokenScopes validates scope names and removes duplicates.
+func parseTokenScopes(scopes []string) ([]domain.TokenScope, error) {
+	var parsed []domain.TokenScope
+	seen := map[domain.TokenScope]bool{}
+	for _, raw := range scopes {
+		scope := domain.TokenScope(strings.ToLower(strings.TrimSpace(raw)))
+		switch scope {
+		case domain.TokenScopeRead, domain.TokenScopeSubmit, domain.TokenScopeAdmin:
+		default:
+			return nil, fmt.Errorf("unknown token scope %q", raw)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			parsed = append(parsed, scope)
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("at least one token scope is required")
+	}
+	return parsed, nil
+}
+
+// hashTokenSecret returns the hex SHA-256 stored in place of a secret.
+func hashTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// requireScope wraps next so it only runs for requests whose bearer token
+// grants scope.
+func (a *App) requireScope(scope domain.TokenScope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := a.authorize(scope, r.Header.Get("Authorization"), r.RemoteAddr)
+		var missing scopeError
+		switch {
+		case err == nil:
+			next(w, r)
+		case errors.Is(err, errUnauthorized):
+			w.Header().Set("WWW-Authenticate", `Bearer realm="media-transcriber"`)
+			writeError(w, http.StatusUnauthorized, err)
+		case errors.Is(err, errNoTokens), errors.Is(err, errRemoteWithoutTokens), errors.As(err, &missing):
+			writeError(w, http.StatusForbidden, err)
+		default:
+			writeError(w, http.StatusInternalServerError, err)
+		}
+	}
+}
+
+// scopeError reports a valid token that lacks the required scope.
+type scopeError struct {
+	name  string
+	scope domain.TokenScope
+}
+
+func (e scopeError) Error() string {
+	return fmt.Sprintf("token %q lacks the %s scope", e.name, e.scope)
+}
+
+// authorize checks that authorization, a "Bearer <secret>" header or gRPC
+// metadata value, grants scope to the client at remoteAddr. Authentication
+// is enforced once at least one token exists; until then a fresh server
+// stays usable from the machine it runs on, but clients on other hosts are
+// refused, so an unconfigured server on a network queues nothing for them.
+// The admin scope is never open: without tokens it is refused, so nobody
+// who can reach the server can mint the first token themselves; that one
+// comes from the `token` command on the server.
+func (a *App) authorize(scope domain.TokenScope, authorization, remoteAddr string) error {
+	if a.Tokens == nil {
+		return nil
+	}
+	tokens, err := a.Tokens.Load()
+	if err != nil {
+		return fmt.Errorf("load tokens: %w", err)
+	}
+	if len(tokens) == 0 {
+		if scope == domain.TokenScopeAdmin {
+			return errNoTokens
+		}
+		if !isLocalAddr(remoteAddr) {
+			return errRemoteWithoutTokens
+		}
+		return nil
+	}
+
+	secret, ok := strings.CutPrefix(authorization, "Bearer ")
+	token, found := matchToken(tokens, strings.TrimSpace(secret))
+	if !ok || !found {
+		return errUnauthorized
+	}
+	if !token.Allows(scope) {
+		return scopeError{name: token.Name, scope: scope}
+	}
+	return nil
+}
+
+// isLocalAddr reports whether remoteAddr, a host:port or bare host, is on
+// this machine: a loopback IP, or no IP at all, as for Unix sockets and
+// in-memory connections.
+func isLocalAddr(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip == nil || ip.IsLoopback()
+}
+
+// matchToken finds the token whose hash matches secret in constant time per token.
+func matchToken(tokens []domain.APIToken, secret string) (domain.APIToken, bool) {
+	if secret == "" {
+		return domain.APIToken{}, false
+	}
+	hash := []byte(hashTokenSecret(secret))
+	for _, token := range tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(token.Hash)) == 1 {
+			return token, true
+		}
+	}
+	return domain.APIToken{}, false
+}
@@ -0,0 +1,172 @@
+package bootstrap
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// TestAPITokenLifecycle creates, lists, and revokes tokens without storing secrets.
+func TestAPITokenLifecycle(t *testing.T) {
+	app := &App{Tokens: config.NewJSONTokenStore(filepath.Join(t.TempDir(), "api-tokens.json"))}
+
+	created, err := app.CreateAPIToken("ci", []string{"submit", " READ ", "submit"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.HasPrefix(created.Secret, tokenSecretPrefix) || created.Token.Hash != "" {
+		t.Fatalf("created = %+v", created)
+	}
+	if id := strings.TrimPrefix(created.Token.ID, "tok-"); strings.Contains(created.Secret, id) {
+		t.Fatalf("token ID %s is part of the secret", created.Token.ID)
+	}
+	if stored, err := app.Tokens.Load(); err != nil || len(stored) != 1 || stored[0].Hash != hashTokenSecret(created.Secret) {
+		t.Fatalf("stored = %+v, %v; want the secret hash", stored, err)
+	}
+	if len(created.Token.Scopes) != 2 || !created.Token.Allows(domain.TokenScopeRead) || created.Token.Allows(domain.TokenScopeAdmin) {
+		t.Fatalf("scopes = %v", created.Token.Scopes)
+	}
+
+	for name, scopes := range map[string][]string{"no scopes": nil, "unknown scope": {"write"}} {
+		if _, err := app.CreateAPIToken("other", scopes); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+	if _, err := app.CreateAPIToken("CI", []string{"read"}); err == nil {
+		t.Fatal("expected error for duplicate name")
+	}
+
+	tokens, err := app.ListAPITokens()
+	if err != nil || len(tokens) != 1 || tokens[0].Hash != "" {
+		t.Fatalf("tokens = %+v, %v", tokens, err)
+	}
+	if err := app.RevokeAPIToken("ci"); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := app.RevokeAPIToken(created.Token.ID); err == nil {
+		t.Fatal("expected error revoking a missing token")
+	}
+}
+
+// TestServerRequiresTokenScopes checks 401/403 handling once a token exists.
+func TestServerRequiresTokenScopes(t *testing.T) {
+	app := &App{
+		Tokens: config.NewJSONTokenStore(filepath.Join(t.TempDir(), "api-tokens.json")),
+		Jobs:   jobs.NewManager(),
+		Queue:  jobs.NewQueue(),
+		events: jobs.NewEventBus(10),
+	}
+	handler := app.serverHandler()
+	call := func(method, path, secret string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.RemoteAddr = "127.0.0.1:41234"
+		if secret != "" {
+			req.Header.Set("Authorization", "Bearer "+secret)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	if got := call("GET", "/api/queue", ""); got != http.StatusOK {
+		t.Fatalf("open server = %d, want 200 before any token exists", got)
+	}
+	// Until then only local clients get in; a fresh server on a network
+	// must not take jobs from other hosts.
+	for _, remote := range []string{"192.0.2.1:1234", "[2001:db8::1]:1234"} {
+		for _, path := range []string{"/api/queue", "/api/jobs"} {
+			method := "GET"
+			if path == "/api/jobs" {
+				method = "POST"
+			}
+			req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+			req.RemoteAddr = remote
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusForbidden {
+				t.Fatalf("%s %s from %s on open server = %d, want 403", method, path, remote, recorder.Code)
+			}
+		}
+	}
+	if got := call("GET", "/api/queue", ""); got != http.StatusOK {
+		t.Fatalf("local client = %d, want 200", got)
+	}
+	// Without tokens nobody may mint the first admin token over the network.
+	mint := httptest.NewRequest("POST", "/api/tokens", strings.NewReader(`{"name":"intruder","scopes":["admin"]}`))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, mint)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("create token on open server = %d, want 403", recorder.Code)
+	}
+	if tokens, _ := app.ListAPITokens(); len(tokens) != 0 {
+		t.Fatalf("tokens = %+v, want none", tokens)
+	}
+
+	reader, err := app.CreateAPIToken("dashboard", []string{"read"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	admin, err := app.CreateAPIToken("ops", []string{"admin"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	tests := []struct {
+		name, method, path, secret string
+		want                       int
+	}{
+		{name: "health stays open", method: "GET", path: "/healthz", want: http.StatusOK},
+		{name: "missing token", method: "GET", path: "/api/queue", want: http.StatusUnauthorized},
+		{name: "wrong token", method: "GET", path: "/api/queue", secret: "mt_nope", want: http.StatusUnauthorized},
+		{name: "read token reads", method: "GET", path: "/api/queue", secret: reader.Secret, want: http.StatusOK},
+		{name: "read token reads metrics", method: "GET", path: "/metrics", secret: reader.Secret, want: http.StatusOK},
+		{name: "read token cannot submit", method: "POST", path: "/api/jobs", secret: reader.Secret, want: http.StatusForbidden},
+		{name: "read token cannot list tokens", method: "GET", path: "/api/tokens", secret: reader.Secret, want: http.StatusForbidden},
+		{name: "admin submits", method: "POST", path: "/api/jobs", secret: admin.Secret, want: http.StatusBadRequest},
+		{name: "admin lists tokens", method: "GET", path: "/api/tokens", secret: admin.Secret, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := call(tt.method, tt.path, tt.secret); got != tt.want {
+				t.Fatalf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTokenCommand drives create, list, and revoke from the command line.
+func TestTokenCommand(t *testing.T) {
+	app := &App{Tokens: config.NewJSONTokenStore(filepath.Join(t.TempDir(), "api-tokens.json"))}
+	var out bytes.Buffer
+
+	if err := app.runTokenCommand([]string{"create", "-name", "laptop", "-scope", "read,submit"}, &out); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !strings.Contains(out.String(), "Secret (shown only once): mt_") {
+		t.Fatalf("create output = %q", out.String())
+	}
+
+	out.Reset()
+	if err := app.runTokenCommand([]string{"list"}, &out); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if !strings.Contains(out.String(), "laptop") || !strings.Contains(out.String(), "read,submit") {
+		t.Fatalf("list output = %q", out.String())
+	}
+
+	if err := app.runTokenCommand([]string{"revoke", "laptop"}, &out); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	for _, args := range [][]string{nil, {"rotate"}, {"revoke"}, {"create", "-bogus"}} {
+		if err := app.runTokenCommand(args, &out); err == nil {
+			t.Fatalf("args %v: expected error", args)
+		}
+	}
+}
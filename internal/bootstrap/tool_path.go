@@ -0,0 +1,108 @@
+package bootstrap
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"media-transcriber/internal/domain"
+)
+
+// ToolPathSetup reports whether the app's tool directory, where whisper
+// aliases are installed, is on the PATH of new terminals. On Unix it offers
+// the line to add to each shell's profile; the app never edits profiles.
+func (a *App) ToolPathSetup() (domain.ToolPathSetup, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return domain.ToolPathSetup{}, fmt.Errorf("resolve user home: %w", err)
+	}
+	return toolPathSetup(homeDir)
+}
+
+// PersistToolPath adds the tool directory to the Windows user PATH, so
+// aliases installed by the app work in other terminals and after a restart.
+// On Unix the snippets of ToolPathSetup go into a shell profile instead.
+func (a *App) PersistToolPath() (domain.ToolPathSetup, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return domain.ToolPathSetup{}, fmt.Errorf("resolve user home: %w", err)
+	}
+	if _, err := persistUserPath(localBinDir(homeDir)); err != nil {
+		return domain.ToolPathSetup{}, fmt.Errorf("update user PATH: %w", err)
+	}
+	return toolPathSetup(homeDir)
+}
+
+// toolPathSetup describes the tool directory of homeDir.
+func toolPathSetup(homeDir string) (domain.ToolPathSetup, error) {
+	setup := domain.ToolPathSetup{Dir: localBinDir(homeDir)}
+	if goruntime.GOOS == "windows" {
+		persisted, err := userPathContains(setup.Dir)
+		if err != nil {
+			return domain.ToolPathSetup{}, fmt.Errorf("read user PATH: %w", err)
+		}
+		setup.Persisted = persisted
+		return setup, nil
+	}
+	setup.Snippets = shellSnippets(setup.Dir, homeDir)
+	for i, snippet := range setup.Snippets {
+		data, err := os.ReadFile(snippet.Profile)
+		if err == nil && strings.Contains(string(data), setup.Dir) {
+			setup.Snippets[i].Installed = true
+			setup.Persisted = true
+		}
+	}
+	return setup, nil
+}
+
+// shellSnippets returns the profile line that puts dir on PATH for each
+// common Unix shell.
+func shellSnippets(dir, homeDir string) []domain.ShellSnippet {
+	quoted := shellQuote(dir)
+	export := "export PATH=" + quoted + `:"$PATH"`
+	return []domain.ShellSnippet{
+		{Shell: "sh", Profile: filepath.Join(homeDir, ".profile"), Snippet: export},
+		{Shell: "bash", Profile: filepath.Join(homeDir, ".bashrc"), Snippet: export},
+		{Shell: "zsh", Profile: filepath.Join(homeDir, ".zshrc"), Snippet: export},
+		{Shell: "fish", Profile: filepath.Join(homeDir, ".config", "fish", "config.fish"), Snippet: "fish_add_path " + quoted},
+	}
+}
+
+// shellQuote single-quotes s for POSIX shells and fish.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// pathListContains reports whether list, a PATH value, has dir as an entry.
+// Windows compares case-insensitively and ignores trailing separators.
+func pathListContains(list, dir string, windows bool) bool {
+	normalize := func(entry string) string {
+		entry = strings.TrimRight(strings.TrimSpace(entry), `\/`)
+		if windows {
+			return strings.ToLower(entry)
+		}
+		return entry
+	}
+	want := normalize(dir)
+	separator := string(os.PathListSeparator)
+	if windows {
+		separator = ";"
+	}
+	for _, entry := range strings.Split(list, separator) {
+		if normalize(entry) == want && want != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUserPath appends dir to the Windows PATH value list.
+func appendUserPath(list, dir string) string {
+	list = strings.TrimRight(list, ";")
+	if list == "" {
+		return dir
+	}
+	return list + ";" + dir
+}
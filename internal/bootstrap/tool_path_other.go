@@ -0,0 +1,16 @@
+//go:build !windows
+
+package bootstrap
+
+import "errors"
+
+// userPathContains reports false: only Windows has a user PATH to read.
+func userPathContains(string) (bool, error) {
+	return false, nil
+}
+
+// persistUserPath fails: Unix PATHs live in shell profiles, which the app
+// leaves to the user.
+func persistUserPath(string) (bool, error) {
+	return false, errors.New("only the Windows user PATH can be updated; add the line for your shell to its profile")
+}
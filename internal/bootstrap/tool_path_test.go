@@ -0,0 +1,101 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+)
+
+// TestPathListContains matches PATH entries, loosely on Windows.
+func TestPathListContains(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		dir     string
+		windows bool
+		want    bool
+	}{
+		{name: "windows case and slash", list: `C:\Windows;C:\Users\Ann\.media-transcriber\BIN\`, dir: `c:\users\ann\.media-transcriber\bin`, windows: true, want: true},
+		{name: "windows missing", list: `C:\Windows;C:\Tools`, dir: `C:\Users\Ann\.media-transcriber\bin`, windows: true},
+		{name: "windows empty", list: "", dir: `C:\bin`, windows: true},
+		{name: "unix exact", list: "/usr/bin:/home/ann/.local/share/media-transcriber/bin/", dir: "/home/ann/.local/share/media-transcriber/bin", want: true},
+		{name: "unix case matters", list: "/usr/bin:/home/ann/BIN", dir: "/home/ann/bin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.windows && goruntime.GOOS == "windows" {
+				t.Skip("Unix PATH separator")
+			}
+			if got := pathListContains(tt.list, tt.dir, tt.windows); got != tt.want {
+				t.Fatalf("pathListContains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAppendUserPath adds the directory after existing entries.
+func TestAppendUserPath(t *testing.T) {
+	tests := []struct{ list, want string }{
+		{list: "", want: `C:\bin`},
+		{list: `%USERPROFILE%\AppData\Local\Microsoft\WindowsApps;`, want: `%USERPROFILE%\AppData\Local\Microsoft\WindowsApps;C:\bin`},
+		{list: `C:\Tools`, want: `C:\Tools;C:\bin`},
+	}
+	for _, tt := range tests {
+		if got := appendUserPath(tt.list, `C:\bin`); got != tt.want {
+			t.Fatalf("appendUserPath(%q) = %q, want %q", tt.list, got, tt.want)
+		}
+	}
+}
+
+// TestShellSnippets quotes the directory for each shell.
+func TestShellSnippets(t *testing.T) {
+	snippets := shellSnippets("/home/o'neil/bin", "/home/o'neil")
+	want := map[string]string{
+		"sh":   `export PATH='/home/o'\''neil/bin':"$PATH"`,
+		"bash": `export PATH='/home/o'\''neil/bin':"$PATH"`,
+		"zsh":  `export PATH='/home/o'\''neil/bin':"$PATH"`,
+		"fish": `fish_add_path '/home/o'\''neil/bin'`,
+	}
+	if len(snippets) != len(want) {
+		t.Fatalf("snippets = %+v", snippets)
+	}
+	for _, snippet := range snippets {
+		if snippet.Snippet != want[snippet.Shell] {
+			t.Fatalf("%s snippet = %q, want %q", snippet.Shell, snippet.Snippet, want[snippet.Shell])
+		}
+	}
+}
+
+// TestToolPathSetupFindsProfiles reports shells whose profile already puts
+// the tool directory on PATH.
+func TestToolPathSetupFindsProfiles(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("Windows uses the user PATH")
+	}
+	home := t.TempDir()
+	setup, err := toolPathSetup(home)
+	if err != nil {
+		t.Fatalf("toolPathSetup() error = %v", err)
+	}
+	if setup.Persisted || setup.Dir != localBinDir(home) {
+		t.Fatalf("setup = %+v, want the tool directory not persisted", setup)
+	}
+
+	mustWrite(t, filepath.Join(home, ".zshrc"), "alias ll='ls -l'\n"+setup.Snippets[2].Snippet+"\n")
+	setup, err = toolPathSetup(home)
+	if err != nil {
+		t.Fatalf("toolPathSetup() error = %v", err)
+	}
+	if !setup.Persisted {
+		t.Fatal("Persisted = false after adding the zsh snippet")
+	}
+	for _, snippet := range setup.Snippets {
+		if snippet.Installed != (snippet.Shell == "zsh") {
+			t.Fatalf("%s installed = %v", snippet.Shell, snippet.Installed)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(home, ".bashrc")); !os.IsNotExist(err) {
+		t.Fatalf("profiles were written: %v", err)
+	}
+}
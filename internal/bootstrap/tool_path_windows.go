@@ -0,0 +1,108 @@
+package bootstrap
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	procRegSetValueExW      = syscall.NewLazyDLL("advapi32.dll").NewProc("RegSetValueExW")
+	procSendMessageTimeoutW = syscall.NewLazyDLL("user32.dll").NewProc("SendMessageTimeoutW")
+)
+
+const (
+	// hwndBroadcast is HWND_BROADCAST.
+	hwndBroadcast = 0xffff
+	// wmSettingChange is WM_SETTINGCHANGE.
+	wmSettingChange = 0x001a
+	// smtoAbortIfHung is SMTO_ABORTIFHUNG.
+	smtoAbortIfHung = 0x0002
+	// settingChangeTimeoutMs bounds how long one window may take to handle the broadcast.
+	settingChangeTimeoutMs = 5000
+)
+
+// userEnvironmentKey is the registry key of the user's environment variables.
+const userEnvironmentKey = "Environment"
+
+// userPathContains reports whether dir is on the user PATH in the registry.
+func userPathContains(dir string) (bool, error) {
+	value, _, err := readUserPath()
+	if err != nil {
+		return false, err
+	}
+	return pathListContains(value, dir, true), nil
+}
+
+// persistUserPath appends dir to the user PATH in the registry and tells
+// running programs, such as Explorer, so terminals opened afterwards see it.
+// It reports whether the PATH changed.
+func persistUserPath(dir string) (bool, error) {
+	value, valueType, err := readUserPath()
+	if err != nil {
+		return false, err
+	}
+	if pathListContains(value, dir, true) {
+		return false, nil
+	}
+
+	key, err := openUserEnvironment(syscall.KEY_SET_VALUE)
+	if err != nil {
+		return false, err
+	}
+	defer syscall.RegCloseKey(key)
+	data, err := syscall.UTF16FromString(appendUserPath(value, dir))
+	if err != nil {
+		return false, err
+	}
+	name, _ := syscall.UTF16PtrFromString("Path")
+	ret, _, _ := procRegSetValueExW.Call(uintptr(key), uintptr(unsafe.Pointer(name)), 0, uintptr(valueType),
+		uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)*2))
+	if ret != 0 {
+		return false, syscall.Errno(ret)
+	}
+
+	environment, _ := syscall.UTF16PtrFromString(userEnvironmentKey)
+	var result uintptr
+	_, _, _ = procSendMessageTimeoutW.Call(hwndBroadcast, wmSettingChange, 0, uintptr(unsafe.Pointer(environment)),
+		smtoAbortIfHung, settingChangeTimeoutMs, uintptr(unsafe.Pointer(&result)))
+	return true, nil
+}
+
+// readUserPath returns the user PATH and its registry type; a missing value
+// is empty and, like the one Windows creates, REG_EXPAND_SZ.
+func readUserPath() (string, uint32, error) {
+	key, err := openUserEnvironment(syscall.KEY_QUERY_VALUE)
+	if err != nil {
+		return "", 0, err
+	}
+	defer syscall.RegCloseKey(key)
+
+	name, _ := syscall.UTF16PtrFromString("Path")
+	var valueType, size uint32
+	err = syscall.RegQueryValueEx(key, name, nil, &valueType, nil, &size)
+	if errors.Is(err, syscall.ERROR_FILE_NOT_FOUND) {
+		return "", syscall.REG_EXPAND_SZ, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if valueType != syscall.REG_SZ && valueType != syscall.REG_EXPAND_SZ {
+		return "", 0, errors.New("user PATH is not a string")
+	}
+	buffer := make([]uint16, size/2+1)
+	if err := syscall.RegQueryValueEx(key, name, nil, &valueType, (*byte)(unsafe.Pointer(&buffer[0])), &size); err != nil {
+		return "", 0, err
+	}
+	return syscall.UTF16ToString(buffer), valueType, nil
+}
+
+// openUserEnvironment opens HKEY_CURRENT_USER\Environment with access.
+func openUserEnvironment(access uint32) (syscall.Handle, error) {
+	path, _ := syscall.UTF16PtrFromString(userEnvironmentKey)
+	var key syscall.Handle
+	if err := syscall.RegOpenKeyEx(syscall.HKEY_CURRENT_USER, path, 0, access, &key); err != nil {
+		return 0, err
+	}
+	return key, nil
+}
@@ -0,0 +1,290 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/textenc"
+	"media-transcriber/internal/transcribe"
+)
+
+// transcribeUsage describes the `transcribe` command.
+const transcribeUsage = `usage:
+  media-transcriber transcribe [flags] FILE
+  some-recorder | media-transcriber transcribe [-name NAME] [flags] -
+
+-json prints a report of the job instead of the transcript. Exit codes:
+1 other error, 2 usage, 3 input, 4 model, 5 ffmpeg, 6 whisper, 7 export,
+130 cancelled.`
+
+// Exit codes of the transcribe command, distinct per failure for scripting.
+const (
+	ExitFailure   = 1
+	ExitUsage     = 2
+	ExitInput     = 3
+	ExitModel     = 4
+	ExitFFmpeg    = 5
+	ExitWhisper   = 6
+	ExitExport    = 7
+	ExitCancelled = 130
+)
+
+// jobPollInterval is how often the transcribe command checks for the end of its job.
+const jobPollInterval = 100 * time.Millisecond
+
+// failureExitCodes maps what a job failed on to its exit code.
+var failureExitCodes = map[domain.Failure]int{
+	domain.FailureInput:   ExitInput,
+	domain.FailureModel:   ExitModel,
+	domain.FailureFFmpeg:  ExitFFmpeg,
+	domain.FailureWhisper: ExitWhisper,
+	domain.FailureExport:  ExitExport,
+}
+
+// exitError is a command error with the process exit code it maps to.
+type exitError struct {
+	code int
+	err  error
+}
+
+// Error returns the underlying error message.
+func (e *exitError) Error() string { return e.err.Error() }
+
+// Unwrap exposes the underlying error for errors.Is / errors.As.
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode returns the process exit code for an error of TranscribeCommand:
+// 0 for nil, the code of its failure when known, and ExitFailure otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return ExitFailure
+}
+
+// transcribeReport is what -json prints: the outcome and outputs of the job.
+type transcribeReport struct {
+	JobID         string               `json:"jobId"`
+	Status        domain.JobStatus     `json:"status"`
+	InputPath     string               `json:"inputPath"`
+	TextPath      string               `json:"textPath,omitempty"`
+	SubtitlePaths []string             `json:"subtitlePaths,omitempty"`
+	ChapterPaths  []string             `json:"chapterPaths,omitempty"`
+	SegmentsPath  string               `json:"segmentsPath,omitempty"`
+	MetadataPath  string               `json:"metadataPath,omitempty"`
+	Language      string               `json:"language,omitempty"`
+	AudioSeconds  float64              `json:"audioSeconds,omitempty"`
+	Segments      *segmentsSummary     `json:"segments,omitempty"`
+	Stages        []domain.StageTiming `json:"stages,omitempty"`
+	Failure       domain.Failure       `json:"failure,omitempty"`
+	Error         string               `json:"error,omitempty"`
+	ExitCode      int                  `json:"exitCode"`
+}
+
+// segmentsSummary condenses the segments of a transcript.
+type segmentsSummary struct {
+	Count             int     `json:"count"`
+	Speakers          int     `json:"speakers,omitempty"`
+	StartMs           int64   `json:"startMs"`
+	EndMs             int64   `json:"endMs"`
+	AverageConfidence float64 `json:"averageConfidence"`
+}
+
+// TranscribeCommand transcribes one file without the window or the HTTP
+// server and prints the transcript, or with -json a report, to out; the
+// outputs are also exported as usual. The input "-" reads the media from in,
+// so recorders can pipe into it. An interrupt or SIGTERM cancels the job.
+// ExitCode maps the returned error to the process exit code.
+func TranscribeCommand(args []string, in io.Reader, out io.Writer) error {
+	options, err := config.LoadTranscribeOptions(args, os.Getenv)
+	if err != nil {
+		return &exitError{code: ExitUsage, err: fmt.Errorf("%v\n%s", err, transcribeUsage)}
+	}
+	app, err := newApp(nil, options.Overrides)
+	if err != nil {
+		return err
+	}
+	defer app.stopWhisperServer()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	return app.transcribeCommand(ctx, options, in, out)
+}
+
+// transcribeCommand runs one job for options and writes its transcript or
+// report to out.
+func (a *App) transcribeCommand(ctx context.Context, options config.TranscribeOptions, in io.Reader, out io.Writer) error {
+	inputPath := options.Input
+	if inputPath == config.StdinInput {
+		path, err := a.receiveStdin(in, options.Name)
+		if err != nil {
+			return &exitError{code: ExitInput, err: err}
+		}
+		inputPath = path
+	}
+
+	job, err := a.StartTranscription(inputPath)
+	if err != nil {
+		a.removeUpload(inputPath)
+		return err
+	}
+	a.waitJob(ctx, job.ID)
+
+	entry, err := a.findHistoryEntry(job.ID)
+	if err != nil {
+		return err
+	}
+	var jobErr error
+	if entry.Status != domain.JobStatusDone {
+		message := fmt.Sprintf("transcription %s", entry.Status)
+		if entry.Error != "" {
+			message += ": " + entry.Error
+		}
+		jobErr = &exitError{code: jobExitCode(entry), err: errors.New(message)}
+	}
+	if options.JSON {
+		report := newTranscribeReport(entry, options.Input)
+		report.ExitCode = ExitCode(jobErr)
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+		return jobErr
+	}
+	if jobErr != nil {
+		return jobErr
+	}
+	data, err := os.ReadFile(entry.TextPath)
+	if err != nil {
+		return fmt.Errorf("read transcript: %w", err)
+	}
+	_, err = io.WriteString(out, textenc.Decode(data))
+	return err
+}
+
+// jobExitCode returns the exit code of a job that did not complete.
+func jobExitCode(entry domain.HistoryEntry) int {
+	if entry.Status == domain.JobStatusCancelled {
+		return ExitCancelled
+	}
+	if code, ok := failureExitCodes[entry.Failure]; ok {
+		return code
+	}
+	return ExitFailure
+}
+
+// newTranscribeReport describes the job of entry; the segments are
+// summarized from its <name>.segments.json when that can be read.
+func newTranscribeReport(entry domain.HistoryEntry, inputPath string) transcribeReport {
+	report := transcribeReport{
+		JobID:         entry.ID,
+		Status:        entry.Status,
+		InputPath:     inputPath,
+		TextPath:      entry.TextPath,
+		SubtitlePaths: entry.SubtitlePaths,
+		ChapterPaths:  entry.ChapterPaths,
+		SegmentsPath:  entry.SegmentsPath,
+		MetadataPath:  entry.MetadataPath,
+		Language:      entry.Language,
+		AudioSeconds:  entry.AudioSeconds,
+		Stages:        entry.Stages,
+		Failure:       entry.Failure,
+		Error:         entry.Error,
+	}
+	if entry.SegmentsPath == "" {
+		return report
+	}
+	data, err := os.ReadFile(entry.SegmentsPath)
+	if err != nil {
+		return report
+	}
+	language, segments, err := transcribe.ParseSegments(data)
+	if err != nil || len(segments) == 0 {
+		return report
+	}
+	if language != "" {
+		report.Language = language
+	}
+	report.Segments = summarizeSegments(segments)
+	return report
+}
+
+// summarizeSegments counts segments and speakers and spans their timeline.
+func summarizeSegments(segments []transcribe.TranscriptSegment) *segmentsSummary {
+	summary := &segmentsSummary{Count: len(segments), StartMs: segments[0].StartMs}
+	speakers := map[string]bool{}
+	var confidence float64
+	for _, segment := range segments {
+		summary.StartMs = min(summary.StartMs, segment.StartMs)
+		summary.EndMs = max(summary.EndMs, segment.EndMs)
+		confidence += segment.Confidence
+		if segment.Speaker != "" {
+			speakers[segment.Speaker] = true
+		}
+	}
+	summary.Speakers = len(speakers)
+	summary.AverageConfidence = math.Round(confidence/float64(len(segments))*1000) / 1000
+	return summary
+}
+
+// receiveStdin stores media read from in as an upload named name, so it is
+// deleted once its job finishes like media uploaded in server mode. ffmpeg
+// recognizes the format from the content, so the name needs no extension.
+func (a *App) receiveStdin(in io.Reader, name string) (string, error) {
+	if a.uploadDir == "" {
+		return "", fmt.Errorf("uploads are not enabled")
+	}
+	dir := filepath.Join(a.uploadDir, fmt.Sprintf("upload-%d", time.Now().UnixNano()))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create upload directory: %w", err)
+	}
+	path := filepath.Join(dir, sanitizeFileName(name))
+	if err := writeUpload(path, in); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	return path, nil
+}
+
+// waitJob blocks until job jobID has finished, history and delivery included.
+// When ctx ends first the job is cancelled and given cancelGracePeriod to
+// record its outcome.
+func (a *App) waitJob(ctx context.Context, jobID string) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for a.isActiveJob(jobID) {
+		select {
+		case <-ctx.Done():
+			_ = a.CancelTranscription()
+			deadline := time.Now().Add(cancelGracePeriod)
+			for a.isActiveJob(jobID) && time.Now().Before(deadline) {
+				<-ticker.C
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// isActiveJob reports whether jobID is still running or finishing up.
+func (a *App) isActiveJob(jobID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.activeJobID == jobID
+}
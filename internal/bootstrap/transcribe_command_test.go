@@ -0,0 +1,182 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestTranscribeCommandReadsStdin stores piped media as an upload, prints
+// the transcript, and deletes the upload once the job is done.
+func TestTranscribeCommandReadsStdin(t *testing.T) {
+	root := t.TempDir()
+	outputDir := filepath.Join(root, "out")
+	var received, inputPath string
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: outputDir}},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			inputPath = req.InputPath
+			data, err := os.ReadFile(req.InputPath)
+			if err != nil {
+				return transcribe.Result{}, err
+			}
+			received = string(data)
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			textPath := transcribe.TranscriptPath(req.OutputDir, req.InputPath)
+			mustWrite(t, textPath, "hello from the pipe\n")
+			return transcribe.Result{TextPath: textPath, Transcript: "hello from the pipe"}, nil
+		}},
+		events:    jobs.NewEventBus(100),
+		uploadDir: filepath.Join(root, "uploads"),
+	}
+
+	var out strings.Builder
+	options := config.TranscribeOptions{Input: config.StdinInput, Name: "call"}
+	if err := app.transcribeCommand(context.Background(), options, strings.NewReader("RIFF audio"), &out); err != nil {
+		t.Fatalf("transcribeCommand() error = %v", err)
+	}
+
+	if received != "RIFF audio" || filepath.Base(inputPath) != "call" {
+		t.Fatalf("pipeline read %q from %q, want stdin as call", received, inputPath)
+	}
+	if out.String() != "hello from the pipe\n" {
+		t.Fatalf("output = %q, want the transcript", out.String())
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "call.txt")); err != nil {
+		t.Fatalf("transcript not exported: %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(inputPath)); !os.IsNotExist(err) {
+		t.Fatalf("stdin upload kept after the job: %v", err)
+	}
+}
+
+// TestTranscribeCommandReportsFailure returns the job error instead of an
+// empty transcript.
+func TestTranscribeCommandReportsFailure(t *testing.T) {
+	root := t.TempDir()
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: root}},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			return transcribe.Result{}, errors.New("whisper crashed")
+		}},
+		events:    jobs.NewEventBus(100),
+		uploadDir: filepath.Join(root, "uploads"),
+	}
+
+	var out strings.Builder
+	err := app.transcribeCommand(context.Background(), config.TranscribeOptions{Input: config.StdinInput, Name: "call"}, strings.NewReader("audio"), &out)
+	if err == nil || !strings.Contains(err.Error(), "whisper crashed") {
+		t.Fatalf("transcribeCommand() error = %v, want the job error", err)
+	}
+	if code := ExitCode(err); code != ExitFailure {
+		t.Fatalf("ExitCode() = %d, want %d for an unclassified failure", code, ExitFailure)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("output = %q, want none", out.String())
+	}
+}
+
+// TestTranscribeCommandJSONReport prints the job's outputs, timings and a
+// summary of its segments instead of the transcript.
+func TestTranscribeCommandJSONReport(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "talk.mp4")
+	mustWrite(t, inputPath, "media")
+	app := &App{
+		Store:   &fakeStore{settings: domain.Settings{OutputDir: root}},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			segmentsPath := filepath.Join(root, "talk.segments.json")
+			mustWrite(t, segmentsPath, `{"language":"de","segments":[{"startMs":500,"endMs":2000,"text":"Hallo.","confidence":0.9,"speaker":"Speaker 1"},{"startMs":2000,"endMs":4500,"text":"Ja.","confidence":0.6,"speaker":"Speaker 2"}]}`)
+			return transcribe.Result{
+				TextPath:      filepath.Join(root, "talk.txt"),
+				SubtitlePaths: []string{filepath.Join(root, "talk.srt")},
+				SegmentsPath:  segmentsPath,
+				Stages:        []domain.StageTiming{{Stage: "transcribing", Seconds: 3}},
+			}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	var out strings.Builder
+	if err := app.transcribeCommand(context.Background(), config.TranscribeOptions{Input: inputPath, JSON: true}, nil, &out); err != nil {
+		t.Fatalf("transcribeCommand() error = %v", err)
+	}
+	var report transcribeReport
+	if err := json.Unmarshal([]byte(out.String()), &report); err != nil {
+		t.Fatalf("output is not a JSON report: %v\n%s", err, out.String())
+	}
+	if report.Status != domain.JobStatusDone || report.ExitCode != 0 || report.TextPath != filepath.Join(root, "talk.txt") || len(report.SubtitlePaths) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.Language != "de" || len(report.Stages) != 1 {
+		t.Fatalf("report language %q, stages %+v", report.Language, report.Stages)
+	}
+	want := segmentsSummary{Count: 2, Speakers: 2, StartMs: 500, EndMs: 4500, AverageConfidence: 0.75}
+	if report.Segments == nil || *report.Segments != want {
+		t.Fatalf("segments = %+v, want %+v", report.Segments, want)
+	}
+}
+
+// TestTranscribeCommandExitCodes maps what the job failed on to a distinct
+// exit code, also reported in the JSON report.
+func TestTranscribeCommandExitCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "input", err: &transcribe.PipelineError{Stage: "preprocessing", Message: "cannot access input media", Kind: domain.FailureInput}, want: ExitInput},
+		{name: "model", err: &transcribe.PipelineError{Stage: "transcribing", Message: "model not found", Kind: domain.FailureModel}, want: ExitModel},
+		{name: "ffmpeg", err: &transcribe.PipelineError{Stage: "preprocessing", Message: "ffmpeg audio conversion failed"}, want: ExitFFmpeg},
+		{name: "whisper", err: &transcribe.PipelineError{Stage: "transcribing", Message: "whisper.cpp transcription failed"}, want: ExitWhisper},
+		{name: "export", err: &transcribe.PipelineError{Stage: "exporting", Message: "failed to write subtitles"}, want: ExitExport},
+		{name: "cancelled", err: context.Canceled, want: ExitCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			inputPath := filepath.Join(root, "talk.mp4")
+			mustWrite(t, inputPath, "media")
+			app := &App{
+				Store:   &fakeStore{settings: domain.Settings{OutputDir: root}},
+				History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+				Jobs:    jobs.NewManager(),
+				Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+					return transcribe.Result{}, tt.err
+				}},
+				events: jobs.NewEventBus(100),
+			}
+
+			var out strings.Builder
+			err := app.transcribeCommand(context.Background(), config.TranscribeOptions{Input: inputPath, JSON: true}, nil, &out)
+			if code := ExitCode(err); code != tt.want {
+				t.Fatalf("ExitCode(%v) = %d, want %d", err, code, tt.want)
+			}
+			var report transcribeReport
+			if err := json.Unmarshal([]byte(out.String()), &report); err != nil {
+				t.Fatalf("output is not a JSON report: %v", err)
+			}
+			if report.ExitCode != tt.want || report.Status == domain.JobStatusDone {
+				t.Fatalf("report = %+v, want exit code %d", report, tt.want)
+			}
+		})
+	}
+}
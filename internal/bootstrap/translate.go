@@ -0,0 +1,196 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/subtitle"
+	"media-transcriber/internal/textenc"
+	"media-transcriber/internal/translate"
+)
+
+// translateTimeout bounds translating all subtitle files of one job.
+const translateTimeout = 30 * time.Minute
+
+// TranslateSubtitles translates the SRT/VTT files a finished job exported into
+// targetLang with the configured backend, writing <name>.<lang>.srt/.vtt next
+// to the originals with the same timing. It returns the written paths.
+func (a *App) TranslateSubtitles(jobID, targetLang string) ([]string, error) {
+	targetLang, ok := subtitleLanguage(targetLang)
+	if !ok {
+		return nil, fmt.Errorf("invalid target language: %q", targetLang)
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load settings: %w", err)
+	}
+	settings = normalizeSettings(settings)
+
+	translator, err := a.newTranslator(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := a.jobSubtitlePaths(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), translateTimeout)
+	defer cancel()
+
+	written := make([]string, 0, len(sources))
+	for _, source := range sources {
+		target, err := translateSubtitleFile(ctx, translator, source, settings.Language, targetLang)
+		if err != nil {
+			return written, fmt.Errorf("translate %s: %w", filepath.Base(source), err)
+		}
+		written = append(written, target)
+		a.publishEvent(jobs.Event{
+			JobID:    jobID,
+			Type:     jobs.EventTypeLog,
+			Message:  "Translated subtitles exported",
+			TextPath: target,
+		})
+	}
+	return written, nil
+}
+
+// translateJobSubtitles translates the subtitles a job just exported into
+// every language of the SubtitleLanguages setting except the transcript's
+// own, so one job yields <name>.<lang>.srt/.vtt for each. It runs after the
+// job is done, from afterJob. A language that fails is reported on the job
+// and skipped; the transcription still counts.
+func (a *App) translateJobSubtitles(ctx context.Context, jobID string, sources []string, sourceLang string, settings domain.Settings) []string {
+	var targets []string
+	for _, lang := range settings.SubtitleLanguages {
+		if lang != strings.ToLower(sourceLang) {
+			targets = append(targets, lang)
+		}
+	}
+	if len(sources) == 0 || len(targets) == 0 {
+		return nil
+	}
+	translator, err := a.newTranslator(settings)
+	if err != nil {
+		a.publishEvent(jobs.Event{JobID: jobID, Type: jobs.EventTypeError, Message: err.Error()})
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, translateTimeout)
+	defer cancel()
+	var written []string
+	for _, lang := range targets {
+		for _, source := range sources {
+			target, err := translateSubtitleFile(ctx, translator, source, sourceLang, lang)
+			if err != nil {
+				a.publishEvent(jobs.Event{
+					JobID:   jobID,
+					Type:    jobs.EventTypeError,
+					Message: fmt.Sprintf("translate %s to %s: %v", filepath.Base(source), lang, err),
+				})
+				continue
+			}
+			written = append(written, target)
+			a.publishEvent(jobs.Event{
+				JobID:    jobID,
+				Type:     jobs.EventTypeLog,
+				Message:  "Translated subtitles exported",
+				TextPath: target,
+			})
+		}
+	}
+	return written
+}
+
+// newTranslator returns the configured translation backend.
+func (a *App) newTranslator(settings domain.Settings) (translate.Translator, error) {
+	translator, err := translate.New(translate.Config{
+		Provider: translate.Provider(settings.TranslationProvider),
+		Endpoint: settings.TranslationEndpoint,
+		Model:    settings.TranslationModel,
+		APIKey:   a.secret(config.SecretTranslationAPIKey),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("translation backend: %w", err)
+	}
+	return translator, nil
+}
+
+// subtitleLanguage normalizes a target language code and reports whether it
+// can name a file suffix.
+func subtitleLanguage(lang string) (string, bool) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	return lang, lang != "" && !strings.ContainsAny(lang, `/\. `)
+}
+
+// normalizeSubtitleLanguages keeps the valid target languages, lowercased,
+// once each.
+func normalizeSubtitleLanguages(langs []string) []string {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, lang := range langs {
+		lang, ok := subtitleLanguage(lang)
+		if !ok || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		normalized = append(normalized, lang)
+	}
+	return normalized
+}
+
+// jobSubtitlePaths returns the subtitle files a job exported, as recorded in
+// its history entry; earlier translations are listed apart and left out.
+func (a *App) jobSubtitlePaths(jobID string) ([]string, error) {
+	entry, err := a.findHistoryEntry(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(entry.SubtitlePaths) == 0 {
+		return nil, fmt.Errorf("job %s exported no subtitles", jobID)
+	}
+	return entry.SubtitlePaths, nil
+}
+
+// translateSubtitleFile writes the translation of source and returns its path.
+func translateSubtitleFile(ctx context.Context, translator translate.Translator, source, sourceLang, targetLang string) (string, error) {
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return "", err
+	}
+	file, err := subtitle.Parse(data)
+	if err != nil {
+		return "", err
+	}
+	file.Cues, err = translate.Cues(ctx, translator, file.Cues, sourceLang, targetLang)
+	if err != nil {
+		return "", err
+	}
+	file.Cues = subtitle.Wrap(file.Cues, subtitle.DefaultLineWidth, targetLang)
+
+	ext := filepath.Ext(source)
+	target := strings.TrimSuffix(source, ext) + "." + targetLang + ext
+	encoding, lineEnding := textenc.Detect(data)
+	if err := os.WriteFile(target, textenc.Encode(string(file.Bytes()), encoding, lineEnding), 0o644); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// SetTranslationAPIKey stores the API key of the subtitle translation backend.
+func (a *App) SetTranslationAPIKey(key string) error {
+	return a.storeSecret(config.SecretTranslationAPIKey, "translation API key", key)
+}
+
+// HasTranslationAPIKey reports whether a translation API key is stored, without exposing it.
+func (a *App) HasTranslationAPIKey() bool {
+	return a.secret(config.SecretTranslationAPIKey) != ""
+}
@@ -0,0 +1,197 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/transcribe"
+)
+
+// TestTranslateSubtitlesWritesTranslatedFiles translates a job's exported
+// subtitles through a LibreTranslate stub and keeps the timing.
+func TestTranslateSubtitlesWritesTranslatedFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Q      []string `json:"q"`
+			APIKey string   `json:"api_key"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.APIKey != "secret" {
+			t.Errorf("api key = %q", body.APIKey)
+		}
+		out := make([]string, len(body.Q))
+		for i, text := range body.Q {
+			out[i] = strings.ToUpper(text)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"translatedText": out})
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	srtPath := filepath.Join(root, "talk.srt")
+	mustWrite(t, srtPath, "1\n00:00:01,000 --> 00:00:02,000\nhello\n")
+	history := config.NewJSONHistoryStore(filepath.Join(root, "history.json"))
+	if err := history.Save([]domain.HistoryEntry{{
+		ID:            "job-1",
+		Status:        domain.JobStatusDone,
+		TextPath:      filepath.Join(root, "talk.txt"),
+		SubtitlePaths: []string{srtPath},
+	}}); err != nil {
+		t.Fatalf("save history: %v", err)
+	}
+
+	secrets := config.NewFileSecretStore(filepath.Join(root, "secrets.json"))
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{
+			TranslationProvider: "LibreTranslate",
+			TranslationEndpoint: server.URL,
+		}},
+		Secrets: secrets,
+		History: history,
+		events:  jobs.NewEventBus(10),
+	}
+	if err := app.SetTranslationAPIKey(" secret "); err != nil {
+		t.Fatalf("set key: %v", err)
+	}
+
+	written, err := app.TranslateSubtitles("job-1", "DE")
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	want := filepath.Join(root, "talk.de.srt")
+	if len(written) != 1 || written[0] != want {
+		t.Fatalf("written = %v, want [%s]", written, want)
+	}
+	assertFile(t, want, "1\n00:00:01,000 --> 00:00:02,000\nHELLO\n")
+
+	// Translations are not among the job's subtitles, so they are not translated again.
+	if again, err := app.TranslateSubtitles("job-1", "fr"); err != nil || len(again) != 1 {
+		t.Fatalf("second translation = %v, %v", again, err)
+	}
+
+	if _, err := app.TranslateSubtitles("job-1", "../x"); err == nil {
+		t.Fatal("expected error for invalid language")
+	}
+}
+
+// TestTranslateJobSubtitles writes a translation per configured language in
+// the finishing job, skips the transcript's own language, and reports a
+// failing language without dropping the others.
+func TestTranslateJobSubtitles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Q      []string `json:"q"`
+			Target string   `json:"target"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Target == "fr" {
+			http.Error(w, "unsupported", http.StatusBadRequest)
+			return
+		}
+		out := make([]string, len(body.Q))
+		for i, text := range body.Q {
+			out[i] = body.Target + ":" + text
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"translatedText": out})
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	srtPath := filepath.Join(root, "talk.srt")
+	vttPath := filepath.Join(root, "talk.vtt")
+	mustWrite(t, srtPath, "1\n00:00:01,000 --> 00:00:02,000\nhello\n")
+	mustWrite(t, vttPath, "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nhello\n")
+	events := jobs.NewEventBus(100)
+	app := &App{Store: &fakeStore{}, events: events}
+	settings := normalizeSettings(domain.Settings{
+		TranslationProvider: "libretranslate",
+		TranslationEndpoint: server.URL,
+		SubtitleLanguages:   []string{" DE ", "en", "fr", "de", "../x"},
+	})
+	if want := []string{"de", "en", "fr"}; strings.Join(settings.SubtitleLanguages, ",") != strings.Join(want, ",") {
+		t.Fatalf("SubtitleLanguages = %v, want %v", settings.SubtitleLanguages, want)
+	}
+
+	written := app.translateJobSubtitles(context.Background(), "job-1", []string{srtPath, vttPath}, "en", settings)
+	want := []string{filepath.Join(root, "talk.de.srt"), filepath.Join(root, "talk.de.vtt")}
+	if strings.Join(written, ",") != strings.Join(want, ",") {
+		t.Fatalf("written = %v, want %v", written, want)
+	}
+	assertFile(t, want[0], "1\n00:00:01,000 --> 00:00:02,000\nde:hello\n")
+
+	failures := events.Query(0, jobs.EventFilter{JobID: "job-1", Types: []jobs.EventType{jobs.EventTypeError}})
+	if len(failures) != 2 || !strings.Contains(failures[0].Message, "translate talk.srt to fr") {
+		t.Fatalf("error events = %+v, want one per French file", failures)
+	}
+
+	settings.SubtitleLanguages = nil
+	if written := app.translateJobSubtitles(context.Background(), "job-1", []string{srtPath}, "en", settings); written != nil {
+		t.Fatalf("written without languages = %v", written)
+	}
+}
+
+// TestJobTranslationRunsAfterJob finishes the job while the translation
+// backend is still answering, then records the translations in history.
+func TestJobTranslationRunsAfterJob(t *testing.T) {
+	root := t.TempDir()
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Q []string `json:"q"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		close(requested)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]any{"translatedText": body.Q})
+	}))
+	defer server.Close()
+	srtPath := filepath.Join(root, "talk.srt")
+	mustWrite(t, srtPath, "1\n00:00:01,000 --> 00:00:02,000\nhello\n")
+	app := &App{
+		Store: &fakeStore{settings: domain.Settings{
+			OutputDir:           root,
+			Language:            "en",
+			SubtitleLanguages:   []string{"de"},
+			TranslationProvider: "libretranslate",
+			TranslationEndpoint: server.URL,
+		}},
+		History: config.NewJSONHistoryStore(filepath.Join(root, "history.json")),
+		Jobs:    jobs.NewManager(),
+		Pipeline: &fakePipeline{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+			req.OnStage("transcribing")
+			req.OnStage("exporting")
+			return transcribe.Result{TextPath: filepath.Join(root, "talk.txt"), SubtitlePaths: []string{srtPath}}, nil
+		}},
+		events: jobs.NewEventBus(100),
+	}
+
+	job, err := app.StartTranscription(filepath.Join(root, "talk.mp3"))
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	<-requested
+	waitForStatus(t, app, domain.JobStatusDone)
+	entry, err := app.findHistoryEntry(job.ID)
+	if err != nil || len(entry.TranslatedSubtitlePaths) != 0 {
+		t.Fatalf("entry before translation = %+v, %v, want no translations", entry, err)
+	}
+
+	close(release)
+	if !app.waitFollowUps(context.Background()) {
+		t.Fatal("translation did not finish")
+	}
+	entry, err = app.findHistoryEntry(job.ID)
+	want := filepath.Join(root, "talk.de.srt")
+	if err != nil || len(entry.TranslatedSubtitlePaths) != 1 || entry.TranslatedSubtitlePaths[0] != want {
+		t.Fatalf("entry after translation = %+v, %v, want [%s]", entry.TranslatedSubtitlePaths, err, want)
+	}
+}
@@ -0,0 +1,134 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/downloads"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/update"
+)
+
+// Version is the release this build was made from, set at build time with
+// -ldflags "-X media-transcriber/internal/bootstrap.Version=v1.2.0".
+// Development builds are never offered updates.
+var Version = "dev"
+
+// updateCheckTimeout bounds fetching release metadata and checksums.
+const updateCheckTimeout = 30 * time.Second
+
+// updateDownloadTimeout bounds downloading an installer.
+const updateDownloadTimeout = 30 * time.Minute
+
+// updateChecker reads the app's releases; tests point it at a fake server.
+// Without Fetch, releaseChecker fills in the shared GitHub fetcher.
+var updateChecker = update.Checker{URL: update.LatestReleaseURL}
+
+// releaseChecker returns updateChecker reading release metadata through the
+// shared GitHub fetcher with the app's token.
+func (a *App) releaseChecker() update.Checker {
+	checker := updateChecker
+	if checker.Fetch == nil {
+		checker.Fetch = a.fetchGithubReleaseJSON
+	}
+	return checker
+}
+
+// checkUpdatesOnStartup announces a newer release with an update event when
+// CheckUpdates is on. Network failures are ignored: the check is advisory.
+func (a *App) checkUpdatesOnStartup() {
+	a.mu.Lock()
+	enabled := a.Settings.CheckUpdates
+	a.mu.Unlock()
+	if !enabled {
+		return
+	}
+
+	info, err := a.CheckForUpdates()
+	if err != nil || !info.Available {
+		return
+	}
+	a.publishEvent(jobs.Event{
+		Type:    jobs.EventTypeUpdate,
+		Message: fmt.Sprintf("Media Transcriber %s is available (installed %s)", info.LatestVersion, info.CurrentVersion),
+	})
+}
+
+// CheckForUpdates compares the latest GitHub release with the running version.
+func (a *App) CheckForUpdates() (domain.UpdateInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	release, err := a.releaseChecker().Latest(ctx)
+	if err != nil {
+		return domain.UpdateInfo{}, fmt.Errorf("check for updates: %w", err)
+	}
+	return updateInfo(release, Version, goruntime.GOOS, goruntime.GOARCH), nil
+}
+
+// DownloadUpdate downloads the installer of the latest release for this
+// platform into the update cache, verifies it against the release checksums,
+// and returns its path for the user to run. Progress is reported as download events.
+func (a *App) DownloadUpdate() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+	checker := a.releaseChecker()
+	release, err := checker.Latest(ctx)
+	if err != nil {
+		return "", fmt.Errorf("check for updates: %w", err)
+	}
+	if !update.Newer(release.TagName, Version) {
+		return "", fmt.Errorf("no newer release than %s", Version)
+	}
+	installer, ok := update.SelectInstaller(release, goruntime.GOOS, goruntime.GOARCH)
+	if !ok {
+		return "", fmt.Errorf("release %s has no installer for %s/%s", release.TagName, goruntime.GOOS, goruntime.GOARCH)
+	}
+	sum, err := checker.Checksum(ctx, release, installer.Name)
+	if err != nil {
+		return "", fmt.Errorf("read release checksums: %w", err)
+	}
+	if a.updateDir == "" {
+		return "", fmt.Errorf("update directory is not configured")
+	}
+
+	path := filepath.Join(a.updateDir, release.TagName, installer.Name)
+	if err := downloadManager.Download(context.Background(), downloads.Request{
+		URL:         installer.URL,
+		Destination: path,
+		Timeout:     updateDownloadTimeout,
+	}); err != nil {
+		return "", fmt.Errorf("download %s: %w", installer.Name, err)
+	}
+	if err := update.VerifyFile(path, sum); err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("verify %s: %w", installer.Name, err)
+	}
+
+	a.publishEvent(jobs.Event{
+		Type:     jobs.EventTypeLog,
+		Message:  fmt.Sprintf("Update %s staged at %s", release.TagName, path),
+		TextPath: path,
+	})
+	return path, nil
+}
+
+// updateInfo summarizes release against the running version for goos/goarch.
+func updateInfo(release update.Release, current, goos, goarch string) domain.UpdateInfo {
+	info := domain.UpdateInfo{
+		CurrentVersion: current,
+		LatestVersion:  release.TagName,
+		Available:      update.Newer(release.TagName, current),
+		ReleaseURL:     release.HTMLURL,
+		Notes:          release.Body,
+	}
+	if installer, ok := update.SelectInstaller(release, goos, goarch); ok {
+		info.InstallerName = installer.Name
+		info.InstallerBytes = installer.Size
+	}
+	return info
+}
@@ -0,0 +1,116 @@
+package bootstrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+	"media-transcriber/internal/update"
+)
+
+// newUpdateServer serves a v1.1.0 release with an installer for this platform
+// and a checksums file listing sum for it, and points the app at it.
+func newUpdateServer(t *testing.T, installer []byte, sum string) string {
+	t.Helper()
+	suffix := map[string]string{"windows": ".msi", "darwin": ".dmg"}[goruntime.GOOS]
+	if suffix == "" {
+		suffix = ".tar.gz"
+	}
+	name := fmt.Sprintf("media-transcriber-%s-%s%s", goruntime.GOOS, goruntime.GOARCH, suffix)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest":
+			fmt.Fprintf(w, `{"tag_name":"v1.1.0","html_url":"https://example.com/v1.1.0","body":"Fixes","assets":[`+
+				`{"name":%q,"browser_download_url":"%s/installer","size":%d},`+
+				`{"name":"SHA256SUMS.txt","browser_download_url":"%s/sums"}]}`, name, server.URL, len(installer), server.URL)
+		case "/installer":
+			_, _ = w.Write(installer)
+		case "/sums":
+			fmt.Fprintf(w, "%s  ./%s\n", sum, name)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	previousChecker, previousVersion := updateChecker, Version
+	fetcher := releaseFetcher{client: server.Client(), cacheDir: t.TempDir(), sleep: func(time.Duration) {}}
+	updateChecker = update.Checker{URL: server.URL + "/latest", Fetch: fetcher.fetchJSON}
+	Version = "v1.0.0"
+	t.Cleanup(func() { updateChecker, Version = previousChecker, previousVersion })
+	return name
+}
+
+// TestCheckUpdatesOnStartupPublishesEvent announces the newer release only
+// when the setting is on.
+func TestCheckUpdatesOnStartupPublishesEvent(t *testing.T) {
+	name := newUpdateServer(t, []byte("installer"), "")
+	for _, enabled := range []bool{false, true} {
+		app := &App{Settings: domain.Settings{CheckUpdates: enabled}, events: jobs.NewEventBus(10)}
+		app.checkUpdatesOnStartup()
+		events := app.events.Since(0)
+		if got := len(events) == 1 && events[0].Type == jobs.EventTypeUpdate; got != enabled {
+			t.Fatalf("enabled=%v: events = %+v", enabled, events)
+		}
+	}
+
+	info, err := (&App{}).CheckForUpdates()
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	want := domain.UpdateInfo{CurrentVersion: "v1.0.0", LatestVersion: "v1.1.0", Available: true,
+		ReleaseURL: "https://example.com/v1.1.0", Notes: "Fixes", InstallerName: name, InstallerBytes: 9}
+	if info != want {
+		t.Fatalf("info = %+v, want %+v", info, want)
+	}
+}
+
+// TestDownloadUpdateVerifiesChecksum stages a matching installer and rejects
+// and removes one that does not match the published sum.
+func TestDownloadUpdateVerifiesChecksum(t *testing.T) {
+	installer := []byte("installer")
+	digest := sha256.Sum256(installer)
+	tests := []struct {
+		name    string
+		sum     string
+		wantErr bool
+	}{
+		{name: "matching", sum: hex.EncodeToString(digest[:])},
+		{name: "mismatch", sum: hex.EncodeToString(make([]byte, sha256.Size)), wantErr: true},
+		{name: "unlisted", sum: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := newUpdateServer(t, installer, tt.sum)
+			app := &App{updateDir: t.TempDir(), events: jobs.NewEventBus(100)}
+			path, err := app.DownloadUpdate()
+			staged := filepath.Join(app.updateDir, "v1.1.0", name)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected a checksum error")
+				}
+				if _, statErr := os.Stat(staged); !os.IsNotExist(statErr) {
+					t.Fatalf("mismatched installer should be removed, stat err = %v", statErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("download update: %v", err)
+			}
+			if path != staged {
+				t.Fatalf("path = %q, want %q", path, staged)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// maxUploadBytes bounds one uploaded media file.
+const maxUploadBytes = 8 << 30
+
+// handleUpload stores the request body as ?name= in the upload directory and
+// queues it, so remote clients can transcribe files the server cannot see.
+// The upload is deleted once its job finishes.
+func (a *App) handleUpload(w http.ResponseWriter, r *http.Request) {
+	if a.draining.Load() {
+		writeError(w, http.StatusServiceUnavailable, errors.New("server is shutting down"))
+		return
+	}
+	if a.uploadDir == "" {
+		writeError(w, http.StatusServiceUnavailable, errors.New("uploads are not enabled"))
+		return
+	}
+	name, err := uploadName(r.URL.Query().Get("name"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	priority, _ := strconv.ParseBool(r.URL.Query().Get("priority"))
+
+	dir := filepath.Join(a.uploadDir, fmt.Sprintf("upload-%d", time.Now().UnixNano()))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("create upload directory: %w", err))
+		return
+	}
+	path := filepath.Join(dir, name)
+	if err := writeUpload(path, http.MaxBytesReader(w, r.Body, maxUploadBytes)); err != nil {
+		_ = os.RemoveAll(dir)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	queued, err := a.EnqueueFile(path, priority)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, queued)
+}
+
+// uploadName returns the base name of an upload, which must name a media file.
+func uploadName(raw string) (string, error) {
+	name := filepath.Base(strings.TrimSpace(raw))
+	if name == "" || name == "." || name == string(filepath.Separator) || !hasExtension(name, defaultMediaExtensions) {
+		return "", fmt.Errorf("name must be a media file name, got %q", name)
+	}
+	return name, nil
+}
+
+// writeUpload streams body into a new file at path.
+func writeUpload(path string, body io.Reader) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create upload: %w", err)
+	}
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return fmt.Errorf("receive upload: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("write upload: %w", err)
+	}
+	return nil
+}
+
+// removeUpload deletes an uploaded input or concatenated folder batch and its
+// directory once its job has finished; other inputs are left alone.
+func (a *App) removeUpload(inputPath string) {
+	if a.uploadDir == "" {
+		return
+	}
+	dir := filepath.Dir(inputPath)
+	if filepath.Dir(dir) != filepath.Clean(a.uploadDir) {
+		return
+	}
+	if name := filepath.Base(dir); !strings.HasPrefix(name, "upload-") && !strings.HasPrefix(name, mergeDirPrefix) {
+		return
+	}
+	_ = os.RemoveAll(dir)
+}
+
+// handleArtifacts lists the output file names of a finished job.
+func (a *App) handleArtifacts(w http.ResponseWriter, r *http.Request) {
+	entry, err := a.findHistoryEntry(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	names := []string{}
+	for _, path := range jobArtifacts(entry) {
+		names = append(names, filepath.Base(path))
+	}
+	writeJSON(w, http.StatusOK, names)
+}
+
+// handleArtifact downloads one output file of a finished job by name.
+func (a *App) handleArtifact(w http.ResponseWriter, r *http.Request) {
+	entry, err := a.findHistoryEntry(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	name := r.PathValue("name")
+	for _, path := range jobArtifacts(entry) {
+		if filepath.Base(path) == name {
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+			http.ServeFile(w, r, path)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Errorf("job %s has no artifact %q", entry.ID, name))
+}
+
+// jobArtifacts lists the output files recorded for a history entry.
+func jobArtifacts(entry domain.HistoryEntry) []string {
+	var paths []string
+	if entry.TextPath != "" {
+		paths = append(paths, entry.TextPath)
+	}
+	paths = append(paths, entry.SubtitlePaths...)
+	paths = append(paths, entry.TranslatedSubtitlePaths...)
+	paths = append(paths, entry.ChapterPaths...)
+	if entry.SegmentsPath != "" {
+		paths = append(paths, entry.SegmentsPath)
+	}
+	if entry.LyricsPath != "" {
+		paths = append(paths, entry.LyricsPath)
+	}
+	if entry.ReviewPath != "" {
+		paths = append(paths, entry.ReviewPath)
+	}
+	if entry.HallucinationPath != "" {
+		paths = append(paths, entry.HallucinationPath)
+	}
+	if entry.SilenceMapPath != "" {
+		paths = append(paths, entry.SilenceMapPath)
+	}
+	paths = append(paths, entry.PluginArtifacts...)
+	if entry.MetadataPath != "" {
+		paths = append(paths, entry.MetadataPath)
+	}
+	return paths
+}
@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/storage"
+)
+
+// webDAVTarget returns the WebDAV export when a URL is configured. Files land
+// in WebDAVPath, which a settings profile can set per project.
+func (a *App) webDAVTarget(settings domain.Settings) (archiveTarget, bool) {
+	if settings.WebDAVURL == "" {
+		return archiveTarget{}, false
+	}
+	return archiveTarget{
+		name: "webdav",
+		newUploader: func() (storage.Uploader, error) {
+			return storage.NewWebDAVUploader(storage.WebDAVConfig{
+				URL:      settings.WebDAVURL,
+				User:     settings.WebDAVUser,
+				Password: a.secret(config.SecretWebDAVPassword),
+			})
+		},
+		dir: settings.WebDAVPath,
+	}, true
+}
+
+// SetWebDAVPassword stores the WebDAV password, e.g. a Nextcloud app password.
+func (a *App) SetWebDAVPassword(password string) error {
+	return a.storeSecret(config.SecretWebDAVPassword, "WebDAV password", password)
+}
+
+// HasWebDAVPassword reports whether a WebDAV password is stored, without exposing it.
+func (a *App) HasWebDAVPassword() bool {
+	return a.secret(config.SecretWebDAVPassword) != ""
+}
@@ -0,0 +1,151 @@
+package bootstrap
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	whisperSourceRepo  = "https://github.com/ggml-org/whisper.cpp.git"
+	sourceBuildTimeout = 90 * time.Minute
+	// buildOutputTailLines is how much build output is kept for error messages.
+	buildOutputTailLines = 20
+)
+
+// buildWhisperFromSource clones whisper.cpp into the local tools directory, builds
+// whisper-cli with cmake, verifies it runs, and links it as whisper.cpp.
+// It is the last resort for distros without a package or release binary.
+func buildWhisperFromSource(progress func(string)) error {
+	for _, tool := range []string{"git", "cmake"} {
+		if !commandAvailable(tool) {
+			return fmt.Errorf("building from source requires %s and a C++ compiler", tool)
+		}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("resolve user home: %w", err)
+	}
+	sourceDir := filepath.Join(localToolsDir(homeDir), "whisper.cpp", "src")
+	buildDir := filepath.Join(sourceDir, "build")
+
+	ctx, cancel := context.WithTimeout(context.Background(), sourceBuildTimeout)
+	defer cancel()
+
+	if err := os.RemoveAll(sourceDir); err != nil {
+		return fmt.Errorf("remove previous source checkout: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(sourceDir), 0o755); err != nil {
+		return fmt.Errorf("create tools directory: %w", err)
+	}
+
+	steps := [][]string{
+		{"git", "clone", "--depth", "1", whisperSourceRepo, sourceDir},
+		{"cmake", "-S", sourceDir, "-B", buildDir, "-DCMAKE_BUILD_TYPE=Release", "-DWHISPER_BUILD_TESTS=OFF"},
+		{"cmake", "--build", buildDir, "--config", "Release", "--target", "whisper-cli", "-j", strconv.Itoa(goruntime.NumCPU())},
+	}
+	for _, step := range steps {
+		emitProgress(progress, "$ "+formatCommand(step[0], step[1:]))
+		if err := runStreamingCommand(ctx, progress, step[0], step[1:]...); err != nil {
+			return err
+		}
+	}
+
+	executablePath, err := findBuiltWhisperCLI(buildDir)
+	if err != nil {
+		return err
+	}
+	if err := verifyWhisperExecutable(ctx, executablePath); err != nil {
+		return err
+	}
+	emitProgress(progress, "Built "+executablePath)
+
+	return createWhisperAliasFromExecutable(executablePath)
+}
+
+// findBuiltWhisperCLI locates whisper-cli under single- and multi-config cmake layouts.
+func findBuiltWhisperCLI(buildDir string) (string, error) {
+	name := "whisper-cli"
+	if goruntime.GOOS == "windows" {
+		name += ".exe"
+	}
+
+	candidates := []string{
+		filepath.Join(buildDir, "bin", name),
+		filepath.Join(buildDir, "bin", "Release", name),
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("build finished but %s was not found in %s", name, filepath.Join(buildDir, "bin"))
+}
+
+// verifyWhisperExecutable runs the built binary with --help to prove it loads.
+func verifyWhisperExecutable(ctx context.Context, path string) error {
+	output, err := exec.CommandContext(ctx, path, "--help").CombinedOutput()
+	if err != nil && !strings.Contains(strings.ToLower(string(output)), "usage") {
+		return fmt.Errorf("built whisper-cli does not run: %w", err)
+	}
+	return nil
+}
+
+// runStreamingCommand runs a command and forwards each combined output line to progress.
+func runStreamingCommand(ctx context.Context, progress func(string), name string, args ...string) error {
+	started := time.Now()
+	reader, writer := io.Pipe()
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s failed to start: %w", formatCommand(name, args), err)
+	}
+
+	tail := make([]string, 0, buildOutputTailLines)
+	scanDone := make(chan struct{})
+	go func() {
+		defer close(scanDone)
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := strings.TrimRight(scanner.Text(), "\r")
+			if len(tail) == buildOutputTailLines {
+				tail = tail[1:]
+			}
+			tail = append(tail, line)
+			emitProgress(progress, line)
+		}
+		_, _ = io.Copy(io.Discard, reader)
+	}()
+
+	err := cmd.Wait()
+	_ = writer.Close()
+	<-scanDone
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s timed out after %s", formatCommand(name, args), time.Since(started).Round(time.Second))
+	}
+	return fmt.Errorf("%s failed: %w (%s)", formatCommand(name, args), err, strings.Join(tail, "\n"))
+}
+
+// emitProgress forwards one line when a progress callback is configured.
+func emitProgress(progress func(string), line string) {
+	if progress != nil {
+		progress(line)
+	}
+}
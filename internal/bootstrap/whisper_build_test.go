@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+)
+
+// TestRunStreamingCommandForwardsLinesAndTail validates streamed build output.
+func TestRunStreamingCommandForwardsLinesAndTail(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	var lines []string
+	err := runStreamingCommand(context.Background(), func(line string) {
+		lines = append(lines, line)
+	}, "sh", "-c", "echo configuring; echo compiler missing >&2; exit 3")
+	if err == nil || !strings.Contains(err.Error(), "compiler missing") {
+		t.Fatalf("expected failure with output tail, got %v", err)
+	}
+	if len(lines) != 2 || lines[0] != "configuring" {
+		t.Fatalf("lines = %v", lines)
+	}
+}
+
+// TestFindBuiltWhisperCLISupportsMultiConfigLayout validates binary lookup.
+func TestFindBuiltWhisperCLISupportsMultiConfigLayout(t *testing.T) {
+	buildDir := t.TempDir()
+	name := "whisper-cli"
+	if goruntime.GOOS == "windows" {
+		name += ".exe"
+	}
+	if _, err := findBuiltWhisperCLI(buildDir); err == nil {
+		t.Fatal("expected missing binary error")
+	}
+
+	want := filepath.Join(buildDir, "bin", "Release", name)
+	if err := os.MkdirAll(filepath.Dir(want), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(want, []byte("bin"), 0o755); err != nil {
+		t.Fatalf("write binary: %v", err)
+	}
+
+	got, err := findBuiltWhisperCLI(buildDir)
+	if err != nil || got != want {
+		t.Fatalf("findBuiltWhisperCLI = %s, %v; want %s", got, err, want)
+	}
+}
@@ -0,0 +1,314 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/modelfile"
+)
+
+const (
+	// minAdoptModelBytes is below the smallest quantized whisper model, like
+	// the diagnostics check; smaller files are test fixtures or partial downloads.
+	minAdoptModelBytes = 16 << 20
+	// adoptVerifyTimeout bounds running a binary with --help before adopting it.
+	adoptVerifyTimeout = 30 * time.Second
+)
+
+// sourceCheckoutRoots are the directories under a home where whisper.cpp
+// checkouts are commonly cloned.
+var sourceCheckoutRoots = []string{"src", "", "code", "projects", "dev", "git"}
+
+// FindExistingWhisper searches common install locations for whisper.cpp
+// binaries and models installed outside the app: PATH, Homebrew prefixes,
+// Scoop and Chocolatey shims, whisper.cpp checkouts such as
+// ~/src/whisper.cpp/build, the Downloads folder, and under WSL the Windows
+// user folders. Adopting one with AdoptWhisperCandidate spares a fresh
+// multi-gigabyte install.
+func (a *App) FindExistingWhisper() ([]domain.WhisperCandidate, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve user home: %w", err)
+	}
+	candidates := newWhisperScanner(homeDir).scan()
+	if a.Store != nil {
+		if settings, err := a.Store.Load(); err == nil {
+			markCandidatesInUse(candidates, settings)
+		}
+	}
+	return candidates, nil
+}
+
+// AdoptWhisperCandidate points the settings at an existing binary or model
+// found by FindExistingWhisper. A binary must run; it becomes the whisper
+// path and the whisper.cpp alias, so terminals find it too. A model must
+// have a whisper header and becomes the model path.
+func (a *App) AdoptWhisperCandidate(path string) (domain.DiagnosticReport, error) {
+	if a.Store == nil {
+		return domain.DiagnosticReport{}, fmt.Errorf("settings store is not configured")
+	}
+	target := filepath.Clean(strings.TrimSpace(path))
+	if target == "." {
+		return domain.DiagnosticReport{}, fmt.Errorf("path is required")
+	}
+	settings, err := a.Store.Load()
+	if err != nil {
+		return domain.DiagnosticReport{}, fmt.Errorf("load settings: %w", err)
+	}
+	settings = normalizeSettings(settings)
+
+	if isModelFileName(target) {
+		if _, _, err := inspectAdoptableModel(target); err != nil {
+			return domain.DiagnosticReport{}, err
+		}
+		settings.ModelPath = target
+	} else {
+		if !isExecutableFile(target) {
+			return domain.DiagnosticReport{}, fmt.Errorf("not an executable: %s", target)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), adoptVerifyTimeout)
+		defer cancel()
+		if err := verifyWhisperExecutable(ctx, target); err != nil {
+			return domain.DiagnosticReport{}, err
+		}
+		if err := createWhisperAliasFromExecutable(target); err != nil {
+			return domain.DiagnosticReport{}, err
+		}
+		settings.WhisperPath = target
+	}
+
+	if err := a.Store.Save(settings); err != nil {
+		return domain.DiagnosticReport{}, fmt.Errorf("save settings: %w", err)
+	}
+	return a.refreshDiagnosticsFromSettings(settings), nil
+}
+
+// markCandidatesInUse flags the candidates the settings already use.
+func markCandidatesInUse(candidates []domain.WhisperCandidate, settings domain.Settings) {
+	for i, candidate := range candidates {
+		switch candidate.Kind {
+		case domain.WhisperCandidateBinary:
+			candidates[i].InUse = samePath(candidate.Path, settings.WhisperPath)
+		case domain.WhisperCandidateModel:
+			candidates[i].InUse = samePath(candidate.Path, settings.ModelPath)
+		}
+	}
+}
+
+// samePath reports whether two paths name the same file.
+func samePath(a, b string) bool {
+	if strings.TrimSpace(a) == "" || strings.TrimSpace(b) == "" {
+		return false
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// whisperScanner lists existing whisper installations.
+type whisperScanner struct {
+	goos   string
+	home   string
+	getenv func(string) string
+	// lookPath finds binaries on PATH.
+	lookPath func(string) (string, error)
+	// brewPrefixes are the Homebrew installs to look in.
+	brewPrefixes []string
+	// windowsHomes are the Windows user folders seen from WSL.
+	windowsHomes []string
+}
+
+// newWhisperScanner returns a scanner of the running system.
+func newWhisperScanner(homeDir string) whisperScanner {
+	scanner := whisperScanner{
+		goos:         goruntime.GOOS,
+		home:         homeDir,
+		getenv:       os.Getenv,
+		lookPath:     exec.LookPath,
+		brewPrefixes: []string{"/opt/homebrew", "/usr/local", "/home/linuxbrew/.linuxbrew"},
+	}
+	if prefix := strings.TrimSpace(os.Getenv("HOMEBREW_PREFIX")); prefix != "" {
+		scanner.brewPrefixes = append([]string{prefix}, scanner.brewPrefixes...)
+	}
+	if scanner.goos == "linux" && os.Getenv("WSL_DISTRO_NAME") != "" {
+		scanner.windowsHomes = wslWindowsHomes("/mnt/c/Users")
+	}
+	return scanner
+}
+
+// wslWindowsHomes lists the Windows user folders under usersDir, without
+// the built-in profiles.
+func wslWindowsHomes(usersDir string) []string {
+	entries, err := os.ReadDir(usersDir)
+	if err != nil {
+		return nil
+	}
+	var homes []string
+	for _, entry := range entries {
+		switch entry.Name() {
+		case "All Users", "Default", "Default User", "Public":
+			continue
+		}
+		if entry.IsDir() {
+			homes = append(homes, filepath.Join(usersDir, entry.Name()))
+		}
+	}
+	return homes
+}
+
+// scan returns the binaries, then the models, found in every location, each
+// file once under the first source it was found in.
+func (s whisperScanner) scan() []domain.WhisperCandidate {
+	var binaries, models []domain.WhisperCandidate
+	seen := map[string]bool{}
+	ownBin := filepath.Clean(localBinDir(s.home))
+	add := func(list *[]domain.WhisperCandidate, candidate domain.WhisperCandidate) {
+		key := candidate.Path
+		if resolved, err := filepath.EvalSymlinks(candidate.Path); err == nil {
+			key = resolved
+		}
+		if seen[key] || filepath.Dir(candidate.Path) == ownBin {
+			return
+		}
+		seen[key] = true
+		*list = append(*list, candidate)
+	}
+	addBinaries := func(source, dir string, names []string) {
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if isExecutableFile(path) {
+				add(&binaries, domain.WhisperCandidate{Kind: domain.WhisperCandidateBinary, Path: path, Source: source})
+			}
+		}
+	}
+	addModels := func(source, dir string) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !isModelFileName(entry.Name()) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			size, info, err := inspectAdoptableModel(path)
+			if err != nil {
+				continue
+			}
+			add(&models, domain.WhisperCandidate{
+				Kind:         domain.WhisperCandidateModel,
+				Path:         path,
+				Source:       source,
+				SizeBytes:    size,
+				ModelType:    info.ModelType,
+				Multilingual: info.Multilingual,
+			})
+		}
+	}
+
+	names := whisperBinaryNames(s.goos)
+	for _, name := range names {
+		if path, err := s.lookPath(name); err == nil {
+			add(&binaries, domain.WhisperCandidate{Kind: domain.WhisperCandidateBinary, Path: path, Source: "path"})
+		}
+	}
+	for _, prefix := range s.brewPrefixes {
+		addBinaries("brew", filepath.Join(prefix, "bin"), names)
+		addModels("brew", filepath.Join(prefix, "share", "whisper-cpp"))
+	}
+	if s.goos == "windows" {
+		scoop := s.getenv("SCOOP")
+		if scoop == "" {
+			scoop = filepath.Join(s.home, "scoop")
+		}
+		addBinaries("scoop", filepath.Join(scoop, "shims"), names)
+		choco := s.getenv("ChocolateyInstall")
+		if choco == "" {
+			choco = `C:\ProgramData\chocolatey`
+		}
+		addBinaries("choco", filepath.Join(choco, "bin"), names)
+	}
+	s.scanHome(s.home, "", s.goos, addBinaries, addModels)
+	for _, home := range s.windowsHomes {
+		s.scanHome(home, "wsl", "windows", addBinaries, addModels)
+	}
+	return append(binaries, models...)
+}
+
+// scanHome looks through the whisper.cpp checkouts and model folders of one
+// home directory, built for goos; a non-empty source overrides the source
+// names of what is found.
+func (s whisperScanner) scanHome(home, source, goos string, addBinaries func(string, string, []string), addModels func(string, string)) {
+	name := func(fallback string) string {
+		if source != "" {
+			return source
+		}
+		return fallback
+	}
+	// Checkouts also hold the "main" binary of older Makefile builds.
+	names := append(whisperBinaryNames(goos), executableName(goos, "main"))
+	for _, root := range sourceCheckoutRoots {
+		checkout := filepath.Join(home, root, "whisper.cpp")
+		for _, dir := range []string{
+			filepath.Join(checkout, "build", "bin"),
+			filepath.Join(checkout, "build", "bin", "Release"),
+			checkout,
+		} {
+			addBinaries(name("source"), dir, names)
+		}
+		addModels(name("source"), filepath.Join(checkout, "models"))
+	}
+	if goos == "windows" && source != "" {
+		addBinaries(source, filepath.Join(home, "scoop", "shims"), whisperBinaryNames(goos))
+	}
+	addModels(name("downloads"), filepath.Join(home, "Downloads"))
+}
+
+// whisperBinaryNames are the whisper.cpp executable names for goos. "whisper"
+// alone is left out: it is usually the Python openai-whisper CLI.
+func whisperBinaryNames(goos string) []string {
+	return []string{executableName(goos, "whisper-cli"), executableName(goos, "whisper-cpp")}
+}
+
+// executableName adds the .exe suffix on Windows.
+func executableName(goos, name string) string {
+	if goos == "windows" {
+		return name + ".exe"
+	}
+	return name
+}
+
+// isExecutableFile reports whether path is a regular file that can be run.
+func isExecutableFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if goruntime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".cmd" || ext == ".bat"
+	}
+	return info.Mode()&0o111 != 0
+}
+
+// inspectAdoptableModel checks that path is a whisper model large enough to
+// be real and returns its size and header.
+func inspectAdoptableModel(path string) (int64, modelfile.Info, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, modelfile.Info{}, fmt.Errorf("resolve model file: %w", err)
+	}
+	if info.Size() < minAdoptModelBytes {
+		return 0, modelfile.Info{}, fmt.Errorf("model file is only %d bytes: %s", info.Size(), path)
+	}
+	header, err := modelfile.Inspect(path)
+	if err != nil {
+		return 0, modelfile.Info{}, err
+	}
+	return info.Size(), header, nil
+}
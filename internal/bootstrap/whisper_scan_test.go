@@ -0,0 +1,172 @@
+package bootstrap
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+
+	"media-transcriber/internal/config"
+	"media-transcriber/internal/domain"
+)
+
+// writeFakeModel writes a ggml base model header padded to size bytes.
+func writeFakeModel(t *testing.T, path string, size int64) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	header := []int32{51865, 1500, 512, 8, 6, 448, 512, 8, 6, 80, 1}
+	if err := binary.Write(file, binary.LittleEndian, uint32(0x67676d6c)); err != nil {
+		t.Fatal(err)
+	}
+	if err := binary.Write(file, binary.LittleEndian, header); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Truncate(size); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeFakeBinary writes an executable script that prints whisper's usage.
+func writeFakeBinary(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	mustWrite(t, path, "#!/bin/sh\necho usage: whisper-cli [options] file0.wav\n")
+	if err := os.Chmod(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestWhisperScannerFindsInstalls finds binaries and models in checkouts,
+// Homebrew, Downloads, and WSL's Windows folders, each once.
+func TestWhisperScannerFindsInstalls(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("fake binaries are shell scripts")
+	}
+	home := t.TempDir()
+	brew := t.TempDir()
+	windowsHome := t.TempDir()
+	checkout := filepath.Join(home, "src", "whisper.cpp")
+
+	writeFakeBinary(t, filepath.Join(checkout, "build", "bin", "whisper-cli"))
+	writeFakeBinary(t, filepath.Join(checkout, "main"))
+	writeFakeBinary(t, filepath.Join(brew, "bin", "whisper-cli"))
+	writeFakeBinary(t, filepath.Join(windowsHome, "whisper.cpp", "build", "bin", "Release", "whisper-cli.exe"))
+	writeFakeBinary(t, filepath.Join(localBinDir(home), "whisper-cli"))
+	mustWrite(t, filepath.Join(brew, "bin", "whisper-cpp"), "not executable")
+	writeFakeModel(t, filepath.Join(checkout, "models", "ggml-base.bin"), minAdoptModelBytes)
+	writeFakeModel(t, filepath.Join(checkout, "models", "for-tests-ggml-tiny.bin"), 1024)
+	writeFakeModel(t, filepath.Join(home, "Downloads", "ggml-base.en.bin"), minAdoptModelBytes)
+	mustWrite(t, filepath.Join(home, "Downloads", "firmware.bin"), "not a model")
+	writeFakeModel(t, filepath.Join(windowsHome, "Downloads", "ggml-small.bin"), minAdoptModelBytes)
+
+	brewCLI := filepath.Join(brew, "bin", "whisper-cli")
+	scanner := whisperScanner{
+		goos:   "linux",
+		home:   home,
+		getenv: func(string) string { return "" },
+		lookPath: func(name string) (string, error) {
+			if name == "whisper-cli" {
+				return brewCLI, nil
+			}
+			return "", errors.New("not found")
+		},
+		brewPrefixes: []string{brew},
+		windowsHomes: []string{windowsHome},
+	}
+
+	got := map[string]string{}
+	for _, candidate := range scanner.scan() {
+		rel := candidate.Path
+		for _, base := range []string{home, brew, windowsHome} {
+			if r, err := filepath.Rel(base, candidate.Path); err == nil && !filepath.IsAbs(r) && r[0] != '.' {
+				rel = r
+				break
+			}
+		}
+		got[string(candidate.Kind)+" "+filepath.ToSlash(rel)] = candidate.Source
+	}
+	want := map[string]string{
+		"binary bin/whisper-cli":                               "path",
+		"binary src/whisper.cpp/build/bin/whisper-cli":         "source",
+		"binary src/whisper.cpp/main":                          "source",
+		"binary whisper.cpp/build/bin/Release/whisper-cli.exe": "wsl",
+		"model src/whisper.cpp/models/ggml-base.bin":           "source",
+		"model Downloads/ggml-base.en.bin":                     "downloads",
+		"model Downloads/ggml-small.bin":                       "wsl",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("candidates = %v, want %v", got, want)
+	}
+	for key, source := range want {
+		if got[key] != source {
+			t.Fatalf("candidates = %v, want %s from %s", got, key, source)
+		}
+	}
+}
+
+// TestAdoptWhisperCandidate points the settings at an adopted model or
+// binary and rejects files that are neither.
+func TestAdoptWhisperCandidate(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("fake binaries are shell scripts")
+	}
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+	// Adopting a binary puts the alias directory on PATH.
+	t.Setenv("PATH", os.Getenv("PATH"))
+	model := filepath.Join(root, "ggml-base.bin")
+	writeFakeModel(t, model, minAdoptModelBytes)
+	stub := filepath.Join(root, "ggml-stub.bin")
+	writeFakeModel(t, stub, 1024)
+	cli := filepath.Join(root, "whisper-cli")
+	writeFakeBinary(t, cli)
+	notes := filepath.Join(root, "notes.txt")
+	mustWrite(t, notes, "text")
+
+	store := config.NewJSONStore(filepath.Join(root, "settings.json"))
+	app := &App{Store: store}
+	if _, err := app.AdoptWhisperCandidate(model); err != nil {
+		t.Fatalf("adopt model: %v", err)
+	}
+	if _, err := app.AdoptWhisperCandidate(cli); err != nil {
+		t.Fatalf("adopt binary: %v", err)
+	}
+	settings, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.ModelPath != model || settings.WhisperPath != cli {
+		t.Fatalf("settings = model %q, whisper %q", settings.ModelPath, settings.WhisperPath)
+	}
+	if _, err := os.Stat(filepath.Join(localBinDir(root), "whisper.cpp")); err != nil {
+		t.Fatalf("whisper.cpp alias: %v", err)
+	}
+
+	for _, path := range []string{stub, notes, ""} {
+		if _, err := app.AdoptWhisperCandidate(path); err == nil {
+			t.Fatalf("AdoptWhisperCandidate(%q) error = nil", path)
+		}
+	}
+
+	candidates := []domain.WhisperCandidate{
+		{Kind: domain.WhisperCandidateBinary, Path: cli},
+		{Kind: domain.WhisperCandidateModel, Path: model},
+		{Kind: domain.WhisperCandidateModel, Path: stub},
+	}
+	markCandidatesInUse(candidates, settings)
+	if !candidates[0].InUse || !candidates[1].InUse || candidates[2].InUse {
+		t.Fatalf("in use = %+v", candidates)
+	}
+}
@@ -0,0 +1,163 @@
+package calendar
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleICS has a one-off meeting, a weekly stand-up with an exception, an
+// all-day event, and a cancelled meeting.
+const sampleICS = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:1\r\n" +
+	"SUMMARY:Budget review\\, Q4\r\n" +
+	"DTSTART:20261016T090000Z\r\n" +
+	"DURATION:PT1H\r\n" +
+	"ATTENDEE;CN=\"Ivanova, Anna\";ROLE=REQ-PARTICIPANT:mailto:anna@example.com\r\n" +
+	"ATTENDEE:mailto:bob@example.com\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"SUMMARY:reminder\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:2\r\n" +
+	"SUMMARY:Stand-up\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20261005T100000\r\n" +
+	"DTEND;TZID=Europe/Berlin:20261005T101500\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,WE\r\n" +
+	"EXDATE;TZID=Europe/Berlin:20261014T100000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:3\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"DTSTART;VALUE=DATE:20261016\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:4\r\n" +
+	"SUMMARY:Cancelled sync\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"DTSTART:20261016T090000Z\r\n" +
+	"DTEND:20261016T100000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+// TestParseICS keeps timed events and reads escapes, attendees, and durations.
+func TestParseICS(t *testing.T) {
+	events, err := ParseICS([]byte(sampleICS))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("events = %d, want 2", len(events))
+	}
+	budget := events[0]
+	if budget.Summary != "Budget review, Q4" || !budget.End.Equal(time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("budget = %+v", budget)
+	}
+	if strings.Join(budget.Attendees, "|") != "Ivanova, Anna|bob@example.com" {
+		t.Fatalf("attendees = %q", budget.Attendees)
+	}
+}
+
+// TestMatch picks the overlapping meeting and expands weekly recurrences.
+func TestMatch(t *testing.T) {
+	events, err := ParseICS([]byte(sampleICS))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no tzdata: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       string
+	}{
+		{name: "inside one-off", start: time.Date(2026, 10, 16, 9, 5, 0, 0, time.UTC), end: time.Date(2026, 10, 16, 9, 55, 0, 0, time.UTC), want: "Budget review, Q4"},
+		{name: "saved within grace", start: time.Date(2026, 10, 16, 10, 10, 0, 0, time.UTC), end: time.Date(2026, 10, 16, 10, 10, 0, 0, time.UTC), want: "Budget review, Q4"},
+		{name: "weekly occurrence", start: time.Date(2026, 10, 12, 10, 0, 0, 0, berlin), end: time.Date(2026, 10, 12, 10, 14, 0, 0, berlin), want: "Stand-up"},
+		{name: "after DST change", start: time.Date(2026, 11, 2, 10, 1, 0, 0, berlin), end: time.Date(2026, 11, 2, 10, 1, 0, 0, berlin), want: "Stand-up"},
+		{name: "excluded date", start: time.Date(2026, 10, 14, 10, 5, 0, 0, berlin), end: time.Date(2026, 10, 14, 10, 5, 0, 0, berlin)},
+		{name: "no meeting", start: time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC), end: time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meeting, ok := Match(events, tt.start, tt.end)
+			if ok != (tt.want != "") || meeting.Title != tt.want {
+				t.Fatalf("match = %q (%v), want %q", meeting.Title, ok, tt.want)
+			}
+		})
+	}
+}
+
+// TestRecurrenceCountAndMonthly stops after COUNT and skips missing month days.
+func TestRecurrenceCountAndMonthly(t *testing.T) {
+	events, err := ParseICS([]byte("BEGIN:VEVENT\nSUMMARY:Daily\nDTSTART:20261001T080000Z\nDTEND:20261001T083000Z\nRRULE:FREQ=DAILY;COUNT=3\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nSUMMARY:Monthly\nDTSTART:20260131T080000Z\nDTEND:20260131T090000Z\nRRULE:FREQ=MONTHLY\nEND:VEVENT\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	at := func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 8, 10, 0, 0, time.UTC) }
+	if meeting, ok := Match(events, at(10, 3), at(10, 3)); !ok || meeting.Title != "Daily" {
+		t.Fatalf("third daily occurrence not matched: %+v", meeting)
+	}
+	if _, ok := Match(events, at(10, 4), at(10, 4)); ok {
+		t.Fatal("matched a daily occurrence past COUNT")
+	}
+	if _, ok := Match(events, at(3, 3), at(3, 3)); ok {
+		t.Fatal("monthly rule rolled Feb 31 over into March")
+	}
+	if meeting, ok := Match(events, at(3, 31), at(3, 31)); !ok || meeting.Title != "Monthly" {
+		t.Fatalf("March 31 not matched: %+v", meeting)
+	}
+}
+
+// TestSourceEvents reads a local file and a CalDAV REPORT response.
+func TestSourceEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "work.ics")
+	if err := os.WriteFile(path, []byte(sampleICS), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if user, pass, _ := r.BasicAuth(); r.Method != "REPORT" || r.Header.Get("Depth") != "1" || user != "anna" || pass != "pw" ||
+			!strings.Contains(string(body), `start="20261016T000000Z"`) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		_, _ = io.WriteString(w, `<?xml version="1.0"?>
+<d:multistatus xmlns:d="DAV:" xmlns:cal="urn:ietf:params:xml:ns:caldav">
+  <d:response><d:href>/cal/a.ics</d:href><d:propstat><d:prop>
+    <cal:calendar-data>BEGIN:VCALENDAR
+BEGIN:VEVENT
+SUMMARY:Client call
+DTSTART:20261016T120000Z
+DTEND:20261016T130000Z
+END:VEVENT
+END:VCALENDAR
+</cal:calendar-data>
+  </d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>
+</d:multistatus>`)
+	}))
+	defer server.Close()
+
+	source := Source{ICS: path, CalDAVURL: server.URL + "/cal/", User: "anna", Password: "pw"}
+	events, err := source.Events(context.Background(), server.Client(),
+		time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if len(events) != 3 || events[2].Summary != "Client call" {
+		t.Fatalf("events = %+v", events)
+	}
+}
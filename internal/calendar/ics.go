@@ -0,0 +1,284 @@
+// Package calendar reads meetings from iCalendar files, ICS feeds, and CalDAV
+// servers and matches recordings to the meeting they were made in.
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is one VEVENT; recurring events keep their rule and expand on demand.
+type Event struct {
+	UID       string
+	Summary   string
+	Start     time.Time
+	End       time.Time
+	Attendees []string
+	rule      *recurrence
+	exdates   map[time.Time]bool
+}
+
+// recurrence is the supported subset of RRULE: DAILY, WEEKLY (with BYDAY),
+// and MONTHLY, bounded by COUNT or UNTIL.
+type recurrence struct {
+	freq     string
+	interval int
+	count    int
+	until    time.Time
+	byDay    []time.Weekday
+}
+
+// weekdays maps RRULE day codes.
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// property is one unfolded content line.
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICS returns the timed events of an iCalendar document. All-day and
+// cancelled events are skipped: they are not meetings a recording belongs to.
+func ParseICS(data []byte) ([]Event, error) {
+	var (
+		events  []Event
+		current *Event
+		allDay  bool
+		skip    bool
+		dur     time.Duration
+		depth   int
+	)
+	for _, line := range unfold(data) {
+		prop, ok := parseProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT"):
+			current, allDay, skip, dur, depth = &Event{}, false, false, 0, 0
+			continue
+		case current == nil:
+			continue
+		case prop.name == "BEGIN":
+			// Nested components such as VALARM carry their own properties.
+			depth++
+			continue
+		case prop.name == "END" && depth > 0:
+			depth--
+			continue
+		case depth > 0:
+			continue
+		case prop.name == "END" && strings.EqualFold(prop.value, "VEVENT"):
+			if current.End.IsZero() && dur > 0 {
+				current.End = current.Start.Add(dur)
+			}
+			if !skip && !allDay && !current.Start.IsZero() {
+				if current.End.Before(current.Start) || current.End.IsZero() {
+					current.End = current.Start
+				}
+				events = append(events, *current)
+			}
+			current = nil
+			continue
+		}
+
+		var err error
+		switch prop.name {
+		case "UID":
+			current.UID = prop.value
+		case "SUMMARY":
+			current.Summary = unescapeText(prop.value)
+		case "STATUS":
+			skip = strings.EqualFold(prop.value, "CANCELLED")
+		case "DTSTART":
+			if prop.params["VALUE"] == "DATE" || len(prop.value) == 8 {
+				allDay = true
+				continue
+			}
+			current.Start, err = parseDateTime(prop.value, prop.params["TZID"])
+		case "DTEND":
+			if prop.params["VALUE"] == "DATE" || len(prop.value) == 8 {
+				continue
+			}
+			current.End, err = parseDateTime(prop.value, prop.params["TZID"])
+		case "DURATION":
+			dur, err = parseDuration(prop.value)
+		case "ATTENDEE":
+			if name := attendeeName(prop); name != "" {
+				current.Attendees = append(current.Attendees, name)
+			}
+		case "RRULE":
+			current.rule, err = parseRule(prop.value, prop.params["TZID"])
+		case "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				exdate, exErr := parseDateTime(value, prop.params["TZID"])
+				if exErr != nil {
+					continue
+				}
+				if current.exdates == nil {
+					current.exdates = map[time.Time]bool{}
+				}
+				current.exdates[exdate.UTC()] = true
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("event %q: %s: %w", current.Summary, prop.name, err)
+		}
+	}
+	return events, nil
+}
+
+// unfold joins continuation lines (RFC 5545 §3.1).
+func unfold(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseProperty splits NAME;PARAM=VALUE:value, honouring quoted parameters.
+func parseProperty(line string) (property, bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return property{}, false
+	}
+	parts := strings.Split(line[:colon], ";")
+	prop := property{name: strings.ToUpper(parts[0]), params: map[string]string{}, value: line[colon+1:]}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prop, true
+}
+
+// parseDateTime reads UTC ("...Z"), zoned (TZID), and floating local date-times.
+func parseDateTime(value, tzid string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse("20060102T150405Z", value)
+	}
+	loc := time.Local
+	if tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+	return time.ParseInLocation("20060102T150405", value, loc)
+}
+
+// parseDuration reads ISO 8601 durations such as PT1H30M or P1D.
+func parseDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.TrimPrefix(strings.TrimSpace(value), "+"), "P")
+	if !ok {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour, 'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+	var total time.Duration
+	number := ""
+	for i := 0; i < len(rest); i++ {
+		c := rest[i]
+		switch {
+		case c == 'T':
+		case c >= '0' && c <= '9':
+			number += string(c)
+		default:
+			unit, known := units[c]
+			n, err := strconv.Atoi(number)
+			if !known || err != nil {
+				return 0, fmt.Errorf("invalid duration %q", value)
+			}
+			total += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	return total, nil
+}
+
+// parseRule reads the supported RRULE subset; other frequencies are ignored.
+func parseRule(value, tzid string) (*recurrence, error) {
+	rule := &recurrence{interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.freq = strings.ToUpper(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid interval %q", val)
+			}
+			rule.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid count %q", val)
+			}
+			rule.count = n
+		case "UNTIL":
+			if len(val) == 8 {
+				val += "T235959"
+			}
+			until, err := parseDateTime(val, tzid)
+			if err != nil {
+				return nil, err
+			}
+			rule.until = until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				// Ordinal prefixes (e.g. 1MO) only apply to monthly rules, which use the start day.
+				if weekday, ok := weekdays[strings.ToUpper(strings.TrimLeft(day, "+-0123456789"))]; ok {
+					rule.byDay = append(rule.byDay, weekday)
+				}
+			}
+		}
+	}
+	switch rule.freq {
+	case "DAILY", "WEEKLY", "MONTHLY":
+		return rule, nil
+	default:
+		return nil, nil
+	}
+}
+
+// attendeeName prefers the display name over the mailto address.
+func attendeeName(prop property) string {
+	if name := strings.TrimSpace(prop.params["CN"]); name != "" {
+		return name
+	}
+	value := strings.TrimSpace(prop.value)
+	if len(value) > len("mailto:") && strings.EqualFold(value[:len("mailto:")], "mailto:") {
+		value = value[len("mailto:"):]
+	}
+	return value
+}
+
+// unescapeText decodes TEXT value escapes.
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
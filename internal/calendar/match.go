@@ -0,0 +1,133 @@
+package calendar
+
+import (
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+const (
+	// MatchGrace lets a recording saved shortly after a meeting ended still match it.
+	MatchGrace = 15 * time.Minute
+	// maxOccurrences bounds recurrence expansion of open-ended rules.
+	maxOccurrences = 5000
+)
+
+// Match returns the meeting that overlaps the recording [start, end] the most.
+// A recording with unknown length (start == end) matches the meeting it falls in.
+func Match(events []Event, start, end time.Time) (domain.Meeting, bool) {
+	var (
+		best      domain.Meeting
+		bestScore time.Duration = -1
+	)
+	for _, event := range events {
+		for _, occurrence := range event.occurrences(start.Add(-24*time.Hour), end) {
+			window := occurrence.end.Add(MatchGrace)
+			if occurrence.start.After(end) || window.Before(start) {
+				continue
+			}
+			score := minTime(window, end).Sub(maxTime(occurrence.start, start))
+			if score > bestScore || (score == bestScore && occurrence.start.After(best.Start)) {
+				bestScore = score
+				best = domain.Meeting{
+					Title:     event.Summary,
+					Attendees: event.Attendees,
+					Start:     occurrence.start,
+					End:       occurrence.end,
+				}
+			}
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// interval is one occurrence of an event.
+type interval struct {
+	start, end time.Time
+}
+
+// occurrences lists the event's instances that start no later than to and end
+// no earlier than from.
+func (e Event) occurrences(from, to time.Time) []interval {
+	length := e.End.Sub(e.Start)
+	if e.rule == nil {
+		return []interval{{e.Start, e.End}}
+	}
+
+	var out []interval
+	emitted := 0
+	add := func(start time.Time) bool {
+		if start.Before(e.Start) {
+			return true
+		}
+		if (!e.rule.until.IsZero() && start.After(e.rule.until)) || start.After(to) {
+			return false
+		}
+		emitted++
+		if e.rule.count > 0 && emitted > e.rule.count {
+			return false
+		}
+		if !e.exdates[start.UTC()] && !start.Add(length).Before(from) {
+			out = append(out, interval{start, start.Add(length)})
+		}
+		return true
+	}
+
+	for step := 0; step < maxOccurrences; step++ {
+		switch e.rule.freq {
+		case "DAILY":
+			if !add(e.Start.AddDate(0, 0, step*e.rule.interval)) {
+				return out
+			}
+		case "MONTHLY":
+			start := e.Start.AddDate(0, step*e.rule.interval, 0)
+			if start.Day() != e.Start.Day() {
+				continue // e.g. the 31st in a 30-day month
+			}
+			if !add(start) {
+				return out
+			}
+		case "WEEKLY":
+			days := e.rule.byDay
+			if len(days) == 0 {
+				days = []time.Weekday{e.Start.Weekday()}
+			}
+			weekStart := e.Start.AddDate(0, 0, -int(e.Start.Weekday())+step*7*e.rule.interval)
+			for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+				if !containsWeekday(days, weekday) {
+					continue
+				}
+				if !add(weekStart.AddDate(0, 0, int(weekday))) {
+					return out
+				}
+			}
+		}
+	}
+	return out
+}
+
+// containsWeekday reports whether day is in days.
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// minTime returns the earlier of a and b.
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// maxTime returns the later of a and b.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,136 @@
+package calendar
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxCalendarBytes caps how much of a feed or CalDAV response is read.
+const maxCalendarBytes = 32 << 20
+
+// Source configures where meetings come from; any combination may be set.
+type Source struct {
+	// ICS is a local .ics file path or an http(s)/webcal feed URL.
+	ICS string
+	// CalDAVURL is a calendar collection URL queried with a time-range REPORT.
+	CalDAVURL string
+	User      string
+	Password  string
+}
+
+// Enabled reports whether any calendar is configured.
+func (s Source) Enabled() bool {
+	return strings.TrimSpace(s.ICS) != "" || strings.TrimSpace(s.CalDAVURL) != ""
+}
+
+// Events loads the events of every configured calendar around [from, to].
+func (s Source) Events(ctx context.Context, client *http.Client, from, to time.Time) ([]Event, error) {
+	var events []Event
+	if ics := strings.TrimSpace(s.ICS); ics != "" {
+		data, err := s.readICS(ctx, client, ics)
+		if err != nil {
+			return nil, fmt.Errorf("read calendar %s: %w", ics, err)
+		}
+		parsed, err := ParseICS(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse calendar %s: %w", ics, err)
+		}
+		events = append(events, parsed...)
+	}
+	if url := strings.TrimSpace(s.CalDAVURL); url != "" {
+		parsed, err := s.queryCalDAV(ctx, client, url, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("query CalDAV %s: %w", url, err)
+		}
+		events = append(events, parsed...)
+	}
+	return events, nil
+}
+
+// readICS reads a local file or downloads a feed.
+func (s Source) readICS(ctx context.Context, client *http.Client, location string) ([]byte, error) {
+	if rest, ok := strings.CutPrefix(location, "webcal://"); ok {
+		location = "https://" + rest
+	}
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return os.ReadFile(location)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.User != "" {
+		req.SetBasicAuth(s.User, s.Password)
+	}
+	return s.do(client, req, http.StatusOK)
+}
+
+// multistatus is the subset of a WebDAV 207 response carrying calendar data.
+type multistatus struct {
+	Responses []struct {
+		CalendarData []string `xml:"propstat>prop>calendar-data"`
+	} `xml:"response"`
+}
+
+// queryCalDAV runs a calendar-query REPORT for events overlapping [from, to].
+func (s Source) queryCalDAV(ctx context.Context, client *http.Client, url string, from, to time.Time) ([]Event, error) {
+	body := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><c:calendar-data/></d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT">
+        <c:time-range start="%s" end="%s"/>
+      </c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`, from.UTC().Format("20060102T150405Z"), to.UTC().Format("20060102T150405Z"))
+	req, err := http.NewRequestWithContext(ctx, "REPORT", url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Depth", "1")
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	if s.User != "" {
+		req.SetBasicAuth(s.User, s.Password)
+	}
+	data, err := s.do(client, req, http.StatusMultiStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	var status multistatus
+	if err := xml.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("decode multistatus: %w", err)
+	}
+	var events []Event
+	for _, response := range status.Responses {
+		for _, ics := range response.CalendarData {
+			parsed, err := ParseICS([]byte(ics))
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, parsed...)
+		}
+	}
+	return events, nil
+}
+
+// do sends req and returns the body when the status is want.
+func (s Source) do(client *http.Client, req *http.Request, want int) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != want {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxCalendarBytes))
+}
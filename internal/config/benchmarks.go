@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// BenchmarkStore persists model benchmark results keyed by model ID.
+type BenchmarkStore interface {
+	Load() (map[string]domain.BenchmarkResult, error)
+	Save(map[string]domain.BenchmarkResult) error
+}
+
+// JSONBenchmarkStore persists benchmark results in a single JSON file on disk.
+type JSONBenchmarkStore struct {
+	path string
+}
+
+// NewJSONBenchmarkStore creates a JSON-backed benchmark store.
+func NewJSONBenchmarkStore(path string) *JSONBenchmarkStore {
+	return &JSONBenchmarkStore{path: path}
+}
+
+// Load reads benchmark results or returns an empty map when the file is missing.
+func (s *JSONBenchmarkStore) Load() (map[string]domain.BenchmarkResult, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]domain.BenchmarkResult{}, nil
+		}
+		return nil, err
+	}
+
+	results := map[string]domain.BenchmarkResult{}
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Save writes benchmark results as indented JSON and creates parent directories.
+func (s *JSONBenchmarkStore) Save(results map[string]domain.BenchmarkResult) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
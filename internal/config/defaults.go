@@ -15,7 +15,7 @@ func DefaultSettings() domain.Settings {
 	}
 
 	return domain.Settings{
-		ModelPath: filepath.Join(homeDir, ".media-transcriber", "models"),
+		ModelPath: filepath.Join(ResolvePaths(homeDir).Data, "models"),
 		OutputDir: filepath.Join(homeDir, "Documents", "Transcripts"),
 		Language:  "auto",
 	}
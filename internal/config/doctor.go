@@ -0,0 +1,32 @@
+package config
+
+import "fmt"
+
+// DoctorOptions configure the `doctor` command. Like Overrides they come from
+// MEDIA_TRANSCRIBER_* variables and flags, so the checked settings match
+// those `serve` would run with.
+type DoctorOptions struct {
+	// JSON prints the diagnostics report as JSON (-json).
+	JSON bool
+	// Fix applies the automatic remediations of failed checks (-fix).
+	Fix       bool
+	Overrides Overrides
+}
+
+// LoadDoctorOptions parses the arguments after the `doctor` command.
+func LoadDoctorOptions(args []string, getenv func(string) string) (DoctorOptions, error) {
+	options := DoctorOptions{}
+	flags := newFlagSet()
+	overrides := bindOverrides(flags, getenv)
+	flags.BoolVar(&options.JSON, "json", false, "print the report as JSON")
+	flags.BoolVar(&options.Fix, "fix", false, "install missing tools and fix failed checks")
+	if err := flags.Parse(args); err != nil {
+		return DoctorOptions{}, err
+	}
+	if flags.NArg() > 0 {
+		return DoctorOptions{}, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	overrides.trim()
+	options.Overrides = *overrides
+	return options, nil
+}
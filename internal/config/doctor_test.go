@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+// TestLoadDoctorOptions checks the report and fix flags and override flags.
+func TestLoadDoctorOptions(t *testing.T) {
+	none := func(string) string { return "" }
+	got, err := LoadDoctorOptions([]string{"-json", "-fix", "-output-dir", "/srv/out"}, none)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !got.JSON || !got.Fix || got.Overrides != (Overrides{OutputDir: "/srv/out"}) {
+		t.Fatalf("options = %+v", got)
+	}
+
+	defaults, err := LoadDoctorOptions(nil, none)
+	if err != nil {
+		t.Fatalf("defaults: %v", err)
+	}
+	if defaults.JSON || defaults.Fix {
+		t.Fatalf("defaults = %+v", defaults)
+	}
+
+	for name, args := range map[string][]string{
+		"unknown flag":   {"-bogus"},
+		"extra argument": {"now"},
+	} {
+		if _, err := LoadDoctorOptions(args, none); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// HistoryStore persists finished jobs.
+type HistoryStore interface {
+	Load() ([]domain.HistoryEntry, error)
+	Save([]domain.HistoryEntry) error
+}
+
+// JSONHistoryStore persists job history in a single JSON file on disk.
+type JSONHistoryStore struct {
+	path string
+}
+
+// NewJSONHistoryStore creates a JSON-backed history store.
+func NewJSONHistoryStore(path string) *JSONHistoryStore {
+	return &JSONHistoryStore{path: path}
+}
+
+// Load reads history entries or returns none when the file is missing.
+func (s *JSONHistoryStore) Load() ([]domain.HistoryEntry, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []domain.HistoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Save writes history entries as indented JSON and creates parent directories.
+func (s *JSONHistoryStore) Save(entries []domain.HistoryEntry) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []domain.HistoryEntry{}
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
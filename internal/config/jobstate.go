@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// JobStateStore persists records of jobs that are currently running.
+type JobStateStore interface {
+	Load() ([]domain.JobRecord, error)
+	Save([]domain.JobRecord) error
+}
+
+// JSONJobStateStore persists running job records in a single JSON file on disk.
+type JSONJobStateStore struct {
+	path string
+}
+
+// NewJSONJobStateStore creates a JSON-backed job state store.
+func NewJSONJobStateStore(path string) *JSONJobStateStore {
+	return &JSONJobStateStore{path: path}
+}
+
+// Load reads running job records or returns none when the file is missing.
+func (s *JSONJobStateStore) Load() ([]domain.JobRecord, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var records []domain.JobRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// Save writes running job records as indented JSON and creates parent directories.
+func (s *JSONJobStateStore) Save(records []domain.JobRecord) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	if records == nil {
+		records = []domain.JobRecord{}
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
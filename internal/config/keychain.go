@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// SecretService names the app's entries in OS credential managers.
+const SecretService = "media-transcriber"
+
+// keychain abstracts an OS credential manager. get reports ok=false for missing keys.
+type keychain interface {
+	get(service, key string) (value string, ok bool, err error)
+	set(service, key, value string) error
+	delete(service, key string) error
+}
+
+// KeychainSecretStore keeps secrets in the OS credential manager: Keychain on
+// macOS, the Secret Service (libsecret) on Linux, and DPAPI on Windows.
+type KeychainSecretStore struct {
+	service  string
+	keychain keychain
+}
+
+// Get returns a secret value or empty string when it is not set.
+func (s *KeychainSecretStore) Get(key string) (string, error) {
+	value, ok, err := s.keychain.get(s.service, key)
+	if err != nil || !ok {
+		return "", err
+	}
+	return value, nil
+}
+
+// Set stores one secret value, removing the key when value is blank.
+func (s *KeychainSecretStore) Set(key, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return s.Delete(key)
+	}
+	return s.keychain.set(s.service, key, value)
+}
+
+// Delete removes one secret value; deleting a missing key is not an error.
+func (s *KeychainSecretStore) Delete(key string) error {
+	return s.keychain.delete(s.service, key)
+}
+
+// NewOSSecretStore returns a store backed by the OS credential manager and moves
+// any secrets from the plaintext file at filePath into it. When no credential
+// manager is usable it falls back to the plaintext file store.
+func NewOSSecretStore(filePath string) SecretStore {
+	fileStore := NewFileSecretStore(filePath)
+	backend, ok := osKeychain(filePath)
+	if !ok {
+		return fileStore
+	}
+
+	store := &KeychainSecretStore{service: SecretService, keychain: backend}
+	if err := migrateFileSecrets(fileStore, store); err != nil {
+		// Keep using the file until its secrets can be moved without loss.
+		return fileStore
+	}
+	return store
+}
+
+// migrateFileSecrets copies every secret from the file store into dest, then
+// deletes the file.
+func migrateFileSecrets(source *FileSecretStore, dest SecretStore) error {
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	values, err := source.read()
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	for key, value := range values {
+		if err := dest.Set(key, value); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(source.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
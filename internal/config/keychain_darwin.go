@@ -0,0 +1,65 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityItemNotFound is the exit status of `security` for missing items.
+const securityItemNotFound = 44
+
+// macKeychain stores generic passwords in the login keychain via /usr/bin/security.
+type macKeychain struct{}
+
+// osKeychain reports the macOS keychain when the security tool is present.
+func osKeychain(string) (keychain, bool) {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil, false
+	}
+	return macKeychain{}, true
+}
+
+// get reads the generic password of key; a missing item is not an error.
+func (macKeychain) get(service, key string) (string, bool, error) {
+	output, err := exec.Command("security", "find-generic-password", "-s", service, "-a", key, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("read keychain item %s: %w", key, err)
+	}
+	return strings.TrimRight(string(output), "\n"), true, nil
+}
+
+// set runs `security -i` so the secret goes through stdin instead of the
+// process arguments, where other users could see it.
+func (macKeychain) set(service, key, value string) error {
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", securityQuote(service), securityQuote(key), securityQuote(value))
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(command)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil || strings.TrimSpace(stderr.String()) != "" {
+		return fmt.Errorf("write keychain item %s: %v %s", key, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// delete removes the generic password of key, if there is one.
+func (macKeychain) delete(service, key string) error {
+	err := exec.Command("security", "delete-generic-password", "-s", service, "-a", key).Run()
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound) {
+		return fmt.Errorf("delete keychain item %s: %w", key, err)
+	}
+	return nil
+}
+
+// securityQuote quotes one argument for `security -i` command lines.
+func securityQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
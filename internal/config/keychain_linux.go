@@ -0,0 +1,68 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretToolKeychain stores secrets in the Secret Service (GNOME Keyring,
+// KWallet) through libsecret's secret-tool.
+type secretToolKeychain struct{}
+
+// osKeychain reports the Secret Service when secret-tool is installed and a
+// service answers on the session bus.
+func osKeychain(string) (keychain, bool) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil, false
+	}
+	// A lookup of a missing item exits 1 silently; errors print to stderr.
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "lookup", "service", SecretService, "key", "availability-probe")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil && strings.TrimSpace(stderr.String()) != "" {
+		return nil, false
+	}
+	return secretToolKeychain{}, true
+}
+
+// get looks key up; secret-tool exits 1 without output when it is missing.
+func (secretToolKeychain) get(service, key string) (string, bool, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "lookup", "service", service, "key", key)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.TrimSpace(stderr.String()) == "" {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("read secret %s: %v %s", key, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), true, nil
+}
+
+// set passes the secret on stdin so it never appears in process arguments.
+func (secretToolKeychain) set(service, key, value string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "store", "--label", "Media Transcriber: "+key, "service", service, "key", key)
+	cmd.Stdin = strings.NewReader(value)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("write secret %s: %v %s", key, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// delete clears key, succeeding when it was already missing.
+func (secretToolKeychain) delete(service, key string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", "clear", "service", service, "key", key)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil && strings.TrimSpace(stderr.String()) != "" {
+		return fmt.Errorf("delete secret %s: %v %s", key, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
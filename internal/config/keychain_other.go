@@ -0,0 +1,8 @@
+//go:build !darwin && !linux && !windows
+
+package config
+
+// osKeychain reports that no credential manager is supported on this platform.
+func osKeychain(string) (keychain, bool) {
+	return nil, false
+}
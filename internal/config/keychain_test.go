@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeKeychain keeps credential manager entries in memory.
+type fakeKeychain struct {
+	items map[string]string
+}
+
+// get returns the item stored under service and key.
+func (k *fakeKeychain) get(service, key string) (string, bool, error) {
+	value, ok := k.items[service+"/"+key]
+	return value, ok, nil
+}
+
+// set stores value under service and key.
+func (k *fakeKeychain) set(service, key, value string) error {
+	k.items[service+"/"+key] = value
+	return nil
+}
+
+// delete removes the item under service and key.
+func (k *fakeKeychain) delete(service, key string) error {
+	delete(k.items, service+"/"+key)
+	return nil
+}
+
+// TestKeychainSecretStoreRoundTrip checks set, get, blank-set, and delete behavior.
+func TestKeychainSecretStoreRoundTrip(t *testing.T) {
+	backend := &fakeKeychain{items: map[string]string{}}
+	store := &KeychainSecretStore{service: SecretService, keychain: backend}
+
+	if got, err := store.Get(SecretHuggingFaceToken); err != nil || got != "" {
+		t.Fatalf("Get() on missing key = %q, %v", got, err)
+	}
+	if err := store.Set(SecretHuggingFaceToken, " hf_abc "); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got := backend.items[SecretService+"/"+SecretHuggingFaceToken]; got != "hf_abc" {
+		t.Fatalf("stored = %q, want hf_abc", got)
+	}
+	if err := store.Set(SecretHuggingFaceToken, " "); err != nil {
+		t.Fatalf("Set(blank) error = %v", err)
+	}
+	if len(backend.items) != 0 {
+		t.Fatalf("items = %v, want empty after blank set", backend.items)
+	}
+}
+
+// TestMigrateFileSecretsMovesValuesAndRemovesFile checks plaintext secrets leave the disk.
+func TestMigrateFileSecretsMovesValuesAndRemovesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+	fileStore := NewFileSecretStore(path)
+	if err := fileStore.Set(SecretHuggingFaceToken, "hf_abc"); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	backend := &fakeKeychain{items: map[string]string{}}
+	store := &KeychainSecretStore{service: SecretService, keychain: backend}
+
+	if err := migrateFileSecrets(fileStore, store); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if got, _ := store.Get(SecretHuggingFaceToken); got != "hf_abc" {
+		t.Fatalf("migrated token = %q, want hf_abc", got)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("plaintext file should be removed, stat err = %v", err)
+	}
+}
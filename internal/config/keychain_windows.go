@@ -0,0 +1,92 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	crypt32                = syscall.NewLazyDLL("crypt32.dll")
+	kernel32               = syscall.NewLazyDLL("kernel32.dll")
+	procCryptProtectData   = crypt32.NewProc("CryptProtectData")
+	procCryptUnprotectData = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree          = kernel32.NewProc("LocalFree")
+)
+
+// cryptProtectUIForbidden is CRYPTPROTECT_UI_FORBIDDEN.
+const cryptProtectUIForbidden = 0x1
+
+// dataBlob mirrors the Win32 DATA_BLOB structure.
+type dataBlob struct {
+	size uint32
+	data *byte
+}
+
+// dpapiKeychain encrypts secrets with DPAPI for the current user and keeps the
+// ciphertext in secrets.dpapi.json next to the plaintext store it replaces.
+type dpapiKeychain struct {
+	file *FileSecretStore
+}
+
+// osKeychain reports DPAPI, which every supported Windows version provides.
+func osKeychain(filePath string) (keychain, bool) {
+	if err := procCryptProtectData.Find(); err != nil {
+		return nil, false
+	}
+	path := filepath.Join(filepath.Dir(filePath), "secrets.dpapi.json")
+	return dpapiKeychain{file: NewFileSecretStore(path)}, true
+}
+
+// get decrypts the stored ciphertext of key; the service is implied by the file.
+func (k dpapiKeychain) get(_, key string) (string, bool, error) {
+	encoded, err := k.file.Get(key)
+	if err != nil || encoded == "" {
+		return "", false, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", false, fmt.Errorf("decode secret %s: %w", key, err)
+	}
+	plaintext, err := dpapiCall(procCryptUnprotectData, ciphertext)
+	if err != nil {
+		return "", false, fmt.Errorf("decrypt secret %s: %w", key, err)
+	}
+	return string(plaintext), true, nil
+}
+
+// set encrypts value for the current user and stores the ciphertext.
+func (k dpapiKeychain) set(_, key, value string) error {
+	ciphertext, err := dpapiCall(procCryptProtectData, []byte(value))
+	if err != nil {
+		return fmt.Errorf("encrypt secret %s: %w", key, err)
+	}
+	return k.file.Set(key, base64.StdEncoding.EncodeToString(ciphertext))
+}
+
+// delete removes the ciphertext of key.
+func (k dpapiKeychain) delete(_, key string) error {
+	return k.file.Delete(key)
+}
+
+// dpapiCall runs CryptProtectData or CryptUnprotectData on input and returns a Go copy of the output.
+func dpapiCall(proc *syscall.LazyProc, input []byte) ([]byte, error) {
+	if len(input) == 0 {
+		return nil, fmt.Errorf("empty input")
+	}
+	in := dataBlob{size: uint32(len(input)), data: &input[0]}
+	var out dataBlob
+	result, _, callErr := proc.Call(
+		uintptr(unsafe.Pointer(&in)),
+		0, 0, 0, 0,
+		cryptProtectUIForbidden,
+		uintptr(unsafe.Pointer(&out)),
+	)
+	if result == 0 {
+		return nil, callErr
+	}
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(out.data)))
+	return append([]byte(nil), unsafe.Slice(out.data, out.size)...), nil
+}
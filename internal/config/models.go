@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// ModelStore persists user-defined model catalog entries.
+type ModelStore interface {
+	Load() ([]domain.WhisperModelOption, error)
+	Save([]domain.WhisperModelOption) error
+}
+
+// JSONModelStore persists custom model entries in a single JSON file on disk.
+type JSONModelStore struct {
+	path string
+}
+
+// NewJSONModelStore creates a JSON-backed custom model store.
+func NewJSONModelStore(path string) *JSONModelStore {
+	return &JSONModelStore{path: path}
+}
+
+// Load reads custom model entries or returns none when the file is missing.
+func (s *JSONModelStore) Load() ([]domain.WhisperModelOption, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var models []domain.WhisperModelOption
+	if err := json.Unmarshal(data, &models); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+// Save writes custom model entries as indented JSON and creates parent directories.
+func (s *JSONModelStore) Save(models []domain.WhisperModelOption) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(models, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
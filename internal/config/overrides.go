@@ -0,0 +1,136 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"strings"
+
+	"media-transcriber/internal/domain"
+)
+
+// EnvPrefix starts every environment variable that overrides a setting.
+const EnvPrefix = "MEDIA_TRANSCRIBER_"
+
+// Overrides are settings from environment variables or command-line flags that
+// take precedence over the JSON store. Empty fields leave the stored value alone.
+type Overrides struct {
+	ModelPath   string
+	OutputDir   string
+	Language    string
+	FFmpegPath  string
+	WhisperPath string
+}
+
+// overrideField binds one override to its environment suffix, flag name, and setting.
+type overrideField struct {
+	env     string
+	flag    string
+	usage   string
+	value   func(o *Overrides) *string
+	setting func(s *domain.Settings) *string
+}
+
+var overrideFields = []overrideField{
+	{"MODEL_PATH", "model", "whisper model file or directory", func(o *Overrides) *string { return &o.ModelPath }, func(s *domain.Settings) *string { return &s.ModelPath }},
+	{"OUTPUT_DIR", "output-dir", "transcript output directory", func(o *Overrides) *string { return &o.OutputDir }, func(s *domain.Settings) *string { return &s.OutputDir }},
+	{"LANGUAGE", "language", "spoken language code or auto", func(o *Overrides) *string { return &o.Language }, func(s *domain.Settings) *string { return &s.Language }},
+	{"FFMPEG_PATH", "ffmpeg", "ffmpeg executable", func(o *Overrides) *string { return &o.FFmpegPath }, func(s *domain.Settings) *string { return &s.FFmpegPath }},
+	{"WHISPER_PATH", "whisper", "whisper.cpp executable", func(o *Overrides) *string { return &o.WhisperPath }, func(s *domain.Settings) *string { return &s.WhisperPath }},
+}
+
+// LoadOverrides reads MEDIA_TRANSCRIBER_* variables through getenv, then applies
+// command-line flags from args on top, so flags win over the environment.
+func LoadOverrides(args []string, getenv func(string) string) (Overrides, error) {
+	flags := newFlagSet()
+	overrides := bindOverrides(flags, getenv)
+	if err := flags.Parse(filterPlatformArgs(args)); err != nil {
+		return Overrides{}, err
+	}
+	overrides.trim()
+	return *overrides, nil
+}
+
+// newFlagSet returns a silent flag set that reports errors instead of exiting.
+func newFlagSet() *flag.FlagSet {
+	flags := flag.NewFlagSet("media-transcriber", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// bindOverrides seeds overrides from the environment and registers their flags,
+// whose defaults are the environment values.
+func bindOverrides(flags *flag.FlagSet, getenv func(string) string) *Overrides {
+	overrides := &Overrides{}
+	for _, field := range overrideFields {
+		target := field.value(overrides)
+		*target = strings.TrimSpace(getenv(EnvPrefix + field.env))
+		flags.StringVar(target, field.flag, *target, field.usage)
+	}
+	return overrides
+}
+
+// trim strips whitespace that flag values may carry.
+func (o *Overrides) trim() {
+	for _, field := range overrideFields {
+		target := field.value(o)
+		*target = strings.TrimSpace(*target)
+	}
+}
+
+// Apply returns settings with every non-empty override applied.
+func (o Overrides) Apply(settings domain.Settings) domain.Settings {
+	for _, field := range overrideFields {
+		if value := *field.value(&o); value != "" {
+			*field.setting(&settings) = value
+		}
+	}
+	return settings
+}
+
+// filterPlatformArgs drops arguments the OS adds on launch, such as the
+// -psn_* process serial number older macOS versions pass to app bundles.
+func filterPlatformArgs(args []string) []string {
+	filtered := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-psn_") {
+			continue
+		}
+		filtered = append(filtered, arg)
+	}
+	return filtered
+}
+
+// OverrideStore wraps a Store so loads see overridden values while saves keep
+// the stored values of overridden fields, leaving the JSON file unaffected.
+type OverrideStore struct {
+	inner     Store
+	overrides Overrides
+}
+
+// NewOverrideStore applies overrides on top of inner.
+func NewOverrideStore(inner Store, overrides Overrides) *OverrideStore {
+	return &OverrideStore{inner: inner, overrides: overrides}
+}
+
+// Load reads the stored settings and applies the overrides.
+func (s *OverrideStore) Load() (domain.Settings, error) {
+	settings, err := s.inner.Load()
+	if err != nil {
+		return domain.Settings{}, err
+	}
+	return s.overrides.Apply(settings), nil
+}
+
+// Save persists settings, restoring the stored value of each overridden field.
+func (s *OverrideStore) Save(settings domain.Settings) error {
+	stored, err := s.inner.Load()
+	if err != nil {
+		return err
+	}
+	for _, field := range overrideFields {
+		if *field.value(&s.overrides) != "" {
+			*field.setting(&settings) = *field.setting(&stored)
+		}
+	}
+	return s.inner.Save(settings)
+}
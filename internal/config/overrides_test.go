@@ -0,0 +1,59 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestLoadOverridesFlagsWinOverEnvironment checks env parsing and flag precedence.
+func TestLoadOverridesFlagsWinOverEnvironment(t *testing.T) {
+	env := map[string]string{
+		"MEDIA_TRANSCRIBER_MODEL_PATH": "/env/model.bin",
+		"MEDIA_TRANSCRIBER_LANGUAGE":   " de ",
+	}
+	args := []string{"-psn_0_12345", "-language", "en", "--output-dir=/flag/out"}
+
+	got, err := LoadOverrides(args, func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("LoadOverrides() error = %v", err)
+	}
+	want := Overrides{ModelPath: "/env/model.bin", OutputDir: "/flag/out", Language: "en"}
+	if got != want {
+		t.Fatalf("overrides = %+v, want %+v", got, want)
+	}
+
+	if _, err := LoadOverrides([]string{"-bogus"}, func(string) string { return "" }); err == nil {
+		t.Fatal("expected error for unknown flag")
+	}
+}
+
+// TestOverrideStoreAppliesOnLoadAndPreservesOnSave checks the stored file keeps its values.
+func TestOverrideStoreAppliesOnLoadAndPreservesOnSave(t *testing.T) {
+	inner := NewJSONStore(filepath.Join(t.TempDir(), "settings.json"))
+	if err := inner.Save(domain.Settings{ModelPath: "/stored/model.bin", OutputDir: "/stored/out", Language: "auto"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	store := NewOverrideStore(inner, Overrides{ModelPath: "/override/model.bin"})
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.ModelPath != "/override/model.bin" || loaded.OutputDir != "/stored/out" {
+		t.Fatalf("loaded = %+v", loaded)
+	}
+
+	loaded.OutputDir = "/new/out"
+	if err := store.Save(loaded); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	persisted, err := inner.Load()
+	if err != nil {
+		t.Fatalf("inner Load() error = %v", err)
+	}
+	if persisted.ModelPath != "/stored/model.bin" || persisted.OutputDir != "/new/out" {
+		t.Fatalf("persisted = %+v", persisted)
+	}
+}
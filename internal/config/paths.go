@@ -0,0 +1,195 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"slices"
+	"strings"
+)
+
+// appDirName names the app's directory under each base location.
+const appDirName = "media-transcriber"
+
+// HistoryFile names the job history store, kept in the data directory.
+const HistoryFile = "history.json"
+
+// Paths are the base directories for configuration (settings and stores), data
+// (models, tools, bin, job history), and disposable caches.
+type Paths struct {
+	Config string
+	Data   string
+	Cache  string
+}
+
+// LegacyDir returns the pre-XDG ~/.media-transcriber directory, still used on macOS and Windows.
+func LegacyDir(homeDir string) string {
+	return filepath.Join(homeDir, "."+appDirName)
+}
+
+// ResolvePaths returns the app directories for this OS. Linux follows the XDG base
+// directory spec unless a legacy ~/.media-transcriber install has not been migrated;
+// other platforms keep everything in ~/.media-transcriber.
+func ResolvePaths(homeDir string) Paths {
+	return resolvePaths(goruntime.GOOS, homeDir, os.Getenv)
+}
+
+// resolvePaths implements ResolvePaths with an injectable OS and environment.
+func resolvePaths(goos, homeDir string, getenv func(string) string) Paths {
+	legacy := LegacyDir(homeDir)
+	if goos != "linux" {
+		return Paths{Config: legacy, Data: legacy, Cache: filepath.Join(legacy, "cache")}
+	}
+	if info, err := os.Stat(legacy); err == nil && info.IsDir() {
+		return Paths{Config: legacy, Data: legacy, Cache: filepath.Join(legacy, "cache")}
+	}
+	return xdgPaths(homeDir, getenv)
+}
+
+// xdgPaths applies XDG_CONFIG_HOME, XDG_DATA_HOME, and XDG_CACHE_HOME with the spec defaults.
+// Relative values are invalid per the spec and ignored.
+func xdgPaths(homeDir string, getenv func(string) string) Paths {
+	base := func(env string, fallback ...string) string {
+		if value := strings.TrimSpace(getenv(env)); filepath.IsAbs(value) {
+			return filepath.Join(value, appDirName)
+		}
+		return filepath.Join(append([]string{homeDir}, append(fallback, appDirName)...)...)
+	}
+	return Paths{
+		Config: base("XDG_CONFIG_HOME", ".config"),
+		Data:   base("XDG_DATA_HOME", ".local", "share"),
+		Cache:  base("XDG_CACHE_HOME", ".cache"),
+	}
+}
+
+// MigrateLegacyLayout moves an existing ~/.media-transcriber install into the XDG
+// directories on Linux: JSON stores go to the config directory and everything else
+// (models, tools, bin, the job history) to the data directory. Absolute paths into the old
+// directory are rewritten to each entry's new location in the moved stores
+// and bin scripts. If a move fails every
+// moved entry is put back, so the app keeps using the legacy directory.
+// It reports whether a migration happened.
+func MigrateLegacyLayout(homeDir string) (bool, error) {
+	if goruntime.GOOS != "linux" {
+		return false, nil
+	}
+	return migrateLegacyLayout(LegacyDir(homeDir), xdgPaths(homeDir, os.Getenv))
+}
+
+// move is one legacy entry and where it was moved to.
+type move struct{ from, to string }
+
+// migrateLegacyLayout implements MigrateLegacyLayout for explicit directories.
+func migrateLegacyLayout(legacy string, paths Paths) (bool, error) {
+	entries, err := os.ReadDir(legacy)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var moved []move
+	rollback := func() {
+		for i := len(moved) - 1; i >= 0; i-- {
+			_ = os.Rename(moved[i].to, moved[i].from)
+		}
+	}
+
+	for _, entry := range entries {
+		destRoot := migrationRoot(entry, paths)
+		from := filepath.Join(legacy, entry.Name())
+		to := filepath.Join(destRoot, entry.Name())
+		if _, err := os.Lstat(to); err == nil {
+			rollback()
+			return false, fmt.Errorf("migrate %s: %s already exists", from, to)
+		}
+		if err := os.MkdirAll(destRoot, 0o755); err != nil {
+			rollback()
+			return false, fmt.Errorf("migrate %s: %w", from, err)
+		}
+		if err := os.Rename(from, to); err != nil {
+			rollback()
+			return false, fmt.Errorf("migrate %s: %w", from, err)
+		}
+		moved = append(moved, move{from: from, to: to})
+	}
+
+	if err := os.Remove(legacy); err != nil {
+		rollback()
+		return false, fmt.Errorf("remove legacy directory: %w", err)
+	}
+
+	// The move is complete; a failed rewrite only leaves a stale path to fix by hand.
+	var rewriteErr error
+	replacer := legacyPathReplacer(legacy, paths.Data, moved)
+	for _, item := range moved {
+		if err := rewriteLegacyPaths(item.to, replacer); err != nil && rewriteErr == nil {
+			rewriteErr = err
+		}
+	}
+	return true, rewriteErr
+}
+
+// legacyPathReplacer rewrites absolute paths into the legacy directory to
+// where each moved entry now lives; other legacy paths go to the data
+// directory. Longer names come first so "models" cannot claim "models-old".
+func legacyPathReplacer(legacy, data string, moved []move) *strings.Replacer {
+	sorted := slices.Clone(moved)
+	slices.SortFunc(sorted, func(a, b move) int { return len(b.from) - len(a.from) })
+	var pairs []string
+	for _, item := range sorted {
+		pairs = append(pairs, item.from, item.to)
+	}
+	pairs = append(pairs, legacy+string(filepath.Separator), data+string(filepath.Separator))
+	return strings.NewReplacer(pairs...)
+}
+
+// migrationRoot returns the directory a legacy entry moves to: JSON stores
+// other than the job history go to config, everything else to data.
+func migrationRoot(entry os.DirEntry, paths Paths) string {
+	if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".json") && entry.Name() != HistoryFile {
+		return paths.Config
+	}
+	return paths.Data
+}
+
+// rewriteLegacyPaths updates absolute legacy paths inside a moved JSON store or
+// in the scripts of a moved bin directory.
+func rewriteLegacyPaths(path string, replacer *strings.Replacer) error {
+	targets := []string{path}
+	if filepath.Base(path) == "bin" {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		targets = targets[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				targets = append(targets, filepath.Join(path, entry.Name()))
+			}
+		}
+	} else if !strings.EqualFold(filepath.Ext(path), ".json") {
+		return nil
+	}
+
+	for _, target := range targets {
+		info, err := os.Stat(target)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(target)
+		if err != nil {
+			return err
+		}
+		rewritten := replacer.Replace(string(data))
+		if rewritten == string(data) {
+			continue
+		}
+		if err := os.WriteFile(target, []byte(rewritten), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("rewrite paths in %s: %w", target, err)
+		}
+	}
+	return nil
+}
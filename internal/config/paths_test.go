@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestResolvePathsPerPlatform checks XDG handling on Linux and the legacy layout elsewhere.
+func TestResolvePathsPerPlatform(t *testing.T) {
+	home := t.TempDir()
+	env := map[string]string{"XDG_CONFIG_HOME": "/xdg/config", "XDG_CACHE_HOME": "relative/cache"}
+	getenv := func(key string) string { return env[key] }
+
+	got := resolvePaths("linux", home, getenv)
+	want := Paths{
+		Config: filepath.Join("/xdg/config", "media-transcriber"),
+		Data:   filepath.Join(home, ".local", "share", "media-transcriber"),
+		Cache:  filepath.Join(home, ".cache", "media-transcriber"),
+	}
+	if got != want {
+		t.Fatalf("linux paths = %+v, want %+v", got, want)
+	}
+
+	legacy := LegacyDir(home)
+	if got := resolvePaths("darwin", home, getenv); got.Config != legacy || got.Data != legacy {
+		t.Fatalf("darwin paths = %+v, want legacy %s", got, legacy)
+	}
+
+	if err := os.MkdirAll(legacy, 0o755); err != nil {
+		t.Fatalf("mkdir legacy: %v", err)
+	}
+	if got := resolvePaths("linux", home, getenv); got.Config != legacy {
+		t.Fatalf("unmigrated linux paths = %+v, want legacy %s", got, legacy)
+	}
+}
+
+// TestMigrateLegacyLayoutMovesAndRewritesPaths checks the move split and path rewriting.
+func TestMigrateLegacyLayoutMovesAndRewritesPaths(t *testing.T) {
+	home := t.TempDir()
+	legacy := LegacyDir(home)
+	paths := xdgPaths(home, func(string) string { return "" })
+	legacyModels := filepath.Join(legacy, "models")
+
+	writeTestFile(t, filepath.Join(legacy, "settings.json"), `{"modelPath":"`+legacyModels+`","presets":"`+filepath.Join(legacy, "presets.json")+`"}`)
+	writeTestFile(t, filepath.Join(legacy, "presets.json"), "{}")
+	writeTestFile(t, filepath.Join(legacy, HistoryFile), "[]")
+	writeTestFile(t, filepath.Join(legacyModels, "ggml-base.bin"), "model")
+	writeTestFile(t, filepath.Join(legacy, "bin", "whisper.cpp"), `exec "`+filepath.Join(legacy, "tools", "whisper-cli")+`" "$@"`)
+
+	migrated, err := migrateLegacyLayout(legacy, paths)
+	if err != nil || !migrated {
+		t.Fatalf("migrate = %v, %v; want true, nil", migrated, err)
+	}
+
+	settings := readTestFile(t, filepath.Join(paths.Config, "settings.json"))
+	if !strings.Contains(settings, filepath.Join(paths.Data, "models")) || !strings.Contains(settings, filepath.Join(paths.Config, "presets.json")) {
+		t.Fatalf("settings not rewritten to each file's new directory: %s", settings)
+	}
+	if _, err := os.Stat(filepath.Join(paths.Data, HistoryFile)); err != nil {
+		t.Fatalf("history should move to the data dir: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(paths.Data, "models", "ggml-base.bin")); err != nil {
+		t.Fatalf("model not moved: %v", err)
+	}
+	if script := readTestFile(t, filepath.Join(paths.Data, "bin", "whisper.cpp")); !strings.Contains(script, filepath.Join(paths.Data, "tools")) {
+		t.Fatalf("alias not rewritten: %s", script)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Fatalf("legacy dir should be removed, stat err = %v", err)
+	}
+
+	if migrated, err := migrateLegacyLayout(legacy, paths); migrated || err != nil {
+		t.Fatalf("second migrate = %v, %v; want false, nil", migrated, err)
+	}
+}
+
+// TestMigrateLegacyLayoutRollsBackOnConflict checks a conflicting target leaves the legacy install intact.
+func TestMigrateLegacyLayoutRollsBackOnConflict(t *testing.T) {
+	home := t.TempDir()
+	legacy := LegacyDir(home)
+	paths := xdgPaths(home, func(string) string { return "" })
+
+	writeTestFile(t, filepath.Join(legacy, "benchmarks.json"), "{}")
+	writeTestFile(t, filepath.Join(legacy, "settings.json"), "{}")
+	writeTestFile(t, filepath.Join(paths.Config, "settings.json"), "{}")
+
+	if migrated, err := migrateLegacyLayout(legacy, paths); migrated || err == nil {
+		t.Fatalf("migrate = %v, %v; want false and a conflict error", migrated, err)
+	}
+	for _, name := range []string{"benchmarks.json", "settings.json"} {
+		if _, err := os.Stat(filepath.Join(legacy, name)); err != nil {
+			t.Fatalf("%s should stay in the legacy dir: %v", name, err)
+		}
+	}
+}
+
+// writeTestFile creates parent directories and writes content.
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// readTestFile returns file content as a string.
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// ProfileStore persists named settings profiles.
+type ProfileStore interface {
+	Load() ([]domain.SettingsProfile, error)
+	Save([]domain.SettingsProfile) error
+}
+
+// JSONProfileStore persists settings profiles in a single JSON file on disk.
+type JSONProfileStore struct {
+	path string
+}
+
+// NewJSONProfileStore creates a JSON-backed profile store.
+func NewJSONProfileStore(path string) *JSONProfileStore {
+	return &JSONProfileStore{path: path}
+}
+
+// Load reads profiles or returns none when the file is missing.
+func (s *JSONProfileStore) Load() ([]domain.SettingsProfile, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var profiles []domain.SettingsProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Save writes profiles as indented JSON and creates parent directories.
+func (s *JSONProfileStore) Save(profiles []domain.SettingsProfile) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	if profiles == nil {
+		profiles = []domain.SettingsProfile{}
+	}
+
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SecretHuggingFaceToken is the secret key for the Hugging Face access token.
+const SecretHuggingFaceToken = "huggingface_token"
+
+// SecretTranslationAPIKey is the secret key for the subtitle translation backend.
+const SecretTranslationAPIKey = "translation_api_key"
+
+// SecretRemoteWorkerToken is the API token sent to remote workers.
+const SecretRemoteWorkerToken = "remote_worker_token"
+
+// SecretS3SecretKey is the secret access key of the S3 archive.
+const SecretS3SecretKey = "s3_secret_key"
+
+// SecretWebDAVPassword is the password of the WebDAV export target.
+const SecretWebDAVPassword = "webdav_password"
+
+// SecretNotionToken is the Notion integration token.
+const SecretNotionToken = "notion_token"
+
+// SecretSMTPPassword is the password of the SMTP account that emails transcripts.
+const SecretSMTPPassword = "smtp_password"
+
+// SecretCalDAVPassword is the password of the CalDAV account or ICS feed.
+const SecretCalDAVPassword = "caldav_password"
+
+// SecretGitHubToken is the token for GitHub release metadata requests.
+const SecretGitHubToken = "github_token"
+
+// SecretStore persists credentials separately from plain settings.
+type SecretStore interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// FileSecretStore keeps secrets in a user-only readable JSON file.
+type FileSecretStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSecretStore creates a file-backed secret store.
+func NewFileSecretStore(path string) *FileSecretStore {
+	return &FileSecretStore{path: path}
+}
+
+// Get returns a secret value or empty string when it is not set.
+func (s *FileSecretStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	return values[key], nil
+}
+
+// Set stores one secret value, removing the key when value is blank.
+func (s *FileSecretStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		delete(values, key)
+	} else {
+		values[key] = value
+	}
+	return s.write(values)
+}
+
+// Delete removes one secret value.
+func (s *FileSecretStore) Delete(key string) error {
+	return s.Set(key, "")
+}
+
+// read loads all secrets, returning an empty map when the file is missing.
+func (s *FileSecretStore) read() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	values := map[string]string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// write persists all secrets with owner-only permissions.
+func (s *FileSecretStore) write(values map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return err
+	}
+	return os.Chmod(s.path, 0o600)
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"testing"
+)
+
+// TestFileSecretStoreRoundTrip checks set, get, and delete behavior.
+func TestFileSecretStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg", "secrets.json")
+	store := NewFileSecretStore(path)
+
+	if got, err := store.Get(SecretHuggingFaceToken); err != nil || got != "" {
+		t.Fatalf("Get() on missing file = %q, %v", got, err)
+	}
+
+	if err := store.Set(SecretHuggingFaceToken, " hf_abc "); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err := store.Get(SecretHuggingFaceToken)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got != "hf_abc" {
+		t.Fatalf("token = %q, want hf_abc", got)
+	}
+
+	if goruntime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("stat: %v", err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Fatalf("mode = %v, want 0600", info.Mode().Perm())
+		}
+	}
+
+	if err := store.Delete(SecretHuggingFaceToken); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got, _ := store.Get(SecretHuggingFaceToken); got != "" {
+		t.Fatalf("token after delete = %q, want empty", got)
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultListenAddr is where the headless server listens when not configured.
+const DefaultListenAddr = ":8080"
+
+// ServeOptions configure the headless `serve` mode. Like Overrides they come
+// from MEDIA_TRANSCRIBER_* variables with command-line flags taking precedence.
+type ServeOptions struct {
+	// Listen is the HTTP listen address (MEDIA_TRANSCRIBER_LISTEN, -listen).
+	Listen string
+	// GRPCListen is the gRPC listen address (MEDIA_TRANSCRIBER_GRPC_LISTEN,
+	// -grpc-listen); empty serves REST only.
+	GRPCListen string
+	// DrainTimeout bounds how long SIGTERM waits for the running and queued
+	// jobs before cancelling them (MEDIA_TRANSCRIBER_DRAIN_TIMEOUT,
+	// -drain-timeout); zero waits until the queue is empty.
+	DrainTimeout time.Duration
+	Overrides    Overrides
+}
+
+// LoadServeOptions parses serve mode options and the setting overrides from
+// the environment and args, the arguments after the `serve` command.
+func LoadServeOptions(args []string, getenv func(string) string) (ServeOptions, error) {
+	options := ServeOptions{
+		Listen:     strings.TrimSpace(getenv(EnvPrefix + "LISTEN")),
+		GRPCListen: strings.TrimSpace(getenv(EnvPrefix + "GRPC_LISTEN")),
+	}
+	if options.Listen == "" {
+		options.Listen = DefaultListenAddr
+	}
+	if raw := strings.TrimSpace(getenv(EnvPrefix + "DRAIN_TIMEOUT")); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil {
+			return ServeOptions{}, fmt.Errorf("parse %sDRAIN_TIMEOUT: %w", EnvPrefix, err)
+		}
+		options.DrainTimeout = timeout
+	}
+
+	flags := newFlagSet()
+	overrides := bindOverrides(flags, getenv)
+	flags.StringVar(&options.Listen, "listen", options.Listen, "HTTP listen address")
+	flags.StringVar(&options.GRPCListen, "grpc-listen", options.GRPCListen, "gRPC listen address; empty disables gRPC")
+	flags.DurationVar(&options.DrainTimeout, "drain-timeout", options.DrainTimeout, "how long SIGTERM waits for queued jobs; 0 waits for all")
+	if err := flags.Parse(args); err != nil {
+		return ServeOptions{}, err
+	}
+	if flags.NArg() > 0 {
+		return ServeOptions{}, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	if options.DrainTimeout < 0 {
+		return ServeOptions{}, fmt.Errorf("drain timeout must not be negative")
+	}
+	overrides.trim()
+	options.Listen = strings.TrimSpace(options.Listen)
+	options.GRPCListen = strings.TrimSpace(options.GRPCListen)
+	options.Overrides = *overrides
+	return options, nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+// TestLoadServeOptions checks defaults, environment values, and flag precedence.
+func TestLoadServeOptions(t *testing.T) {
+	none := func(string) string { return "" }
+	defaults, err := LoadServeOptions(nil, none)
+	if err != nil {
+		t.Fatalf("defaults: %v", err)
+	}
+	if defaults.Listen != DefaultListenAddr || defaults.GRPCListen != "" || defaults.DrainTimeout != 0 {
+		t.Fatalf("defaults = %+v", defaults)
+	}
+
+	env := map[string]string{
+		"MEDIA_TRANSCRIBER_LISTEN":        "127.0.0.1:9000",
+		"MEDIA_TRANSCRIBER_GRPC_LISTEN":   ":9090",
+		"MEDIA_TRANSCRIBER_DRAIN_TIMEOUT": "30s",
+		"MEDIA_TRANSCRIBER_MODEL_PATH":    "/models/ggml-small.bin",
+	}
+	got, err := LoadServeOptions([]string{"-drain-timeout", "2m", "-grpc-listen", "127.0.0.1:9001", "--output-dir=/srv/out"}, func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got.Listen != "127.0.0.1:9000" || got.GRPCListen != "127.0.0.1:9001" || got.DrainTimeout != 2*time.Minute {
+		t.Fatalf("options = %+v", got)
+	}
+	if got.Overrides != (Overrides{ModelPath: "/models/ggml-small.bin", OutputDir: "/srv/out"}) {
+		t.Fatalf("overrides = %+v", got.Overrides)
+	}
+
+	for name, args := range map[string][]string{
+		"unknown flag":     {"-bogus"},
+		"negative timeout": {"-drain-timeout", "-1s"},
+		"extra argument":   {"now"},
+	} {
+		if _, err := LoadServeOptions(args, none); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+	if _, err := LoadServeOptions(nil, func(key string) string { return map[string]string{"MEDIA_TRANSCRIBER_DRAIN_TIMEOUT": "soon"}[key] }); err == nil {
+		t.Fatal("expected error for invalid environment timeout")
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// SourcePresetStore persists per-source output presets.
+type SourcePresetStore interface {
+	Load() ([]domain.SourcePreset, error)
+	Save([]domain.SourcePreset) error
+}
+
+// JSONSourcePresetStore persists source presets in a single JSON file on disk.
+type JSONSourcePresetStore struct {
+	path string
+}
+
+// NewJSONSourcePresetStore creates a JSON-backed source preset store.
+func NewJSONSourcePresetStore(path string) *JSONSourcePresetStore {
+	return &JSONSourcePresetStore{path: path}
+}
+
+// Load reads presets or returns none when the file is missing.
+func (s *JSONSourcePresetStore) Load() ([]domain.SourcePreset, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var presets []domain.SourcePreset
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, err
+	}
+	return presets, nil
+}
+
+// Save writes presets as indented JSON and creates parent directories.
+func (s *JSONSourcePresetStore) Save(presets []domain.SourcePreset) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	if presets == nil {
+		presets = []domain.SourcePreset{}
+	}
+
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
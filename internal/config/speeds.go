@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// SpeedStore persists the rolling speed of each model keyed by model path.
+type SpeedStore interface {
+	Load() (map[string]domain.ModelSpeed, error)
+	Save(map[string]domain.ModelSpeed) error
+}
+
+// JSONSpeedStore persists model speeds in a single JSON file on disk.
+type JSONSpeedStore struct {
+	path string
+}
+
+// NewJSONSpeedStore creates a JSON-backed model speed store.
+func NewJSONSpeedStore(path string) *JSONSpeedStore {
+	return &JSONSpeedStore{path: path}
+}
+
+// Load reads model speeds or returns an empty map when the file is missing.
+func (s *JSONSpeedStore) Load() (map[string]domain.ModelSpeed, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]domain.ModelSpeed{}, nil
+		}
+		return nil, err
+	}
+
+	speeds := map[string]domain.ModelSpeed{}
+	if err := json.Unmarshal(data, &speeds); err != nil {
+		return nil, err
+	}
+	return speeds, nil
+}
+
+// Save writes model speeds as indented JSON and creates parent directories.
+func (s *JSONSpeedStore) Save(speeds map[string]domain.ModelSpeed) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(speeds, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"media-transcriber/internal/domain"
@@ -54,7 +55,7 @@ func TestJSONStoreSaveAndLoadRoundTrip(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Load() error = %v", err)
 	}
-	if got != want {
+	if !reflect.DeepEqual(got, want) {
 		t.Fatalf("settings = %+v, want %+v", got, want)
 	}
 }
@@ -74,3 +75,25 @@ func TestJSONStoreLoadInvalidJSON(t *testing.T) {
 		t.Fatal("expected json parse error")
 	}
 }
+
+// TestJSONModelStoreRoundTrip checks custom model persistence.
+func TestJSONModelStoreRoundTrip(t *testing.T) {
+	store := NewJSONModelStore(filepath.Join(t.TempDir(), "custom-models.json"))
+
+	models, err := store.Load()
+	if err != nil || len(models) != 0 {
+		t.Fatalf("Load() on missing file = %v, %v", models, err)
+	}
+
+	want := []domain.WhisperModelOption{{ID: "custom-x", Name: "X", FileName: "x.gguf", URL: "https://example.com/x.gguf", Custom: true}}
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "custom-x" || !got[0].Custom {
+		t.Fatalf("models = %+v, want %+v", got, want)
+	}
+}
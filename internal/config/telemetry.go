@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// TelemetryStore persists when telemetry was last sent.
+type TelemetryStore interface {
+	Load() (domain.TelemetryState, error)
+	Save(domain.TelemetryState) error
+}
+
+// JSONTelemetryStore persists telemetry state in a single JSON file on disk.
+type JSONTelemetryStore struct {
+	path string
+}
+
+// NewJSONTelemetryStore creates a JSON-backed telemetry state store.
+func NewJSONTelemetryStore(path string) *JSONTelemetryStore {
+	return &JSONTelemetryStore{path: path}
+}
+
+// Load reads the telemetry state or returns the zero state when the file is missing.
+func (s *JSONTelemetryStore) Load() (domain.TelemetryState, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return domain.TelemetryState{}, nil
+		}
+		return domain.TelemetryState{}, err
+	}
+
+	var state domain.TelemetryState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return domain.TelemetryState{}, err
+	}
+	return state, nil
+}
+
+// Save writes the telemetry state as indented JSON and creates parent directories.
+func (s *JSONTelemetryStore) Save(state domain.TelemetryState) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
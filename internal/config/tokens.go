@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"media-transcriber/internal/domain"
+)
+
+// TokenStore persists server-mode API tokens.
+type TokenStore interface {
+	Load() ([]domain.APIToken, error)
+	Save([]domain.APIToken) error
+}
+
+// JSONTokenStore persists API tokens in a single user-only JSON file on disk.
+type JSONTokenStore struct {
+	path string
+}
+
+// NewJSONTokenStore creates a JSON-backed token store.
+func NewJSONTokenStore(path string) *JSONTokenStore {
+	return &JSONTokenStore{path: path}
+}
+
+// Load reads tokens or returns none when the file is missing.
+func (s *JSONTokenStore) Load() ([]domain.APIToken, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var tokens []domain.APIToken
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Save writes tokens as indented JSON readable only by the user.
+func (s *JSONTokenStore) Save(tokens []domain.APIToken) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	if tokens == nil {
+		tokens = []domain.APIToken{}
+	}
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o600)
+}
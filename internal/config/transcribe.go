@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StdinInput is the input argument that makes `transcribe` read the media
+// from standard input.
+const StdinInput = "-"
+
+// DefaultStdinName is the base name of the outputs of media read from stdin.
+const DefaultStdinName = "stdin"
+
+// TranscribeOptions configure the one-shot `transcribe` command. Like
+// Overrides they come from MEDIA_TRANSCRIBER_* variables and flags.
+type TranscribeOptions struct {
+	// Input is the media file to transcribe, or StdinInput.
+	Input string
+	// Name is the base name of the outputs when Input is StdinInput (-name).
+	Name string
+	// JSON prints a machine-readable report of the job instead of the
+	// transcript (-json).
+	JSON      bool
+	Overrides Overrides
+}
+
+// LoadTranscribeOptions parses the arguments after the `transcribe` command:
+// setting override flags followed by exactly one input.
+func LoadTranscribeOptions(args []string, getenv func(string) string) (TranscribeOptions, error) {
+	options := TranscribeOptions{}
+	flags := newFlagSet()
+	overrides := bindOverrides(flags, getenv)
+	flags.StringVar(&options.Name, "name", DefaultStdinName, "base name of the outputs of stdin input")
+	flags.BoolVar(&options.JSON, "json", false, "print a JSON report of the job instead of the transcript")
+	if err := flags.Parse(args); err != nil {
+		return TranscribeOptions{}, err
+	}
+	switch flags.NArg() {
+	case 0:
+		return TranscribeOptions{}, fmt.Errorf("input file or %s for stdin is required", StdinInput)
+	case 1:
+	default:
+		return TranscribeOptions{}, fmt.Errorf("unexpected argument %q", flags.Arg(1))
+	}
+	options.Input = strings.TrimSpace(flags.Arg(0))
+	options.Name = strings.TrimSpace(options.Name)
+	if options.Name == "" {
+		return TranscribeOptions{}, fmt.Errorf("output name must not be empty")
+	}
+	overrides.trim()
+	options.Overrides = *overrides
+	return options, nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+// TestLoadTranscribeOptions checks the input argument, the stdin name, and
+// override flags.
+func TestLoadTranscribeOptions(t *testing.T) {
+	none := func(string) string { return "" }
+	got, err := LoadTranscribeOptions([]string{"-model", "/models/ggml-small.bin", "-"}, none)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got.Input != StdinInput || got.Name != DefaultStdinName || got.Overrides != (Overrides{ModelPath: "/models/ggml-small.bin"}) {
+		t.Fatalf("options = %+v", got)
+	}
+
+	got, err = LoadTranscribeOptions([]string{"-name", "call", "-json", "/media/clip.mp4"}, none)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got.Input != "/media/clip.mp4" || got.Name != "call" || !got.JSON {
+		t.Fatalf("options = %+v", got)
+	}
+
+	for name, args := range map[string][]string{
+		"no input":       nil,
+		"two inputs":     {"a.mp4", "b.mp4"},
+		"unknown flag":   {"-bogus", "-"},
+		"empty name":     {"-name", " ", "-"},
+		"flag after arg": {"-", "-name", "call"},
+	} {
+		if _, err := LoadTranscribeOptions(args, none); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package crash writes crash reports for recovered panics to disk and turns
+// them into prefilled issue reports.
+package crash
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/jobs"
+)
+
+// IssuesURL is where users file problem reports.
+const IssuesURL = "https://github.com/korvin3/media-transcriber/issues/new"
+
+// filePrefix and fileSuffix frame the report ID in its file name.
+const (
+	filePrefix = "crash-"
+	fileSuffix = ".json"
+)
+
+// issueStackLines caps the stack trace quoted in an issue body; the full
+// trace stays in the local report for the user to attach.
+const issueStackLines = 40
+
+// Report describes one recovered panic and the state around it.
+type Report struct {
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+	OS      string    `json:"os"`
+	Arch    string    `json:"arch"`
+	// Where names the component that panicked, e.g. "job" or "retention".
+	Where string `json:"where"`
+	JobID string `json:"jobId,omitempty"`
+	Panic string `json:"panic"`
+	Stack string `json:"stack"`
+	// Settings summarizes the configuration without paths or credentials.
+	Settings map[string]string `json:"settings,omitempty"`
+	// Events are the most recent events before the panic, oldest first.
+	Events []jobs.Event `json:"events,omitempty"`
+}
+
+// Write saves report in dir, assigning an ID from its time when unset, and
+// returns the file path.
+func Write(dir string, report *Report) (string, error) {
+	if report.ID == "" {
+		report.ID = filePrefix + report.Time.UTC().Format("20060102-150405.000")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := Path(dir, report.ID)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Path returns the file of the report with id in dir.
+func Path(dir, id string) string {
+	return filepath.Join(dir, id+fileSuffix)
+}
+
+// Read loads the report with id from dir.
+func Read(dir, id string) (Report, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return Report{}, fmt.Errorf("invalid crash report id %q", id)
+	}
+	data, err := os.ReadFile(Path(dir, id))
+	if err != nil {
+		return Report{}, err
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return Report{}, err
+	}
+	return report, nil
+}
+
+// List returns the reports in dir, newest first; a missing dir has none.
+func List(dir string) ([]Report, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var reports []Report
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		report, err := Read(dir, strings.TrimSuffix(name, fileSuffix))
+		if err != nil {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Time.After(reports[j].Time) })
+	return reports, nil
+}
+
+// IssueURL returns a new-issue link prefilled with the crash summary and the
+// top of its stack. Events and settings stay local; the body asks the user
+// to attach the report file after reviewing it.
+func IssueURL(report Report) string {
+	firstLine, _, _ := strings.Cut(report.Panic, "\n")
+	title := fmt.Sprintf("Crash in %s: %s", report.Where, firstLine)
+
+	stack := strings.Split(strings.TrimRight(report.Stack, "\n"), "\n")
+	if len(stack) > issueStackLines {
+		stack = append(stack[:issueStackLines], "...")
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "**Version:** %s (%s/%s)\n", report.Version, report.OS, report.Arch)
+	fmt.Fprintf(&body, "**Component:** %s\n", report.Where)
+	fmt.Fprintf(&body, "**Time:** %s\n\n", report.Time.UTC().Format(time.RFC3339))
+	body.WriteString("**What were you doing?**\n\n\n\n")
+	fmt.Fprintf(&body, "**Panic:** `%s`\n\n```\n%s\n```\n\n", firstLine, strings.Join(stack, "\n"))
+	fmt.Fprintf(&body, "Please review and attach `%s%s` for the recent events and settings summary.\n", report.ID, fileSuffix)
+
+	return IssuesURL + "?" + url.Values{"title": {title}, "body": {body.String()}}.Encode()
+}
@@ -0,0 +1,72 @@
+package crash
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/jobs"
+)
+
+// TestWriteListRead round-trips reports and lists them newest first.
+func TestWriteListRead(t *testing.T) {
+	dir := t.TempDir()
+	if reports, err := List(filepath.Join(dir, "missing")); err != nil || reports != nil {
+		t.Fatalf("missing dir: %v, %v", reports, err)
+	}
+
+	older := &Report{Time: time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC), Where: "job", Panic: "boom"}
+	newer := &Report{Time: time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC), Where: "retention", Panic: "nil map",
+		Events: []jobs.Event{{Seq: 1, Message: "Job started"}}}
+	for _, report := range []*Report{older, newer} {
+		if _, err := Write(dir, report); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	reports, err := List(dir)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(reports) != 2 || reports[0].ID != newer.ID || reports[1].ID != older.ID {
+		t.Fatalf("reports = %+v", reports)
+	}
+	if len(reports[0].Events) != 1 || reports[0].Events[0].Message != "Job started" {
+		t.Fatalf("events = %+v", reports[0].Events)
+	}
+	if _, err := Read(dir, "../"+older.ID); err == nil {
+		t.Fatal("read should reject path separators in the id")
+	}
+}
+
+// TestIssueURL prefills the title and stack but leaves events and settings local.
+func TestIssueURL(t *testing.T) {
+	report := Report{
+		ID: "crash-1", Version: "v1.2.0", OS: "darwin", Arch: "arm64", Where: "job",
+		Panic:    "runtime error: index out of range\nmore",
+		Stack:    strings.Repeat("frame\n", 100),
+		Settings: map[string]string{"language": "secret-setting"},
+		Events:   []jobs.Event{{Message: "/Users/ann/private.mp3"}},
+	}
+	link, err := url.Parse(IssueURL(report))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	query := link.Query()
+	if got := query.Get("title"); got != "Crash in job: runtime error: index out of range" {
+		t.Fatalf("title = %q", got)
+	}
+	body := query.Get("body")
+	if strings.Count(body, "frame") != issueStackLines || !strings.Contains(body, "crash-1.json") {
+		t.Fatalf("body = %q", body)
+	}
+	if strings.Contains(body, "private.mp3") || strings.Contains(body, "secret-setting") {
+		t.Fatalf("body leaks local details: %q", body)
+	}
+}
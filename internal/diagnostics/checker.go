@@ -1,16 +1,20 @@
 package diagnostics
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
+	goruntime "runtime"
+	"sort"
 	"strings"
 	"time"
 
 	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
 )
 
 // Checker validates external tools and required filesystem paths.
@@ -21,6 +25,11 @@ type Checker struct {
 	mkdirAll   func(string, os.FileMode) error
 	createTemp func(string, string) (*os.File, error)
 	remove     func(string) error
+	probe      func(context.Context, string) error
+	validate   func(string) error
+	hwaccels   func(context.Context, string) ([]string, error)
+	gpus       func() []sysinfo.GPU
+	goos       string
 }
 
 // NewChecker builds a checker using real OS dependencies.
@@ -32,19 +41,43 @@ func NewChecker() *Checker {
 		mkdirAll:   os.MkdirAll,
 		createTemp: os.CreateTemp,
 		remove:     os.Remove,
+		probe:      probeHTTP,
+		validate:   validateModelFile,
+		hwaccels:   listHWAccels,
+		gpus:       sysinfo.DetectGPUs,
+		goos:       goruntime.GOOS,
 	}
 }
 
 // Run executes all startup checks and returns a combined report.
 func (c *Checker) Run(settings domain.Settings) domain.DiagnosticReport {
+	items := append(c.localItems(settings), c.checkHWAccel(settings), c.checkGPUDevice(settings))
+	return newReport(append(items, c.checkNetwork()...))
+}
+
+// RunLocal checks only tools, the model, and the output directory, skipping
+// network probes, so readiness does not depend on third-party hosts.
+func (c *Checker) RunLocal(settings domain.Settings) domain.DiagnosticReport {
+	return newReport(c.localItems(settings))
+}
+
+// localItems runs every check that needs no network access.
+func (c *Checker) localItems(settings domain.Settings) []domain.DiagnosticItem {
 	items := []domain.DiagnosticItem{
-		c.checkTool("ffmpeg"),
-		c.checkTool("ffprobe"),
-		c.checkTool("whisper.cpp"),
+		c.checkTool("ffmpeg", settings.FFmpegPath),
+		c.checkTool("ffprobe", c.ffprobeBeside(settings.FFmpegPath)),
+		c.checkTool("whisper.cpp", settings.WhisperPath),
 		c.checkModelPath(settings.ModelPath),
 		c.checkOutputDir(settings.OutputDir),
 	}
+	if c.goos == "darwin" {
+		items = append(items, c.checkCoreML(settings.ModelPath))
+	}
+	return items
+}
 
+// newReport stamps items and flags whether any of them failed.
+func newReport(items []domain.DiagnosticItem) domain.DiagnosticReport {
 	hasFailures := false
 	for _, item := range items {
 		if item.Status == domain.DiagnosticStatusFail {
@@ -60,8 +93,27 @@ func (c *Checker) Run(settings domain.Settings) domain.DiagnosticReport {
 	}
 }
 
-// checkTool verifies a required CLI executable is on PATH.
-func (c *Checker) checkTool(name string) domain.DiagnosticItem {
+// checkTool verifies a required CLI executable at the configured path or on PATH.
+func (c *Checker) checkTool(name string, configuredPath string) domain.DiagnosticItem {
+	if configured := strings.TrimSpace(configuredPath); configured != "" {
+		path, err := c.lookPath(configured)
+		if err != nil {
+			return domain.DiagnosticItem{
+				ID:      "tool_" + name,
+				Name:    name,
+				Status:  domain.DiagnosticStatusFail,
+				Message: fmt.Sprintf("Configured %s is not an executable: %s", name, configured),
+				Hint:    "Fix the path in settings, or clear it to look the tool up on PATH.",
+			}
+		}
+		return domain.DiagnosticItem{
+			ID:      "tool_" + name,
+			Name:    name,
+			Status:  domain.DiagnosticStatusPass,
+			Message: fmt.Sprintf("Using configured %s", path),
+		}
+	}
+
 	path, err := c.lookPath(name)
 	if err != nil {
 		return domain.DiagnosticItem{
@@ -81,6 +133,20 @@ func (c *Checker) checkTool(name string) domain.DiagnosticItem {
 	}
 }
 
+// ffprobeBeside returns the ffprobe next to a configured ffmpeg, or "" to use PATH.
+func (c *Checker) ffprobeBeside(ffmpegPath string) string {
+	ffmpegPath = strings.TrimSpace(ffmpegPath)
+	if ffmpegPath == "" {
+		return ""
+	}
+
+	candidate := filepath.Join(filepath.Dir(ffmpegPath), "ffprobe"+filepath.Ext(ffmpegPath))
+	if info, err := c.stat(candidate); err == nil && !info.IsDir() {
+		return candidate
+	}
+	return ""
+}
+
 // checkModelPath validates configured model file or model directory.
 func (c *Checker) checkModelPath(modelPath string) domain.DiagnosticItem {
 	item := domain.DiagnosticItem{
@@ -108,6 +174,9 @@ func (c *Checker) checkModelPath(modelPath string) domain.DiagnosticItem {
 	}
 
 	if !info.IsDir() {
+		if err := c.validate(modelPath); err != nil {
+			return invalidModelItem(item, modelPath, err)
+		}
 		item.Status = domain.DiagnosticStatusPass
 		item.Message = fmt.Sprintf("Model file found: %s", modelPath)
 		return item
@@ -121,17 +190,27 @@ func (c *Checker) checkModelPath(modelPath string) domain.DiagnosticItem {
 		return item
 	}
 
+	modelNames := make([]string, 0, len(entries))
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
 		ext := strings.ToLower(filepath.Ext(entry.Name()))
 		if ext == ".bin" || ext == ".gguf" {
-			item.Status = domain.DiagnosticStatusPass
-			item.Message = fmt.Sprintf("Model directory is valid: %s", modelPath)
-			return item
+			modelNames = append(modelNames, entry.Name())
 		}
 	}
+	if len(modelNames) > 0 {
+		// Validate the file the pipeline will pick (lexically first).
+		sort.Strings(modelNames)
+		selected := filepath.Join(modelPath, modelNames[0])
+		if err := c.validate(selected); err != nil {
+			return invalidModelItem(item, selected, err)
+		}
+		item.Status = domain.DiagnosticStatusPass
+		item.Message = fmt.Sprintf("Model directory is valid: %s", modelPath)
+		return item
+	}
 
 	item.Status = domain.DiagnosticStatusFail
 	item.Message = fmt.Sprintf("No model files found in directory: %s", modelPath)
@@ -139,6 +218,14 @@ func (c *Checker) checkModelPath(modelPath string) domain.DiagnosticItem {
 	return item
 }
 
+// invalidModelItem reports a model file that exists but cannot be loaded.
+func invalidModelItem(item domain.DiagnosticItem, path string, err error) domain.DiagnosticItem {
+	item.Status = domain.DiagnosticStatusFail
+	item.Message = fmt.Sprintf("Model file is not a valid whisper.cpp model: %s (%v)", path, err)
+	item.Hint = "Delete the file and download the model again; GGML (.bin) and GGUF (.gguf) formats are supported."
+	return item
+}
+
 // checkOutputDir validates output directory existence and write access.
 func (c *Checker) checkOutputDir(outputDir string) domain.DiagnosticItem {
 	item := domain.DiagnosticItem{
@@ -193,6 +280,14 @@ func NewCheckerForTests(
 		mkdirAll:   mkdirAll,
 		createTemp: createTemp,
 		remove:     remove,
+		// Network probes, model header validation, and the hwaccel and GPU
+		// listings succeed by default so tests stay offline and can use stub
+		// files.
+		probe:    func(context.Context, string) error { return nil },
+		validate: func(string) error { return nil },
+		hwaccels: func(context.Context, string) ([]string, error) { return nil, nil },
+		gpus:     func() []sysinfo.GPU { return nil },
+		goos:     goruntime.GOOS,
 	}
 }
 
@@ -1,9 +1,11 @@
 package diagnostics
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"media-transcriber/internal/domain"
@@ -109,3 +111,184 @@ func assertStatusByID(t *testing.T, report domain.DiagnosticReport, id string, w
 	}
 	t.Fatalf("diagnostic item not found: %s", id)
 }
+
+// TestCheckerRunReportsUnreachableHosts validates network failure reporting.
+func TestCheckerRunReportsUnreachableHosts(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "")
+	checker := NewCheckerForTests(
+		func(name string) (string, error) { return "/usr/local/bin/" + name, nil },
+		os.Stat,
+		os.ReadDir,
+		os.MkdirAll,
+		os.CreateTemp,
+		os.Remove,
+	)
+	checker.probe = func(ctx context.Context, url string) error {
+		if url == "https://huggingface.co" {
+			return errors.New("dial tcp: i/o timeout")
+		}
+		return nil
+	}
+
+	report := checker.Run(domain.Settings{})
+
+	assertStatusByID(t, report, "network_huggingface", domain.DiagnosticStatusFail)
+	assertStatusByID(t, report, "network_github", domain.DiagnosticStatusPass)
+}
+
+// TestCheckerRunLocalSkipsNetwork keeps unreachable hosts out of readiness checks.
+func TestCheckerRunLocalSkipsNetwork(t *testing.T) {
+	root := t.TempDir()
+	modelFile := filepath.Join(root, "ggml-base.bin")
+	if err := os.WriteFile(modelFile, []byte("stub"), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	checker := NewCheckerForTests(
+		func(name string) (string, error) { return "/usr/local/bin/" + name, nil },
+		os.Stat,
+		os.ReadDir,
+		os.MkdirAll,
+		os.CreateTemp,
+		os.Remove,
+	)
+	checker.goos = "linux"
+	checker.probe = func(context.Context, string) error { return errors.New("offline") }
+
+	report := checker.RunLocal(domain.Settings{ModelPath: modelFile, OutputDir: filepath.Join(root, "out")})
+	if report.HasFailures {
+		t.Fatalf("expected local checks to pass, got %+v", report.Items)
+	}
+	for _, item := range report.Items {
+		if strings.HasPrefix(item.ID, "network_") {
+			t.Fatalf("unexpected network item %s", item.ID)
+		}
+	}
+}
+
+// TestCheckerRunRejectsInvalidModelFile validates header checks on model files.
+func TestCheckerRunRejectsInvalidModelFile(t *testing.T) {
+	root := t.TempDir()
+	modelFile := filepath.Join(root, "ggml-base.bin")
+	if err := os.WriteFile(modelFile, []byte("<html>Not Found</html>"), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+
+	checker := NewCheckerForTests(
+		func(name string) (string, error) { return "/usr/local/bin/" + name, nil },
+		os.Stat,
+		os.ReadDir,
+		os.MkdirAll,
+		os.CreateTemp,
+		os.Remove,
+	)
+	checker.validate = validateModelFile
+
+	report := checker.Run(domain.Settings{
+		ModelPath: modelFile,
+		OutputDir: filepath.Join(root, "output"),
+	})
+	assertStatusByID(t, report, "model_path", domain.DiagnosticStatusFail)
+
+	report = checker.Run(domain.Settings{
+		ModelPath: root,
+		OutputDir: filepath.Join(root, "output"),
+	})
+	assertStatusByID(t, report, "model_path", domain.DiagnosticStatusFail)
+}
+
+// TestCheckerRunReportsCoreMLEncoderOnMacOS validates the CoreML item message.
+func TestCheckerRunReportsCoreMLEncoderOnMacOS(t *testing.T) {
+	root := t.TempDir()
+	modelFile := filepath.Join(root, "ggml-base.en-q5_0.bin")
+	if err := os.WriteFile(modelFile, []byte("stub"), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+
+	checker := NewCheckerForTests(
+		func(name string) (string, error) { return "/usr/local/bin/" + name, nil },
+		os.Stat,
+		os.ReadDir,
+		os.MkdirAll,
+		os.CreateTemp,
+		os.Remove,
+	)
+	checker.goos = "darwin"
+	settings := domain.Settings{ModelPath: root, OutputDir: filepath.Join(root, "output")}
+
+	item := findItem(t, checker.Run(settings), "coreml")
+	if item.Status != domain.DiagnosticStatusPass || !strings.HasPrefix(item.Message, "Not in use") {
+		t.Fatalf("expected encoder missing, got %+v", item)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "ggml-base.en-encoder.mlmodelc"), 0o755); err != nil {
+		t.Fatalf("mkdir encoder: %v", err)
+	}
+	item = findItem(t, checker.Run(settings), "coreml")
+	if !strings.HasPrefix(item.Message, "In use") {
+		t.Fatalf("expected encoder in use, got %+v", item)
+	}
+
+	checker.goos = "linux"
+	for _, other := range checker.Run(settings).Items {
+		if other.ID == "coreml" {
+			t.Fatal("coreml item should only be reported on macOS")
+		}
+	}
+}
+
+// findItem returns one diagnostic item by ID.
+func findItem(t *testing.T, report domain.DiagnosticReport, id string) domain.DiagnosticItem {
+	t.Helper()
+	for _, item := range report.Items {
+		if item.ID == id {
+			return item
+		}
+	}
+	t.Fatalf("diagnostic item not found: %s", id)
+	return domain.DiagnosticItem{}
+}
+
+// TestCheckerRunUsesConfiguredToolPaths validates overrides of PATH lookup.
+func TestCheckerRunUsesConfiguredToolPaths(t *testing.T) {
+	root := t.TempDir()
+	ffmpeg := filepath.Join(root, "ffmpeg")
+	ffprobe := filepath.Join(root, "ffprobe")
+	for _, path := range []string{ffmpeg, ffprobe} {
+		if err := os.WriteFile(path, []byte("bin"), 0o755); err != nil {
+			t.Fatalf("write tool: %v", err)
+		}
+	}
+
+	var looked []string
+	checker := NewCheckerForTests(
+		func(name string) (string, error) {
+			looked = append(looked, name)
+			if name == ffmpeg || name == ffprobe {
+				return name, nil
+			}
+			return "", errors.New("not found")
+		},
+		os.Stat,
+		os.ReadDir,
+		os.MkdirAll,
+		os.CreateTemp,
+		os.Remove,
+	)
+
+	report := checker.Run(domain.Settings{
+		FFmpegPath:  ffmpeg,
+		WhisperPath: filepath.Join(root, "missing-whisper"),
+		OutputDir:   filepath.Join(root, "output"),
+	})
+
+	assertStatusByID(t, report, "tool_ffmpeg", domain.DiagnosticStatusPass)
+	assertStatusByID(t, report, "tool_ffprobe", domain.DiagnosticStatusPass)
+	assertStatusByID(t, report, "tool_whisper.cpp", domain.DiagnosticStatusFail)
+	if item := findItem(t, report, "tool_whisper.cpp"); !strings.Contains(item.Message, "Configured") {
+		t.Fatalf("expected configured-path failure, got %+v", item)
+	}
+	if looked[2] != filepath.Join(root, "missing-whisper") {
+		t.Fatalf("expected whisper override lookup, got %v", looked)
+	}
+}
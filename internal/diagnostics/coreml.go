@@ -0,0 +1,71 @@
+package diagnostics
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/modelfile"
+)
+
+// checkCoreML reports whether the configured model has a CoreML encoder beside it.
+// The item never fails: without the encoder whisper.cpp still runs on Metal and CPU.
+func (c *Checker) checkCoreML(modelPath string) domain.DiagnosticItem {
+	item := domain.DiagnosticItem{
+		ID:     "coreml",
+		Name:   "CoreML acceleration",
+		Status: domain.DiagnosticStatusPass,
+	}
+
+	model := c.selectedModelFile(modelPath)
+	if model == "" {
+		item.Message = "Not in use: no model file is configured."
+		return item
+	}
+
+	encoder := modelfile.CoreMLEncoderPath(model)
+	if info, err := c.stat(encoder); err != nil || !info.IsDir() {
+		item.Message = fmt.Sprintf("Not in use: no CoreML encoder found for %s.", filepath.Base(model))
+		item.Hint = fmt.Sprintf("Download the model from the catalog to fetch %s, or place it beside the model; whisper.cpp then runs the encoder on the Apple Neural Engine.", filepath.Base(encoder))
+		return item
+	}
+
+	item.Message = fmt.Sprintf("In use: CoreML encoder found at %s.", encoder)
+	item.Hint = "Requires a whisper.cpp build with CoreML support (WHISPER_COREML=1); other builds ignore the encoder."
+	return item
+}
+
+// selectedModelFile resolves the model file the pipeline will load, or "" when there is none.
+func (c *Checker) selectedModelFile(modelPath string) string {
+	modelPath = strings.TrimSpace(modelPath)
+	if modelPath == "" {
+		return ""
+	}
+
+	info, err := c.stat(modelPath)
+	if err != nil {
+		return ""
+	}
+	if !info.IsDir() {
+		return modelPath
+	}
+
+	entries, err := c.readDir(modelPath)
+	if err != nil {
+		return ""
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".bin" || ext == ".gguf") {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return filepath.Join(modelPath, names[0])
+}
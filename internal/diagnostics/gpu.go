@@ -0,0 +1,52 @@
+package diagnostics
+
+import (
+	"fmt"
+	"strconv"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
+)
+
+// checkGPUDevice reports which GPU whisper.cpp runs on and lists the detected
+// ones. It fails only when the GPUDevice setting names a GPU that is not
+// there, because jobs refuse to start in that case.
+func (c *Checker) checkGPUDevice(settings domain.Settings) domain.DiagnosticItem {
+	item := domain.DiagnosticItem{
+		ID:     "whisper_gpu",
+		Name:   "whisper.cpp GPU",
+		Status: domain.DiagnosticStatusPass,
+	}
+	if settings.GPUDevice == domain.GPUDeviceCPU {
+		item.Message = "Off: whisper.cpp runs on the CPU."
+		return item
+	}
+
+	gpus := c.gpus()
+	detected := sysinfo.DescribeGPUs(gpus)
+	switch {
+	case settings.GPUDevice == "" && len(gpus) > 1:
+		item.Message = fmt.Sprintf("whisper.cpp picks the GPU. Detected: %s.", detected)
+		item.Hint = "Choose a GPU in settings to keep transcription off the display GPU."
+	case settings.GPUDevice == "":
+		item.Message = fmt.Sprintf("whisper.cpp picks the GPU. Detected: %s.", detected)
+	case len(gpus) == 0:
+		if _, err := strconv.Atoi(settings.GPUDevice); err == nil {
+			item.Message = fmt.Sprintf("Using device %s; no GPUs could be listed to confirm it.", settings.GPUDevice)
+			return item
+		}
+		item.Status = domain.DiagnosticStatusFail
+		item.Message = fmt.Sprintf("GPU %q was not found: no GPUs were detected", settings.GPUDevice)
+		item.Hint = "Check the GPU driver, or clear the GPU setting."
+	default:
+		gpu, err := sysinfo.SelectGPU(gpus, settings.GPUDevice)
+		if err != nil {
+			item.Status = domain.DiagnosticStatusFail
+			item.Message = err.Error()
+			item.Hint = "Choose one of the detected GPUs in settings, or clear the GPU setting."
+			return item
+		}
+		item.Message = fmt.Sprintf("Using GPU %d: %s.", gpu.Index, gpu.Name)
+	}
+	return item
+}
@@ -0,0 +1,55 @@
+package diagnostics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/sysinfo"
+)
+
+// TestCheckerRunReportsGPUDevice validates the whisper GPU item messages.
+func TestCheckerRunReportsGPUDevice(t *testing.T) {
+	root := t.TempDir()
+	twoGPUs := []sysinfo.GPU{{Index: 0, Name: "NVIDIA GeForce RTX 3060"}, {Index: 1, Name: "NVIDIA GeForce RTX 3090"}}
+	tests := []struct {
+		name     string
+		device   string
+		gpus     []sysinfo.GPU
+		wantFail bool
+		want     string
+		wantHint bool
+	}{
+		{name: "default with two", gpus: twoGPUs, want: "whisper.cpp picks the GPU. Detected: 0: NVIDIA GeForce RTX 3060, 1: NVIDIA GeForce RTX 3090.", wantHint: true},
+		{name: "default without gpu", want: "whisper.cpp picks the GPU. Detected: none."},
+		{name: "cpu", device: domain.GPUDeviceCPU, gpus: twoGPUs, want: "Off: whisper.cpp runs on the CPU."},
+		{name: "by name", device: "3090", gpus: twoGPUs, want: "Using GPU 1: NVIDIA GeForce RTX 3090."},
+		{name: "by index", device: "0", gpus: twoGPUs, want: "Using GPU 0: NVIDIA GeForce RTX 3060."},
+		{name: "unlisted index", device: "1", want: "Using device 1; no GPUs could be listed to confirm it."},
+		{name: "missing", device: "4090", gpus: twoGPUs, wantFail: true, want: `no GPU matches "4090"`, wantHint: true},
+		{name: "missing without gpu", device: "rtx", wantFail: true, want: "no GPUs were detected", wantHint: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewCheckerForTests(
+				func(name string) (string, error) { return "/usr/bin/" + name, nil },
+				os.Stat,
+				os.ReadDir,
+				os.MkdirAll,
+				os.CreateTemp,
+				os.Remove,
+			)
+			checker.gpus = func() []sysinfo.GPU { return tt.gpus }
+			report := checker.Run(domain.Settings{OutputDir: filepath.Join(root, "out"), GPUDevice: tt.device})
+			item := findItem(t, report, "whisper_gpu")
+			if (item.Status == domain.DiagnosticStatusFail) != tt.wantFail || !strings.Contains(item.Message, tt.want) {
+				t.Fatalf("item = %+v, want fail %v and message %q", item, tt.wantFail, tt.want)
+			}
+			if (item.Hint != "") != tt.wantHint {
+				t.Fatalf("hint = %q, want hint %v", item.Hint, tt.wantHint)
+			}
+		})
+	}
+}
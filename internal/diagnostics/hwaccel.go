@@ -0,0 +1,98 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// hwaccelProbeTimeout bounds the `ffmpeg -hwaccels` call.
+const hwaccelProbeTimeout = 5 * time.Second
+
+// listHWAccels asks ffmpeg which -hwaccel methods it was built with.
+func listHWAccels(ctx context.Context, ffmpegPath string) ([]string, error) {
+	out, err := exec.CommandContext(ctx, ffmpegPath, "-hide_banner", "-hwaccels").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseHWAccels(string(out)), nil
+}
+
+// parseHWAccels extracts the method names listed after ffmpeg's
+// "Hardware acceleration methods:" header.
+func parseHWAccels(output string) []string {
+	var methods []string
+	listing := false
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Hardware acceleration methods"):
+			listing = true
+		case listing && line != "":
+			methods = append(methods, line)
+		}
+	}
+	return methods
+}
+
+// checkHWAccel lists the hardware decoders the installed ffmpeg supports and
+// whether the configured one is among them. The item never fails: an
+// unsupported method falls back to CPU decoding.
+func (c *Checker) checkHWAccel(settings domain.Settings) domain.DiagnosticItem {
+	item := domain.DiagnosticItem{
+		ID:     "ffmpeg_hwaccel",
+		Name:   "ffmpeg hardware decoding",
+		Status: domain.DiagnosticStatusPass,
+	}
+
+	name := "ffmpeg"
+	if configured := strings.TrimSpace(settings.FFmpegPath); configured != "" {
+		name = configured
+	}
+	path, err := c.lookPath(name)
+	if err != nil {
+		item.Message = "Not checked: ffmpeg was not found."
+		return item
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hwaccelProbeTimeout)
+	defer cancel()
+	methods, err := c.hwaccels(ctx, path)
+	if err != nil {
+		item.Message = fmt.Sprintf("Could not list hardware decoders: %v", err)
+		return item
+	}
+	supported := "none"
+	if len(methods) > 0 {
+		supported = strings.Join(methods, ", ")
+	}
+
+	selected := string(settings.FFmpegHWAccel)
+	switch {
+	case selected == "":
+		item.Message = fmt.Sprintf("Off. Supported by ffmpeg: %s.", supported)
+		if len(methods) > 0 {
+			item.Hint = "Choose a hardware decoder in settings to speed up preprocessing of large video files."
+		}
+	case settings.FFmpegHWAccel == domain.HWAccelAuto || containsString(methods, selected):
+		item.Message = fmt.Sprintf("Using %s. Supported by ffmpeg: %s.", selected, supported)
+	default:
+		item.Message = fmt.Sprintf("%s is not supported by this ffmpeg build. Supported: %s.", selected, supported)
+		item.Hint = "Preprocessing falls back to CPU decoding; pick a supported method or auto."
+	}
+	return item
+}
+
+// containsString reports whether values holds value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package diagnostics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestParseHWAccels reads the method list printed by `ffmpeg -hwaccels`.
+func TestParseHWAccels(t *testing.T) {
+	output := "Hardware acceleration methods:\nvdpau\ncuda\nvaapi\n\n"
+	got := strings.Join(parseHWAccels(output), ",")
+	if got != "vdpau,cuda,vaapi" {
+		t.Fatalf("methods = %q", got)
+	}
+	if methods := parseHWAccels("ffmpeg version 6.1\n"); len(methods) != 0 {
+		t.Fatalf("methods without header = %v", methods)
+	}
+}
+
+// TestCheckerRunReportsHWAccelSupport validates the hwaccel item messages.
+func TestCheckerRunReportsHWAccelSupport(t *testing.T) {
+	root := t.TempDir()
+	checker := NewCheckerForTests(
+		func(name string) (string, error) { return "/usr/bin/" + name, nil },
+		os.Stat,
+		os.ReadDir,
+		os.MkdirAll,
+		os.CreateTemp,
+		os.Remove,
+	)
+	var probed string
+	checker.hwaccels = func(_ context.Context, path string) ([]string, error) {
+		probed = path
+		return []string{"cuda", "vaapi"}, nil
+	}
+
+	tests := []struct {
+		name     string
+		hwaccel  domain.HWAccel
+		prefix   string
+		wantHint bool
+	}{
+		{name: "off", hwaccel: domain.HWAccelOff, prefix: "Off.", wantHint: true},
+		{name: "supported", hwaccel: domain.HWAccelCUDA, prefix: "Using cuda."},
+		{name: "auto", hwaccel: domain.HWAccelAuto, prefix: "Using auto."},
+		{name: "unsupported", hwaccel: domain.HWAccelVideoToolbox, prefix: "videotoolbox is not supported", wantHint: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := checker.Run(domain.Settings{OutputDir: filepath.Join(root, "out"), FFmpegHWAccel: tt.hwaccel})
+			item := findItem(t, report, "ffmpeg_hwaccel")
+			if item.Status != domain.DiagnosticStatusPass || !strings.HasPrefix(item.Message, tt.prefix) {
+				t.Fatalf("item = %+v, want message prefix %q", item, tt.prefix)
+			}
+			if (item.Hint != "") != tt.wantHint {
+				t.Fatalf("hint = %q, want hint %v", item.Hint, tt.wantHint)
+			}
+			if !strings.Contains(item.Message, "cuda, vaapi") {
+				t.Fatalf("message should list supported methods: %q", item.Message)
+			}
+		})
+	}
+	if probed != "/usr/bin/ffmpeg" {
+		t.Fatalf("probed %q, want /usr/bin/ffmpeg", probed)
+	}
+}
@@ -0,0 +1,27 @@
+package diagnostics
+
+import (
+	"fmt"
+	"os"
+
+	"media-transcriber/internal/modelfile"
+)
+
+// minModelFileBytes is below the smallest quantized whisper model; smaller files are partial downloads.
+const minModelFileBytes = 16 << 20
+
+// validateModelFile checks size and GGML/GGUF header of a model file.
+func validateModelFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Size() < minModelFileBytes {
+		return fmt.Errorf("file is only %d bytes; the download is likely incomplete", info.Size())
+	}
+
+	if _, err := modelfile.Inspect(path); err != nil {
+		return err
+	}
+	return nil
+}
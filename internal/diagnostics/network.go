@@ -0,0 +1,114 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// networkProbeTimeout keeps connectivity checks short so startup is not blocked.
+const networkProbeTimeout = 4 * time.Second
+
+// networkTarget is one remote host required by downloads and installers.
+type networkTarget struct {
+	id      string
+	name    string
+	url     string
+	purpose string
+}
+
+var networkTargets = []networkTarget{
+	{
+		id:      "network_huggingface",
+		name:    "huggingface.co",
+		url:     "https://huggingface.co",
+		purpose: "model downloads",
+	},
+	{
+		id:      "network_github",
+		name:    "api.github.com",
+		url:     "https://api.github.com",
+		purpose: "whisper.cpp release installs",
+	},
+}
+
+// probeHTTP sends a HEAD request; any HTTP response means the host is reachable.
+func probeHTTP(ctx context.Context, rawURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "media-transcriber")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// checkNetwork probes all download hosts concurrently.
+func (c *Checker) checkNetwork() []domain.DiagnosticItem {
+	items := make([]domain.DiagnosticItem, len(networkTargets))
+	var wg sync.WaitGroup
+	for i, target := range networkTargets {
+		wg.Add(1)
+		go func(i int, target networkTarget) {
+			defer wg.Done()
+			items[i] = c.checkHost(target)
+		}(i, target)
+	}
+	wg.Wait()
+	return items
+}
+
+// checkHost validates reachability of one host, honoring HTTP(S)_PROXY.
+func (c *Checker) checkHost(target networkTarget) domain.DiagnosticItem {
+	item := domain.DiagnosticItem{
+		ID:   target.id,
+		Name: target.name,
+	}
+
+	proxy := proxyHostFor(target.url)
+
+	ctx, cancel := context.WithTimeout(context.Background(), networkProbeTimeout)
+	defer cancel()
+	if err := c.probe(ctx, target.url); err != nil {
+		item.Status = domain.DiagnosticStatusFail
+		if proxy != "" {
+			item.Message = fmt.Sprintf("Cannot reach %s via proxy %s; %s will fail.", target.name, proxy, target.purpose)
+			item.Hint = "Check that the proxy in HTTPS_PROXY/HTTP_PROXY is reachable and allows this host, or add it to NO_PROXY."
+		} else {
+			item.Message = fmt.Sprintf("Cannot reach %s; %s will fail.", target.name, target.purpose)
+			item.Hint = "Check your internet connection and firewall. Behind a corporate proxy, set HTTPS_PROXY before starting the app."
+		}
+		return item
+	}
+
+	item.Status = domain.DiagnosticStatusPass
+	item.Message = "Reachable"
+	if proxy != "" {
+		item.Message = fmt.Sprintf("Reachable via proxy %s", proxy)
+	}
+	return item
+}
+
+// proxyHostFor returns the proxy host used for rawURL, without credentials.
+func proxyHostFor(rawURL string) string {
+	req, err := http.NewRequest(http.MethodHead, rawURL, nil)
+	if err != nil {
+		return ""
+	}
+
+	proxyURL, err := http.ProxyFromEnvironment(req)
+	if err != nil || proxyURL == nil {
+		return ""
+	}
+	return (&url.URL{Scheme: proxyURL.Scheme, Host: proxyURL.Host}).String()
+}
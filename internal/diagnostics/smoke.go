@@ -0,0 +1,150 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// Transcriber runs one transcription request through the pipeline.
+type Transcriber interface {
+	Run(ctx context.Context, req transcribe.Request) (transcribe.Result, error)
+}
+
+// SmokeTester proves the toolchain works by transcribing a generated test tone.
+type SmokeTester struct {
+	ffmpegPath string
+	pipeline   Transcriber
+	runCommand func(ctx context.Context, name string, args ...string) ([]byte, error)
+	mkdirTemp  func(dir, pattern string) (string, error)
+	removeAll  func(string) error
+	stat       func(string) (os.FileInfo, error)
+}
+
+// NewSmokeTester builds a smoke tester using real OS dependencies.
+func NewSmokeTester(pipeline Transcriber) *SmokeTester {
+	return &SmokeTester{
+		ffmpegPath: "ffmpeg",
+		pipeline:   pipeline,
+		runCommand: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return exec.CommandContext(ctx, name, args...).CombinedOutput()
+		},
+		mkdirTemp: os.MkdirTemp,
+		removeAll: os.RemoveAll,
+		stat:      os.Stat,
+	}
+}
+
+// Run generates a 3-second tone, transcribes it with configured settings, and checks the transcript file.
+func (s *SmokeTester) Run(ctx context.Context, settings domain.Settings) domain.DiagnosticItem {
+	item := domain.DiagnosticItem{
+		ID:   "smoke_test",
+		Name: "End-to-end smoke test",
+	}
+
+	if s.pipeline == nil {
+		item.Status = domain.DiagnosticStatusFail
+		item.Message = "Transcription pipeline is not configured."
+		return item
+	}
+
+	workDir, err := s.mkdirTemp(strings.TrimSpace(settings.TempDir), "media-transcriber-smoke-*")
+	if err != nil {
+		item.Status = domain.DiagnosticStatusFail
+		item.Message = "Cannot create temporary directory for smoke test."
+		item.Hint = "Check free disk space and permissions of the configured temp directory."
+		return item
+	}
+	defer func() { _ = s.removeAll(workDir) }()
+
+	ffmpegPath := s.ffmpegPath
+	if configured := strings.TrimSpace(settings.FFmpegPath); configured != "" {
+		ffmpegPath = configured
+	}
+
+	tonePath := filepath.Join(workDir, "smoke-tone.wav")
+	output, err := s.runCommand(ctx, ffmpegPath, buildToneArgs(tonePath)...)
+	if err != nil {
+		item.Status = domain.DiagnosticStatusFail
+		item.Message = fmt.Sprintf("ffmpeg could not generate a test tone: %v", err)
+		if trimmed := strings.TrimSpace(string(output)); trimmed != "" {
+			item.Message += " (" + lastLine(trimmed) + ")"
+		}
+		item.Hint = "Reinstall ffmpeg; the installed build may lack the lavfi input device."
+		return item
+	}
+
+	result, err := s.pipeline.Run(ctx, transcribe.Request{
+		InputPath:   tonePath,
+		ModelPath:   settings.ModelPath,
+		Language:    settings.Language,
+		OutputDir:   workDir,
+		FFmpegPath:  settings.FFmpegPath,
+		WhisperPath: settings.WhisperPath,
+		TempDir:     settings.TempDir,
+	})
+	defer func() { _ = result.Cleanup() }()
+	if err != nil {
+		item.Status = domain.DiagnosticStatusFail
+		item.Message = fmt.Sprintf("Pipeline failed on test tone: %v", err)
+		item.Hint = "Check the model file and whisper.cpp installation, then run the smoke test again."
+		var pipelineErr *transcribe.PipelineError
+		if errors.As(err, &pipelineErr) && pipelineErr.Stage == "preprocessing" {
+			item.Hint = "Check the ffmpeg installation, then run the smoke test again."
+		}
+		return item
+	}
+
+	if _, err := s.stat(result.TextPath); err != nil {
+		item.Status = domain.DiagnosticStatusFail
+		item.Message = "Pipeline completed but no transcript file was written."
+		item.Hint = "Check that the whisper.cpp build supports txt output (-otxt)."
+		return item
+	}
+
+	item.Status = domain.DiagnosticStatusPass
+	item.Message = "Test tone transcribed successfully with the configured model."
+	return item
+}
+
+// buildToneArgs builds ffmpeg args that synthesize a 3-second 440 Hz sine tone.
+func buildToneArgs(outPath string) []string {
+	return []string{
+		"-hide_banner",
+		"-nostdin",
+		"-y",
+		"-f", "lavfi",
+		"-i", "sine=frequency=440:duration=3",
+		"-ac", "1",
+		"-ar", "16000",
+		outPath,
+	}
+}
+
+// lastLine returns the final line of multi-line command output.
+func lastLine(text string) string {
+	lines := strings.Split(text, "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// NewSmokeTesterForTests creates smoke tester with injectable dependencies.
+func NewSmokeTesterForTests(
+	pipeline Transcriber,
+	runCommand func(ctx context.Context, name string, args ...string) ([]byte, error),
+) *SmokeTester {
+	return &SmokeTester{
+		ffmpegPath: "ffmpeg",
+		pipeline:   pipeline,
+		runCommand: runCommand,
+		mkdirTemp:  os.MkdirTemp,
+		removeAll:  os.RemoveAll,
+		stat:       os.Stat,
+	}
+}
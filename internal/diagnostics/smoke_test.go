@@ -0,0 +1,78 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// fakeTranscriber allows injecting pipeline behavior in smoke tests.
+type fakeTranscriber struct {
+	run func(ctx context.Context, req transcribe.Request) (transcribe.Result, error)
+}
+
+// Run delegates to injected behavior.
+func (f *fakeTranscriber) Run(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+	return f.run(ctx, req)
+}
+
+// TestSmokeTesterPassesWhenTranscriptWritten validates the happy path.
+func TestSmokeTesterPassesWhenTranscriptWritten(t *testing.T) {
+	pipeline := &fakeTranscriber{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+		if _, err := os.Stat(req.InputPath); err != nil {
+			t.Fatalf("tone file missing: %v", err)
+		}
+		textPath := filepath.Join(req.OutputDir, "smoke-tone.txt")
+		if err := os.WriteFile(textPath, []byte(""), 0o644); err != nil {
+			t.Fatalf("write transcript: %v", err)
+		}
+		return transcribe.Result{TextPath: textPath}, nil
+	}}
+
+	tester := NewSmokeTesterForTests(pipeline, func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		return nil, os.WriteFile(args[len(args)-1], []byte("wav"), 0o644)
+	})
+
+	item := tester.Run(context.Background(), domain.Settings{ModelPath: "/m.bin", Language: "auto"})
+	if item.Status != domain.DiagnosticStatusPass {
+		t.Fatalf("status = %s, want pass (%s)", item.Status, item.Message)
+	}
+}
+
+// TestSmokeTesterFailsOnToneGenerationError validates ffmpeg failure reporting.
+func TestSmokeTesterFailsOnToneGenerationError(t *testing.T) {
+	pipeline := &fakeTranscriber{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+		t.Fatal("pipeline should not run when tone generation fails")
+		return transcribe.Result{}, nil
+	}}
+
+	tester := NewSmokeTesterForTests(pipeline, func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		return []byte("Unknown input format: 'lavfi'"), errors.New("exit status 1")
+	})
+
+	item := tester.Run(context.Background(), domain.Settings{})
+	if item.Status != domain.DiagnosticStatusFail {
+		t.Fatalf("status = %s, want fail", item.Status)
+	}
+}
+
+// TestSmokeTesterFailsOnPipelineError validates whisper failure reporting.
+func TestSmokeTesterFailsOnPipelineError(t *testing.T) {
+	pipeline := &fakeTranscriber{run: func(ctx context.Context, req transcribe.Request) (transcribe.Result, error) {
+		return transcribe.Result{}, &transcribe.PipelineError{Stage: "transcribing", Message: "whisper.cpp transcription failed"}
+	}}
+
+	tester := NewSmokeTesterForTests(pipeline, func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		return nil, os.WriteFile(args[len(args)-1], []byte("wav"), 0o644)
+	})
+
+	item := tester.Run(context.Background(), domain.Settings{ModelPath: "/m.bin"})
+	if item.Status != domain.DiagnosticStatusFail {
+		t.Fatalf("status = %s, want fail", item.Status)
+	}
+}
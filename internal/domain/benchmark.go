@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// BenchmarkResult stores one measured model run on this machine.
+// RealTimeFactor is compute seconds per audio second (lower is faster).
+type BenchmarkResult struct {
+	ModelID         string    `json:"modelId"`
+	ModelPath       string    `json:"modelPath"`
+	AudioSeconds    float64   `json:"audioSeconds"`
+	ElapsedSeconds  float64   `json:"elapsedSeconds"`
+	RealTimeFactor  float64   `json:"realTimeFactor"`
+	PeakMemoryBytes uint64    `json:"peakMemoryBytes,omitempty"`
+	MeasuredAt      time.Time `json:"measuredAt"`
+}
+
+// ModelSpeed is the rolling speed of a model measured over finished jobs on
+// this machine. RealTimeFactor is transcription seconds per second of input
+// audio, like a benchmark's, weighted toward recent jobs.
+type ModelSpeed struct {
+	ModelPath      string    `json:"modelPath"`
+	RealTimeFactor float64   `json:"realTimeFactor"`
+	Jobs           int       `json:"jobs"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// ModelRecommendation is the suggested model for the detected hardware.
+type ModelRecommendation struct {
+	ModelID string `json:"modelId"`
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+}
@@ -25,3 +25,25 @@ type DiagnosticReport struct {
 	HasFailures bool             `json:"hasFailures"`
 	Items       []DiagnosticItem `json:"items"`
 }
+
+// ToolPathSetup tells whether the directory of tools installed by the app
+// is on the PATH that new terminals and the app's next start get.
+type ToolPathSetup struct {
+	Dir string `json:"dir"`
+	// Persisted is set when Dir is on the Windows user PATH or in one of
+	// the shell profiles of Snippets.
+	Persisted bool `json:"persisted"`
+	// Snippets lists a line per Unix shell that adds Dir to PATH; empty on
+	// Windows, where the user PATH is updated instead.
+	Snippets []ShellSnippet `json:"snippets,omitempty"`
+}
+
+// ShellSnippet is the line to add to a shell's profile to put the tool
+// directory on PATH.
+type ShellSnippet struct {
+	Shell   string `json:"shell"`
+	Profile string `json:"profile"`
+	Snippet string `json:"snippet"`
+	// Installed is set when the profile already mentions the directory.
+	Installed bool `json:"installed"`
+}
@@ -0,0 +1,145 @@
+package domain
+
+import "time"
+
+// HistoryEntry is the persisted record of a finished job.
+type HistoryEntry struct {
+	ID            string    `json:"id"`
+	InputPath     string    `json:"inputPath"`
+	Status        JobStatus `json:"status"`
+	ModelPath     string    `json:"modelPath,omitempty"`
+	Language      string    `json:"language,omitempty"`
+	TextPath      string    `json:"textPath,omitempty"`
+	SubtitlePaths []string  `json:"subtitlePaths,omitempty"`
+	Error         string    `json:"error,omitempty"`
+	StartedAt     time.Time `json:"startedAt"`
+	FinishedAt    time.Time `json:"finishedAt"`
+	// AudioSeconds is the length of the transcribed audio, known for completed jobs.
+	AudioSeconds float64 `json:"audioSeconds,omitempty"`
+	// Worker is the server base URL of a job dispatched to a remote worker.
+	Worker string `json:"worker,omitempty"`
+	// Tags are keywords, names, and topics extracted from the transcript.
+	Tags []string `json:"tags,omitempty"`
+	// Meeting is the calendar event the recording was made in, if any, and
+	// MetadataPath the JSON sidecar describing it.
+	Meeting      *Meeting `json:"meeting,omitempty"`
+	MetadataPath string   `json:"metadataPath,omitempty"`
+	// ChapterPaths are the per-chapter transcripts of a chaptered input, then
+	// the combined transcript with chapter headings.
+	ChapterPaths []string `json:"chapterPaths,omitempty"`
+	// Stages records how long each pipeline stage of a completed job took.
+	Stages []StageTiming `json:"stages,omitempty"`
+	// ResubmitOf is the history ID of the job this one re-ran with changed
+	// parameters, so the two can be compared.
+	ResubmitOf string `json:"resubmitOf,omitempty"`
+	// SegmentsPath is the <name>.segments.json file of a completed job.
+	SegmentsPath string `json:"segmentsPath,omitempty"`
+	// LyricsPath is the <name>.lrc file, when lyrics were exported.
+	LyricsPath string `json:"lyricsPath,omitempty"`
+	// TranslatedSubtitlePaths are the <name>.<lang>.srt/.vtt translations of
+	// SubtitlePaths into the SubtitleLanguages setting.
+	TranslatedSubtitlePaths []string `json:"translatedSubtitlePaths,omitempty"`
+	// ReviewPath is the <name>.review.txt report of low-confidence passages.
+	ReviewPath string `json:"reviewPath,omitempty"`
+	// HallucinationPath is the <name>.hallucinations.txt report of suspected hallucinations.
+	HallucinationPath string `json:"hallucinationPath,omitempty"`
+	// SilenceMapPath is the map of gaps without speech, when one was written.
+	SilenceMapPath string `json:"silenceMapPath,omitempty"`
+	// PluginArtifacts are the files post-processing plugins reported.
+	PluginArtifacts []string `json:"pluginArtifacts,omitempty"`
+	// Failure classifies the error of a failed job; empty when it is unknown.
+	Failure Failure `json:"failure,omitempty"`
+	// InputHash is the hex SHA-256 of the input's content, used to detect
+	// duplicates; empty when the check is off.
+	InputHash string `json:"inputHash,omitempty"`
+}
+
+// PluginInfo is one executable or transform script in the plugin directory
+// and whether it runs with jobs.
+type PluginInfo struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Enabled bool   `json:"enabled"`
+	// Transform marks a .lua script run in the exporting stage.
+	Transform bool `json:"transform,omitempty"`
+}
+
+// Failure names what a failed job failed on.
+type Failure string
+
+const (
+	// FailureInput is a missing, unreadable, or unsuitable input file.
+	FailureInput Failure = "input"
+	// FailureModel is a missing or unresolvable whisper model.
+	FailureModel Failure = "model"
+	// FailureFFmpeg is a failed or timed-out preprocessing stage.
+	FailureFFmpeg Failure = "ffmpeg"
+	// FailureWhisper is a failed or timed-out transcription.
+	FailureWhisper Failure = "whisper"
+	// FailureExport is a failure to write the transcript or another output.
+	FailureExport Failure = "export"
+)
+
+// JobOverrides changes parameters of a resubmitted job; empty fields keep
+// the original job's values.
+type JobOverrides struct {
+	ModelPath string `json:"modelPath,omitempty"`
+	Language  string `json:"language,omitempty"`
+	// SplitChapters, when set, turns per-chapter transcripts on or off.
+	SplitChapters *bool `json:"splitChapters,omitempty"`
+	// Formats, when set, lists the outputs to write besides the transcript:
+	// "srt" and "vtt" turn on subtitles, "lrc" synced lyrics, and anything not
+	// listed is off. "txt" is accepted and always written.
+	Formats []string `json:"formats,omitempty"`
+}
+
+// ExcerptFormat selects the text format of an exported excerpt.
+type ExcerptFormat string
+
+const (
+	// ExcerptFormatText is the quote in quotation marks over its citation (the default).
+	ExcerptFormatText ExcerptFormat = "txt"
+	// ExcerptFormatMarkdown is the quote as a Markdown block quote.
+	ExcerptFormatMarkdown ExcerptFormat = "md"
+)
+
+// Excerpt is a quoted passage of a finished job's transcript. StartMs and
+// EndMs span the whole segments quoted, which may be wider than the range
+// asked for. AudioPath is empty unless the audio clip was requested.
+type Excerpt struct {
+	Text      string `json:"text"`
+	Citation  string `json:"citation"`
+	StartMs   int64  `json:"startMs"`
+	EndMs     int64  `json:"endMs"`
+	Path      string `json:"path"`
+	AudioPath string `json:"audioPath,omitempty"`
+}
+
+// StageTiming is the wall-clock duration of one pipeline stage ("preprocessing",
+// "transcribing", "exporting") and the peak memory of the command it ran, zero
+// when the stage ran no command or the platform does not report it.
+type StageTiming struct {
+	Stage           string  `json:"stage"`
+	Seconds         float64 `json:"seconds"`
+	PeakMemoryBytes uint64  `json:"peakMemoryBytes,omitempty"`
+}
+
+// HistoryFilter narrows ListHistory results; zero values match everything.
+// Tag matches one tag exactly, Query matches the input file name or any tag
+// as a substring, both case-insensitively.
+type HistoryFilter struct {
+	Tag   string `json:"tag,omitempty"`
+	Query string `json:"query,omitempty"`
+}
+
+// TaggingMode selects how transcripts are tagged after a job completes.
+type TaggingMode string
+
+const (
+	// TaggingLocal extracts tags with built-in frequency rules (the default).
+	TaggingLocal TaggingMode = "local"
+	// TaggingLLM asks the configured OpenAI-compatible LLM, falling back to local rules.
+	TaggingLLM TaggingMode = "llm"
+	// TaggingOff skips tagging.
+	TaggingOff TaggingMode = "off"
+)
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// JobRecord is the persisted trace of a running job. A record still present at
+// startup means the app exited uncleanly while the job ran.
+type JobRecord struct {
+	ID        string    `json:"id"`
+	InputPath string    `json:"inputPath"`
+	Status    JobStatus `json:"status"`
+	Workspace string    `json:"workspace,omitempty"`
+	StartedAt time.Time `json:"startedAt"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// TranscriptSort orders ListTranscripts results.
+type TranscriptSort string
+
+const (
+	// TranscriptSortNewest lists the most recently finished first (the default).
+	TranscriptSortNewest TranscriptSort = "newest"
+	// TranscriptSortOldest lists the earliest finished first.
+	TranscriptSortOldest TranscriptSort = "oldest"
+	// TranscriptSortName orders by input file name, case-insensitively.
+	TranscriptSortName TranscriptSort = "name"
+	// TranscriptSortDuration lists the longest recordings first.
+	TranscriptSortDuration TranscriptSort = "duration"
+)
+
+// PageRequest selects one page of a listing. Page counts from 1; zero values
+// mean the first page of DefaultPageSize items.
+type PageRequest struct {
+	Page int `json:"page,omitempty"`
+	Size int `json:"size,omitempty"`
+}
+
+const (
+	// DefaultPageSize is the page size of a PageRequest without one.
+	DefaultPageSize = 50
+	// MaxPageSize caps the page size of a PageRequest.
+	MaxPageSize = 200
+)
+
+// TranscriptSummary is one completed job in the transcript library.
+type TranscriptSummary struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	InputPath     string   `json:"inputPath"`
+	TextPath      string   `json:"textPath"`
+	SubtitlePaths []string `json:"subtitlePaths,omitempty"`
+	// Preview is the start of the transcript, empty when the file is gone.
+	Preview      string    `json:"preview"`
+	AudioSeconds float64   `json:"audioSeconds,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	Language     string    `json:"language,omitempty"`
+	ModelPath    string    `json:"modelPath,omitempty"`
+	FinishedAt   time.Time `json:"finishedAt"`
+	// Missing is set when the transcript file no longer exists.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// TranscriptPage is one page of the transcript library and the number of
+// transcripts matching the filter across all pages.
+type TranscriptPage struct {
+	Items []TranscriptSummary `json:"items"`
+	Total int                 `json:"total"`
+	Page  int                 `json:"page"`
+	Size  int                 `json:"size"`
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Meeting is the calendar event a recording was made in.
+type Meeting struct {
+	Title     string    `json:"title"`
+	Attendees []string  `json:"attendees,omitempty"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
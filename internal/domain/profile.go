@@ -0,0 +1,8 @@
+package domain
+
+// SettingsProfile is a named, reusable set of settings such as
+// "Podcasts – large-v3 – en" or "Quick notes – tiny".
+type SettingsProfile struct {
+	Name     string   `json:"name"`
+	Settings Settings `json:"settings"`
+}
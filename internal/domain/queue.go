@@ -0,0 +1,93 @@
+package domain
+
+import "time"
+
+// QueuedJob is a file waiting to be transcribed after the running job.
+type QueuedJob struct {
+	ID         string    `json:"id"`
+	InputPath  string    `json:"inputPath"`
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+	// Priority jobs run before every normal job, in the order they were flagged.
+	Priority bool `json:"priority,omitempty"`
+	// ResubmitOf is the history ID of the job this one re-runs.
+	ResubmitOf string `json:"resubmitOf,omitempty"`
+	// OutputSubdir is the folder below the output directory the outputs go
+	// to, mirroring where a folder scan found the input.
+	OutputSubdir string `json:"outputSubdir,omitempty"`
+	// Settings, when set, replace the current settings for this job. They
+	// stay in the process and are never serialized.
+	Settings *Settings `json:"-"`
+}
+
+// FolderScanOptions filters the media files TranscribeFolder picks up.
+// Zero values disable a filter; empty Extensions means the default media types.
+type FolderScanOptions struct {
+	Extensions     []string  `json:"extensions,omitempty"`
+	Include        []string  `json:"include,omitempty"`
+	Exclude        []string  `json:"exclude,omitempty"`
+	MinSizeBytes   int64     `json:"minSizeBytes,omitempty"`
+	MaxSizeBytes   int64     `json:"maxSizeBytes,omitempty"`
+	ModifiedAfter  time.Time `json:"modifiedAfter"`
+	ModifiedBefore time.Time `json:"modifiedBefore"`
+	// Retranscribe also picks files whose transcript already exists.
+	Retranscribe bool `json:"retranscribe,omitempty"`
+	// DryRun estimates the matched files instead of queueing them.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Merge combines the matched files, in order, into a single transcript.
+	Merge MergeMode `json:"merge,omitempty"`
+}
+
+// MergeMode selects how a batch of files becomes one transcript, e.g. a
+// lecture split across many short recordings.
+type MergeMode string
+
+const (
+	// MergeNone transcribes each file on its own (the default).
+	MergeNone MergeMode = ""
+	// MergeConcat joins the inputs with ffmpeg and transcribes them as one
+	// file, split into one chapter per input.
+	MergeConcat MergeMode = "concat"
+	// MergeTranscripts transcribes each file, then merges the transcripts
+	// with per-file headings and continuous timestamps.
+	MergeTranscripts MergeMode = "transcripts"
+)
+
+// FolderScanSummary reports what a folder scan found and what was queued.
+type FolderScanSummary struct {
+	Root            string      `json:"root"`
+	Matched         []string    `json:"matched"`
+	SkippedExisting []string    `json:"skippedExisting"`
+	FilteredOut     int         `json:"filteredOut"`
+	TotalBytes      int64       `json:"totalBytes"`
+	Queued          []QueuedJob `json:"queued"`
+	// Estimate is set for dry runs only.
+	Estimate *QueueEstimate `json:"estimate,omitempty"`
+}
+
+// FileEstimate is the probed duration and predicted run time of one input file.
+type FileEstimate struct {
+	InputPath        string  `json:"inputPath"`
+	SizeBytes        int64   `json:"sizeBytes"`
+	DurationSeconds  float64 `json:"durationSeconds"`
+	EstimatedSeconds float64 `json:"estimatedSeconds"`
+	// Error explains why the duration could not be probed.
+	Error string `json:"error,omitempty"`
+}
+
+// QueueEstimate predicts transcription time and disk use for a batch before it is queued.
+// Estimated time is audio duration times the model's real-time factor; Measured is false
+// when no benchmark exists for the configured model and a default factor was assumed.
+type QueueEstimate struct {
+	ModelPath            string         `json:"modelPath"`
+	RealTimeFactor       float64        `json:"realTimeFactor"`
+	Measured             bool           `json:"measured"`
+	Files                []FileEstimate `json:"files"`
+	TotalDurationSeconds float64        `json:"totalDurationSeconds"`
+	EstimatedSeconds     float64        `json:"estimatedSeconds"`
+	InputBytes           int64          `json:"inputBytes"`
+	// WorkspaceBytes is the peak temp space for preprocessed audio: the largest
+	// single file, or every file when intermediates are kept.
+	WorkspaceBytes int64 `json:"workspaceBytes"`
+	// Unprobed counts files left out of the totals because ffprobe failed.
+	Unprobed int `json:"unprobed"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+// SourcePreset routes media from one source, such as a podcast recorder's
+// folder or a client's share, to its own output directory and settings
+// profile. Prefix is a directory; files anywhere below it match.
+type SourcePreset struct {
+	Prefix string `json:"prefix"`
+	// OutputDir replaces the output directory of matching jobs when set.
+	OutputDir string `json:"outputDir,omitempty"`
+	// Profile names a settings profile matching jobs run with when set.
+	Profile string `json:"profile,omitempty"`
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// StatsPeriod selects the history window aggregated by GetStats.
+type StatsPeriod string
+
+const (
+	// StatsPeriodDay covers the last 24 hours.
+	StatsPeriodDay StatsPeriod = "day"
+	// StatsPeriodWeek covers the last 7 days.
+	StatsPeriodWeek StatsPeriod = "week"
+	// StatsPeriodMonth covers the last 30 days.
+	StatsPeriodMonth StatsPeriod = "month"
+	// StatsPeriodAll covers the whole history (the default).
+	StatsPeriodAll StatsPeriod = "all"
+)
+
+// UsageStats aggregates job history for the dashboard. Audio and processing
+// hours count completed jobs only; status counts cover every finished job.
+type UsageStats struct {
+	Period StatsPeriod `json:"period"`
+	// Since is the start of the window, zero for StatsPeriodAll.
+	Since           time.Time       `json:"since"`
+	Jobs            int             `json:"jobs"`
+	Succeeded       int             `json:"succeeded"`
+	Failed          int             `json:"failed"`
+	Cancelled       int             `json:"cancelled"`
+	AudioHours      float64         `json:"audioHours"`
+	ProcessingHours float64         `json:"processingHours"`
+	Models          []ModelUsage    `json:"models"`
+	Languages       []LanguageUsage `json:"languages"`
+}
+
+// ModelUsage is the per-model share of UsageStats. AverageRealTimeFactor is
+// processing time over audio time across the model's completed jobs, zero
+// when none recorded an audio length.
+type ModelUsage struct {
+	ModelPath             string  `json:"modelPath"`
+	Jobs                  int     `json:"jobs"`
+	AudioHours            float64 `json:"audioHours"`
+	AverageRealTimeFactor float64 `json:"averageRealTimeFactor"`
+	// Stages averages the model's stage timings over jobs that recorded them.
+	Stages []StageUsage `json:"stages,omitempty"`
+}
+
+// StageUsage is the per-stage share of ModelUsage: the mean duration and the
+// highest peak memory seen across the stage's runs.
+type StageUsage struct {
+	Stage           string  `json:"stage"`
+	Jobs            int     `json:"jobs"`
+	AverageSeconds  float64 `json:"averageSeconds"`
+	PeakMemoryBytes uint64  `json:"peakMemoryBytes,omitempty"`
+}
+
+// LanguageUsage is the per-language share of UsageStats.
+type LanguageUsage struct {
+	Language   string  `json:"language"`
+	Jobs       int     `json:"jobs"`
+	AudioHours float64 `json:"audioHours"`
+}
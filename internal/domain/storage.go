@@ -0,0 +1,22 @@
+package domain
+
+// StorageUsage reports the disk space taken by data the app manages.
+// Transcripts count the output files of jobs still in history.
+type StorageUsage struct {
+	HistoryEntries  int   `json:"historyEntries"`
+	CacheEntries    int   `json:"cacheEntries"`
+	CacheBytes      int64 `json:"cacheBytes"`
+	TranscriptFiles int   `json:"transcriptFiles"`
+	TranscriptBytes int64 `json:"transcriptBytes"`
+	// UploadBytes counts media uploaded to server mode and not yet transcribed.
+	UploadBytes int64 `json:"uploadBytes"`
+	TotalBytes  int64 `json:"totalBytes"`
+}
+
+// CleanupReport summarizes one pass of the retention policy.
+type CleanupReport struct {
+	HistoryRemoved     int   `json:"historyRemoved"`
+	CacheRemoved       int   `json:"cacheRemoved"`
+	TranscriptsRemoved int   `json:"transcriptsRemoved"`
+	FreedBytes         int64 `json:"freedBytes"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// TelemetryReport is the anonymous usage summary sent when telemetry is on.
+// It holds only these coarse fields: never file names, paths, transcript
+// text, or account details.
+type TelemetryReport struct {
+	Schema     int    `json:"schema"`
+	AppVersion string `json:"appVersion"`
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	// ModelClass is the size class of the configured model ("tiny" through
+	// "large", "custom", or "none"), not its file name.
+	ModelClass string `json:"modelClass"`
+	// PeriodStart and PeriodEnd are whole UTC days bounding the counted jobs.
+	PeriodStart   time.Time `json:"periodStart"`
+	PeriodEnd     time.Time `json:"periodEnd"`
+	JobsDone      int       `json:"jobsDone"`
+	JobsFailed    int       `json:"jobsFailed"`
+	JobsCancelled int       `json:"jobsCancelled"`
+	// Failures counts failed jobs by category, e.g. "transcribing" or "timeout".
+	Failures map[string]int `json:"failures,omitempty"`
+}
+
+// TelemetryState records when the last telemetry report was sent.
+type TelemetryState struct {
+	LastSent time.Time `json:"lastSent"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// TokenScope grants access to a group of server-mode API endpoints.
+type TokenScope string
+
+const (
+	// TokenScopeRead allows reading jobs, queue, history, stats, events, and metrics.
+	TokenScopeRead TokenScope = "read"
+	// TokenScopeSubmit allows submitting, uploading, and cancelling jobs.
+	TokenScopeSubmit TokenScope = "submit"
+	// TokenScopeAdmin allows everything, including managing tokens.
+	TokenScopeAdmin TokenScope = "admin"
+)
+
+// APIToken is a stored server-mode API token. Only the SHA-256 hash of the
+// secret is kept; the secret itself is shown once, when the token is created.
+type APIToken struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Scopes    []TokenScope `json:"scopes"`
+	Hash      string       `json:"hash,omitempty"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// Allows reports whether the token grants scope; admin grants every scope.
+func (t APIToken) Allows(scope TokenScope) bool {
+	for _, granted := range t.Scopes {
+		if granted == scope || granted == TokenScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// CreatedAPIToken is returned once on creation and carries the secret.
+type CreatedAPIToken struct {
+	Token  APIToken `json:"token"`
+	Secret string   `json:"secret"`
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // JobStatus tracks each pipeline stage for a single transcription job.
 type JobStatus string
 
@@ -13,15 +15,412 @@ const (
 	JobStatusCancelled     JobStatus = "cancelled"
 )
 
+// ProcessPriority selects how aggressively ffmpeg and whisper.cpp compete for the machine.
+type ProcessPriority string
+
+const (
+	// ProcessPriorityPerformance runs jobs at normal OS priority (the default).
+	ProcessPriorityPerformance ProcessPriority = "performance"
+	// ProcessPriorityResponsive runs jobs below normal CPU and I/O priority.
+	ProcessPriorityResponsive ProcessPriority = "responsive"
+)
+
+// WhisperEngine selects how whisper.cpp runs for each job.
+type WhisperEngine string
+
+const (
+	// WhisperEngineCLI starts whisper.cpp for every job (the default).
+	WhisperEngineCLI WhisperEngine = "cli"
+	// WhisperEngineServer keeps a whisper.cpp server with the model loaded
+	// between jobs, saving the model load on every file of a batch.
+	WhisperEngineServer WhisperEngine = "server"
+)
+
+// MemoryGuardMode selects what happens when the selected model likely needs
+// more memory than the machine has available.
+type MemoryGuardMode string
+
+const (
+	// MemoryGuardBlock refuses to start the job (the default).
+	MemoryGuardBlock MemoryGuardMode = "block"
+	// MemoryGuardWarn starts the job and reports a warning.
+	MemoryGuardWarn MemoryGuardMode = "warn"
+	// MemoryGuardOff skips the check.
+	MemoryGuardOff MemoryGuardMode = "off"
+)
+
+// DuplicateMode selects what happens when an input's content was already
+// transcribed with the same model and language.
+type DuplicateMode string
+
+const (
+	// DuplicateWarn starts the job and links the earlier transcript (the default).
+	DuplicateWarn DuplicateMode = "warn"
+	// DuplicateSkip refuses to start the job.
+	DuplicateSkip DuplicateMode = "skip"
+	// DuplicateOff neither hashes inputs nor checks them.
+	DuplicateOff DuplicateMode = "off"
+)
+
+// HWAccel selects the ffmpeg hardware decoder used during preprocessing.
+type HWAccel string
+
+const (
+	// HWAccelOff decodes on the CPU (the default).
+	HWAccelOff HWAccel = ""
+	// HWAccelAuto lets ffmpeg pick any available hardware decoder.
+	HWAccelAuto         HWAccel = "auto"
+	HWAccelCUDA         HWAccel = "cuda"
+	HWAccelVideoToolbox HWAccel = "videotoolbox"
+	HWAccelQSV          HWAccel = "qsv"
+	HWAccelVAAPI        HWAccel = "vaapi"
+)
+
+// GPUDeviceCPU is the GPUDevice setting that keeps whisper.cpp off the GPU.
+const GPUDeviceCPU = "cpu"
+
+// TextEncoding selects how exported text files are encoded.
+type TextEncoding string
+
+const (
+	// TextEncodingUTF8 writes UTF-8 without a byte order mark (the default).
+	TextEncodingUTF8 TextEncoding = ""
+	// TextEncodingUTF8BOM prefixes UTF-8 with a byte order mark, which several
+	// Windows video editors require to read SRT files.
+	TextEncodingUTF8BOM TextEncoding = "utf8-bom"
+	// TextEncodingUTF16LE writes little-endian UTF-16 with a byte order mark.
+	TextEncodingUTF16LE TextEncoding = "utf16le"
+)
+
+// TextTimestamps selects the timestamps in the .txt transcript.
+type TextTimestamps string
+
+const (
+	// TextTimestampsNone writes the text alone (the default).
+	TextTimestampsNone TextTimestamps = ""
+	// TextTimestampsSegment prefixes every segment's line with its start.
+	TextTimestampsSegment TextTimestamps = "segment"
+	// TextTimestampsInterval joins segments into paragraphs of about
+	// TextTimestampSeconds, each prefixed with its start.
+	TextTimestampsInterval TextTimestamps = "interval"
+)
+
+// TextLayout selects how the .txt transcript groups whisper's segments.
+type TextLayout string
+
+const (
+	// TextLayoutSegments writes each segment as whisper cut it (the default).
+	TextLayoutSegments TextLayout = ""
+	// TextLayoutSentences merges segments into whole sentences.
+	TextLayoutSentences TextLayout = "sentences"
+	// TextLayoutParagraphs groups the sentences into paragraphs at pauses and
+	// speaker changes.
+	TextLayoutParagraphs TextLayout = "paragraphs"
+)
+
+// HallucinationFilter selects what happens to segments that look like
+// whisper hallucinations.
+type HallucinationFilter string
+
+const (
+	// HallucinationFilterOff keeps every segment unchecked (the default).
+	HallucinationFilterOff HallucinationFilter = ""
+	// HallucinationFilterFlag keeps suspect segments and lists them in a report.
+	HallucinationFilterFlag HallucinationFilter = "flag"
+	// HallucinationFilterRemove drops suspect segments from every export and
+	// lists them in a report.
+	HallucinationFilterRemove HallucinationFilter = "remove"
+)
+
+// GrammarMode selects the grammar whisper.cpp decodes with.
+type GrammarMode string
+
+const (
+	// GrammarOff decodes freely (the default).
+	GrammarOff GrammarMode = ""
+	// GrammarDigits allows only numbers, for dictated codes and account numbers.
+	GrammarDigits GrammarMode = "digits"
+	// GrammarCommands allows only the phrases in GrammarVocabulary, for a
+	// fixed command vocabulary.
+	GrammarCommands GrammarMode = "commands"
+	// GrammarFile uses the GBNF grammar file at GrammarPath.
+	GrammarFile GrammarMode = "file"
+)
+
+// PipelineMode selects which stages a job runs.
+type PipelineMode string
+
+const (
+	// PipelineModeFull preprocesses, transcribes and exports (the default).
+	PipelineModeFull PipelineMode = ""
+	// PipelineModePreprocess only converts the input to the 16 kHz mono WAV
+	// whisper reads and exports it as <name>.16k.wav.
+	PipelineModePreprocess PipelineMode = "preprocess"
+	// PipelineModeTranscribeWAV transcribes an input that already is such a
+	// WAV, skipping ffmpeg.
+	PipelineModeTranscribeWAV PipelineMode = "transcribe-wav"
+	// PipelineModeReformat regenerates subtitles from a <name>.segments.json
+	// of an earlier run without running whisper again.
+	PipelineModeReformat PipelineMode = "reformat"
+)
+
+// LineEnding selects the line terminator of exported text files.
+type LineEnding string
+
+const (
+	// LineEndingLF ends lines with "\n" (the default).
+	LineEndingLF LineEnding = ""
+	// LineEndingCRLF ends lines with "\r\n".
+	LineEndingCRLF LineEnding = "crlf"
+)
+
+// Locale selects the language of backend messages shown in the UI.
+type Locale string
+
+const (
+	// LocaleEnglish keeps messages as written (the default).
+	LocaleEnglish Locale = "en"
+	LocaleRussian Locale = "ru"
+)
+
 // Settings contains user-selectable runtime configuration.
 type Settings struct {
 	ModelPath string `json:"modelPath"`
 	OutputDir string `json:"outputDir"`
 	Language  string `json:"language"`
+
+	// DraftModelPath, when set, runs a quick pass with this (typically tiny)
+	// model first and publishes its transcript as a provisional draft.
+	DraftModelPath string `json:"draftModelPath,omitempty"`
+
+	// ActiveProfile names the profile these settings were switched from, if any.
+	ActiveProfile string `json:"activeProfile,omitempty"`
+
+	// FFmpegPath and WhisperPath override PATH lookup of ffmpeg and whisper.cpp when set.
+	FFmpegPath  string `json:"ffmpegPath,omitempty"`
+	WhisperPath string `json:"whisperPath,omitempty"`
+
+	// WhisperEngine selects per-job whisper.cpp runs or a warm server;
+	// WhisperServerPath overrides PATH lookup of whisper-server when set.
+	WhisperEngine     WhisperEngine `json:"whisperEngine,omitempty"`
+	WhisperServerPath string        `json:"whisperServerPath,omitempty"`
+
+	// Timeouts in minutes for the preprocessing stage, the transcribing stage, and the
+	// whole job; zero disables the limit.
+	PreprocessTimeoutMinutes int `json:"preprocessTimeoutMinutes,omitempty"`
+	TranscribeTimeoutMinutes int `json:"transcribeTimeoutMinutes,omitempty"`
+	JobTimeoutMinutes        int `json:"jobTimeoutMinutes,omitempty"`
+
+	ProcessPriority ProcessPriority `json:"processPriority,omitempty"`
+
+	// MemoryGuard compares the model's estimated memory needs with free RAM
+	// and GPU memory before a job starts.
+	MemoryGuard MemoryGuardMode `json:"memoryGuard,omitempty"`
+
+	// Duplicates compares the SHA-256 of each input with the inputs of
+	// completed jobs before a job starts, so batches with the same recording
+	// under different names are not transcribed twice.
+	Duplicates DuplicateMode `json:"duplicates,omitempty"`
+
+	// FFmpegHWAccel decodes video with ffmpeg's -hwaccel during preprocessing,
+	// falling back to the CPU when the device is unavailable.
+	FFmpegHWAccel HWAccel `json:"ffmpegHwaccel,omitempty"`
+
+	// GPUDevice picks the GPU whisper.cpp runs on when several are installed:
+	// an index as listed by nvidia-smi, part of the GPU's name such as
+	// "3090", or GPUDeviceCPU. Empty leaves the choice to whisper.cpp.
+	GPUDevice string `json:"gpuDevice,omitempty"`
+
+	// AudioTempo speeds the preprocessed audio up by this factor (e.g. 1.25
+	// to 1.5, at most 2) for proportionally faster transcription at some cost
+	// in accuracy; zero or 1 keeps normal speed. Exported timestamps stay on
+	// the original timeline.
+	AudioTempo float64 `json:"audioTempo,omitempty"`
+
+	// SkipNonSpeech cuts long silence and music, such as podcast intros that
+	// whisper hallucinates lyrics over, before transcribing.
+	SkipNonSpeech bool `json:"skipNonSpeech,omitempty"`
+
+	// HallucinationFilter flags or removes repeated segments, outro phrases
+	// such as "thanks for watching", and segments past the end of the audio.
+	HallucinationFilter HallucinationFilter `json:"hallucinationFilter,omitempty"`
+
+	// SilenceMarkers puts lines such as "[silence 00:12:30–00:14:02]" in the
+	// transcript where nothing was said for at least SilenceMinSeconds
+	// (default 10), and SilenceMap lists those gaps in <name>.silence.json,
+	// so editors can tell a pause from dropped content.
+	SilenceMarkers    bool `json:"silenceMarkers,omitempty"`
+	SilenceMap        bool `json:"silenceMap,omitempty"`
+	SilenceMinSeconds int  `json:"silenceMinSeconds,omitempty"`
+
+	// SplitChannels transcribes the two channels of a stereo call recording
+	// separately and labels them as two speakers.
+	SplitChannels bool `json:"splitChannels,omitempty"`
+
+	// ExportSubtitles also writes the segments as <name>.srt and <name>.vtt.
+	// Script alignment and SubtitleLanguages export them regardless.
+	ExportSubtitles bool `json:"exportSubtitles,omitempty"`
+
+	// ExportLRC also writes the transcript as <name>.lrc synced lyrics, and
+	// EmbedLyrics writes the lyrics (or the transcript) into the lyrics tag of
+	// MP3 and M4A inputs.
+	ExportLRC   bool `json:"exportLrc,omitempty"`
+	EmbedLyrics bool `json:"embedLyrics,omitempty"`
+
+	// Terms lists names and jargon for this profile: whisper gets them as its
+	// initial prompt and near misses in the transcript are respelled to match.
+	Terms []string `json:"terms,omitempty"`
+
+	// Grammar constrains whisper.cpp's decoding to a GBNF grammar, for
+	// recordings with a known vocabulary such as IVR menus or dictated codes.
+	// GrammarVocabulary lists the phrases of GrammarCommands, and
+	// GrammarPath and GrammarRule (default "root") name the file and start
+	// rule of GrammarFile. GrammarPenalty scales down tokens the grammar does
+	// not allow; zero keeps whisper.cpp's default of 100.
+	Grammar           GrammarMode `json:"grammar,omitempty"`
+	GrammarVocabulary []string    `json:"grammarVocabulary,omitempty"`
+	GrammarPath       string      `json:"grammarPath,omitempty"`
+	GrammarRule       string      `json:"grammarRule,omitempty"`
+	GrammarPenalty    float64     `json:"grammarPenalty,omitempty"`
+
+	// EnabledPlugins names the executables in the plugin directory that run
+	// after each completed job; others found there stay off.
+	EnabledPlugins []string `json:"enabledPlugins,omitempty"`
+
+	// TempDir is where per-job scratch workspaces are created, e.g. a fast disk or
+	// RAM disk; empty uses the OS temp directory. KeepIntermediates retains them.
+	TempDir           string `json:"tempDir,omitempty"`
+	KeepIntermediates bool   `json:"keepIntermediates,omitempty"`
+
+	// PreprocessCacheMB caps the cache of preprocessed WAVs reused when the same
+	// file is transcribed again, e.g. with another model; zero disables it.
+	PreprocessCacheMB int `json:"preprocessCacheMb,omitempty"`
+
+	// RetentionDays removes history entries, cached audio, and, with
+	// RetentionTranscripts, transcripts older than this many days. RetentionGB
+	// deletes the oldest cached audio, then the oldest transcripts, once they
+	// take more space. Zero disables either limit.
+	RetentionDays        int  `json:"retentionDays,omitempty"`
+	RetentionGB          int  `json:"retentionGb,omitempty"`
+	RetentionTranscripts bool `json:"retentionTranscripts,omitempty"`
+
+	// DownloadLimitKBps caps combined download bandwidth; zero means unlimited.
+	DownloadLimitKBps int `json:"downloadLimitKBps,omitempty"`
+
+	// CheckUpdates looks for a newer app release on GitHub at startup.
+	CheckUpdates bool `json:"checkUpdates,omitempty"`
+
+	// Telemetry sends a weekly anonymous usage report to TelemetryURL; it is
+	// off by default and PreviewTelemetry shows exactly what would be sent.
+	Telemetry    bool   `json:"telemetry,omitempty"`
+	TelemetryURL string `json:"telemetryUrl,omitempty"`
+
+	// TranslationProvider selects the subtitle translation backend ("libretranslate"
+	// or "openai" for any OpenAI-compatible LLM API); empty disables translation.
+	// TranslationModel names the LLM; the API key lives in the secret store.
+	TranslationProvider string `json:"translationProvider,omitempty"`
+	TranslationEndpoint string `json:"translationEndpoint,omitempty"`
+	TranslationModel    string `json:"translationModel,omitempty"`
+	// SubtitleLanguages lists languages every job's subtitles are also
+	// translated into with the translation backend, in the same job.
+	SubtitleLanguages []string `json:"subtitleLanguages,omitempty"`
+
+	// Tagging selects how finished transcripts are tagged for history search.
+	// The LLM mode reuses the translation endpoint, model, and API key.
+	Tagging TaggingMode `json:"tagging,omitempty"`
+
+	// RemoteWorkers lists media-transcriber servers (`serve` mode) that
+	// DispatchRemote may send jobs to, as comma-separated base URLs.
+	RemoteWorkers string `json:"remoteWorkers,omitempty"`
+
+	// S3Bucket enables uploading finished transcripts to an S3-compatible
+	// service (AWS, MinIO...) under S3Prefix/<job ID>/; S3UploadSource also
+	// uploads the source media. The secret access key lives in the secret store.
+	S3Endpoint     string `json:"s3Endpoint,omitempty"`
+	S3Region       string `json:"s3Region,omitempty"`
+	S3Bucket       string `json:"s3Bucket,omitempty"`
+	S3Prefix       string `json:"s3Prefix,omitempty"`
+	S3AccessKeyID  string `json:"s3AccessKeyId,omitempty"`
+	S3UploadSource bool   `json:"s3UploadSource,omitempty"`
+
+	// WebDAVURL enables exporting finished transcripts to a WebDAV server
+	// (Nextcloud, ownCloud) into the WebDAVPath folder below it. The password
+	// lives in the secret store.
+	WebDAVURL  string `json:"webDavUrl,omitempty"`
+	WebDAVUser string `json:"webDavUser,omitempty"`
+	WebDAVPath string `json:"webDavPath,omitempty"`
+
+	// ObsidianFolder is a folder inside an Obsidian vault that receives each
+	// finished transcript as a Markdown note. NotionParentPageID is the page
+	// new transcript pages are created under; the token lives in the secret store.
+	ObsidianFolder     string `json:"obsidianFolder,omitempty"`
+	NotionParentPageID string `json:"notionParentPageId,omitempty"`
+
+	// SlackWebhookURL and DiscordWebhookURL receive a message when a job
+	// completes or fails, except during NotifyQuietHours ("22:00-07:00",
+	// local time).
+	SlackWebhookURL   string `json:"slackWebhookUrl,omitempty"`
+	DiscordWebhookURL string `json:"discordWebhookUrl,omitempty"`
+	NotifyQuietHours  string `json:"notifyQuietHours,omitempty"`
+
+	// SMTPHost enables emailing finished transcripts to EmailRecipients
+	// (comma-separated). SMTPPort defaults to 587 (STARTTLS); 465 uses TLS.
+	// EmailAttachFormats lists output extensions to attach ("txt,srt"); empty
+	// sends the transcript inline. The password lives in the secret store.
+	SMTPHost           string `json:"smtpHost,omitempty"`
+	SMTPPort           int    `json:"smtpPort,omitempty"`
+	SMTPUser           string `json:"smtpUser,omitempty"`
+	SMTPFrom           string `json:"smtpFrom,omitempty"`
+	EmailRecipients    string `json:"emailRecipients,omitempty"`
+	EmailAttachFormats string `json:"emailAttachFormats,omitempty"`
+
+	// CalendarICS (a local .ics file or feed URL) and CalDAVURL (a calendar
+	// collection, with CalDAVUser and a password in the secret store) let
+	// recordings made during a meeting be named and tagged after it.
+	CalendarICS string `json:"calendarIcs,omitempty"`
+	CalDAVURL   string `json:"calDavUrl,omitempty"`
+	CalDAVUser  string `json:"calDavUser,omitempty"`
+
+	// OutputNameTemplate names output files, e.g. "{date} {meeting}"; see
+	// the README for placeholders. Empty keeps the input file name unless the
+	// recording matched a meeting.
+	OutputNameTemplate string `json:"outputNameTemplate,omitempty"`
+
+	// TextEncoding and LineEnding apply to every exported transcript and
+	// subtitle file.
+	TextEncoding TextEncoding `json:"textEncoding,omitempty"`
+	LineEnding   LineEnding   `json:"lineEnding,omitempty"`
+
+	// TextTimestamps prefixes the .txt transcript with "[00:12:30]"
+	// timestamps per segment or per paragraph of TextTimestampSeconds
+	// (default 30), rendered from the segments rather than whisper's text.
+	TextTimestamps       TextTimestamps `json:"textTimestamps,omitempty"`
+	TextTimestampSeconds int            `json:"textTimestampSeconds,omitempty"`
+
+	// TextLayout merges whisper's choppy segments into sentences or
+	// paragraphs in the .txt transcript and the notes made from it, using
+	// punctuation and pauses; subtitles keep the original segments.
+	TextLayout TextLayout `json:"textLayout,omitempty"`
+
+	// Locale translates diagnostics, job events, and errors shown in the UI.
+	// The REST API, event log, and metrics keep English messages.
+	Locale Locale `json:"locale,omitempty"`
+
+	// SplitChapters splits transcripts of chaptered inputs (audiobooks, mka)
+	// into one file per chapter plus a combined file with chapter headings.
+	SplitChapters bool `json:"splitChapters,omitempty"`
 }
 
-// Job stores the current job identity and lifecycle status.
+// Job stores the current job identity, lifecycle status, and progress.
+// Progress is the overall completion fraction from 0 to 1; ETASeconds is an
+// estimate of the remaining time and zero while unknown.
 type Job struct {
-	ID     string    `json:"id"`
-	Status JobStatus `json:"status"`
+	ID         string     `json:"id"`
+	Status     JobStatus  `json:"status"`
+	InputPath  string     `json:"inputPath,omitempty"`
+	Stage      string     `json:"stage,omitempty"`
+	Progress   float64    `json:"progress"`
+	ETASeconds float64    `json:"etaSeconds,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
 }
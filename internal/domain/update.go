@@ -0,0 +1,13 @@
+package domain
+
+// UpdateInfo describes the latest app release compared to the running build.
+// Installer fields are empty when the release has no installer for this platform.
+type UpdateInfo struct {
+	CurrentVersion string `json:"currentVersion"`
+	LatestVersion  string `json:"latestVersion"`
+	Available      bool   `json:"available"`
+	ReleaseURL     string `json:"releaseUrl,omitempty"`
+	Notes          string `json:"notes,omitempty"`
+	InstallerName  string `json:"installerName,omitempty"`
+	InstallerBytes int64  `json:"installerBytes,omitempty"`
+}
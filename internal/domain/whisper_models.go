@@ -1,13 +1,76 @@
 package domain
 
+import "time"
+
 // WhisperModelOption describes one downloadable whisper.cpp model preset.
+// Languages is empty for multilingual models and lists the supported languages
+// of specialized ones. RequiresToken marks gated models needing a Hugging Face token.
+// Custom entries are user-defined and may carry an expected SHA256 checksum.
+// CoreMLURL points to the zipped CoreML encoder fetched alongside the model on macOS.
 type WhisperModelOption struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	FileName    string `json:"fileName"`
-	URL         string `json:"url"`
-	SizeLabel   string `json:"sizeLabel,omitempty"`
-	Description string `json:"description,omitempty"`
-	Downloaded  bool   `json:"downloaded"`
-	LocalPath   string `json:"localPath,omitempty"`
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	FileName      string             `json:"fileName"`
+	URL           string             `json:"url"`
+	SizeLabel     string             `json:"sizeLabel,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Languages     []string           `json:"languages,omitempty"`
+	RequiresToken bool               `json:"requiresToken,omitempty"`
+	SHA256        string             `json:"sha256,omitempty"`
+	Custom        bool               `json:"custom,omitempty"`
+	LanguageMatch ModelLanguageMatch `json:"languageMatch,omitempty"`
+	CoreMLURL     string             `json:"coreMLUrl,omitempty"`
+	Downloaded    bool               `json:"downloaded"`
+	LocalPath     string             `json:"localPath,omitempty"`
+	CoreMLReady   bool               `json:"coreMLReady,omitempty"`
+}
+
+// ModelLanguageMatch describes how well a model fits the configured language.
+type ModelLanguageMatch string
+
+const (
+	ModelLanguageSpecialized ModelLanguageMatch = "specialized"
+	ModelLanguageSupported   ModelLanguageMatch = "supported"
+	ModelLanguageUnsupported ModelLanguageMatch = "unsupported"
+)
+
+// LocalModel describes one model file found in a known model directory.
+type LocalModel struct {
+	Path         string    `json:"path"`
+	FileName     string    `json:"fileName"`
+	SizeBytes    int64     `json:"sizeBytes"`
+	ModifiedAt   time.Time `json:"modifiedAt"`
+	Format       string    `json:"format,omitempty"`
+	ModelType    string    `json:"modelType,omitempty"`
+	Quantization string    `json:"quantization,omitempty"`
+	Multilingual bool      `json:"multilingual"`
+	InUse        bool      `json:"inUse"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// WhisperCandidateKind tells a whisper binary from a model among the
+// existing installations found by FindExistingWhisper.
+type WhisperCandidateKind string
+
+const (
+	// WhisperCandidateBinary is a whisper.cpp command-line executable.
+	WhisperCandidateBinary WhisperCandidateKind = "binary"
+	// WhisperCandidateModel is a ggml model file.
+	WhisperCandidateModel WhisperCandidateKind = "model"
+)
+
+// WhisperCandidate is a whisper binary or model installed outside the app
+// that can be adopted instead of downloading a new one. Source names where
+// it was found: path, brew, scoop, choco, source (a whisper.cpp checkout),
+// downloads, or wsl (the Windows side of a WSL machine).
+type WhisperCandidate struct {
+	Kind      WhisperCandidateKind `json:"kind"`
+	Path      string               `json:"path"`
+	Source    string               `json:"source"`
+	SizeBytes int64                `json:"sizeBytes,omitempty"`
+	// ModelType and Multilingual come from a model's header.
+	ModelType    string `json:"modelType,omitempty"`
+	Multilingual bool   `json:"multilingual,omitempty"`
+	// InUse is set when the settings already point at the candidate.
+	InUse bool `json:"inUse"`
 }
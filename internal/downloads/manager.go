@@ -0,0 +1,390 @@
+// Package downloads runs queued HTTP file downloads with progress, cancel, and bandwidth limits.
+package downloads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownDownload is returned for IDs that were never enqueued.
+var ErrUnknownDownload = errors.New("unknown download")
+
+// ErrCancelled is returned when a download is cancelled by the user.
+var ErrCancelled = errors.New("download cancelled")
+
+// progressInterval throttles progress notifications per download.
+const progressInterval = 250 * time.Millisecond
+
+// Status tracks the lifecycle of one download.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusDone      Status = "done"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Request describes one file to fetch. Timeout of zero means no deadline.
+type Request struct {
+	URL         string
+	Destination string
+	Header      http.Header
+	Timeout     time.Duration
+}
+
+// Download is a snapshot of one queued or finished download.
+type Download struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Destination string    `json:"destination"`
+	Status      Status    `json:"status"`
+	BytesDone   int64     `json:"bytesDone"`
+	BytesTotal  int64     `json:"bytesTotal"`
+	Error       string    `json:"error,omitempty"`
+	QueuedAt    time.Time `json:"queuedAt"`
+	StartedAt   time.Time `json:"startedAt,omitempty"`
+	FinishedAt  time.Time `json:"finishedAt,omitempty"`
+}
+
+// entry is the mutable state behind one Download.
+type entry struct {
+	download Download
+	request  Request
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+}
+
+// Manager runs downloads with bounded concurrency and a shared bandwidth limit.
+type Manager struct {
+	mu            sync.Mutex
+	client        *http.Client
+	maxConcurrent int
+	running       int
+	nextID        int64
+	queue         []*entry
+	entries       map[string]*entry
+	limiter       *rateLimiter
+	onUpdate      func(Download)
+}
+
+// NewManager creates a manager running at most maxConcurrent downloads at once.
+func NewManager(maxConcurrent int) *Manager {
+	if maxConcurrent <= 0 {
+		maxConcurrent = 2
+	}
+	return &Manager{
+		client:        http.DefaultClient,
+		maxConcurrent: maxConcurrent,
+		entries:       map[string]*entry{},
+		limiter:       newRateLimiter(0),
+	}
+}
+
+// OnUpdate registers a callback invoked on status changes and throttled progress.
+func (m *Manager) OnUpdate(cb func(Download)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onUpdate = cb
+}
+
+// SetBandwidthLimit caps combined throughput in bytes per second; zero disables the cap.
+func (m *Manager) SetBandwidthLimit(bytesPerSecond int64) {
+	m.limiter.setRate(bytesPerSecond)
+}
+
+// Enqueue adds a download to the queue and starts it when a slot is free.
+func (m *Manager) Enqueue(req Request) Download {
+	m.mu.Lock()
+	m.nextID++
+	e := &entry{
+		download: Download{
+			ID:          fmt.Sprintf("dl-%d", m.nextID),
+			URL:         req.URL,
+			Destination: req.Destination,
+			Status:      StatusQueued,
+			QueuedAt:    time.Now().UTC(),
+		},
+		request: req,
+		done:    make(chan struct{}),
+	}
+	m.entries[e.download.ID] = e
+	m.queue = append(m.queue, e)
+	snapshot := e.download
+	m.mu.Unlock()
+
+	m.notify(snapshot)
+	m.startNext()
+	return snapshot
+}
+
+// Download enqueues a request and blocks until it finishes or ctx is done.
+func (m *Manager) Download(ctx context.Context, req Request) error {
+	queued := m.Enqueue(req)
+	_, err := m.Wait(ctx, queued.ID)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		_ = m.Cancel(queued.ID)
+	}
+	return err
+}
+
+// Wait blocks until the download finishes and returns its final state and error.
+func (m *Manager) Wait(ctx context.Context, id string) (Download, error) {
+	m.mu.Lock()
+	e, ok := m.entries[id]
+	m.mu.Unlock()
+	if !ok {
+		return Download{}, ErrUnknownDownload
+	}
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return m.snapshot(e), ctx.Err()
+	}
+	return m.snapshot(e), e.err
+}
+
+// Cancel stops a queued or running download.
+func (m *Manager) Cancel(id string) error {
+	m.mu.Lock()
+	e, ok := m.entries[id]
+	if !ok {
+		m.mu.Unlock()
+		return ErrUnknownDownload
+	}
+
+	switch e.download.Status {
+	case StatusQueued:
+		for i, queued := range m.queue {
+			if queued == e {
+				m.queue = append(m.queue[:i], m.queue[i+1:]...)
+				break
+			}
+		}
+		m.finishLocked(e, StatusCancelled, ErrCancelled)
+		snapshot := e.download
+		m.mu.Unlock()
+		m.notify(snapshot)
+		return nil
+	case StatusRunning:
+		cancel := e.cancel
+		m.mu.Unlock()
+		cancel()
+		return nil
+	default:
+		m.mu.Unlock()
+		return nil
+	}
+}
+
+// List returns snapshots of all known downloads, oldest first.
+func (m *Manager) List() []Download {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]Download, 0, len(m.entries))
+	for _, e := range m.entries {
+		out = append(out, e.download)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].QueuedAt.Before(out[j].QueuedAt) || (out[i].QueuedAt.Equal(out[j].QueuedAt) && out[i].ID < out[j].ID)
+	})
+	return out
+}
+
+// startNext launches queued downloads while concurrency slots are free.
+func (m *Manager) startNext() {
+	for {
+		m.mu.Lock()
+		if m.running >= m.maxConcurrent || len(m.queue) == 0 {
+			m.mu.Unlock()
+			return
+		}
+		e := m.queue[0]
+		m.queue = m.queue[1:]
+		m.running++
+
+		ctx := context.Background()
+		var cancel context.CancelFunc
+		if e.request.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, e.request.Timeout)
+		} else {
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		e.cancel = cancel
+		e.download.Status = StatusRunning
+		e.download.StartedAt = time.Now().UTC()
+		snapshot := e.download
+		m.mu.Unlock()
+
+		m.notify(snapshot)
+		go m.run(ctx, cancel, e)
+	}
+}
+
+// run executes one download and records its outcome.
+func (m *Manager) run(ctx context.Context, cancel context.CancelFunc, e *entry) {
+	defer cancel()
+	err := m.fetch(ctx, e)
+
+	status := StatusDone
+	switch {
+	case err == nil:
+	case errors.Is(err, context.Canceled):
+		status = StatusCancelled
+		err = ErrCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		status = StatusFailed
+		err = fmt.Errorf("timed out after %s: %w", e.request.Timeout, err)
+	default:
+		status = StatusFailed
+	}
+
+	m.mu.Lock()
+	m.running--
+	m.finishLocked(e, status, err)
+	snapshot := e.download
+	m.mu.Unlock()
+
+	m.notify(snapshot)
+	m.startNext()
+}
+
+// finishLocked records terminal state; caller holds m.mu.
+func (m *Manager) finishLocked(e *entry, status Status, err error) {
+	e.download.Status = status
+	e.download.FinishedAt = time.Now().UTC()
+	e.err = err
+	if err != nil {
+		e.download.Error = err.Error()
+	}
+	close(e.done)
+}
+
+// fetch streams the response into a temp file and moves it into place.
+func (m *Manager) fetch(ctx context.Context, e *entry) error {
+	destination := e.request.Destination
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+		return fmt.Errorf("prepare destination directory: %w", err)
+	}
+
+	tmpPath := destination + ".download"
+	if err := os.Remove(tmpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove stale temp file: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.request.URL, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	for key, values := range e.request.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("User-Agent", "media-transcriber")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	m.mu.Lock()
+	e.download.BytesTotal = resp.ContentLength
+	m.mu.Unlock()
+
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+
+	_, copyErr := io.Copy(file, &progressReader{ctx: ctx, reader: resp.Body, manager: m, entry: e})
+	closeErr := file.Close()
+	if copyErr != nil {
+		_ = os.Remove(tmpPath)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("write destination file: %w", copyErr)
+	}
+	if closeErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("close destination file: %w", closeErr)
+	}
+
+	if err := os.Remove(destination); err != nil && !errors.Is(err, os.ErrNotExist) {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("remove old destination file: %w", err)
+	}
+	if err := os.Rename(tmpPath, destination); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("move downloaded file into place: %w", err)
+	}
+	return nil
+}
+
+// snapshot copies the current download state.
+func (m *Manager) snapshot(e *entry) Download {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return e.download
+}
+
+// notify forwards a snapshot to the registered callback.
+func (m *Manager) notify(download Download) {
+	m.mu.Lock()
+	cb := m.onUpdate
+	m.mu.Unlock()
+	if cb != nil {
+		cb(download)
+	}
+}
+
+// progressReader counts bytes, applies the bandwidth limit, and reports progress.
+type progressReader struct {
+	ctx        context.Context
+	reader     io.Reader
+	manager    *Manager
+	entry      *entry
+	lastNotify time.Time
+}
+
+// Read reads a rate-limited chunk and updates progress counters.
+func (r *progressReader) Read(p []byte) (int, error) {
+	if max := r.manager.limiter.chunkSize(); max > 0 && len(p) > max {
+		p = p[:max]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		if waitErr := r.manager.limiter.wait(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+
+		r.manager.mu.Lock()
+		r.entry.download.BytesDone += int64(n)
+		snapshot := r.entry.download
+		r.manager.mu.Unlock()
+
+		if time.Since(r.lastNotify) >= progressInterval {
+			r.lastNotify = time.Now()
+			r.manager.notify(snapshot)
+		}
+	}
+	return n, err
+}
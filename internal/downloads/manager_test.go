@@ -0,0 +1,141 @@
+package downloads
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestManagerDownloadWritesFileAndForwardsHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			http.Error(w, "missing auth", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("model-bytes"))
+	}))
+	defer server.Close()
+
+	manager := NewManager(1)
+	var mu sync.Mutex
+	var statuses []Status
+	manager.OnUpdate(func(d Download) {
+		mu.Lock()
+		statuses = append(statuses, d.Status)
+		mu.Unlock()
+	})
+
+	dest := filepath.Join(t.TempDir(), "nested", "model.bin")
+	err := manager.Download(context.Background(), Request{
+		URL:         server.URL,
+		Destination: dest,
+		Header:      http.Header{"Authorization": {"Bearer secret"}},
+	})
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(dest)
+	if err != nil || string(data) != "model-bytes" {
+		t.Fatalf("unexpected file contents %q (err=%v)", data, err)
+	}
+	if _, err := os.Stat(dest + ".download"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected temp file removed, got %v", err)
+	}
+
+	list := manager.List()
+	if len(list) != 1 || list[0].Status != StatusDone || list[0].BytesDone != int64(len("model-bytes")) {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if statuses[0] != StatusQueued || statuses[len(statuses)-1] != StatusDone {
+		t.Fatalf("unexpected status sequence: %v", statuses)
+	}
+}
+
+func TestManagerDownloadReportsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	manager := NewManager(1)
+	err := manager.Download(context.Background(), Request{URL: server.URL, Destination: filepath.Join(t.TempDir(), "x.bin")})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 error, got %v", err)
+	}
+	if list := manager.List(); list[0].Status != StatusFailed || list[0].Error == "" {
+		t.Fatalf("expected failed status, got %+v", list[0])
+	}
+}
+
+func TestManagerQueuesBeyondConcurrencyAndCancels(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	manager := NewManager(1)
+	dir := t.TempDir()
+	first := manager.Enqueue(Request{URL: server.URL, Destination: filepath.Join(dir, "a.bin")})
+	second := manager.Enqueue(Request{URL: server.URL, Destination: filepath.Join(dir, "b.bin")})
+
+	list := manager.List()
+	if list[0].Status != StatusRunning || list[1].Status != StatusQueued {
+		t.Fatalf("expected first running and second queued, got %+v", list)
+	}
+
+	if err := manager.Cancel(second.ID); err != nil {
+		t.Fatalf("Cancel queued returned error: %v", err)
+	}
+	if _, err := manager.Wait(context.Background(), second.ID); !errors.Is(err, ErrCancelled) {
+		t.Fatalf("expected queued download cancelled, got %v", err)
+	}
+
+	if err := manager.Cancel(first.ID); err != nil {
+		t.Fatalf("Cancel running returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	got, err := manager.Wait(ctx, first.ID)
+	if !errors.Is(err, ErrCancelled) || got.Status != StatusCancelled {
+		t.Fatalf("expected running download cancelled, got %+v err=%v", got, err)
+	}
+
+	if err := manager.Cancel("dl-missing"); !errors.Is(err, ErrUnknownDownload) {
+		t.Fatalf("expected ErrUnknownDownload, got %v", err)
+	}
+}
+
+func TestManagerBandwidthLimitSlowsTransfer(t *testing.T) {
+	payload := strings.Repeat("x", 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	manager := NewManager(1)
+	manager.SetBandwidthLimit(8192)
+
+	started := time.Now()
+	if err := manager.Download(context.Background(), Request{URL: server.URL, Destination: filepath.Join(t.TempDir(), "x.bin")}); err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected throttled transfer of ~500ms, took %s", elapsed)
+	}
+}
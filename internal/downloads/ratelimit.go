@@ -0,0 +1,81 @@
+package downloads
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by all running downloads.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rate     int64
+	tokens   float64
+	lastFill time.Time
+}
+
+// newRateLimiter creates a limiter; a rate of zero means unlimited.
+func newRateLimiter(bytesPerSecond int64) *rateLimiter {
+	return &rateLimiter{rate: bytesPerSecond, lastFill: time.Now()}
+}
+
+// setRate changes the limit for subsequent reads.
+func (l *rateLimiter) setRate(bytesPerSecond int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if bytesPerSecond < 0 {
+		bytesPerSecond = 0
+	}
+	l.rate = bytesPerSecond
+	l.tokens = 0
+	l.lastFill = time.Now()
+}
+
+// chunkSize bounds single reads so one download cannot burst past the limit.
+func (l *rateLimiter) chunkSize() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return 0
+	}
+	chunk := l.rate / 10
+	if chunk < 1024 {
+		chunk = 1024
+	}
+	return int(chunk)
+}
+
+// wait consumes n tokens, sleeping until the bucket refills when needed.
+func (l *rateLimiter) wait(ctx context.Context, n int) error {
+	l.mu.Lock()
+	if l.rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	l.tokens += now.Sub(l.lastFill).Seconds() * float64(l.rate)
+	if burst := float64(l.rate); l.tokens > burst {
+		l.tokens = burst
+	}
+	l.lastFill = now
+	l.tokens -= float64(n)
+
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / float64(l.rate) * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
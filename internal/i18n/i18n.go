@@ -0,0 +1,122 @@
+// Package i18n translates user-facing backend messages (diagnostics, job
+// events, and errors) into the locale chosen in settings.
+//
+// Catalogs are keyed by the English format string passed to fmt, so messages
+// are translated after formatting without changing the code that builds them:
+// the message is matched against each format, the values filled into the
+// verbs are translated in turn, and they are placed into the translated format.
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"media-transcriber/internal/domain"
+)
+
+// catalogs maps each supported locale other than English to its messages.
+var catalogs = map[domain.Locale]map[string]string{
+	domain.LocaleRussian: russian,
+}
+
+// verbPattern matches one fmt verb with optional flags, width, and precision.
+var verbPattern = regexp.MustCompile(`%(?:\[[0-9]+\])?[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+// entry is a catalog message compiled for matching.
+type entry struct {
+	format      string
+	pattern     *regexp.Regexp
+	translation string
+	// literal is the length of the fixed text; entries are tried longest
+	// first so the most specific format wins when several match.
+	literal int
+}
+
+var (
+	compileOnce sync.Once
+	compiled    map[domain.Locale][]entry
+)
+
+// Locales lists the supported locales, English first.
+func Locales() []domain.Locale {
+	return []domain.Locale{domain.LocaleEnglish, domain.LocaleRussian}
+}
+
+// Translate returns message in locale. Messages without a catalog entry, and
+// every message in English, are returned unchanged.
+func Translate(locale domain.Locale, message string) string {
+	if message == "" {
+		return message
+	}
+	compileOnce.Do(compileCatalogs)
+	entries := compiled[locale]
+	if len(entries) == 0 {
+		return message
+	}
+	return translate(entries, message)
+}
+
+// translate finds the most specific format matching message and fills its
+// translation with the translated values.
+func translate(entries []entry, message string) string {
+	for _, e := range entries {
+		match := e.pattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		values := make([]any, len(match)-1)
+		for i, arg := range match[1:] {
+			values[i] = translate(entries, arg)
+		}
+		return fmt.Sprintf(e.translation, values...)
+	}
+	return message
+}
+
+// compileCatalogs turns every catalog format into an anchored pattern with
+// one lazy capture group per verb.
+func compileCatalogs() {
+	compiled = make(map[domain.Locale][]entry, len(catalogs))
+	for locale, catalog := range catalogs {
+		entries := make([]entry, 0, len(catalog))
+		for format, translation := range catalog {
+			pattern, literal := compileFormat(format)
+			entries = append(entries, entry{format: format, pattern: pattern, translation: translation, literal: literal})
+		}
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].literal != entries[j].literal {
+				return entries[i].literal > entries[j].literal
+			}
+			return entries[i].format < entries[j].format
+		})
+		compiled[locale] = entries
+	}
+}
+
+// compileFormat converts a fmt format into a regular expression and reports
+// how many characters of it are fixed text.
+func compileFormat(format string) (*regexp.Regexp, int) {
+	var pattern strings.Builder
+	pattern.WriteString(`(?s)^`)
+	literal := 0
+	last := 0
+	for _, loc := range verbPattern.FindAllStringIndex(format, -1) {
+		text := format[last:loc[0]]
+		pattern.WriteString(regexp.QuoteMeta(text))
+		literal += len(text)
+		if format[loc[0]:loc[1]] == "%%" {
+			pattern.WriteString("%")
+			literal++
+		} else {
+			pattern.WriteString(`(.*?)`)
+		}
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(format[last:]))
+	literal += len(format) - last
+	pattern.WriteString(`$`)
+	return regexp.MustCompile(pattern.String()), literal
+}
@@ -0,0 +1,153 @@
+package i18n
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestTranslate covers exact messages, formatted values, nested causes, and
+// messages the catalog does not know.
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  domain.Locale
+		message string
+		want    string
+	}{
+		{name: "english unchanged", locale: domain.LocaleEnglish, message: "Model path is empty.", want: "Model path is empty."},
+		{name: "unknown locale", locale: "de", message: "Model path is empty.", want: "Model path is empty."},
+		{name: "exact", locale: domain.LocaleRussian, message: "Model path is empty.", want: "Путь к модели не указан."},
+		{name: "value kept", locale: domain.LocaleRussian, message: "Found at /usr/bin/ffmpeg", want: "Найден: /usr/bin/ffmpeg"},
+		{
+			name:    "reordered values",
+			locale:  domain.LocaleRussian,
+			message: "Found 5 media files in /talks: 3 to transcribe, 1 already transcribed, 1 filtered out",
+			want:    "В /talks найдено медиафайлов: 5; к распознаванию: 3, уже распознано: 1, отфильтровано: 1",
+		},
+		{name: "wrapped cause", locale: domain.LocaleRussian, message: "load settings: settings store is not configured", want: "загрузка настроек: хранилище настроек не настроено"},
+		{name: "unknown cause kept", locale: domain.LocaleRussian, message: "load settings: open /x: permission denied", want: "загрузка настроек: open /x: permission denied"},
+		{
+			name:    "pipeline error",
+			locale:  domain.LocaleRussian,
+			message: "transcribing: whisper.cpp transcription failed (cmd=whisper-cli -m model.bin exit=1)",
+			want:    "распознавание: ошибка распознавания в whisper.cpp (команда=whisper-cli -m model.bin код=1)",
+		},
+		{
+			name:    "adjacent values",
+			locale:  domain.LocaleRussian,
+			message: "Cannot reach huggingface.co via proxy proxy:3128; model downloads will fail.",
+			want:    "Нет связи с huggingface.co через прокси proxy:3128; загрузка моделей не будет работать.",
+		},
+		{name: "unknown message", locale: domain.LocaleRussian, message: "something new happened", want: "something new happened"},
+		{name: "empty", locale: domain.LocaleRussian, message: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.locale, tt.message); got != tt.want {
+				t.Fatalf("Translate(%q) = %q, want %q", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCatalogsUseEveryValue checks each translation consumes exactly the
+// values its English format produces, as strings.
+func TestCatalogsUseEveryValue(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for format, translation := range catalog {
+			count := 0
+			for _, verb := range verbPattern.FindAllString(format, -1) {
+				if verb != "%%" {
+					count++
+				}
+			}
+			values := make([]any, count)
+			for i := range values {
+				values[i] = fmt.Sprintf("<%d>", i)
+			}
+			got := fmt.Sprintf(translation, values...)
+			if strings.Contains(got, "%!") {
+				t.Errorf("%s: %q -> %q: %s", locale, format, translation, got)
+				continue
+			}
+			for _, value := range values {
+				if !strings.Contains(got, value.(string)) {
+					t.Errorf("%s: %q -> %q drops %s", locale, format, translation, value)
+				}
+			}
+		}
+	}
+}
+
+// TestCatalogKeysAppearInCode checks every catalog key is a message the code
+// still produces, so a reworded message cannot leave a dead translation. A
+// key matches a string literal with any verbs, the context of a wrapped
+// error ("load settings" in "load settings: %w"), a literal a value is
+// concatenated to, or an action reported as failed or timed out.
+func TestCatalogKeysAppearInCode(t *testing.T) {
+	normalize := func(format string) string { return verbPattern.ReplaceAllString(format, "%s") }
+	literals := map[string]bool{}
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (d.Name() == "frontend" || d.Name() == "i18n" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			if lit, ok := node.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if value, err := strconv.Unquote(lit.Value); err == nil {
+					literals[normalize(strings.TrimSpace(value))] = true
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("read sources: %v", err)
+	}
+
+	used := func(key string) bool {
+		candidates := []string{key, strings.TrimSuffix(key, "%s"), strings.TrimPrefix(key, "%s"),
+			strings.TrimSuffix(key, " failed"), strings.TrimSuffix(key, " timed out")}
+		for _, candidate := range candidates {
+			if candidate = strings.TrimSpace(candidate); candidate != "" && literals[candidate] {
+				return true
+			}
+		}
+		for literal := range literals {
+			if strings.HasPrefix(literal, key+": ") {
+				return true
+			}
+		}
+		return false
+	}
+	for locale, catalog := range catalogs {
+		for key := range catalog {
+			if key != "%s: %s" && !used(normalize(key)) {
+				t.Errorf("%s: %q is not produced by the code", locale, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package i18n
+
+// russian is the Russian catalog. Translations take every value as a string,
+// so they use %s (or %[n]s to reorder) whatever verb the English format has.
+var russian = map[string]string{
+	// Errors are wrapped as "context: cause"; translating both halves lets
+	// causes from other packages read naturally under a known context.
+	"%s: %s": "%s: %s",
+
+	// Pipeline stages and failures.
+	"validation":                                                "проверка",
+	"preprocessing":                                             "подготовка",
+	"transcribing":                                              "распознавание",
+	"exporting":                                                 "экспорт",
+	"%s: %s (cmd=%s exit=%d)":                                   "%s: %s (команда=%s код=%s)",
+	"input media path is required":                              "не указан путь к медиафайлу",
+	"cannot access input media: %s":                             "нет доступа к медиафайлу: %s",
+	"output directory is required":                              "не указан каталог для результатов",
+	"cannot create output directory: %s":                        "не удалось создать каталог для результатов: %s",
+	"failed to create temporary workspace":                      "не удалось создать временный рабочий каталог",
+	"ffmpeg audio conversion failed":                            "ошибка преобразования аудио в ffmpeg",
+	"ffmpeg audio conversion timed out":                         "превышено время преобразования аудио в ffmpeg",
+	"ffmpeg completed but output file is missing":               "ffmpeg завершился, но выходной файл не создан",
+	"whisper.cpp transcription failed":                          "ошибка распознавания в whisper.cpp",
+	"whisper.cpp transcription timed out":                       "превышено время распознавания в whisper.cpp",
+	"whisper.cpp completed but transcript .txt file is missing": "whisper.cpp завершился, но файл расшифровки .txt не создан",
+	"failed to read transcript file: %s":                        "не удалось прочитать файл расшифровки: %s",
+	"failed to re-encode transcript file: %s":                   "не удалось перекодировать файл расшифровки: %s",
+	"failed to write review report: %s":                         "не удалось записать отчёт для проверки: %s",
+	"failed to write hallucination report: %s":                  "не удалось записать отчёт о галлюцинациях: %s",
+	"failed to write corrected transcript: %s":                  "не удалось записать исправленный транскрипт: %s",
+	"failed to write lyrics: %s":                                "не удалось записать файл LRC: %s",
+	"failed to write silence map: %s":                           "не удалось записать карту тишины: %s",
+	"failed to write transcript: %s":                            "не удалось записать транскрипт: %s",
+	"failed to write segments: %s":                              "не удалось записать сегменты: %s",
+	"failed to read segments: %s":                               "не удалось прочитать сегменты: %s",
+	"failed to run %s":                                          "не удалось выполнить %s",
+	"transform %s: %s":                                          "скрипт преобразования %s: %s",
+	"invalid segments file: %s":                                 "некорректный файл сегментов: %s",
+	"failed to export preprocessed audio: %s":                   "не удалось сохранить подготовленное аудио: %s",
+	"input is not a 16 kHz mono 16-bit PCM WAV: %s":             "входной файл не является WAV 16 кГц, моно, 16 бит PCM: %s",
+	"failed to write chapter transcripts":                       "не удалось записать расшифровки глав",
+	"whisper produced no timed segments to align against":       "whisper не вернул сегментов с таймкодами для выравнивания",
+	"start whisper server":                                      "не удалось запустить сервер whisper",
+	"whisper server exited while loading the model":             "сервер whisper завершился при загрузке модели",
+	"whisper server returned %s: %s":                            "сервер whisper вернул %s: %s",
+	"decode whisper server response":                            "не удалось разобрать ответ сервера whisper",
+	"timed out":                                                 "превышено время ожидания",
+	"model path is required":                                    "не указан путь к модели",
+	"cannot access model path: %s":                              "нет доступа к пути модели: %s",
+	"cannot read model directory: %s":                           "не удалось прочитать каталог моделей: %s",
+	"no .bin or .gguf model files found in: %s":                 "в каталоге нет моделей .bin или .gguf: %s",
+
+	// Job control.
+	"job already running":                                       "задача уже выполняется",
+	"no running job":                                            "нет выполняемой задачи",
+	"queued job not found":                                      "задача в очереди не найдена",
+	"invalid job id":                                            "неверный идентификатор задачи",
+	"invalid transition: %s -> %s":                              "недопустимый переход: %s -> %s",
+	"cannot transition without an active job":                   "нельзя сменить состояние без активной задачи",
+	"input path is a directory: %s":                             "указан каталог, а не файл: %s",
+	"job %s not found in history":                               "задача %s не найдена в истории",
+	"cannot access input file: %s":                              "нет доступа к входному файлу: %s",
+	"job queue is not available":                                "очередь задач недоступна",
+	"unknown output format %q":                                  "неизвестный формат вывода %s",
+	"job %s exported no subtitles":                              "задача %s не создала субтитров",
+	"no segments to export":                                     "нет сегментов для экспорта",
+	"segment %d is out of range (0-%d)":                         "сегмент %s вне диапазона (0-%s)",
+	"server is shutting down":                                   "сервер завершает работу",
+	"uploads are not enabled":                                   "загрузка файлов отключена",
+	"unsupported diagnostic item id":                            "неизвестная проверка диагностики",
+	"unknown profile: %s":                                       "неизвестный профиль: %s",
+	"unknown model id: %s":                                      "неизвестный идентификатор модели: %s",
+	"unknown custom model id: %s":                               "неизвестный идентификатор пользовательской модели: %s",
+	"unknown merge mode %q":                                     "неизвестный режим объединения %s",
+	"unknown pipeline mode %q":                                  "неизвестный режим обработки %s",
+	"unknown excerpt format %q":                                 "неизвестный формат цитаты %s",
+	"unknown grammar %q":                                        "неизвестная грамматика %s",
+	"the command grammar needs at least one phrase":             "для грамматики команд нужна хотя бы одна фраза",
+	"grammar file is not set":                                   "не указан файл грамматики",
+	"cannot read grammar file: %v":                              "не удалось прочитать файл грамматики: %s",
+	"excerpt end must be after its start":                       "конец цитаты должен быть позже начала",
+	"no speech between %s and %s":                               "нет речи между %s и %s",
+	"unknown placeholder %s in naming template":                 "неизвестный заполнитель %s в шаблоне имени",
+	"not a model file: %s":                                      "это не файл модели: %s",
+	"subtitle path is required":                                 "не указан путь к субтитрам",
+	"token name is required":                                    "не указано имя токена",
+	"source preset prefix is required":                          "не указан каталог источника",
+	"source preset %s: output directory or profile is required": "источник %s: укажите каталог для результатов или профиль",
+	"duplicate source preset: %s":                               "источник указан дважды: %s",
+
+	// Error contexts.
+	"load settings":                         "загрузка настроек",
+	"save settings":                         "сохранение настроек",
+	"invalid settings":                      "неверные настройки",
+	"load history":                          "загрузка истории",
+	"save job history":                      "сохранение истории задач",
+	"load profiles":                         "загрузка профилей",
+	"save profiles":                         "сохранение профилей",
+	"load source presets":                   "загрузка источников",
+	"save source presets":                   "сохранение источников",
+	"source preset %s":                      "источник %s",
+	"load benchmarks":                       "загрузка замеров",
+	"check model path":                      "проверка пути модели",
+	"read subtitles":                        "чтение субтитров",
+	"write subtitles":                       "запись субтитров",
+	"read chapters":                         "чтение глав",
+	"cannot read input WAV":                 "чтение входного WAV",
+	"read plugin directory":                 "чтение папки плагинов",
+	"write excerpt":                         "запись цитаты",
+	"unknown plugin: %s":                    "неизвестный плагин: %s",
+	"read transform %s":                     "чтение скрипта преобразования %s",
+	"invalid transform %s":                  "ошибка в скрипте преобразования %s",
+	"apply retention":                       "очистка по правилам хранения",
+	"read preprocess cache":                 "чтение кэша подготовленного аудио",
+	"launch file manager":                   "запуск файлового менеджера",
+	"settings store is not configured":      "хранилище настроек не настроено",
+	"profile store is not configured":       "хранилище профилей не настроено",
+	"source preset store is not configured": "хранилище источников не настроено",
+	"secret store is not configured":        "хранилище секретов не настроено",
+	"token store is not configured":         "хранилище токенов не настроено",
+	"custom model store is not configured":  "хранилище пользовательских моделей не настроено",
+	"cleanup temporary files":               "удаление временных файлов",
+	"read interrupted job state":            "чтение состояния прерванных задач",
+	"write metadata sidecar":                "запись файла метаданных",
+	"embed lyrics":                          "встраивание текста в теги",
+	"start queued file %s":                  "запуск файла из очереди %s",
+	"already transcribed with this model and language in job %s: %s": "уже расшифровано этой моделью на этом языке в задаче %s: %s",
+	"merge transcripts of %s":               "объединение расшифровок %s",
+	"load telemetry state":                  "загрузка состояния телеметрии",
+	"save telemetry state":                  "сохранение состояния телеметрии",
+	"internal error: %v":                    "внутренняя ошибка: %s",
+	"list crash reports":                    "список отчётов о сбоях",
+	"read crash report":                     "чтение отчёта о сбое",
+	"invalid crash report id %q":            "неверный идентификатор отчёта о сбое %s",
+	"check for updates":                     "проверка обновлений",
+	"read release checksums":                "чтение контрольных сумм релиза",
+	"download %s":                           "загрузка %s",
+	"verify %s":                             "проверка %s",
+	"no newer release than %s":              "нет релиза новее %s",
+	"release %s has no installer for %s/%s": "в релизе %s нет установщика для %s/%s",
+	"GitHub API rate limit exceeded":        "превышен лимит запросов к GitHub API",
+	"%v; store a GitHub token with SetGitHubToken or set %s to raise the limit": "%s; чтобы поднять лимит, сохраните токен GitHub через SetGitHubToken или укажите его в %s",
+	"update directory is not configured":                                        "каталог обновлений не настроен",
+	"checksum mismatch: got %s, want %s":                                        "контрольная сумма не совпадает: получено %s, ожидалось %s",
+	"remote job on %s":                                                          "удалённая задача на %s",
+
+	// Job events.
+	"Transcript exported":              "Расшифровка сохранена",
+	"Subtitles exported":               "Субтитры сохранены",
+	"Translated subtitles exported":    "Переведённые субтитры сохранены",
+	"%d chapters exported":             "Сохранено глав: %s",
+	"%d audio clips exported to %s":    "Аудиофрагментов сохранено: %s, папка %s",
+	"Anki deck with %d cards exported": "Колода Anki сохранена, карточек: %s",
+	"Lyrics exported":                  "Текст LRC сохранён",
+	"%d gaps without speech marked":    "Отмечено пауз без речи: %s",
+	"Excerpt exported":                 "Цитата сохранена",
+	"Preprocessed audio exported":      "Подготовленное аудио сохранено",
+	"Subtitles regenerated":            "Субтитры пересобраны",
+	"Plugin %s exported a file":        "Плагин %s сохранил файл",
+	"Transform output exported":        "Результат скрипта преобразования сохранён",
+	"Same content already transcribed with this model and language in job %s": "Этот файл уже расшифрован этой моделью на этом языке в задаче %s",
+	"Duplicate skipped":                     "Повтор пропущен",
+	"Plugin %s failed: %v":                  "Ошибка плагина %s: %s",
+	"Plugin %s reported a missing file: %s": "Плагин %s указал несуществующий файл: %s",
+	"Estimated to finish at %s: %s of audio at %.2fs per audio second": "Ожидаемое завершение в %s: %s аудио, %s с обработки на секунду аудио",
+	"Lyrics embedded in %s":                            "Текст записан в теги %s",
+	"%d chapter transcripts exported":                  "Сохранено расшифровок глав: %s",
+	"Splitting transcript into %d chapters":            "Расшифровка делится на главы: %s",
+	"Input is mono; channels are not split":            "Запись моно, каналы не разделяются",
+	"Preprocessed audio reused from cache":             "Подготовленное аудио взято из кэша",
+	"Skipped %d silence or music regions totalling %s": "Пропущено фрагментов тишины или музыки: %s, всего %s",
+	"Low-confidence passages need review":              "Фрагменты с низкой уверенностью требуют проверки",
+	"%d suspected hallucinations flagged":              "Отмечено возможных галлюцинаций: %s",
+	"%d suspected hallucinations removed":              "Удалено возможных галлюцинаций: %s",
+	"Notifications skipped during quiet hours":         "Уведомления пропущены в тихие часы",
+	"Media Transcriber %s is available (installed %s)": "Доступна версия Media Transcriber %s (установлена %s)",
+	"Update %s staged at %s":                           "Обновление %s загружено: %s",
+	"Internal error in %s: %v":                         "Внутренняя ошибка в %s: %s",
+	"Internal error in %s, crash report saved to %s":   "Внутренняя ошибка в %s, отчёт о сбое сохранён в %s",
+	"Remote job cancelled":                             "Удалённая задача отменена",
+	"Obsidian note written":                            "Заметка Obsidian записана",
+	"Notion page created: %s":                          "Создана страница Notion: %s",
+	"Uploaded to %s":                                   "Загружено в %s",
+	"Emailed to %s":                                    "Отправлено на %s",
+	"Intermediate files kept in %s":                    "Промежуточные файлы сохранены в %s",
+	"Dropped queued file on shutdown: %s":              "Файл из очереди отброшен при завершении: %s",
+	"Sending %s to %s":                                 "Отправка %s на %s",
+	"Downloaded %d files from %s":                      "Загружено файлов: %s из %s",
+	"Server shutting down, draining %d queued jobs":    "Сервер завершает работу, задач в очереди: %s",
+	"Merged %d of %d transcripts into %s":              "Объединено расшифровок: %s из %s в %s",
+	"Recorded during %q":                               "Записано во время %s",
+	"Found %d media files in %s: %d to transcribe, %d already transcribed, %d filtered out":               "В %[2]s найдено медиафайлов: %[1]s; к распознаванию: %[3]s, уже распознано: %[4]s, отфильтровано: %[5]s",
+	"Retention cleanup removed %d history entries, %d cached audio files, and %d transcripts, freeing %s": "Очистка по правилам хранения удалила записей истории: %s, файлов аудио из кэша: %s, расшифровок: %s; освобождено %s",
+	"LLM tagging failed, using local rules: %v":                                                           "Ошибка разметки через LLM, используются локальные правила: %s",
+	"%s notification: %v":    "Уведомление %s: %s",
+	"%s upload %s: %v":       "Загрузка %s %s: %s",
+	"%s upload: %v":          "Загрузка %s: %s",
+	"Using source preset %s": "Используется источник %s",
+	"Warning: %s":            "Предупреждение: %s",
+	"model %s downloaded without CoreML acceleration: %v": "модель %s загружена без ускорения CoreML: %s",
+
+	// Diagnostics.
+	"Fixing %s (%d/%d)":                               "Исправление %s (%s из %s)",
+	"Handled together with ffmpeg":                    "Исправлено вместе с ffmpeg",
+	"Fix applied":                                     "Исправление применено",
+	"Fix failed":                                      "Исправить не удалось",
+	"Authorization required to run %s: %s":            "Нужно разрешение на запуск %s: %s",
+	"confirm the system authorization dialog":         "подтвердите его в системном окне авторизации",
+	"enter your password in the terminal":             "введите пароль в терминале",
+	"%s must be allowed to run it without a password": "%s должен разрешать запуск без пароля",
+	"authorize it with a polkit agent":                "подтвердите его через агент polkit",
+	"installing needs root, but none of pkexec, sudo, or doas is available":                            "для установки нужны права root, но нет ни pkexec, ни sudo, ни doas",
+	"authorization to run %s was dismissed":                                                            "запрос разрешения на запуск %s отклонён",
+	"not authorized to run %s: %v":                                                                     "нет разрешения на запуск %s: %s",
+	"%s needs a password to run %s; run media-transcriber doctor -fix in a terminal or install pkexec": "%s требует пароль для запуска %s; выполните media-transcriber doctor -fix в терминале или установите pkexec",
+	"only the Windows user PATH can be updated; add the line for your shell to its profile":            "автоматически обновляется только пользовательский PATH Windows; добавьте строку для своей оболочки в её профиль",
+	"update user PATH":                       "обновление пользовательского PATH",
+	"read user PATH":                         "чтение пользовательского PATH",
+	"not an executable: %s":                  "не исполняемый файл: %s",
+	"translate %s to %s: %v":                 "перевод %s на %s: %s",
+	"translation backend":                    "сервис перевода",
+	"model file is only %d bytes: %s":        "файл модели занимает всего %s байт: %s",
+	"path is required":                       "не указан путь",
+	"resolve model file":                     "поиск файла модели",
+	"Model path":                             "Путь к модели",
+	"Output directory":                       "Каталог результатов",
+	"CoreML acceleration":                    "Ускорение CoreML",
+	"ffmpeg hardware decoding":               "Аппаратное декодирование ffmpeg",
+	"End-to-end smoke test":                  "Сквозной тест",
+	"Found at %s":                            "Найден: %s",
+	"Using configured %s":                    "Используется указанный путь: %s",
+	"Tool not found in PATH: %s":             "Программа не найдена в PATH: %s",
+	"Configured %s is not an executable: %s": "Указанный %s не является исполняемым файлом: %s",
+	"Fix the path in settings, or clear it to look the tool up on PATH.":                         "Исправьте путь в настройках или очистите его, чтобы искать программу в PATH.",
+	"Install it and ensure the binary is available on PATH before starting a transcription job.": "Установите программу и убедитесь, что она доступна в PATH, перед запуском распознавания.",
+	"Model path is empty.":                                                                              "Путь к модели не указан.",
+	"Model path does not exist: %s":                                                                     "Путь к модели не существует: %s",
+	"Cannot access model path: %s":                                                                      "Нет доступа к пути модели: %s",
+	"Cannot read model directory: %s":                                                                   "Не удалось прочитать каталог моделей: %s",
+	"No model files found in directory: %s":                                                             "В каталоге нет файлов моделей: %s",
+	"Model directory is valid: %s":                                                                      "Каталог моделей в порядке: %s",
+	"Model file found: %s":                                                                              "Файл модели найден: %s",
+	"Model file is not a valid whisper.cpp model: %s (%v)":                                              "Файл не является моделью whisper.cpp: %s (%s)",
+	"Download a whisper.cpp model and configure the path in settings.":                                  "Скачайте модель whisper.cpp и укажите путь к ней в настройках.",
+	"Set a valid model file path or a directory containing whisper models.":                             "Укажите файл модели или каталог с моделями whisper.",
+	"Check permissions for the model directory.":                                                        "Проверьте права доступа к каталогу моделей.",
+	"Place a .bin or .gguf model file in this directory or point to a model file directly.":             "Поместите в каталог файл модели .bin или .gguf или укажите файл модели напрямую.",
+	"Delete the file and download the model again; GGML (.bin) and GGUF (.gguf) formats are supported.": "Удалите файл и скачайте модель заново; поддерживаются форматы GGML (.bin) и GGUF (.gguf).",
+	"Output directory is empty.":                                                                        "Каталог результатов не указан.",
+	"Cannot create output directory: %s":                                                                "Не удалось создать каталог результатов: %s",
+	"Output directory is not writable: %s":                                                              "Нет права записи в каталог результатов: %s",
+	"Writable directory: %s":                                                                            "Каталог доступен для записи: %s",
+	"Set an output directory where transcript files can be written.":                                    "Укажите каталог, в который можно записывать расшифровки.",
+	"Choose a writable directory for transcript export.":                                                "Выберите каталог для расшифровок с правом записи.",
+	"Choose a writable location or adjust filesystem permissions.":                                      "Выберите доступное для записи место или измените права доступа.",
+	"In use: CoreML encoder found at %s.":                                                               "Используется: кодировщик CoreML найден в %s.",
+	"Not in use: no CoreML encoder found for %s.":                                                       "Не используется: кодировщик CoreML для %s не найден.",
+	"Not in use: no model file is configured.":                                                          "Не используется: файл модели не указан.",
+	"Download the model from the catalog to fetch %s, or place it beside the model; whisper.cpp then runs the encoder on the Apple Neural Engine.": "Скачайте модель из каталога, чтобы получить %s, или положите его рядом с моделью; тогда whisper.cpp выполнит кодировщик на Apple Neural Engine.",
+	"Requires a whisper.cpp build with CoreML support (WHISPER_COREML=1); other builds ignore the encoder.":                                        "Нужна сборка whisper.cpp с поддержкой CoreML (WHISPER_COREML=1); другие сборки игнорируют кодировщик.",
+	"model downloads":                             "загрузка моделей",
+	"whisper.cpp release installs":                "установка whisper.cpp из релизов",
+	"Reachable":                                   "Доступен",
+	"Reachable via proxy %s":                      "Доступен через прокси %s",
+	"Cannot reach %s; %s will fail.":              "Нет связи с %s; %s не будет работать.",
+	"Cannot reach %s via proxy %s; %s will fail.": "Нет связи с %s через прокси %s; %s не будет работать.",
+	"Check your internet connection and firewall. Behind a corporate proxy, set HTTPS_PROXY before starting the app.": "Проверьте подключение к интернету и брандмауэр. За корпоративным прокси задайте HTTPS_PROXY перед запуском приложения.",
+	"Check that the proxy in HTTPS_PROXY/HTTP_PROXY is reachable and allows this host, or add it to NO_PROXY.":        "Проверьте, что прокси из HTTPS_PROXY/HTTP_PROXY доступен и пропускает этот хост, или добавьте хост в NO_PROXY.",
+	"Not checked: ffmpeg was not found.":                                                    "Не проверено: ffmpeg не найден.",
+	"Could not list hardware decoders: %v":                                                  "Не удалось получить список аппаратных декодеров: %s",
+	"Off. Supported by ffmpeg: %s.":                                                         "Выключено. ffmpeg поддерживает: %s.",
+	"Using %s. Supported by ffmpeg: %s.":                                                    "Используется %s. ffmpeg поддерживает: %s.",
+	"%s is not supported by this ffmpeg build. Supported: %s.":                              "%s не поддерживается этой сборкой ffmpeg. Поддерживаются: %s.",
+	"Choose a hardware decoder in settings to speed up preprocessing of large video files.": "Выберите аппаратный декодер в настройках, чтобы ускорить подготовку больших видеофайлов.",
+	"Preprocessing falls back to CPU decoding; pick a supported method or auto.":            "Подготовка выполняется на CPU; выберите поддерживаемый способ или auto.",
+	"whisper.cpp GPU":                                                                   "GPU для whisper.cpp",
+	"Off: whisper.cpp runs on the CPU.":                                                 "Выключено: whisper.cpp работает на CPU.",
+	"whisper.cpp picks the GPU. Detected: %s.":                                          "GPU выбирает whisper.cpp. Обнаружены: %s.",
+	"Choose a GPU in settings to keep transcription off the display GPU.":               "Выберите GPU в настройках, чтобы распознавание не занимало видеокарту дисплея.",
+	"Using device %s; no GPUs could be listed to confirm it.":                           "Используется устройство %s; проверить его не удалось: список GPU недоступен.",
+	"GPU %q was not found: no GPUs were detected":                                       "GPU %s не найден: видеокарты не обнаружены",
+	"Check the GPU driver, or clear the GPU setting.":                                   "Проверьте драйвер видеокарты или очистите настройку GPU.",
+	"no GPU matches %q; detected: %s":                                                   "нет GPU, подходящего под %s; обнаружены: %s",
+	"%q matches %d GPUs, choose one by index; detected: %s":                             "под %s подходят %s GPU, выберите один по номеру; обнаружены: %s",
+	"Choose one of the detected GPUs in settings, or clear the GPU setting.":            "Выберите в настройках один из обнаруженных GPU или очистите настройку GPU.",
+	"Using GPU %d: %s.":                                                                 "Используется GPU %s: %s.",
+	"none":                                                                              "нет",
+	"Transcription pipeline is not configured.":                                         "Конвейер распознавания не настроен.",
+	"Cannot create temporary directory for smoke test.":                                 "Не удалось создать временный каталог для теста.",
+	"Check free disk space and permissions of the configured temp directory.":           "Проверьте свободное место и права доступа к временному каталогу.",
+	"ffmpeg could not generate a test tone: %v":                                         "ffmpeg не смог создать тестовый сигнал: %s",
+	"Reinstall ffmpeg; the installed build may lack the lavfi input device.":            "Переустановите ffmpeg; в установленной сборке может не быть устройства ввода lavfi.",
+	"Pipeline failed on test tone: %v":                                                  "Конвейер не справился с тестовым сигналом: %s",
+	"Check the ffmpeg installation, then run the smoke test again.":                     "Проверьте установку ffmpeg и запустите тест снова.",
+	"Check the model file and whisper.cpp installation, then run the smoke test again.": "Проверьте файл модели и установку whisper.cpp и запустите тест снова.",
+	"Pipeline completed but no transcript file was written.":                            "Конвейер завершился, но файл расшифровки не записан.",
+	"Check that the whisper.cpp build supports txt output (-otxt).":                     "Проверьте, что сборка whisper.cpp поддерживает вывод txt (-otxt).",
+	"Test tone transcribed successfully with the configured model.":                     "Тестовый сигнал успешно распознан выбранной моделью.",
+	"Temporary files were removed.":                                                     "Временные файлы удалены.",
+
+	// Application menu.
+	"File":               "Файл",
+	"Open Media…":        "Открыть медиафайл…",
+	"Open Recent":        "Недавние файлы",
+	"No Recent Files":    "Нет недавних файлов",
+	"Start Job":          "Запустить задачу",
+	"Cancel Job":         "Отменить задачу",
+	"Quit":               "Выход",
+	"Help":               "Справка",
+	"Diagnostics":        "Диагностика",
+	"Check for Updates…": "Проверить обновления…",
+}
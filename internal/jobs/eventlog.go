@@ -0,0 +1,135 @@
+package jobs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidJobID is returned when a job ID cannot name an event log file.
+var ErrInvalidJobID = errors.New("invalid job id")
+
+// EventLog appends each job's complete event stream to <dir>/<jobID>.events.jsonl.
+// Unlike EventBus it is unbounded, so failed runs can be inspected after the fact.
+type EventLog struct {
+	dir string
+
+	mu    sync.Mutex
+	files map[string]*os.File
+}
+
+// NewEventLog creates an event log rooted at dir; the directory is created on first write.
+func NewEventLog(dir string) *EventLog {
+	return &EventLog{dir: dir, files: map[string]*os.File{}}
+}
+
+// Append writes one event as a JSON line to its job's file, keeping the file open
+// until Close. Events without a job ID are ignored.
+func (l *EventLog) Append(event Event) error {
+	if event.JobID == "" {
+		return nil
+	}
+	path, err := l.path(event.JobID)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, ok := l.files[event.JobID]
+	if !ok {
+		if err := os.MkdirAll(l.dir, 0o755); err != nil {
+			return err
+		}
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		l.files[event.JobID] = file
+	}
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// Close releases the open file of a finished job. Later appends reopen it.
+func (l *EventLog) Close(jobID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, ok := l.files[jobID]
+	if !ok {
+		return nil
+	}
+	delete(l.files, jobID)
+	return file.Close()
+}
+
+// Remove closes and deletes a job's log, as when its history entry is dropped.
+// A job that never logged anything is not an error.
+func (l *EventLog) Remove(jobID string) error {
+	path, err := l.path(jobID)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if file, ok := l.files[jobID]; ok {
+		delete(l.files, jobID)
+		_ = file.Close()
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Read returns every logged event of a job in write order.
+func (l *EventLog) Read(jobID string) ([]Event, error) {
+	path, err := l.path(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// A crash can truncate the final line; keep everything before it.
+			return events, fmt.Errorf("parse %s line %d: %w", filepath.Base(path), line, err)
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// path maps a job ID to its log file, rejecting IDs that could escape the directory.
+func (l *EventLog) path(jobID string) (string, error) {
+	if jobID == "" || jobID == "." || jobID == ".." || strings.ContainsAny(jobID, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidJobID, jobID)
+	}
+	return filepath.Join(l.dir, jobID+".events.jsonl"), nil
+}
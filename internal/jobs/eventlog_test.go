@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestEventLogAppendAndRead verifies per-job JSONL persistence beyond the bus limit.
+func TestEventLogAppendAndRead(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+	log := NewEventLog(dir)
+
+	for i := 0; i < 3; i++ {
+		if err := log.Append(Event{Seq: int64(i + 1), JobID: "job-1", Type: EventTypeOutput, Stderr: "line"}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := log.Append(Event{JobID: "job-2", Type: EventTypeStatus}); err != nil {
+		t.Fatalf("append job-2: %v", err)
+	}
+	if err := log.Append(Event{Type: EventTypeDownload}); err != nil {
+		t.Fatalf("append without job: %v", err)
+	}
+	if err := log.Close("job-1"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := log.Append(Event{Seq: 4, JobID: "job-1", Type: EventTypeResult}); err != nil {
+		t.Fatalf("append after close: %v", err)
+	}
+
+	events, err := log.Read("job-1")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(events) != 4 || events[3].Type != EventTypeResult || events[0].Stderr != "line" {
+		t.Fatalf("events = %+v", events)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("log files = %d, want 2", len(entries))
+	}
+}
+
+// TestEventLogRemove verifies a removed log is closed and deleted, and that
+// removing a job without a log succeeds.
+func TestEventLogRemove(t *testing.T) {
+	dir := t.TempDir()
+	log := NewEventLog(dir)
+
+	if err := log.Append(Event{JobID: "job-1", Type: EventTypeStatus}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := log.Remove("job-1"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "job-1.events.jsonl")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("log file still present: %v", err)
+	}
+	if len(log.files) != 0 {
+		t.Fatalf("open files = %d, want 0", len(log.files))
+	}
+	if err := log.Remove("job-2"); err != nil {
+		t.Fatalf("remove missing log: %v", err)
+	}
+	if err := log.Remove("../job"); !errors.Is(err, ErrInvalidJobID) {
+		t.Fatalf("Remove(../job) error = %v, want ErrInvalidJobID", err)
+	}
+}
+
+// TestEventLogRejectsUnsafeJobIDs verifies IDs cannot escape the log directory.
+func TestEventLogRejectsUnsafeJobIDs(t *testing.T) {
+	log := NewEventLog(t.TempDir())
+	for _, id := range []string{"", "..", "../job", `a\b`} {
+		if _, err := log.Read(id); !errors.Is(err, ErrInvalidJobID) {
+			t.Fatalf("Read(%q) error = %v, want ErrInvalidJobID", id, err)
+		}
+	}
+}
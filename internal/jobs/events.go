@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -15,6 +16,40 @@ const (
 	EventTypeLog    EventType = "log"
 	EventTypeResult EventType = "result"
 	EventTypeError  EventType = "error"
+
+	// EventTypeRemediation reports progress of diagnostic fixes.
+	EventTypeRemediation EventType = "remediation"
+
+	// EventTypeAuthorization announces that a diagnostic fix is about to ask
+	// the user to let Command, an escalation tool such as pkexec or sudo, run
+	// Args as root.
+	EventTypeAuthorization EventType = "authorization"
+
+	// EventTypeDownload reports queued, running, and finished file downloads.
+	EventTypeDownload EventType = "download"
+
+	// EventTypeOutput carries one live output line of a running command in Stdout or Stderr.
+	EventTypeOutput EventType = "output"
+
+	// EventTypeRecovery reports a job found interrupted by an unclean shutdown.
+	EventTypeRecovery EventType = "recovery"
+
+	// EventTypeProgress carries a Job snapshot whenever overall progress advances a percent.
+	EventTypeProgress EventType = "progress"
+
+	// EventTypeUpdate announces a newer app release found by the startup check.
+	EventTypeUpdate EventType = "update"
+
+	// EventTypeCrash reports a recovered panic; TextPath is the crash report.
+	EventTypeCrash EventType = "crash"
+
+	// EventTypeSegment carries one transcript segment in Text, timed by
+	// StartMs and EndMs, as whisper prints it during a job.
+	EventTypeSegment EventType = "segment"
+
+	// EventTypeDraft carries the draft model's provisional transcript in Text;
+	// the job's result event later replaces it.
+	EventTypeDraft EventType = "draft"
 )
 
 // Event is a sequenced payload consumed by UI subscribers.
@@ -31,25 +66,90 @@ type Event struct {
 	Stdout    string           `json:"stdout,omitempty"`
 	Stderr    string           `json:"stderr,omitempty"`
 	TextPath  string           `json:"textPath,omitempty"`
+
+	// Stages carries per-stage timing and peak memory on the final result event.
+	Stages []domain.StageTiming `json:"stages,omitempty"`
+
+	// Job is a snapshot of the job with stage, progress, and timing on status and progress events.
+	Job *domain.Job `json:"job,omitempty"`
+
+	// Text, StartMs, and EndMs carry the transcript passage of segment events,
+	// and Speaker its channel's speaker in split-channel jobs; draft events
+	// carry only Text.
+	Text    string `json:"text,omitempty"`
+	StartMs int64  `json:"startMs,omitempty"`
+	EndMs   int64  `json:"endMs,omitempty"`
+	Speaker string `json:"speaker,omitempty"`
+
+	DiagnosticID string `json:"diagnosticId,omitempty"`
+	DownloadID   string `json:"downloadId,omitempty"`
+	BytesDone    int64  `json:"bytesDone,omitempty"`
+	BytesTotal   int64  `json:"bytesTotal,omitempty"`
+}
+
+const (
+	// maxRetainedJobs bounds how many per-job histories the bus keeps; the oldest job is dropped first.
+	maxRetainedJobs = 32
+	// subscriberBuffer is how many undelivered events a subscriber may fall behind.
+	subscriberBuffer = 256
+)
+
+// EventFilter narrows event queries. Empty fields match everything.
+type EventFilter struct {
+	JobID string      `json:"jobId,omitempty"`
+	Types []EventType `json:"types,omitempty"`
+}
+
+// Matches reports whether the event passes both the job and the type filter,
+// for checking live events that did not come from a per-job buffer.
+func (f EventFilter) Matches(event Event) bool {
+	if f.JobID != "" && event.JobID != f.JobID {
+		return false
+	}
+	return f.matches(event)
 }
 
-// EventBus stores recent events and provides incremental reads.
+// matches reports whether the event passes the type filter.
+func (f EventFilter) matches(event Event) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, eventType := range f.Types {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventBus stores recent events and provides incremental reads. Besides the
+// global buffer it keeps a bounded buffer per job, so one job's history
+// survives bursts of events from other jobs or downloads.
 type EventBus struct {
 	mu        sync.RWMutex
 	nextSeq   int64
 	maxEvents int
 	events    []Event
+	jobEvents map[string][]Event
+	jobOrder  []string
+
+	// subscribers maps each subscriber channel to a channel closed when the
+	// subscriber is removed, which releases its context watcher.
+	subscribers map[chan Event]chan struct{}
 }
 
-// NewEventBus creates a bounded in-memory event buffer.
+// NewEventBus creates a bounded in-memory event buffer. maxEvents caps the
+// global buffer and each per-job buffer.
 func NewEventBus(maxEvents int) *EventBus {
 	if maxEvents <= 0 {
 		maxEvents = 500
 	}
 
 	return &EventBus{
-		maxEvents: maxEvents,
-		events:    make([]Event, 0, maxEvents),
+		maxEvents:   maxEvents,
+		events:      make([]Event, 0, maxEvents),
+		jobEvents:   map[string][]Event{},
+		subscribers: map[chan Event]chan struct{}{},
 	}
 }
 
@@ -64,29 +164,101 @@ func (b *EventBus) Publish(event Event) Event {
 		event.Timestamp = time.Now().UTC()
 	}
 
-	b.events = append(b.events, event)
-	if len(b.events) > b.maxEvents {
-		trim := len(b.events) - b.maxEvents
-		b.events = append([]Event(nil), b.events[trim:]...)
+	b.events = appendBounded(b.events, event, b.maxEvents)
+	if event.JobID != "" {
+		if _, ok := b.jobEvents[event.JobID]; !ok {
+			b.jobOrder = append(b.jobOrder, event.JobID)
+			if len(b.jobOrder) > maxRetainedJobs {
+				delete(b.jobEvents, b.jobOrder[0])
+				b.jobOrder = append([]string(nil), b.jobOrder[1:]...)
+			}
+		}
+		b.jobEvents[event.JobID] = appendBounded(b.jobEvents[event.JobID], event, b.maxEvents)
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Slow consumer: close its channel rather than block publishers. It can
+			// resubscribe and catch up with Since(lastSeq).
+			b.unsubscribe(ch)
+		}
 	}
 
 	return event
 }
 
+// Subscribe returns a channel receiving every event published after the call.
+// The channel is closed when ctx is done or when the subscriber falls more than
+// subscriberBuffer events behind.
+func (b *EventBus) Subscribe(ctx context.Context) <-chan Event {
+	ch := make(chan Event, subscriberBuffer)
+	removed := make(chan struct{})
+
+	b.mu.Lock()
+	b.subscribers[ch] = removed
+	b.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-removed:
+			return
+		}
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(ch)
+	}()
+	return ch
+}
+
+// unsubscribe closes ch and stops its context watcher, if ch is still
+// subscribed. The caller holds b.mu.
+func (b *EventBus) unsubscribe(ch chan Event) {
+	removed, ok := b.subscribers[ch]
+	if !ok {
+		return
+	}
+	delete(b.subscribers, ch)
+	close(removed)
+	close(ch)
+}
+
 // Since returns events with sequence strictly greater than seq.
 func (b *EventBus) Since(seq int64) []Event {
+	return b.Query(seq, EventFilter{})
+}
+
+// Query returns events with sequence strictly greater than seq that match the
+// filter. A job filter reads that job's own buffer instead of the global one.
+func (b *EventBus) Query(seq int64, filter EventFilter) []Event {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	if len(b.events) == 0 {
+	source := b.events
+	if filter.JobID != "" {
+		source = b.jobEvents[filter.JobID]
+	}
+	if len(source) == 0 {
 		return nil
 	}
 
-	out := make([]Event, 0, len(b.events))
-	for _, event := range b.events {
-		if event.Seq > seq {
+	out := make([]Event, 0, len(source))
+	for _, event := range source {
+		if event.Seq > seq && filter.matches(event) {
 			out = append(out, event)
 		}
 	}
 	return out
 }
+
+// appendBounded appends an event and trims the oldest entries beyond limit.
+func appendBounded(events []Event, event Event, limit int) []Event {
+	events = append(events, event)
+	if len(events) > limit {
+		trim := len(events) - limit
+		events = append([]Event(nil), events[trim:]...)
+	}
+	return events
+}
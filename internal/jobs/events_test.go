@@ -1,6 +1,12 @@
 package jobs
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
 
 // TestEventBusSince verifies incremental event reads by sequence.
 func TestEventBusSince(t *testing.T) {
@@ -33,3 +39,133 @@ func TestEventBusCapsHistory(t *testing.T) {
 		t.Fatalf("unexpected events: %+v", events)
 	}
 }
+
+// TestEventBusQueryFiltersByJobAndType verifies per-job buffers and type filters.
+func TestEventBusQueryFiltersByJobAndType(t *testing.T) {
+	bus := NewEventBus(3)
+	bus.Publish(Event{JobID: "job-1", Type: EventTypeStatus, Message: "a"})
+	bus.Publish(Event{JobID: "job-1", Type: EventTypeLog, Message: "b"})
+	for i := 0; i < 3; i++ {
+		bus.Publish(Event{JobID: "job-2", Type: EventTypeOutput})
+	}
+
+	tests := []struct {
+		name   string
+		seq    int64
+		filter EventFilter
+		want   []string
+	}{
+		{name: "job history survives global trim", filter: EventFilter{JobID: "job-1"}, want: []string{"a", "b"}},
+		{name: "job and type", filter: EventFilter{JobID: "job-1", Types: []EventType{EventTypeLog}}, want: []string{"b"}},
+		{name: "job since seq", seq: 1, filter: EventFilter{JobID: "job-1"}, want: []string{"b"}},
+		{name: "type only uses global buffer", filter: EventFilter{Types: []EventType{EventTypeStatus}}, want: []string{}},
+		{name: "unknown job", filter: EventFilter{JobID: "job-3"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := bus.Query(tt.seq, tt.filter)
+			if len(events) != len(tt.want) {
+				t.Fatalf("events = %+v, want messages %v", events, tt.want)
+			}
+			for i, event := range events {
+				if event.Message != tt.want[i] {
+					t.Fatalf("event %d message = %q, want %q", i, event.Message, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestEventFilterMatches checks job and type matching of live events.
+func TestEventFilterMatches(t *testing.T) {
+	event := Event{JobID: "job-1", Type: EventTypeLog}
+	tests := []struct {
+		name   string
+		filter EventFilter
+		want   bool
+	}{
+		{name: "empty", filter: EventFilter{}, want: true},
+		{name: "same job", filter: EventFilter{JobID: "job-1"}, want: true},
+		{name: "other job", filter: EventFilter{JobID: "job-2"}, want: false},
+		{name: "job and type", filter: EventFilter{JobID: "job-1", Types: []EventType{EventTypeStatus, EventTypeLog}}, want: true},
+		{name: "other type", filter: EventFilter{Types: []EventType{EventTypeStatus}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(event); got != tt.want {
+				t.Fatalf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEventBusDropsOldestJobHistory verifies the retained job count is bounded.
+func TestEventBusDropsOldestJobHistory(t *testing.T) {
+	bus := NewEventBus(10)
+	for i := 0; i <= maxRetainedJobs; i++ {
+		bus.Publish(Event{JobID: fmt.Sprintf("job-%d", i)})
+	}
+
+	if events := bus.Query(0, EventFilter{JobID: "job-0"}); len(events) != 0 {
+		t.Fatalf("oldest job should be dropped, got %+v", events)
+	}
+	if events := bus.Query(0, EventFilter{JobID: fmt.Sprintf("job-%d", maxRetainedJobs)}); len(events) != 1 {
+		t.Fatalf("newest job events = %+v, want 1", events)
+	}
+}
+
+// TestEventBusSubscribeDeliversUntilCancelled verifies fan-out and cancellation.
+func TestEventBusSubscribeDeliversUntilCancelled(t *testing.T) {
+	bus := NewEventBus(10)
+	bus.Publish(Event{Message: "before"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := bus.Subscribe(ctx)
+	second := bus.Subscribe(context.Background())
+	bus.Publish(Event{Message: "after"})
+
+	for _, ch := range []<-chan Event{first, second} {
+		if event := <-ch; event.Message != "after" || event.Seq != 2 {
+			t.Fatalf("event = %+v, want seq 2 after", event)
+		}
+	}
+
+	cancel()
+	select {
+	case _, ok := <-first:
+		if ok {
+			t.Fatal("expected closed channel after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+// TestEventBusSubscribeDropsSlowConsumer verifies a full subscriber is closed,
+// not blocking, and its context watcher exits with it.
+func TestEventBusSubscribeDropsSlowConsumer(t *testing.T) {
+	bus := NewEventBus(10)
+	goroutines := runtime.NumGoroutine()
+	ch := bus.Subscribe(context.Background())
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		bus.Publish(Event{})
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Fatalf("received = %d, want %d before close", received, subscriberBuffer)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutines {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d, want %d after the drop", runtime.NumGoroutine(), goroutines)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
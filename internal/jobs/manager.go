@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"media-transcriber/internal/domain"
 )
@@ -14,10 +15,17 @@ var ErrJobAlreadyRunning = errors.New("job already running")
 // ErrNoRunningJob is returned when cancel is requested for idle state.
 var ErrNoRunningJob = errors.New("no running job")
 
+// minProgressForETA is the completion fraction below which ETAs are too noisy to report.
+const minProgressForETA = 0.02
+
 // Manager tracks the single allowed active job and its transitions.
 type Manager struct {
 	mu      sync.RWMutex
 	current domain.Job
+	// estimate is the expected run time of the current job in seconds, 0
+	// when the model's speed is unknown.
+	estimate float64
+	now      func() time.Time
 }
 
 // NewManager creates a manager in idle state.
@@ -26,11 +34,12 @@ func NewManager() *Manager {
 		current: domain.Job{
 			Status: domain.JobStatusIdle,
 		},
+		now: time.Now,
 	}
 }
 
-// Start creates a new job and moves it to preprocessing state.
-func (m *Manager) Start(jobID string) error {
+// Start creates a new job for inputPath and moves it to preprocessing state.
+func (m *Manager) Start(jobID, inputPath string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -38,13 +47,62 @@ func (m *Manager) Start(jobID string) error {
 		return ErrJobAlreadyRunning
 	}
 
+	m.estimate = 0
 	m.current = domain.Job{
-		ID:     jobID,
-		Status: domain.JobStatusPreprocessing,
+		ID:        jobID,
+		Status:    domain.JobStatusPreprocessing,
+		InputPath: inputPath,
+		Stage:     string(domain.JobStatusPreprocessing),
+		StartedAt: m.now().UTC(),
 	}
 	return nil
 }
 
+// SetProgress records the overall completion fraction of the running job and
+// refreshes its ETA. Progress never moves backwards.
+func (m *Manager) SetProgress(progress float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !isRunning(m.current.Status) || progress <= m.current.Progress {
+		return
+	}
+	m.current.Progress = min(progress, 1)
+	m.refreshETA()
+}
+
+// SetEstimate records how long the running job is expected to take in
+// total, from the model's measured speed and the input's duration. The ETA
+// counts it down until progress comes in, then shifts toward the observed
+// pace as the job advances.
+func (m *Manager) SetEstimate(seconds float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !isRunning(m.current.Status) || seconds <= 0 {
+		return
+	}
+	m.estimate = seconds
+	m.refreshETA()
+}
+
+// refreshETA recomputes the ETA from the estimate and the progress so far,
+// trusting progress more the further the job is.
+func (m *Manager) refreshETA() {
+	elapsed := m.now().Sub(m.current.StartedAt).Seconds()
+	progress := m.current.Progress
+	byEstimate := max(m.estimate-elapsed, 0)
+	switch {
+	case progress >= minProgressForETA && m.estimate > 0:
+		byProgress := elapsed * (1 - progress) / progress
+		m.current.ETASeconds = (1-progress)*byEstimate + progress*byProgress
+	case progress >= minProgressForETA:
+		m.current.ETASeconds = elapsed * (1 - progress) / progress
+	default:
+		m.current.ETASeconds = byEstimate
+	}
+}
+
 // Transition validates and applies state transitions for current job.
 func (m *Manager) Transition(status domain.JobStatus) error {
 	m.mu.Lock()
@@ -61,9 +119,27 @@ func (m *Manager) Transition(status domain.JobStatus) error {
 	}
 
 	m.current.Status = status
+	m.applyStatus(status)
 	return nil
 }
 
+// applyStatus updates stage and timing fields after a status change.
+func (m *Manager) applyStatus(status domain.JobStatus) {
+	if isRunning(status) {
+		m.current.Stage = string(status)
+		return
+	}
+	switch status {
+	case domain.JobStatusDone, domain.JobStatusFailed, domain.JobStatusCancelled:
+		finished := m.now().UTC()
+		m.current.FinishedAt = &finished
+		m.current.ETASeconds = 0
+		if status == domain.JobStatusDone {
+			m.current.Progress = 1
+		}
+	}
+}
+
 // Current returns a snapshot of the current job.
 func (m *Manager) Current() domain.Job {
 	m.mu.RLock()
@@ -94,6 +170,7 @@ func (m *Manager) Cancel() error {
 		return ErrNoRunningJob
 	}
 	m.current.Status = domain.JobStatusCancelled
+	m.applyStatus(domain.JobStatusCancelled)
 	return nil
 }
 
@@ -2,6 +2,7 @@ package jobs
 
 import (
 	"testing"
+	"time"
 
 	"media-transcriber/internal/domain"
 )
@@ -13,7 +14,7 @@ func TestManagerLifecycle(t *testing.T) {
 		t.Fatal("new manager should be idle")
 	}
 
-	if err := m.Start("job-1"); err != nil {
+	if err := m.Start("job-1", "/media/clip.mp4"); err != nil {
 		t.Fatalf("start: %v", err)
 	}
 	if !m.IsRunning() {
@@ -39,7 +40,7 @@ func TestManagerLifecycle(t *testing.T) {
 // TestManagerRejectsInvalidTransition checks state machine constraints.
 func TestManagerRejectsInvalidTransition(t *testing.T) {
 	m := NewManager()
-	if err := m.Start("job-1"); err != nil {
+	if err := m.Start("job-1", "/media/clip.mp4"); err != nil {
 		t.Fatalf("start: %v", err)
 	}
 
@@ -51,7 +52,7 @@ func TestManagerRejectsInvalidTransition(t *testing.T) {
 // TestManagerCancel verifies cancel behavior and repeated cancel handling.
 func TestManagerCancel(t *testing.T) {
 	m := NewManager()
-	if err := m.Start("job-1"); err != nil {
+	if err := m.Start("job-1", "/media/clip.mp4"); err != nil {
 		t.Fatalf("start: %v", err)
 	}
 
@@ -66,3 +67,82 @@ func TestManagerCancel(t *testing.T) {
 		t.Fatalf("second cancel error = %v, want %v", err, ErrNoRunningJob)
 	}
 }
+
+// TestManagerTracksProgressTimingAndETA verifies progress, ETA, and finish time.
+func TestManagerTracksProgressTimingAndETA(t *testing.T) {
+	m := NewManager()
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := start
+	m.now = func() time.Time { return now }
+
+	if err := m.Start("job-1", "/media/clip.mp4"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := m.Transition(domain.JobStatusTranscribing); err != nil {
+		t.Fatalf("transition: %v", err)
+	}
+
+	now = start.Add(30 * time.Second)
+	m.SetProgress(0.25)
+	m.SetProgress(0.1)
+	current := m.Current()
+	if current.InputPath != "/media/clip.mp4" || current.Stage != "transcribing" || !current.StartedAt.Equal(start) {
+		t.Fatalf("job = %+v", current)
+	}
+	if current.Progress != 0.25 || current.ETASeconds != 90 {
+		t.Fatalf("progress = %v eta = %v, want 0.25 and 90", current.Progress, current.ETASeconds)
+	}
+
+	now = start.Add(2 * time.Minute)
+	for _, status := range []domain.JobStatus{domain.JobStatusExporting, domain.JobStatusDone} {
+		if err := m.Transition(status); err != nil {
+			t.Fatalf("transition to %s: %v", status, err)
+		}
+	}
+	current = m.Current()
+	if current.Progress != 1 || current.ETASeconds != 0 || current.FinishedAt == nil || !current.FinishedAt.Equal(now) {
+		t.Fatalf("finished job = %+v", current)
+	}
+}
+
+// TestManagerBlendsEstimateIntoETA counts the speed-based estimate down
+// before progress arrives and weighs observed progress in as it grows.
+func TestManagerBlendsEstimateIntoETA(t *testing.T) {
+	m := NewManager()
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := start
+	m.now = func() time.Time { return now }
+	if err := m.Start("job-1", "/media/clip.mp4"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	now = start.Add(10 * time.Second)
+	m.SetEstimate(100)
+	if eta := m.Current().ETASeconds; eta != 90 {
+		t.Fatalf("eta before progress = %v, want 90", eta)
+	}
+
+	// Half done after 40s: the estimate says 60s left, progress says 40s.
+	now = start.Add(40 * time.Second)
+	m.SetProgress(0.5)
+	if eta := m.Current().ETASeconds; eta != 50 {
+		t.Fatalf("blended eta = %v, want 50", eta)
+	}
+
+	if err := m.Start("job-2", "/media/other.mp4"); err == nil {
+		t.Fatal("second start should fail while running")
+	}
+	for _, status := range []domain.JobStatus{domain.JobStatusTranscribing, domain.JobStatusExporting, domain.JobStatusDone} {
+		if err := m.Transition(status); err != nil {
+			t.Fatalf("transition to %s: %v", status, err)
+		}
+	}
+	if err := m.Start("job-2", "/media/other.mp4"); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	now = now.Add(10 * time.Second)
+	m.SetProgress(0.01)
+	if eta := m.Current().ETASeconds; eta != 0 {
+		t.Fatalf("eta without estimate or enough progress = %v, want 0", eta)
+	}
+}
@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"errors"
+	"sync"
+
+	"media-transcriber/internal/domain"
+)
+
+// ErrQueuedJobNotFound is returned when an ID does not name a queued job.
+var ErrQueuedJobNotFound = errors.New("queued job not found")
+
+// Queue holds files waiting for the single job slot, in run order.
+type Queue struct {
+	mu    sync.Mutex
+	items []domain.QueuedJob
+}
+
+// NewQueue creates an empty queue.
+func NewQueue() *Queue {
+	return &Queue{}
+}
+
+// Push appends jobs to the end of the queue. Priority jobs are inserted after
+// the priority jobs already waiting instead, ahead of every normal job.
+func (q *Queue) Push(items ...domain.QueuedJob) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, item := range items {
+		if item.Priority {
+			q.insertPriority(item)
+			continue
+		}
+		q.items = append(q.items, item)
+	}
+}
+
+// insertPriority places item right behind the leading run of priority jobs.
+func (q *Queue) insertPriority(item domain.QueuedJob) {
+	at := 0
+	for at < len(q.items) && q.items[at].Priority {
+		at++
+	}
+	q.items = append(q.items, domain.QueuedJob{})
+	copy(q.items[at+1:], q.items[at:])
+	q.items[at] = item
+}
+
+// Pop removes and returns the next job.
+func (q *Queue) Pop() (domain.QueuedJob, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return domain.QueuedJob{}, false
+	}
+	next := q.items[0]
+	q.items = q.items[1:]
+	return next, true
+}
+
+// List returns a snapshot of the queued jobs in run order.
+func (q *Queue) List() []domain.QueuedJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]domain.QueuedJob(nil), q.items...)
+}
+
+// Remove drops the job with id from the queue.
+func (q *Queue) Remove(id string) (domain.QueuedJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	at := q.indexOf(id)
+	if at < 0 {
+		return domain.QueuedJob{}, ErrQueuedJobNotFound
+	}
+	removed := q.items[at]
+	q.items = append(q.items[:at], q.items[at+1:]...)
+	return removed, nil
+}
+
+// Reorder moves the listed jobs to the front in the given order; jobs not
+// listed keep their relative order behind them. Unknown or repeated IDs fail
+// without changing the queue, so a stale client view cannot drop jobs.
+func (q *Queue) Reorder(ids []string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	listed := make(map[string]bool, len(ids))
+	front := make([]domain.QueuedJob, 0, len(q.items))
+	for _, id := range ids {
+		at := q.indexOf(id)
+		if at < 0 || listed[id] {
+			return ErrQueuedJobNotFound
+		}
+		listed[id] = true
+		front = append(front, q.items[at])
+	}
+	for _, item := range q.items {
+		if !listed[item.ID] {
+			front = append(front, item)
+		}
+	}
+	q.items = front
+	return nil
+}
+
+// SetPriority flags or unflags a queued job and moves it to the boundary
+// between priority and normal jobs: last of the urgent ones, or first of the rest.
+func (q *Queue) SetPriority(id string, priority bool) (domain.QueuedJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	at := q.indexOf(id)
+	if at < 0 {
+		return domain.QueuedJob{}, ErrQueuedJobNotFound
+	}
+	item := q.items[at]
+	if item.Priority == priority {
+		return item, nil
+	}
+	q.items = append(q.items[:at], q.items[at+1:]...)
+	item.Priority = priority
+	q.insertPriority(item)
+	return item, nil
+}
+
+// indexOf returns the position of id, or -1.
+func (q *Queue) indexOf(id string) int {
+	for i, item := range q.items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
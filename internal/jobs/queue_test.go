@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestQueuePushPopOrder verifies FIFO order and snapshots.
+func TestQueuePushPopOrder(t *testing.T) {
+	q := NewQueue()
+	q.Push(domain.QueuedJob{ID: "a"}, domain.QueuedJob{ID: "b"})
+	q.Push(domain.QueuedJob{ID: "c"})
+
+	snapshot := q.List()
+	snapshot[0].ID = "mutated"
+
+	for _, want := range []string{"a", "b", "c"} {
+		got, ok := q.Pop()
+		if !ok || got.ID != want {
+			t.Fatalf("Pop() = %+v, %v; want %s", got, ok, want)
+		}
+	}
+	if _, ok := q.Pop(); ok {
+		t.Fatal("expected empty queue")
+	}
+}
+
+// TestQueuePriorityJumpsAhead verifies priority jobs run before normal ones.
+func TestQueuePriorityJumpsAhead(t *testing.T) {
+	q := NewQueue()
+	q.Push(domain.QueuedJob{ID: "a"}, domain.QueuedJob{ID: "b"})
+	q.Push(domain.QueuedJob{ID: "urgent1", Priority: true})
+	q.Push(domain.QueuedJob{ID: "urgent2", Priority: true})
+	assertQueueIDs(t, q, "urgent1", "urgent2", "a", "b")
+
+	if _, err := q.SetPriority("b", true); err != nil {
+		t.Fatalf("set priority: %v", err)
+	}
+	assertQueueIDs(t, q, "urgent1", "urgent2", "b", "a")
+
+	if _, err := q.SetPriority("urgent1", false); err != nil {
+		t.Fatalf("clear priority: %v", err)
+	}
+	assertQueueIDs(t, q, "urgent2", "b", "urgent1", "a")
+
+	if _, err := q.SetPriority("missing", true); !errors.Is(err, ErrQueuedJobNotFound) {
+		t.Fatalf("err = %v, want ErrQueuedJobNotFound", err)
+	}
+}
+
+// TestQueueReorderAndRemove covers reordering, removal, and stale IDs.
+func TestQueueReorderAndRemove(t *testing.T) {
+	q := NewQueue()
+	q.Push(domain.QueuedJob{ID: "a"}, domain.QueuedJob{ID: "b"}, domain.QueuedJob{ID: "c"}, domain.QueuedJob{ID: "d"})
+
+	if err := q.Reorder([]string{"c", "a"}); err != nil {
+		t.Fatalf("reorder: %v", err)
+	}
+	assertQueueIDs(t, q, "c", "a", "b", "d")
+
+	for _, ids := range [][]string{{"c", "missing"}, {"a", "a"}} {
+		if err := q.Reorder(ids); !errors.Is(err, ErrQueuedJobNotFound) {
+			t.Fatalf("Reorder(%v) err = %v, want ErrQueuedJobNotFound", ids, err)
+		}
+	}
+	assertQueueIDs(t, q, "c", "a", "b", "d")
+
+	if removed, err := q.Remove("b"); err != nil || removed.ID != "b" {
+		t.Fatalf("Remove() = %+v, %v", removed, err)
+	}
+	if _, err := q.Remove("b"); !errors.Is(err, ErrQueuedJobNotFound) {
+		t.Fatalf("err = %v, want ErrQueuedJobNotFound", err)
+	}
+	assertQueueIDs(t, q, "c", "a", "d")
+}
+
+// assertQueueIDs compares the queue order with want.
+func assertQueueIDs(t *testing.T, q *Queue, want ...string) {
+	t.Helper()
+	items := q.List()
+	got := make([]string, len(items))
+	for i, item := range items {
+		got[i] = item.ID
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("queue = %v, want %v", got, want)
+	}
+}
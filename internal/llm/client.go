@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultEndpoint is the OpenAI API base URL used when none is configured.
+const DefaultEndpoint = "https://api.openai.com/v1"
+
+const requestTimeout = 2 * time.Minute
+
+// Client calls an OpenAI-compatible /chat/completions endpoint (OpenAI,
+// Ollama, llama.cpp server, LM Studio).
+type Client struct {
+	endpoint string
+	apiKey   string
+	model    string
+	http     *http.Client
+}
+
+// NewClient builds a client; an empty endpoint means the OpenAI API.
+func NewClient(endpoint, apiKey, model string) (*Client, error) {
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return nil, fmt.Errorf("LLM model name is required")
+	}
+	return &Client{endpoint: endpoint, apiKey: apiKey, model: model, http: &http.Client{Timeout: requestTimeout}}, nil
+}
+
+// Complete sends one system and one user message and returns the reply text.
+func (c *Client) Complete(ctx context.Context, system, user string) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":       c.model,
+		"temperature": 0,
+		"messages": []map[string]string{
+			{"role": "system", "content": system},
+			{"role": "user", "content": user},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("LLM: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return "", fmt.Errorf("LLM: %w", err)
+	}
+
+	var out struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	decodeErr := json.Unmarshal(data, &out)
+	if out.Error.Message != "" {
+		return "", fmt.Errorf("LLM: %s", out.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("LLM: unexpected status %s", resp.Status)
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("LLM: decode reply: %w", decodeErr)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("LLM returned no choices")
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+// ExtractJSON returns the outermost JSON value delimited by open and close in
+// a reply, tolerating prose or a markdown code fence around it.
+func ExtractJSON(content string, open, close byte) (string, error) {
+	start := strings.IndexByte(content, open)
+	end := strings.LastIndexByte(content, close)
+	if start < 0 || end < start {
+		return "", fmt.Errorf("LLM reply has no JSON %c...%c value", open, close)
+	}
+	return content[start : end+1], nil
+}
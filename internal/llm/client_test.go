@@ -0,0 +1,73 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestCompleteReturnsReplyAndErrors covers a reply, an API error, and a bad status.
+func TestCompleteReturnsReplyAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch body.Model {
+		case "ok":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"choices": []map[string]any{{"message": map[string]string{"content": "hi"}}},
+			})
+		case "quota":
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]string{"message": "quota exceeded"}})
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		model   string
+		want    string
+		wantErr string
+	}{
+		{model: "ok", want: "hi"},
+		{model: "quota", wantErr: "quota exceeded"},
+		{model: "down", wantErr: "502"},
+	}
+	for _, tt := range tests {
+		client, err := NewClient(server.URL+"/", "", tt.model)
+		if err != nil {
+			t.Fatalf("new client: %v", err)
+		}
+		got, err := client.Complete(context.Background(), "system", "user")
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("%s: err = %v, want %q", tt.model, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Fatalf("%s: Complete() = %q, %v", tt.model, got, err)
+		}
+	}
+
+	if _, err := NewClient("", "", " "); err == nil {
+		t.Fatal("expected error without a model")
+	}
+}
+
+// TestExtractJSON strips prose and code fences around the JSON value.
+func TestExtractJSON(t *testing.T) {
+	got, err := ExtractJSON("Sure:\n```json\n{\"a\": [1]}\n```", '{', '}')
+	if err != nil || got != `{"a": [1]}` {
+		t.Fatalf("ExtractJSON() = %q, %v", got, err)
+	}
+	if _, err := ExtractJSON("no json here", '[', ']'); err == nil {
+		t.Fatal("expected error")
+	}
+}
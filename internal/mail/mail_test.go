@@ -0,0 +1,180 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBuildWithAttachments round-trips the body and attachments through a MIME parser.
+func TestBuildWithAttachments(t *testing.T) {
+	data, err := Build(Message{
+		From:        "Transcriber <bot@example.com>",
+		To:          []string{"client@example.com"},
+		Subject:     "Транскрипт: ep12.mp3",
+		Body:        "Attached: ep12.srt",
+		Attachments: []Attachment{{Name: "ep12.srt", Data: []byte(strings.Repeat("1\n00:00:00,000 --> 00:00:01,000\nПривет\n\n", 10))}},
+	}, time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	msg, err := netmail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != "Транскрипт: ep12.mp3" {
+		t.Fatalf("subject = %q", subject)
+	}
+	if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+		t.Fatalf("message id = %q", msg.Header.Get("Message-ID"))
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("content type: %v", err)
+	}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("body part: %v", err)
+	}
+	if text, _ := io.ReadAll(body); string(text) != "Attached: ep12.srt" {
+		t.Fatalf("body = %q", text)
+	}
+	attachment, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("attachment part: %v", err)
+	}
+	if attachment.FileName() != "ep12.srt" {
+		t.Fatalf("filename = %q", attachment.FileName())
+	}
+	if content, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, attachment)); !strings.Contains(string(content), "Привет") {
+		t.Fatalf("attachment = %q", content)
+	}
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if len(line) > 998 {
+			t.Fatalf("line of %d bytes exceeds the SMTP limit", len(line))
+		}
+	}
+}
+
+// TestBuildInlineBody encodes a plain message as quoted-printable UTF-8.
+func TestBuildInlineBody(t *testing.T) {
+	data, err := Build(Message{From: "bot@example.com", To: []string{"a@example.com"}, Subject: "s", Body: "Привет,\nмир"}, time.Now())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	msg, err := netmail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	text, _ := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	if string(text) != "Привет,\r\nмир" {
+		t.Fatalf("body = %q", text)
+	}
+}
+
+// fakeSMTP accepts one plain-text SMTP session and returns the envelope and data.
+func fakeSMTP(t *testing.T) (port int, result chan []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	result = make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		var envelope []string
+		reply("220 fake ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch verb := strings.ToUpper(strings.Fields(line)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 fake")
+			case "MAIL", "RCPT":
+				envelope = append(envelope, line)
+				reply("250 ok")
+			case "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				envelope = append(envelope, data.String())
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				result <- envelope
+				return
+			default:
+				reply("502 unsupported")
+			}
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port, result
+}
+
+// TestSendDeliversEnvelope speaks SMTP to a fake server.
+func TestSendDeliversEnvelope(t *testing.T) {
+	port, result := fakeSMTP(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := Send(ctx, Config{Host: "127.0.0.1", Port: port}, Message{
+		From: "Transcriber <bot@example.com>", To: []string{"a@example.com", "B <b@example.com>"}, Subject: "Hi", Body: "hello",
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	envelope := <-result
+	if len(envelope) != 4 || envelope[0] != "MAIL FROM:<bot@example.com>" || envelope[2] != "RCPT TO:<b@example.com>" {
+		t.Fatalf("envelope = %q", envelope)
+	}
+	if !strings.Contains(envelope[3], "Subject: Hi\r\n") {
+		t.Fatalf("data = %q", envelope[3])
+	}
+}
+
+// TestSendValidatesInput fails before dialing on bad configuration.
+func TestSendValidatesInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		msg    Message
+	}{
+		{name: "host", msg: Message{From: "a@example.com", To: []string{"b@example.com"}}},
+		{name: "recipients", config: Config{Host: "localhost", Port: 25}, msg: Message{From: "a@example.com"}},
+		{name: "sender", config: Config{Host: "localhost", Port: 25}, msg: Message{From: "nobody", To: []string{"b@example.com"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Send(context.Background(), tt.config, tt.msg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
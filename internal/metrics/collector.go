@@ -0,0 +1,162 @@
+// Package metrics aggregates job events into Prometheus counters for server mode.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// namespace prefixes every exported metric name.
+const namespace = "media_transcriber"
+
+// Gauges reads point-in-time values at scrape time.
+type Gauges struct {
+	QueueDepth func() int
+	JobRunning func() bool
+}
+
+// Collector derives job, stage, and download metrics from the event stream.
+// Feed it every published event with Observe and serve it with Handler.
+type Collector struct {
+	gauges Gauges
+
+	mu            sync.Mutex
+	jobsFinished  map[domain.JobStatus]int64
+	stageSeconds  map[string]float64
+	stageCount    map[string]int64
+	running       map[string]stageStart
+	downloadBytes int64
+	downloadSeen  map[string]int64
+}
+
+// stageStart remembers which stage a job entered and when.
+type stageStart struct {
+	stage string
+	at    time.Time
+}
+
+// NewCollector returns an empty collector reading gauges at scrape time.
+func NewCollector(gauges Gauges) *Collector {
+	return &Collector{
+		gauges:       gauges,
+		jobsFinished: map[domain.JobStatus]int64{},
+		stageSeconds: map[string]float64{},
+		stageCount:   map[string]int64{},
+		running:      map[string]stageStart{},
+		downloadSeen: map[string]int64{},
+	}
+}
+
+// Observe updates counters from one published event.
+func (c *Collector) Observe(event jobs.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch event.Type {
+	case jobs.EventTypeStatus:
+		c.observeStatus(event)
+	case jobs.EventTypeDownload:
+		// Download events report cumulative progress; count only the growth.
+		if seen := c.downloadSeen[event.DownloadID]; event.BytesDone > seen {
+			c.downloadBytes += event.BytesDone - seen
+			c.downloadSeen[event.DownloadID] = event.BytesDone
+		}
+	}
+}
+
+// observeStatus closes the job's previous stage and opens the next one.
+func (c *Collector) observeStatus(event jobs.Event) {
+	if prev, ok := c.running[event.JobID]; ok {
+		if prev.stage == string(event.Status) {
+			return
+		}
+		c.stageSeconds[prev.stage] += event.Timestamp.Sub(prev.at).Seconds()
+		c.stageCount[prev.stage]++
+		delete(c.running, event.JobID)
+	}
+
+	switch event.Status {
+	case domain.JobStatusPreprocessing, domain.JobStatusTranscribing, domain.JobStatusExporting:
+		c.running[event.JobID] = stageStart{stage: string(event.Status), at: event.Timestamp}
+	case domain.JobStatusDone, domain.JobStatusFailed, domain.JobStatusCancelled:
+		c.jobsFinished[event.Status]++
+	}
+}
+
+// Handler serves the metrics in the Prometheus text exposition format.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = c.Write(w)
+	})
+}
+
+// Write renders the current metrics in the Prometheus text exposition format.
+func (c *Collector) Write(w io.Writer) error {
+	queueDepth, running := 0, 0
+	if c.gauges.QueueDepth != nil {
+		queueDepth = c.gauges.QueueDepth()
+	}
+	if c.gauges.JobRunning != nil && c.gauges.JobRunning() {
+		running = 1
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := &printer{w: w}
+	p.header("jobs_finished_total", "counter", "Jobs that reached a final status.")
+	for _, status := range []domain.JobStatus{domain.JobStatusDone, domain.JobStatusFailed, domain.JobStatusCancelled} {
+		p.sample("jobs_finished_total", fmt.Sprintf(`{status=%q}`, status), float64(c.jobsFinished[status]))
+	}
+	p.header("job_running", "gauge", "Whether a transcription job is running.")
+	p.sample("job_running", "", float64(running))
+	p.header("queue_depth", "gauge", "Jobs waiting in the queue.")
+	p.sample("queue_depth", "", float64(queueDepth))
+
+	stages := make([]string, 0, len(c.stageCount))
+	for stage := range c.stageCount {
+		stages = append(stages, stage)
+	}
+	sort.Strings(stages)
+	p.header("stage_duration_seconds", "summary", "Wall-clock time spent in each pipeline stage.")
+	for _, stage := range stages {
+		labels := fmt.Sprintf(`{stage=%q}`, stage)
+		p.sample("stage_duration_seconds_sum", labels, c.stageSeconds[stage])
+		p.sample("stage_duration_seconds_count", labels, float64(c.stageCount[stage]))
+	}
+
+	p.header("download_bytes_total", "counter", "Bytes received by model and tool downloads.")
+	p.sample("download_bytes_total", "", float64(c.downloadBytes))
+	return p.err
+}
+
+// printer writes exposition lines and keeps the first write error.
+type printer struct {
+	w   io.Writer
+	err error
+}
+
+// header writes the HELP and TYPE lines of a metric family.
+func (p *printer) header(name, kind, help string) {
+	p.printf("# HELP %s_%s %s\n# TYPE %s_%s %s\n", namespace, name, help, namespace, name, kind)
+}
+
+// sample writes one metric value with optional {labels}.
+func (p *printer) sample(name, labels string, value float64) {
+	p.printf("%s_%s%s %g\n", namespace, name, labels, value)
+}
+
+// printf writes to the underlying writer until the first error.
+func (p *printer) printf(format string, args ...any) {
+	if p.err == nil {
+		_, p.err = fmt.Fprintf(p.w, format, args...)
+	}
+}
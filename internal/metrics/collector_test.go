@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// TestCollectorAggregatesEvents covers job counts, stage durations, downloads, and gauges.
+func TestCollectorAggregatesEvents(t *testing.T) {
+	collector := NewCollector(Gauges{
+		QueueDepth: func() int { return 3 },
+		JobRunning: func() bool { return true },
+	})
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	status := func(jobID string, status domain.JobStatus, offset time.Duration) jobs.Event {
+		return jobs.Event{JobID: jobID, Type: jobs.EventTypeStatus, Status: status, Timestamp: start.Add(offset)}
+	}
+	for _, event := range []jobs.Event{
+		status("a", domain.JobStatusPreprocessing, 0),
+		status("a", domain.JobStatusTranscribing, 2*time.Second),
+		status("a", domain.JobStatusTranscribing, 3*time.Second),
+		status("a", domain.JobStatusExporting, 12*time.Second),
+		status("a", domain.JobStatusDone, 13*time.Second),
+		status("b", domain.JobStatusPreprocessing, 20*time.Second),
+		status("b", domain.JobStatusFailed, 24*time.Second),
+		{Type: jobs.EventTypeDownload, DownloadID: "d1", BytesDone: 100},
+		{Type: jobs.EventTypeDownload, DownloadID: "d1", BytesDone: 250},
+		{Type: jobs.EventTypeDownload, DownloadID: "d1", BytesDone: 250},
+		{Type: jobs.EventTypeDownload, DownloadID: "d2", BytesDone: 50},
+	} {
+		collector.Observe(event)
+	}
+
+	recorder := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`media_transcriber_jobs_finished_total{status="done"} 1`,
+		`media_transcriber_jobs_finished_total{status="failed"} 1`,
+		`media_transcriber_jobs_finished_total{status="cancelled"} 0`,
+		"media_transcriber_job_running 1",
+		"media_transcriber_queue_depth 3",
+		`media_transcriber_stage_duration_seconds_sum{stage="preprocessing"} 6`,
+		`media_transcriber_stage_duration_seconds_count{stage="preprocessing"} 2`,
+		`media_transcriber_stage_duration_seconds_sum{stage="transcribing"} 10`,
+		`media_transcriber_stage_duration_seconds_count{stage="exporting"} 1`,
+		"media_transcriber_download_bytes_total 300",
+		"# TYPE media_transcriber_stage_duration_seconds summary",
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Fatalf("metrics missing %q:\n%s", want, body)
+		}
+	}
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Fatalf("content type = %q", got)
+	}
+}
@@ -0,0 +1,20 @@
+package modelfile
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// CoreMLEncoderPath returns the CoreML encoder directory whisper.cpp loads for a model.
+// Like whisper.cpp, it drops the extension and a "-qX_Y" quantization suffix, then
+// appends "-encoder.mlmodelc", so quantized models share their base model's encoder.
+func CoreMLEncoderPath(modelPath string) string {
+	base := strings.TrimSuffix(modelPath, filepath.Ext(modelPath))
+	if pos := strings.LastIndex(base, "-"); pos >= 0 {
+		suffix := base[pos:]
+		if len(suffix) == 5 && suffix[1] == 'q' && suffix[3] == '_' {
+			base = base[:pos]
+		}
+	}
+	return base + "-encoder.mlmodelc"
+}
@@ -0,0 +1,147 @@
+package modelfile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GGUF metadata value types.
+const (
+	ggufTypeUint8   uint32 = 0
+	ggufTypeInt8    uint32 = 1
+	ggufTypeUint16  uint32 = 2
+	ggufTypeInt16   uint32 = 3
+	ggufTypeUint32  uint32 = 4
+	ggufTypeInt32   uint32 = 5
+	ggufTypeFloat32 uint32 = 6
+	ggufTypeBool    uint32 = 7
+	ggufTypeString  uint32 = 8
+	ggufTypeArray   uint32 = 9
+	ggufTypeUint64  uint32 = 10
+	ggufTypeInt64   uint32 = 11
+	ggufTypeFloat64 uint32 = 12
+)
+
+// maxGGUFString guards against corrupt length prefixes.
+const maxGGUFString = 1 << 20
+
+func readGGUF(r io.Reader) (Info, error) {
+	var header struct {
+		Version     uint32
+		TensorCount uint64
+		KVCount     uint64
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return Info{}, fmt.Errorf("read gguf header: %w", truncated(err))
+	}
+	if header.Version < 2 {
+		return Info{}, fmt.Errorf("unsupported gguf version %d", header.Version)
+	}
+
+	info := Info{Format: FormatGGUF, Version: header.Version}
+	for i := uint64(0); i < header.KVCount; i++ {
+		key, err := readGGUFString(r)
+		if err != nil {
+			return Info{}, fmt.Errorf("read gguf key: %w", err)
+		}
+		var valueType uint32
+		if err := binary.Read(r, binary.LittleEndian, &valueType); err != nil {
+			return Info{}, fmt.Errorf("read gguf value type: %w", truncated(err))
+		}
+		value, err := readGGUFValue(r, valueType)
+		if err != nil {
+			return Info{}, fmt.Errorf("read gguf value %s: %w", key, err)
+		}
+		applyGGUFKey(&info, key, value)
+	}
+
+	if info.ModelType == "" {
+		info.ModelType = "unknown"
+	}
+	return info, nil
+}
+
+// applyGGUFKey copies known metadata keys into info.
+func applyGGUFKey(info *Info, key string, value any) {
+	switch {
+	case key == "general.file_type":
+		if n, ok := toInt(value); ok {
+			info.Quantization = quantizationName(n)
+		}
+	case key == "general.size_label":
+		if s, ok := value.(string); ok && s != "" {
+			info.ModelType = s
+		}
+	case strings.HasSuffix(key, ".encoder.block_count") || strings.HasSuffix(key, ".encoder.layer_count"):
+		if n, ok := toInt(value); ok {
+			info.ModelType = modelTypeForLayers(n)
+		}
+	case strings.HasSuffix(key, ".vocab_size"):
+		if n, ok := toInt(value); ok {
+			info.Multilingual = n >= multilingualVocab
+		}
+	}
+}
+
+func readGGUFString(r io.Reader) (string, error) {
+	var length uint64
+	if err := binary.Read(r, binary.LittleEndian, &length); err != nil {
+		return "", truncated(err)
+	}
+	if length > maxGGUFString {
+		return "", fmt.Errorf("string length %d exceeds limit", length)
+	}
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", truncated(err)
+	}
+	return string(buf), nil
+}
+
+// readGGUFValue reads one value; arrays are consumed and returned as nil.
+func readGGUFValue(r io.Reader, valueType uint32) (any, error) {
+	switch valueType {
+	case ggufTypeUint8, ggufTypeInt8, ggufTypeBool:
+		var v uint8
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return int(v), truncated(err)
+	case ggufTypeUint16, ggufTypeInt16:
+		var v uint16
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return int(v), truncated(err)
+	case ggufTypeUint32, ggufTypeInt32, ggufTypeFloat32:
+		var v uint32
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return int(v), truncated(err)
+	case ggufTypeUint64, ggufTypeInt64, ggufTypeFloat64:
+		var v uint64
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return int(v), truncated(err)
+	case ggufTypeString:
+		return readGGUFString(r)
+	case ggufTypeArray:
+		var elemType uint32
+		var count uint64
+		if err := binary.Read(r, binary.LittleEndian, &elemType); err != nil {
+			return nil, truncated(err)
+		}
+		if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+			return nil, truncated(err)
+		}
+		for i := uint64(0); i < count; i++ {
+			if _, err := readGGUFValue(r, elemType); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown gguf value type %d", valueType)
+	}
+}
+
+func toInt(value any) (int, bool) {
+	n, ok := value.(int)
+	return n, ok
+}
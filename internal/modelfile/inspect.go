@@ -0,0 +1,161 @@
+// Package modelfile reads whisper.cpp model headers (legacy GGML and GGUF).
+package modelfile
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// ggmlMagic is the little-endian "ggml" magic used by whisper.cpp .bin models.
+	ggmlMagic uint32 = 0x67676d6c
+	// ggufMagic is the little-endian "GGUF" magic.
+	ggufMagic uint32 = 0x46554747
+
+	// ftypeVersionFactor splits quantization version from ftype in GGML headers.
+	ftypeVersionFactor = 1000
+	// multilingualVocab is the minimum vocabulary size of multilingual whisper models.
+	multilingualVocab = 51865
+)
+
+// Format identifies the on-disk model container.
+type Format string
+
+const (
+	FormatGGML Format = "ggml"
+	FormatGGUF Format = "gguf"
+)
+
+// ErrUnknownFormat is returned when the file magic is neither GGML nor GGUF.
+var ErrUnknownFormat = errors.New("unknown model file format")
+
+// Info is parsed model metadata from the file header.
+type Info struct {
+	Format       Format
+	Version      uint32
+	ModelType    string
+	Quantization string
+	Multilingual bool
+}
+
+// Inspect opens a model file and parses its header.
+func Inspect(path string) (Info, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Info{}, err
+	}
+	defer file.Close()
+
+	return Read(bufio.NewReader(file))
+}
+
+// Read parses a model header from r.
+func Read(r io.Reader) (Info, error) {
+	var magic uint32
+	if err := binary.Read(r, binary.LittleEndian, &magic); err != nil {
+		return Info{}, fmt.Errorf("read magic: %w", truncated(err))
+	}
+
+	switch magic {
+	case ggmlMagic:
+		return readGGML(r)
+	case ggufMagic:
+		return readGGUF(r)
+	default:
+		return Info{}, fmt.Errorf("%w (magic 0x%08x)", ErrUnknownFormat, magic)
+	}
+}
+
+// ggmlHeader mirrors whisper.cpp's hparams block after the magic.
+type ggmlHeader struct {
+	NVocab      int32
+	NAudioCtx   int32
+	NAudioState int32
+	NAudioHead  int32
+	NAudioLayer int32
+	NTextCtx    int32
+	NTextState  int32
+	NTextHead   int32
+	NTextLayer  int32
+	NMels       int32
+	FType       int32
+}
+
+func readGGML(r io.Reader) (Info, error) {
+	var header ggmlHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return Info{}, fmt.Errorf("read ggml header: %w", truncated(err))
+	}
+	if header.NVocab <= 0 || header.NAudioLayer <= 0 || header.NMels <= 0 {
+		return Info{}, fmt.Errorf("invalid ggml header values")
+	}
+
+	return Info{
+		Format:       FormatGGML,
+		ModelType:    modelTypeForLayers(int(header.NAudioLayer)),
+		Quantization: quantizationName(int(header.FType % ftypeVersionFactor)),
+		Multilingual: header.NVocab >= multilingualVocab,
+	}, nil
+}
+
+// modelTypeForLayers maps encoder depth to the whisper model family.
+func modelTypeForLayers(layers int) string {
+	switch layers {
+	case 4:
+		return "tiny"
+	case 6:
+		return "base"
+	case 12:
+		return "small"
+	case 24:
+		return "medium"
+	case 32:
+		return "large"
+	default:
+		return fmt.Sprintf("unknown (%d layers)", layers)
+	}
+}
+
+// quantizationName maps ggml ftype values to short names.
+func quantizationName(ftype int) string {
+	switch ftype {
+	case 0:
+		return "f32"
+	case 1:
+		return "f16"
+	case 2:
+		return "q4_0"
+	case 3:
+		return "q4_1"
+	case 7:
+		return "q8_0"
+	case 8:
+		return "q5_0"
+	case 9:
+		return "q5_1"
+	case 10:
+		return "q2_k"
+	case 11:
+		return "q3_k"
+	case 12:
+		return "q4_k"
+	case 13:
+		return "q5_k"
+	case 14:
+		return "q6_k"
+	default:
+		return fmt.Sprintf("ftype %d", ftype)
+	}
+}
+
+// truncated maps short reads to a clearer error.
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("file is truncated: %w", io.ErrUnexpectedEOF)
+	}
+	return err
+}
@@ -0,0 +1,101 @@
+package modelfile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+// TestReadGGMLHeader parses a whisper.cpp legacy header.
+func TestReadGGMLHeader(t *testing.T) {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, ggmlMagic)
+	_ = binary.Write(&buf, binary.LittleEndian, ggmlHeader{
+		NVocab:      51865,
+		NAudioCtx:   1500,
+		NAudioState: 512,
+		NAudioHead:  8,
+		NAudioLayer: 6,
+		NTextCtx:    448,
+		NTextState:  512,
+		NTextHead:   8,
+		NTextLayer:  6,
+		NMels:       80,
+		FType:       1008,
+	})
+
+	info, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if info.Format != FormatGGML || info.ModelType != "base" || info.Quantization != "q5_0" || !info.Multilingual {
+		t.Fatalf("info = %+v", info)
+	}
+}
+
+// TestReadGGUFHeader parses GGUF metadata key/value pairs.
+func TestReadGGUFHeader(t *testing.T) {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, ggufMagic)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(3))
+	_ = binary.Write(&buf, binary.LittleEndian, uint64(0))
+	_ = binary.Write(&buf, binary.LittleEndian, uint64(3))
+	writeKV(&buf, "general.architecture", ggufTypeString, "whisper")
+	writeKV(&buf, "whisper.encoder.block_count", ggufTypeUint32, uint32(12))
+	writeKV(&buf, "general.file_type", ggufTypeUint32, uint32(1))
+
+	info, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if info.Format != FormatGGUF || info.Version != 3 || info.ModelType != "small" || info.Quantization != "f16" {
+		t.Fatalf("info = %+v", info)
+	}
+}
+
+// TestReadRejectsUnknownAndTruncatedFiles checks validation errors.
+func TestReadRejectsUnknownAndTruncatedFiles(t *testing.T) {
+	if _, err := Read(bytes.NewReader([]byte("<html>not a model"))); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("error = %v, want ErrUnknownFormat", err)
+	}
+
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, ggmlMagic)
+	_ = binary.Write(&buf, binary.LittleEndian, int32(51865))
+	if _, err := Read(&buf); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("error = %v, want truncated", err)
+	}
+}
+
+// writeKV appends one GGUF metadata pair.
+func writeKV(buf *bytes.Buffer, key string, valueType uint32, value any) {
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(key)))
+	buf.WriteString(key)
+	_ = binary.Write(buf, binary.LittleEndian, valueType)
+	if s, ok := value.(string); ok {
+		_ = binary.Write(buf, binary.LittleEndian, uint64(len(s)))
+		buf.WriteString(s)
+		return
+	}
+	_ = binary.Write(buf, binary.LittleEndian, value)
+}
+
+// TestCoreMLEncoderPath mirrors whisper.cpp encoder path derivation.
+func TestCoreMLEncoderPath(t *testing.T) {
+	tests := []struct {
+		model string
+		want  string
+	}{
+		{model: "/m/ggml-base.en.bin", want: "/m/ggml-base.en-encoder.mlmodelc"},
+		{model: "/m/ggml-large-v3-turbo-q5_0.bin", want: "/m/ggml-large-v3-turbo-encoder.mlmodelc"},
+		{model: "/m/ggml-medium-q8_0.gguf", want: "/m/ggml-medium-encoder.mlmodelc"},
+		{model: "/m/ggml-large-v3.bin", want: "/m/ggml-large-v3-encoder.mlmodelc"},
+	}
+	for _, tt := range tests {
+		if got := CoreMLEncoderPath(tt.model); got != tt.want {
+			t.Errorf("CoreMLEncoderPath(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
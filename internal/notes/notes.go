@@ -0,0 +1,64 @@
+// Package notes publishes finished transcripts to note-taking tools: Markdown
+// notes in an Obsidian vault and pages in Notion.
+package notes
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Note is a transcript with the metadata shown alongside it.
+type Note struct {
+	Title    string
+	Text     string
+	Tags     []string
+	Source   string
+	Language string
+	Model    string
+	Date     time.Time
+	// Project names the project note the transcript links back to; empty omits it.
+	Project string
+}
+
+// paragraphs splits text on blank lines, falling back to single lines for
+// transcripts without paragraph breaks.
+func paragraphs(text string) []string {
+	sep := "\n\n"
+	if !strings.Contains(text, sep) {
+		sep = "\n"
+	}
+	var out []string
+	for _, part := range strings.Split(text, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// tagSlug turns a free-form tag into one Obsidian accepts: no spaces, letters,
+// digits, '-', '_' and '/' only, and not purely numeric.
+func tagSlug(tag string) string {
+	var b strings.Builder
+	digitsOnly := true
+	for _, r := range strings.ToLower(strings.TrimSpace(tag)) {
+		switch {
+		case unicode.IsLetter(r):
+			digitsOnly = false
+			b.WriteRune(r)
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '-' || r == '_' || r == '/':
+			digitsOnly = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			digitsOnly = false
+			b.WriteRune('-')
+		}
+	}
+	if digitsOnly {
+		return ""
+	}
+	return b.String()
+}
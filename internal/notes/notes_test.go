@@ -0,0 +1,147 @@
+package notes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFormatObsidian renders front matter, slugged tags, backlinks, and paragraphs.
+func TestFormatObsidian(t *testing.T) {
+	got := FormatObsidian(Note{
+		Title:    "Episode 12",
+		Text:     "First paragraph.\n\nSecond paragraph.",
+		Tags:     []string{"Carbon Tax", "2024", "Alice"},
+		Source:   "/media/ep12.mp3",
+		Language: "en",
+		Date:     time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+		Project:  "Podcasts",
+	})
+	want := `---
+title: "Episode 12"
+date: 2026-10-16
+source: "/media/ep12.mp3"
+language: "en"
+tags:
+  - carbon-tax
+  - alice
+---
+
+# Episode 12
+
+Project: [[Podcasts]] · Date: [[2026-10-16]]
+
+First paragraph.
+
+Second paragraph.
+`
+	if got != want {
+		t.Fatalf("note =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestWriteObsidianSanitizesName keeps the note inside the folder and never
+// overwrites an earlier note of the same title.
+func TestWriteObsidianSanitizesName(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "Vault", "Transcripts")
+	path, err := WriteObsidian(folder, Note{Title: "a/b: c", Text: "hi"})
+	if err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if path != filepath.Join(folder, "a-b- c.md") {
+		t.Fatalf("path = %s", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	again, err := WriteObsidian(folder, Note{Title: "a/b: c", Text: "again"})
+	if err != nil || again != filepath.Join(folder, "a-b- c 2.md") {
+		t.Fatalf("second write = %s, %v; want a numbered note", again, err)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "hi") {
+		t.Fatalf("first note = %q, %v; want it kept", data, err)
+	}
+	if _, err := WriteObsidian("", Note{Title: "x"}); err == nil {
+		t.Fatal("expected error without folder")
+	}
+}
+
+// TestNotionCreatePageBatchesBlocks creates the page with the first 100 blocks
+// and appends the rest; long paragraphs are split at the rich text limit.
+func TestNotionCreatePageBatchesBlocks(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	blockCounts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer secret_x" || r.Header.Get("Notion-Version") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var body struct {
+			Children []notionBlock `json:"children"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, block := range body.Children {
+			if n := len([]rune(block.Paragraph.RichText[0].Text.Content)); n > notionTextLimit {
+				t.Errorf("rich text of %d characters", n)
+			}
+		}
+		key := r.Method + " " + r.URL.Path
+		requests = append(requests, key)
+		blockCounts[key] += len(body.Children)
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"id":"page-1","url":"https://notion.so/page-1"}`))
+		}
+	}))
+	defer server.Close()
+
+	lines := make([]string, 150)
+	for i := range lines {
+		lines[i] = "Line."
+	}
+	lines[0] = strings.Repeat("x", notionTextLimit+10)
+	client, err := NewNotionClient("secret_x")
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	client.baseURL = server.URL
+
+	url, err := client.CreatePage(context.Background(), "parent-1", Note{Title: "Talk", Text: strings.Join(lines, "\n"), Tags: []string{"a"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if url != "https://notion.so/page-1" {
+		t.Fatalf("url = %s", url)
+	}
+	// Tags line + 151 transcript blocks (the long line split in two) = 152.
+	if blockCounts["POST /pages"] != 100 || blockCounts["PATCH /blocks/page-1/children"] != 52 {
+		t.Fatalf("requests = %v, blocks = %v", requests, blockCounts)
+	}
+}
+
+// TestNotionReportsAPIError surfaces the Notion error message.
+func TestNotionReportsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"object":"error","message":"Could not find page with ID: p."}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewNotionClient("secret_x")
+	client.baseURL = server.URL
+	if _, err := client.CreatePage(context.Background(), "p", Note{Title: "t", Text: "x"}); err == nil || !strings.Contains(err.Error(), "Could not find page") {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := NewNotionClient(" "); err == nil {
+		t.Fatal("expected error without token")
+	}
+}
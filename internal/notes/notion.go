@@ -0,0 +1,177 @@
+package notes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// notionAPIURL is the public Notion API; tests point the client elsewhere.
+	notionAPIURL = "https://api.notion.com/v1"
+	// notionVersion pins the API version the request bodies follow.
+	notionVersion = "2022-06-28"
+	// notionTextLimit is the maximum length of one rich text object.
+	notionTextLimit = 2000
+	// notionBlockBatch is the maximum number of blocks per request.
+	notionBlockBatch = 100
+)
+
+// NotionClient creates pages through the Notion API with an integration token.
+type NotionClient struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewNotionClient returns a client for the public Notion API.
+func NewNotionClient(token string) (*NotionClient, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fmt.Errorf("notion token is not set")
+	}
+	return &NotionClient{baseURL: notionAPIURL, token: token, http: &http.Client{}}, nil
+}
+
+// notionBlock is a paragraph block.
+type notionBlock struct {
+	Object    string          `json:"object"`
+	Type      string          `json:"type"`
+	Paragraph notionParagraph `json:"paragraph"`
+}
+
+// notionParagraph holds the text runs of a paragraph block.
+type notionParagraph struct {
+	RichText []notionText `json:"rich_text"`
+}
+
+// notionText is a single plain-text run.
+type notionText struct {
+	Type string `json:"type"`
+	Text struct {
+		Content string `json:"content"`
+	} `json:"text"`
+}
+
+// CreatePage adds note as a child page of parentID (a page the integration is
+// shared with) and returns the page URL. Long transcripts are appended in batches.
+func (c *NotionClient) CreatePage(ctx context.Context, parentID string, note Note) (string, error) {
+	parentID = strings.TrimSpace(parentID)
+	if parentID == "" {
+		return "", fmt.Errorf("notion parent page is not set")
+	}
+	blocks := notionBlocks(note)
+	first := blocks[:min(len(blocks), notionBlockBatch)]
+	body := map[string]any{
+		"parent": map[string]string{"page_id": parentID},
+		"properties": map[string]any{
+			"title": map[string]any{"title": []notionText{richText(note.Title)}},
+		},
+		"children": first,
+	}
+	var page struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err := c.call(ctx, http.MethodPost, "/pages", body, &page); err != nil {
+		return "", err
+	}
+	for rest := blocks[len(first):]; len(rest) > 0; {
+		batch := rest[:min(len(rest), notionBlockBatch)]
+		rest = rest[len(batch):]
+		if err := c.call(ctx, http.MethodPatch, "/blocks/"+page.ID+"/children", map[string]any{"children": batch}, nil); err != nil {
+			return page.URL, fmt.Errorf("append to page %s: %w", page.ID, err)
+		}
+	}
+	return page.URL, nil
+}
+
+// notionBlocks renders metadata and transcript paragraphs, splitting text
+// longer than one rich text object allows.
+func notionBlocks(note Note) []notionBlock {
+	var lines []string
+	if len(note.Tags) > 0 {
+		lines = append(lines, "Tags: "+strings.Join(note.Tags, ", "))
+	}
+	if note.Project != "" {
+		lines = append(lines, "Project: "+note.Project)
+	}
+	if note.Source != "" {
+		lines = append(lines, "Source: "+note.Source)
+	}
+	lines = append(lines, paragraphs(note.Text)...)
+
+	var blocks []notionBlock
+	for _, line := range lines {
+		for _, chunk := range splitRunes(line, notionTextLimit) {
+			blocks = append(blocks, notionBlock{
+				Object:    "block",
+				Type:      "paragraph",
+				Paragraph: notionParagraph{RichText: []notionText{richText(chunk)}},
+			})
+		}
+	}
+	return blocks
+}
+
+// richText wraps content in a plain-text run.
+func richText(content string) notionText {
+	text := notionText{Type: "text"}
+	text.Text.Content = content
+	return text
+}
+
+// splitRunes cuts s into pieces of at most limit characters.
+func splitRunes(s string, limit int) []string {
+	var out []string
+	for utf8.RuneCountInString(s) > limit {
+		cut := 0
+		for i := 0; i < limit; i++ {
+			_, size := utf8.DecodeRuneInString(s[cut:])
+			cut += size
+		}
+		out = append(out, s[:cut])
+		s = s[cut:]
+	}
+	return append(out, s)
+}
+
+// call sends one JSON request and decodes the response into out when non-nil.
+func (c *NotionClient) call(ctx context.Context, method, path string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Notion-Version", notionVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(raw, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("notion %s %s: %s: %s", method, path, resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("notion %s %s: %s", method, path, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,107 @@
+package notes
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// WriteObsidian writes note as <folder>/<title>.md with YAML front matter and
+// backlinks to its project and daily note, returning the file path. An
+// existing note is kept: the new one gets a numeric suffix, "<title> 2.md".
+func WriteObsidian(folder string, note Note) (string, error) {
+	if strings.TrimSpace(folder) == "" {
+		return "", fmt.Errorf("obsidian folder is not set")
+	}
+	if err := os.MkdirAll(folder, 0o755); err != nil {
+		return "", err
+	}
+	name := safeFileName(note.Title)
+	for n := 1; ; n++ {
+		path := filepath.Join(folder, name+".md")
+		if n > 1 {
+			path = filepath.Join(folder, fmt.Sprintf("%s %d.md", name, n))
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = file.WriteString(FormatObsidian(note))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+}
+
+// FormatObsidian renders the Markdown note.
+func FormatObsidian(note Note) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", strconv.Quote(note.Title))
+	if !note.Date.IsZero() {
+		fmt.Fprintf(&b, "date: %s\n", note.Date.Format("2006-01-02"))
+	}
+	if note.Source != "" {
+		fmt.Fprintf(&b, "source: %s\n", strconv.Quote(note.Source))
+	}
+	if note.Language != "" {
+		fmt.Fprintf(&b, "language: %s\n", strconv.Quote(note.Language))
+	}
+	if note.Model != "" {
+		fmt.Fprintf(&b, "model: %s\n", strconv.Quote(note.Model))
+	}
+	var tags []string
+	for _, tag := range note.Tags {
+		if slug := tagSlug(tag); slug != "" {
+			tags = append(tags, slug)
+		}
+	}
+	if len(tags) > 0 {
+		b.WriteString("tags:\n")
+		for _, tag := range tags {
+			fmt.Fprintf(&b, "  - %s\n", tag)
+		}
+	}
+	b.WriteString("---\n\n")
+
+	fmt.Fprintf(&b, "# %s\n\n", note.Title)
+	var links []string
+	if note.Project != "" {
+		links = append(links, "Project: [["+note.Project+"]]")
+	}
+	if !note.Date.IsZero() {
+		links = append(links, "Date: [["+note.Date.Format("2006-01-02")+"]]")
+	}
+	if len(links) > 0 {
+		b.WriteString(strings.Join(links, " · ") + "\n\n")
+	}
+	for _, paragraph := range paragraphs(note.Text) {
+		b.WriteString(paragraph + "\n\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// safeFileName replaces characters Obsidian or the OS reject in note names.
+func safeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`\/:*?"<>|#^[]`, r) {
+			return '-'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		return "transcript"
+	}
+	return name
+}
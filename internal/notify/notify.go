@@ -0,0 +1,97 @@
+// Package notify posts job completion messages to chat webhooks (Slack, Discord).
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// discordContentLimit is the maximum length of a Discord webhook message.
+const discordContentLimit = 2000
+
+// Message summarizes one finished job.
+type Message struct {
+	FileName  string
+	Succeeded bool
+	// Audio is the media length and Elapsed the processing time; zero omits them.
+	Audio   time.Duration
+	Elapsed time.Duration
+	// Link points at the published transcript, e.g. a WebDAV or Notion URL.
+	Link    string
+	Excerpt string
+	Error   string
+}
+
+// Slack posts msg to a Slack incoming webhook.
+func Slack(ctx context.Context, client *http.Client, webhookURL string, msg Message) error {
+	return post(ctx, client, webhookURL, map[string]string{"text": Format(msg, "*")})
+}
+
+// Discord posts msg to a Discord webhook.
+func Discord(ctx context.Context, client *http.Client, webhookURL string, msg Message) error {
+	content := Format(msg, "**")
+	if utf8.RuneCountInString(content) > discordContentLimit {
+		content = string([]rune(content)[:discordContentLimit-1]) + "…"
+	}
+	return post(ctx, client, webhookURL, map[string]string{"content": content})
+}
+
+// Format renders msg as chat Markdown; bold is the platform's bold marker.
+func Format(msg Message, bold string) string {
+	var b strings.Builder
+	if msg.Succeeded {
+		fmt.Fprintf(&b, "✅ %s%s%s transcribed", bold, msg.FileName, bold)
+		var details []string
+		if msg.Audio > 0 {
+			details = append(details, msg.Audio.Round(time.Second).String()+" of audio")
+		}
+		if msg.Elapsed > 0 {
+			details = append(details, "took "+msg.Elapsed.Round(time.Second).String())
+		}
+		if len(details) > 0 {
+			b.WriteString(" (" + strings.Join(details, ", ") + ")")
+		}
+	} else {
+		fmt.Fprintf(&b, "❌ %s%s%s failed", bold, msg.FileName, bold)
+		if msg.Error != "" {
+			b.WriteString(": " + msg.Error)
+		}
+	}
+	if msg.Link != "" {
+		b.WriteString("\n" + msg.Link)
+	}
+	if excerpt := strings.TrimSpace(msg.Excerpt); excerpt != "" {
+		b.WriteString("\n> " + strings.ReplaceAll(excerpt, "\n", "\n> "))
+	}
+	return b.String()
+}
+
+// post sends a JSON body to a webhook.
+func post(ctx context.Context, client *http.Client, webhookURL string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook: %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFormat renders success and failure messages.
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+		bold string
+		want string
+	}{
+		{
+			name: "success",
+			msg:  Message{FileName: "ep12.mp3", Succeeded: true, Audio: 754 * time.Second, Elapsed: 95 * time.Second, Link: "https://cloud/ep12.txt", Excerpt: "Hello.\nWorld."},
+			bold: "*",
+			want: "✅ *ep12.mp3* transcribed (12m34s of audio, took 1m35s)\nhttps://cloud/ep12.txt\n> Hello.\n> World.",
+		},
+		{
+			name: "failure",
+			msg:  Message{FileName: "ep12.mp3", Error: "ffmpeg exited with 1"},
+			bold: "**",
+			want: "❌ **ep12.mp3** failed: ffmpeg exited with 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.msg, tt.bold); got != tt.want {
+				t.Fatalf("format =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWebhooksPostPlatformBodies checks the Slack "text" and the truncated Discord "content" fields.
+func TestWebhooksPostPlatformBodies(t *testing.T) {
+	bodies := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+	}))
+	defer server.Close()
+
+	msg := Message{FileName: "a.mp3", Succeeded: true, Excerpt: strings.Repeat("word ", 600)}
+	if err := Slack(context.Background(), server.Client(), server.URL+"/slack", msg); err != nil {
+		t.Fatalf("slack: %v", err)
+	}
+	if err := Discord(context.Background(), server.Client(), server.URL+"/discord", msg); err != nil {
+		t.Fatalf("discord: %v", err)
+	}
+	if !strings.HasPrefix(bodies["/slack"]["text"], "✅ *a.mp3*") {
+		t.Fatalf("slack body = %v", bodies["/slack"])
+	}
+	if content := bodies["/discord"]["content"]; !strings.HasPrefix(content, "✅ **a.mp3**") || len([]rune(content)) != discordContentLimit {
+		t.Fatalf("discord content of %d runes", len([]rune(content)))
+	}
+}
+
+// TestWebhookReportsFailure surfaces non-2xx responses.
+func TestWebhookReportsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if err := Slack(context.Background(), server.Client(), server.URL, Message{}); err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// TestQuietHours covers same-day and overnight windows and parse errors.
+func TestQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2026, 10, 16, hour, minute, 0, 0, time.Local) }
+	tests := []struct {
+		spec string
+		at   time.Time
+		want bool
+	}{
+		{spec: "22:00-07:00", at: at(23, 30), want: true},
+		{spec: "22:00-07:00", at: at(6, 59), want: true},
+		{spec: "22:00-07:00", at: at(7, 0), want: false},
+		{spec: "12:00-13:30", at: at(13, 0), want: true},
+		{spec: "12:00-13:30", at: at(14, 0), want: false},
+	}
+	for _, tt := range tests {
+		hours, ok, err := ParseQuietHours(tt.spec)
+		if err != nil || !ok {
+			t.Fatalf("parse %q: ok=%v err=%v", tt.spec, ok, err)
+		}
+		if got := hours.Contains(tt.at); got != tt.want {
+			t.Fatalf("%s contains %s = %v, want %v", tt.spec, tt.at.Format("15:04"), got, tt.want)
+		}
+	}
+
+	if _, ok, err := ParseQuietHours(" "); ok || err != nil {
+		t.Fatalf("empty spec: ok=%v err=%v", ok, err)
+	}
+	for _, spec := range []string{"22:00", "25:00-07:00", "22:00-7pm"} {
+		if _, _, err := ParseQuietHours(spec); err == nil {
+			t.Fatalf("expected error for %q", spec)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuietHours is a daily local-time window in which notifications are held back.
+// The window may wrap past midnight, e.g. 22:00-07:00.
+type QuietHours struct {
+	// Start and End are minutes after midnight; End is exclusive.
+	Start, End int
+}
+
+// ParseQuietHours parses "HH:MM-HH:MM". An empty spec returns ok=false.
+func ParseQuietHours(spec string) (hours QuietHours, ok bool, err error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return QuietHours{}, false, nil
+	}
+	from, to, found := strings.Cut(spec, "-")
+	if !found {
+		return QuietHours{}, false, fmt.Errorf("quiet hours %q: want HH:MM-HH:MM", spec)
+	}
+	if hours.Start, err = parseClock(from); err != nil {
+		return QuietHours{}, false, fmt.Errorf("quiet hours %q: %w", spec, err)
+	}
+	if hours.End, err = parseClock(to); err != nil {
+		return QuietHours{}, false, fmt.Errorf("quiet hours %q: %w", spec, err)
+	}
+	return hours, true, nil
+}
+
+// Contains reports whether t falls inside the window.
+func (q QuietHours) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if q.Start <= q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// parseClock converts HH:MM to minutes after midnight.
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", strings.TrimSpace(value))
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
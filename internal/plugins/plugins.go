@@ -0,0 +1,151 @@
+// Package plugins runs user post-processing executables after a job: each
+// receives the finished job as JSON on stdin and reports extra artifacts and
+// messages on stdout.
+package plugins
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"sort"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/transcribe"
+)
+
+// InputVersion is the version of the Input format plugins receive.
+const InputVersion = 1
+
+// windowsExecutables are the file extensions Windows can run directly.
+var windowsExecutables = map[string]bool{".exe": true, ".bat": true, ".cmd": true, ".com": true}
+
+// Plugin is one executable or transform script in the plugin directory,
+// named by its file name.
+type Plugin struct {
+	Name string
+	Path string
+	// Transform marks a <name>.lua transform script, which the pipeline runs
+	// in the exporting stage instead of executing it after the job.
+	Transform bool
+}
+
+// Input is the JSON a plugin reads from stdin.
+type Input struct {
+	Version int                 `json:"version"`
+	Job     domain.HistoryEntry `json:"job"`
+}
+
+// Output is what a plugin reported on stdout. Each stdout line may be a JSON
+// object {"artifact": path}, {"message": text}, or {"error": text}; any other
+// line is a message. Relative artifact paths are resolved against the
+// plugin's working directory.
+type Output struct {
+	Artifacts []string
+	Messages  []string
+	Errors    []string
+}
+
+// Discover lists the executables and transform scripts in dir by name; a
+// missing dir has none. Hidden files and, outside Windows, other files
+// without an execute bit are skipped.
+func Discover(dir string) ([]Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var plugins []Plugin
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		transform := strings.HasSuffix(entry.Name(), transcribe.TransformSuffix)
+		if !transform && !isExecutable(entry.Name(), info.Mode(), goruntime.GOOS) {
+			continue
+		}
+		plugins = append(plugins, Plugin{Name: entry.Name(), Path: filepath.Join(dir, entry.Name()), Transform: transform})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+// isExecutable reports whether a file named name with mode can be run on goos.
+func isExecutable(name string, mode os.FileMode, goos string) bool {
+	if goos == "windows" {
+		return windowsExecutables[strings.ToLower(filepath.Ext(name))]
+	}
+	return mode&0o111 != 0
+}
+
+// Run executes plugin in dir with input on stdin until it exits or ctx ends.
+// A non-zero exit is an error that carries the plugin's stderr; the output
+// it printed until then is still returned.
+func Run(ctx context.Context, plugin Plugin, dir string, input Input) (Output, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return Output{}, fmt.Errorf("encode plugin input: %w", err)
+	}
+	cmd := exec.CommandContext(ctx, plugin.Path)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	output := parseOutput(stdout.String(), dir)
+	if runErr != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return output, fmt.Errorf("%w: %s", runErr, message)
+		}
+		return output, runErr
+	}
+	return output, nil
+}
+
+// parseOutput reads the artifact, message, and error lines of stdout.
+func parseOutput(stdout, dir string) Output {
+	var output Output
+	scanner := bufio.NewScanner(strings.NewReader(stdout))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var report struct {
+			Artifact string `json:"artifact"`
+			Message  string `json:"message"`
+			Error    string `json:"error"`
+		}
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &report) != nil {
+			output.Messages = append(output.Messages, line)
+			continue
+		}
+		if path := strings.TrimSpace(report.Artifact); path != "" {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			output.Artifacts = append(output.Artifacts, filepath.Clean(path))
+		}
+		if message := strings.TrimSpace(report.Message); message != "" {
+			output.Messages = append(output.Messages, message)
+		}
+		if message := strings.TrimSpace(report.Error); message != "" {
+			output.Errors = append(output.Errors, message)
+		}
+	}
+	return output
+}
@@ -0,0 +1,134 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// writePlugin writes a shell script plugin with the given mode.
+func writePlugin(t *testing.T, dir, name, script string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), mode); err != nil {
+		t.Fatalf("write plugin: %v", err)
+	}
+	return path
+}
+
+// TestDiscover lists executables by name and skips the rest.
+func TestDiscover(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("execute bits do not apply on Windows")
+	}
+	dir := t.TempDir()
+	writePlugin(t, dir, "zip-outputs", "", 0o755)
+	writePlugin(t, dir, "anki", "", 0o700)
+	writePlugin(t, dir, "README", "", 0o644)
+	writePlugin(t, dir, ".hidden", "", 0o755)
+	writePlugin(t, dir, "notes.md.lua", "", 0o644)
+	if err := os.Mkdir(filepath.Join(dir, "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	plugins, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+	want := []Plugin{
+		{Name: "anki", Path: filepath.Join(dir, "anki")},
+		{Name: "notes.md.lua", Path: filepath.Join(dir, "notes.md.lua"), Transform: true},
+		{Name: "zip-outputs", Path: filepath.Join(dir, "zip-outputs")},
+	}
+	if !reflect.DeepEqual(plugins, want) {
+		t.Fatalf("Discover() = %+v, want %+v", plugins, want)
+	}
+
+	if plugins, err := Discover(filepath.Join(dir, "missing")); err != nil || plugins != nil {
+		t.Fatalf("Discover(missing) = %v, %v, want none", plugins, err)
+	}
+}
+
+// TestIsExecutable uses extensions on Windows and execute bits elsewhere.
+func TestIsExecutable(t *testing.T) {
+	tests := []struct {
+		name string
+		mode os.FileMode
+		goos string
+		want bool
+	}{
+		{name: "export.exe", mode: 0o644, goos: "windows", want: true},
+		{name: "export.CMD", mode: 0o644, goos: "windows", want: true},
+		{name: "export.py", mode: 0o755, goos: "windows", want: false},
+		{name: "export.py", mode: 0o755, goos: "linux", want: true},
+		{name: "export", mode: 0o644, goos: "darwin", want: false},
+	}
+	for _, tt := range tests {
+		if got := isExecutable(tt.name, tt.mode, tt.goos); got != tt.want {
+			t.Errorf("isExecutable(%q, %v, %s) = %v, want %v", tt.name, tt.mode, tt.goos, got, tt.want)
+		}
+	}
+}
+
+// TestRun passes the job on stdin in the working directory and parses the
+// artifact, message, and error lines.
+func TestRun(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	dir := t.TempDir()
+	path := writePlugin(t, dir, "echo-job", `cat > job.json
+echo '{"artifact": "job.json"}'
+echo '{"message": "copied the job"}'
+echo '{"error": "no network"}'
+echo plain text
+`, 0o755)
+
+	output, err := Run(context.Background(), Plugin{Name: "echo-job", Path: path}, dir, Input{
+		Version: InputVersion,
+		Job:     domain.HistoryEntry{ID: "job-1", Status: domain.JobStatusDone, TextPath: "/out/talk.txt"},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := Output{
+		Artifacts: []string{filepath.Join(dir, "job.json")},
+		Messages:  []string{"copied the job", "plain text"},
+		Errors:    []string{"no network"},
+	}
+	if !reflect.DeepEqual(output, want) {
+		t.Fatalf("Run() = %+v, want %+v", output, want)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "job.json"))
+	if err != nil {
+		t.Fatalf("read plugin input: %v", err)
+	}
+	for _, part := range []string{`"version":1`, `"id":"job-1"`, `"textPath":"/out/talk.txt"`} {
+		if !strings.Contains(string(data), part) {
+			t.Fatalf("input %s lacks %s", data, part)
+		}
+	}
+}
+
+// TestRunFailure reports a non-zero exit with the plugin's stderr.
+func TestRunFailure(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("shell script plugins need a POSIX shell")
+	}
+	dir := t.TempDir()
+	path := writePlugin(t, dir, "broken", "echo partial\necho 'missing token' >&2\nexit 3\n", 0o755)
+
+	output, err := Run(context.Background(), Plugin{Name: "broken", Path: path}, dir, Input{Version: InputVersion})
+	if err == nil || !strings.Contains(err.Error(), "missing token") {
+		t.Fatalf("Run() error = %v, want stderr", err)
+	}
+	if !reflect.DeepEqual(output.Messages, []string{"partial"}) {
+		t.Fatalf("output = %+v, want the partial message", output)
+	}
+}
@@ -0,0 +1,256 @@
+// Package remote talks to media-transcriber servers started with `serve`, so
+// the desktop app can run jobs on another machine.
+package remote
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// Client calls the REST/SSE API of one media-transcriber server.
+type Client struct {
+	baseURL *url.URL
+	token   string
+	http    *http.Client
+}
+
+// NewClient validates baseURL, e.g. http://gpu-box:8080. A non-empty token is
+// sent as a bearer token with every request.
+func NewClient(baseURL, token string) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimRight(strings.TrimSpace(baseURL), "/"))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid worker URL %q", baseURL)
+	}
+	// No overall timeout: uploads and event streams last as long as the job.
+	return &Client{baseURL: parsed, token: strings.TrimSpace(token), http: &http.Client{}}, nil
+}
+
+// String returns the server base URL.
+func (c *Client) String() string {
+	return c.baseURL.String()
+}
+
+// Load returns how many jobs the server has queued or running.
+func (c *Client) Load(ctx context.Context) (int, error) {
+	var queue []domain.QueuedJob
+	if err := c.getJSON(ctx, "/api/queue", &queue); err != nil {
+		return 0, err
+	}
+	var current domain.Job
+	if err := c.getJSON(ctx, "/api/jobs/current", &current); err != nil {
+		return 0, err
+	}
+	load := len(queue)
+	switch current.Status {
+	case domain.JobStatusPreprocessing, domain.JobStatusTranscribing, domain.JobStatusExporting:
+		load++
+	}
+	return load, nil
+}
+
+// Upload sends a local media file and queues it on the server.
+func (c *Client) Upload(ctx context.Context, inputPath string) (domain.QueuedJob, error) {
+	file, err := os.Open(inputPath)
+	if err != nil {
+		return domain.QueuedJob{}, fmt.Errorf("open input: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return domain.QueuedJob{}, fmt.Errorf("stat input: %w", err)
+	}
+
+	endpoint := c.endpoint("/api/uploads", url.Values{"name": {filepath.Base(inputPath)}})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, file)
+	if err != nil {
+		return domain.QueuedJob{}, err
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	var queued domain.QueuedJob
+	if err := c.do(req, http.StatusAccepted, &queued); err != nil {
+		return domain.QueuedJob{}, fmt.Errorf("upload %s: %w", filepath.Base(inputPath), err)
+	}
+	return queued, nil
+}
+
+// Events streams server events after since that match filter, calling handle
+// for each until it returns false, ctx ends, or the server closes the stream.
+func (c *Client) Events(ctx context.Context, since int64, filter jobs.EventFilter, handle func(jobs.Event) bool) error {
+	query := url.Values{"since": {strconv.FormatInt(since, 10)}}
+	if filter.JobID != "" {
+		query.Set("jobId", filter.JobID)
+	}
+	for _, eventType := range filter.Types {
+		query.Add("type", string(eventType))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint("/api/events/stream", query), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4<<20)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var event jobs.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("decode event: %w", err)
+		}
+		if !handle(event) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Artifacts lists the output file names of a finished server job.
+func (c *Client) Artifacts(ctx context.Context, jobID string) ([]string, error) {
+	var names []string
+	if err := c.getJSON(ctx, "/api/jobs/"+url.PathEscape(jobID)+"/artifacts", &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// Download saves one artifact of a server job to destPath.
+func (c *Client) Download(ctx context.Context, jobID, name, destPath string) error {
+	endpoint := c.endpoint("/api/jobs/"+url.PathEscape(jobID)+"/artifacts/"+url.PathEscape(name), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+
+	tmp := destPath + ".part"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, destPath)
+}
+
+// Cancel stops a server job: it is removed from the queue while waiting, or
+// cancelled if it is the running job.
+func (c *Client) Cancel(ctx context.Context, queuedID, jobID string) error {
+	if jobID == "" {
+		return c.send(ctx, http.MethodDelete, "/api/queue/"+url.PathEscape(queuedID))
+	}
+	var current domain.Job
+	if err := c.getJSON(ctx, "/api/jobs/current", &current); err != nil {
+		return err
+	}
+	if current.ID != jobID {
+		return nil
+	}
+	return c.send(ctx, http.MethodPost, "/api/jobs/current/cancel")
+}
+
+// roundTrip sends req with the bearer token attached.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return c.http.Do(req)
+}
+
+// endpoint resolves path and query against the base URL.
+func (c *Client) endpoint(path string, query url.Values) string {
+	u := *c.baseURL
+	u.Path = strings.TrimRight(u.Path, "/") + path
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// getJSON decodes the JSON body of a GET request.
+func (c *Client) getJSON(ctx context.Context, path string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint(path, nil), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, http.StatusOK, target)
+}
+
+// send issues a request without body and expects 204 No Content.
+func (c *Client) send(ctx context.Context, method, path string) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint(path, nil), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, http.StatusNoContent, nil)
+}
+
+// do runs req and decodes the body into target when the status matches.
+func (c *Client) do(req *http.Request, wantStatus int, target any) error {
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		return responseError(resp)
+	}
+	if target == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// responseError turns an error response into an error carrying the server message.
+func responseError(resp *http.Response) error {
+	var body struct {
+		Error string `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if json.Unmarshal(data, &body) == nil && body.Error != "" {
+		return fmt.Errorf("server returned %s: %s", resp.Status, body.Error)
+	}
+	return fmt.Errorf("server returned %s", resp.Status)
+}
@@ -0,0 +1,122 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+const (
+	// maxReconnects is how many consecutive broken event streams are retried.
+	maxReconnects = 5
+	// reconnectDelay is the pause before reopening a broken event stream.
+	reconnectDelay = time.Second
+	// cancelTimeout bounds the request that cancels a server job.
+	cancelTimeout = 10 * time.Second
+)
+
+// ErrJobLost is returned when the event stream keeps failing before the job finishes.
+var ErrJobLost = errors.New("lost track of the remote job")
+
+// Outcome is the final state of a job run on a server.
+type Outcome struct {
+	Worker string           `json:"worker"`
+	JobID  string           `json:"jobId"`
+	Status domain.JobStatus `json:"status"`
+	// Error is the last error the server reported for the job.
+	Error string `json:"error,omitempty"`
+	// Files are the downloaded artifacts in the local output directory.
+	Files []string `json:"files,omitempty"`
+}
+
+// Transcribe uploads inputPath, forwards the server job's events to onEvent,
+// and downloads its artifacts once it is done into a subdirectory of
+// outputDir named after the server job, so sending the same input again
+// keeps the earlier results. Cancelling ctx cancels the server job as well.
+func (c *Client) Transcribe(ctx context.Context, inputPath, outputDir string, onEvent func(jobs.Event)) (Outcome, error) {
+	outcome := Outcome{Worker: c.String()}
+	queued, err := c.Upload(ctx, inputPath)
+	if err != nil {
+		return outcome, err
+	}
+
+	var since int64
+	failures := 0
+	for outcome.Status == "" {
+		streamErr := c.Events(ctx, since, jobs.EventFilter{}, func(event jobs.Event) bool {
+			since = event.Seq
+			failures = 0
+			if outcome.JobID == "" {
+				// The server assigns the job ID when the queued upload starts.
+				if event.Type != jobs.EventTypeStatus || event.Job == nil || event.Job.InputPath != queued.InputPath {
+					return true
+				}
+				outcome.JobID = event.JobID
+			}
+			if event.JobID != outcome.JobID {
+				return true
+			}
+			if onEvent != nil {
+				onEvent(event)
+			}
+			switch {
+			case event.Type == jobs.EventTypeError:
+				outcome.Error = event.Message
+			case event.Type == jobs.EventTypeStatus && isFinal(event.Status):
+				outcome.Status = event.Status
+				return false
+			}
+			return true
+		})
+		if ctx.Err() != nil {
+			cancelCtx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+			_ = c.Cancel(cancelCtx, queued.ID, outcome.JobID)
+			cancel()
+			return outcome, ctx.Err()
+		}
+		if outcome.Status != "" {
+			break
+		}
+		if failures++; failures > maxReconnects {
+			if streamErr != nil {
+				return outcome, fmt.Errorf("%w: %v", ErrJobLost, streamErr)
+			}
+			return outcome, ErrJobLost
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(reconnectDelay):
+		}
+	}
+
+	if outcome.Status != domain.JobStatusDone {
+		return outcome, nil
+	}
+	names, err := c.Artifacts(ctx, outcome.JobID)
+	if err != nil {
+		return outcome, fmt.Errorf("list artifacts: %w", err)
+	}
+	jobDir := filepath.Join(outputDir, filepath.Base(outcome.JobID))
+	if err := os.MkdirAll(jobDir, 0o755); err != nil {
+		return outcome, fmt.Errorf("create output directory: %w", err)
+	}
+	for _, name := range names {
+		dest := filepath.Join(jobDir, filepath.Base(name))
+		if err := c.Download(ctx, outcome.JobID, name, dest); err != nil {
+			return outcome, fmt.Errorf("download %s: %w", name, err)
+		}
+		outcome.Files = append(outcome.Files, dest)
+	}
+	return outcome, nil
+}
+
+// isFinal reports whether status ends a job.
+func isFinal(status domain.JobStatus) bool {
+	return status == domain.JobStatusDone || status == domain.JobStatusFailed || status == domain.JobStatusCancelled
+}
@@ -0,0 +1,246 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/jobs"
+)
+
+// fakeWorker serves the parts of the serve-mode API that Transcribe uses.
+// Each event stream request takes the next batch of streams; a batch ending
+// with block keeps the connection open until the client goes away.
+type fakeWorker struct {
+	t       *testing.T
+	current domain.Job
+	streams [][]jobs.Event
+	block   bool
+
+	mu        sync.Mutex
+	sinces    []string
+	cancelled []string
+}
+
+// upload is the queued job every upload returns.
+var upload = domain.QueuedJob{ID: "queued-1", InputPath: "/srv/uploads/upload-1/talk.mp3"}
+
+// handler routes the fake API.
+func (f *fakeWorker) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/uploads", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "talk.mp3" {
+			f.t.Errorf("upload name = %q", r.URL.Query().Get("name"))
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(upload)
+	})
+	mux.HandleFunc("GET /api/events/stream", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.sinces = append(f.sinces, r.URL.Query().Get("since"))
+		var batch []jobs.Event
+		last := len(f.streams) <= 1
+		if len(f.streams) > 0 {
+			batch = f.streams[0]
+			f.streams = f.streams[1:]
+		}
+		f.mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range batch {
+			data, _ := json.Marshal(event)
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", event.Seq, data)
+		}
+		w.(http.Flusher).Flush()
+		if last && f.block {
+			<-r.Context().Done()
+		}
+	})
+	mux.HandleFunc("GET /api/jobs/current", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(f.current)
+	})
+	mux.HandleFunc("POST /api/jobs/current/cancel", func(w http.ResponseWriter, r *http.Request) {
+		f.record("job " + f.current.ID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /api/queue/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.record("queue " + r.PathValue("id"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /api/jobs/{id}/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]string{"talk.txt", "talk.srt"})
+	})
+	mux.HandleFunc("GET /api/jobs/{id}/artifacts/{name}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s of %s", r.PathValue("name"), r.PathValue("id"))
+	})
+	return mux
+}
+
+// record notes one cancel request.
+func (f *fakeWorker) record(cancel string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelled = append(f.cancelled, cancel)
+}
+
+// start serves f and returns a client for it and a local input file.
+func (f *fakeWorker) start(t *testing.T) (*Client, string) {
+	t.Helper()
+	server := httptest.NewServer(f.handler())
+	t.Cleanup(server.Close)
+	client, err := NewClient(server.URL, "")
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	input := filepath.Join(t.TempDir(), "talk.mp3")
+	if err := os.WriteFile(input, []byte("audio"), 0o644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	return client, input
+}
+
+// statusEvent is a status event of jobID, which runs inputPath.
+func statusEvent(seq int64, jobID, inputPath string, status domain.JobStatus) jobs.Event {
+	return jobs.Event{
+		Seq: seq, JobID: jobID, Type: jobs.EventTypeStatus, Status: status,
+		Job: &domain.Job{ID: jobID, Status: status, InputPath: inputPath},
+	}
+}
+
+// TestTranscribeFollowsUploadedJob ignores events of other jobs, reports the
+// error of a failed job, and downloads nothing for it.
+func TestTranscribeFollowsUploadedJob(t *testing.T) {
+	worker := &fakeWorker{t: t, streams: [][]jobs.Event{{
+		statusEvent(1, "job-other", "/srv/other.mp3", domain.JobStatusTranscribing),
+		{Seq: 2, JobID: "job-other", Type: jobs.EventTypeError, Message: "not ours"},
+		statusEvent(3, "job-1", upload.InputPath, domain.JobStatusPreprocessing),
+		{Seq: 4, JobID: "job-other", Type: jobs.EventTypeLog, Message: "not ours"},
+		{Seq: 5, JobID: "job-1", Type: jobs.EventTypeError, Message: "whisper crashed"},
+		statusEvent(6, "job-1", upload.InputPath, domain.JobStatusFailed),
+	}}}
+	client, input := worker.start(t)
+
+	var forwarded []int64
+	outcome, err := client.Transcribe(context.Background(), input, t.TempDir(), func(event jobs.Event) {
+		forwarded = append(forwarded, event.Seq)
+	})
+	if err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	if outcome.JobID != "job-1" || outcome.Status != domain.JobStatusFailed || outcome.Error != "whisper crashed" || len(outcome.Files) != 0 {
+		t.Fatalf("outcome = %+v", outcome)
+	}
+	if fmt.Sprint(forwarded) != "[3 5 6]" {
+		t.Fatalf("forwarded = %v, want [3 5 6]", forwarded)
+	}
+}
+
+// TestTranscribeReconnectsEventStream resumes after the last seen event when
+// the stream breaks before the job finishes.
+func TestTranscribeReconnectsEventStream(t *testing.T) {
+	worker := &fakeWorker{t: t, streams: [][]jobs.Event{
+		{statusEvent(7, "job-1", upload.InputPath, domain.JobStatusTranscribing)},
+		{statusEvent(8, "job-1", upload.InputPath, domain.JobStatusDone)},
+	}}
+	client, input := worker.start(t)
+
+	outcome, err := client.Transcribe(context.Background(), input, t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	if outcome.Status != domain.JobStatusDone {
+		t.Fatalf("outcome = %+v", outcome)
+	}
+	worker.mu.Lock()
+	defer worker.mu.Unlock()
+	if strings.Join(worker.sinces, ",") != "0,7" {
+		t.Fatalf("since per stream = %v, want [0 7]", worker.sinces)
+	}
+}
+
+// TestTranscribeCancelsServerJob cancels the running job, or the queue entry
+// when the upload has not started yet.
+func TestTranscribeCancelsServerJob(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []jobs.Event
+		want   string
+	}{
+		{name: "running", events: []jobs.Event{statusEvent(1, "job-1", upload.InputPath, domain.JobStatusTranscribing)}, want: "job job-1"},
+		{name: "queued", events: []jobs.Event{{Seq: 1, Type: jobs.EventTypeLog, Message: "Queued talk.mp3"}}, want: "queue queued-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worker := &fakeWorker{t: t, current: domain.Job{ID: "job-1"}, streams: [][]jobs.Event{tt.events}, block: true}
+			client, input := worker.start(t)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			// Cancel once the first event arrived, while the stream stays open.
+			go func() {
+				for {
+					worker.mu.Lock()
+					started := len(worker.sinces) > 0
+					worker.mu.Unlock()
+					if started {
+						time.Sleep(50 * time.Millisecond)
+						cancel()
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+
+			_, err := client.Transcribe(ctx, input, t.TempDir(), nil)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("transcribe error = %v, want context.Canceled", err)
+			}
+			worker.mu.Lock()
+			defer worker.mu.Unlock()
+			if strings.Join(worker.cancelled, ",") != tt.want {
+				t.Fatalf("cancelled = %v, want %s", worker.cancelled, tt.want)
+			}
+		})
+	}
+}
+
+// TestTranscribeDownloadsArtifactsPerJob saves each run's artifacts in its
+// own directory, so a second run of the same input keeps the first.
+func TestTranscribeDownloadsArtifactsPerJob(t *testing.T) {
+	outputDir := t.TempDir()
+	var runs [][]string
+	for _, jobID := range []string{"job-1", "job-2"} {
+		worker := &fakeWorker{t: t, streams: [][]jobs.Event{{statusEvent(1, jobID, upload.InputPath, domain.JobStatusDone)}}}
+		client, input := worker.start(t)
+
+		outcome, err := client.Transcribe(context.Background(), input, outputDir, nil)
+		if err != nil {
+			t.Fatalf("transcribe: %v", err)
+		}
+		runs = append(runs, outcome.Files)
+	}
+
+	for i, jobID := range []string{"job-1", "job-2"} {
+		want := []string{filepath.Join(outputDir, jobID, "talk.txt"), filepath.Join(outputDir, jobID, "talk.srt")}
+		if strings.Join(runs[i], ",") != strings.Join(want, ",") {
+			t.Fatalf("files of %s = %v, want %v", jobID, runs[i], want)
+		}
+		data, err := os.ReadFile(want[0])
+		if err != nil {
+			t.Fatalf("read artifact: %v", err)
+		}
+		if string(data) != "talk.txt of "+jobID {
+			t.Fatalf("artifact of %s = %q", jobID, data)
+		}
+	}
+}
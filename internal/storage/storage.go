@@ -0,0 +1,10 @@
+// Package storage uploads finished transcripts to external archives.
+package storage
+
+import "context"
+
+// Uploader stores one local file under a slash-separated key and returns
+// where it ended up.
+type Uploader interface {
+	Upload(ctx context.Context, key, path string) (string, error)
+}
@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// WebDAVConfig addresses a WebDAV folder, e.g. a Nextcloud
+// https://cloud.example.com/remote.php/dav/files/<user>/ URL.
+type WebDAVConfig struct {
+	URL      string
+	User     string
+	Password string
+}
+
+// WebDAVUploader PUTs files below the base URL, creating missing folders with MKCOL.
+type WebDAVUploader struct {
+	config  WebDAVConfig
+	baseURL *url.URL
+	http    *http.Client
+	// created caches folders known to exist so each is MKCOL'ed once.
+	created map[string]bool
+}
+
+// NewWebDAVUploader validates config.
+func NewWebDAVUploader(config WebDAVConfig) (*WebDAVUploader, error) {
+	baseURL, err := url.Parse(strings.TrimRight(strings.TrimSpace(config.URL), "/"))
+	if err != nil || (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid WebDAV URL %q", config.URL)
+	}
+	return &WebDAVUploader{config: config, baseURL: baseURL, http: &http.Client{}, created: map[string]bool{}}, nil
+}
+
+// Upload stores the file at path as key (a slash-separated path below the base
+// URL) and returns the resulting URL.
+func (u *WebDAVUploader) Upload(ctx context.Context, key, path string) (string, error) {
+	segments := strings.Split(strings.Trim(key, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if err := u.mkcol(ctx, segments[:i]); err != nil {
+			return "", err
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	target := u.url(segments)
+	req, err := u.request(ctx, http.MethodPut, target, file)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = info.Size()
+	if err := u.do(req, http.StatusOK, http.StatusCreated, http.StatusNoContent); err != nil {
+		return "", fmt.Errorf("put %s: %w", key, err)
+	}
+	return target, nil
+}
+
+// mkcol creates one folder; 405 means it already exists.
+func (u *WebDAVUploader) mkcol(ctx context.Context, segments []string) error {
+	target := u.url(segments) + "/"
+	if u.created[target] {
+		return nil
+	}
+	req, err := u.request(ctx, "MKCOL", target, nil)
+	if err != nil {
+		return err
+	}
+	if err := u.do(req, http.StatusCreated, http.StatusMethodNotAllowed); err != nil {
+		return fmt.Errorf("create folder %s: %w", strings.Join(segments, "/"), err)
+	}
+	u.created[target] = true
+	return nil
+}
+
+// url escapes each path segment below the base URL.
+func (u *WebDAVUploader) url(segments []string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return u.baseURL.String() + "/" + strings.Join(escaped, "/")
+}
+
+// request builds a request with basic auth when a user is configured.
+func (u *WebDAVUploader) request(ctx context.Context, method, target string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if u.config.User != "" {
+		req.SetBasicAuth(u.config.User, u.config.Password)
+	}
+	return req, nil
+}
+
+// do sends req and fails unless the response status is one of ok.
+func (u *WebDAVUploader) do(req *http.Request, ok ...int) error {
+	resp, err := u.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	for _, status := range ok {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
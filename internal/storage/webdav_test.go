@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDAV is a minimal WebDAV server that tracks folders and stored files.
+type fakeDAV struct {
+	mu      sync.Mutex
+	folders map[string]bool
+	files   map[string]string
+	mkcols  int
+}
+
+// ServeHTTP answers MKCOL and PUT against the in-memory tree.
+func (d *fakeDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if user, password, ok := r.BasicAuth(); !ok || user != "alice" || password != "app-pass" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch r.Method {
+	case "MKCOL":
+		d.mkcols++
+		if d.folders[r.URL.Path] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		d.folders[r.URL.Path] = true
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		if !d.folders[r.URL.Path[:strings.LastIndex(r.URL.Path, "/")+1]] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		d.files[r.URL.Path] = string(body)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// TestWebDAVUploaderCreatesFoldersAndPuts checks MKCOL of missing folders, escaping, and auth.
+func TestWebDAVUploaderCreatesFoldersAndPuts(t *testing.T) {
+	dav := &fakeDAV{folders: map[string]bool{"/dav/": true, "/dav/Projects/": true}, files: map[string]string{}}
+	server := httptest.NewServer(dav)
+	defer server.Close()
+
+	root := t.TempDir()
+	for _, name := range []string{"talk 1.txt", "talk 1.srt"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	uploader, err := NewWebDAVUploader(WebDAVConfig{URL: server.URL + "/dav/", User: "alice", Password: "app-pass"})
+	if err != nil {
+		t.Fatalf("new uploader: %v", err)
+	}
+	for _, name := range []string{"talk 1.txt", "talk 1.srt"} {
+		location, err := uploader.Upload(context.Background(), "Projects/Podcast/"+name, filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("upload %s: %v", name, err)
+		}
+		if want := server.URL + "/dav/Projects/Podcast/talk%201" + filepath.Ext(name); location != want {
+			t.Fatalf("location = %q, want %q", location, want)
+		}
+	}
+
+	if dav.files["/dav/Projects/Podcast/talk 1.txt"] != "talk 1.txt" || len(dav.files) != 2 {
+		t.Fatalf("files = %v", dav.files)
+	}
+	// Projects exists (405) and Podcast is created once, then cached.
+	if dav.mkcols != 2 {
+		t.Fatalf("mkcols = %d, want 2", dav.mkcols)
+	}
+}
+
+// TestWebDAVUploaderReportsAuthFailure surfaces the server status.
+func TestWebDAVUploaderReportsAuthFailure(t *testing.T) {
+	server := httptest.NewServer(&fakeDAV{folders: map[string]bool{}, files: map[string]string{}})
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	uploader, err := NewWebDAVUploader(WebDAVConfig{URL: server.URL, User: "alice", Password: "wrong"})
+	if err != nil {
+		t.Fatalf("new uploader: %v", err)
+	}
+	if _, err := uploader.Upload(context.Background(), "a.txt", path); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("err = %v, want 401", err)
+	}
+	if _, err := NewWebDAVUploader(WebDAVConfig{URL: "cloud.example.com"}); err == nil {
+		t.Fatal("expected error for URL without scheme")
+	}
+}
@@ -0,0 +1,259 @@
+package subtitle
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+const (
+	// alignWindow is how many recognized words ahead a script word may match,
+	// bounding how far one misrecognition can pull the alignment off course.
+	alignWindow = 24
+	// maxCueChars keeps cues within two 42-character subtitle lines.
+	maxCueChars = 84
+)
+
+// ErrScriptMismatch means almost no script word was found in the recognized speech.
+var ErrScriptMismatch = errors.New("script does not match the audio")
+
+// timedWord is one word with its estimated time span.
+type timedWord struct {
+	text    string
+	key     string
+	startMs int64
+	endMs   int64
+	matched bool
+}
+
+// Align times a prepared script against recognized speech. Recognized cues
+// come from free transcription; their words are timed by spreading each cue's
+// span over its characters. Script words are matched to recognized words in
+// order, unmatched script words are interpolated between matched neighbours,
+// and the script is cut into cues at line and sentence ends.
+func Align(script string, recognized []Cue) ([]Cue, error) {
+	heard := recognizedWords(recognized)
+	words := scriptWords(script)
+	total := 0
+	for _, word := range words {
+		if word.key != "" {
+			total++
+		}
+	}
+	if total == 0 {
+		return nil, errors.New("script is empty")
+	}
+
+	// Fewer than one word in five found means the script belongs to other audio.
+	matched := matchWords(words, heard)
+	if matched == 0 || matched*5 < total {
+		return nil, ErrScriptMismatch
+	}
+	interpolate(words)
+	return buildCues(words), nil
+}
+
+// recognizedWords splits cues into words timed proportionally to their length.
+func recognizedWords(cues []Cue) []timedWord {
+	var out []timedWord
+	for _, cue := range cues {
+		fields := strings.Fields(cue.Text)
+		total := 0
+		for _, field := range fields {
+			total += len([]rune(field))
+		}
+		if total == 0 {
+			continue
+		}
+		span := cue.EndMs - cue.StartMs
+		done := 0
+		for _, field := range fields {
+			start := cue.StartMs + span*int64(done)/int64(total)
+			done += len([]rune(field))
+			end := cue.StartMs + span*int64(done)/int64(total)
+			if key := normalizeWord(field); key != "" {
+				out = append(out, timedWord{text: field, key: key, startMs: start, endMs: end})
+			}
+		}
+	}
+	return out
+}
+
+// scriptWords splits the script into words, marking line breaks with an empty
+// word so cue building can honour them. Punctuation-only fields such as dashes
+// are glued to a neighbouring word because they have nothing to match.
+func scriptWords(script string) []timedWord {
+	var out []timedWord
+	for _, line := range strings.Split(script, "\n") {
+		lineStart := len(out)
+		prefix := ""
+		for _, field := range strings.Fields(line) {
+			key := normalizeWord(field)
+			switch {
+			case key != "":
+				out = append(out, timedWord{text: prefix + field, key: key})
+				prefix = ""
+			case len(out) > lineStart:
+				out[len(out)-1].text += " " + field
+			default:
+				prefix += field + " "
+			}
+		}
+		if len(out) > lineStart {
+			out = append(out, timedWord{})
+		}
+	}
+	return out
+}
+
+// matchWords copies times from recognized words onto script words in order
+// and returns how many script words matched. A match further ahead than the
+// next recognized word must be confirmed by the following word as well, so
+// common short words do not jump the cursor past a misrecognized stretch.
+func matchWords(words, heard []timedWord) int {
+	cursor, matched := 0, 0
+	for i := range words {
+		if words[i].key == "" {
+			continue
+		}
+		for j := cursor; j < len(heard) && j < cursor+alignWindow; j++ {
+			if heard[j].key != words[i].key {
+				continue
+			}
+			if j > cursor && !nextWordsAgree(words, i, heard, j) {
+				continue
+			}
+			words[i].startMs, words[i].endMs, words[i].matched = heard[j].startMs, heard[j].endMs, true
+			cursor = j + 1
+			matched++
+			break
+		}
+	}
+	return matched
+}
+
+// nextWordsAgree reports whether the script word after i matches the recognized word after j.
+func nextWordsAgree(words []timedWord, i int, heard []timedWord, j int) bool {
+	next := i + 1
+	for next < len(words) && words[next].key == "" {
+		next++
+	}
+	if next >= len(words) || j+1 >= len(heard) {
+		return true
+	}
+	return words[next].key == heard[j+1].key
+}
+
+// interpolate spreads unmatched runs evenly between their matched neighbours.
+// Runs before the first match end at it; runs after the last match start there.
+func interpolate(words []timedWord) {
+	i := 0
+	for i < len(words) {
+		if words[i].matched || words[i].key == "" {
+			i++
+			continue
+		}
+		runStart := i
+		for i < len(words) && !words[i].matched {
+			i++
+		}
+
+		var from, to int64
+		prev := previousMatched(words, runStart)
+		if prev >= 0 {
+			from = words[prev].endMs
+		}
+		if i < len(words) {
+			to = words[i].startMs
+		} else {
+			to = from
+		}
+		if prev < 0 {
+			from = to
+		}
+
+		count := 0
+		for k := runStart; k < i; k++ {
+			if words[k].key != "" {
+				count++
+			}
+		}
+		n := 0
+		for k := runStart; k < i; k++ {
+			if words[k].key == "" {
+				continue
+			}
+			words[k].startMs = from + (to-from)*int64(n)/int64(count)
+			n++
+			words[k].endMs = from + (to-from)*int64(n)/int64(count)
+		}
+	}
+}
+
+// previousMatched returns the index of the last matched word before i, or -1.
+func previousMatched(words []timedWord, i int) int {
+	for k := i - 1; k >= 0; k-- {
+		if words[k].matched {
+			return k
+		}
+	}
+	return -1
+}
+
+// buildCues groups timed script words into cues at line ends, sentence ends,
+// and before a cue would outgrow maxCueChars.
+func buildCues(words []timedWord) []Cue {
+	var cues []Cue
+	var text []string
+	var start, end int64
+	length := 0
+
+	flush := func() {
+		if len(text) == 0 {
+			return
+		}
+		if end < start {
+			end = start
+		}
+		cues = append(cues, Cue{StartMs: start, EndMs: end, Text: strings.Join(text, " ")})
+		text, length = nil, 0
+	}
+
+	for _, word := range words {
+		if word.text == "" {
+			flush()
+			continue
+		}
+		if length > 0 && length+1+len([]rune(word.text)) > maxCueChars {
+			flush()
+		}
+		if len(text) == 0 {
+			start = word.startMs
+		}
+		text = append(text, word.text)
+		length += len([]rune(word.text)) + 1
+		end = word.endMs
+		if endsSentence(word.text) {
+			flush()
+		}
+	}
+	flush()
+	return cues
+}
+
+// endsSentence reports a word ending in . ! ? or …, ignoring closing quotes and brackets.
+func endsSentence(word string) bool {
+	word = strings.TrimRight(word, "\"')]»”’")
+	return strings.HasSuffix(word, ".") || strings.HasSuffix(word, "!") ||
+		strings.HasSuffix(word, "?") || strings.HasSuffix(word, "…")
+}
+
+// normalizeWord lowercases a word and drops punctuation so "Hello," matches "hello".
+func normalizeWord(word string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, word)
+}
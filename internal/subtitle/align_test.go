@@ -0,0 +1,65 @@
+package subtitle
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestAlignCarriesRecognizedTimesOntoScript aligns a script with punctuation,
+// a misrecognized word, and a line break.
+func TestAlignCarriesRecognizedTimesOntoScript(t *testing.T) {
+	recognized := []Cue{
+		{StartMs: 1000, EndMs: 3000, Text: "good evening every one"},
+		{StartMs: 4000, EndMs: 6000, Text: "welcome to the show"},
+	}
+	script := "Good evening, everyone.\nWelcome to the show!"
+
+	cues, err := Align(script, recognized)
+	if err != nil {
+		t.Fatalf("align: %v", err)
+	}
+	if len(cues) != 2 {
+		t.Fatalf("cues = %+v, want 2", cues)
+	}
+	if cues[0].Text != "Good evening, everyone." || cues[1].Text != "Welcome to the show!" {
+		t.Fatalf("texts = %q / %q", cues[0].Text, cues[1].Text)
+	}
+	if cues[0].StartMs != 1000 || cues[1].StartMs != 4000 || cues[1].EndMs != 6000 {
+		t.Fatalf("times = %+v", cues)
+	}
+	// "everyone" was heard as "every one": interpolated between its neighbours.
+	if cues[0].EndMs <= cues[0].StartMs || cues[0].EndMs > cues[1].StartMs {
+		t.Fatalf("first cue end = %d, want within (1000, 4000]", cues[0].EndMs)
+	}
+}
+
+// TestAlignSplitsLongSentences keeps cues within the subtitle line budget.
+func TestAlignSplitsLongSentences(t *testing.T) {
+	text := "one two three four five six seven eight nine ten eleven twelve thirteen fourteen fifteen sixteen seventeen"
+	cues, err := Align(text, []Cue{{StartMs: 0, EndMs: 17000, Text: text}})
+	if err != nil {
+		t.Fatalf("align: %v", err)
+	}
+	if len(cues) < 2 {
+		t.Fatalf("cues = %+v, want the sentence split", cues)
+	}
+	for _, cue := range cues {
+		if len([]rune(cue.Text)) > maxCueChars {
+			t.Fatalf("cue too long: %q", cue.Text)
+		}
+	}
+	if cues[len(cues)-1].EndMs != 17000 {
+		t.Fatalf("last end = %d, want 17000", cues[len(cues)-1].EndMs)
+	}
+}
+
+// TestAlignRejectsUnrelatedScript fails when the script is not what was said.
+func TestAlignRejectsUnrelatedScript(t *testing.T) {
+	_, err := Align("completely different prepared words here", []Cue{{StartMs: 0, EndMs: 1000, Text: "hello world"}})
+	if !errors.Is(err, ErrScriptMismatch) {
+		t.Fatalf("err = %v, want ErrScriptMismatch", err)
+	}
+	if _, err := Align(" — ", nil); err == nil {
+		t.Fatal("expected error for empty script")
+	}
+}
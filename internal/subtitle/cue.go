@@ -0,0 +1,89 @@
+package subtitle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cue is one timed subtitle line.
+type Cue struct {
+	// ID is the optional WebVTT cue identifier; SRT numbering is regenerated on output.
+	ID      string `json:"id,omitempty"`
+	StartMs int64  `json:"startMs"`
+	EndMs   int64  `json:"endMs"`
+	// Settings holds WebVTT cue settings such as "align:start line:90%".
+	Settings string `json:"settings,omitempty"`
+	Text     string `json:"text"`
+}
+
+// FormatSRT renders cues as SubRip text.
+func FormatSRT(cues []Cue) string {
+	var b strings.Builder
+	for i, cue := range cues {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n", i+1, formatTime(cue.StartMs, ","), formatTime(cue.EndMs, ","), cue.Text)
+	}
+	return b.String()
+}
+
+// FormatVTT renders cues as WebVTT text.
+func FormatVTT(cues []Cue) string {
+	return formatVTT("WEBVTT", cues)
+}
+
+// lrcClearGapMs is the silence after a cue that gets an empty LRC line, so
+// players stop showing the cue once it is over.
+const lrcClearGapMs = 2000
+
+// FormatLRC renders cues as LRC lyrics: one [mm:ss.xx] line per cue with its
+// lines joined, and an empty line where the cue ends before a long gap or the
+// end.
+func FormatLRC(cues []Cue) string {
+	var b strings.Builder
+	for i, cue := range cues {
+		fmt.Fprintf(&b, "[%s]%s\n", formatLRCTime(cue.StartMs), strings.Join(strings.Fields(cue.Text), " "))
+		if i == len(cues)-1 || cues[i+1].StartMs-cue.EndMs >= lrcClearGapMs {
+			fmt.Fprintf(&b, "[%s]\n", formatLRCTime(cue.EndMs))
+		}
+	}
+	return b.String()
+}
+
+// formatLRCTime renders milliseconds as LRC's mm:ss.xx; minutes keep counting
+// past an hour.
+func formatLRCTime(ms int64) string {
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d.%02d", ms/60000, ms/1000%60, ms%1000/10)
+}
+
+// formatVTT renders cues below a WebVTT header block.
+func formatVTT(header string, cues []Cue) string {
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString("\n")
+	for _, cue := range cues {
+		b.WriteString("\n")
+		if cue.ID != "" {
+			b.WriteString(cue.ID + "\n")
+		}
+		fmt.Fprintf(&b, "%s --> %s", formatTime(cue.StartMs, "."), formatTime(cue.EndMs, "."))
+		if cue.Settings != "" {
+			b.WriteString(" " + cue.Settings)
+		}
+		fmt.Fprintf(&b, "\n%s\n", cue.Text)
+	}
+	return b.String()
+}
+
+// formatTime renders milliseconds as HH:MM:SS plus the millisecond separator
+// the format expects ("," for SRT, "." for WebVTT).
+func formatTime(ms int64, sep string) string {
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
@@ -0,0 +1,34 @@
+package subtitle
+
+import "testing"
+
+// TestFormatSRTAndVTT checks numbering, separators, and headers.
+func TestFormatSRTAndVTT(t *testing.T) {
+	cues := []Cue{
+		{StartMs: 1500, EndMs: 4000, Text: "Hello."},
+		{StartMs: 3723004, EndMs: 3725000, Text: "Later."},
+	}
+
+	wantSRT := "1\n00:00:01,500 --> 00:00:04,000\nHello.\n\n2\n01:02:03,004 --> 01:02:05,000\nLater.\n"
+	if got := FormatSRT(cues); got != wantSRT {
+		t.Fatalf("srt =\n%s\nwant\n%s", got, wantSRT)
+	}
+	wantVTT := "WEBVTT\n\n00:00:01.500 --> 00:00:04.000\nHello.\n\n01:02:03.004 --> 01:02:05.000\nLater.\n"
+	if got := FormatVTT(cues); got != wantVTT {
+		t.Fatalf("vtt =\n%s\nwant\n%s", got, wantVTT)
+	}
+}
+
+// TestFormatLRC checks centisecond stamps, joined lines, and clearing lines
+// after long gaps and the last cue.
+func TestFormatLRC(t *testing.T) {
+	cues := []Cue{
+		{StartMs: 1500, EndMs: 4000, Text: "Hello\nthere."},
+		{StartMs: 4500, EndMs: 6789, Text: "Close behind."},
+		{StartMs: 3723004, EndMs: 3725000, Text: "Much later."},
+	}
+	want := "[00:01.50]Hello there.\n[00:04.50]Close behind.\n[00:06.78]\n[62:03.00]Much later.\n[62:05.00]\n"
+	if got := FormatLRC(cues); got != want {
+		t.Fatalf("lrc =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+package subtitle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"media-transcriber/internal/textenc"
+)
+
+// Format names a subtitle file format.
+type Format string
+
+const (
+	SRT Format = "srt"
+	VTT Format = "vtt"
+)
+
+// File is a parsed subtitle file.
+type File struct {
+	Format Format
+	// Header is the WebVTT header block with any STYLE/REGION blocks before the first cue.
+	Header string
+	Cues   []Cue
+}
+
+// Parse reads SRT or WebVTT text in UTF-8 or, with a byte order mark, UTF-16;
+// WebVTT is detected by its "WEBVTT" signature.
+// NOTE blocks between WebVTT cues are not kept.
+func Parse(data []byte) (File, error) {
+	blocks := splitBlocks(textenc.Decode(data))
+
+	file := File{Format: SRT}
+	if len(blocks) > 0 && strings.HasPrefix(blocks[0], "WEBVTT") {
+		file.Format = VTT
+		header := []string{blocks[0]}
+		blocks = blocks[1:]
+		for len(blocks) > 0 && !strings.Contains(blocks[0], "-->") {
+			if !strings.HasPrefix(blocks[0], "NOTE") {
+				header = append(header, blocks[0])
+			}
+			blocks = blocks[1:]
+		}
+		file.Header = strings.Join(header, "\n\n")
+	}
+
+	for _, block := range blocks {
+		lines := strings.Split(block, "\n")
+		timing := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			if file.Format == VTT {
+				continue
+			}
+			return File{}, fmt.Errorf("subtitle block without timing: %q", firstLine(block))
+		}
+
+		cue, err := parseTiming(lines[timing])
+		if err != nil {
+			return File{}, err
+		}
+		if file.Format == VTT && timing > 0 {
+			cue.ID = strings.Join(lines[:timing], " ")
+		}
+		cue.Text = strings.Join(lines[timing+1:], "\n")
+		file.Cues = append(file.Cues, cue)
+	}
+	if len(file.Cues) == 0 {
+		return File{}, fmt.Errorf("no subtitle cues found")
+	}
+	return file, nil
+}
+
+// Bytes renders the file back in its own format.
+func (f File) Bytes() []byte {
+	if f.Format == VTT {
+		header := f.Header
+		if header == "" {
+			header = "WEBVTT"
+		}
+		return []byte(formatVTT(header, f.Cues))
+	}
+	return []byte(FormatSRT(f.Cues))
+}
+
+// splitBlocks splits text on blank lines, dropping empty blocks.
+func splitBlocks(text string) []string {
+	var blocks []string
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				blocks = append(blocks, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, strings.TrimRight(line, " \t"))
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, strings.Join(current, "\n"))
+	}
+	return blocks
+}
+
+// parseTiming reads "start --> end [settings]".
+func parseTiming(line string) (Cue, error) {
+	startText, rest, _ := strings.Cut(line, "-->")
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return Cue{}, fmt.Errorf("invalid subtitle timing: %q", line)
+	}
+	start, err := parseTime(strings.TrimSpace(startText))
+	if err != nil {
+		return Cue{}, err
+	}
+	end, err := parseTime(fields[0])
+	if err != nil {
+		return Cue{}, err
+	}
+	return Cue{StartMs: start, EndMs: end, Settings: strings.Join(fields[1:], " ")}, nil
+}
+
+// parseTime reads [HH:]MM:SS(,|.)mmm into milliseconds.
+func parseTime(value string) (int64, error) {
+	clock, fraction, ok := strings.Cut(strings.ReplaceAll(value, ",", "."), ".")
+	parts := strings.Split(clock, ":")
+	if !ok || len(fraction) != 3 || len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid subtitle timestamp: %q", value)
+	}
+
+	var ms int64
+	for _, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid subtitle timestamp: %q", value)
+		}
+		ms = ms*60 + n
+	}
+	millis, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil || millis < 0 {
+		return 0, fmt.Errorf("invalid subtitle timestamp: %q", value)
+	}
+	return ms*1000 + millis, nil
+}
+
+// firstLine returns the first line of block for error messages.
+func firstLine(block string) string {
+	line, _, _ := strings.Cut(block, "\n")
+	return line
+}
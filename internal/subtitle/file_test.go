@@ -0,0 +1,94 @@
+package subtitle
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseSRTRoundTrip parses CRLF SubRip with multi-line cues and renders it back.
+func TestParseSRTRoundTrip(t *testing.T) {
+	input := "\ufeff1\r\n00:00:01,500 --> 00:00:04,000\r\nHello.\r\nSecond line\r\n\r\n2\r\n00:00:05,000 --> 00:00:06,250\r\nBye.\r\n"
+	file, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if file.Format != SRT || len(file.Cues) != 2 {
+		t.Fatalf("file = %+v", file)
+	}
+	if file.Cues[0].Text != "Hello.\nSecond line" || file.Cues[1].EndMs != 6250 {
+		t.Fatalf("cues = %+v", file.Cues)
+	}
+
+	want := "1\n00:00:01,500 --> 00:00:04,000\nHello.\nSecond line\n\n2\n00:00:05,000 --> 00:00:06,250\nBye.\n"
+	if got := string(file.Bytes()); got != want {
+		t.Fatalf("bytes =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestParseUTF16 reads subtitles exported as UTF-16 with a byte order mark.
+func TestParseUTF16(t *testing.T) {
+	input := []byte{0xFF, 0xFE}
+	for _, r := range "1\r\n00:00:01,000 --> 00:00:02,000\r\nHi\r\n" {
+		input = append(input, byte(r), 0)
+	}
+	file, err := Parse(input)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(file.Cues) != 1 || file.Cues[0].Text != "Hi" || file.Cues[0].StartMs != 1000 {
+		t.Fatalf("cues = %+v", file.Cues)
+	}
+}
+
+// TestParseVTTKeepsHeaderIDsAndSettings parses short timestamps and cue metadata.
+func TestParseVTTKeepsHeaderIDsAndSettings(t *testing.T) {
+	input := strings.Join([]string{
+		"WEBVTT - captions",
+		"",
+		"STYLE",
+		"::cue { color: yellow }",
+		"",
+		"NOTE dropped comment",
+		"",
+		"intro",
+		"00:01.000 --> 00:02.500 align:start line:90%",
+		"Hi",
+		"",
+		"01:00:00.000 --> 01:00:01.000",
+		"Later",
+		"",
+	}, "\n")
+
+	file, err := Parse([]byte(input))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if file.Format != VTT || file.Header != "WEBVTT - captions\n\nSTYLE\n::cue { color: yellow }" {
+		t.Fatalf("header = %q", file.Header)
+	}
+	first := file.Cues[0]
+	if first.ID != "intro" || first.StartMs != 1000 || first.EndMs != 2500 || first.Settings != "align:start line:90%" {
+		t.Fatalf("first cue = %+v", first)
+	}
+	if file.Cues[1].StartMs != 3600000 {
+		t.Fatalf("second cue = %+v", file.Cues[1])
+	}
+	if !strings.Contains(string(file.Bytes()), "intro\n00:00:01.000 --> 00:00:02.500 align:start line:90%\nHi\n") {
+		t.Fatalf("bytes =\n%s", file.Bytes())
+	}
+}
+
+// TestParseRejectsInvalidInput covers malformed timing and empty files.
+func TestParseRejectsInvalidInput(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"1\nHello\n",
+		"1\n00:00:01 --> 00:00:02,000\nHello\n",
+		"1\n00:00:01,000 -->\nHello\n",
+		"WEBVTT\n",
+	} {
+		if _, err := Parse([]byte(input)); err == nil {
+			t.Fatalf("expected error for %q", input)
+		}
+	}
+}
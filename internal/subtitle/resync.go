@@ -0,0 +1,43 @@
+package subtitle
+
+import (
+	"fmt"
+	"math"
+)
+
+// SyncPoint pairs a time as it appears in the subtitles with the time it should be.
+type SyncPoint struct {
+	FromMs int64 `json:"fromMs"`
+	ToMs   int64 `json:"toMs"`
+}
+
+// Shift moves every cue by offsetMs, clamping at zero.
+func Shift(cues []Cue, offsetMs int64) []Cue {
+	return mapTimes(cues, func(ms int64) int64 { return ms + offsetMs })
+}
+
+// Stretch linearly remaps cue times so first.FromMs lands on first.ToMs and
+// second.FromMs on second.ToMs, fixing both an offset and a frame-rate drift.
+func Stretch(cues []Cue, first, second SyncPoint) ([]Cue, error) {
+	if first.FromMs == second.FromMs {
+		return nil, fmt.Errorf("sync points must be at different subtitle times")
+	}
+	scale := float64(second.ToMs-first.ToMs) / float64(second.FromMs-first.FromMs)
+	if scale <= 0 {
+		return nil, fmt.Errorf("sync points would reverse the subtitle order")
+	}
+	return mapTimes(cues, func(ms int64) int64 {
+		return first.ToMs + int64(math.Round(float64(ms-first.FromMs)*scale))
+	}), nil
+}
+
+// mapTimes returns a copy of cues with start and end passed through fn.
+func mapTimes(cues []Cue, fn func(int64) int64) []Cue {
+	out := make([]Cue, len(cues))
+	for i, cue := range cues {
+		cue.StartMs = max(fn(cue.StartMs), 0)
+		cue.EndMs = max(fn(cue.EndMs), 0)
+		out[i] = cue
+	}
+	return out
+}
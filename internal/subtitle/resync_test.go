@@ -0,0 +1,50 @@
+package subtitle
+
+import "testing"
+
+// TestShift moves cues and clamps them at zero.
+func TestShift(t *testing.T) {
+	cues := []Cue{{StartMs: 500, EndMs: 1500, Text: "a"}, {StartMs: 2000, EndMs: 3000, Text: "b"}}
+
+	later := Shift(cues, 250)
+	if later[0].StartMs != 750 || later[1].EndMs != 3250 {
+		t.Fatalf("shift +250 = %+v", later)
+	}
+	earlier := Shift(cues, -1000)
+	if earlier[0].StartMs != 0 || earlier[0].EndMs != 500 || earlier[1].StartMs != 1000 {
+		t.Fatalf("shift -1000 = %+v", earlier)
+	}
+	if cues[0].StartMs != 500 {
+		t.Fatal("Shift modified its input")
+	}
+}
+
+// TestStretch maps two sync points and scales everything between and beyond.
+func TestStretch(t *testing.T) {
+	cues := []Cue{{StartMs: 1000, EndMs: 2000}, {StartMs: 11000, EndMs: 12000}, {StartMs: 21000, EndMs: 22000}}
+
+	// 25 fps subtitles on 23.976 fps video: drift grows over time.
+	got, err := Stretch(cues, SyncPoint{FromMs: 1000, ToMs: 1500}, SyncPoint{FromMs: 11000, ToMs: 12000})
+	if err != nil {
+		t.Fatalf("stretch: %v", err)
+	}
+	want := []int64{1500, 2550, 12000, 13050, 22500, 23550}
+	for i, cue := range got {
+		if cue.StartMs != want[2*i] || cue.EndMs != want[2*i+1] {
+			t.Fatalf("cue %d = %+v, want %d-%d", i, cue, want[2*i], want[2*i+1])
+		}
+	}
+
+	tests := []struct {
+		name          string
+		first, second SyncPoint
+	}{
+		{name: "same source time", first: SyncPoint{FromMs: 1000, ToMs: 0}, second: SyncPoint{FromMs: 1000, ToMs: 500}},
+		{name: "reversed", first: SyncPoint{FromMs: 1000, ToMs: 5000}, second: SyncPoint{FromMs: 2000, ToMs: 4000}},
+	}
+	for _, tt := range tests {
+		if _, err := Stretch(cues, tt.first, tt.second); err == nil {
+			t.Fatalf("%s: expected error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package subtitle
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultLineWidth is the widest subtitle line, in display columns, before it
+// is wrapped. Wide CJK characters take two columns.
+const DefaultLineWidth = 42
+
+// rtlMark is the right-to-left mark. Starting a line with it makes players
+// that take the base direction from the first strong character lay the line
+// out right to left, so trailing punctuation stays at the visual end.
+const rtlMark = "\u200f"
+
+// script groups languages by their line-breaking rules.
+type script int
+
+const (
+	scriptSpaced script = iota
+	// scriptCJK may break between any two characters, subject to kinsoku.
+	scriptCJK
+	// scriptRTL breaks at spaces and marks each line right to left.
+	scriptRTL
+)
+
+// Characters that must not start a line (closing punctuation, Japanese small
+// kana and the prolonged sound mark) or end one (opening brackets).
+const (
+	noLineStart = ".,!?;:%)]}»”’…、。，．！？：；）」』】〕〉》〙〗ー‥・ぁぃぅぇぉっゃゅょゎァィゥェォッャュョヮヵヶ،؛؟۔"
+	noLineEnd   = "([{«“‘（「『【〔〈《〘〖"
+)
+
+// Wrap returns cues with each line wider than width display columns broken
+// by the rules of language, an ISO 639-1 code; "" or "auto" use spaces only.
+func Wrap(cues []Cue, width int, language string) []Cue {
+	wrapped := make([]Cue, len(cues))
+	for i, cue := range cues {
+		cue.Text = WrapText(cue.Text, width, language)
+		wrapped[i] = cue
+	}
+	return wrapped
+}
+
+// WrapText breaks the lines of text that are wider than width. Existing line
+// breaks are kept, words are never split, and right-to-left lines are marked.
+func WrapText(text string, width int, language string) string {
+	s := scriptOf(language)
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimPrefix(line, rtlMark)
+		for _, wrapped := range wrapLine(line, width, s) {
+			if s == scriptRTL {
+				wrapped = rtlMark + wrapped
+			}
+			lines = append(lines, wrapped)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// DisplayWidth is the number of terminal columns s occupies: two for East
+// Asian wide and fullwidth characters, none for combining and format marks.
+func DisplayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		case isWide(r):
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// scriptOf maps a language code to its line-breaking rules.
+func scriptOf(language string) script {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if base, _, ok := strings.Cut(language, "-"); ok {
+		language = base
+	}
+	switch language {
+	case "zh", "ja", "yue":
+		return scriptCJK
+	case "ar", "he", "fa", "ur", "yi", "ps", "sd", "ug":
+		return scriptRTL
+	default:
+		return scriptSpaced
+	}
+}
+
+// token is an unbreakable run of text and whether a space precedes it.
+type token struct {
+	text  string
+	space bool
+}
+
+// wrapLine fills lines greedily with tokens up to width columns. A token
+// wider than width gets a line of its own.
+func wrapLine(line string, width int, s script) []string {
+	if width <= 0 || DisplayWidth(line) <= width {
+		return []string{line}
+	}
+	var lines []string
+	var current strings.Builder
+	currentWidth := 0
+	for _, tok := range tokenize(line, s) {
+		tokWidth := DisplayWidth(tok.text)
+		gap := 0
+		if tok.space && current.Len() > 0 {
+			gap = 1
+		}
+		if current.Len() > 0 && currentWidth+gap+tokWidth > width {
+			lines = append(lines, current.String())
+			current.Reset()
+			currentWidth, gap = 0, 0
+		}
+		if gap > 0 {
+			current.WriteByte(' ')
+		}
+		current.WriteString(tok.text)
+		currentWidth += gap + tokWidth
+	}
+	if current.Len() > 0 {
+		lines = append(lines, current.String())
+	}
+	return lines
+}
+
+// tokenize splits a line at spaces and, in CJK text, between wide characters,
+// then glues closing punctuation to the token before it and opening brackets
+// to the token after it so neither lands on the wrong side of a break.
+func tokenize(line string, s script) []token {
+	var raw []token
+	var word strings.Builder
+	space := false
+	flush := func() {
+		if word.Len() > 0 {
+			raw = append(raw, token{text: word.String(), space: space})
+			word.Reset()
+			space = false
+		}
+	}
+	for _, r := range line {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+			space = true
+		case s == scriptCJK && (isWide(r) || strings.ContainsRune(noLineStart+noLineEnd, r)):
+			flush()
+			raw = append(raw, token{text: string(r), space: space})
+			space = false
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+
+	var tokens []token
+	glueNext := false
+	for _, tok := range raw {
+		if len(tokens) > 0 && (glueNext || onlyRunesIn(tok.text, noLineStart)) {
+			last := &tokens[len(tokens)-1]
+			if tok.space {
+				last.text += " "
+			}
+			last.text += tok.text
+		} else {
+			tokens = append(tokens, tok)
+		}
+		glueNext = onlyRunesIn(tok.text, noLineEnd)
+	}
+	return tokens
+}
+
+// onlyRunesIn reports whether text is non-empty and made only of runes in set.
+func onlyRunesIn(text, set string) bool {
+	if text == "" {
+		return false
+	}
+	for _, r := range text {
+		if !strings.ContainsRune(set, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWide reports whether r is an East Asian wide or fullwidth character.
+func isWide(r rune) bool {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, // Hangul Jamo initials
+		r >= 0x2E80 && r <= 0x303E, // CJK radicals, symbols, and punctuation
+		r >= 0x3041 && r <= 0x33FF, // kana, bopomofo, CJK compatibility
+		r >= 0x3400 && r <= 0x4DBF, // CJK extension A
+		r >= 0x4E00 && r <= 0x9FFF, // CJK unified ideographs
+		r >= 0xA000 && r <= 0xA4CF, // Yi
+		r >= 0xAC00 && r <= 0xD7A3, // Hangul syllables
+		r >= 0xF900 && r <= 0xFAFF, // CJK compatibility ideographs
+		r >= 0xFE30 && r <= 0xFE4F, // CJK compatibility forms
+		r >= 0xFF00 && r <= 0xFF60, // fullwidth forms
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x1F300 && r <= 0x1F64F, // emoji
+		r >= 0x1F900 && r <= 0x1F9FF,
+		r >= 0x20000 && r <= 0x3FFFD: // CJK extensions B and later
+		return true
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package subtitle
+
+import "testing"
+
+// TestDisplayWidth counts wide characters twice and marks not at all.
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "Hello", want: 5},
+		{text: "日本語", want: 6},
+		{text: "한국어 ok", want: 9},
+		{text: "é", want: 1},
+		{text: "‏שלום", want: 4},
+	}
+	for _, tt := range tests {
+		if got := DisplayWidth(tt.text); got != tt.want {
+			t.Fatalf("DisplayWidth(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestWrapText covers spaced, CJK, and right-to-left line breaking.
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		language string
+		want     string
+	}{
+		{name: "fits", text: "Short line.", width: 20, language: "en", want: "Short line."},
+		{name: "spaces", text: "The quick brown fox jumps over the lazy dog", width: 20, language: "en", want: "The quick brown fox\njumps over the lazy\ndog"},
+		{name: "detached punctuation", text: "Vraiment incroyable !", width: 19, language: "fr", want: "Vraiment\nincroyable !"},
+		{name: "existing breaks kept", text: "One\nTwo three four", width: 9, want: "One\nTwo three\nfour"},
+		{name: "long word", text: "a supercalifragilistic b", width: 10, want: "a\nsupercalifragilistic\nb"},
+		{name: "cjk by width", text: "今日はとても良い天気ですね", width: 10, language: "ja", want: "今日はとて\nも良い天気\nですね"},
+		{name: "kinsoku closing", text: "晴れです。", width: 8, language: "ja", want: "晴れで\nす。"},
+		{name: "kinsoku opening", text: "ああ「はい」", width: 6, language: "ja", want: "ああ\n「は\nい」"},
+		{name: "cjk keeps latin words", text: "我们用GPU加速", width: 6, language: "zh", want: "我们用\nGPU加\n速"},
+		{name: "rtl marked", text: "مرحبا بكم في البرنامج؟", width: 12, language: "ar", want: "‏مرحبا بكم في\n‏البرنامج؟"},
+		{name: "rtl idempotent", text: "‏שלום", width: 12, language: "he", want: "‏שלום"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapText(tt.text, tt.width, tt.language); got != tt.want {
+				t.Fatalf("WrapText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package sysinfo
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// memoryStats reads total memory via sysctl; available memory is estimated from vm_stat.
+func memoryStats() (total, available uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "sysctl", "-n", "hw.memsize").Output()
+	if err == nil {
+		total, _ = strconv.ParseUint(strings.TrimSpace(string(output)), 10, 64)
+	}
+
+	output, err = exec.CommandContext(ctx, "vm_stat").Output()
+	if err == nil {
+		available = parseVMStat(string(output))
+	}
+	return total, available
+}
+
+// parseVMStat sums free, inactive, and speculative pages.
+func parseVMStat(output string) uint64 {
+	pageSize := uint64(4096)
+	var pages uint64
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "page size of") {
+			for _, field := range strings.Fields(line) {
+				if n, err := strconv.ParseUint(field, 10, 64); err == nil {
+					pageSize = n
+				}
+			}
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(name) {
+		case "Pages free", "Pages inactive", "Pages speculative":
+			n, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(value), "."), 10, 64)
+			if err == nil {
+				pages += n
+			}
+		}
+	}
+	return pages * pageSize
+}
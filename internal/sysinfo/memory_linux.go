@@ -0,0 +1,40 @@
+package sysinfo
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// memoryStats reads total and available memory from /proc/meminfo.
+func memoryStats() (total, available uint64) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, 0
+	}
+	defer file.Close()
+
+	return parseMeminfo(bufio.NewScanner(file))
+}
+
+// parseMeminfo extracts MemTotal and MemAvailable (reported in kB).
+func parseMeminfo(scanner *bufio.Scanner) (total, available uint64) {
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = value << 10
+		case "MemAvailable:":
+			available = value << 10
+		}
+	}
+	return total, available
+}
@@ -0,0 +1,19 @@
+package sysinfo
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+// TestParseMeminfo reads MemTotal and MemAvailable in bytes.
+func TestParseMeminfo(t *testing.T) {
+	input := "MemTotal:       16384000 kB\nMemFree:         1000 kB\nMemAvailable:    8192000 kB\n"
+	total, available := parseMeminfo(bufio.NewScanner(strings.NewReader(input)))
+	if total != 16384000<<10 {
+		t.Fatalf("total = %d", total)
+	}
+	if available != 8192000<<10 {
+		t.Fatalf("available = %d", available)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !windows
+
+package sysinfo
+
+// memoryStats is not implemented on this platform.
+func memoryStats() (total, available uint64) {
+	return 0, 0
+}
@@ -0,0 +1,153 @@
+// Package sysinfo detects hardware resources relevant to model selection.
+package sysinfo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os/exec"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gpuProbeTimeout bounds external GPU query commands.
+const gpuProbeTimeout = 5 * time.Second
+
+// GPU describes one detected accelerator. Memory values are 0 when unknown.
+type GPU struct {
+	Index            int    `json:"index"`
+	Name             string `json:"name"`
+	Vendor           string `json:"vendor"`
+	TotalMemoryBytes uint64 `json:"totalMemoryBytes,omitempty"`
+	FreeMemoryBytes  uint64 `json:"freeMemoryBytes,omitempty"`
+}
+
+// Info summarizes host resources. Memory values are 0 when unknown.
+type Info struct {
+	OS                   string `json:"os"`
+	Arch                 string `json:"arch"`
+	CPUs                 int    `json:"cpus"`
+	TotalMemoryBytes     uint64 `json:"totalMemoryBytes,omitempty"`
+	AvailableMemoryBytes uint64 `json:"availableMemoryBytes,omitempty"`
+	GPUs                 []GPU  `json:"gpus,omitempty"`
+}
+
+// Detect gathers CPU, memory, and GPU information for the current host.
+func Detect() Info {
+	total, available := Memory()
+	return Info{
+		OS:                   goruntime.GOOS,
+		Arch:                 goruntime.GOARCH,
+		CPUs:                 goruntime.NumCPU(),
+		TotalMemoryBytes:     total,
+		AvailableMemoryBytes: available,
+		GPUs:                 DetectGPUs(),
+	}
+}
+
+// Memory returns total and available system memory in bytes, 0 when unknown.
+func Memory() (total, available uint64) {
+	return memoryStats()
+}
+
+// HasGPU reports whether any accelerator usable by whisper.cpp was found.
+func (i Info) HasGPU() bool {
+	return len(i.GPUs) > 0
+}
+
+// DetectGPUs lists NVIDIA GPUs via nvidia-smi and the integrated GPU on Apple silicon.
+func DetectGPUs() []GPU {
+	gpus := detectNvidiaGPUs()
+	if goruntime.GOOS == "darwin" && goruntime.GOARCH == "arm64" {
+		gpus = append(gpus, GPU{Index: len(gpus), Name: "Apple silicon GPU (Metal)", Vendor: "apple"})
+	}
+	return gpus
+}
+
+func detectNvidiaGPUs() []GPU {
+	if _, err := exec.LookPath("nvidia-smi"); err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gpuProbeTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(
+		ctx,
+		"nvidia-smi",
+		"--query-gpu=index,name,memory.total,memory.free",
+		"--format=csv,noheader,nounits",
+	).Output()
+	if err != nil {
+		return nil
+	}
+	return parseNvidiaSMI(string(output))
+}
+
+// parseNvidiaSMI parses "index, name, totalMiB, freeMiB" CSV rows.
+func parseNvidiaSMI(output string) []GPU {
+	var gpus []GPU
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ",")
+		if len(fields) < 4 {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			continue
+		}
+		total, _ := strconv.ParseUint(strings.TrimSpace(fields[2]), 10, 64)
+		free, _ := strconv.ParseUint(strings.TrimSpace(fields[3]), 10, 64)
+		gpus = append(gpus, GPU{
+			Index:            index,
+			Name:             strings.TrimSpace(fields[1]),
+			Vendor:           "nvidia",
+			TotalMemoryBytes: total << 20,
+			FreeMemoryBytes:  free << 20,
+		})
+	}
+	return gpus
+}
+
+// SelectGPU finds the GPU a setting names: its index as listed by nvidia-smi,
+// or else a case-insensitive part of its name such as "3090" that matches
+// exactly one GPU.
+func SelectGPU(gpus []GPU, selector string) (GPU, error) {
+	selector = strings.TrimSpace(selector)
+	if index, err := strconv.Atoi(selector); err == nil {
+		for _, gpu := range gpus {
+			if gpu.Index == index {
+				return gpu, nil
+			}
+		}
+	}
+
+	var matches []GPU
+	for _, gpu := range gpus {
+		if strings.Contains(strings.ToLower(gpu.Name), strings.ToLower(selector)) {
+			matches = append(matches, gpu)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return GPU{}, fmt.Errorf("no GPU matches %q; detected: %s", selector, DescribeGPUs(gpus))
+	default:
+		return GPU{}, fmt.Errorf("%q matches %d GPUs, choose one by index; detected: %s", selector, len(matches), DescribeGPUs(gpus))
+	}
+}
+
+// DescribeGPUs lists GPUs as "index: name" pairs, or "none".
+func DescribeGPUs(gpus []GPU) string {
+	if len(gpus) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(gpus))
+	for _, gpu := range gpus {
+		parts = append(parts, fmt.Sprintf("%d: %s", gpu.Index, gpu.Name))
+	}
+	return strings.Join(parts, ", ")
+}
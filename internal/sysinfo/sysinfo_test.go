@@ -0,0 +1,58 @@
+package sysinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseNvidiaSMI parses nvidia-smi CSV output.
+func TestParseNvidiaSMI(t *testing.T) {
+	gpus := parseNvidiaSMI("0, NVIDIA GeForce RTX 3090, 24576, 24000\n1, NVIDIA T400, 2048, 1500\nbad line\n")
+	if len(gpus) != 2 {
+		t.Fatalf("gpus = %d, want 2", len(gpus))
+	}
+	if gpus[0].Name != "NVIDIA GeForce RTX 3090" || gpus[0].TotalMemoryBytes != 24576<<20 {
+		t.Fatalf("unexpected gpu: %+v", gpus[0])
+	}
+	if gpus[1].Index != 1 || gpus[1].FreeMemoryBytes != 1500<<20 {
+		t.Fatalf("unexpected gpu: %+v", gpus[1])
+	}
+}
+
+// TestSelectGPU picks a GPU by index or by a unique part of its name.
+func TestSelectGPU(t *testing.T) {
+	gpus := []GPU{
+		{Index: 0, Name: "NVIDIA GeForce RTX 3060"},
+		{Index: 1, Name: "NVIDIA GeForce RTX 3090"},
+	}
+	tests := []struct {
+		name      string
+		selector  string
+		wantIndex int
+		wantErr   string
+	}{
+		{name: "index", selector: "1", wantIndex: 1},
+		{name: "name", selector: " rtx 3090 ", wantIndex: 1},
+		{name: "model number", selector: "3060", wantIndex: 0},
+		{name: "unknown index", selector: "2", wantErr: `no GPU matches "2"; detected: 0: NVIDIA GeForce RTX 3060, 1: NVIDIA GeForce RTX 3090`},
+		{name: "unknown name", selector: "4090", wantErr: `no GPU matches "4090"`},
+		{name: "ambiguous", selector: "geforce", wantErr: `"geforce" matches 2 GPUs`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gpu, err := SelectGPU(gpus, tt.selector)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SelectGPU() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || gpu.Index != tt.wantIndex {
+				t.Fatalf("SelectGPU() = %+v, %v, want index %d", gpu, err, tt.wantIndex)
+			}
+		})
+	}
+	if got := DescribeGPUs(nil); got != "none" {
+		t.Fatalf("DescribeGPUs(nil) = %q", got)
+	}
+}
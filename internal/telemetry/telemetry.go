@@ -0,0 +1,126 @@
+// Package telemetry builds the anonymous usage report and sends it to the
+// configured endpoint. Reports only carry coarse counts and categories.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// Schema is the report format version, bumped whenever fields change.
+const Schema = 1
+
+// Environment describes the running build and configuration.
+type Environment struct {
+	AppVersion string
+	OS         string
+	Arch       string
+	ModelPath  string
+}
+
+// Build summarizes history entries finished in [since, now) for env. The
+// period is widened to whole UTC days so exact job times are not revealed.
+func Build(entries []domain.HistoryEntry, since, now time.Time, env Environment) domain.TelemetryReport {
+	report := domain.TelemetryReport{
+		Schema:      Schema,
+		AppVersion:  env.AppVersion,
+		OS:          env.OS,
+		Arch:        env.Arch,
+		ModelClass:  ModelClass(env.ModelPath),
+		PeriodStart: since.UTC().Truncate(24 * time.Hour),
+		PeriodEnd:   now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour),
+	}
+	for _, entry := range entries {
+		if entry.FinishedAt.Before(since) || !entry.FinishedAt.Before(now) {
+			continue
+		}
+		switch entry.Status {
+		case domain.JobStatusDone:
+			report.JobsDone++
+		case domain.JobStatusCancelled:
+			report.JobsCancelled++
+		case domain.JobStatusFailed:
+			report.JobsFailed++
+			if report.Failures == nil {
+				report.Failures = map[string]int{}
+			}
+			report.Failures[FailureCategory(entry)]++
+		}
+	}
+	return report
+}
+
+// modelClasses are the size classes of whisper models, matched in order
+// against the model file name so "large-v3-turbo" is large, not tiny.
+var modelClasses = []string{"large", "turbo", "medium", "small", "base", "tiny"}
+
+// ModelClass reduces a model path to its size class: "tiny", "base",
+// "small", "medium", or "large"; "custom" for other names, "none" when unset.
+func ModelClass(path string) string {
+	if strings.TrimSpace(path) == "" {
+		return "none"
+	}
+	name := strings.ToLower(filepath.Base(path))
+	for _, class := range modelClasses {
+		if strings.Contains(name, class) {
+			if class == "turbo" {
+				return "large"
+			}
+			return class
+		}
+	}
+	return "custom"
+}
+
+// FailureCategory reduces a failed job to where it failed, without any of
+// the error text: "timeout", "remote", a pipeline stage, or "other".
+func FailureCategory(entry domain.HistoryEntry) string {
+	switch {
+	case strings.Contains(entry.Error, "timed out"):
+		return "timeout"
+	case entry.Worker != "":
+		return "remote"
+	}
+	stage, _, _ := strings.Cut(entry.Error, ":")
+	switch stage {
+	case "validation", "preprocessing", "transcribing", "exporting":
+		return stage
+	default:
+		return "other"
+	}
+}
+
+// Send posts report as JSON to url; a nil client uses http.DefaultClient.
+func Send(ctx context.Context, client *http.Client, url string, report domain.TelemetryReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "media-transcriber")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestModelClass reduces model paths to size classes.
+func TestModelClass(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "", want: "none"},
+		{path: "/models/ggml-base.en.bin", want: "base"},
+		{path: "/models/ggml-large-v3-turbo-q5_0.bin", want: "large"},
+		{path: "/models/ggml-small.bin", want: "small"},
+		{path: "/models/my-finetune.gguf", want: "custom"},
+	}
+	for _, tt := range tests {
+		if got := ModelClass(tt.path); got != tt.want {
+			t.Errorf("ModelClass(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+// TestBuildCountsJobsWithoutDetails counts jobs in the period by status and
+// failure category and leaves names and error text out of the report.
+func TestBuildCountsJobsWithoutDetails(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	since := now.Add(-7 * 24 * time.Hour)
+	entries := []domain.HistoryEntry{
+		{InputPath: "/home/ann/secret-interview.mp3", Status: domain.JobStatusDone, FinishedAt: now.Add(-time.Hour)},
+		{Status: domain.JobStatusDone, FinishedAt: now.Add(-8 * 24 * time.Hour)},
+		{Status: domain.JobStatusCancelled, FinishedAt: now.Add(-2 * time.Hour)},
+		{Status: domain.JobStatusFailed, Error: "transcribing: whisper.cpp transcription failed (cmd=whisper-cli -f /home/ann/a.wav exit=1)", FinishedAt: now.Add(-3 * time.Hour)},
+		{Status: domain.JobStatusFailed, Error: "preprocessing: ffmpeg audio conversion timed out", FinishedAt: now.Add(-4 * time.Hour)},
+		{Status: domain.JobStatusFailed, Error: "connection refused", Worker: "http://gpu-box:8080", FinishedAt: now.Add(-5 * time.Hour)},
+	}
+
+	report := Build(entries, since, now, Environment{AppVersion: "v1.2.0", OS: "linux", Arch: "amd64", ModelPath: "/home/ann/ggml-medium.bin"})
+	want := domain.TelemetryReport{
+		Schema: Schema, AppVersion: "v1.2.0", OS: "linux", Arch: "amd64", ModelClass: "medium",
+		PeriodStart: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC),
+		JobsDone:    1, JobsFailed: 3, JobsCancelled: 1,
+		Failures: map[string]int{"transcribing": 1, "timeout": 1, "remote": 1},
+	}
+	got, _ := json.Marshal(report)
+	wantJSON, _ := json.Marshal(want)
+	if string(got) != string(wantJSON) {
+		t.Fatalf("report = %s\nwant     %s", got, wantJSON)
+	}
+	for _, leak := range []string{"ann", "secret", "gpu-box", "whisper-cli"} {
+		if strings.Contains(string(got), leak) {
+			t.Fatalf("report leaks %q: %s", leak, got)
+		}
+	}
+}
+
+// TestSendPostsJSON delivers the report body and reports server errors.
+func TestSendPostsJSON(t *testing.T) {
+	var received domain.TelemetryReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" || r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := Send(context.Background(), nil, server.URL, domain.TelemetryReport{Schema: Schema, JobsDone: 4}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if received.JobsDone != 4 {
+		t.Fatalf("received = %+v", received)
+	}
+	if err := Send(context.Background(), nil, server.URL+"/fail", domain.TelemetryReport{}); err == nil {
+		t.Fatal("send should fail when the server rejects the report")
+	}
+}
@@ -0,0 +1,93 @@
+// Package textenc encodes exported text files with a configured byte order
+// mark, UTF-16, and line endings, and decodes them back to UTF-8.
+package textenc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"media-transcriber/internal/domain"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// Encode converts text, which may use either line ending, to the given
+// encoding and line endings.
+func Encode(text string, encoding domain.TextEncoding, lineEnding domain.LineEnding) []byte {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if lineEnding == domain.LineEndingCRLF {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	switch encoding {
+	case domain.TextEncodingUTF8BOM:
+		return append(append([]byte{}, utf8BOM...), text...)
+	case domain.TextEncodingUTF16LE:
+		units := utf16.Encode([]rune(text))
+		out := make([]byte, len(utf16LEBOM), len(utf16LEBOM)+2*len(units))
+		copy(out, utf16LEBOM)
+		for _, unit := range units {
+			out = binary.LittleEndian.AppendUint16(out, unit)
+		}
+		return out
+	default:
+		return []byte(text)
+	}
+}
+
+// Decode returns the text of data as UTF-8 with "\n" line endings, honouring
+// a UTF-8 or UTF-16 byte order mark.
+func Decode(data []byte) string {
+	return strings.ReplaceAll(decode(data), "\r\n", "\n")
+}
+
+// Detect reports the encoding and line ending of data, so a rewritten file
+// can keep them. UTF-16 big endian is reported as little endian.
+func Detect(data []byte) (domain.TextEncoding, domain.LineEnding) {
+	encoding := domain.TextEncodingUTF8
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		encoding = domain.TextEncodingUTF8BOM
+	case bytes.HasPrefix(data, utf16LEBOM), bytes.HasPrefix(data, utf16BEBOM):
+		encoding = domain.TextEncodingUTF16LE
+	}
+	lineEnding := domain.LineEndingLF
+	if strings.Contains(decode(data), "\r\n") {
+		lineEnding = domain.LineEndingCRLF
+	}
+	return encoding, lineEnding
+}
+
+// decode converts data to UTF-8 by its byte order mark, keeping line endings.
+func decode(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		return string(data[len(utf8BOM):])
+	case bytes.HasPrefix(data, utf16LEBOM):
+		return decodeUTF16(data[len(utf16LEBOM):], binary.LittleEndian)
+	case bytes.HasPrefix(data, utf16BEBOM):
+		return decodeUTF16(data[len(utf16BEBOM):], binary.BigEndian)
+	default:
+		return string(data)
+	}
+}
+
+// decodeUTF16 decodes UTF-16 code units in the given byte order; a trailing
+// odd byte becomes a replacement character.
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		units = append(units, order.Uint16(data[i:]))
+	}
+	text := string(utf16.Decode(units))
+	if len(data)%2 == 1 {
+		text += string(utf8.RuneError)
+	}
+	return text
+}
@@ -0,0 +1,47 @@
+package textenc
+
+import (
+	"bytes"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestEncodeRoundTrip checks each encoding's bytes and that Decode and Detect
+// recover the text and options.
+func TestEncodeRoundTrip(t *testing.T) {
+	text := "1\n00:00:00,000 --> 00:00:01,000\nПривет\n"
+	tests := []struct {
+		name       string
+		encoding   domain.TextEncoding
+		lineEnding domain.LineEnding
+		prefix     []byte
+	}{
+		{name: "utf8", prefix: []byte("1\n00")},
+		{name: "utf8 bom crlf", encoding: domain.TextEncodingUTF8BOM, lineEnding: domain.LineEndingCRLF, prefix: []byte("\xEF\xBB\xBF1\r\n")},
+		{name: "utf16le", encoding: domain.TextEncodingUTF16LE, prefix: []byte{0xFF, 0xFE, '1', 0, '\n', 0}},
+		{name: "utf16le crlf", encoding: domain.TextEncodingUTF16LE, lineEnding: domain.LineEndingCRLF, prefix: []byte{0xFF, 0xFE, '1', 0, '\r', 0, '\n', 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := Encode(text, tt.encoding, tt.lineEnding)
+			if !bytes.HasPrefix(data, tt.prefix) {
+				t.Fatalf("encoded = % x, want prefix % x", data[:min(len(data), 12)], tt.prefix)
+			}
+			if got := Decode(data); got != text {
+				t.Fatalf("decoded = %q, want %q", got, text)
+			}
+			encoding, lineEnding := Detect(data)
+			if encoding != tt.encoding || lineEnding != tt.lineEnding {
+				t.Fatalf("detected %q/%q, want %q/%q", encoding, lineEnding, tt.encoding, tt.lineEnding)
+			}
+		})
+	}
+}
+
+// TestDecodeUTF16BigEndian reads files written by other tools in UTF-16 BE.
+func TestDecodeUTF16BigEndian(t *testing.T) {
+	if got := Decode([]byte{0xFE, 0xFF, 0, 'h', 0, 'i', 0, '\r', 0, '\n'}); got != "hi\n" {
+		t.Fatalf("decoded = %q", got)
+	}
+}
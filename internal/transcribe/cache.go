@@ -0,0 +1,235 @@
+package transcribe
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PreprocessCache keeps preprocessed 16 kHz mono WAVs keyed by the SHA-256 of
+// the input file's content, so re-running a file with another model skips
+// ffmpeg. Each entry is <key>.wav plus <key>.json metadata; the least recently
+// used entries are evicted once the WAVs exceed the size limit. Jobs run one
+// at a time, so the cache does no locking of its own.
+type PreprocessCache struct {
+	dir      string
+	maxBytes int64
+}
+
+// cacheMeta is the metadata stored beside a cached WAV.
+type cacheMeta struct {
+	DurationSeconds float64 `json:"durationSeconds"`
+	// Language is whisper's detected language, empty until a run with
+	// automatic language detection reported one.
+	Language string `json:"language,omitempty"`
+}
+
+// NewPreprocessCache returns a cache in dir holding at most maxBytes of audio.
+func NewPreprocessCache(dir string, maxBytes int64) *PreprocessCache {
+	return &PreprocessCache{dir: dir, maxBytes: maxBytes}
+}
+
+// Key hashes the content of inputPath.
+func (c *PreprocessCache) Key(ctx context.Context, inputPath string) (string, error) {
+	return ContentHash(ctx, inputPath)
+}
+
+// ContentHash returns the hex SHA-256 of the file at path. Hashing a large
+// file stops with ctx's error once ctx is done.
+func ContentHash(ctx context.Context, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, contextReader{ctx: ctx, r: file}); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// contextReader reads from r until ctx is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// Restore places the cached WAV of key at outPath and marks the entry as
+// recently used; ok is false on a miss.
+func (c *PreprocessCache) Restore(key, outPath string) (meta cacheMeta, ok bool) {
+	wavPath := c.path(key, ".wav")
+	data, err := os.ReadFile(c.path(key, ".json"))
+	if err != nil || json.Unmarshal(data, &meta) != nil {
+		return cacheMeta{}, false
+	}
+	if err := linkOrCopy(wavPath, outPath); err != nil {
+		return cacheMeta{}, false
+	}
+	now := time.Now()
+	_ = os.Chtimes(wavPath, now, now)
+	return meta, true
+}
+
+// Store adds the WAV at wavPath under key and evicts old entries over the limit.
+func (c *PreprocessCache) Store(key, wavPath string) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	info, err := os.Stat(wavPath)
+	if err != nil {
+		return err
+	}
+	if info.Size() > c.maxBytes {
+		return nil
+	}
+	if err := linkOrCopy(wavPath, c.path(key, ".wav")); err != nil {
+		return err
+	}
+	meta := cacheMeta{DurationSeconds: float64(max(info.Size()-PreprocessedHeaderBytes, 0)) / PreprocessedBytesPerSecond}
+	if err := c.writeMeta(key, meta); err != nil {
+		return err
+	}
+	return c.evict(key)
+}
+
+// SetLanguage records the detected language of a cached entry.
+func (c *PreprocessCache) SetLanguage(key, language string) error {
+	data, err := os.ReadFile(c.path(key, ".json"))
+	if err != nil {
+		return err
+	}
+	var meta cacheMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return err
+	}
+	meta.Language = language
+	return c.writeMeta(key, meta)
+}
+
+// Clear removes every cached entry.
+func (c *PreprocessCache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// writeMeta replaces the metadata file of key.
+func (c *PreprocessCache) writeMeta(key string, meta cacheMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path(key, ".json"), data, 0o644)
+}
+
+// CacheEntry describes one cached WAV.
+type CacheEntry struct {
+	Key      string
+	Bytes    int64
+	LastUsed time.Time
+}
+
+// Entries lists the cached WAVs, least recently used first. A cache that was
+// never written has no entries.
+func (c *PreprocessCache) Entries() ([]CacheEntry, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []CacheEntry
+	for _, entry := range dirEntries {
+		key, isWAV := strings.CutSuffix(entry.Name(), ".wav")
+		if !isWAV || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, CacheEntry{Key: key, Bytes: info.Size(), LastUsed: info.ModTime()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].LastUsed.Before(entries[j].LastUsed) })
+	return entries, nil
+}
+
+// Remove deletes the entry of key; a missing entry is not an error.
+func (c *PreprocessCache) Remove(key string) error {
+	if err := os.Remove(c.path(key, ".wav")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	_ = os.Remove(c.path(key, ".json"))
+	return nil
+}
+
+// evict removes least recently used entries until the WAVs fit in maxBytes,
+// never evicting keep.
+func (c *PreprocessCache) evict(keep string) error {
+	entries, err := c.Entries()
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, entry := range entries {
+		total += entry.Bytes
+	}
+	for _, entry := range entries {
+		if total <= c.maxBytes {
+			break
+		}
+		if entry.Key == keep {
+			continue
+		}
+		if err := c.Remove(entry.Key); err != nil {
+			return fmt.Errorf("evict %s: %w", entry.Key, err)
+		}
+		total -= entry.Bytes
+	}
+	return nil
+}
+
+// path returns the file of key with the given extension.
+func (c *PreprocessCache) path(key, ext string) string {
+	return filepath.Join(c.dir, key+ext)
+}
+
+// linkOrCopy hard-links src to dst, copying when the two are on different
+// file systems. The WAV is never modified in place, so sharing it is safe.
+func linkOrCopy(src, dst string) error {
+	_ = os.Remove(dst)
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
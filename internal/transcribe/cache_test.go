@@ -0,0 +1,106 @@
+package transcribe
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestPreprocessCacheStoreRestore round-trips a WAV and its metadata.
+func TestPreprocessCacheStoreRestore(t *testing.T) {
+	root := t.TempDir()
+	input := filepath.Join(root, "talk.mp4")
+	wav := filepath.Join(root, "job", "out.wav")
+	mustWriteFile(t, input, "media")
+	mustWriteFile(t, wav, string(make([]byte, PreprocessedHeaderBytes+3*PreprocessedBytesPerSecond)))
+
+	cache := NewPreprocessCache(filepath.Join(root, "cache"), 1<<20)
+	key, err := cache.Key(context.Background(), input)
+	if err != nil {
+		t.Fatalf("key: %v", err)
+	}
+	restored := filepath.Join(root, "next", "out.wav")
+	if err := os.MkdirAll(filepath.Dir(restored), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if _, ok := cache.Restore(key, restored); ok {
+		t.Fatal("expected miss before store")
+	}
+
+	if err := cache.Store(key, wav); err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	if err := cache.SetLanguage(key, "de"); err != nil {
+		t.Fatalf("set language: %v", err)
+	}
+	meta, ok := cache.Restore(key, restored)
+	if !ok {
+		t.Fatal("expected hit after store")
+	}
+	if meta.DurationSeconds != 3 || meta.Language != "de" {
+		t.Fatalf("meta = %+v", meta)
+	}
+	if info, err := os.Stat(restored); err != nil || info.Size() != PreprocessedHeaderBytes+3*PreprocessedBytesPerSecond {
+		t.Fatalf("restored wav: %v", err)
+	}
+
+	other := filepath.Join(root, "other.mp4")
+	mustWriteFile(t, other, "different media")
+	if otherKey, _ := cache.Key(context.Background(), other); otherKey == key {
+		t.Fatal("different content must hash to different keys")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ContentHash(ctx, input); !errors.Is(err, context.Canceled) {
+		t.Fatalf("hash after cancel error = %v, want context.Canceled", err)
+	}
+}
+
+// TestPreprocessCacheEvictsLeastRecentlyUsed keeps the cache under its size limit.
+func TestPreprocessCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "cache")
+	cache := NewPreprocessCache(dir, 350)
+
+	for i, key := range []string{"old", "used", "new"} {
+		// Separate sources: hard-linked entries would share modification times.
+		wav := filepath.Join(root, key+".wav")
+		mustWriteFile(t, wav, string(make([]byte, 100)))
+		if err := cache.Store(key, wav); err != nil {
+			t.Fatalf("store %s: %v", key, err)
+		}
+		stamp := time.Now().Add(time.Duration(i-10) * time.Minute)
+		if err := os.Chtimes(filepath.Join(dir, key+".wav"), stamp, stamp); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+	// Restoring "old" makes it the most recently used entry.
+	if _, ok := cache.Restore("old", filepath.Join(root, "restored.wav")); !ok {
+		t.Fatal("expected old to be cached")
+	}
+	newest := filepath.Join(root, "newest.wav")
+	mustWriteFile(t, newest, string(make([]byte, 100)))
+	if err := cache.Store("newest", newest); err != nil {
+		t.Fatalf("store newest: %v", err)
+	}
+
+	for key, want := range map[string]bool{"old": true, "used": false, "new": true, "newest": true} {
+		_, err := os.Stat(filepath.Join(dir, key+".wav"))
+		if got := err == nil; got != want {
+			t.Fatalf("%s cached = %v, want %v", key, got, want)
+		}
+	}
+
+	big := filepath.Join(root, "big.wav")
+	mustWriteFile(t, big, string(make([]byte, 400)))
+	if err := cache.Store("big", big); err != nil {
+		t.Fatalf("store big: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "big.wav")); !os.IsNotExist(err) {
+		t.Fatal("entries over the limit must not be cached")
+	}
+}
@@ -0,0 +1,113 @@
+package transcribe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// channelSpeakers labels the left and right channel of a split recording.
+var channelSpeakers = []string{"Speaker 1", "Speaker 2"}
+
+// channelAudioPaths returns the per-channel WAVs preprocessing writes into
+// the workspace for a split run, left channel first.
+func channelAudioPaths(tempDir string) []string {
+	return []string{filepath.Join(tempDir, "channel-1.wav"), filepath.Join(tempDir, "channel-2.wav")}
+}
+
+// buildChannelSplitArgs builds preprocessing CLI args that write the usual
+// mono mixdown to outPath and each stereo channel as its own 16k PCM WAV to
+// channelPaths, in one decoding pass.
+func buildChannelSplitArgs(inputPath, outPath string, channelPaths []string, hwaccel string, tempo float64) []string {
+	args := []string{
+		"-hide_banner",
+		"-nostdin",
+		"-y",
+	}
+	if hwaccel != "" {
+		args = append(args, "-hwaccel", hwaccel)
+	}
+	source := "[0:a:0]"
+	if tempo > 1 {
+		source += "atempo=" + formatTempo(tempo) + ","
+	}
+	args = append(args,
+		"-i", inputPath,
+		"-filter_complex", source+"asplit=2[mix][stereo];[stereo]channelsplit=channel_layout=stereo[left][right]",
+		"-map", "[mix]", "-ac", "1", "-ar", "16000", "-c:a", "pcm_s16le", outPath,
+	)
+	for i, label := range []string{"[left]", "[right]"} {
+		args = append(args, "-map", label, "-ar", "16000", "-c:a", "pcm_s16le", channelPaths[i])
+	}
+	return args
+}
+
+// transcribeChannels transcribes each channel WAV separately, labels its
+// segments with the channel's speaker, and writes the segments of both,
+// interleaved by start time, to textPath and textBase.json. It returns the
+// log of every whisper run so far, at least one.
+func (p *Pipeline) transcribeChannels(ctx context.Context, req Request, whisperPath, modelPath string, audioPaths []string, textPath, textBase, language string) ([]CommandLog, error) {
+	var logs []CommandLog
+	var merged whisperJSON
+	for i, audioPath := range audioPaths {
+		speaker := channelSpeakers[i]
+		channelReq := req
+		if req.OnSegment != nil {
+			channelReq.OnSegment = func(segment TranscriptSegment) {
+				segment.Speaker = speaker
+				req.OnSegment(segment)
+			}
+		}
+		if req.OnProgress != nil {
+			channelReq.OnProgress = func(stage string, fraction float64) {
+				req.OnProgress(stage, (float64(i)+fraction)/float64(len(audioPaths)))
+			}
+		}
+
+		base := strings.TrimSuffix(audioPath, filepath.Ext(audioPath))
+		log, err := p.transcribeAudio(ctx, channelReq, whisperPath, modelPath, audioPath, base+".txt", base, language)
+		logs = append(logs, log)
+		if err != nil {
+			return logs, err
+		}
+		data, err := p.readFile(base + ".json")
+		if err != nil {
+			return logs, fmt.Errorf("read %s segments: %w", speaker, err)
+		}
+		doc, err := decodeWhisperJSON(data)
+		if err != nil {
+			return logs, fmt.Errorf("read %s segments: %w", speaker, err)
+		}
+		for _, segment := range doc.Transcription {
+			segment.Speaker = speaker
+			merged.Transcription = append(merged.Transcription, segment)
+		}
+		if merged.Result.Language == "" {
+			merged.Result.Language = doc.Result.Language
+		}
+	}
+
+	sort.SliceStable(merged.Transcription, func(i, j int) bool {
+		return merged.Transcription[i].Offsets.From < merged.Transcription[j].Offsets.From
+	})
+	var text strings.Builder
+	for _, segment := range merged.Transcription {
+		if line := strings.TrimSpace(segment.Text); line != "" {
+			fmt.Fprintf(&text, "%s: %s\n", segment.Speaker, line)
+		}
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return logs, err
+	}
+	if err := p.writeFile(textBase+".json", data, 0o644); err != nil {
+		return logs, err
+	}
+	if err := p.writeFile(textPath, []byte(text.String()), 0o644); err != nil {
+		return logs, err
+	}
+	return logs, nil
+}
@@ -0,0 +1,98 @@
+package transcribe
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBuildChannelSplitArgs writes the mixdown and both channels in one pass,
+// speeding all of them up when asked.
+func TestBuildChannelSplitArgs(t *testing.T) {
+	args := buildChannelSplitArgs("/in.m4a", "/tmp/mix.wav", []string{"/tmp/l.wav", "/tmp/r.wav"}, "", 1.5)
+	want := "-hide_banner -nostdin -y -i /in.m4a " +
+		"-filter_complex [0:a:0]atempo=1.5,asplit=2[mix][stereo];[stereo]channelsplit=channel_layout=stereo[left][right] " +
+		"-map [mix] -ac 1 -ar 16000 -c:a pcm_s16le /tmp/mix.wav " +
+		"-map [left] -ar 16000 -c:a pcm_s16le /tmp/l.wav " +
+		"-map [right] -ar 16000 -c:a pcm_s16le /tmp/r.wav"
+	if got := strings.Join(args, " "); got != want {
+		t.Fatalf("args = %q\nwant %q", got, want)
+	}
+}
+
+// TestPipelineRunSplitChannelsInterleavesSpeakers transcribes each channel
+// and merges the labeled segments by start time into every export.
+func TestPipelineRunSplitChannelsInterleavesSpeakers(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "call.m4a")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	channelJSON := map[string]string{
+		"channel-1.wav": `{"result":{"language":"en"},"transcription":[` +
+			`{"offsets":{"from":0,"to":2000},"text":" Hi, thanks for calling."},` +
+			`{"offsets":{"from":5000,"to":7000},"text":" Sure, one moment."}]}`,
+		"channel-2.wav": `{"result":{"language":"en"},"transcription":[` +
+			`{"offsets":{"from":2500,"to":4500},"text":" I have a question."}]}`,
+	}
+	var transcribed []string
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		if name == "ffmpeg" {
+			for _, arg := range args {
+				if strings.HasSuffix(arg, ".wav") {
+					mustWriteFile(t, arg, "wav")
+				}
+			}
+			return commandResult{}, nil
+		}
+		audio := filepath.Base(argValue(args, "-f"))
+		transcribed = append(transcribed, audio)
+		doc, ok := channelJSON[audio]
+		if !ok {
+			return commandResult{ExitCode: 1}, fmt.Errorf("unexpected audio %s", audio)
+		}
+		base := argValue(args, "-of")
+		mustWriteFile(t, base+".txt", "unused")
+		mustWriteFile(t, base+".json", doc)
+		return commandResult{}, nil
+	}}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		Subtitles:     true,
+		InputPath:     inputPath,
+		ModelPath:     modelPath,
+		OutputDir:     outputDir,
+		SplitChannels: true,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if strings.Join(transcribed, ",") != "channel-1.wav,channel-2.wav" {
+		t.Fatalf("transcribed = %v", transcribed)
+	}
+	wantText := "Speaker 1: Hi, thanks for calling.\nSpeaker 2: I have a question.\nSpeaker 1: Sure, one moment."
+	if result.Transcript != wantText {
+		t.Fatalf("transcript = %q, want %q", result.Transcript, wantText)
+	}
+	if len(result.Segments) != 3 || result.Segments[1].Speaker != "Speaker 2" || result.Segments[1].Text != "I have a question." {
+		t.Fatalf("segments = %+v", result.Segments)
+	}
+	if result.Language != "en" || len(result.Logs) != 3 {
+		t.Fatalf("language = %q, logs = %d", result.Language, len(result.Logs))
+	}
+	srt, err := os.ReadFile(filepath.Join(outputDir, "call.srt"))
+	if err != nil {
+		t.Fatalf("read srt: %v", err)
+	}
+	if !strings.Contains(string(srt), "Speaker 2: I have a question.") {
+		t.Fatalf("srt = %s", srt)
+	}
+}
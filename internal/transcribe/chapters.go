@@ -0,0 +1,65 @@
+package transcribe
+
+import (
+	"fmt"
+	"strings"
+)
+
+// exportChapters splits the transcript at the input's chapter markers into
+// <name>.chapter-NN.txt files plus <name>.by-chapter.txt, the whole transcript
+// under chapter headings. Each segment belongs to the chapter it starts in.
+// Fewer than two chapters, or no timed segments, export nothing.
+func (p *Pipeline) exportChapters(req Request, segments []TranscriptSegment, textBase string) ([]string, error) {
+	chapters := req.Chapters
+	if len(chapters) < 2 || len(segments) == 0 {
+		return nil, nil
+	}
+
+	texts := splitByChapter(chapters, segments)
+	var combined strings.Builder
+	paths := make([]string, 0, len(chapters)+1)
+	for i, chapter := range chapters {
+		heading := ChapterHeading(chapter.Title, chapter.StartMs)
+		fmt.Fprintf(&combined, "## %s\n\n%s\n\n", heading, texts[i])
+
+		path := fmt.Sprintf("%s.chapter-%02d.txt", textBase, i+1)
+		if err := p.writeText(req, path, heading+"\n\n"+texts[i]+"\n"); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	combinedPath := textBase + ".by-chapter.txt"
+	if err := p.writeText(req, combinedPath, strings.TrimRight(combined.String(), "\n")+"\n"); err != nil {
+		return nil, err
+	}
+	return append(paths, combinedPath), nil
+}
+
+// ChapterHeading titles a chapter with its start time, e.g. "Prologue [00:00:00.000]".
+func ChapterHeading(title string, startMs int64) string {
+	return fmt.Sprintf("%s [%s]", title, formatTimestamp(startMs))
+}
+
+// splitByChapter joins the segment texts of each chapter, one per line.
+// Speech before the first marker counts toward the first chapter and speech
+// after the last marker's end toward the last.
+func splitByChapter(chapters []Chapter, segments []TranscriptSegment) []string {
+	lines := make([][]string, len(chapters))
+	current := 0
+	for _, segment := range segments {
+		if segment.Text == "" {
+			continue
+		}
+		for current+1 < len(chapters) && segment.StartMs >= chapters[current+1].StartMs {
+			current++
+		}
+		lines[current] = append(lines[current], segment.labeledText())
+	}
+
+	texts := make([]string, len(chapters))
+	for i := range lines {
+		texts[i] = strings.Join(lines[i], "\n")
+	}
+	return texts
+}
@@ -0,0 +1,66 @@
+package transcribe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestExportChaptersWritesPerChapterAndCombinedFiles assigns segments by start time.
+func TestExportChaptersWritesPerChapterAndCombinedFiles(t *testing.T) {
+	root := t.TempDir()
+	pipeline := NewPipeline()
+	chapters := []Chapter{
+		{Title: "Prologue", StartMs: 0, EndMs: 60_000},
+		{Title: "The Storm", StartMs: 60_000, EndMs: 3_700_000},
+	}
+	segments := []TranscriptSegment{
+		{StartMs: 0, EndMs: 5_000, Text: "It was late."},
+		{StartMs: 58_000, EndMs: 61_000, Text: "The wind rose."},
+		{StartMs: 60_000, EndMs: 64_000, Text: ""},
+		{StartMs: 62_000, EndMs: 66_000, Text: "Rain followed."},
+		{StartMs: 3_800_000, EndMs: 3_801_000, Text: "Credits."},
+	}
+
+	paths, err := pipeline.exportChapters(Request{Chapters: chapters}, segments, filepath.Join(root, "book"))
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	want := []string{"book.chapter-01.txt", "book.chapter-02.txt", "book.by-chapter.txt"}
+	if len(paths) != len(want) {
+		t.Fatalf("paths = %v", paths)
+	}
+	for i, name := range want {
+		if filepath.Base(paths[i]) != name {
+			t.Fatalf("paths[%d] = %s, want %s", i, paths[i], name)
+		}
+	}
+
+	second, err := os.ReadFile(paths[1])
+	if err != nil {
+		t.Fatalf("read chapter: %v", err)
+	}
+	if string(second) != "The Storm [00:01:00.000]\n\nRain followed.\nCredits.\n" {
+		t.Fatalf("chapter 2 = %q", second)
+	}
+	combined, err := os.ReadFile(paths[2])
+	if err != nil {
+		t.Fatalf("read combined: %v", err)
+	}
+	if !strings.HasPrefix(string(combined), "## Prologue [00:00:00.000]\n\nIt was late.\nThe wind rose.\n\n## The Storm") {
+		t.Fatalf("combined = %q", combined)
+	}
+}
+
+// TestExportChaptersSkipsUnchapteredInput writes nothing for a single chapter.
+func TestExportChaptersSkipsUnchapteredInput(t *testing.T) {
+	root := t.TempDir()
+	paths, err := NewPipeline().exportChapters(Request{Chapters: []Chapter{{Title: "Whole", EndMs: 1000}}}, []TranscriptSegment{{Text: "hi"}}, filepath.Join(root, "talk"))
+	if err != nil || paths != nil {
+		t.Fatalf("paths = %v, err = %v", paths, err)
+	}
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Fatalf("unexpected files: %v", entries)
+	}
+}
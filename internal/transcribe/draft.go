@@ -0,0 +1,47 @@
+package transcribe
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// runDraft transcribes the preprocessed audio with the request's draft model
+// into the workspace and returns the draft text. The draft only previews the
+// transcript, so the caller treats its errors as non-fatal.
+func (p *Pipeline) runDraft(ctx context.Context, req Request, whisperPath, audioPath, tempDir, language string) (string, CommandLog, error) {
+	modelPath, err := p.resolveModelPath(req.DraftModelPath)
+	if err != nil {
+		return "", CommandLog{}, fmt.Errorf("resolve draft model: %w", err)
+	}
+	draftBase := filepath.Join(tempDir, "draft")
+	args := append(buildWhisperArgs(modelPath, audioPath, draftBase, language), whisperPromptArgs(req.Terms)...)
+	args = append(args, whisperDeviceArgs(req.GPUDevice)...)
+	grammarArgs, err := p.grammarArgs(req.Grammar)
+	if err != nil {
+		return "", CommandLog{}, err
+	}
+	args = append(args, grammarArgs...)
+	cmdResult, runErr := p.runStage(ctx, req.TranscribeTimeout, commandOptions{
+		onLine:      outputForwarder(req.OnOutput, whisperPath),
+		lowPriority: req.LowPriority,
+		env:         whisperDeviceEnv(req.GPUDevice),
+	}, whisperPath, args...)
+	log := CommandLog{
+		Command:         whisperPath,
+		Args:            args,
+		ExitCode:        cmdResult.ExitCode,
+		Stdout:          cmdResult.Stdout,
+		Stderr:          cmdResult.Stderr,
+		PeakMemoryBytes: cmdResult.PeakMemoryBytes,
+	}
+	if runErr != nil {
+		return "", log, fmt.Errorf("draft transcription: %w", runErr)
+	}
+	content, err := p.readFile(draftBase + ".txt")
+	if err != nil {
+		return "", log, fmt.Errorf("read draft transcript: %w", err)
+	}
+	return strings.TrimSpace(string(content)), log, nil
+}
@@ -0,0 +1,93 @@
+package transcribe
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPipelineRunEmitsDraftBeforeFinalTranscript runs the draft model first,
+// hands its text to OnDraft, and keeps the draft out of the output directory.
+func TestPipelineRunEmitsDraftBeforeFinalTranscript(t *testing.T) {
+	tests := []struct {
+		name      string
+		draftErr  error
+		wantDraft []string
+	}{
+		{name: "draft succeeds", wantDraft: []string{"rough words"}},
+		{name: "draft fails", draftErr: errors.New("exit status 1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			inputPath := filepath.Join(root, "meeting.mp4")
+			outputDir := filepath.Join(root, "output")
+			mustWriteFile(t, inputPath, "media")
+			mustWriteFile(t, filepath.Join(root, "ggml-tiny.bin"), "model")
+			mustWriteFile(t, filepath.Join(root, "ggml-large.bin"), "model")
+
+			var models []string
+			runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+				if name == "ffmpeg" {
+					mustWriteFile(t, args[len(args)-1], "wav")
+					return commandResult{}, nil
+				}
+				model := filepath.Base(argValue(args, "-m"))
+				models = append(models, model)
+				if model == "ggml-tiny.bin" {
+					if tt.draftErr != nil {
+						return commandResult{ExitCode: 1}, tt.draftErr
+					}
+					mustWriteFile(t, argValue(args, "-of")+".txt", " rough words\n")
+					return commandResult{}, nil
+				}
+				mustWriteFile(t, argValue(args, "-of")+".txt", "final words")
+				return commandResult{}, nil
+			}}
+
+			var events []string
+			var drafts []string
+			pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+			result, err := pipeline.Run(context.Background(), Request{
+				InputPath:      inputPath,
+				ModelPath:      filepath.Join(root, "ggml-large.bin"),
+				DraftModelPath: filepath.Join(root, "ggml-tiny.bin"),
+				OutputDir:      outputDir,
+				OnDraft: func(text string) {
+					drafts = append(drafts, text)
+					events = append(events, "draft")
+				},
+				OnStage: func(stage string) { events = append(events, stage) },
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			defer result.Cleanup()
+
+			if strings.Join(models, ",") != "ggml-tiny.bin,ggml-large.bin" {
+				t.Fatalf("models = %v", models)
+			}
+			if strings.Join(drafts, "|") != strings.Join(tt.wantDraft, "|") {
+				t.Fatalf("drafts = %q, want %q", drafts, tt.wantDraft)
+			}
+			if len(drafts) > 0 && strings.Join(events, ",") != "preprocessing,transcribing,draft,exporting" {
+				t.Fatalf("events = %v", events)
+			}
+			if result.Transcript != "final words" || len(result.Logs) != 3 {
+				t.Fatalf("transcript = %q, logs = %d", result.Transcript, len(result.Logs))
+			}
+			entries, err := os.ReadDir(outputDir)
+			if err != nil {
+				t.Fatalf("read output dir: %v", err)
+			}
+			for _, entry := range entries {
+				if strings.HasPrefix(entry.Name(), "draft") {
+					t.Fatalf("draft written to output dir: %s", entry.Name())
+				}
+			}
+		})
+	}
+}
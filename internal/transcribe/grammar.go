@@ -0,0 +1,101 @@
+package transcribe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"media-transcriber/internal/domain"
+)
+
+// digitsGrammar allows numbers, optionally spaced and punctuated as whisper
+// writes dictated codes. The leading space matters: whisper's tokens start
+// with one.
+const digitsGrammar = `root ::= (" "? [0-9]+ [-.,]?)+`
+
+// Grammar constrains whisper's decoding to a GBNF grammar.
+type Grammar struct {
+	Mode domain.GrammarMode
+	// Vocabulary lists the phrases allowed by domain.GrammarCommands.
+	Vocabulary []string
+	// Path and Rule name the file and start rule of domain.GrammarFile; an
+	// empty rule is "root".
+	Path string
+	Rule string
+	// Penalty scales down disallowed tokens; zero keeps whisper's default.
+	Penalty float64
+}
+
+// grammarArgs returns the whisper flags applying grammar, or nil when it is
+// off. Generated grammars are passed inline, which whisper accepts in place
+// of a file path.
+func (p *Pipeline) grammarArgs(grammar Grammar) ([]string, error) {
+	var source, rule string
+	switch grammar.Mode {
+	case domain.GrammarOff:
+		return nil, nil
+	case domain.GrammarDigits:
+		source, rule = digitsGrammar, "root"
+	case domain.GrammarCommands:
+		commands := commandsGrammar(grammar.Vocabulary)
+		if commands == "" {
+			return nil, fmt.Errorf("the command grammar needs at least one phrase")
+		}
+		source, rule = commands, "root"
+	case domain.GrammarFile:
+		source = strings.TrimSpace(grammar.Path)
+		if source == "" {
+			return nil, fmt.Errorf("grammar file is not set")
+		}
+		if _, err := p.stat(source); err != nil {
+			return nil, fmt.Errorf("cannot read grammar file: %w", err)
+		}
+		rule = strings.TrimSpace(grammar.Rule)
+		if rule == "" {
+			rule = "root"
+		}
+	default:
+		return nil, fmt.Errorf("unknown grammar %q", grammar.Mode)
+	}
+	args := []string{"--grammar", source, "--grammar-rule", rule}
+	if grammar.Penalty > 0 {
+		args = append(args, "--grammar-penalty", strconv.FormatFloat(grammar.Penalty, 'f', -1, 64))
+	}
+	return args, nil
+}
+
+// commandsGrammar allows a sequence of the given phrases, each as written
+// and capitalized, with optional trailing punctuation; "" when there are none.
+func commandsGrammar(phrases []string) string {
+	var alternatives []string
+	seen := map[string]bool{}
+	for _, phrase := range phrases {
+		phrase = strings.Join(strings.Fields(phrase), " ")
+		if phrase == "" {
+			continue
+		}
+		for _, variant := range []string{phrase, capitalize(phrase)} {
+			if !seen[variant] {
+				seen[variant] = true
+				alternatives = append(alternatives, gbnfLiteral(variant))
+			}
+		}
+	}
+	if len(alternatives) == 0 {
+		return ""
+	}
+	return "root ::= (\" \"? phrase [.,?!]?)+\nphrase ::= " + strings.Join(alternatives, " | ")
+}
+
+// capitalize upper-cases the first letter of s, as whisper starts sentences.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// gbnfLiteral quotes s as a GBNF string literal.
+func gbnfLiteral(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,106 @@
+package transcribe
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestGrammarArgs builds whisper's grammar flags for each grammar mode.
+func TestGrammarArgs(t *testing.T) {
+	root := t.TempDir()
+	grammarPath := filepath.Join(root, "ivr.gbnf")
+	mustWriteFile(t, grammarPath, `menu ::= " one" | " two"`)
+
+	tests := []struct {
+		name    string
+		grammar Grammar
+		want    []string
+		wantErr string
+	}{
+		{name: "off"},
+		{name: "digits", grammar: Grammar{Mode: domain.GrammarDigits}, want: []string{"--grammar", digitsGrammar, "--grammar-rule", "root"}},
+		{
+			name:    "commands",
+			grammar: Grammar{Mode: domain.GrammarCommands, Vocabulary: []string{" yes ", "Yes", `say "agent"`}, Penalty: 50.5},
+			want: []string{"--grammar", "root ::= (\" \"? phrase [.,?!]?)+\nphrase ::= \"yes\" | \"Yes\" | \"say \\\"agent\\\"\" | \"Say \\\"agent\\\"\"",
+				"--grammar-rule", "root", "--grammar-penalty", "50.5"},
+		},
+		{name: "file", grammar: Grammar{Mode: domain.GrammarFile, Path: grammarPath, Rule: "menu"}, want: []string{"--grammar", grammarPath, "--grammar-rule", "menu"}},
+		{name: "file default rule", grammar: Grammar{Mode: domain.GrammarFile, Path: grammarPath}, want: []string{"--grammar", grammarPath, "--grammar-rule", "root"}},
+		{name: "no phrases", grammar: Grammar{Mode: domain.GrammarCommands, Vocabulary: []string{" "}}, wantErr: "at least one phrase"},
+		{name: "no file", grammar: Grammar{Mode: domain.GrammarFile}, wantErr: "grammar file is not set"},
+		{name: "missing file", grammar: Grammar{Mode: domain.GrammarFile, Path: filepath.Join(root, "gone.gbnf")}, wantErr: "cannot read grammar file"},
+		{name: "unknown", grammar: Grammar{Mode: "regex"}, wantErr: `unknown grammar "regex"`},
+	}
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", &fakeRunner{}, os.MkdirTemp, os.RemoveAll, os.Stat)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pipeline.grammarArgs(tt.grammar)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("grammarArgs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("grammarArgs() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestPipelineRunAppliesGrammar passes the grammar to whisper instead of the
+// warm server, and refuses a job whose grammar is unusable before running
+// anything.
+func TestPipelineRunAppliesGrammar(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "call.wav")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	var calls []string
+	var grammar string
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		calls = append(calls, name)
+		if name == "ffmpeg" {
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		}
+		grammar = argValue(args, "--grammar")
+		mustWriteFile(t, argValue(args, "-of")+".txt", "4 8 15 16 23 42")
+		return commandResult{}, nil
+	}}
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	req := Request{
+		InputPath: inputPath,
+		ModelPath: modelPath,
+		OutputDir: filepath.Join(root, "output"),
+		Grammar:   Grammar{Mode: domain.GrammarDigits},
+		Server:    NewWhisperServer(time.Minute),
+	}
+	result, err := pipeline.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+	if grammar != digitsGrammar || !reflect.DeepEqual(calls, []string{"ffmpeg", "whisper-cli"}) {
+		t.Fatalf("calls = %v with grammar %q, want whisper-cli with the digits grammar", calls, grammar)
+	}
+
+	calls = nil
+	req.Grammar = Grammar{Mode: domain.GrammarCommands}
+	_, err = pipeline.Run(context.Background(), req)
+	var pipelineErr *PipelineError
+	if !errors.As(err, &pipelineErr) || pipelineErr.Kind != domain.FailureWhisper || len(calls) != 0 {
+		t.Fatalf("Run() error = %v after calls %v, want a whisper failure before any command", err, calls)
+	}
+}
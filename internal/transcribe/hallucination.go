@@ -0,0 +1,169 @@
+package transcribe
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"media-transcriber/internal/domain"
+)
+
+const (
+	// minRepeatRun is how many consecutive identical segments make a
+	// repetition loop; every segment after the first of the run is suspect.
+	minRepeatRun = 3
+	// audioEndSlackMs tolerates segments ending slightly past the audio.
+	audioEndSlackMs = 1000
+)
+
+// Reasons a segment is suspected to be a hallucination.
+const (
+	reasonRepeated  = "repeated"
+	reasonOutro     = "outro phrase"
+	reasonPastAudio = "past end of audio"
+)
+
+// outroPhrases are whole-segment texts whisper is known to produce over
+// silence and music, learned from video subtitles, normalized by
+// normalizeSegmentText.
+var outroPhrases = map[string]bool{
+	"thanks for watching":                              true,
+	"thank you for watching":                           true,
+	"thanks for watching and see you next time":        true,
+	"please subscribe":                                 true,
+	"please like and subscribe":                        true,
+	"dont forget to like and subscribe":                true,
+	"subtitles by the amaraorg community":              true,
+	"transcription by castingwords":                    true,
+	"продолжение следует":                              true,
+	"спасибо за просмотр":                              true,
+	"субтитры сделал dimatorzok":                       true,
+	"untertitel der amaraorg-community":                true,
+	"sous-titres réalisés par la communauté damaraorg": true,
+}
+
+// Hallucination is a segment the hallucination filter flagged or removed.
+type Hallucination struct {
+	Segment TranscriptSegment `json:"segment"`
+	// Reason is "repeated", "outro phrase", or "past end of audio".
+	Reason string `json:"reason"`
+}
+
+// filterHallucinations applies the request's hallucination filter to
+// segments. It writes <name>.hallucinations.txt listing the suspects and, in
+// remove mode, rewrites the transcript at textPath without them, returning
+// the remaining segments. audioMs is the input's length, zero when unknown.
+func (p *Pipeline) filterHallucinations(req Request, segments []TranscriptSegment, textPath, textBase string, audioMs int64) ([]TranscriptSegment, []Hallucination, string, error) {
+	mode := req.HallucinationFilter
+	if mode != domain.HallucinationFilterFlag && mode != domain.HallucinationFilterRemove {
+		return segments, nil, "", nil
+	}
+	reportPath := textBase + ".hallucinations.txt"
+	suspects, reasons := detectHallucinations(segments, audioMs)
+	if len(suspects) == 0 {
+		// Drop a stale report from an earlier run of the same file.
+		_ = p.removeAll(reportPath)
+		return segments, nil, "", nil
+	}
+	if err := p.writeText(req, reportPath, buildHallucinationReport(req.InputPath, mode, suspects)); err != nil {
+		return nil, nil, reportPath, err
+	}
+	if mode == domain.HallucinationFilterFlag {
+		return segments, suspects, reportPath, nil
+	}
+
+	kept := make([]TranscriptSegment, 0, len(segments)-len(suspects))
+	for i, segment := range segments {
+		if reasons[i] == "" {
+			kept = append(kept, segment)
+		}
+	}
+	if err := p.writeText(req, textPath, segmentsText(kept)); err != nil {
+		return nil, nil, reportPath, err
+	}
+	return kept, suspects, reportPath, nil
+}
+
+// detectHallucinations returns the suspect segments in transcript order and,
+// per segment, the reason it is suspect or "".
+func detectHallucinations(segments []TranscriptSegment, audioMs int64) ([]Hallucination, []string) {
+	reasons := make([]string, len(segments))
+	for i := 0; i < len(segments); {
+		text := normalizeSegmentText(segments[i].Text)
+		run := i + 1
+		for run < len(segments) && text != "" && normalizeSegmentText(segments[run].Text) == text {
+			run++
+		}
+		if run-i >= minRepeatRun {
+			for j := i + 1; j < run; j++ {
+				reasons[j] = reasonRepeated
+			}
+		}
+		i = run
+	}
+
+	var suspects []Hallucination
+	for i, segment := range segments {
+		switch {
+		case reasons[i] != "":
+		case audioMs > 0 && segment.EndMs > audioMs+audioEndSlackMs:
+			reasons[i] = reasonPastAudio
+		case outroPhrases[normalizeSegmentText(segment.Text)]:
+			reasons[i] = reasonOutro
+		default:
+			continue
+		}
+		suspects = append(suspects, Hallucination{Segment: segment, Reason: reasons[i]})
+	}
+	return suspects, reasons
+}
+
+// normalizeSegmentText lowercases text and drops punctuation and extra
+// spaces, so "Thanks for watching!" and "thanks for watching" compare equal.
+func normalizeSegmentText(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-':
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// segmentsText renders segments one per line, as whisper writes the .txt.
+func segmentsText(segments []TranscriptSegment) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		if segment.Text != "" {
+			b.WriteString(segment.labeledText())
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// buildHallucinationReport lists suspect segments with timestamps and the
+// reason each was flagged or removed.
+func buildHallucinationReport(inputPath string, mode domain.HallucinationFilter, suspects []Hallucination) string {
+	action := "Flagged"
+	if mode == domain.HallucinationFilterRemove {
+		action = "Removed"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d suspected hallucinations\n", action, len(suspects))
+	fmt.Fprintf(&b, "Source: %s\n\n", filepath.Base(inputPath))
+	for _, suspect := range suspects {
+		fmt.Fprintf(&b, "[%s - %s] %-17s  %s\n",
+			formatTimestamp(suspect.Segment.StartMs), formatTimestamp(suspect.Segment.EndMs), suspect.Reason, suspect.Segment.labeledText())
+	}
+	return b.String()
+}
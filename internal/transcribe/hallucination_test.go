@@ -0,0 +1,118 @@
+package transcribe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestDetectHallucinations flags repetition loops, outro phrases, and
+// segments past the audio, and leaves ordinary speech alone.
+func TestDetectHallucinations(t *testing.T) {
+	segments := []TranscriptSegment{
+		{StartMs: 0, EndMs: 2000, Text: "Yes."},
+		{StartMs: 2000, EndMs: 3000, Text: "Yes."},
+		{StartMs: 3000, EndMs: 5000, Text: "Let's begin."},
+		{StartMs: 5000, EndMs: 7000, Text: "I'm sorry."},
+		{StartMs: 7000, EndMs: 9000, Text: "I'm sorry"},
+		{StartMs: 9000, EndMs: 11000, Text: "i'm  sorry!"},
+		{StartMs: 50000, EndMs: 55000, Text: "Thanks for watching!"},
+		{StartMs: 58000, EndMs: 64000, Text: "See you."},
+	}
+	suspects, reasons := detectHallucinations(segments, 60000)
+
+	wantReasons := []string{"", "", "", "", reasonRepeated, reasonRepeated, reasonOutro, reasonPastAudio}
+	if !reflect.DeepEqual(reasons, wantReasons) {
+		t.Fatalf("reasons = %q, want %q", reasons, wantReasons)
+	}
+	if len(suspects) != 4 || suspects[0].Segment.StartMs != 7000 || suspects[3].Segment.Text != "See you." {
+		t.Fatalf("suspects = %+v", suspects)
+	}
+}
+
+// TestPipelineRunHallucinationFilter reports suspects in flag mode and also
+// drops them from the transcript and subtitles in remove mode.
+func TestPipelineRunHallucinationFilter(t *testing.T) {
+	tests := []struct {
+		mode       domain.HallucinationFilter
+		transcript string
+		report     bool
+	}{
+		{mode: domain.HallucinationFilterOff, transcript: "Welcome back.\nThank you for watching."},
+		{mode: domain.HallucinationFilterFlag, transcript: "Welcome back.\nThank you for watching.", report: true},
+		{mode: domain.HallucinationFilterRemove, transcript: "Welcome back.", report: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			root := t.TempDir()
+			inputPath := filepath.Join(root, "episode.mp3")
+			modelPath := filepath.Join(root, "ggml-base.bin")
+			outputDir := filepath.Join(root, "output")
+			mustWriteFile(t, inputPath, "media")
+			mustWriteFile(t, modelPath, "model")
+
+			runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+				if name == "ffmpeg" {
+					mustWriteFile(t, args[len(args)-1], "wav")
+					return commandResult{}, nil
+				}
+				base := argValue(args, "-of")
+				mustWriteFile(t, base+".txt", "Welcome back.\nThank you for watching.\n")
+				mustWriteFile(t, base+".json", `{"transcription":[`+
+					`{"offsets":{"from":0,"to":2000},"text":" Welcome back."},`+
+					`{"offsets":{"from":30000,"to":32000},"text":" Thank you for watching."}]}`)
+				return commandResult{}, nil
+			}}
+
+			pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+			result, err := pipeline.Run(context.Background(), Request{
+				Subtitles:           true,
+				InputPath:           inputPath,
+				ModelPath:           modelPath,
+				OutputDir:           outputDir,
+				HallucinationFilter: tt.mode,
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			defer result.Cleanup()
+
+			if result.Transcript != tt.transcript {
+				t.Fatalf("transcript = %q, want %q", result.Transcript, tt.transcript)
+			}
+			written, err := os.ReadFile(result.TextPath)
+			if err != nil || strings.TrimSpace(string(written)) != tt.transcript {
+				t.Fatalf("transcript file = %q, %v", written, err)
+			}
+			srt, err := os.ReadFile(filepath.Join(outputDir, "episode.srt"))
+			if err != nil {
+				t.Fatalf("read srt: %v", err)
+			}
+			if strings.Contains(string(srt), "watching") == (tt.mode == domain.HallucinationFilterRemove) {
+				t.Fatalf("srt = %s", srt)
+			}
+
+			if !tt.report {
+				if result.HallucinationPath != "" || len(result.Hallucinations) != 0 {
+					t.Fatalf("unexpected report %q: %+v", result.HallucinationPath, result.Hallucinations)
+				}
+				return
+			}
+			if len(result.Hallucinations) != 1 || result.Hallucinations[0].Reason != reasonOutro {
+				t.Fatalf("hallucinations = %+v", result.Hallucinations)
+			}
+			report, err := os.ReadFile(result.HallucinationPath)
+			if err != nil {
+				t.Fatalf("read report: %v", err)
+			}
+			if !strings.Contains(string(report), "[00:00:30.000 - 00:00:32.000] outro phrase") {
+				t.Fatalf("report = %s", report)
+			}
+		})
+	}
+}
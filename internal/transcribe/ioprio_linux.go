@@ -0,0 +1,19 @@
+package transcribe
+
+import "syscall"
+
+const (
+	ioprioWhoPgrp    = 2
+	ioprioClassBE    = 2
+	ioprioClassShift = 13
+	// ioprioLowestBE is the lowest best-effort level, like `ionice -c2 -n7`.
+	ioprioLowestBE = ioprioClassBE<<ioprioClassShift | 7
+)
+
+// lowerIOPriority moves the process group to the lowest best-effort I/O priority.
+func lowerIOPriority(pgid int) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_SET, ioprioWhoPgrp, uintptr(pgid), ioprioLowestBE); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !windows
+
+package transcribe
+
+// lowerIOPriority is not supported on this platform; nice alone applies.
+func lowerIOPriority(pgid int) error {
+	return nil
+}
@@ -0,0 +1,23 @@
+package transcribe
+
+import (
+	"media-transcriber/internal/subtitle"
+)
+
+// exportLRC writes <name>.lrc with one timed line per segment when the
+// request asks for lyrics, and returns its path; "" when it does not or no
+// segment is timed.
+func (p *Pipeline) exportLRC(req Request, segments []TranscriptSegment, textBase string) (string, error) {
+	if !req.LRC {
+		return "", nil
+	}
+	cues := SegmentCues(segments)
+	if len(cues) == 0 {
+		return "", nil
+	}
+	lrcPath := textBase + ".lrc"
+	if err := p.writeText(req, lrcPath, subtitle.FormatLRC(cues)); err != nil {
+		return lrcPath, err
+	}
+	return lrcPath, nil
+}
@@ -0,0 +1,57 @@
+package transcribe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestPipelineRunExportsLRC writes <name>.lrc from the segments only when
+// the request asks for it.
+func TestPipelineRunExportsLRC(t *testing.T) {
+	for _, lrc := range []bool{false, true} {
+		root := t.TempDir()
+		inputPath := filepath.Join(root, "song.mp3")
+		modelPath := filepath.Join(root, "ggml-base.bin")
+		mustWriteFile(t, inputPath, "media")
+		mustWriteFile(t, modelPath, "model")
+
+		runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+				return commandResult{}, nil
+			}
+			base := argValue(args, "-of")
+			mustWriteFile(t, base+".txt", "La la. Oh yeah.")
+			mustWriteFile(t, base+".json", `{"transcription":[{"offsets":{"from":1000,"to":2500},"text":" La la."},{"offsets":{"from":6000,"to":7000},"text":" Oh yeah."}]}`)
+			return commandResult{}, nil
+		}}
+
+		pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+		result, err := pipeline.Run(context.Background(), Request{
+			InputPath: inputPath,
+			ModelPath: modelPath,
+			OutputDir: filepath.Join(root, "output"),
+			LRC:       lrc,
+		})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		result.Cleanup()
+
+		if !lrc {
+			if result.LyricsPath != "" {
+				t.Fatalf("lyrics path = %q without LRC", result.LyricsPath)
+			}
+			continue
+		}
+		data, err := os.ReadFile(result.LyricsPath)
+		if err != nil {
+			t.Fatalf("read lyrics: %v", err)
+		}
+		if want := "[00:01.00]La la.\n[00:02.50]\n[00:06.00]Oh yeah.\n[00:07.00]\n"; string(data) != want {
+			t.Fatalf("lyrics = %q, want %q", data, want)
+		}
+	}
+}
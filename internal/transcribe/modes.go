@@ -0,0 +1,240 @@
+package transcribe
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/domain"
+)
+
+// SegmentsSuffix ends the name of the stored segments a full run writes next
+// to the transcript, and the reformat mode reads back.
+const SegmentsSuffix = ".segments.json"
+
+// PreprocessedSuffix ends the name of the WAV the preprocess-only mode exports.
+const PreprocessedSuffix = ".16k.wav"
+
+// storedSegments is the <name>.segments.json file: the final segments of a
+// run, on the input's timeline, and the language they are in.
+type storedSegments struct {
+	Language string              `json:"language,omitempty"`
+	Segments []TranscriptSegment `json:"segments"`
+}
+
+// exportSegments writes <name>.segments.json so subtitles can later be
+// regenerated without whisper, and returns its path; "" when there are no
+// segments to store.
+func (p *Pipeline) exportSegments(segments []TranscriptSegment, language, textBase string) (string, error) {
+	if len(segments) == 0 {
+		return "", nil
+	}
+	data, err := json.MarshalIndent(storedSegments{Language: language, Segments: segments}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := textBase + SegmentsSuffix
+	if err := p.writeFile(path, append(data, '\n'), 0o644); err != nil {
+		return path, err
+	}
+	return path, nil
+}
+
+// ParseSegments reads a <name>.segments.json file and returns its language,
+// empty when whisper reported none, and segments.
+func ParseSegments(data []byte) (string, []TranscriptSegment, error) {
+	var stored storedSegments
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return "", nil, err
+	}
+	return stored.Language, stored.Segments, nil
+}
+
+// exportPreprocessed finishes the preprocess-only mode: the converted WAV is
+// linked or copied to <name>.16k.wav in the output directory. The transcribing
+// stage is still announced, empty, so jobs move through every status in order.
+func (p *Pipeline) exportPreprocessed(req Request, outPath, tempDir string, cached bool, tempo float64, stages *stageTimer, logs []CommandLog) (Result, error) {
+	emitStage(req.OnStage, "transcribing")
+	emitStage(req.OnStage, "exporting")
+	stages.start("exporting")
+	textPath := outputTextPath(req)
+	audioPath := strings.TrimSuffix(textPath, filepath.Ext(textPath)) + PreprocessedSuffix
+	if err := linkOrCopy(outPath, audioPath); err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:     "exporting",
+			Workspace: workspace,
+			Message:   fmt.Sprintf("failed to export preprocessed audio: %s", audioPath),
+			Err:       err,
+		}
+	}
+
+	cleanupDir := tempDir
+	if req.KeepIntermediates {
+		cleanupDir = ""
+	}
+	return Result{
+		PreprocessedAudioPath: outPath,
+		AudioPath:             audioPath,
+		Tempo:                 tempo,
+		Cached:                cached,
+		Stages:                stages.finish(),
+		Logs:                  logs,
+		tempDir:               cleanupDir,
+	}, nil
+}
+
+// reformat runs the reformat mode: the segments stored by an earlier run are
+// exported as subtitles and lyrics again, with the request's current terms,
+// encoding and line endings. The transcript is reused when it is still next to
+// them, or rendered again when the request asks for timestamps.
+func (p *Pipeline) reformat(ctx context.Context, req Request) (Result, error) {
+	var stages stageTimer
+	emitStage(req.OnStage, "preprocessing")
+	emitStage(req.OnStage, "transcribing")
+	emitStage(req.OnStage, "exporting")
+	stages.start("exporting")
+
+	data, err := p.readFile(req.InputPath)
+	if err != nil {
+		return Result{}, &PipelineError{
+			Stage:   "exporting",
+			Message: fmt.Sprintf("failed to read segments: %s", req.InputPath),
+			Kind:    domain.FailureInput,
+			Err:     err,
+		}
+	}
+	language, segments, err := ParseSegments(data)
+	if err != nil {
+		return Result{}, &PipelineError{
+			Stage:   "exporting",
+			Message: fmt.Sprintf("invalid segments file: %s", req.InputPath),
+			Kind:    domain.FailureInput,
+			Err:     err,
+		}
+	}
+
+	name := req.OutputName
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(req.InputPath), SegmentsSuffix)
+	}
+	textBase := filepath.Join(req.OutputDir, name)
+	if corrector := newTermCorrector(req.Terms); corrector != nil {
+		for i := range segments {
+			segments[i].Text = corrector.correct(segments[i].Text)
+		}
+	}
+
+	// Rebuilding the subtitles is what this mode is for.
+	req.Subtitles = true
+	subtitlePaths, err := p.exportSubtitles(req, segments, textBase, subtitleLanguage(req.Language, language))
+	if err != nil {
+		message := "failed to write subtitles"
+		if strings.TrimSpace(req.ScriptText) != "" {
+			message = fmt.Sprintf("failed to align script: %v", err)
+		}
+		return Result{}, &PipelineError{Stage: "exporting", Message: message, Err: err}
+	}
+	lyricsPath, err := p.exportLRC(req, segments, textBase)
+	if err != nil {
+		return Result{}, &PipelineError{
+			Stage:   "exporting",
+			Message: fmt.Sprintf("failed to write lyrics: %s", lyricsPath),
+			Err:     err,
+		}
+	}
+
+	textPath := textBase + ".txt"
+	transcript := segmentsText(segments)
+	if rendered, err := p.rewriteTranscript(req, segments, nil, textPath); err != nil {
+		return Result{}, &PipelineError{
+			Stage:   "exporting",
+			Message: fmt.Sprintf("failed to write transcript: %s", textPath),
+			Err:     err,
+		}
+	} else if rendered != "" {
+		transcript = rendered
+	}
+	transcript = strings.TrimSpace(transcript)
+	reserved := append([]string{req.InputPath, textPath, lyricsPath}, subtitlePaths...)
+	transformPaths, err := p.exportTransforms(ctx, req, TransformData{
+		Name:       name,
+		Input:      req.InputPath,
+		Language:   language,
+		Transcript: transcript,
+		Segments:   segments,
+	}, textBase, reserved)
+	if err != nil {
+		return Result{}, &PipelineError{Stage: "exporting", Message: fmt.Sprintf("failed to run %v", err), Err: err}
+	}
+
+	if _, err := p.stat(textPath); err != nil {
+		textPath = ""
+	}
+	return Result{
+		TextPath:       textPath,
+		Transcript:     transcript,
+		Segments:       segments,
+		SubtitlePaths:  subtitlePaths,
+		LyricsPath:     lyricsPath,
+		SegmentsPath:   req.InputPath,
+		TransformPaths: transformPaths,
+		Language:       language,
+		Tempo:          1,
+		Stages:         stages.finish(),
+	}, nil
+}
+
+// checkPreprocessedWAV reports whether path is a WAV in the format whisper
+// reads, 16 kHz mono 16-bit PCM, so it can be transcribed without ffmpeg.
+func checkPreprocessedWAV(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cannot read input WAV: %w", err)
+	}
+	defer file.Close()
+
+	notPreprocessed := fmt.Errorf("input is not a 16 kHz mono 16-bit PCM WAV: %s", path)
+	var header [12]byte
+	if _, err := io.ReadFull(file, header[:]); err != nil || string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return notPreprocessed
+	}
+	// Chunks such as LIST may precede "fmt "; skip them by their sizes.
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(file, chunk[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return notPreprocessed
+			}
+			return fmt.Errorf("cannot read input WAV: %w", err)
+		}
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+		if string(chunk[0:4]) != "fmt " {
+			if _, err := file.Seek(size+size%2, io.SeekCurrent); err != nil {
+				return notPreprocessed
+			}
+			continue
+		}
+		var format [16]byte
+		if size < int64(len(format)) {
+			return notPreprocessed
+		}
+		if _, err := io.ReadFull(file, format[:]); err != nil {
+			return notPreprocessed
+		}
+		pcm := binary.LittleEndian.Uint16(format[0:2]) == 1
+		channels := binary.LittleEndian.Uint16(format[2:4])
+		rate := binary.LittleEndian.Uint32(format[4:8])
+		bits := binary.LittleEndian.Uint16(format[14:16])
+		if !pcm || channels != 1 || rate != 16000 || bits != 16 {
+			return notPreprocessed
+		}
+		return nil
+	}
+}
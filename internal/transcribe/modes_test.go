@@ -0,0 +1,244 @@
+package transcribe
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// wavHeader builds a 44-byte canonical WAV header in the given format.
+func wavHeader(format, channels uint16, rate uint32, bits uint16) string {
+	header := make([]byte, 44)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], 36)
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16)
+	binary.LittleEndian.PutUint16(header[20:22], format)
+	binary.LittleEndian.PutUint16(header[22:24], channels)
+	binary.LittleEndian.PutUint32(header[24:28], rate)
+	binary.LittleEndian.PutUint16(header[34:36], bits)
+	copy(header[36:40], "data")
+	return string(header)
+}
+
+// TestCheckPreprocessedWAV accepts only 16 kHz mono 16-bit PCM WAVs,
+// including ones with a chunk before "fmt ".
+func TestCheckPreprocessedWAV(t *testing.T) {
+	list := "RIFF\x00\x00\x00\x00WAVELIST\x03\x00\x00\x00abc\x00" + wavHeader(1, 1, 16000, 16)[12:]
+	tests := []struct {
+		name string
+		data string
+		ok   bool
+	}{
+		{name: "preprocessed", data: wavHeader(1, 1, 16000, 16), ok: true},
+		{name: "leading chunk", data: list, ok: true},
+		{name: "stereo", data: wavHeader(1, 2, 16000, 16)},
+		{name: "44.1 kHz", data: wavHeader(1, 1, 44100, 16)},
+		{name: "float", data: wavHeader(3, 1, 16000, 32)},
+		{name: "not a WAV", data: "ID3 mp3 data"},
+		{name: "truncated", data: wavHeader(1, 1, 16000, 16)[:20]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audio.wav")
+			mustWriteFile(t, path, tt.data)
+			if err := checkPreprocessedWAV(path); (err == nil) != tt.ok {
+				t.Fatalf("checkPreprocessedWAV() error = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+// TestPipelineRunPreprocessOnly exports the WAV without running whisper or
+// needing a model, and still announces every stage in order.
+func TestPipelineRunPreprocessOnly(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "lecture.mp4")
+	mustWriteFile(t, inputPath, "media")
+
+	var commands []string
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		commands = append(commands, name)
+		mustWriteFile(t, args[len(args)-1], "wav")
+		return commandResult{}, nil
+	}}
+	var stages []string
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath: inputPath,
+		OutputDir: filepath.Join(root, "output"),
+		Mode:      domain.PipelineModePreprocess,
+		OnStage:   func(stage string) { stages = append(stages, stage) },
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if err := result.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(commands, []string{"ffmpeg"}) {
+		t.Fatalf("commands = %v, want only ffmpeg", commands)
+	}
+	if want := []string{"preprocessing", "transcribing", "exporting"}; !reflect.DeepEqual(stages, want) {
+		t.Fatalf("stages = %v, want %v", stages, want)
+	}
+	if want := filepath.Join(root, "output", "lecture"+PreprocessedSuffix); result.AudioPath != want {
+		t.Fatalf("audio path = %q, want %q", result.AudioPath, want)
+	}
+	if data, err := os.ReadFile(result.AudioPath); err != nil || string(data) != "wav" {
+		t.Fatalf("exported audio = %q, %v after cleanup", data, err)
+	}
+	if result.TextPath != "" {
+		t.Fatalf("text path = %q, want none", result.TextPath)
+	}
+}
+
+// TestPipelineRunTranscribeWAV hands the input WAV straight to whisper, and
+// rejects an input in another format before running anything.
+func TestPipelineRunTranscribeWAV(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "lecture.16k.wav")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	mustWriteFile(t, inputPath, wavHeader(1, 1, 16000, 16))
+	mustWriteFile(t, modelPath, "model")
+
+	var commands []string
+	var audio string
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		commands = append(commands, name)
+		audio = argValue(args, "-f")
+		mustWriteFile(t, argValue(args, "-of")+".txt", "hello")
+		return commandResult{}, nil
+	}}
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath: inputPath,
+		ModelPath: modelPath,
+		OutputDir: filepath.Join(root, "output"),
+		Mode:      domain.PipelineModeTranscribeWAV,
+		Tempo:     2,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	result.Cleanup()
+
+	if !reflect.DeepEqual(commands, []string{"whisper-cli"}) {
+		t.Fatalf("commands = %v, want only whisper-cli", commands)
+	}
+	if audio != inputPath {
+		t.Fatalf("whisper audio = %q, want input %q", audio, inputPath)
+	}
+	if result.Tempo != 1 {
+		t.Fatalf("tempo = %v, want 1 for a preprocessed input", result.Tempo)
+	}
+	if _, err := os.Stat(inputPath); err != nil {
+		t.Fatalf("input WAV removed by cleanup: %v", err)
+	}
+
+	mp3 := filepath.Join(root, "song.mp3")
+	mustWriteFile(t, mp3, "ID3")
+	commands = nil
+	_, err = pipeline.Run(context.Background(), Request{
+		InputPath: mp3,
+		ModelPath: modelPath,
+		OutputDir: filepath.Join(root, "output"),
+		Mode:      domain.PipelineModeTranscribeWAV,
+	})
+	if err == nil || !strings.Contains(err.Error(), "16 kHz mono") {
+		t.Fatalf("Run() error = %v, want format error", err)
+	}
+	if len(commands) != 0 {
+		t.Fatalf("commands = %v, want none for a rejected input", commands)
+	}
+}
+
+// TestPipelineRunReformat regenerates subtitles from the segments a full run
+// stored, without ffmpeg or whisper, applying the current terms.
+func TestPipelineRunReformat(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "talk.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	calls := 0
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		calls++
+		if name == "ffmpeg" {
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		}
+		base := argValue(args, "-of")
+		mustWriteFile(t, base+".txt", "We use Kubernets.")
+		mustWriteFile(t, base+".json", `{"result":{"language":"en"},"transcription":[{"offsets":{"from":0,"to":1500},"text":" We use Kubernets."}]}`)
+		return commandResult{}, nil
+	}}
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	full, err := pipeline.Run(context.Background(), Request{InputPath: inputPath, ModelPath: modelPath, OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	full.Cleanup()
+	if want := filepath.Join(outputDir, "talk"+SegmentsSuffix); full.SegmentsPath != want {
+		t.Fatalf("segments path = %q, want %q", full.SegmentsPath, want)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "talk.srt")); !os.IsNotExist(err) {
+		t.Fatalf("subtitles are opt-in, stat err = %v", err)
+	}
+
+	calls = 0
+	var stages []string
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath: full.SegmentsPath,
+		OutputDir: outputDir,
+		Mode:      domain.PipelineModeReformat,
+		Terms:     []string{"Kubernetes"},
+		OnStage:   func(stage string) { stages = append(stages, stage) },
+	})
+	if err != nil {
+		t.Fatalf("Run(reformat) error = %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("reformat ran %d commands, want none", calls)
+	}
+	if want := []string{"preprocessing", "transcribing", "exporting"}; !reflect.DeepEqual(stages, want) {
+		t.Fatalf("stages = %v, want %v", stages, want)
+	}
+	if want := filepath.Join(outputDir, "talk.txt"); result.TextPath != want {
+		t.Fatalf("text path = %q, want existing transcript %q", result.TextPath, want)
+	}
+	if result.Language != "en" || result.Transcript != "We use Kubernetes." {
+		t.Fatalf("result = %q in %q, want corrected text in en", result.Transcript, result.Language)
+	}
+	srt, err := os.ReadFile(filepath.Join(outputDir, "talk.srt"))
+	if err != nil {
+		t.Fatalf("read regenerated srt: %v", err)
+	}
+	if want := "00:00:00,000 --> 00:00:01,500\nWe use Kubernetes."; !strings.Contains(string(srt), want) {
+		t.Fatalf("srt = %q, want cue %q", srt, want)
+	}
+
+	result, err = pipeline.Run(context.Background(), Request{
+		InputPath:      full.SegmentsPath,
+		OutputDir:      outputDir,
+		Mode:           domain.PipelineModeReformat,
+		TextTimestamps: domain.TextTimestampsSegment,
+	})
+	if err != nil {
+		t.Fatalf("Run(reformat with timestamps) error = %v", err)
+	}
+	text, err := os.ReadFile(result.TextPath)
+	if err != nil || string(text) != "[00:00:00] We use Kubernets.\n" {
+		t.Fatalf("rewritten transcript = %q (%v), want it timestamped", text, err)
+	}
+}
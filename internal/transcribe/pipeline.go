@@ -1,15 +1,18 @@
 package transcribe
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+	"media-transcriber/internal/subtitle"
+	"media-transcriber/internal/textenc"
 )
 
 // Request contains input media and execution callbacks for one run.
@@ -18,8 +21,127 @@ type Request struct {
 	ModelPath string
 	Language  string
 	OutputDir string
-	OnStage   func(stage string)
-	OnLog     func(log CommandLog)
+	// FFmpegPath and WhisperPath override the pipeline's default executables when set.
+	FFmpegPath  string
+	WhisperPath string
+	// PreprocessTimeout and TranscribeTimeout bound each stage; zero means no limit.
+	PreprocessTimeout time.Duration
+	TranscribeTimeout time.Duration
+	// TempDir is the parent of the per-job workspace; empty uses the OS temp directory.
+	TempDir string
+	// KeepIntermediates leaves the workspace in place, even on failure, for debugging.
+	KeepIntermediates bool
+	// LowPriority runs ffmpeg and whisper below normal OS priority to keep the machine responsive.
+	LowPriority bool
+	OnStage     func(stage string)
+	OnLog       func(log CommandLog)
+	// OnWorkspace receives the temp workspace directory as soon as it exists.
+	OnWorkspace func(dir string)
+	// OnOutput receives command output line by line while the process runs.
+	OnOutput func(line OutputLine)
+	// OnProgress receives the completion fraction (0 to 1) of the running stage.
+	OnProgress func(stage string, fraction float64)
+	// OnSegment receives each timed segment whisper prints while transcribing,
+	// so the transcript can be shown as it grows.
+	OnSegment func(segment TranscriptSegment)
+	// ReviewThreshold flags segments below this confidence in the review report;
+	// zero uses DefaultReviewThreshold.
+	ReviewThreshold float64
+	// ScriptText switches subtitle export to forced alignment: the prepared
+	// script is timed against the recognized speech instead of whisper's text.
+	ScriptText string
+	// OutputName is the base file name of every output, e.g. from a naming
+	// template; empty derives it from the input file name.
+	OutputName string
+	// Tempo, above 1, speeds the preprocessed audio up with ffmpeg's atempo
+	// (at most MaxTempo) so whisper finishes proportionally sooner; segment
+	// timestamps are scaled back to the original timeline.
+	Tempo float64
+	// HWAccel is passed to ffmpeg as -hwaccel (e.g. "auto", "cuda"); empty
+	// decodes on the CPU.
+	HWAccel string
+	// DraftModelPath, when set, runs a quick pass with this (typically tiny)
+	// model before the configured one and hands its transcript to OnDraft,
+	// so a provisional transcript is available long before the final one.
+	DraftModelPath string
+	OnDraft        func(text string)
+	// SkipNonSpeech cuts long silence and music, such as intro music that
+	// whisper would hallucinate lyrics over, from the audio before
+	// transcribing; timestamps stay on the input's timeline. It does not
+	// apply to SplitChannels.
+	SkipNonSpeech bool
+	// InputHash is the ContentHash of InputPath when the caller already
+	// computed it, so the preprocess cache does not read the input again.
+	InputHash string
+	// Terms are names and jargon to spell right: they are passed to whisper
+	// as its initial prompt, and near misses in the transcript are corrected
+	// to the listed spelling.
+	Terms []string
+	// Grammar constrains decoding to a GBNF grammar. whisper-server cannot
+	// take one, so a job with a grammar runs whisper directly even when
+	// Server is set.
+	Grammar Grammar
+	// HallucinationFilter flags or removes segments that look like whisper
+	// hallucinations and reports them in <name>.hallucinations.txt.
+	HallucinationFilter domain.HallucinationFilter
+	// SilenceMarkers puts a line such as "[silence 00:12:30–00:14:02]" in the
+	// transcript for every gap of at least SilenceMinGap between segments,
+	// and SilenceMap lists the gaps in <name>.silence.json. A zero
+	// SilenceMinGap is DefaultSilenceGap.
+	SilenceMarkers bool
+	SilenceMap     bool
+	SilenceMinGap  time.Duration
+	// TextTimestamps prefixes the transcript's lines with their start, per
+	// segment or per paragraph of TimestampInterval (zero is
+	// DefaultTimestampInterval); the transcript is then rendered from the
+	// segments instead of whisper's text.
+	TextTimestamps    domain.TextTimestamps
+	TimestampInterval time.Duration
+	// TextLayout merges segments into sentences or paragraphs in the
+	// transcript; subtitles and Result.Segments keep whisper's segments.
+	TextLayout domain.TextLayout
+	// SplitChannels transcribes the left and right channels of a stereo
+	// recording separately, labels them as two speakers, and interleaves
+	// their segments by time. The preprocess cache is not used for it.
+	SplitChannels bool
+	// Server, when set, transcribes on a warm whisper.cpp server that keeps
+	// the model loaded between jobs instead of starting whisper per job.
+	// WhisperServerPath is its executable; empty uses DefaultServerPath.
+	Server            *WhisperServer
+	WhisperServerPath string
+	// GPUDevice picks where whisper runs: empty keeps whisper's default GPU,
+	// "cpu" runs without the GPU, and an index selects that GPU, counted in
+	// nvidia-smi's PCI bus order.
+	GPUDevice string
+	// Cache, when set, reuses the preprocessed WAV (and the language whisper
+	// detected in it) of earlier runs on the same input content.
+	Cache *PreprocessCache
+	// Chapters, when there are at least two, split the transcript into one
+	// file per chapter plus a combined file with chapter headings.
+	Chapters []Chapter
+	// Subtitles also exports the segments as <name>.srt and <name>.vtt; a
+	// ScriptText always does.
+	Subtitles bool
+	// LRC also exports the segments as <name>.lrc lyrics.
+	LRC bool
+	// Mode runs only part of the pipeline: preprocessing alone, transcription
+	// of an already preprocessed WAV, or re-export of stored segments, in
+	// which case InputPath is the <name>.segments.json file.
+	Mode domain.PipelineMode
+	// TextEncoding and LineEnding apply to the transcript and every text file
+	// exported next to it.
+	TextEncoding domain.TextEncoding
+	LineEnding   domain.LineEnding
+	// Transforms are user scripts that render extra outputs from the final
+	// segments at the end of the exporting stage.
+	Transforms []Transform
+}
+
+// OutputLine is one line of live stdout or stderr output from an external command.
+type OutputLine struct {
+	Command string `json:"command"`
+	Stream  string `json:"stream"`
+	Text    string `json:"text"`
 }
 
 // Result contains output artifact paths, transcript text, and command logs.
@@ -27,11 +149,50 @@ type Result struct {
 	PreprocessedAudioPath string
 	TextPath              string
 	Transcript            string
-	Logs                  []CommandLog
-	tempDir               string
+	// Segments carries per-segment timing and confidence when whisper wrote JSON output.
+	Segments []TranscriptSegment
+	// ReviewPath is the low-confidence report, empty when no passage needs review.
+	ReviewPath string
+	// SubtitlePaths lists the .srt and .vtt files, aligned to the script in alignment mode.
+	SubtitlePaths []string
+	// ChapterPaths lists the per-chapter transcripts followed by the combined
+	// <name>.by-chapter.txt; empty unless the request carried chapters.
+	ChapterPaths []string
+	// LyricsPath is the <name>.lrc file, empty unless the request asked for it.
+	LyricsPath string
+	// SegmentsPath is the <name>.segments.json file the reformat mode reads.
+	SegmentsPath string
+	// AudioPath is the exported <name>.16k.wav of the preprocess-only mode.
+	AudioPath string
+	// TransformPaths lists the files the request's transforms wrote.
+	TransformPaths []string
+	// Language is the language whisper transcribed in, detected or forced;
+	// empty when its JSON output did not report one.
+	Language string
+	// Tempo is the factor the preprocessed audio was sped up by, 1 at normal
+	// speed.
+	Tempo float64
+	// Skipped lists the silence and music regions cut before transcribing.
+	Skipped []SkippedRegion
+	// Hallucinations lists the segments the hallucination filter flagged or
+	// removed, and HallucinationPath is their report, empty when there are none.
+	Hallucinations    []Hallucination
+	HallucinationPath string
+	// Silence lists the gaps without speech when silence markers or a silence
+	// map were requested, and SilenceMapPath is the map, if written.
+	Silence        []SilenceGap
+	SilenceMapPath string
+	// Cached is set when preprocessing was skipped because the input's WAV was
+	// in the preprocess cache.
+	Cached bool
+	// Stages holds the wall-clock duration and peak memory of each stage.
+	Stages  []domain.StageTiming
+	Logs    []CommandLog
+	tempDir string
 }
 
 // Cleanup removes temporary preprocessing artifacts created by Run.
+// It is a no-op when the request kept intermediates.
 func (r *Result) Cleanup() error {
 	if r == nil || r.tempDir == "" {
 		return nil
@@ -46,19 +207,44 @@ func (r *Result) Cleanup() error {
 
 // CommandLog captures one external command invocation result.
 type CommandLog struct {
-	Command  string   `json:"command"`
-	Args     []string `json:"args"`
-	ExitCode int      `json:"exitCode"`
-	Stdout   string   `json:"stdout"`
-	Stderr   string   `json:"stderr"`
+	Command         string   `json:"command"`
+	Args            []string `json:"args"`
+	ExitCode        int      `json:"exitCode"`
+	Stdout          string   `json:"stdout"`
+	Stderr          string   `json:"stderr"`
+	PeakMemoryBytes uint64   `json:"peakMemoryBytes,omitempty"`
 }
 
+// ErrTimeout marks a PipelineError caused by a stage or job deadline.
+var ErrTimeout = errors.New("timed out")
+
 // PipelineError is a stage-aware error with optional command context.
 type PipelineError struct {
 	Stage      string     `json:"stage"`
 	Message    string     `json:"message"`
 	CommandLog CommandLog `json:"commandLog"`
-	Err        error      `json:"-"`
+	// Workspace is the retained intermediate directory when KeepIntermediates is set.
+	Workspace string `json:"workspace,omitempty"`
+	// Kind is set for failures the stage does not tell apart: a bad input
+	// or a missing model. See Failure.
+	Kind domain.Failure `json:"kind,omitempty"`
+	Err  error          `json:"-"`
+}
+
+// Failure classifies the error: Kind when set, otherwise the tool or step
+// of the stage that failed.
+func (e *PipelineError) Failure() domain.Failure {
+	if e.Kind != "" {
+		return e.Kind
+	}
+	switch e.Stage {
+	case "preprocessing":
+		return domain.FailureFFmpeg
+	case "transcribing":
+		return domain.FailureWhisper
+	default:
+		return domain.FailureExport
+	}
 }
 
 // Error formats pipeline failures for logs and UI.
@@ -87,47 +273,6 @@ func (e *PipelineError) Unwrap() error {
 	return e.Err
 }
 
-// commandResult is an internal process execution response.
-type commandResult struct {
-	Stdout   string
-	Stderr   string
-	ExitCode int
-}
-
-// commandRunner abstracts process execution for testability.
-type commandRunner interface {
-	Run(ctx context.Context, name string, args ...string) (commandResult, error)
-}
-
-// execRunner executes commands via os/exec.
-type execRunner struct{}
-
-// Run executes one command and captures stdout/stderr and exit code.
-func (r *execRunner) Run(ctx context.Context, name string, args ...string) (commandResult, error) {
-	cmd := exec.CommandContext(ctx, name, args...)
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-	result := commandResult{
-		Stdout:   stdout.String(),
-		Stderr:   stderr.String(),
-		ExitCode: 0,
-	}
-	if err != nil {
-		result.ExitCode = -1
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			result.ExitCode = exitErr.ExitCode()
-		}
-		return result, err
-	}
-
-	return result, nil
-}
-
 // Pipeline orchestrates ffmpeg preprocessing and whisper transcription.
 type Pipeline struct {
 	ffmpegPath  string
@@ -139,6 +284,7 @@ type Pipeline struct {
 	mkdirAll    func(path string, perm os.FileMode) error
 	readDir     func(name string) ([]os.DirEntry, error)
 	readFile    func(name string) ([]byte, error)
+	writeFile   func(name string, data []byte, perm os.FileMode) error
 }
 
 // NewPipeline constructs the production pipeline with OS dependencies.
@@ -146,13 +292,14 @@ func NewPipeline() *Pipeline {
 	return &Pipeline{
 		ffmpegPath:  "ffmpeg",
 		whisperPath: "whisper.cpp",
-		runner:      &execRunner{},
+		runner:      &execRunner{gracePeriod: defaultCancelGracePeriod},
 		mkdirTemp:   os.MkdirTemp,
 		removeAll:   os.RemoveAll,
 		stat:        os.Stat,
 		mkdirAll:    os.MkdirAll,
 		readDir:     os.ReadDir,
 		readFile:    os.ReadFile,
+		writeFile:   os.WriteFile,
 	}
 }
 
@@ -162,6 +309,7 @@ func (p *Pipeline) Run(ctx context.Context, req Request) (Result, error) {
 		return Result{}, &PipelineError{
 			Stage:   "preprocessing",
 			Message: "input media path is required",
+			Kind:    domain.FailureInput,
 		}
 	}
 
@@ -169,16 +317,30 @@ func (p *Pipeline) Run(ctx context.Context, req Request) (Result, error) {
 		return Result{}, &PipelineError{
 			Stage:   "preprocessing",
 			Message: fmt.Sprintf("cannot access input media: %s", req.InputPath),
+			Kind:    domain.FailureInput,
 			Err:     err,
 		}
 	}
 
-	modelPath, err := p.resolveModelPath(req.ModelPath)
-	if err != nil {
-		return Result{}, &PipelineError{
-			Stage:   "transcribing",
-			Message: err.Error(),
-			Err:     err,
+	var modelPath string
+	if req.Mode != domain.PipelineModePreprocess && req.Mode != domain.PipelineModeReformat {
+		path, err := p.resolveModelPath(req.ModelPath)
+		if err != nil {
+			return Result{}, &PipelineError{
+				Stage:   "transcribing",
+				Message: err.Error(),
+				Kind:    domain.FailureModel,
+				Err:     err,
+			}
+		}
+		modelPath = path
+		if _, err := p.grammarArgs(req.Grammar); err != nil {
+			return Result{}, &PipelineError{
+				Stage:   "transcribing",
+				Message: err.Error(),
+				Kind:    domain.FailureWhisper,
+				Err:     err,
+			}
 		}
 	}
 
@@ -196,7 +358,25 @@ func (p *Pipeline) Run(ctx context.Context, req Request) (Result, error) {
 		}
 	}
 
-	tempDir, err := p.mkdirTemp("", "media-transcriber-*")
+	switch req.Mode {
+	case domain.PipelineModeReformat:
+		return p.reformat(ctx, req)
+	case domain.PipelineModeTranscribeWAV:
+		if err := checkPreprocessedWAV(req.InputPath); err != nil {
+			return Result{}, &PipelineError{
+				Stage:   "preprocessing",
+				Message: err.Error(),
+				Kind:    domain.FailureInput,
+				Err:     err,
+			}
+		}
+		// The input is the WAV; there is nothing to convert, cache or split.
+		req.Tempo, req.Cache, req.SplitChannels = 1, nil, false
+	case domain.PipelineModePreprocess:
+		req.SplitChannels = false
+	}
+
+	tempDir, err := p.mkdirTemp(strings.TrimSpace(req.TempDir), WorkspacePattern)
 	if err != nil {
 		return Result{}, &PipelineError{
 			Stage:   "preprocessing",
@@ -205,67 +385,197 @@ func (p *Pipeline) Run(ctx context.Context, req Request) (Result, error) {
 		}
 	}
 
+	if req.OnWorkspace != nil {
+		req.OnWorkspace(tempDir)
+	}
+
+	ffmpegPath := p.ffmpegPath
+	if path := strings.TrimSpace(req.FFmpegPath); path != "" {
+		ffmpegPath = path
+	}
+	whisperPath := p.whisperPath
+	if path := strings.TrimSpace(req.WhisperPath); path != "" {
+		whisperPath = path
+	}
+
 	outPath := filepath.Join(tempDir, "preprocessed-16k-mono.wav")
+	if req.Mode == domain.PipelineModeTranscribeWAV {
+		outPath = req.InputPath
+	}
+	tempo := speedFactor(req.Tempo)
 	emitStage(req.OnStage, "preprocessing")
-	args := buildFFmpegArgs(req.InputPath, outPath)
-
-	cmdResult, runErr := p.runner.Run(ctx, p.ffmpegPath, args...)
-	log := CommandLog{
-		Command:  p.ffmpegPath,
-		Args:     args,
-		ExitCode: cmdResult.ExitCode,
-		Stdout:   cmdResult.Stdout,
-		Stderr:   cmdResult.Stderr,
+	var stages stageTimer
+	stages.start("preprocessing")
+	language := req.Language
+	cacheKey := ""
+	if req.Cache != nil && !req.SplitChannels {
+		key := req.InputHash
+		var err error
+		if key == "" {
+			key, err = req.Cache.Key(ctx, req.InputPath)
+		}
+		if err == nil {
+			cacheKey = key
+			if tempo != 1 {
+				// Sped-up audio is a different WAV of the same content.
+				cacheKey += "-x" + formatTempo(tempo)
+			}
+		}
 	}
-	emitLog(req.OnLog, log)
-	if runErr != nil {
-		_ = p.removeAll(tempDir)
-		return Result{}, &PipelineError{
-			Stage:      "preprocessing",
-			Message:    "ffmpeg audio conversion failed",
-			CommandLog: log,
-			Err:        runErr,
+	cached := false
+	if cacheKey != "" {
+		if meta, ok := req.Cache.Restore(cacheKey, outPath); ok {
+			cached = true
+			if normalizeLanguage(language) == "" && meta.Language != "" {
+				language = meta.Language
+			}
+			if req.OnProgress != nil {
+				req.OnProgress("preprocessing", 1)
+			}
 		}
 	}
+	var logs []CommandLog
+	preprocess := func(hwaccel string) (CommandLog, error) {
+		args := buildFFmpegArgs(req.InputPath, outPath, hwaccel, tempo)
+		if req.SplitChannels {
+			args = buildChannelSplitArgs(req.InputPath, outPath, channelAudioPaths(tempDir), hwaccel, tempo)
+		}
+		cmdResult, runErr := p.runStage(ctx, req.PreprocessTimeout, commandOptions{
+			onLine:      progressForwarder(outputForwarder(req.OnOutput, ffmpegPath), req.OnProgress, "preprocessing", (&ffmpegProgress{tempo: tempo}).parse),
+			lowPriority: req.LowPriority,
+		}, ffmpegPath, args...)
+		log := CommandLog{
+			Command:         ffmpegPath,
+			Args:            args,
+			ExitCode:        cmdResult.ExitCode,
+			Stdout:          cmdResult.Stdout,
+			Stderr:          cmdResult.Stderr,
+			PeakMemoryBytes: cmdResult.PeakMemoryBytes,
+		}
+		emitLog(req.OnLog, log)
+		stages.peak(log.PeakMemoryBytes)
+		return log, runErr
+	}
+	if req.Mode == domain.PipelineModeTranscribeWAV && req.OnProgress != nil {
+		req.OnProgress("preprocessing", 1)
+	}
+	if !cached && req.Mode != domain.PipelineModeTranscribeWAV {
+		log, runErr := preprocess(req.HWAccel)
+		if runErr != nil && req.HWAccel != "" && ctx.Err() == nil && !errors.Is(runErr, ErrTimeout) {
+			// An unavailable device fails the whole command; decode on the CPU instead.
+			log, runErr = preprocess("")
+		}
+		if runErr != nil {
+			workspace := p.releaseWorkspace(req, tempDir)
+			return Result{}, &PipelineError{
+				Stage:      "preprocessing",
+				Workspace:  workspace,
+				Message:    failureMessage("ffmpeg audio conversion", runErr),
+				CommandLog: log,
+				Err:        runErr,
+			}
+		}
 
-	if _, err := p.stat(outPath); err != nil {
-		_ = p.removeAll(tempDir)
-		return Result{}, &PipelineError{
-			Stage:      "preprocessing",
-			Message:    "ffmpeg completed but output file is missing",
-			CommandLog: log,
-			Err:        err,
+		if _, err := p.stat(outPath); err != nil {
+			workspace := p.releaseWorkspace(req, tempDir)
+			return Result{}, &PipelineError{
+				Stage:      "preprocessing",
+				Workspace:  workspace,
+				Message:    "ffmpeg completed but output file is missing",
+				CommandLog: log,
+				Err:        err,
+			}
+		}
+		logs = append(logs, log)
+		if cacheKey != "" {
+			// A cache write failure only costs the next run of this file its speedup.
+			_ = req.Cache.Store(cacheKey, outPath)
+		}
+	}
+	if req.Mode == domain.PipelineModePreprocess {
+		return p.exportPreprocessed(req, outPath, tempDir, cached, tempo, &stages, logs)
+	}
+
+	audioPath := outPath
+	line := timeline{tempo: tempo}
+	var audioMs int64
+	if info, err := p.stat(outPath); err == nil {
+		audioMs = line.toSource(max(info.Size()-PreprocessedHeaderBytes, 0) * 1000 / PreprocessedBytesPerSecond)
+	}
+	var skipped []SkippedRegion
+	if req.SkipNonSpeech && !req.SplitChannels {
+		scan, err := p.skipNonSpeech(ctx, req, ffmpegPath, outPath, tempDir)
+		for _, log := range scan.logs {
+			emitLog(req.OnLog, log)
+			stages.peak(log.PeakMemoryBytes)
+		}
+		logs = append(logs, scan.logs...)
+		// A failed scan only costs the speedup; the full audio is transcribed.
+		if err == nil && scan.path != "" {
+			audioPath, line.kept = scan.path, scan.kept
+			// Regions are found on the untrimmed audio, which only needs the speed-up undone.
+			for _, region := range scan.skipped {
+				region.StartMs = timeline{tempo: tempo}.toSource(region.StartMs)
+				region.EndMs = timeline{tempo: tempo}.toSource(region.EndMs)
+				skipped = append(skipped, region)
+			}
+		}
+	}
+	corrector := newTermCorrector(req.Terms)
+	if onSegment := req.OnSegment; onSegment != nil {
+		req.OnSegment = func(segment TranscriptSegment) {
+			segment.Text = corrector.correct(segment.Text)
+			onSegment(line.segment(segment))
 		}
 	}
 
-	textPath := filepath.Join(req.OutputDir, transcriptFileName(req.InputPath))
+	textPath := outputTextPath(req)
 	textBase := strings.TrimSuffix(textPath, filepath.Ext(textPath))
 	emitStage(req.OnStage, "transcribing")
-	whisperArgs := buildWhisperArgs(modelPath, outPath, textBase, req.Language)
-
-	whisperResult, runErr := p.runner.Run(ctx, p.whisperPath, whisperArgs...)
-	whisperLog := CommandLog{
-		Command:  p.whisperPath,
-		Args:     whisperArgs,
-		ExitCode: whisperResult.ExitCode,
-		Stdout:   whisperResult.Stdout,
-		Stderr:   whisperResult.Stderr,
+	stages.start("transcribing")
+	if strings.TrimSpace(req.DraftModelPath) != "" && req.OnDraft != nil {
+		draft, draftLog, draftErr := p.runDraft(ctx, req, whisperPath, audioPath, tempDir, language)
+		if draftLog.Command != "" {
+			emitLog(req.OnLog, draftLog)
+			stages.peak(draftLog.PeakMemoryBytes)
+			logs = append(logs, draftLog)
+		}
+		// A failed draft only costs the preview; the full pass still runs.
+		if draftErr == nil && draft != "" {
+			req.OnDraft(draft)
+		}
+	}
+	var whisperLogs []CommandLog
+	var runErr error
+	if req.SplitChannels {
+		whisperLogs, runErr = p.transcribeChannels(ctx, req, whisperPath, modelPath, channelAudioPaths(tempDir), textPath, textBase, language)
+	} else {
+		var log CommandLog
+		log, runErr = p.transcribeAudio(ctx, req, whisperPath, modelPath, audioPath, textPath, textBase, language)
+		whisperLogs = []CommandLog{log}
 	}
-	emitLog(req.OnLog, whisperLog)
+	for _, log := range whisperLogs {
+		emitLog(req.OnLog, log)
+		stages.peak(log.PeakMemoryBytes)
+	}
+	whisperLog := whisperLogs[len(whisperLogs)-1]
+	logs = append(logs, whisperLogs[:len(whisperLogs)-1]...)
 	if runErr != nil {
-		_ = p.removeAll(tempDir)
+		workspace := p.releaseWorkspace(req, tempDir)
 		return Result{}, &PipelineError{
 			Stage:      "transcribing",
-			Message:    "whisper.cpp transcription failed",
+			Workspace:  workspace,
+			Message:    failureMessage("whisper.cpp transcription", runErr),
 			CommandLog: whisperLog,
 			Err:        runErr,
 		}
 	}
 
 	if _, err := p.stat(textPath); err != nil {
-		_ = p.removeAll(tempDir)
+		workspace := p.releaseWorkspace(req, tempDir)
 		return Result{}, &PipelineError{
 			Stage:      "exporting",
+			Workspace:  workspace,
 			Message:    "whisper.cpp completed but transcript .txt file is missing",
 			CommandLog: whisperLog,
 			Err:        err,
@@ -273,26 +583,362 @@ func (p *Pipeline) Run(ctx context.Context, req Request) (Result, error) {
 	}
 
 	emitStage(req.OnStage, "exporting")
+	stages.start("exporting")
 	content, err := p.readFile(textPath)
 	if err != nil {
-		_ = p.removeAll(tempDir)
+		workspace := p.releaseWorkspace(req, tempDir)
 		return Result{}, &PipelineError{
 			Stage:      "exporting",
+			Workspace:  workspace,
 			Message:    fmt.Sprintf("failed to read transcript file: %s", textPath),
 			CommandLog: whisperLog,
 			Err:        err,
 		}
 	}
 
+	if req.TextEncoding != domain.TextEncodingUTF8 || req.LineEnding != domain.LineEndingLF {
+		if err := p.writeText(req, textPath, string(content)); err != nil {
+			workspace := p.releaseWorkspace(req, tempDir)
+			return Result{}, &PipelineError{
+				Stage:      "exporting",
+				Workspace:  workspace,
+				Message:    fmt.Sprintf("failed to re-encode transcript file: %s", textPath),
+				CommandLog: whisperLog,
+				Err:        err,
+			}
+		}
+	}
+
+	segments, detected := p.readSegments(textBase, line)
+	if corrector != nil {
+		for i := range segments {
+			segments[i].Text = corrector.correct(segments[i].Text)
+		}
+		if corrected := corrector.correct(string(content)); corrected != string(content) {
+			if err := p.writeText(req, textPath, corrected); err != nil {
+				workspace := p.releaseWorkspace(req, tempDir)
+				return Result{}, &PipelineError{
+					Stage:      "exporting",
+					Workspace:  workspace,
+					Message:    fmt.Sprintf("failed to write corrected transcript: %s", textPath),
+					CommandLog: whisperLog,
+					Err:        err,
+				}
+			}
+			content = []byte(corrected)
+		}
+	}
+	segments, suspects, hallucinationPath, err := p.filterHallucinations(req, segments, textPath, textBase, audioMs)
+	if err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    fmt.Sprintf("failed to write hallucination report: %s", hallucinationPath),
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+	if req.HallucinationFilter == domain.HallucinationFilterRemove && len(suspects) > 0 {
+		content = []byte(segmentsText(segments))
+	}
+
+	silence, silencePath, err := p.exportSilence(req, segments, skipped, audioMs, textBase)
+	if err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    fmt.Sprintf("failed to write silence map: %s", silencePath),
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+	if text, err := p.rewriteTranscript(req, segments, silence, textPath); err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    fmt.Sprintf("failed to write transcript: %s", textPath),
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	} else if text != "" {
+		content = []byte(text)
+	}
+
+	reviewPath, err := p.exportReview(req, textBase, segments)
+	if err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    fmt.Sprintf("failed to write review report: %s", reviewPath),
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+
+	subtitlePaths, err := p.exportSubtitles(req, segments, textBase, subtitleLanguage(language, detected))
+	if err != nil {
+		message := "failed to write subtitles"
+		if strings.TrimSpace(req.ScriptText) != "" {
+			message = fmt.Sprintf("failed to align script: %v", err)
+		}
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    message,
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+
+	lyricsPath, err := p.exportLRC(req, segments, textBase)
+	if err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    fmt.Sprintf("failed to write lyrics: %s", lyricsPath),
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+
+	chapterPaths, err := p.exportChapters(req, segments, textBase)
+	if err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    "failed to write chapter transcripts",
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+
+	segmentsPath, err := p.exportSegments(segments, detected, textBase)
+	if err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    fmt.Sprintf("failed to write segments: %s", segmentsPath),
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+
+	reserved := []string{req.InputPath, textPath, textBase + ".json", hallucinationPath, silencePath, reviewPath, lyricsPath, segmentsPath}
+	reserved = append(append(reserved, subtitlePaths...), chapterPaths...)
+	transformPaths, err := p.exportTransforms(ctx, req, TransformData{
+		Name:       filepath.Base(textBase),
+		Input:      req.InputPath,
+		Language:   detected,
+		Transcript: strings.TrimSpace(string(content)),
+		Segments:   segments,
+	}, textBase, reserved)
+	if err != nil {
+		workspace := p.releaseWorkspace(req, tempDir)
+		return Result{}, &PipelineError{
+			Stage:      "exporting",
+			Workspace:  workspace,
+			Message:    fmt.Sprintf("failed to run %v", err),
+			CommandLog: whisperLog,
+			Err:        err,
+		}
+	}
+
+	if cacheKey != "" && normalizeLanguage(language) == "" && detected != "" {
+		_ = req.Cache.SetLanguage(cacheKey, detected)
+	}
+
+	cleanupDir := tempDir
+	if req.KeepIntermediates {
+		cleanupDir = ""
+	}
 	return Result{
 		PreprocessedAudioPath: outPath,
 		TextPath:              textPath,
 		Transcript:            strings.TrimSpace(string(content)),
-		Logs:                  []CommandLog{log, whisperLog},
-		tempDir:               tempDir,
+		Segments:              segments,
+		ReviewPath:            reviewPath,
+		SubtitlePaths:         subtitlePaths,
+		ChapterPaths:          chapterPaths,
+		LyricsPath:            lyricsPath,
+		SegmentsPath:          segmentsPath,
+		TransformPaths:        transformPaths,
+		Language:              detected,
+		Tempo:                 tempo,
+		Skipped:               skipped,
+		Hallucinations:        suspects,
+		HallucinationPath:     hallucinationPath,
+		Silence:               silence,
+		SilenceMapPath:        silencePath,
+		Cached:                cached,
+		Stages:                stages.finish(),
+		Logs:                  append(logs, whisperLog),
+		tempDir:               cleanupDir,
 	}, nil
 }
 
+// transcribeAudio runs whisper on audioPath, on the warm server when the
+// request has one, writing textPath and the -ojf JSON at textBase.json.
+func (p *Pipeline) transcribeAudio(ctx context.Context, req Request, whisperPath, modelPath, audioPath, textPath, textBase, language string) (CommandLog, error) {
+	if req.Server != nil && req.Grammar.Mode == domain.GrammarOff {
+		return p.transcribeWithServer(ctx, req, modelPath, audioPath, textPath, textBase, language)
+	}
+	args := append(buildWhisperArgs(modelPath, audioPath, textBase, language), whisperPromptArgs(req.Terms)...)
+	args = append(args, whisperDeviceArgs(req.GPUDevice)...)
+	// Checked before the job started; only the file could have gone since.
+	grammarArgs, err := p.grammarArgs(req.Grammar)
+	if err != nil {
+		return CommandLog{Command: whisperPath}, err
+	}
+	args = append(args, grammarArgs...)
+	result, err := p.runStage(ctx, req.TranscribeTimeout, commandOptions{
+		onLine:      progressForwarder(segmentForwarder(outputForwarder(req.OnOutput, whisperPath), req.OnSegment), req.OnProgress, "transcribing", parseWhisperProgress),
+		lowPriority: req.LowPriority,
+		env:         whisperDeviceEnv(req.GPUDevice),
+	}, whisperPath, args...)
+	return CommandLog{
+		Command:         whisperPath,
+		Args:            args,
+		ExitCode:        result.ExitCode,
+		Stdout:          result.Stdout,
+		Stderr:          result.Stderr,
+		PeakMemoryBytes: result.PeakMemoryBytes,
+	}, err
+}
+
+// readSegments parses whisper's JSON next to the transcript, removes it from
+// the output directory, and maps the segments back to the input's timeline.
+// It also returns the language the JSON reports. A missing or unreadable JSON
+// file only means no segments: the transcript itself is complete without it.
+func (p *Pipeline) readSegments(textBase string, line timeline) ([]TranscriptSegment, string) {
+	jsonPath := textBase + ".json"
+	data, err := p.readFile(jsonPath)
+	if err != nil {
+		return nil, ""
+	}
+	_ = p.removeAll(jsonPath)
+	segments, err := parseWhisperJSON(data)
+	if err != nil {
+		return nil, ""
+	}
+	return line.segments(segments), whisperLanguage(data)
+}
+
+// exportReview writes <name>.review.txt when any passage falls below the
+// review threshold and returns its path, or "" when nothing needs review.
+func (p *Pipeline) exportReview(req Request, textBase string, segments []TranscriptSegment) (string, error) {
+	threshold := req.ReviewThreshold
+	if threshold <= 0 {
+		threshold = DefaultReviewThreshold
+	}
+	reviewPath := textBase + ".review.txt"
+	flagged := lowConfidenceSegments(segments, threshold)
+	if len(flagged) == 0 {
+		// Drop a stale report from an earlier run of the same file.
+		_ = p.removeAll(reviewPath)
+		return "", nil
+	}
+	report := buildReviewReport(req.InputPath, len(segments), flagged, threshold)
+	if err := p.writeText(req, reviewPath, report); err != nil {
+		return reviewPath, err
+	}
+	return reviewPath, nil
+}
+
+// exportSubtitles writes <name>.srt and <name>.vtt next to the transcript when
+// the request asks for subtitles or aligns a script. Cues are whisper's own
+// segments, or with a script, the script timed against them, with lines
+// wrapped by the rules of language. Without segments there is nothing to
+// time, which only fails alignment.
+func (p *Pipeline) exportSubtitles(req Request, segments []TranscriptSegment, textBase, language string) ([]string, error) {
+	aligning := strings.TrimSpace(req.ScriptText) != ""
+	if !req.Subtitles && !aligning {
+		return nil, nil
+	}
+	cues := make([]subtitle.Cue, 0, len(segments))
+	for _, segment := range segments {
+		if segment.Text == "" {
+			continue
+		}
+		text := segment.labeledText()
+		if aligning {
+			// Speaker labels are not spoken, so they would not match the script.
+			text = segment.Text
+		}
+		cues = append(cues, subtitle.Cue{StartMs: segment.StartMs, EndMs: segment.EndMs, Text: text})
+	}
+	if aligning {
+		if len(cues) == 0 {
+			return nil, errors.New("whisper produced no timed segments to align against")
+		}
+		aligned, err := subtitle.Align(req.ScriptText, cues)
+		if err != nil {
+			return nil, err
+		}
+		cues = aligned
+	}
+	if len(cues) == 0 {
+		return nil, nil
+	}
+	cues = subtitle.Wrap(cues, subtitle.DefaultLineWidth, language)
+
+	srtPath, vttPath := textBase+".srt", textBase+".vtt"
+	if err := p.writeText(req, srtPath, subtitle.FormatSRT(cues)); err != nil {
+		return nil, err
+	}
+	if err := p.writeText(req, vttPath, subtitle.FormatVTT(cues)); err != nil {
+		return nil, err
+	}
+	return []string{srtPath, vttPath}, nil
+}
+
+// writeText writes an exported text file in the request's encoding and line endings.
+func (p *Pipeline) writeText(req Request, path, text string) error {
+	return p.writeFile(path, textenc.Encode(text, req.TextEncoding, req.LineEnding), 0o644)
+}
+
+// releaseWorkspace removes a failed run's workspace unless the request retains
+// intermediates, in which case it returns the directory for the error report.
+func (p *Pipeline) releaseWorkspace(req Request, dir string) string {
+	if req.KeepIntermediates {
+		return dir
+	}
+	_ = p.removeAll(dir)
+	return ""
+}
+
+// runStage runs one command under an optional stage timeout. Deadline failures,
+// whether from the stage or the caller's job context, are wrapped with ErrTimeout.
+func (p *Pipeline) runStage(ctx context.Context, timeout time.Duration, opts commandOptions, name string, args ...string) (commandResult, error) {
+	stageCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		stageCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result, err := p.runner.Run(stageCtx, opts, name, args...)
+	if err != nil && errors.Is(stageCtx.Err(), context.DeadlineExceeded) {
+		return result, fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return result, err
+}
+
+// failureMessage describes a failed command, distinguishing timeouts.
+func failureMessage(action string, err error) string {
+	if errors.Is(err, ErrTimeout) {
+		return action + " timed out"
+	}
+	return action + " failed"
+}
+
 // emitStage forwards stage updates when callback is configured.
 func emitStage(cb func(stage string), stage string) {
 	if cb != nil {
@@ -307,6 +953,23 @@ func emitLog(cb func(log CommandLog), log CommandLog) {
 	}
 }
 
+// outputForwarder adapts OnOutput to the runner's per-line callback; nil when unset.
+func outputForwarder(cb func(line OutputLine), command string) func(stream, text string) {
+	if cb == nil {
+		return nil
+	}
+	return func(stream, text string) {
+		cb(OutputLine{Command: command, Stream: stream, Text: text})
+	}
+}
+
+// ResolveModelPath returns the model file Run loads for a configured model file
+// or directory.
+func ResolveModelPath(rawPath string) (string, error) {
+	p := &Pipeline{stat: os.Stat, readDir: os.ReadDir}
+	return p.resolveModelPath(rawPath)
+}
+
 // resolveModelPath returns model file path from file or directory input.
 func (p *Pipeline) resolveModelPath(rawPath string) (string, error) {
 	modelPath := strings.TrimSpace(rawPath)
@@ -346,6 +1009,15 @@ func (p *Pipeline) resolveModelPath(rawPath string) (string, error) {
 	return filepath.Join(modelPath, modelNames[0]), nil
 }
 
+// subtitleLanguage is the configured language, or with automatic detection
+// the one whisper reported.
+func subtitleLanguage(configured, detected string) string {
+	if language := normalizeLanguage(configured); language != "" {
+		return language
+	}
+	return detected
+}
+
 // normalizeLanguage maps "auto" and empty language to no CLI override.
 func normalizeLanguage(raw string) string {
 	lang := strings.TrimSpace(raw)
@@ -355,28 +1027,40 @@ func normalizeLanguage(raw string) string {
 	return lang
 }
 
-// buildFFmpegArgs builds preprocessing CLI args for mono 16k PCM WAV output.
-func buildFFmpegArgs(inputPath, outPath string) []string {
-	return []string{
+// buildFFmpegArgs builds preprocessing CLI args for mono 16k PCM WAV output,
+// decoding with the hwaccel method when one is given and speeding the audio
+// up by tempo when it is above 1.
+func buildFFmpegArgs(inputPath, outPath, hwaccel string, tempo float64) []string {
+	args := []string{
 		"-hide_banner",
 		"-nostdin",
 		"-y",
-		"-i", inputPath,
-		"-vn",
+	}
+	if hwaccel != "" {
+		args = append(args, "-hwaccel", hwaccel)
+	}
+	args = append(args, "-i", inputPath, "-vn")
+	if tempo > 1 {
+		args = append(args, "-af", "atempo="+formatTempo(tempo))
+	}
+	return append(args,
 		"-ac", "1",
 		"-ar", "16000",
 		"-c:a", "pcm_s16le",
 		outPath,
-	}
+	)
 }
 
-// buildWhisperArgs builds whisper.cpp args for txt transcript export.
+// buildWhisperArgs builds whisper.cpp args for txt transcript export plus full
+// JSON output, which carries the token probabilities used for confidence.
 func buildWhisperArgs(modelPath, audioPath, textBase, language string) []string {
 	args := []string{
 		"-m", modelPath,
 		"-f", audioPath,
 		"-of", textBase,
 		"-otxt",
+		"-ojf",
+		"-pp",
 	}
 
 	if lang := normalizeLanguage(language); lang != "" {
@@ -386,6 +1070,46 @@ func buildWhisperArgs(modelPath, audioPath, textBase, language string) []string
 	return args
 }
 
+// CPUDevice is the GPUDevice that runs whisper without a GPU.
+const CPUDevice = "cpu"
+
+// whisperDeviceArgs selects the GPU of device: -ng for "cpu", -dev for an
+// index, nothing for whisper's default.
+func whisperDeviceArgs(device string) []string {
+	switch device {
+	case "":
+		return nil
+	case CPUDevice:
+		return []string{"-ng"}
+	default:
+		return []string{"-dev", device}
+	}
+}
+
+// whisperDeviceEnv makes CUDA number GPUs in PCI bus order, as nvidia-smi
+// does, when device selects one by index; by default CUDA puts the fastest
+// first, so an index from nvidia-smi could name another card.
+func whisperDeviceEnv(device string) []string {
+	if device == "" || device == CPUDevice {
+		return nil
+	}
+	return []string{"CUDA_DEVICE_ORDER=PCI_BUS_ID"}
+}
+
+// TranscriptPath returns where Run writes the .txt transcript of inputPath.
+func TranscriptPath(outputDir, inputPath string) string {
+	return filepath.Join(outputDir, transcriptFileName(inputPath))
+}
+
+// outputTextPath returns the transcript path of the request, named after
+// OutputName when set and after the input file otherwise.
+func outputTextPath(req Request) string {
+	if req.OutputName != "" {
+		return filepath.Join(req.OutputDir, req.OutputName+".txt")
+	}
+	return TranscriptPath(req.OutputDir, req.InputPath)
+}
+
 // transcriptFileName builds output text filename from input media name.
 func transcriptFileName(inputPath string) string {
 	base := filepath.Base(inputPath)
@@ -415,5 +1139,6 @@ func NewPipelineForTests(
 		mkdirAll:    os.MkdirAll,
 		readDir:     os.ReadDir,
 		readFile:    os.ReadFile,
+		writeFile:   os.WriteFile,
 	}
 }
@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
 )
 
 // fakeRunner simulates command execution order and outcomes.
@@ -15,7 +18,7 @@ type fakeRunner struct {
 }
 
 // Run delegates to injected behavior.
-func (f *fakeRunner) Run(ctx context.Context, name string, args ...string) (commandResult, error) {
+func (f *fakeRunner) Run(ctx context.Context, opts commandOptions, name string, args ...string) (commandResult, error) {
 	if f.run == nil {
 		return commandResult{}, nil
 	}
@@ -51,7 +54,7 @@ func TestPipelineRunSuccessAutoLanguage(t *testing.T) {
 				whisperArgs = append([]string{}, args...)
 				base := argValue(args, "-of")
 				mustWriteFile(t, base+".txt", "hello world")
-				return commandResult{Stdout: "whisper ok", ExitCode: 0}, nil
+				return commandResult{Stdout: "whisper ok", ExitCode: 0, PeakMemoryBytes: 512 << 20}, nil
 			default:
 				t.Fatalf("unexpected command call: %d", call)
 				return commandResult{}, nil
@@ -76,6 +79,12 @@ func TestPipelineRunSuccessAutoLanguage(t *testing.T) {
 	if len(result.Logs) != 2 {
 		t.Fatalf("logs count = %d, want 2", len(result.Logs))
 	}
+	if len(result.Stages) != 3 || result.Stages[0].Stage != "preprocessing" || result.Stages[1].Stage != "transcribing" || result.Stages[2].Stage != "exporting" {
+		t.Fatalf("stages = %+v", result.Stages)
+	}
+	if result.Stages[1].PeakMemoryBytes != 512<<20 {
+		t.Fatalf("transcribing peak memory = %d", result.Stages[1].PeakMemoryBytes)
+	}
 	if result.TextPath != filepath.Join(outputDir, "meeting.txt") {
 		t.Fatalf("text path = %q", result.TextPath)
 	}
@@ -291,7 +300,7 @@ func TestPipelineRunRequiresModelPath(t *testing.T) {
 
 // TestBuildFFmpegArgs verifies deterministic ffmpeg command arguments.
 func TestBuildFFmpegArgs(t *testing.T) {
-	args := buildFFmpegArgs("/in.mp4", "/tmp/out.wav")
+	args := buildFFmpegArgs("/in.mp4", "/tmp/out.wav", "", 1)
 	want := []string{
 		"-hide_banner",
 		"-nostdin",
@@ -314,6 +323,15 @@ func TestBuildFFmpegArgs(t *testing.T) {
 	}
 }
 
+// TestBuildFFmpegArgsHWAccel places -hwaccel before the input it applies to.
+func TestBuildFFmpegArgsHWAccel(t *testing.T) {
+	args := buildFFmpegArgs("/in.mp4", "/tmp/out.wav", "vaapi", 1)
+	got := strings.Join(args[:7], " ")
+	if want := "-hide_banner -nostdin -y -hwaccel vaapi -i /in.mp4"; got != want {
+		t.Fatalf("args = %q, want prefix %q", got, want)
+	}
+}
+
 // TestBuildWhisperArgsAutoLanguage verifies no language flag for auto mode.
 func TestBuildWhisperArgsAutoLanguage(t *testing.T) {
 	args := buildWhisperArgs("/m.bin", "/audio.wav", "/out/base", "auto")
@@ -333,6 +351,28 @@ func TestBuildWhisperArgsFixedLanguage(t *testing.T) {
 	}
 }
 
+// TestWhisperDeviceArgs maps the GPU device to whisper flags and pins CUDA
+// to nvidia-smi's numbering when an index is chosen.
+func TestWhisperDeviceArgs(t *testing.T) {
+	tests := []struct {
+		device   string
+		wantArgs string
+		wantEnv  string
+	}{
+		{device: "", wantArgs: "", wantEnv: ""},
+		{device: CPUDevice, wantArgs: "-ng", wantEnv: ""},
+		{device: "1", wantArgs: "-dev 1", wantEnv: "CUDA_DEVICE_ORDER=PCI_BUS_ID"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(whisperDeviceArgs(tt.device), " "); got != tt.wantArgs {
+			t.Fatalf("whisperDeviceArgs(%q) = %q, want %q", tt.device, got, tt.wantArgs)
+		}
+		if got := strings.Join(whisperDeviceEnv(tt.device), " "); got != tt.wantEnv {
+			t.Fatalf("whisperDeviceEnv(%q) = %q, want %q", tt.device, got, tt.wantEnv)
+		}
+	}
+}
+
 // mustWriteFile creates parent directory and writes file content.
 func mustWriteFile(t *testing.T, path, content string) {
 	t.Helper()
@@ -363,3 +403,409 @@ func hasArg(args []string, key string) bool {
 	}
 	return false
 }
+
+// TestPipelineRunUsesConfiguredExecutables checks request overrides of tool paths.
+func TestPipelineRunUsesConfiguredExecutables(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "talk.wav")
+	modelPath := filepath.Join(root, "model.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	var names []string
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			names = append(names, name)
+			if len(names) == 1 {
+				mustWriteFile(t, args[len(args)-1], "wav")
+			} else {
+				mustWriteFile(t, argValue(args, "-of")+".txt", "text")
+			}
+			return commandResult{}, nil
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper.cpp", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath:   inputPath,
+		ModelPath:   modelPath,
+		OutputDir:   filepath.Join(root, "out"),
+		FFmpegPath:  "/opt/ffmpeg/bin/ffmpeg",
+		WhisperPath: "/opt/whisper-cuda/whisper-cli",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if len(names) != 2 || names[0] != "/opt/ffmpeg/bin/ffmpeg" || names[1] != "/opt/whisper-cuda/whisper-cli" {
+		t.Fatalf("commands = %v", names)
+	}
+}
+
+// TestPipelineRunUsesOutputName names every output after Request.OutputName.
+func TestPipelineRunUsesOutputName(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "rec-0042.m4a")
+	modelPath := filepath.Join(root, "model.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+			} else {
+				mustWriteFile(t, argValue(args, "-of")+".txt", "text")
+			}
+			return commandResult{}, nil
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath:  inputPath,
+		ModelPath:  modelPath,
+		OutputDir:  filepath.Join(root, "out"),
+		OutputName: "2026-10-16 Budget review",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if result.TextPath != filepath.Join(root, "out", "2026-10-16 Budget review.txt") {
+		t.Fatalf("text path = %q", result.TextPath)
+	}
+	for _, path := range result.SubtitlePaths {
+		if !strings.HasPrefix(filepath.Base(path), "2026-10-16 Budget review.") {
+			t.Fatalf("subtitle path = %q", path)
+		}
+	}
+}
+
+// TestPipelineRunEncodesTextExports rewrites whisper's transcript and writes
+// subtitles in the requested encoding and line endings.
+func TestPipelineRunEncodesTextExports(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "talk.m4a")
+	modelPath := filepath.Join(root, "model.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+			} else {
+				mustWriteFile(t, argValue(args, "-of")+".txt", "Первая строка\nВторая\n")
+			}
+			return commandResult{}, nil
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath:    inputPath,
+		ModelPath:    modelPath,
+		OutputDir:    filepath.Join(root, "out"),
+		TextEncoding: domain.TextEncodingUTF8BOM,
+		LineEnding:   domain.LineEndingCRLF,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	data, err := os.ReadFile(result.TextPath)
+	if err != nil {
+		t.Fatalf("read transcript: %v", err)
+	}
+	if string(data) != "\ufeffПервая строка\r\nВторая\r\n" {
+		t.Fatalf("transcript bytes = %q", data)
+	}
+	if result.Transcript != "Первая строка\nВторая" {
+		t.Fatalf("transcript = %q", result.Transcript)
+	}
+
+	base := filepath.Join(root, "out", "talk")
+	paths, err := pipeline.exportSubtitles(Request{Subtitles: true, TextEncoding: domain.TextEncodingUTF16LE}, []TranscriptSegment{{EndMs: 1000, Text: "Hi"}}, base, "en")
+	if err != nil || len(paths) != 2 {
+		t.Fatalf("export subtitles = %v, %v", paths, err)
+	}
+	srt, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatalf("read srt: %v", err)
+	}
+	if !strings.HasPrefix(string(srt), "\xff\xfe1\x00\n\x00") {
+		t.Fatalf("srt bytes = %q", srt[:min(len(srt), 8)])
+	}
+}
+
+// TestPipelineRunHWAccelFallsBackToCPU retries preprocessing without -hwaccel
+// when the hardware decoder fails.
+func TestPipelineRunHWAccelFallsBackToCPU(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "clip.mp4")
+	modelPath := filepath.Join(root, "model.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	var ffmpegCalls [][]string
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name != "ffmpeg" {
+				mustWriteFile(t, argValue(args, "-of")+".txt", "text")
+				return commandResult{}, nil
+			}
+			ffmpegCalls = append(ffmpegCalls, args)
+			if argValue(args, "-hwaccel") != "" {
+				return commandResult{ExitCode: 1, Stderr: "No device available for decoder"}, errors.New("exit status 1")
+			}
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath: inputPath,
+		ModelPath: modelPath,
+		OutputDir: filepath.Join(root, "out"),
+		HWAccel:   "cuda",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if len(ffmpegCalls) != 2 {
+		t.Fatalf("ffmpeg calls = %d, want 2", len(ffmpegCalls))
+	}
+	if argValue(ffmpegCalls[0], "-hwaccel") != "cuda" || argValue(ffmpegCalls[1], "-hwaccel") != "" {
+		t.Fatalf("ffmpeg calls = %v", ffmpegCalls)
+	}
+}
+
+// TestPipelineRunReusesPreprocessCache skips ffmpeg on the second run of the
+// same input and reuses the language detected by the first.
+func TestPipelineRunReusesPreprocessCache(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "lecture.mp4")
+	modelPath := filepath.Join(root, "model.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	var commands []string
+	var whisperArgs []string
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			commands = append(commands, name)
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+				return commandResult{}, nil
+			}
+			whisperArgs = args
+			base := argValue(args, "-of")
+			mustWriteFile(t, base+".txt", "text")
+			mustWriteFile(t, base+".json", `{"result":{"language":"fr"},"transcription":[]}`)
+			return commandResult{}, nil
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	req := Request{
+		InputPath: inputPath,
+		ModelPath: modelPath,
+		Language:  "auto",
+		OutputDir: filepath.Join(root, "out"),
+		Cache:     NewPreprocessCache(filepath.Join(root, "cache"), 1<<20),
+	}
+	first, err := pipeline.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("first Run() error = %v", err)
+	}
+	defer first.Cleanup()
+	if first.Cached || first.Language != "fr" || hasArg(whisperArgs, "-l") {
+		t.Fatalf("first run: cached=%v language=%q whisper args=%v", first.Cached, first.Language, whisperArgs)
+	}
+
+	commands = nil
+	second, err := pipeline.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("second Run() error = %v", err)
+	}
+	defer second.Cleanup()
+	if !second.Cached || len(commands) != 1 || commands[0] != "whisper" {
+		t.Fatalf("second run: cached=%v commands=%v", second.Cached, commands)
+	}
+	if argValue(whisperArgs, "-l") != "fr" {
+		t.Fatalf("whisper args = %v, want cached language", whisperArgs)
+	}
+	if len(second.Logs) != 1 {
+		t.Fatalf("logs = %d, want only whisper", len(second.Logs))
+	}
+
+	// A hash the caller already computed is the key; the input is not read again.
+	req.InputHash, err = ContentHash(context.Background(), inputPath)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	mustWriteFile(t, inputPath, "changed media")
+	commands = nil
+	third, err := pipeline.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("third Run() error = %v", err)
+	}
+	defer third.Cleanup()
+	if !third.Cached || len(commands) != 1 {
+		t.Fatalf("third run: cached=%v commands=%v, want the given hash reused", third.Cached, commands)
+	}
+}
+
+// TestPipelineRunStageTimeoutReturnsTimeoutError checks per-stage deadlines.
+func TestPipelineRunStageTimeoutReturnsTimeoutError(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "corrupt.mp4")
+	modelPath := filepath.Join(root, "model.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			<-ctx.Done()
+			return commandResult{ExitCode: -1}, ctx.Err()
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper.cpp", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	_, err := pipeline.Run(context.Background(), Request{
+		InputPath:         inputPath,
+		ModelPath:         modelPath,
+		OutputDir:         filepath.Join(root, "out"),
+		PreprocessTimeout: 50 * time.Millisecond,
+	})
+
+	var pipelineErr *PipelineError
+	if !errors.As(err, &pipelineErr) || pipelineErr.Stage != "preprocessing" {
+		t.Fatalf("expected preprocessing PipelineError, got %v", err)
+	}
+	if !errors.Is(err, ErrTimeout) || !strings.Contains(pipelineErr.Message, "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Fatal("timeout must not be reported as cancellation")
+	}
+}
+
+// TestPipelineRunKeepIntermediatesRetainsWorkspace checks the workspace location and retention on failure.
+func TestPipelineRunKeepIntermediatesRetainsWorkspace(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "clip.mp4")
+	modelPath := filepath.Join(root, "model.bin")
+	outputDir := filepath.Join(root, "out")
+	scratchDir := filepath.Join(root, "scratch")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+	if err := os.MkdirAll(scratchDir, 0o755); err != nil {
+		t.Fatalf("mkdir scratch: %v", err)
+	}
+
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+				return commandResult{}, nil
+			}
+			return commandResult{ExitCode: 1}, errors.New("exit status 1")
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper.cpp", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	_, err := pipeline.Run(context.Background(), Request{
+		InputPath:         inputPath,
+		ModelPath:         modelPath,
+		OutputDir:         outputDir,
+		TempDir:           scratchDir,
+		KeepIntermediates: true,
+	})
+
+	var pErr *PipelineError
+	if !errors.As(err, &pErr) {
+		t.Fatalf("error = %v, want *PipelineError", err)
+	}
+	if filepath.Dir(pErr.Workspace) != scratchDir {
+		t.Fatalf("workspace = %q, want a directory under %q", pErr.Workspace, scratchDir)
+	}
+	if _, statErr := os.Stat(filepath.Join(pErr.Workspace, "preprocessed-16k-mono.wav")); statErr != nil {
+		t.Fatalf("intermediate audio should be kept: %v", statErr)
+	}
+}
+
+// TestSubtitleLanguage prefers the configured language over the detected one.
+func TestSubtitleLanguage(t *testing.T) {
+	tests := []struct{ configured, detected, want string }{
+		{configured: "ja", detected: "en", want: "ja"},
+		{configured: "auto", detected: "ar", want: "ar"},
+		{configured: "", detected: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := subtitleLanguage(tt.configured, tt.detected); got != tt.want {
+			t.Fatalf("subtitleLanguage(%q, %q) = %q, want %q", tt.configured, tt.detected, got, tt.want)
+		}
+	}
+}
+
+// TestPipelineErrorFailure classifies failures by what failed, telling a bad
+// input from ffmpeg and a missing model from whisper.
+func TestPipelineErrorFailure(t *testing.T) {
+	failing := func(command string) *fakeRunner {
+		return &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name == command {
+				return commandResult{ExitCode: 1}, errors.New("exit status 1")
+			}
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+			}
+			return commandResult{}, nil
+		}}
+	}
+	tests := []struct {
+		name    string
+		runner  *fakeRunner
+		request func(root string) Request
+		want    domain.Failure
+	}{
+		{name: "missing input", runner: failing(""), want: domain.FailureInput, request: func(root string) Request {
+			return Request{InputPath: filepath.Join(root, "gone.mp4"), ModelPath: filepath.Join(root, "model.bin"), OutputDir: root}
+		}},
+		{name: "missing model", runner: failing(""), want: domain.FailureModel, request: func(root string) Request {
+			return Request{InputPath: filepath.Join(root, "clip.mp4"), ModelPath: filepath.Join(root, "gone.bin"), OutputDir: root}
+		}},
+		{name: "ffmpeg", runner: failing("ffmpeg"), want: domain.FailureFFmpeg, request: func(root string) Request {
+			return Request{InputPath: filepath.Join(root, "clip.mp4"), ModelPath: filepath.Join(root, "model.bin"), OutputDir: root}
+		}},
+		{name: "whisper", runner: failing("whisper-cli"), want: domain.FailureWhisper, request: func(root string) Request {
+			return Request{InputPath: filepath.Join(root, "clip.mp4"), ModelPath: filepath.Join(root, "model.bin"), OutputDir: root}
+		}},
+		{name: "export", runner: failing(""), want: domain.FailureExport, request: func(root string) Request {
+			return Request{InputPath: filepath.Join(root, "clip.mp4"), ModelPath: filepath.Join(root, "model.bin")}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			mustWriteFile(t, filepath.Join(root, "clip.mp4"), "media")
+			mustWriteFile(t, filepath.Join(root, "model.bin"), "model")
+			pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", tt.runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+			_, err := pipeline.Run(context.Background(), tt.request(root))
+			var pErr *PipelineError
+			if !errors.As(err, &pErr) {
+				t.Fatalf("error = %v, want *PipelineError", err)
+			}
+			if got := pErr.Failure(); got != tt.want {
+				t.Fatalf("Failure() = %q, want %q (%v)", got, tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package transcribe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// PreprocessedBytesPerSecond is the size of the mono 16 kHz 16-bit WAV that
+// preprocessing writes into the job workspace, per second of input audio.
+const PreprocessedBytesPerSecond = 16000 * 2
+
+// PreprocessedHeaderBytes is the header size of the canonical WAV written by ffmpeg.
+const PreprocessedHeaderBytes = 44
+
+// FFprobePath returns the ffprobe next to a configured ffmpeg, or "ffprobe" to use PATH.
+func FFprobePath(ffmpegPath string) string {
+	ffmpegPath = strings.TrimSpace(ffmpegPath)
+	if ffmpegPath != "" {
+		candidate := filepath.Join(filepath.Dir(ffmpegPath), "ffprobe"+filepath.Ext(ffmpegPath))
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+	}
+	return "ffprobe"
+}
+
+// ProbeDuration returns the container duration of inputPath in seconds as reported by ffprobe.
+func ProbeDuration(ctx context.Context, ffprobePath, inputPath string) (float64, error) {
+	output, err := exec.CommandContext(ctx, ffprobePath,
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		inputPath,
+	).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return 0, fmt.Errorf("ffprobe %s: %s", filepath.Base(inputPath), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return 0, fmt.Errorf("ffprobe %s: %w", filepath.Base(inputPath), err)
+	}
+	return parseProbeDuration(string(output))
+}
+
+// ProbeChannels returns the channel count of the first audio stream of inputPath.
+func ProbeChannels(ctx context.Context, ffprobePath, inputPath string) (int, error) {
+	output, err := exec.CommandContext(ctx, ffprobePath,
+		"-v", "error",
+		"-select_streams", "a:0",
+		"-show_entries", "stream=channels",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		inputPath,
+	).Output()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe channels of %s: %w", filepath.Base(inputPath), err)
+	}
+	return parseProbeChannels(string(output))
+}
+
+// parseProbeChannels reads the bare channel count line ffprobe prints; no
+// output means the input has no audio stream.
+func parseProbeChannels(output string) (int, error) {
+	text := strings.TrimSpace(output)
+	if text == "" {
+		return 0, fmt.Errorf("input has no audio stream")
+	}
+	channels, err := strconv.Atoi(text)
+	if err != nil || channels < 1 {
+		return 0, fmt.Errorf("unexpected ffprobe channel count %q", text)
+	}
+	return channels, nil
+}
+
+// parseProbeDuration reads the bare duration line ffprobe prints; "N/A" means
+// the container carries no duration (raw streams, broken files).
+func parseProbeDuration(output string) (float64, error) {
+	text := strings.TrimSpace(output)
+	if text == "" || text == "N/A" {
+		return 0, fmt.Errorf("media duration is unknown")
+	}
+	seconds, err := strconv.ParseFloat(text, 64)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("unexpected ffprobe duration %q", text)
+	}
+	return seconds, nil
+}
+
+// Chapter is one chapter marker embedded in the input (audiobooks, mka, mkv).
+type Chapter struct {
+	Title   string `json:"title"`
+	StartMs int64  `json:"startMs"`
+	EndMs   int64  `json:"endMs"`
+}
+
+// ProbeChapters returns the chapter markers of inputPath as reported by
+// ffprobe; inputs without chapters return none.
+func ProbeChapters(ctx context.Context, ffprobePath, inputPath string) ([]Chapter, error) {
+	output, err := exec.CommandContext(ctx, ffprobePath,
+		"-v", "error",
+		"-show_chapters",
+		"-of", "json",
+		inputPath,
+	).Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe chapters of %s: %w", filepath.Base(inputPath), err)
+	}
+	return parseProbeChapters(output)
+}
+
+// parseProbeChapters reads ffprobe's JSON chapter list. Untitled chapters are
+// numbered.
+func parseProbeChapters(data []byte) ([]Chapter, error) {
+	var doc struct {
+		Chapters []struct {
+			StartTime string            `json:"start_time"`
+			EndTime   string            `json:"end_time"`
+			Tags      map[string]string `json:"tags"`
+		} `json:"chapters"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode ffprobe chapters: %w", err)
+	}
+
+	chapters := make([]Chapter, 0, len(doc.Chapters))
+	for i, raw := range doc.Chapters {
+		start, err := strconv.ParseFloat(raw.StartTime, 64)
+		if err != nil {
+			return nil, fmt.Errorf("chapter %d start %q: %w", i+1, raw.StartTime, err)
+		}
+		end, err := strconv.ParseFloat(raw.EndTime, 64)
+		if err != nil {
+			return nil, fmt.Errorf("chapter %d end %q: %w", i+1, raw.EndTime, err)
+		}
+		title := strings.TrimSpace(raw.Tags["title"])
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", i+1)
+		}
+		chapters = append(chapters, Chapter{Title: title, StartMs: int64(start * 1000), EndMs: int64(end * 1000)})
+	}
+	return chapters, nil
+}
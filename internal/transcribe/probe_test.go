@@ -0,0 +1,83 @@
+package transcribe
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseProbeDuration covers ffprobe duration output variants.
+func TestParseProbeDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    float64
+		wantErr bool
+	}{
+		{name: "seconds", output: "123.456000\n", want: 123.456},
+		{name: "zero", output: "0.000000", want: 0},
+		{name: "not available", output: "N/A\n", wantErr: true},
+		{name: "empty", output: "", wantErr: true},
+		{name: "garbage", output: "duration=12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProbeDuration(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("duration = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseProbeChannels reads ffprobe's channel count line.
+func TestParseProbeChannels(t *testing.T) {
+	tests := []struct {
+		output  string
+		want    int
+		wantErr bool
+	}{
+		{output: "2\n", want: 2},
+		{output: "1", want: 1},
+		{output: "", wantErr: true},
+		{output: "stereo", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseProbeChannels(tt.output)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%q: err = %v, wantErr %v", tt.output, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("%q: channels = %d, want %d", tt.output, got, tt.want)
+		}
+	}
+}
+
+// TestParseProbeChapters reads ffprobe's chapter JSON and numbers untitled chapters.
+func TestParseProbeChapters(t *testing.T) {
+	output := `{"chapters": [
+		{"id": 0, "time_base": "1/1000", "start_time": "0.000000", "end_time": "754.250000", "tags": {"title": "Prologue"}},
+		{"id": 1, "time_base": "1/1000", "start_time": "754.250000", "end_time": "1800.000000"}
+	]}`
+	chapters, err := parseProbeChapters([]byte(output))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []Chapter{
+		{Title: "Prologue", StartMs: 0, EndMs: 754250},
+		{Title: "Chapter 2", StartMs: 754250, EndMs: 1800000},
+	}
+	if !reflect.DeepEqual(chapters, want) {
+		t.Fatalf("chapters = %+v, want %+v", chapters, want)
+	}
+
+	if chapters, err := parseProbeChapters([]byte(`{}`)); err != nil || len(chapters) != 0 {
+		t.Fatalf("no chapters = %v, %v", chapters, err)
+	}
+	if _, err := parseProbeChapters([]byte(`{"chapters": [{"start_time": "x", "end_time": "1"}]}`)); err == nil {
+		t.Fatal("expected error for malformed start time")
+	}
+}
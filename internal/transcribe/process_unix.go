@@ -0,0 +1,36 @@
+//go:build !windows
+
+package transcribe
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// lowPriorityNice is the nice value used for background-friendly jobs.
+const lowPriorityNice = 10
+
+// configureProcess starts the command in its own process group so cancellation
+// and priority changes reach every child it spawns.
+func configureProcess(cmd *exec.Cmd, lowPriority bool) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// lowerProcessPriority renices the process group and lowers its I/O priority where supported.
+func lowerProcessPriority(process *os.Process) error {
+	if err := syscall.Setpriority(syscall.PRIO_PGRP, process.Pid, lowPriorityNice); err != nil {
+		return err
+	}
+	return lowerIOPriority(process.Pid)
+}
+
+// interruptProcessGroup sends SIGINT to the whole process group.
+func interruptProcessGroup(process *os.Process) error {
+	return syscall.Kill(-process.Pid, syscall.SIGINT)
+}
+
+// killProcessGroup sends SIGKILL to the whole process group.
+func killProcessGroup(process *os.Process) error {
+	return syscall.Kill(-process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,49 @@
+package transcribe
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+const (
+	// ctrlBreakEvent is CTRL_BREAK_EVENT for GenerateConsoleCtrlEvent.
+	ctrlBreakEvent = 1
+	// belowNormalPriorityClass is BELOW_NORMAL_PRIORITY_CLASS; child processes inherit it.
+	belowNormalPriorityClass = 0x00004000
+)
+
+var procGenerateConsoleCtrlEvent = syscall.NewLazyDLL("kernel32.dll").NewProc("GenerateConsoleCtrlEvent")
+
+// configureProcess starts the command in a new process group so it can receive
+// CTRL_BREAK without affecting the app, optionally below normal priority.
+func configureProcess(cmd *exec.Cmd, lowPriority bool) {
+	flags := uint32(syscall.CREATE_NEW_PROCESS_GROUP)
+	if lowPriority {
+		flags |= belowNormalPriorityClass
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: flags}
+}
+
+// lowerProcessPriority is a no-op; the priority class is set at creation.
+func lowerProcessPriority(process *os.Process) error {
+	return nil
+}
+
+// interruptProcessGroup sends CTRL_BREAK to the process group. It fails when the
+// app has no console attached, in which case the caller escalates to a kill.
+func interruptProcessGroup(process *os.Process) error {
+	ok, _, err := procGenerateConsoleCtrlEvent.Call(ctrlBreakEvent, uintptr(process.Pid))
+	if ok == 0 {
+		return err
+	}
+	return nil
+}
+
+// killProcessGroup terminates the process and all of its children with taskkill /T.
+func killProcessGroup(process *os.Process) error {
+	cmd := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(process.Pid))
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd.Run()
+}
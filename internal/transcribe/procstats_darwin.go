@@ -0,0 +1,18 @@
+package transcribe
+
+import (
+	"os"
+	"syscall"
+)
+
+// peakMemoryBytes returns the child's max resident set size (macOS reports bytes).
+func peakMemoryBytes(state *os.ProcessState) uint64 {
+	if state == nil {
+		return 0
+	}
+	usage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || usage == nil || usage.Maxrss < 0 {
+		return 0
+	}
+	return uint64(usage.Maxrss)
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package transcribe
+
+import "os"
+
+// peakMemoryBytes is not measured on this platform.
+func peakMemoryBytes(state *os.ProcessState) uint64 {
+	return 0
+}
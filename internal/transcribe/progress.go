@@ -0,0 +1,109 @@
+package transcribe
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ffmpegDurationPattern  = regexp.MustCompile(`Duration: (\d+):(\d{2}):(\d{2}(?:\.\d+)?)`)
+	ffmpegTimePattern      = regexp.MustCompile(`time=(\d+):(\d{2}):(\d{2}(?:\.\d+)?)`)
+	whisperProgressPattern = regexp.MustCompile(`progress\s*=\s*(\d+)%`)
+	whisperSegmentPattern  = regexp.MustCompile(`^\s*\[(\d+):(\d{2}):(\d{2}\.\d+)\s*-->\s*(\d+):(\d{2}):(\d{2}\.\d+)\]\s*(.*)$`)
+)
+
+// ffmpegProgress turns ffmpeg stderr into a completion fraction: the first
+// "Duration:" line sets the total and each "time=" status line the position.
+// With atempo the position is on the sped-up output, so it is scaled by tempo.
+type ffmpegProgress struct {
+	totalSeconds float64
+	tempo        float64
+}
+
+// parse returns the completion fraction reported by one output line.
+func (p *ffmpegProgress) parse(line string) (float64, bool) {
+	if p.totalSeconds == 0 {
+		if match := ffmpegDurationPattern.FindStringSubmatch(line); match != nil {
+			p.totalSeconds = clockSeconds(match[1:])
+		}
+		return 0, false
+	}
+	match := ffmpegTimePattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+	return min(clockSeconds(match[1:])*max(p.tempo, 1)/p.totalSeconds, 1), true
+}
+
+// parseWhisperProgress reads whisper.cpp's --print-progress percentage lines.
+func parseWhisperProgress(line string) (float64, bool) {
+	match := whisperProgressPattern.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+	percent, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return min(float64(percent)/100, 1), true
+}
+
+// clockSeconds converts captured hours, minutes, and seconds to seconds.
+func clockSeconds(parts []string) float64 {
+	hours, _ := strconv.ParseFloat(parts[0], 64)
+	minutes, _ := strconv.ParseFloat(parts[1], 64)
+	seconds, _ := strconv.ParseFloat(parts[2], 64)
+	return hours*3600 + minutes*60 + seconds
+}
+
+// progressForwarder wraps a line callback so progress lines of one stage are
+// also reported through onProgress.
+func progressForwarder(onLine func(stream, text string), onProgress func(stage string, fraction float64), stage string, parse func(line string) (float64, bool)) func(stream, text string) {
+	if onProgress == nil {
+		return onLine
+	}
+	return func(stream, text string) {
+		if fraction, ok := parse(text); ok {
+			onProgress(stage, fraction)
+		}
+		if onLine != nil {
+			onLine(stream, text)
+		}
+	}
+}
+
+// parseWhisperSegment reads one segment line whisper.cpp prints while it runs,
+// "[00:00:01.000 --> 00:00:03.500]  text". Live segments carry no confidence;
+// the final JSON has it.
+func parseWhisperSegment(line string) (TranscriptSegment, bool) {
+	match := whisperSegmentPattern.FindStringSubmatch(line)
+	if match == nil {
+		return TranscriptSegment{}, false
+	}
+	text := strings.TrimSpace(match[7])
+	if text == "" {
+		return TranscriptSegment{}, false
+	}
+	return TranscriptSegment{
+		StartMs: int64(clockSeconds(match[1:4])*1000 + 0.5),
+		EndMs:   int64(clockSeconds(match[4:7])*1000 + 0.5),
+		Text:    text,
+	}, true
+}
+
+// segmentForwarder wraps a line callback so segment lines, on either stream,
+// are also reported through onSegment.
+func segmentForwarder(onLine func(stream, text string), onSegment func(segment TranscriptSegment)) func(stream, text string) {
+	if onSegment == nil {
+		return onLine
+	}
+	return func(stream, text string) {
+		if segment, ok := parseWhisperSegment(text); ok {
+			onSegment(segment)
+		}
+		if onLine != nil {
+			onLine(stream, text)
+		}
+	}
+}
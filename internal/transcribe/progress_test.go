@@ -0,0 +1,97 @@
+package transcribe
+
+import "testing"
+
+// TestFFmpegProgressParsesDurationAndTime checks fraction tracking across ffmpeg lines.
+func TestFFmpegProgressParsesDurationAndTime(t *testing.T) {
+	progress := &ffmpegProgress{}
+	lines := []struct {
+		line string
+		want float64
+		ok   bool
+	}{
+		{line: "size=       0kB time=00:00:01.00 bitrate=N/A", ok: false},
+		{line: "  Duration: 00:01:40.00, start: 0.000000, bitrate: 128 kb/s", ok: false},
+		{line: "size=    1024kB time=00:00:25.00 bitrate= 256.0kbits/s speed=50x", want: 0.25, ok: true},
+		{line: "size=    4096kB time=00:01:45.00 bitrate= 256.0kbits/s speed=50x", want: 1, ok: true},
+		{line: "Stream mapping:", ok: false},
+	}
+
+	for _, tt := range lines {
+		got, ok := progress.parse(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Fatalf("parse(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestFFmpegProgressWithTempo measures the sped-up output against the
+// original duration.
+func TestFFmpegProgressWithTempo(t *testing.T) {
+	progress := &ffmpegProgress{tempo: 1.25}
+	progress.parse("  Duration: 00:01:40.00, start: 0.000000, bitrate: 128 kb/s")
+	if got, ok := progress.parse("size=    1024kB time=00:00:40.00 bitrate= 256.0kbits/s"); !ok || got != 0.5 {
+		t.Fatalf("parse = %v, %v; want 0.5", got, ok)
+	}
+}
+
+// TestParseWhisperProgress checks whisper.cpp progress callback lines.
+func TestParseWhisperProgress(t *testing.T) {
+	tests := []struct {
+		line string
+		want float64
+		ok   bool
+	}{
+		{line: "whisper_print_progress_callback: progress =  40%", want: 0.4, ok: true},
+		{line: "whisper_print_progress_callback: progress = 100%", want: 1, ok: true},
+		{line: "[00:00:00.000 --> 00:00:02.000]  hello", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseWhisperProgress(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Fatalf("parseWhisperProgress(%q) = %v, %v; want %v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestParseWhisperSegment checks whisper.cpp segment lines.
+func TestParseWhisperSegment(t *testing.T) {
+	tests := []struct {
+		line string
+		want TranscriptSegment
+		ok   bool
+	}{
+		{line: "[00:00:01.000 --> 00:00:03.480]   Hello there.", want: TranscriptSegment{StartMs: 1000, EndMs: 3480, Text: "Hello there."}, ok: true},
+		{line: "[01:02:03.250 --> 01:02:05.000]  Bonjour", want: TranscriptSegment{StartMs: 3723250, EndMs: 3725000, Text: "Bonjour"}, ok: true},
+		{line: "[00:00:05.000 --> 00:00:06.000]   ", ok: false},
+		{line: "whisper_print_progress_callback: progress =  40%", ok: false},
+		{line: "main: processing 'a.wav' (16000 samples, 1.0 sec)", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseWhisperSegment(tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Fatalf("parseWhisperSegment(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestSegmentForwarderKeepsLines reports segments and still forwards every line.
+func TestSegmentForwarderKeepsLines(t *testing.T) {
+	var lines []string
+	var segments []TranscriptSegment
+	forward := segmentForwarder(func(stream, text string) { lines = append(lines, text) }, func(segment TranscriptSegment) {
+		segments = append(segments, segment)
+	})
+	forward("stdout", "[00:00:00.000 --> 00:00:02.000]  one")
+	forward("stderr", "whisper_print_progress_callback: progress =  40%")
+	forward("stderr", "[00:00:02.000 --> 00:00:04.000]  two")
+
+	if len(lines) != 3 {
+		t.Fatalf("lines = %q", lines)
+	}
+	if len(segments) != 2 || segments[0].Text != "one" || segments[1].StartMs != 2000 {
+		t.Fatalf("segments = %+v", segments)
+	}
+}
@@ -0,0 +1,68 @@
+package transcribe
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"media-transcriber/internal/domain"
+)
+
+const (
+	// sentencePause ends a sentence that whisper left without punctuation.
+	sentencePause = 1500 * time.Millisecond
+	// paragraphPause starts a new paragraph.
+	paragraphPause = 3 * time.Second
+	// maxParagraphRunes ends a paragraph at the next sentence once it is this
+	// long, so a monologue without pauses still breaks up.
+	maxParagraphRunes = 700
+)
+
+// resegment regroups segments for layout: whole sentences, ending at
+// sentence punctuation or a pause of sentencePause, or paragraphs of those
+// sentences, ending at a pause of paragraphPause or after maxParagraphRunes.
+// A speaker change always ends both. Merged segments span their parts, with
+// the lowest confidence among them.
+func resegment(segments []TranscriptSegment, layout domain.TextLayout) []TranscriptSegment {
+	if layout != domain.TextLayoutSentences && layout != domain.TextLayoutParagraphs {
+		return segments
+	}
+	sentences := mergeSegments(segments, func(current, next TranscriptSegment) bool {
+		return !endsSentence(current.Text) && next.StartMs-current.EndMs < sentencePause.Milliseconds()
+	})
+	if layout == domain.TextLayoutSentences {
+		return sentences
+	}
+	return mergeSegments(sentences, func(current, next TranscriptSegment) bool {
+		return next.StartMs-current.EndMs < paragraphPause.Milliseconds() && utf8.RuneCountInString(current.Text) < maxParagraphRunes
+	})
+}
+
+// mergeSegments joins each segment onto the one before it while join allows
+// and the speaker stays the same. Empty segments are dropped.
+func mergeSegments(segments []TranscriptSegment, join func(current, next TranscriptSegment) bool) []TranscriptSegment {
+	var merged []TranscriptSegment
+	for _, segment := range segments {
+		segment.Text = strings.TrimSpace(segment.Text)
+		if segment.Text == "" {
+			continue
+		}
+		if n := len(merged); n > 0 && merged[n-1].Speaker == segment.Speaker && join(merged[n-1], segment) {
+			current := &merged[n-1]
+			current.Text += " " + segment.Text
+			current.EndMs = max(current.EndMs, segment.EndMs)
+			current.Confidence = min(current.Confidence, segment.Confidence)
+			continue
+		}
+		merged = append(merged, segment)
+	}
+	return merged
+}
+
+// endsSentence reports whether text ends with sentence punctuation, allowing
+// closing quotes and brackets after it.
+func endsSentence(text string) bool {
+	text = strings.TrimRight(text, " \"'»”’)]")
+	r, _ := utf8.DecodeLastRuneInString(text)
+	return strings.ContainsRune(".!?…。！？", r)
+}
@@ -0,0 +1,131 @@
+package transcribe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestResegment merges choppy segments into sentences and paragraphs at
+// punctuation, pauses, and speaker changes.
+func TestResegment(t *testing.T) {
+	segments := []TranscriptSegment{
+		{StartMs: 0, EndMs: 1000, Text: " So today we", Confidence: 0.9},
+		{StartMs: 1000, EndMs: 2500, Text: " talk about caching.", Confidence: 0.7},
+		{StartMs: 2600, EndMs: 4000, Text: " It is «hard.»", Confidence: 0.8},
+		{StartMs: 4100, EndMs: 5000, Text: " "},
+		{StartMs: 9000, EndMs: 10000, Text: " next part without a stop", Confidence: 0.9},
+		{StartMs: 12000, EndMs: 13000, Text: " Really?", Confidence: 0.9},
+		{StartMs: 13100, EndMs: 14000, Text: " Yes.", Speaker: "Speaker 2", Confidence: 0.9},
+	}
+	tests := []struct {
+		name   string
+		layout domain.TextLayout
+		want   []TranscriptSegment
+	}{
+		{name: "segments", layout: domain.TextLayoutSegments, want: segments},
+		{
+			name:   "sentences",
+			layout: domain.TextLayoutSentences,
+			want: []TranscriptSegment{
+				{StartMs: 0, EndMs: 2500, Text: "So today we talk about caching.", Confidence: 0.7},
+				{StartMs: 2600, EndMs: 4000, Text: "It is «hard.»", Confidence: 0.8},
+				{StartMs: 9000, EndMs: 10000, Text: "next part without a stop", Confidence: 0.9},
+				{StartMs: 12000, EndMs: 13000, Text: "Really?", Confidence: 0.9},
+				{StartMs: 13100, EndMs: 14000, Text: "Yes.", Speaker: "Speaker 2", Confidence: 0.9},
+			},
+		},
+		{
+			name:   "paragraphs",
+			layout: domain.TextLayoutParagraphs,
+			want: []TranscriptSegment{
+				{StartMs: 0, EndMs: 4000, Text: "So today we talk about caching. It is «hard.»", Confidence: 0.7},
+				{StartMs: 9000, EndMs: 13000, Text: "next part without a stop Really?", Confidence: 0.9},
+				{StartMs: 13100, EndMs: 14000, Text: "Yes.", Speaker: "Speaker 2", Confidence: 0.9},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resegment(segments, tt.layout); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("resegment() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResegmentBreaksLongParagraphs ends a paragraph without pauses at the
+// first sentence past maxParagraphRunes.
+func TestResegmentBreaksLongParagraphs(t *testing.T) {
+	sentence := strings.Repeat("word ", 99) + "end."
+	var segments []TranscriptSegment
+	for i := range 4 {
+		segments = append(segments, TranscriptSegment{StartMs: int64(i) * 1000, EndMs: int64(i)*1000 + 900, Text: sentence})
+	}
+	got := resegment(segments, domain.TextLayoutParagraphs)
+	if len(got) != 2 || got[1].StartMs != 2000 {
+		t.Fatalf("paragraphs = %d starting at %d, want 2 with the second at 2000", len(got), got[len(got)-1].StartMs)
+	}
+}
+
+// TestTranscriptTextParagraphs separates paragraphs with a blank line and
+// timestamps each one.
+func TestTranscriptTextParagraphs(t *testing.T) {
+	segments := []TranscriptSegment{
+		{StartMs: 0, EndMs: 1000, Text: "First part"},
+		{StartMs: 1000, EndMs: 2000, Text: "of it."},
+		{StartMs: 8000, EndMs: 9000, Text: "Second."},
+	}
+	want := "[00:00:00] First part of it.\n\n[00:00:08] Second.\n"
+	if got := transcriptText(segments, nil, domain.TextLayoutParagraphs, domain.TextTimestampsSegment, 0); got != want {
+		t.Fatalf("transcriptText() = %q, want %q", got, want)
+	}
+}
+
+// TestPipelineRunParagraphs rewrites the transcript as paragraphs and keeps
+// whisper's segments in the subtitles.
+func TestPipelineRunParagraphs(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "talk.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		if name == "ffmpeg" {
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		}
+		base := argValue(args, "-of")
+		mustWriteFile(t, base+".txt", " We moved\n to the cloud.\n")
+		mustWriteFile(t, base+".json", `{"transcription":[`+
+			`{"offsets":{"from":0,"to":1000},"text":" We moved"},`+
+			`{"offsets":{"from":1000,"to":2000},"text":" to the cloud."}]}`)
+		return commandResult{}, nil
+	}}
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		Subtitles:  true,
+		InputPath:  inputPath,
+		ModelPath:  modelPath,
+		OutputDir:  filepath.Join(root, "output"),
+		TextLayout: domain.TextLayoutParagraphs,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if result.Transcript != "We moved to the cloud." || len(result.Segments) != 2 {
+		t.Fatalf("transcript = %q with %d segments, want one paragraph over two segments", result.Transcript, len(result.Segments))
+	}
+	srt, err := os.ReadFile(filepath.Join(root, "output", "talk.srt"))
+	if err != nil || !strings.Contains(string(srt), "00:00:01,000 --> 00:00:02,000\nto the cloud.") {
+		t.Fatalf("srt = %q (%v), want whisper's second cue", srt, err)
+	}
+}
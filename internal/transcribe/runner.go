@@ -0,0 +1,170 @@
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+
+	// defaultCancelGracePeriod is how long a cancelled process may exit on its own
+	// after SIGINT/CTRL_BREAK before its process tree is killed.
+	defaultCancelGracePeriod = 5 * time.Second
+)
+
+// commandResult is an internal process execution response.
+type commandResult struct {
+	Stdout          string
+	Stderr          string
+	ExitCode        int
+	PeakMemoryBytes uint64
+}
+
+// commandOptions tunes one command execution.
+type commandOptions struct {
+	// onLine, when non-nil, receives each output line as the process writes it.
+	onLine func(stream, text string)
+	// lowPriority runs the process tree below normal CPU and I/O priority.
+	lowPriority bool
+	// env holds KEY=value pairs added to the inherited environment.
+	env []string
+}
+
+// commandRunner abstracts process execution for testability.
+type commandRunner interface {
+	Run(ctx context.Context, opts commandOptions, name string, args ...string) (commandResult, error)
+}
+
+// execRunner executes commands via os/exec.
+type execRunner struct {
+	gracePeriod time.Duration
+}
+
+// Run executes one command, streams output lines, and captures stdout/stderr and exit code.
+// On cancellation the process group is interrupted first and killed after the grace period.
+func (r *execRunner) Run(ctx context.Context, opts commandOptions, name string, args ...string) (commandResult, error) {
+	grace := r.gracePeriod
+	if grace <= 0 {
+		grace = defaultCancelGracePeriod
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	configureProcess(cmd, opts.lowPriority)
+	if len(opts.env) > 0 {
+		cmd.Env = append(os.Environ(), opts.env...)
+	}
+
+	var escalateMu sync.Mutex
+	var escalate *time.Timer
+	cmd.Cancel = func() error {
+		escalateMu.Lock()
+		escalate = time.AfterFunc(grace, func() { _ = killProcessGroup(cmd.Process) })
+		escalateMu.Unlock()
+		if err := interruptProcessGroup(cmd.Process); err != nil {
+			return killProcessGroup(cmd.Process)
+		}
+		return nil
+	}
+	// Backstop for children that keep output pipes open after the group kill.
+	cmd.WaitDelay = 2 * grace
+
+	var mu sync.Mutex
+	stdout := &lineWriter{stream: streamStdout, onLine: opts.onLine, mu: &mu}
+	stderr := &lineWriter{stream: streamStderr, onLine: opts.onLine, mu: &mu}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Start()
+	if err == nil {
+		if opts.lowPriority {
+			// Best effort: a process that keeps normal priority still transcribes correctly.
+			_ = lowerProcessPriority(cmd.Process)
+		}
+		err = cmd.Wait()
+	}
+	escalateMu.Lock()
+	if escalate != nil {
+		escalate.Stop()
+	}
+	escalateMu.Unlock()
+	stdout.flush()
+	stderr.flush()
+	result := commandResult{
+		Stdout:          stdout.String(),
+		Stderr:          stderr.String(),
+		ExitCode:        0,
+		PeakMemoryBytes: peakMemoryBytes(cmd.ProcessState),
+	}
+	if err != nil {
+		result.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, fmt.Errorf("%w: %v", ctxErr, err)
+		}
+		return result, err
+	}
+
+	return result, nil
+}
+
+// lineWriter captures a full stream and emits complete lines. Carriage returns also end
+// a line so ffmpeg's in-place progress updates arrive as they are printed. The mutex is
+// shared by a command's stdout and stderr writers so callbacks never run concurrently.
+type lineWriter struct {
+	stream  string
+	onLine  func(stream, text string)
+	mu      *sync.Mutex
+	all     bytes.Buffer
+	pending []byte
+}
+
+// Write records p and forwards every completed line.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.all.Write(p)
+	if w.onLine == nil {
+		return len(p), nil
+	}
+
+	for _, b := range p {
+		if b != '\n' && b != '\r' {
+			w.pending = append(w.pending, b)
+			continue
+		}
+		if len(w.pending) > 0 {
+			w.onLine(w.stream, string(w.pending))
+			w.pending = w.pending[:0]
+		}
+	}
+	return len(p), nil
+}
+
+// flush emits a trailing line that was not newline-terminated.
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.onLine != nil && len(w.pending) > 0 {
+		w.onLine(w.stream, string(w.pending))
+		w.pending = nil
+	}
+}
+
+// String returns everything written to the stream.
+func (w *lineWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.all.String()
+}
@@ -0,0 +1,94 @@
+package transcribe
+
+import (
+	"context"
+	"errors"
+	goruntime "runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestExecRunnerStreamsLinesWhileCapturing checks live line callbacks and full capture.
+func TestExecRunnerStreamsLinesWhileCapturing(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	var lines []OutputLine
+	runner := &execRunner{}
+	result, err := runner.Run(context.Background(), commandOptions{onLine: func(stream, text string) {
+		lines = append(lines, OutputLine{Stream: stream, Text: text})
+	}}, "sh", "-c", `printf 'first\nsize=1\rsize=2\n'; printf 'warn' >&2`)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if result.Stdout != "first\nsize=1\rsize=2\n" || result.Stderr != "warn" {
+		t.Fatalf("captured stdout=%q stderr=%q", result.Stdout, result.Stderr)
+	}
+
+	want := []OutputLine{
+		{Stream: streamStdout, Text: "first"},
+		{Stream: streamStdout, Text: "size=1"},
+		{Stream: streamStdout, Text: "size=2"},
+		{Stream: streamStderr, Text: "warn"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %+v, want %+v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("line %d = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+}
+
+// TestExecRunnerCancelInterruptsProcessGroup checks SIGINT reaches children and reports cancellation.
+func TestExecRunnerCancelInterruptsProcessGroup(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	tests := []struct {
+		name       string
+		script     string
+		maxElapsed time.Duration
+	}{
+		{name: "interrupt", script: "sleep 30; echo done", maxElapsed: 300 * time.Millisecond},
+		{name: "escalate to kill", script: `trap "" INT; sleep 30; echo done`, maxElapsed: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(100*time.Millisecond, cancel)
+
+			started := time.Now()
+			runner := &execRunner{gracePeriod: 400 * time.Millisecond}
+			_, err := runner.Run(ctx, commandOptions{}, "sh", "-c", tt.script)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+			if elapsed := time.Since(started); elapsed > tt.maxElapsed {
+				t.Fatalf("cancellation took %s", elapsed)
+			}
+		})
+	}
+}
+
+// TestExecRunnerLowPriorityRenicesProcess checks low-priority commands run with a raised nice value.
+func TestExecRunnerLowPriorityRenicesProcess(t *testing.T) {
+	if goruntime.GOOS == "windows" {
+		t.Skip("uses sh and nice")
+	}
+
+	runner := &execRunner{gracePeriod: time.Second}
+	// The short sleep lets the runner renice the group before nice reports it.
+	result, err := runner.Run(context.Background(), commandOptions{lowPriority: true}, "sh", "-c", "sleep 0.2; nice")
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "10" {
+		t.Fatalf("nice = %q, want 10", got)
+	}
+}
@@ -0,0 +1,162 @@
+package transcribe
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"media-transcriber/internal/subtitle"
+)
+
+// DefaultReviewThreshold flags segments whose confidence is below 60%.
+const DefaultReviewThreshold = 0.6
+
+// TranscriptSegment is one timed passage of the transcript.
+// Confidence is the mean probability of its text tokens (0 to 1); it is 0
+// when whisper reported no scored tokens for the segment. Speaker labels the
+// channel of a split stereo recording and is empty otherwise.
+type TranscriptSegment struct {
+	StartMs    int64   `json:"startMs"`
+	EndMs      int64   `json:"endMs"`
+	Text       string  `json:"text"`
+	Confidence float64 `json:"confidence"`
+	Speaker    string  `json:"speaker,omitempty"`
+}
+
+// labeledText returns the segment text prefixed with its speaker, if any,
+// e.g. "Speaker 1: Hello.".
+func (s TranscriptSegment) labeledText() string {
+	if s.Speaker == "" {
+		return s.Text
+	}
+	return s.Speaker + ": " + s.Text
+}
+
+// SegmentCues returns one cue per segment with text, labeled with its
+// speaker like the exported subtitles.
+func SegmentCues(segments []TranscriptSegment) []subtitle.Cue {
+	cues := make([]subtitle.Cue, 0, len(segments))
+	for _, segment := range segments {
+		if segment.Text != "" {
+			cues = append(cues, subtitle.Cue{StartMs: segment.StartMs, EndMs: segment.EndMs, Text: segment.labeledText()})
+		}
+	}
+	return cues
+}
+
+// whisperJSON is the subset of whisper.cpp's -ojf (full JSON) output we read.
+type whisperJSON struct {
+	Transcription []whisperJSONSegment `json:"transcription"`
+	Result        struct {
+		Language string `json:"language"`
+	} `json:"result"`
+}
+
+// whisperJSONSegment is one timed segment of whisper's full JSON output.
+type whisperJSONSegment struct {
+	Offsets struct {
+		From int64 `json:"from"`
+		To   int64 `json:"to"`
+	} `json:"offsets"`
+	Text   string             `json:"text"`
+	Tokens []whisperJSONToken `json:"tokens"`
+	// Speaker is set on segments merged from split channels; whisper never
+	// writes it.
+	Speaker string `json:"speaker,omitempty"`
+}
+
+// whisperJSONToken is one scored token of a segment.
+type whisperJSONToken struct {
+	Text string  `json:"text"`
+	P    float64 `json:"p"`
+}
+
+// parseWhisperJSON reads segments and token probabilities from whisper's full JSON output.
+// Special tokens such as [_BEG_] and [_TT_150] carry timing, not text, and are not scored.
+func parseWhisperJSON(data []byte) ([]TranscriptSegment, error) {
+	doc, err := decodeWhisperJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := make([]TranscriptSegment, 0, len(doc.Transcription))
+	for _, entry := range doc.Transcription {
+		segment := TranscriptSegment{
+			StartMs: entry.Offsets.From,
+			EndMs:   entry.Offsets.To,
+			Text:    strings.TrimSpace(entry.Text),
+			Speaker: entry.Speaker,
+		}
+		var sum float64
+		var scored int
+		for _, token := range entry.Tokens {
+			if isSpecialToken(token.Text) {
+				continue
+			}
+			sum += token.P
+			scored++
+		}
+		if scored > 0 {
+			segment.Confidence = sum / float64(scored)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// whisperLanguage returns the language whisper transcribed in, detected or
+// forced, or "" when the JSON does not say.
+func whisperLanguage(data []byte) string {
+	doc, err := decodeWhisperJSON(data)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Result.Language)
+}
+
+// decodeWhisperJSON unmarshals whisper's full JSON output.
+func decodeWhisperJSON(data []byte) (whisperJSON, error) {
+	var doc whisperJSON
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return whisperJSON{}, fmt.Errorf("decode whisper json: %w", err)
+	}
+	return doc, nil
+}
+
+// isSpecialToken reports whisper control tokens, which are rendered as [_NAME_] or [_TT_N].
+func isSpecialToken(text string) bool {
+	return strings.HasPrefix(text, "[_") && strings.HasSuffix(text, "]")
+}
+
+// lowConfidenceSegments returns scored segments below threshold, in transcript order.
+func lowConfidenceSegments(segments []TranscriptSegment, threshold float64) []TranscriptSegment {
+	var flagged []TranscriptSegment
+	for _, segment := range segments {
+		if segment.Confidence > 0 && segment.Confidence < threshold && segment.Text != "" {
+			flagged = append(flagged, segment)
+		}
+	}
+	return flagged
+}
+
+// buildReviewReport lists low-confidence passages with timestamps so an editor
+// knows where to listen back.
+func buildReviewReport(inputPath string, total int, flagged []TranscriptSegment, threshold float64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Review needed: %d of %d segments below %.0f%% confidence\n", len(flagged), total, threshold*100)
+	fmt.Fprintf(&b, "Source: %s\n\n", filepath.Base(inputPath))
+	for _, segment := range flagged {
+		fmt.Fprintf(&b, "[%s - %s] %3.0f%%  %s\n",
+			formatTimestamp(segment.StartMs), formatTimestamp(segment.EndMs), segment.Confidence*100, segment.labeledText())
+	}
+	return b.String()
+}
+
+// formatTimestamp renders milliseconds as HH:MM:SS.mmm.
+func formatTimestamp(ms int64) string {
+	if ms < 0 {
+		ms = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,186 @@
+package transcribe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleWhisperJSON = `{
+  "transcription": [
+    {
+      "offsets": {"from": 0, "to": 2500},
+      "text": " Hello there.",
+      "tokens": [
+        {"text": "[_BEG_]", "p": 0.1},
+        {"text": " Hello", "p": 0.9},
+        {"text": " there.", "p": 0.7},
+        {"text": "[_TT_125]", "p": 0.2}
+      ]
+    },
+    {
+      "offsets": {"from": 62500, "to": 65000},
+      "text": " Mumbled words",
+      "tokens": [
+        {"text": " Mumbled", "p": 0.3},
+        {"text": " words", "p": 0.5}
+      ]
+    },
+    {
+      "offsets": {"from": 65000, "to": 66000},
+      "text": "",
+      "tokens": []
+    }
+  ]
+}`
+
+// TestParseWhisperJSON averages text token probabilities and skips special tokens.
+func TestParseWhisperJSON(t *testing.T) {
+	segments, err := parseWhisperJSON([]byte(sampleWhisperJSON))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(segments) != 3 {
+		t.Fatalf("segments = %d, want 3", len(segments))
+	}
+
+	tests := []struct {
+		segment        TranscriptSegment
+		wantText       string
+		wantStart      int64
+		wantConfidence float64
+	}{
+		{segment: segments[0], wantText: "Hello there.", wantStart: 0, wantConfidence: 0.8},
+		{segment: segments[1], wantText: "Mumbled words", wantStart: 62500, wantConfidence: 0.4},
+		{segment: segments[2], wantText: "", wantStart: 65000, wantConfidence: 0},
+	}
+	for _, tt := range tests {
+		if tt.segment.Text != tt.wantText || tt.segment.StartMs != tt.wantStart {
+			t.Fatalf("segment = %+v", tt.segment)
+		}
+		if diff := tt.segment.Confidence - tt.wantConfidence; diff > 1e-9 || diff < -1e-9 {
+			t.Fatalf("confidence = %v, want %v", tt.segment.Confidence, tt.wantConfidence)
+		}
+	}
+
+	if _, err := parseWhisperJSON([]byte("{")); err == nil {
+		t.Fatal("expected decode error")
+	}
+}
+
+// TestBuildReviewReport lists only low-confidence passages with timestamps.
+func TestBuildReviewReport(t *testing.T) {
+	segments, _ := parseWhisperJSON([]byte(sampleWhisperJSON))
+	flagged := lowConfidenceSegments(segments, DefaultReviewThreshold)
+	if len(flagged) != 1 {
+		t.Fatalf("flagged = %+v, want one segment", flagged)
+	}
+
+	report := buildReviewReport("/media/meeting.mp4", len(segments), flagged, DefaultReviewThreshold)
+	for _, want := range []string{
+		"Review needed: 1 of 3 segments below 60% confidence",
+		"Source: meeting.mp4",
+		"[00:01:02.500 - 00:01:05.000]  40%  Mumbled words",
+	} {
+		if !strings.Contains(report, want) {
+			t.Fatalf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+// TestPipelineRunWritesReviewReport checks JSON parsing, cleanup, and the report file.
+func TestPipelineRunWritesReviewReport(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "meeting.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+				return commandResult{}, nil
+			}
+			base := argValue(args, "-of")
+			mustWriteFile(t, base+".txt", "Hello there. Mumbled words")
+			mustWriteFile(t, base+".json", sampleWhisperJSON)
+			return commandResult{}, nil
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{InputPath: inputPath, ModelPath: modelPath, OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if len(result.Segments) != 3 {
+		t.Fatalf("segments = %d, want 3", len(result.Segments))
+	}
+	if result.ReviewPath != filepath.Join(outputDir, "meeting.review.txt") {
+		t.Fatalf("review path = %q", result.ReviewPath)
+	}
+	if _, err := os.Stat(result.ReviewPath); err != nil {
+		t.Fatalf("review report missing: %v", err)
+	}
+	if len(result.SubtitlePaths) != 0 {
+		t.Fatalf("subtitle paths = %v, want none without Subtitles", result.SubtitlePaths)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "meeting.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected whisper json to be removed, stat err = %v", err)
+	}
+}
+
+// TestPipelineRunAlignsScript writes SRT and VTT timed from the recognized segments.
+func TestPipelineRunAlignsScript(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "speech.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{
+		run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+			if name == "ffmpeg" {
+				mustWriteFile(t, args[len(args)-1], "wav")
+				return commandResult{}, nil
+			}
+			base := argValue(args, "-of")
+			mustWriteFile(t, base+".txt", "Hello there. Mumbled words")
+			mustWriteFile(t, base+".json", sampleWhisperJSON)
+			return commandResult{}, nil
+		},
+	}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath:  inputPath,
+		ModelPath:  modelPath,
+		OutputDir:  outputDir,
+		ScriptText: "Hello there!\nMumbled words.",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if len(result.SubtitlePaths) != 2 {
+		t.Fatalf("subtitle paths = %v", result.SubtitlePaths)
+	}
+	srt, err := os.ReadFile(filepath.Join(outputDir, "speech.srt"))
+	if err != nil {
+		t.Fatalf("read srt: %v", err)
+	}
+	if !strings.Contains(string(srt), "00:01:02,500 --> 00:01:05,000\nMumbled words.") {
+		t.Fatalf("srt =\n%s", srt)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "speech.vtt")); err != nil {
+		t.Fatalf("vtt missing: %v", err)
+	}
+}
@@ -0,0 +1,485 @@
+package transcribe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultServerPath is the whisper.cpp server executable used when none is configured.
+const DefaultServerPath = "whisper-server"
+
+// DefaultServerIdleTimeout stops a warm server no job used for this long, freeing its memory.
+const DefaultServerIdleTimeout = 10 * time.Minute
+
+// serverPollInterval is how often a starting server is probed while it loads the model.
+const serverPollInterval = 200 * time.Millisecond
+
+// serverOutputLimit bounds the server output kept for error messages.
+const serverOutputLimit = 16 << 10
+
+// WhisperServer keeps one whisper.cpp server process running with its model
+// loaded between jobs, so a batch of short clips pays the model load (seconds,
+// minutes for large models) once instead of once per file. The process starts
+// on first use, restarts when the executable or model changes, and stops after
+// sitting idle or when a job is cancelled mid-request.
+type WhisperServer struct {
+	idleTimeout time.Duration
+	launch      func(binary, model, device string, lowPriority bool) (*serverProcess, error)
+	client      *http.Client
+
+	// mu serializes requests; the server transcribes one file at a time.
+	mu sync.Mutex
+	// procMu guards the process and is never held across a request, so
+	// Close does not wait for a transcription to finish.
+	procMu sync.Mutex
+	proc   *serverProcess
+	idle   *time.Timer
+	// cancelRequest aborts the request in flight, if any.
+	cancelRequest context.CancelFunc
+}
+
+// serverProcess is one running whisper.cpp server.
+type serverProcess struct {
+	binary, model string
+	// device is the GPUDevice the server runs on.
+	device string
+	args   []string
+	url    string
+	// done is closed when the process exits.
+	done   chan struct{}
+	stop   func()
+	output *tailBuffer
+}
+
+// NewWhisperServer creates a stopped server that shuts down after idleTimeout
+// without requests; zero uses DefaultServerIdleTimeout.
+func NewWhisperServer(idleTimeout time.Duration) *WhisperServer {
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultServerIdleTimeout
+	}
+	return &WhisperServer{idleTimeout: idleTimeout, launch: launchServer, client: &http.Client{}}
+}
+
+// Close stops the server process, if any, aborting the request in flight.
+func (s *WhisperServer) Close() {
+	s.procMu.Lock()
+	defer s.procMu.Unlock()
+	if s.cancelRequest != nil {
+		s.cancelRequest()
+	}
+	s.stopLocked()
+}
+
+// serverVerboseJSON is the subset of the server's verbose_json response we read.
+type serverVerboseJSON struct {
+	Language string `json:"language"`
+	Segments []struct {
+		Start float64 `json:"start"`
+		End   float64 `json:"end"`
+		Text  string  `json:"text"`
+		Words []struct {
+			Word        string  `json:"word"`
+			Probability float64 `json:"probability"`
+		} `json:"words"`
+	} `json:"segments"`
+}
+
+// transcribe sends audioPath to the server for binary and model on device,
+// starting or restarting it as needed. It returns the server's command line and output
+// for the job log alongside the result.
+func (s *WhisperServer) transcribe(ctx context.Context, binary, model, device, audioPath, language, prompt string, lowPriority bool) (CommandLog, serverVerboseJSON, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	proc, started, err := s.acquire(binary, model, device, lowPriority, cancel)
+	if err != nil {
+		return CommandLog{Command: binary, ExitCode: -1}, serverVerboseJSON{}, fmt.Errorf("start whisper server: %w", err)
+	}
+	defer s.release()
+	if started {
+		if err := s.waitReady(ctx, proc); err != nil {
+			s.stop(proc)
+			return proc.log(-1), serverVerboseJSON{}, err
+		}
+	}
+
+	response, err := s.infer(ctx, proc, audioPath, language, prompt)
+	if err != nil {
+		if ctx.Err() != nil {
+			// The server would keep transcribing the abandoned file; the
+			// next job pays the model load again instead.
+			s.stop(proc)
+		}
+		return proc.log(-1), serverVerboseJSON{}, err
+	}
+	return proc.log(0), response, nil
+}
+
+// acquire returns the process for binary and model on device, launching it,
+// and reports whether it was just started. cancel aborts the caller's
+// request when the server is closed.
+func (s *WhisperServer) acquire(binary, model, device string, lowPriority bool, cancel context.CancelFunc) (*serverProcess, bool, error) {
+	s.procMu.Lock()
+	defer s.procMu.Unlock()
+	if s.idle != nil {
+		s.idle.Stop()
+	}
+	if s.proc != nil && (s.proc.binary != binary || s.proc.model != model || s.proc.device != device || s.proc.exited()) {
+		s.stopLocked()
+	}
+	started := false
+	if s.proc == nil {
+		proc, err := s.launch(binary, model, device, lowPriority)
+		if err != nil {
+			return nil, false, err
+		}
+		s.proc, started = proc, true
+	}
+	s.cancelRequest = cancel
+	return s.proc, started, nil
+}
+
+// release ends a request and schedules the idle stop.
+func (s *WhisperServer) release() {
+	s.procMu.Lock()
+	defer s.procMu.Unlock()
+	s.cancelRequest = nil
+	s.scheduleIdleStop()
+}
+
+// stop stops proc unless it was already stopped or replaced.
+func (s *WhisperServer) stop(proc *serverProcess) {
+	s.procMu.Lock()
+	defer s.procMu.Unlock()
+	if s.proc == proc {
+		s.stopLocked()
+	}
+}
+
+// waitReady polls the starting server until it answers, exits, or ctx ends.
+// Servers that report loading with 503 are waited for.
+func (s *WhisperServer) waitReady(ctx context.Context, proc *serverProcess) error {
+	ticker := time.NewTicker(serverPollInterval)
+	defer ticker.Stop()
+	for {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, proc.url+"/health", nil)
+		if err != nil {
+			return err
+		}
+		if response, err := s.client.Do(request); err == nil {
+			response.Body.Close()
+			if response.StatusCode != http.StatusServiceUnavailable {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-proc.done:
+			return fmt.Errorf("whisper server exited while loading the model")
+		case <-ticker.C:
+		}
+	}
+}
+
+// infer posts one audio file to proc, with an initial prompt when prompt is
+// set, and decodes the timed segments. The file is streamed rather than read
+// into memory, as hour-long WAVs run to hundreds of megabytes.
+func (s *WhisperServer) infer(ctx context.Context, proc *serverProcess, audioPath, language, prompt string) (serverVerboseJSON, error) {
+	audio, err := os.Open(audioPath)
+	if err != nil {
+		return serverVerboseJSON{}, err
+	}
+	if language == "" {
+		language = "auto"
+	}
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		defer audio.Close()
+		writer.CloseWithError(writeInferenceForm(form, audio, filepath.Base(audioPath), language, prompt))
+	}()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, proc.url+"/inference", body)
+	if err != nil {
+		body.Close()
+		return serverVerboseJSON{}, err
+	}
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	response, err := s.client.Do(request)
+	// Unblocks the writer when the server answers without reading the file.
+	body.Close()
+	if err != nil {
+		return serverVerboseJSON{}, err
+	}
+	defer response.Body.Close()
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return serverVerboseJSON{}, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return serverVerboseJSON{}, fmt.Errorf("whisper server returned %s: %s", response.Status, strings.TrimSpace(string(data)))
+	}
+	var result serverVerboseJSON
+	if err := json.Unmarshal(data, &result); err != nil {
+		return serverVerboseJSON{}, fmt.Errorf("decode whisper server response: %w", err)
+	}
+	return result, nil
+}
+
+// writeInferenceForm writes the multipart inference request: the audio as
+// "file", then the language, response format, and prompt fields.
+func writeInferenceForm(form *multipart.Writer, audio io.Reader, name, language, prompt string) error {
+	part, err := form.CreateFormFile("file", name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, audio); err != nil {
+		return err
+	}
+	fields := [][2]string{{"language", language}, {"response_format", "verbose_json"}}
+	if prompt != "" {
+		fields = append(fields, [2]string{"prompt", prompt})
+	}
+	for _, field := range fields {
+		if err := form.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+	return form.Close()
+}
+
+// scheduleIdleStop stops the server once it has been idle for idleTimeout;
+// s.procMu must be held.
+func (s *WhisperServer) scheduleIdleStop() {
+	if s.proc == nil {
+		return
+	}
+	proc := s.proc
+	s.idle = time.AfterFunc(s.idleTimeout, func() { s.stop(proc) })
+}
+
+// stopLocked stops the running process; s.procMu must be held.
+func (s *WhisperServer) stopLocked() {
+	if s.idle != nil {
+		s.idle.Stop()
+	}
+	if s.proc != nil {
+		s.proc.stop()
+		s.proc = nil
+	}
+}
+
+// exited reports whether the process is gone.
+func (p *serverProcess) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// log describes the server for the job log with exitCode.
+func (p *serverProcess) log(exitCode int) CommandLog {
+	return CommandLog{Command: p.binary, Args: p.args, ExitCode: exitCode, Stderr: p.output.String()}
+}
+
+// launchServer starts binary on a free loopback port with model loaded on device.
+func launchServer(binary, model, device string, lowPriority bool) (*serverProcess, error) {
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	args := append([]string{"-m", model, "--host", "127.0.0.1", "--port", strconv.Itoa(port)}, whisperDeviceArgs(device)...)
+	cmd := exec.Command(binary, args...)
+	configureProcess(cmd, lowPriority)
+	if env := whisperDeviceEnv(device); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	output := &tailBuffer{limit: serverOutputLimit}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	if lowPriority {
+		// Best effort, as for per-job commands.
+		_ = lowerProcessPriority(cmd.Process)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(done)
+	}()
+	return &serverProcess{
+		binary: binary,
+		model:  model,
+		device: device,
+		args:   args,
+		url:    "http://127.0.0.1:" + strconv.Itoa(port),
+		done:   done,
+		stop: func() {
+			_ = killProcessGroup(cmd.Process)
+			<-done
+		},
+		output: output,
+	}, nil
+}
+
+// freePort asks the OS for an unused loopback TCP port.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	mu    sync.Mutex
+	limit int
+	data  []byte
+}
+
+// Write appends p, dropping the oldest bytes beyond the limit.
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = append(b.data, p...)
+	if over := len(b.data) - b.limit; over > 0 {
+		b.data = b.data[over:]
+	}
+	return len(p), nil
+}
+
+// String returns the kept output.
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data)
+}
+
+// whisperJSONFromServer converts a verbose_json response to the -ojf document
+// the CLI writes, so review, subtitles, and chapters read both alike.
+func whisperJSONFromServer(response serverVerboseJSON) whisperJSON {
+	var doc whisperJSON
+	doc.Result.Language = whisperLanguageCode(response.Language)
+	doc.Transcription = make([]whisperJSONSegment, len(response.Segments))
+	for i, segment := range response.Segments {
+		entry := &doc.Transcription[i]
+		entry.Offsets.From = int64(math.Round(segment.Start * 1000))
+		entry.Offsets.To = int64(math.Round(segment.End * 1000))
+		entry.Text = segment.Text
+		for _, word := range segment.Words {
+			entry.Tokens = append(entry.Tokens, whisperJSONToken{Text: word.Word, P: word.Probability})
+		}
+	}
+	return doc
+}
+
+// transcribeWithServer runs the transcribing stage on the warm server and
+// writes the .txt and -ojf .json files the CLI would have written.
+func (p *Pipeline) transcribeWithServer(ctx context.Context, req Request, modelPath, audioPath, textPath, textBase, language string) (CommandLog, error) {
+	binary := strings.TrimSpace(req.WhisperServerPath)
+	if binary == "" {
+		binary = DefaultServerPath
+	}
+	stageCtx := ctx
+	if req.TranscribeTimeout > 0 {
+		var cancel context.CancelFunc
+		stageCtx, cancel = context.WithTimeout(ctx, req.TranscribeTimeout)
+		defer cancel()
+	}
+
+	log, response, err := req.Server.transcribe(stageCtx, binary, modelPath, req.GPUDevice, audioPath, normalizeLanguage(language), termsPrompt(req.Terms), req.LowPriority)
+	if err != nil {
+		if errors.Is(stageCtx.Err(), context.DeadlineExceeded) {
+			return log, fmt.Errorf("%w: %w", ErrTimeout, err)
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return log, fmt.Errorf("%w: %v", ctxErr, err)
+		}
+		return log, err
+	}
+
+	doc := whisperJSONFromServer(response)
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return log, err
+	}
+	var text strings.Builder
+	for _, segment := range doc.Transcription {
+		text.WriteString(strings.TrimSpace(segment.Text))
+		text.WriteString("\n")
+	}
+	if err := p.writeFile(textBase+".json", data, 0o644); err != nil {
+		return log, err
+	}
+	if err := p.writeFile(textPath, []byte(text.String()), 0o644); err != nil {
+		return log, err
+	}
+
+	for _, segment := range doc.Transcription {
+		if req.OnSegment != nil && strings.TrimSpace(segment.Text) != "" {
+			req.OnSegment(TranscriptSegment{StartMs: segment.Offsets.From, EndMs: segment.Offsets.To, Text: strings.TrimSpace(segment.Text)})
+		}
+	}
+	if req.OnProgress != nil {
+		req.OnProgress("transcribing", 1)
+	}
+	return log, nil
+}
+
+// whisperLanguageCode maps the language name the server reports ("english")
+// to the code the CLI writes ("en"); codes pass through.
+func whisperLanguageCode(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if code, ok := whisperLanguageCodes[language]; ok {
+		return code
+	}
+	return language
+}
+
+// whisperLanguageCodes lists whisper's languages by full name.
+var whisperLanguageCodes = map[string]string{
+	"english": "en", "chinese": "zh", "german": "de", "spanish": "es", "russian": "ru",
+	"korean": "ko", "french": "fr", "japanese": "ja", "portuguese": "pt", "turkish": "tr",
+	"polish": "pl", "catalan": "ca", "dutch": "nl", "arabic": "ar", "swedish": "sv",
+	"italian": "it", "indonesian": "id", "hindi": "hi", "finnish": "fi", "vietnamese": "vi",
+	"hebrew": "he", "ukrainian": "uk", "greek": "el", "malay": "ms", "czech": "cs",
+	"romanian": "ro", "danish": "da", "hungarian": "hu", "tamil": "ta", "norwegian": "no",
+	"thai": "th", "urdu": "ur", "croatian": "hr", "bulgarian": "bg", "lithuanian": "lt",
+	"latin": "la", "maori": "mi", "malayalam": "ml", "welsh": "cy", "slovak": "sk",
+	"telugu": "te", "persian": "fa", "latvian": "lv", "bengali": "bn", "serbian": "sr",
+	"azerbaijani": "az", "slovenian": "sl", "kannada": "kn", "estonian": "et", "macedonian": "mk",
+	"breton": "br", "basque": "eu", "icelandic": "is", "armenian": "hy", "nepali": "ne",
+	"mongolian": "mn", "bosnian": "bs", "kazakh": "kk", "albanian": "sq", "swahili": "sw",
+	"galician": "gl", "marathi": "mr", "punjabi": "pa", "sinhala": "si", "khmer": "km",
+	"shona": "sn", "yoruba": "yo", "somali": "so", "afrikaans": "af", "occitan": "oc",
+	"georgian": "ka", "belarusian": "be", "tajik": "tg", "sindhi": "sd", "gujarati": "gu",
+	"amharic": "am", "yiddish": "yi", "lao": "lo", "uzbek": "uz", "faroese": "fo",
+	"haitian creole": "ht", "pashto": "ps", "turkmen": "tk", "nynorsk": "nn", "maltese": "mt",
+	"sanskrit": "sa", "luxembourgish": "lb", "myanmar": "my", "tibetan": "bo", "tagalog": "tl",
+	"malagasy": "mg", "assamese": "as", "tatar": "tt", "hawaiian": "haw", "lingala": "ln",
+	"hausa": "ha", "bashkir": "ba", "javanese": "jw", "sundanese": "su", "cantonese": "yue",
+}
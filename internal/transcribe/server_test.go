@@ -0,0 +1,287 @@
+package transcribe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServers launches in-process whisper servers that answer inference
+// requests with one segment, and records every launch and stop.
+type fakeServers struct {
+	mu       sync.Mutex
+	launches []string
+	stops    int
+	// loading makes /health answer 503 this many times per launch.
+	loading int
+	// exitOnLaunch makes the process exit before it is ready.
+	exitOnLaunch bool
+	// inferring, when set, receives every inference request, which then
+	// hangs until the client gives up.
+	inferring chan struct{}
+}
+
+// launch implements WhisperServer.launch.
+func (f *fakeServers) launch() func(binary, model, device string, lowPriority bool) (*serverProcess, error) {
+	return func(binary, model, device string, lowPriority bool) (*serverProcess, error) {
+		f.mu.Lock()
+		launch := filepath.Base(model)
+		if device != "" {
+			launch += "@" + device
+		}
+		f.launches = append(f.launches, launch)
+		loading := f.loading
+		f.mu.Unlock()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/health":
+				f.mu.Lock()
+				defer f.mu.Unlock()
+				if loading > 0 {
+					loading--
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			case "/inference":
+				if r.FormValue("response_format") != "verbose_json" {
+					http.Error(w, "bad format", http.StatusBadRequest)
+					return
+				}
+				file, header, err := r.FormFile("file")
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				file.Close()
+				if f.inferring != nil {
+					f.inferring <- struct{}{}
+					<-r.Context().Done()
+					return
+				}
+				fmt.Fprintf(w, `{"language":"english","segments":[{"start":0.5,"end":2.25,"text":" Hello from %s in %s.","words":[{"word":" Hello","probability":0.9},{"word":" there","probability":0.5}]}]}`,
+					header.Filename, r.FormValue("language"))
+			}
+		}))
+		done := make(chan struct{})
+		if f.exitOnLaunch {
+			close(done)
+		}
+		var once sync.Once
+		return &serverProcess{
+			binary: binary,
+			model:  model,
+			device: device,
+			args:   []string{"-m", model},
+			url:    server.URL,
+			done:   done,
+			stop: func() {
+				once.Do(func() {
+					server.Close()
+					f.mu.Lock()
+					f.stops++
+					f.mu.Unlock()
+				})
+			},
+			output: &tailBuffer{limit: serverOutputLimit},
+		}, nil
+	}
+}
+
+// TestWhisperServerReusesProcess keeps one process across requests for the
+// same model and device and restarts it for another model or device.
+func TestWhisperServerReusesProcess(t *testing.T) {
+	audio := filepath.Join(t.TempDir(), "clip.wav")
+	mustWriteFile(t, audio, "wav")
+	fakes := &fakeServers{loading: 2}
+	server := NewWhisperServer(time.Minute)
+	server.launch = fakes.launch()
+	defer server.Close()
+
+	for _, run := range []struct{ model, device string }{
+		{model: "/m/base.bin"}, {model: "/m/base.bin"}, {model: "/m/large.bin"}, {model: "/m/large.bin", device: "1"},
+	} {
+		_, response, err := server.transcribe(context.Background(), "whisper-server", run.model, run.device, audio, "", "", false)
+		if err != nil {
+			t.Fatalf("transcribe with %s: %v", run.model, err)
+		}
+		if len(response.Segments) != 1 || response.Segments[0].Text != " Hello from clip.wav in auto." {
+			t.Fatalf("response = %+v", response)
+		}
+	}
+	if strings.Join(fakes.launches, ",") != "base.bin,large.bin,large.bin@1" || fakes.stops != 2 {
+		t.Fatalf("launches = %v, stops = %d", fakes.launches, fakes.stops)
+	}
+}
+
+// TestWhisperServerStopsWhenIdle frees the model after the idle timeout.
+func TestWhisperServerStopsWhenIdle(t *testing.T) {
+	audio := filepath.Join(t.TempDir(), "clip.wav")
+	mustWriteFile(t, audio, "wav")
+	fakes := &fakeServers{}
+	server := NewWhisperServer(20 * time.Millisecond)
+	server.launch = fakes.launch()
+
+	if _, _, err := server.transcribe(context.Background(), "whisper-server", "/m/base.bin", "", audio, "de", "", false); err != nil {
+		t.Fatalf("transcribe: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		fakes.mu.Lock()
+		stops := fakes.stops
+		fakes.mu.Unlock()
+		if stops == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("idle server was not stopped")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestWhisperServerCloseAbortsRequest checks Close stops the server without
+// waiting for the transcription in flight, which then fails.
+func TestWhisperServerCloseAbortsRequest(t *testing.T) {
+	audio := filepath.Join(t.TempDir(), "clip.wav")
+	mustWriteFile(t, audio, "wav")
+	fakes := &fakeServers{inferring: make(chan struct{})}
+	server := NewWhisperServer(time.Minute)
+	server.launch = fakes.launch()
+
+	result := make(chan error, 1)
+	go func() {
+		_, _, err := server.transcribe(context.Background(), "whisper-server", "/m/base.bin", "", audio, "", "", false)
+		result <- err
+	}()
+	<-fakes.inferring
+
+	closed := make(chan struct{})
+	go func() {
+		server.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close waited for the request in flight")
+	}
+	if err := <-result; err == nil {
+		t.Fatal("expected the aborted request to fail")
+	}
+	if fakes.stops != 1 {
+		t.Fatalf("stops = %d, want 1", fakes.stops)
+	}
+}
+
+// TestWhisperServerReportsEarlyExit fails when the process dies while loading.
+func TestWhisperServerReportsEarlyExit(t *testing.T) {
+	fakes := &fakeServers{loading: 1000, exitOnLaunch: true}
+	server := NewWhisperServer(time.Minute)
+	server.launch = fakes.launch()
+
+	_, _, err := server.transcribe(context.Background(), "whisper-server", "/m/base.bin", "", "clip.wav", "", "", false)
+	if err == nil || !strings.Contains(err.Error(), "exited") {
+		t.Fatalf("err = %v, want early exit", err)
+	}
+	if fakes.stops != 1 {
+		t.Fatalf("stops = %d, want 1", fakes.stops)
+	}
+}
+
+// TestPipelineRunWithServerWritesCLIOutputs transcribes on the warm server
+// and exports the transcript and scored segments like the CLI path.
+func TestPipelineRunWithServerWritesCLIOutputs(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "meeting.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		if name != "ffmpeg" {
+			t.Fatalf("unexpected command %s", name)
+		}
+		mustWriteFile(t, args[len(args)-1], "wav")
+		return commandResult{}, nil
+	}}
+	fakes := &fakeServers{}
+	server := NewWhisperServer(time.Minute)
+	server.launch = fakes.launch()
+	defer server.Close()
+
+	var live []TranscriptSegment
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath: inputPath,
+		ModelPath: modelPath,
+		Language:  "auto",
+		OutputDir: outputDir,
+		Server:    server,
+		OnSegment: func(segment TranscriptSegment) { live = append(live, segment) },
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if !strings.HasPrefix(result.Transcript, "Hello from ") {
+		t.Fatalf("transcript = %q", result.Transcript)
+	}
+	if len(result.Segments) != 1 || result.Segments[0].StartMs != 500 || result.Segments[0].EndMs != 2250 || result.Segments[0].Confidence != 0.7 {
+		t.Fatalf("segments = %+v", result.Segments)
+	}
+	if len(live) != 1 || live[0].Text != result.Segments[0].Text {
+		t.Fatalf("live segments = %+v", live)
+	}
+	if len(result.Logs) != 2 || result.Logs[1].Command != DefaultServerPath {
+		t.Fatalf("logs = %+v", result.Logs)
+	}
+}
+
+// TestPipelineRunWithServerTimesOut reports a transcription timeout.
+func TestPipelineRunWithServerTimesOut(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "meeting.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		mustWriteFile(t, args[len(args)-1], "wav")
+		return commandResult{}, nil
+	}}
+	fakes := &fakeServers{loading: 1 << 30}
+	server := NewWhisperServer(time.Minute)
+	server.launch = fakes.launch()
+	defer server.Close()
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	_, err := pipeline.Run(context.Background(), Request{
+		InputPath:         inputPath,
+		ModelPath:         modelPath,
+		OutputDir:         filepath.Join(root, "output"),
+		TranscribeTimeout: 50 * time.Millisecond,
+		Server:            server,
+	})
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("err = %v, want timeout", err)
+	}
+}
+
+// TestWhisperLanguageCode maps server language names to codes.
+func TestWhisperLanguageCode(t *testing.T) {
+	for name, want := range map[string]string{"english": "en", "Japanese": "ja", "de": "de", "": ""} {
+		if got := whisperLanguageCode(name); got != want {
+			t.Errorf("whisperLanguageCode(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
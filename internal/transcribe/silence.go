@@ -0,0 +1,116 @@
+package transcribe
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultSilenceGap is the shortest stretch without speech reported as
+	// silence when the request sets none.
+	DefaultSilenceGap = 10 * time.Second
+	// SilenceMapSuffix is appended to the output name of the silence map.
+	SilenceMapSuffix = ".silence.json"
+)
+
+// SilenceGap is a stretch of the input in which nothing was transcribed.
+type SilenceGap struct {
+	StartMs int64 `json:"startMs"`
+	EndMs   int64 `json:"endMs"`
+	// Kind is "music" when SkipNonSpeech cut music there, else "silence".
+	Kind string `json:"kind"`
+}
+
+// silenceMap is the content of <name>.silence.json.
+type silenceMap struct {
+	Input      string       `json:"input"`
+	DurationMs int64        `json:"durationMs,omitempty"`
+	MinGapMs   int64        `json:"minGapMs"`
+	Gaps       []SilenceGap `json:"gaps"`
+}
+
+// findSilence returns the gaps of at least minGap between the segments,
+// including those before the first and, when audioMs is known, after the
+// last. Segments may overlap, as the two speakers of split channels do.
+func findSilence(segments []TranscriptSegment, skipped []SkippedRegion, audioMs int64, minGap time.Duration) []SilenceGap {
+	spoken := make([]TranscriptSegment, 0, len(segments))
+	for _, segment := range segments {
+		if strings.TrimSpace(segment.Text) != "" {
+			spoken = append(spoken, segment)
+		}
+	}
+	sort.SliceStable(spoken, func(i, j int) bool { return spoken[i].StartMs < spoken[j].StartMs })
+
+	var gaps []SilenceGap
+	addGap := func(start, end int64) {
+		if end-start >= minGap.Milliseconds() {
+			gaps = append(gaps, SilenceGap{StartMs: start, EndMs: end, Kind: gapKind(start, end, skipped)})
+		}
+	}
+	var covered int64
+	for _, segment := range spoken {
+		addGap(covered, segment.StartMs)
+		covered = max(covered, segment.EndMs)
+	}
+	if audioMs > covered {
+		addGap(covered, audioMs)
+	}
+	return gaps
+}
+
+// gapKind labels a gap "music" when it overlaps music cut before
+// transcription, and "silence" otherwise.
+func gapKind(startMs, endMs int64, skipped []SkippedRegion) string {
+	for _, region := range skipped {
+		if region.Kind == "music" && region.StartMs < endMs && region.EndMs > startMs {
+			return "music"
+		}
+	}
+	return "silence"
+}
+
+// silenceMarker renders a gap as a transcript line such as
+// "[silence 00:12:30–00:14:02]".
+func silenceMarker(gap SilenceGap) string {
+	return fmt.Sprintf("[%s %s–%s]", gap.Kind, markerTime(gap.StartMs), markerTime(gap.EndMs))
+}
+
+// markerTime renders milliseconds as HH:MM:SS.
+func markerTime(ms int64) string {
+	return fmt.Sprintf("%02d:%02d:%02d", ms/3600000, ms/60000%60, ms/1000%60)
+}
+
+// exportSilence finds the gaps in the segments when the request asks for
+// silence markers or a silence map and writes <name>.silence.json. It returns
+// the gaps and the map's path; without segments there is nothing to place
+// gaps between.
+func (p *Pipeline) exportSilence(req Request, segments []TranscriptSegment, skipped []SkippedRegion, audioMs int64, textBase string) ([]SilenceGap, string, error) {
+	if (!req.SilenceMarkers && !req.SilenceMap) || len(segments) == 0 {
+		return nil, "", nil
+	}
+	minGap := req.SilenceMinGap
+	if minGap <= 0 {
+		minGap = DefaultSilenceGap
+	}
+	gaps := findSilence(segments, skipped, audioMs, minGap)
+	if !req.SilenceMap {
+		return gaps, "", nil
+	}
+	mapPath := textBase + SilenceMapSuffix
+	data, err := json.MarshalIndent(silenceMap{
+		Input:      req.InputPath,
+		DurationMs: audioMs,
+		MinGapMs:   minGap.Milliseconds(),
+		Gaps:       append([]SilenceGap{}, gaps...),
+	}, "", "  ")
+	if err != nil {
+		return nil, mapPath, err
+	}
+	if err := p.writeFile(mapPath, append(data, '\n'), 0o644); err != nil {
+		return nil, mapPath, err
+	}
+	return gaps, mapPath, nil
+}
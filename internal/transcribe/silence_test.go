@@ -0,0 +1,109 @@
+package transcribe
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFindSilence reports long gaps before, between, and after segments.
+func TestFindSilence(t *testing.T) {
+	segments := []TranscriptSegment{
+		{StartMs: 12000, EndMs: 15000, Text: " Hello."},
+		{StartMs: 14000, EndMs: 16000, Text: " Hi.", Speaker: "Speaker 2"},
+		{StartMs: 20000, EndMs: 21000, Text: " "},
+		{StartMs: 30000, EndMs: 32000, Text: " Bye."},
+	}
+	tests := []struct {
+		name    string
+		skipped []SkippedRegion
+		audioMs int64
+		minGap  time.Duration
+		want    []SilenceGap
+	}{
+		{
+			name:   "between overlapping speakers",
+			minGap: 10 * time.Second,
+			want:   []SilenceGap{{StartMs: 0, EndMs: 12000, Kind: "silence"}, {StartMs: 16000, EndMs: 30000, Kind: "silence"}},
+		},
+		{
+			name:    "trailing and music",
+			skipped: []SkippedRegion{{StartMs: 18000, EndMs: 28000, Kind: "music"}},
+			audioMs: 50000,
+			minGap:  13 * time.Second,
+			want:    []SilenceGap{{StartMs: 16000, EndMs: 30000, Kind: "music"}, {StartMs: 32000, EndMs: 50000, Kind: "silence"}},
+		},
+		{name: "none long enough", minGap: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findSilence(segments, tt.skipped, tt.audioMs, tt.minGap)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("findSilence() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPipelineRunExportsSilence writes markers into the transcript and the
+// gaps into <name>.silence.json.
+func TestPipelineRunExportsSilence(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "lecture.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		if name == "ffmpeg" {
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		}
+		base := argValue(args, "-of")
+		mustWriteFile(t, base+".txt", " Part one.\n Part two.\n")
+		mustWriteFile(t, base+".json", `{"transcription":[`+
+			`{"offsets":{"from":0,"to":4000},"text":" Part one."},`+
+			`{"offsets":{"from":34000,"to":36000},"text":" Part two."}]}`)
+		return commandResult{}, nil
+	}}
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath:      inputPath,
+		ModelPath:      modelPath,
+		OutputDir:      outputDir,
+		SilenceMarkers: true,
+		SilenceMap:     true,
+		SilenceMinGap:  20 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	const want = "Part one.\n[silence 00:00:04–00:00:34]\nPart two.\n"
+	data, err := os.ReadFile(result.TextPath)
+	if err != nil || string(data) != want || result.Transcript != strings.TrimSpace(want) {
+		t.Fatalf("transcript file = %q (%v), result = %q, want %q", data, err, result.Transcript, want)
+	}
+	if result.SilenceMapPath != filepath.Join(outputDir, "lecture"+SilenceMapSuffix) {
+		t.Fatalf("silence map path = %q", result.SilenceMapPath)
+	}
+	data, err = os.ReadFile(result.SilenceMapPath)
+	if err != nil {
+		t.Fatalf("read silence map: %v", err)
+	}
+	var stored silenceMap
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("parse silence map: %v", err)
+	}
+	wantGaps := []SilenceGap{{StartMs: 4000, EndMs: 34000, Kind: "silence"}}
+	if stored.Input != inputPath || stored.MinGapMs != 20000 || !reflect.DeepEqual(stored.Gaps, wantGaps) || !reflect.DeepEqual(result.Silence, wantGaps) {
+		t.Fatalf("silence map = %+v, result gaps = %+v", stored, result.Silence)
+	}
+}
@@ -0,0 +1,281 @@
+package transcribe
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// minSkipMs is the shortest silence or music region that is cut before
+	// transcribing; shorter pauses are left to whisper.
+	minSkipMs = 10000
+	// skipPaddingMs of a skipped region's edges is still transcribed so
+	// speech next to it is not clipped.
+	skipPaddingMs = 500
+	// silenceNoise is the level below which silencedetect counts silence.
+	silenceNoise = "-40dB"
+	// musicWindowMs is the window over which momentary loudness is compared:
+	// speech dips between words and phrases, music holds a steady level.
+	musicWindowMs = 3000
+	// musicMaxRangeLU is the largest momentary loudness swing within one
+	// window that still counts as steady.
+	musicMaxRangeLU = 4.0
+	// musicMinLUFS is the quietest steady level counted as music rather
+	// than silence or room tone.
+	musicMinLUFS = -45.0
+)
+
+var (
+	silenceStartPattern = regexp.MustCompile(`silence_start:\s*(-?[\d.]+)`)
+	silenceEndPattern   = regexp.MustCompile(`silence_end:\s*(-?[\d.]+)`)
+	loudnessPattern     = regexp.MustCompile(`\bt:\s*([\d.]+)\s+TARGET:.*?\bM:\s*(\S+)`)
+)
+
+// SkippedRegion is a stretch of the input, on its own timeline, that was not
+// transcribed because it held only silence or music.
+type SkippedRegion struct {
+	StartMs int64 `json:"startMs"`
+	EndMs   int64 `json:"endMs"`
+	// Kind is "silence" or "music".
+	Kind string `json:"kind"`
+}
+
+// keptRange is a stretch of the preprocessed audio that is transcribed;
+// offsetMs is where it starts in the trimmed audio.
+type keptRange struct {
+	startMs  int64
+	endMs    int64
+	offsetMs int64
+}
+
+// speechScan is the outcome of skipNonSpeech.
+type speechScan struct {
+	// path is the trimmed WAV, or "" when nothing was cut.
+	path    string
+	kept    []keptRange
+	skipped []SkippedRegion
+	logs    []CommandLog
+}
+
+// skipNonSpeech scans the preprocessed audio for long silence and music and,
+// when it finds any, writes a WAV without them. Errors only mean the full
+// audio is transcribed, so the caller need not fail the job.
+func (p *Pipeline) skipNonSpeech(ctx context.Context, req Request, ffmpegPath, audioPath, tempDir string) (speechScan, error) {
+	var scan speechScan
+	info, err := p.stat(audioPath)
+	if err != nil {
+		return scan, fmt.Errorf("measure preprocessed audio: %w", err)
+	}
+	totalMs := max(info.Size()-PreprocessedHeaderBytes, 0) * 1000 / PreprocessedBytesPerSecond
+
+	args := buildSpeechScanArgs(audioPath)
+	result, runErr := p.runStage(ctx, req.PreprocessTimeout, commandOptions{lowPriority: req.LowPriority}, ffmpegPath, args...)
+	scan.logs = append(scan.logs, CommandLog{
+		Command:  ffmpegPath,
+		Args:     args,
+		ExitCode: result.ExitCode,
+		Stdout:   result.Stdout,
+		// The per-frame loudness lines would swamp the log.
+		Stderr:          dropLoudnessLines(result.Stderr),
+		PeakMemoryBytes: result.PeakMemoryBytes,
+	})
+	if runErr != nil {
+		return scan, fmt.Errorf("scan for non-speech audio: %w", runErr)
+	}
+
+	skipped := nonSpeechRegions(result.Stderr, totalMs)
+	kept := keptRanges(skipped, totalMs)
+	if len(skipped) == 0 || len(kept) == 0 {
+		// Nothing to cut, or nothing left to transcribe: keep the full audio.
+		return scan, nil
+	}
+
+	speechPath := filepath.Join(tempDir, "speech-16k-mono.wav")
+	args = buildTrimArgs(audioPath, speechPath, kept)
+	result, runErr = p.runStage(ctx, req.PreprocessTimeout, commandOptions{lowPriority: req.LowPriority}, ffmpegPath, args...)
+	scan.logs = append(scan.logs, CommandLog{
+		Command:         ffmpegPath,
+		Args:            args,
+		ExitCode:        result.ExitCode,
+		Stdout:          result.Stdout,
+		Stderr:          result.Stderr,
+		PeakMemoryBytes: result.PeakMemoryBytes,
+	})
+	if runErr != nil {
+		return scan, fmt.Errorf("cut non-speech audio: %w", runErr)
+	}
+	if _, err := p.stat(speechPath); err != nil {
+		return scan, fmt.Errorf("cut non-speech audio: %w", err)
+	}
+	scan.path, scan.kept, scan.skipped = speechPath, kept, skipped
+	return scan, nil
+}
+
+// buildSpeechScanArgs builds an ffmpeg run that decodes the audio once,
+// reporting long silences (silencedetect) and momentary loudness every
+// 100 ms (ebur128) on stderr.
+func buildSpeechScanArgs(audioPath string) []string {
+	return []string{
+		"-hide_banner",
+		"-nostdin",
+		"-i", audioPath,
+		"-af", fmt.Sprintf("silencedetect=noise=%s:d=%g,ebur128", silenceNoise, float64(minSkipMs)/1000),
+		"-f", "null",
+		"-",
+	}
+}
+
+// buildTrimArgs builds an ffmpeg run that keeps only the kept ranges of the
+// audio, joined back to back.
+func buildTrimArgs(audioPath, outPath string, kept []keptRange) []string {
+	terms := make([]string, len(kept))
+	for i, r := range kept {
+		terms[i] = fmt.Sprintf("between(t,%.3f,%.3f)", float64(r.startMs)/1000, float64(r.endMs)/1000)
+	}
+	return []string{
+		"-hide_banner",
+		"-nostdin",
+		"-y",
+		"-i", audioPath,
+		"-af", fmt.Sprintf("aselect='%s',asetpts=N/SR/TB", strings.Join(terms, "+")),
+		"-c:a", "pcm_s16le",
+		outPath,
+	}
+}
+
+// nonSpeechRegions returns the silence and music regions of at least
+// minSkipMs reported in the scan output, merged, in order, and shrunk by
+// skipPaddingMs on every edge that borders audio.
+func nonSpeechRegions(output string, totalMs int64) []SkippedRegion {
+	regions := append(silenceRegions(output, totalMs), musicRegions(output)...)
+	sort.Slice(regions, func(i, j int) bool { return regions[i].StartMs < regions[j].StartMs })
+
+	var merged []SkippedRegion
+	for _, region := range regions {
+		if n := len(merged); n > 0 && region.StartMs <= merged[n-1].EndMs {
+			merged[n-1].EndMs = max(merged[n-1].EndMs, region.EndMs)
+			continue
+		}
+		merged = append(merged, region)
+	}
+
+	var skipped []SkippedRegion
+	for _, region := range merged {
+		region.EndMs = min(region.EndMs, totalMs)
+		if region.EndMs-region.StartMs < minSkipMs {
+			continue
+		}
+		if region.StartMs > 0 {
+			region.StartMs += skipPaddingMs
+		}
+		if region.EndMs < totalMs {
+			region.EndMs -= skipPaddingMs
+		}
+		skipped = append(skipped, region)
+	}
+	return skipped
+}
+
+// silenceRegions reads silencedetect's start and end lines; a silence still
+// open at the end of the output runs to totalMs.
+func silenceRegions(output string, totalMs int64) []SkippedRegion {
+	var regions []SkippedRegion
+	start := int64(-1)
+	for _, line := range strings.Split(output, "\n") {
+		if match := silenceStartPattern.FindStringSubmatch(line); match != nil {
+			start = max(secondsToMs(match[1]), 0)
+		} else if match := silenceEndPattern.FindStringSubmatch(line); match != nil && start >= 0 {
+			regions = append(regions, SkippedRegion{StartMs: start, EndMs: secondsToMs(match[1]), Kind: "silence"})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		regions = append(regions, SkippedRegion{StartMs: start, EndMs: totalMs, Kind: "silence"})
+	}
+	return regions
+}
+
+// musicRegions groups ebur128's momentary loudness into musicWindowMs
+// windows and returns runs of windows whose loudness holds steady at an
+// audible level, which is how music differs from speech.
+func musicRegions(output string) []SkippedRegion {
+	var regions []SkippedRegion
+	window := int64(-1)
+	low, high := math.Inf(1), math.Inf(-1)
+	runStart := int64(-1)
+	closeWindow := func() {
+		if window < 0 {
+			return
+		}
+		windowStart := window * musicWindowMs
+		steady := low >= musicMinLUFS && high-low <= musicMaxRangeLU
+		switch {
+		case steady && runStart < 0:
+			runStart = windowStart
+		case !steady && runStart >= 0:
+			regions = append(regions, SkippedRegion{StartMs: runStart, EndMs: windowStart, Kind: "music"})
+			runStart = -1
+		}
+	}
+	for _, line := range strings.Split(output, "\n") {
+		match := loudnessPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		loudness, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			continue
+		}
+		// ebur128 stamps the end of each 400 ms measurement.
+		at := secondsToMs(match[1])
+		if index := at / musicWindowMs; index != window {
+			closeWindow()
+			window, low, high = index, math.Inf(1), math.Inf(-1)
+		}
+		low, high = min(low, loudness), max(high, loudness)
+	}
+	closeWindow()
+	if runStart >= 0 {
+		regions = append(regions, SkippedRegion{StartMs: runStart, EndMs: (window + 1) * musicWindowMs, Kind: "music"})
+	}
+	return regions
+}
+
+// keptRanges returns the complement of skipped within [0, totalMs], with
+// each range's position in the trimmed audio.
+func keptRanges(skipped []SkippedRegion, totalMs int64) []keptRange {
+	var kept []keptRange
+	var at, offset int64
+	for _, region := range append(skipped, SkippedRegion{StartMs: totalMs, EndMs: totalMs}) {
+		if region.StartMs > at {
+			kept = append(kept, keptRange{startMs: at, endMs: region.StartMs, offsetMs: offset})
+			offset += region.StartMs - at
+		}
+		at = max(at, region.EndMs)
+	}
+	return kept
+}
+
+// dropLoudnessLines removes ebur128's per-frame lines from scan output.
+func dropLoudnessLines(output string) string {
+	lines := strings.Split(output, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !loudnessPattern.MatchString(line) {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// secondsToMs parses a decimal seconds value into milliseconds.
+func secondsToMs(raw string) int64 {
+	seconds, _ := strconv.ParseFloat(raw, 64)
+	return int64(math.Round(seconds * 1000))
+}
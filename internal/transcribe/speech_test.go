@@ -0,0 +1,134 @@
+package transcribe
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// loudnessLines renders ebur128 frame lines every 100 ms from fromMs to toMs
+// with momentary loudness from level.
+func loudnessLines(fromMs, toMs int64, level func(ms int64) float64) string {
+	var b strings.Builder
+	for ms := fromMs + 100; ms <= toMs; ms += 100 {
+		fmt.Fprintf(&b, "[Parsed_ebur128_1 @ 0x1] t: %.1f      TARGET:-23 LUFS    M: %.1f S: -22.0     I: -23.0 LUFS       LRA:   3.0 LU\n",
+			float64(ms)/1000, level(ms))
+	}
+	return b.String()
+}
+
+// TestNonSpeechRegions finds long silence and steady music, ignores short
+// pauses, and pads the edges next to speech.
+func TestNonSpeechRegions(t *testing.T) {
+	speech := func(ms int64) float64 {
+		if ms%1000 < 300 {
+			return -38 // pause between phrases
+		}
+		return -20
+	}
+	output := "[silencedetect @ 0x2] silence_start: 0\n" +
+		"[silencedetect @ 0x2] silence_end: 12.4 | silence_duration: 12.4\n" +
+		loudnessLines(0, 12000, func(int64) float64 { return -70 }) +
+		loudnessLines(12000, 30000, func(int64) float64 { return -18.5 }) +
+		loudnessLines(30000, 60000, speech) +
+		loudnessLines(60000, 66000, func(int64) float64 { return -19 }) +
+		loudnessLines(66000, 90000, speech)
+
+	got := nonSpeechRegions(output, 90000)
+	want := []SkippedRegion{
+		{StartMs: 0, EndMs: 11900, Kind: "silence"},
+		// Music is found in whole windows, so it starts at the first window
+		// after the silence.
+		{StartMs: 15500, EndMs: 29500, Kind: "music"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("regions = %+v, want %+v", got, want)
+	}
+
+	trailing := "[silencedetect @ 0x2] silence_start: 75.25\n"
+	if got := nonSpeechRegions(trailing, 90000); !reflect.DeepEqual(got, []SkippedRegion{{StartMs: 75750, EndMs: 90000, Kind: "silence"}}) {
+		t.Fatalf("trailing silence = %+v", got)
+	}
+}
+
+// TestTimelineMapsAcrossCuts maps trimmed, sped-up times to the input.
+func TestTimelineMapsAcrossCuts(t *testing.T) {
+	kept := keptRanges([]SkippedRegion{{StartMs: 0, EndMs: 15000}, {StartMs: 30000, EndMs: 50000}}, 60000)
+	want := []keptRange{{startMs: 15000, endMs: 30000}, {startMs: 50000, endMs: 60000, offsetMs: 15000}}
+	if !reflect.DeepEqual(kept, want) {
+		t.Fatalf("kept = %+v, want %+v", kept, want)
+	}
+
+	line := timeline{tempo: 1, kept: kept}
+	for in, out := range map[int64]int64{0: 15000, 14000: 29000, 15000: 50000, 16000: 51000} {
+		if got := line.toSource(in); got != out {
+			t.Errorf("toSource(%d) = %d, want %d", in, got, out)
+		}
+	}
+	if got := (timeline{tempo: 1.5, kept: kept}).toSource(16000); got != 76500 {
+		t.Errorf("sped-up toSource = %d, want 76500", got)
+	}
+}
+
+// TestPipelineRunSkipsNonSpeech transcribes only the speech and keeps the
+// timestamps on the input's timeline.
+func TestPipelineRunSkipsNonSpeech(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "podcast.mp3")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	var trimArgs []string
+	var transcribed string
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		switch {
+		case name == "ffmpeg" && argValue(args, "-f") == "null":
+			return commandResult{Stderr: "[silencedetect @ 0x2] silence_start: 0\n[silencedetect @ 0x2] silence_end: 20 | silence_duration: 20\n"}, nil
+		case name == "ffmpeg" && strings.HasPrefix(argValue(args, "-af"), "aselect="):
+			trimArgs = args
+			mustWriteFile(t, args[len(args)-1], "speech")
+		case name == "ffmpeg":
+			mustWriteFile(t, args[len(args)-1], string(make([]byte, PreprocessedHeaderBytes+60*PreprocessedBytesPerSecond)))
+		default:
+			transcribed = filepath.Base(argValue(args, "-f"))
+			base := argValue(args, "-of")
+			mustWriteFile(t, base+".txt", "Welcome to the show.")
+			mustWriteFile(t, base+".json", `{"transcription":[{"offsets":{"from":1000,"to":3000},"text":" Welcome to the show."}]}`)
+		}
+		return commandResult{}, nil
+	}}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath:     inputPath,
+		ModelPath:     modelPath,
+		OutputDir:     outputDir,
+		SkipNonSpeech: true,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if transcribed != "speech-16k-mono.wav" {
+		t.Fatalf("transcribed %s, want the trimmed audio", transcribed)
+	}
+	if got := argValue(trimArgs, "-af"); got != "aselect='between(t,19.500,60.000)',asetpts=N/SR/TB" {
+		t.Fatalf("trim filter = %q", got)
+	}
+	if !reflect.DeepEqual(result.Skipped, []SkippedRegion{{StartMs: 0, EndMs: 19500, Kind: "silence"}}) {
+		t.Fatalf("skipped = %+v", result.Skipped)
+	}
+	if len(result.Segments) != 1 || result.Segments[0].StartMs != 20500 || result.Segments[0].EndMs != 22500 {
+		t.Fatalf("segments = %+v", result.Segments)
+	}
+	if len(result.Logs) != 4 {
+		t.Fatalf("logs = %d, want preprocess, scan, trim, and whisper", len(result.Logs))
+	}
+}
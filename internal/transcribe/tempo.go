@@ -0,0 +1,60 @@
+package transcribe
+
+import "strconv"
+
+// MaxTempo bounds the preprocessing speed-up: a single ffmpeg atempo filter
+// handles up to 2x, and whisper's accuracy drops quickly beyond it.
+const MaxTempo = 2.0
+
+// speedFactor returns the factor a request speeds its audio up by, clamped to
+// MaxTempo; values at or below 1 mean normal speed.
+func speedFactor(tempo float64) float64 {
+	if tempo <= 1 {
+		return 1
+	}
+	return min(tempo, MaxTempo)
+}
+
+// formatTempo renders a speed factor for ffmpeg and cache keys, e.g. "1.25".
+func formatTempo(tempo float64) string {
+	return strconv.FormatFloat(tempo, 'f', -1, 64)
+}
+
+// timeline maps times whisper reports on the audio it transcribed back to the
+// input: first across the cuts of skipped non-speech regions, then undoing
+// the speed-up.
+type timeline struct {
+	tempo float64
+	// kept lists the stretches of the preprocessed audio that were
+	// transcribed, in order; empty when nothing was cut.
+	kept []keptRange
+}
+
+// toSource maps a time on the transcribed audio to the input's timeline.
+func (t timeline) toSource(ms int64) int64 {
+	for i := len(t.kept) - 1; i >= 0; i-- {
+		if ms >= t.kept[i].offsetMs {
+			ms = t.kept[i].startMs + ms - t.kept[i].offsetMs
+			break
+		}
+	}
+	if t.tempo <= 1 {
+		return ms
+	}
+	return int64(float64(ms)*t.tempo + 0.5)
+}
+
+// segment maps one segment's timestamps to the input's timeline.
+func (t timeline) segment(segment TranscriptSegment) TranscriptSegment {
+	segment.StartMs = t.toSource(segment.StartMs)
+	segment.EndMs = t.toSource(segment.EndMs)
+	return segment
+}
+
+// segments applies segment to every segment in place.
+func (t timeline) segments(segments []TranscriptSegment) []TranscriptSegment {
+	for i := range segments {
+		segments[i] = t.segment(segments[i])
+	}
+	return segments
+}
@@ -0,0 +1,72 @@
+package transcribe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestSpeedFactor treats factors up to 1 as normal speed and clamps the rest.
+func TestSpeedFactor(t *testing.T) {
+	for tempo, want := range map[float64]float64{0: 1, 0.5: 1, 1: 1, 1.25: 1.25, 1.5: 1.5, 3: MaxTempo} {
+		if got := speedFactor(tempo); got != want {
+			t.Errorf("speedFactor(%v) = %v, want %v", tempo, got, want)
+		}
+	}
+}
+
+// TestPipelineRunWithTempoScalesTimestamps speeds the audio up in ffmpeg and
+// maps whisper's timestamps back to the original timeline.
+func TestPipelineRunWithTempoScalesTimestamps(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "lecture.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	var ffmpegArgs []string
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		if name == "ffmpeg" {
+			ffmpegArgs = args
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		}
+		base := argValue(args, "-of")
+		mustWriteFile(t, base+".txt", "Hello there. Mumbled words")
+		mustWriteFile(t, base+".json", sampleWhisperJSON)
+		return commandResult{}, nil
+	}}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		Subtitles: true,
+		InputPath: inputPath,
+		ModelPath: modelPath,
+		OutputDir: outputDir,
+		Tempo:     1.5,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if argValue(ffmpegArgs, "-af") != "atempo=1.5" {
+		t.Fatalf("ffmpeg args = %v, want atempo=1.5", ffmpegArgs)
+	}
+	if result.Tempo != 1.5 {
+		t.Fatalf("result tempo = %v", result.Tempo)
+	}
+	if len(result.Segments) < 2 || result.Segments[0].EndMs != 3750 || result.Segments[1].StartMs != 93750 || result.Segments[1].EndMs != 97500 {
+		t.Fatalf("segments = %+v", result.Segments)
+	}
+	srt, err := os.ReadFile(filepath.Join(outputDir, "lecture.srt"))
+	if err != nil {
+		t.Fatalf("read srt: %v", err)
+	}
+	if !strings.Contains(string(srt), "00:01:33,750 --> 00:01:37,500") {
+		t.Fatalf("srt = %s", srt)
+	}
+}
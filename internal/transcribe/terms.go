@@ -0,0 +1,208 @@
+package transcribe
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"media-transcriber/internal/analyze"
+)
+
+// maxPromptRunes bounds the glossary prompt: whisper keeps only the last 224
+// tokens of its initial prompt, so a longer list would lose its first terms.
+const maxPromptRunes = 600
+
+// termsPrompt renders terms as whisper's initial prompt, which biases
+// recognition toward their spelling; "" when there are none.
+func termsPrompt(terms []string) string {
+	var listed []string
+	length := 0
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		length += utf8.RuneCountInString(term) + 2
+		if length > maxPromptRunes {
+			break
+		}
+		listed = append(listed, term)
+	}
+	if len(listed) == 0 {
+		return ""
+	}
+	return "Glossary: " + strings.Join(listed, ", ") + "."
+}
+
+// whisperPromptArgs returns the CLI flags passing the terms prompt, if any.
+func whisperPromptArgs(terms []string) []string {
+	if prompt := termsPrompt(terms); prompt != "" {
+		return []string{"--prompt", prompt}
+	}
+	return nil
+}
+
+// termCorrector rewrites words that match a known term up to casing or a
+// small misspelling to the term's own spelling. Short terms and common words
+// keep the casing they were heard with.
+type termCorrector struct {
+	// terms holds the known terms, longest word count first so "New York
+	// Times" wins over "New York".
+	terms []knownTerm
+}
+
+// knownTerm is one term with its words lowercased for comparison.
+type knownTerm struct {
+	text  string
+	words []string
+}
+
+// wordSpan is one word of a text and its byte range.
+type wordSpan struct {
+	start, end int
+	lower      string
+}
+
+// newTermCorrector returns a corrector for terms, or nil when there are none.
+func newTermCorrector(terms []string) *termCorrector {
+	var known []knownTerm
+	for _, term := range terms {
+		spans := splitWords(term)
+		if len(spans) == 0 {
+			continue
+		}
+		words := make([]string, len(spans))
+		for i, span := range spans {
+			words[i] = span.lower
+		}
+		known = append(known, knownTerm{text: strings.TrimSpace(term), words: words})
+	}
+	if len(known) == 0 {
+		return nil
+	}
+	sort.SliceStable(known, func(i, j int) bool { return len(known[i].words) > len(known[j].words) })
+	return &termCorrector{terms: known}
+}
+
+// correct returns text with every near match of a term replaced by the term.
+func (c *termCorrector) correct(text string) string {
+	if c == nil {
+		return text
+	}
+	spans := splitWords(text)
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(spans); {
+		term, ok := c.match(spans[i:])
+		if !ok {
+			i++
+			continue
+		}
+		end := spans[i+len(term.words)-1].end
+		b.WriteString(text[last:spans[i].start])
+		b.WriteString(term.text)
+		last = end
+		i += len(term.words)
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// match returns the first term the words starting at spans[0] spell.
+func (c *termCorrector) match(spans []wordSpan) (knownTerm, bool) {
+	for _, term := range c.terms {
+		if len(term.words) > len(spans) {
+			continue
+		}
+		distance, letters := 0, 0
+		for i, word := range term.words {
+			heard := spans[i].lower
+			// A different first letter is a different word, not a misspelling.
+			if first(heard) != first(word) {
+				distance = -1
+				break
+			}
+			distance += editDistance(heard, word)
+			letters += utf8.RuneCountInString(word)
+		}
+		if distance < 0 || distance > allowedTypos(letters) {
+			continue
+		}
+		// Only the casing differs: "go" or "every" in speech is rarely the
+		// term "Go" or "Every", so short and everyday words stay as heard.
+		if distance == 0 && (letters < 5 || (len(term.words) == 1 && analyze.IsStopword(term.words[0]))) {
+			continue
+		}
+		return term, true
+	}
+	return knownTerm{}, false
+}
+
+// allowedTypos is how many edits a term of letters runes tolerates: short
+// terms must be heard exactly.
+func allowedTypos(letters int) int {
+	switch {
+	case letters < 5:
+		return 0
+	case letters < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// splitWords returns the words of text: runs of letters, digits, apostrophes,
+// and inner hyphens.
+func splitWords(text string) []wordSpan {
+	var spans []wordSpan
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r) || ((r == '\'' || r == '-') && start >= 0)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			spans = append(spans, newWordSpan(text, start, i))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, newWordSpan(text, start, len(text)))
+	}
+	return spans
+}
+
+// newWordSpan trims trailing apostrophes and hyphens off text[start:end].
+func newWordSpan(text string, start, end int) wordSpan {
+	end = start + len(strings.TrimRight(text[start:end], "'-"))
+	return wordSpan{start: start, end: end, lower: strings.ToLower(text[start:end])}
+}
+
+// first returns the first rune of s.
+func first(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+// editDistance is the Levenshtein distance between a and b in runes.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
@@ -0,0 +1,104 @@
+package transcribe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTermCorrectorRespellsNearMatches fixes casing and small misspellings of
+// known terms and leaves other words alone.
+func TestTermCorrectorRespellsNearMatches(t *testing.T) {
+	corrector := newTermCorrector([]string{"Kubernetes", "PostgreSQL", "New York Times", "Go", "Korvin", "Every"})
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "we deploy on kubernetis now", want: "we deploy on Kubernetes now"},
+		{text: "running postgresql", want: "running PostgreSQL"},
+		{text: "the new york time's editors", want: "the New York Times editors"},
+		{text: "go to the store, Gone.", want: "go to the store, Gone."},
+		{text: "every day in Go", want: "every day in Go"},
+		{text: "KUBERNETES rocks", want: "Kubernetes rocks"},
+		{text: "Corvin and korvim met", want: "Corvin and Korvin met"},
+		{text: "nothing to fix", want: "nothing to fix"},
+	}
+	for _, tt := range tests {
+		if got := corrector.correct(tt.text); got != tt.want {
+			t.Errorf("correct(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+	if got := (*termCorrector)(nil).correct("kubernetis"); got != "kubernetis" {
+		t.Errorf("nil corrector changed text to %q", got)
+	}
+}
+
+// TestTermsPrompt lists terms as a glossary and stops before the prompt
+// outgrows whisper's context.
+func TestTermsPrompt(t *testing.T) {
+	if got := termsPrompt([]string{" Kubernetes ", "", "gRPC"}); got != "Glossary: Kubernetes, gRPC." {
+		t.Fatalf("termsPrompt = %q", got)
+	}
+	if got := termsPrompt(nil); got != "" {
+		t.Fatalf("termsPrompt(nil) = %q", got)
+	}
+	long := make([]string, 200)
+	for i := range long {
+		long[i] = "terminology"
+	}
+	if got := termsPrompt(long); len(got) > maxPromptRunes+len("Glossary: .") {
+		t.Fatalf("prompt has %d runes, want at most %d", len(got), maxPromptRunes)
+	}
+}
+
+// TestPipelineRunAppliesTerms passes the glossary prompt to whisper and
+// respells the transcript and its segments.
+func TestPipelineRunAppliesTerms(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "meeting.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	outputDir := filepath.Join(root, "output")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	var prompt string
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		if name == "ffmpeg" {
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		}
+		prompt = argValue(args, "--prompt")
+		base := argValue(args, "-of")
+		mustWriteFile(t, base+".txt", "We moved to kubernetis.")
+		mustWriteFile(t, base+".json", `{"transcription":[{"offsets":{"from":0,"to":1500},"text":" We moved to kubernetis."}]}`)
+		return commandResult{}, nil
+	}}
+
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath: inputPath,
+		ModelPath: modelPath,
+		OutputDir: outputDir,
+		Terms:     []string{"Kubernetes"},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	if prompt != "Glossary: Kubernetes." {
+		t.Fatalf("prompt = %q", prompt)
+	}
+	if result.Transcript != "We moved to Kubernetes." {
+		t.Fatalf("transcript = %q", result.Transcript)
+	}
+	data, err := os.ReadFile(result.TextPath)
+	if err != nil || !strings.Contains(string(data), "Kubernetes") {
+		t.Fatalf("text file = %q, %v", data, err)
+	}
+	if len(result.Segments) != 1 || !strings.Contains(result.Segments[0].Text, "Kubernetes") {
+		t.Fatalf("segments = %+v", result.Segments)
+	}
+}
@@ -0,0 +1,51 @@
+package transcribe
+
+import (
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// stageTimer measures consecutive pipeline stages: starting one stage ends the
+// previous one.
+type stageTimer struct {
+	stages  []domain.StageTiming
+	started time.Time
+	now     func() time.Time
+}
+
+// start ends the running stage, if any, and begins timing stage.
+func (t *stageTimer) start(stage string) {
+	now := t.clock()
+	t.end(now)
+	t.stages = append(t.stages, domain.StageTiming{Stage: stage})
+	t.started = now
+}
+
+// peak raises the running stage's peak memory to bytes.
+func (t *stageTimer) peak(bytes uint64) {
+	if n := len(t.stages); n > 0 && bytes > t.stages[n-1].PeakMemoryBytes {
+		t.stages[n-1].PeakMemoryBytes = bytes
+	}
+}
+
+// finish ends the running stage and returns every recorded stage.
+func (t *stageTimer) finish() []domain.StageTiming {
+	t.end(t.clock())
+	return t.stages
+}
+
+// end stamps the running stage's duration.
+func (t *stageTimer) end(now time.Time) {
+	if n := len(t.stages); n > 0 {
+		t.stages[n-1].Seconds = now.Sub(t.started).Seconds()
+	}
+}
+
+// clock returns the current time from the injected clock or the system.
+func (t *stageTimer) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
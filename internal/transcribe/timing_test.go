@@ -0,0 +1,41 @@
+package transcribe
+
+import (
+	"testing"
+	"time"
+)
+
+// TestStageTimerMeasuresConsecutiveStages ends each stage when the next starts.
+func TestStageTimerMeasuresConsecutiveStages(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	timer := stageTimer{now: func() time.Time { return clock }}
+
+	timer.start("preprocessing")
+	timer.peak(100)
+	timer.peak(50)
+	clock = clock.Add(3 * time.Second)
+	timer.start("transcribing")
+	timer.peak(800)
+	clock = clock.Add(90 * time.Second)
+	timer.start("exporting")
+	clock = clock.Add(500 * time.Millisecond)
+	stages := timer.finish()
+
+	want := []struct {
+		stage   string
+		seconds float64
+		peak    uint64
+	}{
+		{"preprocessing", 3, 100},
+		{"transcribing", 90, 800},
+		{"exporting", 0.5, 0},
+	}
+	if len(stages) != len(want) {
+		t.Fatalf("stages = %+v", stages)
+	}
+	for i, w := range want {
+		if stages[i].Stage != w.stage || stages[i].Seconds != w.seconds || stages[i].PeakMemoryBytes != w.peak {
+			t.Fatalf("stage %d = %+v, want %+v", i, stages[i], w)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package transcribe
+
+import (
+	"strings"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// DefaultTimestampInterval is the paragraph length of interval timestamps
+// when the request sets none.
+const DefaultTimestampInterval = 30 * time.Second
+
+// transcriptText renders segments as the .txt transcript: the segments are
+// regrouped by layout, then written one per line, prefixed with their start
+// for TextTimestampsSegment, or joined into paragraphs of about interval
+// prefixed with their start for TextTimestampsInterval, which also begin a new
+// paragraph when the speaker changes. Each gap gets a marker line where it
+// falls.
+func transcriptText(segments []TranscriptSegment, gaps []SilenceGap, layout domain.TextLayout, style domain.TextTimestamps, interval time.Duration) string {
+	if interval <= 0 {
+		interval = DefaultTimestampInterval
+	}
+	separator := "\n"
+	if layout == domain.TextLayoutParagraphs || style == domain.TextTimestampsInterval {
+		separator = "\n\n"
+	}
+	segments = resegment(segments, layout)
+
+	var blocks []string
+	var paragraph strings.Builder
+	var paragraphStart int64
+	var speaker string
+	closeParagraph := func() {
+		if paragraph.Len() > 0 {
+			blocks = append(blocks, paragraph.String())
+			paragraph.Reset()
+		}
+	}
+	next := 0
+	for _, segment := range segments {
+		if segment.Text == "" {
+			continue
+		}
+		for next < len(gaps) && gaps[next].StartMs <= segment.StartMs {
+			closeParagraph()
+			blocks = append(blocks, silenceMarker(gaps[next]))
+			next++
+		}
+		switch style {
+		case domain.TextTimestampsSegment:
+			blocks = append(blocks, timestampPrefix(segment.StartMs)+segment.labeledText())
+		case domain.TextTimestampsInterval:
+			if paragraph.Len() > 0 && segment.Speaker == speaker && segment.StartMs < paragraphStart+interval.Milliseconds() {
+				paragraph.WriteString(" " + segment.Text)
+				continue
+			}
+			closeParagraph()
+			paragraphStart, speaker = segment.StartMs, segment.Speaker
+			paragraph.WriteString(timestampPrefix(segment.StartMs) + segment.labeledText())
+		default:
+			blocks = append(blocks, segment.labeledText())
+		}
+	}
+	closeParagraph()
+	for _, gap := range gaps[next:] {
+		blocks = append(blocks, silenceMarker(gap))
+	}
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, separator) + "\n"
+}
+
+// timestampPrefix renders the "[00:12:30] " that starts a timestamped line.
+func timestampPrefix(ms int64) string {
+	return "[" + markerTime(ms) + "] "
+}
+
+// rewriteTranscript renders the transcript from segments and writes it to
+// textPath when the request asks for sentences or paragraphs, timestamps, or
+// silence markers and there are gaps. It returns the transcript, or "" when
+// whisper's is kept.
+func (p *Pipeline) rewriteTranscript(req Request, segments []TranscriptSegment, gaps []SilenceGap, textPath string) (string, error) {
+	if !req.SilenceMarkers {
+		gaps = nil
+	}
+	if len(segments) == 0 || (req.TextLayout == domain.TextLayoutSegments && req.TextTimestamps == domain.TextTimestampsNone && len(gaps) == 0) {
+		return "", nil
+	}
+	text := transcriptText(segments, gaps, req.TextLayout, req.TextTimestamps, req.TimestampInterval)
+	if err := p.writeText(req, textPath, text); err != nil {
+		return "", err
+	}
+	return text, nil
+}
@@ -0,0 +1,107 @@
+package transcribe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestTranscriptText renders plain, per-segment, and per-paragraph
+// transcripts with silence markers where the gaps fall.
+func TestTranscriptText(t *testing.T) {
+	segments := []TranscriptSegment{
+		{StartMs: 1000, EndMs: 2000, Text: "Hello."},
+		{StartMs: 12000, EndMs: 14000, Text: "Welcome."},
+		{StartMs: 40000, EndMs: 42000, Text: "Next topic."},
+		{StartMs: 43000, EndMs: 44000, Text: "Thanks.", Speaker: "Speaker 2"},
+		{StartMs: 750000, EndMs: 751000, Text: "Back again."},
+	}
+	gaps := []SilenceGap{
+		{StartMs: 44000, EndMs: 750000, Kind: "silence"},
+		{StartMs: 751000, EndMs: 842000, Kind: "music"},
+	}
+	tests := []struct {
+		name     string
+		gaps     []SilenceGap
+		style    domain.TextTimestamps
+		interval time.Duration
+		want     string
+	}{
+		{
+			name: "markers only",
+			gaps: gaps,
+			want: "Hello.\nWelcome.\nNext topic.\nSpeaker 2: Thanks.\n[silence 00:00:44–00:12:30]\nBack again.\n[music 00:12:31–00:14:02]\n",
+		},
+		{
+			name:  "per segment",
+			style: domain.TextTimestampsSegment,
+			want:  "[00:00:01] Hello.\n[00:00:12] Welcome.\n[00:00:40] Next topic.\n[00:00:43] Speaker 2: Thanks.\n[00:12:30] Back again.\n",
+		},
+		{
+			name:  "default interval",
+			style: domain.TextTimestampsInterval,
+			want:  "[00:00:01] Hello. Welcome.\n\n[00:00:40] Next topic.\n\n[00:00:43] Speaker 2: Thanks.\n\n[00:12:30] Back again.\n",
+		},
+		{
+			name:     "long interval with markers",
+			gaps:     gaps,
+			style:    domain.TextTimestampsInterval,
+			interval: time.Minute,
+			want:     "[00:00:01] Hello. Welcome. Next topic.\n\n[00:00:43] Speaker 2: Thanks.\n\n[silence 00:00:44–00:12:30]\n\n[00:12:30] Back again.\n\n[music 00:12:31–00:14:02]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transcriptText(segments, tt.gaps, domain.TextLayoutSegments, tt.style, tt.interval); got != tt.want {
+				t.Fatalf("transcriptText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPipelineRunTimestampsTranscript replaces whisper's text with the
+// timestamped rendering of its segments.
+func TestPipelineRunTimestampsTranscript(t *testing.T) {
+	root := t.TempDir()
+	inputPath := filepath.Join(root, "talk.mp4")
+	modelPath := filepath.Join(root, "ggml-base.bin")
+	mustWriteFile(t, inputPath, "media")
+	mustWriteFile(t, modelPath, "model")
+
+	runner := &fakeRunner{run: func(ctx context.Context, name string, args ...string) (commandResult, error) {
+		if name == "ffmpeg" {
+			mustWriteFile(t, args[len(args)-1], "wav")
+			return commandResult{}, nil
+		}
+		base := argValue(args, "-of")
+		mustWriteFile(t, base+".txt", " One.\n Two.\n")
+		mustWriteFile(t, base+".json", `{"transcription":[`+
+			`{"offsets":{"from":0,"to":4000},"text":" One."},`+
+			`{"offsets":{"from":65000,"to":66000},"text":" Two."}]}`)
+		return commandResult{}, nil
+	}}
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", runner, os.MkdirTemp, os.RemoveAll, os.Stat)
+	result, err := pipeline.Run(context.Background(), Request{
+		InputPath:      inputPath,
+		ModelPath:      modelPath,
+		OutputDir:      filepath.Join(root, "output"),
+		TextTimestamps: domain.TextTimestampsSegment,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	defer result.Cleanup()
+
+	const want = "[00:00:00] One.\n[00:01:05] Two.\n"
+	data, err := os.ReadFile(result.TextPath)
+	if err != nil || string(data) != want {
+		t.Fatalf("transcript file = %q (%v), want %q", data, err, want)
+	}
+	if result.Transcript != "[00:00:00] One.\n[00:01:05] Two." {
+		t.Fatalf("transcript = %q", result.Transcript)
+	}
+}
@@ -0,0 +1,194 @@
+package transcribe
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// TransformSuffix ends the file name of a transform script.
+const TransformSuffix = ".lua"
+
+// transformTimeout bounds one run of a transform script, so a runaway loop
+// cannot hold up the exporting stage.
+const transformTimeout = 30 * time.Second
+
+// Transform is a user Lua script run in the exporting stage with the job's
+// segments and metadata. What it passes to write or print is saved to
+// <name>.<script name without .lua>, e.g. <name>.notes.md for notes.md.lua;
+// rename("file") picks another file name in the output directory, and an
+// output that is only whitespace writes nothing. An output may not replace
+// another file of the job, such as its transcript or subtitles. Scripts get
+// the base, string, table, and math libraries only, with no file, process,
+// or module access.
+type Transform struct {
+	Name   string
+	Source string
+}
+
+// TransformData is what a transform script sees: the job table and the
+// segments array.
+type TransformData struct {
+	// Name is the base name of the job's outputs and Input the media it read.
+	Name       string
+	Input      string
+	Language   string
+	Transcript string
+	Segments   []TranscriptSegment
+}
+
+// transformLibs are the Lua standard libraries opened for scripts.
+var transformLibs = []struct {
+	name string
+	open lua.LGFunction
+}{
+	{lua.BaseLibName, lua.OpenBase},
+	{lua.TabLibName, lua.OpenTable},
+	{lua.StringLibName, lua.OpenString},
+	{lua.MathLibName, lua.OpenMath},
+}
+
+// transformBlocked are base library globals that reach outside the sandbox.
+var transformBlocked = []string{"dofile", "loadfile", "require", "module", "collectgarbage", "_printregs"}
+
+// ParseTransform compiles a transform script, reporting syntax errors before
+// a job runs into them.
+func ParseTransform(name, source string) (*lua.FunctionProto, error) {
+	chunk, err := parse.Parse(strings.NewReader(source), name)
+	if err != nil {
+		return nil, err
+	}
+	return lua.Compile(chunk, name)
+}
+
+// exportTransforms runs the request's transforms in order and returns the
+// files they wrote. reserved lists the job's other outputs, which transforms
+// must not overwrite.
+func (p *Pipeline) exportTransforms(ctx context.Context, req Request, data TransformData, textBase string, reserved []string) ([]string, error) {
+	taken := map[string]bool{}
+	for _, path := range reserved {
+		if path != "" {
+			taken[filepath.Clean(path)] = true
+		}
+	}
+	var paths []string
+	for _, transform := range req.Transforms {
+		path, err := p.runTransform(ctx, req, transform, data, textBase, taken)
+		if err != nil {
+			return paths, fmt.Errorf("transform %s: %w", transform.Name, err)
+		}
+		if path != "" {
+			taken[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// runTransform runs one transform and writes its output, returning the
+// written path or "" when the output was empty.
+func (p *Pipeline) runTransform(ctx context.Context, req Request, transform Transform, data TransformData, textBase string, taken map[string]bool) (string, error) {
+	proto, err := ParseTransform(transform.Name, transform.Source)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, transformTimeout)
+	defer cancel()
+
+	state := newTransformState(data)
+	defer state.Close()
+	state.SetContext(ctx)
+
+	path := textBase + "." + strings.TrimSuffix(transform.Name, TransformSuffix)
+	var out strings.Builder
+	write := func(state *lua.LState, sep, end string) {
+		for i := 1; i <= state.GetTop(); i++ {
+			if i > 1 {
+				out.WriteString(sep)
+			}
+			out.WriteString(state.ToStringMeta(state.Get(i)).String())
+		}
+		out.WriteString(end)
+	}
+	state.SetGlobal("write", state.NewFunction(func(state *lua.LState) int {
+		write(state, "", "")
+		return 0
+	}))
+	state.SetGlobal("print", state.NewFunction(func(state *lua.LState) int {
+		write(state, "\t", "\n")
+		return 0
+	}))
+	state.SetGlobal("rename", state.NewFunction(func(state *lua.LState) int {
+		name := state.CheckString(1)
+		base := filepath.Base(strings.TrimSpace(name))
+		if base == "." || base == ".." || base != strings.TrimSpace(name) {
+			state.RaiseError("rename %q: want a file name without directories", name)
+			return 0
+		}
+		path = filepath.Join(filepath.Dir(textBase), base)
+		return 0
+	}))
+
+	state.Push(state.NewFunctionFromProto(proto))
+	if err := state.PCall(0, 0, nil); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("script stopped: %w", ctx.Err())
+		}
+		return "", err
+	}
+	if strings.TrimSpace(out.String()) == "" {
+		return "", nil
+	}
+	path = filepath.Clean(path)
+	if taken[path] {
+		return "", fmt.Errorf("output %s would overwrite another output of the job", filepath.Base(path))
+	}
+	if err := p.writeText(req, path, out.String()); err != nil {
+		return path, err
+	}
+	return path, nil
+}
+
+// newTransformState returns a sandboxed Lua state with the job table, the
+// segments array, and the timestamp helper set.
+func newTransformState(data TransformData) *lua.LState {
+	state := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range transformLibs {
+		state.Push(state.NewFunction(lib.open))
+		state.Push(lua.LString(lib.name))
+		state.Call(1, 0)
+	}
+	for _, name := range transformBlocked {
+		state.SetGlobal(name, lua.LNil)
+	}
+
+	job := state.NewTable()
+	job.RawSetString("name", lua.LString(data.Name))
+	job.RawSetString("input", lua.LString(data.Input))
+	job.RawSetString("language", lua.LString(data.Language))
+	job.RawSetString("transcript", lua.LString(data.Transcript))
+	state.SetGlobal("job", job)
+
+	segments := state.CreateTable(len(data.Segments), 0)
+	for _, segment := range data.Segments {
+		row := state.CreateTable(0, 5)
+		row.RawSetString("startMs", lua.LNumber(segment.StartMs))
+		row.RawSetString("endMs", lua.LNumber(segment.EndMs))
+		row.RawSetString("text", lua.LString(segment.Text))
+		row.RawSetString("confidence", lua.LNumber(segment.Confidence))
+		row.RawSetString("speaker", lua.LString(segment.Speaker))
+		segments.Append(row)
+	}
+	state.SetGlobal("segments", segments)
+
+	state.SetGlobal("timestamp", state.NewFunction(func(state *lua.LState) int {
+		state.Push(lua.LString(formatTimestamp(int64(state.CheckNumber(1)))))
+		return 1
+	}))
+	return state
+}
@@ -0,0 +1,133 @@
+package transcribe
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"media-transcriber/internal/domain"
+)
+
+// TestTransforms runs transform scripts over the segments of a reformat run:
+// formatting, filtering, keeping state across segments, and renaming their
+// output, or failing the exporting stage on a broken or escaping script.
+func TestTransforms(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		wantFile string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "notes.md.lua",
+			script:   "write('# ', job.name, ' (', job.language, ')\\n')\nfor _, s in ipairs(segments) do\n  write('- [', timestamp(s.startMs), '] ', s.speaker, ': ', s.text, '\\n')\nend",
+			wantFile: "talk.notes.md",
+			want:     "# talk (en)\n- [00:00:00.000] Speaker 1: Hello there.\n- [00:00:01.500] Speaker 2: um\n- [00:00:02.000] Speaker 1: Bye.\n",
+		},
+		{
+			name: "clean.txt.lua",
+			script: `local names = {["Speaker 1"] = "Anna"}
+for _, s in ipairs(segments) do
+  if s.confidence > 0.5 and not s.text:find("^um") then
+    print((names[s.speaker] or s.speaker) .. ": " .. s.text)
+  end
+end`,
+			wantFile: "talk.clean.txt",
+			want:     "Anna: Hello there.\nAnna: Bye.\n",
+		},
+		{
+			name: "turns.txt.lua",
+			script: `local last, words = nil, 0
+for _, s in ipairs(segments) do
+  if s.speaker ~= last then words = 0; last = s.speaker end
+  words = words + select(2, s.text:gsub("%S+", ""))
+  print(s.speaker, words)
+end`,
+			wantFile: "talk.turns.txt",
+			want:     "Speaker 1\t2\nSpeaker 2\t1\nSpeaker 1\t1\n",
+		},
+		{
+			name:     "rename.lua",
+			script:   `rename("summary.txt") write(job.transcript:upper())`,
+			wantFile: "summary.txt",
+			want:     "SPEAKER 1: HELLO THERE.\nSPEAKER 2: UM\nSPEAKER 1: BYE.",
+		},
+		{name: "empty.txt.lua", script: "for _, s in ipairs(segments) do if s.text:find('never') then write(s.text) end end\nwrite('\\n')"},
+		{name: "escape.lua", script: `rename("../outside.txt") write("text")`, wantErr: true},
+		{name: "transcript.lua", script: `rename("talk.txt") write("text")`, wantErr: true},
+		{name: "subtitles.lua", script: `rename("talk.srt") write("text")`, wantErr: true},
+		{name: "segments.lua", script: `rename("talk.segments.json") write("text")`, wantErr: true},
+		{name: "txt.lua", script: `write("text")`, wantErr: true},
+		{name: "broken.lua", script: "for _, s in ipairs(segments) do", wantErr: true},
+		{name: "runtime.lua", script: "write(segments[1].missing.field)", wantErr: true},
+		{name: "files.lua", script: `io.open("/etc/passwd")`, wantErr: true},
+		{name: "commands.lua", script: `os.execute("true")`, wantErr: true},
+		{name: "dofile.lua", script: `dofile("/etc/passwd")`, wantErr: true},
+		{name: "require.lua", script: `require("os")`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			segmentsPath := filepath.Join(root, "talk"+SegmentsSuffix)
+			mustWriteFile(t, segmentsPath, `{"language":"en","segments":[`+
+				`{"startMs":0,"endMs":1500,"text":"Hello there.","confidence":0.9,"speaker":"Speaker 1"},`+
+				`{"startMs":1500,"endMs":2000,"text":"um","confidence":0.8,"speaker":"Speaker 2"},`+
+				`{"startMs":2000,"endMs":3000,"text":"Bye.","confidence":0.7,"speaker":"Speaker 1"}]}`)
+			pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", &fakeRunner{}, os.MkdirTemp, os.RemoveAll, os.Stat)
+
+			result, err := pipeline.Run(context.Background(), Request{
+				InputPath:  segmentsPath,
+				OutputDir:  root,
+				Mode:       domain.PipelineModeReformat,
+				Transforms: []Transform{{Name: tt.name, Source: tt.script}},
+			})
+			if tt.wantErr {
+				var pipelineErr *PipelineError
+				if !errors.As(err, &pipelineErr) || pipelineErr.Stage != "exporting" {
+					t.Fatalf("Run() error = %v, want an exporting failure", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if tt.wantFile == "" {
+				if len(result.TransformPaths) != 0 {
+					t.Fatalf("transform paths = %v, want none for empty output", result.TransformPaths)
+				}
+				return
+			}
+			path := filepath.Join(root, tt.wantFile)
+			if !reflect.DeepEqual(result.TransformPaths, []string{path}) {
+				t.Fatalf("transform paths = %v, want %v", result.TransformPaths, []string{path})
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read transform output: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Fatalf("output = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
+
+// TestTransformStopsRunawayScript ends a script that never finishes when its
+// context does.
+func TestTransformStopsRunawayScript(t *testing.T) {
+	root := t.TempDir()
+	pipeline := NewPipelineForTests("ffmpeg", "whisper-cli", &fakeRunner{}, os.MkdirTemp, os.RemoveAll, os.Stat)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	req := Request{Transforms: []Transform{{Name: "loop.txt.lua", Source: "while true do end"}}}
+	_, err := pipeline.exportTransforms(ctx, req, TransformData{}, filepath.Join(root, "talk"), nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("exportTransforms() error = %v, want deadline exceeded", err)
+	}
+}
@@ -0,0 +1,49 @@
+package transcribe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WorkspacePattern names per-job temp workspaces; smoke tests and benchmarks
+// share the prefix so one sweep covers every leftover.
+const WorkspacePattern = "media-transcriber-*"
+
+// SweepOrphanedWorkspaces removes media-transcriber-* directories under parent
+// (the OS temp directory when empty) last modified before olderThan ago.
+// Crashed runs leave these behind because Cleanup never ran. It returns the
+// removed paths and the first removal error, continuing past failures.
+func SweepOrphanedWorkspaces(parent string, olderThan time.Duration, now time.Time) ([]string, error) {
+	if strings.TrimSpace(parent) == "" {
+		parent = os.TempDir()
+	}
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(WorkspacePattern, "*")
+	cutoff := now.Add(-olderThan)
+	var removed []string
+	var firstErr error
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		path := filepath.Join(parent, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		removed = append(removed, path)
+	}
+	return removed, firstErr
+}
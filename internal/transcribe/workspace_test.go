@@ -0,0 +1,51 @@
+package transcribe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSweepOrphanedWorkspacesRemovesOnlyStaleWorkspaces checks prefix and age filtering.
+func TestSweepOrphanedWorkspacesRemovesOnlyStaleWorkspaces(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+
+	dirs := []struct {
+		name    string
+		modTime time.Time
+		removed bool
+	}{
+		{name: "media-transcriber-123", modTime: old, removed: true},
+		{name: "media-transcriber-smoke-456", modTime: old, removed: true},
+		{name: "media-transcriber-789", modTime: now, removed: false},
+		{name: "other-app-123", modTime: old, removed: false},
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(root, dir.name)
+		mustWriteFile(t, filepath.Join(path, "preprocessed-16k-mono.wav"), "wav")
+		if err := os.Chtimes(path, dir.modTime, dir.modTime); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+	mustWriteFile(t, filepath.Join(root, "media-transcriber-file"), "not a dir")
+
+	removed, err := SweepOrphanedWorkspaces(root, 24*time.Hour, now)
+	if err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Fatalf("removed = %v, want 2 entries", removed)
+	}
+	for _, dir := range dirs {
+		_, statErr := os.Stat(filepath.Join(root, dir.name))
+		if gone := os.IsNotExist(statErr); gone != dir.removed {
+			t.Fatalf("%s removed = %v, want %v", dir.name, gone, dir.removed)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "media-transcriber-file")); err != nil {
+		t.Fatalf("plain file should be kept: %v", err)
+	}
+}
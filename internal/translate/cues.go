@@ -0,0 +1,38 @@
+package translate
+
+import (
+	"context"
+	"fmt"
+
+	"media-transcriber/internal/subtitle"
+)
+
+// BatchSize is how many cues go into one backend request.
+const BatchSize = 40
+
+// Cues translates cue texts in batches and returns copies with the original timing.
+// A batch answered with the wrong number of lines fails rather than shifting text
+// onto the wrong cues.
+func Cues(ctx context.Context, t Translator, cues []subtitle.Cue, sourceLang, targetLang string) ([]subtitle.Cue, error) {
+	out := make([]subtitle.Cue, len(cues))
+	copy(out, cues)
+	for start := 0; start < len(out); start += BatchSize {
+		end := min(start+BatchSize, len(out))
+		texts := make([]string, 0, end-start)
+		for _, cue := range out[start:end] {
+			texts = append(texts, cue.Text)
+		}
+
+		translated, err := t.Translate(ctx, texts, sourceLang, targetLang)
+		if err != nil {
+			return nil, fmt.Errorf("translate cues %d-%d: %w", start+1, end, err)
+		}
+		if len(translated) != len(texts) {
+			return nil, fmt.Errorf("translate cues %d-%d: got %d lines for %d", start+1, end, len(translated), len(texts))
+		}
+		for i, text := range translated {
+			out[start+i].Text = text
+		}
+	}
+	return out, nil
+}
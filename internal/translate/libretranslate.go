@@ -0,0 +1,69 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// libreTranslate talks to a LibreTranslate server, which accepts a batch of texts per request.
+type libreTranslate struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+}
+
+// Translate posts texts as one batch to /translate.
+func (t *libreTranslate) Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	body, err := json.Marshal(map[string]any{
+		"q":       texts,
+		"source":  sourceLang,
+		"target":  targetLang,
+		"format":  "text",
+		"api_key": t.apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var out struct {
+		TranslatedText []string `json:"translatedText"`
+		Error          string   `json:"error"`
+	}
+	if err := doJSON(t.client, req, &out); err != nil {
+		if out.Error != "" {
+			return nil, fmt.Errorf("LibreTranslate: %s", out.Error)
+		}
+		return nil, fmt.Errorf("LibreTranslate: %w", err)
+	}
+	return out.TranslatedText, nil
+}
+
+// doJSON sends req and decodes the JSON body into out, also on error statuses
+// so callers can surface the server's message.
+func doJSON(client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return err
+	}
+	decodeErr := json.Unmarshal(data, out)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(data[:min(len(data), 200)])))
+	}
+	return decodeErr
+}
@@ -0,0 +1,48 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"media-transcriber/internal/llm"
+)
+
+// openAIChat translates with an LLM behind an OpenAI-compatible chat API.
+type openAIChat struct {
+	client *llm.Client
+}
+
+// Translate sends texts as a JSON array and expects an array of the same length back.
+func (t *openAIChat) Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	input, err := json.Marshal(texts)
+	if err != nil {
+		return nil, err
+	}
+	source := sourceLang
+	if source == "" || strings.EqualFold(source, "auto") {
+		source = "the source language"
+	}
+	prompt := fmt.Sprintf("Translate each subtitle line in this JSON array from %s to %s. "+
+		"Keep the array length and order, keep line breaks inside items, and reply with the JSON array only.", source, targetLang)
+
+	reply, err := t.client.Complete(ctx, "You are a professional subtitle translator.", prompt+"\n\n"+string(input))
+	if err != nil {
+		return nil, err
+	}
+	return parseJSONArray(reply)
+}
+
+// parseJSONArray extracts the string array from a reply, tolerating a markdown code fence.
+func parseJSONArray(content string) ([]string, error) {
+	raw, err := llm.ExtractJSON(content, '[', ']')
+	if err != nil {
+		return nil, err
+	}
+	var texts []string
+	if err := json.Unmarshal([]byte(raw), &texts); err != nil {
+		return nil, fmt.Errorf("decode LLM reply: %w", err)
+	}
+	return texts, nil
+}
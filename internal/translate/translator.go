@@ -0,0 +1,64 @@
+package translate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"media-transcriber/internal/llm"
+)
+
+// Provider names a translation backend.
+type Provider string
+
+const (
+	// ProviderLibreTranslate calls a LibreTranslate-compatible /translate endpoint.
+	ProviderLibreTranslate Provider = "libretranslate"
+	// ProviderOpenAI calls an OpenAI-compatible /chat/completions endpoint with an LLM.
+	ProviderOpenAI Provider = "openai"
+)
+
+const requestTimeout = 2 * time.Minute
+
+// ErrNotConfigured means no translation backend is selected in settings.
+var ErrNotConfigured = errors.New("translation backend is not configured")
+
+// Translator turns texts into targetLang, returning one translation per input in order.
+// sourceLang may be "auto".
+type Translator interface {
+	Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error)
+}
+
+// Config selects and addresses a backend.
+type Config struct {
+	Provider Provider
+	Endpoint string
+	Model    string
+	APIKey   string
+}
+
+// New builds the translator for cfg.
+func New(cfg Config) (Translator, error) {
+	endpoint := strings.TrimRight(strings.TrimSpace(cfg.Endpoint), "/")
+	client := &http.Client{Timeout: requestTimeout}
+	switch cfg.Provider {
+	case ProviderLibreTranslate:
+		if endpoint == "" {
+			return nil, fmt.Errorf("LibreTranslate endpoint is required")
+		}
+		return &libreTranslate{endpoint: endpoint, apiKey: cfg.APIKey, client: client}, nil
+	case ProviderOpenAI:
+		client, err := llm.NewClient(endpoint, cfg.APIKey, cfg.Model)
+		if err != nil {
+			return nil, err
+		}
+		return &openAIChat{client: client}, nil
+	case "":
+		return nil, ErrNotConfigured
+	default:
+		return nil, fmt.Errorf("unknown translation provider: %s", cfg.Provider)
+	}
+}
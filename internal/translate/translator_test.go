@@ -0,0 +1,138 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"media-transcriber/internal/subtitle"
+)
+
+// TestLibreTranslateSendsBatch checks the request shape and reply decoding.
+func TestLibreTranslateSendsBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/translate" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body struct {
+			Q      []string `json:"q"`
+			Source string   `json:"source"`
+			Target string   `json:"target"`
+			APIKey string   `json:"api_key"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Source != "en" || body.Target != "de" || body.APIKey != "key" || len(body.Q) != 2 {
+			t.Errorf("body = %+v", body)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"translatedText": []string{"Hallo", "Tschüss"}})
+	}))
+	defer server.Close()
+
+	translator, err := New(Config{Provider: ProviderLibreTranslate, Endpoint: server.URL + "/", APIKey: "key"})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	got, err := translator.Translate(context.Background(), []string{"Hello", "Bye"}, "en", "de")
+	if err != nil || len(got) != 2 || got[1] != "Tschüss" {
+		t.Fatalf("Translate() = %v, %v", got, err)
+	}
+}
+
+// TestOpenAIChatParsesFencedArray checks auth and tolerant reply parsing.
+func TestOpenAIChatParsesFencedArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk" || r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("auth = %q path = %s", r.Header.Get("Authorization"), r.URL.Path)
+		}
+		reply := "```json\n[\"Hola\", \"Adiós\"]\n```"
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"content": reply}}},
+		})
+	}))
+	defer server.Close()
+
+	translator, err := New(Config{Provider: ProviderOpenAI, Endpoint: server.URL + "/v1", Model: "gpt", APIKey: "sk"})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	got, err := translator.Translate(context.Background(), []string{"Hello", "Bye"}, "auto", "es")
+	if err != nil || strings.Join(got, "|") != "Hola|Adiós" {
+		t.Fatalf("Translate() = %v, %v", got, err)
+	}
+}
+
+// TestBackendErrorsSurfaceServerMessage reports the server's error text.
+func TestBackendErrorsSurfaceServerMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": "xx is not supported"})
+	}))
+	defer server.Close()
+
+	translator, _ := New(Config{Provider: ProviderLibreTranslate, Endpoint: server.URL})
+	if _, err := translator.Translate(context.Background(), []string{"a"}, "en", "xx"); err == nil || !strings.Contains(err.Error(), "xx is not supported") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// TestNewValidatesConfig covers missing and unknown backends.
+func TestNewValidatesConfig(t *testing.T) {
+	if _, err := New(Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Fatalf("err = %v, want ErrNotConfigured", err)
+	}
+	for _, cfg := range []Config{
+		{Provider: ProviderLibreTranslate},
+		{Provider: ProviderOpenAI},
+		{Provider: "babelfish", Endpoint: "http://x"},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Fatalf("expected error for %+v", cfg)
+		}
+	}
+}
+
+// fakeTranslator upper-cases texts and records batch sizes.
+type fakeTranslator struct {
+	batches []int
+	drop    bool
+}
+
+func (f *fakeTranslator) Translate(ctx context.Context, texts []string, sourceLang, targetLang string) ([]string, error) {
+	f.batches = append(f.batches, len(texts))
+	out := make([]string, 0, len(texts))
+	for _, text := range texts {
+		out = append(out, strings.ToUpper(text))
+	}
+	if f.drop {
+		out = out[1:]
+	}
+	return out, nil
+}
+
+// TestCuesBatchesAndKeepsTiming translates in batches without touching timing.
+func TestCuesBatchesAndKeepsTiming(t *testing.T) {
+	cues := make([]subtitle.Cue, BatchSize+5)
+	for i := range cues {
+		cues[i] = subtitle.Cue{StartMs: int64(i) * 1000, EndMs: int64(i)*1000 + 500, Text: "line"}
+	}
+
+	fake := &fakeTranslator{}
+	got, err := Cues(context.Background(), fake, cues, "en", "de")
+	if err != nil {
+		t.Fatalf("cues: %v", err)
+	}
+	if len(fake.batches) != 2 || fake.batches[0] != BatchSize || fake.batches[1] != 5 {
+		t.Fatalf("batches = %v", fake.batches)
+	}
+	if got[3].Text != "LINE" || got[3].StartMs != 3000 || cues[3].Text != "line" {
+		t.Fatalf("cue = %+v, original = %+v", got[3], cues[3])
+	}
+
+	if _, err := Cues(context.Background(), &fakeTranslator{drop: true}, cues, "en", "de"); err == nil {
+		t.Fatal("expected error when a batch loses lines")
+	}
+}
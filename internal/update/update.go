@@ -0,0 +1,310 @@
+// Package update checks the app's GitHub releases for a newer version, picks
+// the installer for the running platform, and verifies it against the
+// release checksums.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// LatestReleaseURL is the GitHub API endpoint of the app's latest release.
+const LatestReleaseURL = "https://api.github.com/repos/korvin3/media-transcriber/releases/latest"
+
+// ChecksumsName is the release asset listing SHA-256 sums of the other assets.
+const ChecksumsName = "SHA256SUMS.txt"
+
+// maxMetadataBytes bounds release metadata and checksum downloads.
+const maxMetadataBytes = 4 << 20
+
+// Release is the part of a GitHub release the updater uses.
+type Release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Body    string  `json:"body"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is one downloadable file of a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Checker fetches release metadata from URL. Fetch, when set, requests the
+// metadata, so the app's GitHub client with its token, cache, and retries
+// serves it; otherwise, and for checksum files, Client is used, and a nil
+// Client means http.DefaultClient.
+type Checker struct {
+	URL    string
+	Fetch  func(ctx context.Context, url string) ([]byte, error)
+	Client *http.Client
+}
+
+// Latest returns the newest published release.
+func (c Checker) Latest(ctx context.Context) (Release, error) {
+	var body []byte
+	var err error
+	if c.Fetch != nil {
+		body, err = c.Fetch(ctx, c.URL)
+	} else {
+		body, err = c.get(ctx, c.URL, "application/vnd.github+json")
+	}
+	if err != nil {
+		return Release{}, fmt.Errorf("request release metadata: %w", err)
+	}
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return Release{}, fmt.Errorf("decode release metadata: %w", err)
+	}
+	if strings.TrimSpace(release.TagName) == "" {
+		return Release{}, fmt.Errorf("release metadata did not include a tag name")
+	}
+	return release, nil
+}
+
+// Checksum returns the published SHA-256 of the named asset. A release
+// without a checksums file, or one that does not list the asset, is an error:
+// the installer could not be verified.
+func (c Checker) Checksum(ctx context.Context, release Release, name string) (string, error) {
+	for _, asset := range release.Assets {
+		if asset.Name != ChecksumsName {
+			continue
+		}
+		body, err := c.get(ctx, asset.URL, "")
+		if err != nil {
+			return "", fmt.Errorf("download %s: %w", ChecksumsName, err)
+		}
+		sum, ok := ParseChecksums(body)[name]
+		if !ok {
+			return "", fmt.Errorf("%s does not list %s", ChecksumsName, name)
+		}
+		return sum, nil
+	}
+	return "", fmt.Errorf("release %s has no %s", release.TagName, ChecksumsName)
+}
+
+// get fetches url and returns its body.
+func (c Checker) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	req.Header.Set("User-Agent", "media-transcriber")
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxMetadataBytes))
+}
+
+// ParseChecksums reads sha256sum output into a map from file name to sum.
+func ParseChecksums(data []byte) map[string]string {
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(fields[1], "*"), "./")
+		sums[name] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// VerifyFile checks that the file at path has the SHA-256 sum want.
+func VerifyFile(path, want string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != strings.ToLower(want) {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, want)
+	}
+	return nil
+}
+
+// Newer reports whether latest is a higher version than current. Versions
+// look like "v1.2.3" with an optional "-rc.1" pre-release suffix; a current
+// version that does not parse, such as "dev", is never offered updates.
+func Newer(latest, current string) bool {
+	l, lok := parseVersion(latest)
+	c, cok := parseVersion(current)
+	if !lok || !cok {
+		return false
+	}
+	for i := range l.numbers {
+		if l.numbers[i] != c.numbers[i] {
+			return l.numbers[i] > c.numbers[i]
+		}
+	}
+	switch {
+	case l.pre == c.pre:
+		return false
+	case l.pre == "":
+		return true
+	case c.pre == "":
+		return false
+	default:
+		return comparePrerelease(l.pre, c.pre) > 0
+	}
+}
+
+// comparePrerelease orders pre-release tags by their dot-separated parts as
+// semver does: numeric parts by value, so "rc.10" follows "rc.9", numeric
+// before alphanumeric, and a tag before any longer one it prefixes.
+func comparePrerelease(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNumber != bNumber {
+				return cmp.Compare(aNumber, bNumber)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if order := strings.Compare(aParts[i], bParts[i]); order != 0 {
+				return order
+			}
+		}
+	}
+	return cmp.Compare(len(aParts), len(bParts))
+}
+
+// version is a parsed major.minor.patch with an optional pre-release tag.
+type version struct {
+	numbers [3]int
+	pre     string
+}
+
+// parseVersion accepts "1", "1.2", or "1.2.3", optionally prefixed with "v".
+func parseVersion(s string) (version, bool) {
+	var v version
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, v.pre, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) > len(v.numbers) {
+		return version{}, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		v.numbers[i] = n
+	}
+	return v, true
+}
+
+// installerKind is an installer file suffix, in order of preference for an
+// OS. Generic suffixes only match when the name also mentions the OS.
+type installerKind struct {
+	suffix    string
+	requireOS bool
+}
+
+var installerKinds = map[string][]installerKind{
+	"windows": {{suffix: "-installer.exe"}, {suffix: ".msi"}, {suffix: ".exe"}, {suffix: ".zip", requireOS: true}},
+	"darwin":  {{suffix: ".dmg"}, {suffix: ".pkg"}, {suffix: ".app.zip"}, {suffix: ".app.tar.gz"}},
+	"linux":   {{suffix: ".appimage"}, {suffix: ".deb"}, {suffix: ".rpm"}, {suffix: ".tar.gz", requireOS: true}},
+}
+
+// osTokens are asset name words naming each OS.
+var osTokens = map[string][]string{
+	"windows": {"windows", "win64", "win32"},
+	"darwin":  {"darwin", "macos", "osx"},
+	"linux":   {"linux"},
+}
+
+// archTokens are asset name words naming each architecture.
+var archTokens = map[string][]string{
+	"amd64": {"amd64", "x86_64", "x64"},
+	"arm64": {"arm64", "aarch64"},
+}
+
+// SelectInstaller picks the installer asset for goos and goarch, skipping
+// assets that name another OS or architecture.
+func SelectInstaller(release Release, goos, goarch string) (Asset, bool) {
+	for _, kind := range installerKinds[goos] {
+		for _, asset := range release.Assets {
+			name := strings.ToLower(asset.Name)
+			if !strings.HasSuffix(name, kind.suffix) || mentionsOther(name, osTokens, goos) || mentionsOther(name, archTokens, goarch) {
+				continue
+			}
+			if kind.requireOS && !containsAny(name, osTokens[goos]) {
+				continue
+			}
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// mentionsOther reports whether name contains a token of a key other than own.
+func mentionsOther(name string, tokens map[string][]string, own string) bool {
+	for key, values := range tokens {
+		if key != own && containsAny(name, values) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny reports whether name contains any of tokens as whole words, so
+// "win64" does not match inside "darwin64". A token of several words, such
+// as "x86_64", matches those words in a row.
+func containsAny(name string, tokens []string) bool {
+	words := nameWords(name)
+	for _, token := range tokens {
+		want := nameWords(token)
+		for i := 0; i+len(want) <= len(words); i++ {
+			if slices.Equal(words[i:i+len(want)], want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nameWords splits an asset name on everything but letters and digits.
+func nameWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z')
+	})
+}
@@ -0,0 +1,192 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestNewer compares release tags, including pre-releases and dev builds.
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{latest: "v1.3.0", current: "v1.2.9", want: true},
+		{latest: "v1.10.0", current: "v1.9.0", want: true},
+		{latest: "v1.2.0", current: "v1.2.0", want: false},
+		{latest: "v1.2.0", current: "v1.3.0", want: false},
+		{latest: "v2", current: "1.9.9", want: true},
+		{latest: "v1.2.0", current: "v1.2.0-rc.1", want: true},
+		{latest: "v1.2.0-rc.2", current: "v1.2.0-rc.1", want: true},
+		{latest: "v1.2.0-rc.1", current: "v1.2.0", want: false},
+		{latest: "v1.2.0-rc.10", current: "v1.2.0-rc.9", want: true},
+		{latest: "v1.2.0-rc.9", current: "v1.2.0-rc.10", want: false},
+		{latest: "v1.2.0-rc.1.1", current: "v1.2.0-rc.1", want: true},
+		{latest: "v1.2.0-rc.1", current: "v1.2.0-beta.2", want: true},
+		{latest: "v1.2.0-beta", current: "v1.2.0-1", want: true},
+		{latest: "v1.2.0", current: "dev", want: false},
+		{latest: "nightly", current: "v1.0.0", want: false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+// TestSelectInstaller picks the preferred installer for each platform.
+func TestSelectInstaller(t *testing.T) {
+	release := Release{Assets: []Asset{
+		{Name: "SHA256SUMS.txt"},
+		{Name: "media-transcriber.exe"},
+		{Name: "media-transcriber-amd64-installer.exe"},
+		{Name: "media-transcriber.app.tar.gz"},
+		{Name: "media-transcriber-linux-arm64.tar.gz"},
+		{Name: "media-transcriber-linux-amd64.tar.gz"},
+	}}
+	tests := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{goos: "windows", goarch: "amd64", want: "media-transcriber-amd64-installer.exe"},
+		{goos: "windows", goarch: "arm64", want: "media-transcriber.exe"},
+		{goos: "darwin", goarch: "arm64", want: "media-transcriber.app.tar.gz"},
+		{goos: "linux", goarch: "amd64", want: "media-transcriber-linux-amd64.tar.gz"},
+		{goos: "linux", goarch: "arm64", want: "media-transcriber-linux-arm64.tar.gz"},
+		{goos: "freebsd", goarch: "amd64", want: ""},
+	}
+	for _, tt := range tests {
+		asset, ok := SelectInstaller(release, tt.goos, tt.goarch)
+		if asset.Name != tt.want || ok != (tt.want != "") {
+			t.Errorf("SelectInstaller(%s/%s) = %q, %v, want %q", tt.goos, tt.goarch, asset.Name, ok, tt.want)
+		}
+	}
+}
+
+// TestSelectInstallerMatchesWholeWords reads OS and architecture names only
+// as whole words of an asset name, split on any separator.
+func TestSelectInstallerMatchesWholeWords(t *testing.T) {
+	tests := []struct {
+		name         string
+		assets       []string
+		goos, goarch string
+		want         string
+	}{
+		{
+			name:   "OS inside another word",
+			assets: []string{"media-transcriber-darwin64.dmg"},
+			goos:   "darwin", goarch: "amd64",
+			want: "media-transcriber-darwin64.dmg",
+		},
+		{
+			name:   "underscore separators",
+			assets: []string{"media-transcriber_1.4.0_linux_arm64.tar.gz", "media-transcriber_1.4.0_linux_x86_64.tar.gz"},
+			goos:   "linux", goarch: "amd64",
+			want: "media-transcriber_1.4.0_linux_x86_64.tar.gz",
+		},
+		{
+			name:   "architecture inside another word",
+			assets: []string{"media-transcriber-linux-x64wrapper.tar.gz"},
+			goos:   "linux", goarch: "arm64",
+			want: "media-transcriber-linux-x64wrapper.tar.gz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var release Release
+			for _, name := range tt.assets {
+				release.Assets = append(release.Assets, Asset{Name: name})
+			}
+			if asset, _ := SelectInstaller(release, tt.goos, tt.goarch); asset.Name != tt.want {
+				t.Fatalf("SelectInstaller = %q, want %q", asset.Name, tt.want)
+			}
+		})
+	}
+}
+
+// TestCheckerFetchesReleaseAndChecksum reads the latest release and the sum
+// of one asset from its checksums file, then verifies a file against it.
+func TestCheckerFetchesReleaseAndChecksum(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"v1.4.0","html_url":"https://example.com/v1.4.0","assets":[` +
+				`{"name":"SHA256SUMS.txt","browser_download_url":"` + server.URL + `/sums"}]}`))
+		case "/sums":
+			// sha256("hello\n")
+			_, _ = w.Write([]byte("5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03  ./app.exe\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	checker := Checker{URL: server.URL + "/latest"}
+	release, err := checker.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("latest: %v", err)
+	}
+	if release.TagName != "v1.4.0" || release.HTMLURL != "https://example.com/v1.4.0" {
+		t.Fatalf("release = %+v", release)
+	}
+	sum, err := checker.Checksum(context.Background(), release, "app.exe")
+	if err != nil {
+		t.Fatalf("checksum: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "app.exe")
+	if err := os.WriteFile(path, []byte("hello\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := VerifyFile(path, sum); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("tampered\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := VerifyFile(path, sum); err == nil {
+		t.Fatal("verify should fail for a modified file")
+	}
+
+	if _, err := checker.Checksum(context.Background(), release, "other.exe"); err == nil {
+		t.Fatal("checksum should fail for an asset the checksums file does not list")
+	}
+	if _, err := checker.Checksum(context.Background(), Release{TagName: "v1.4.0"}, "app.exe"); err == nil {
+		t.Fatal("checksum should fail for a release without a checksums file")
+	}
+
+	if _, err := (Checker{URL: server.URL + "/missing"}).Latest(context.Background()); err == nil {
+		t.Fatal("latest should fail on 404")
+	}
+}
+
+// TestCheckerLatestUsesFetch reads release metadata through Fetch instead
+// of its own client when one is set.
+func TestCheckerLatestUsesFetch(t *testing.T) {
+	var fetched string
+	checker := Checker{
+		URL: "https://example.com/latest",
+		Fetch: func(_ context.Context, url string) ([]byte, error) {
+			fetched = url
+			return []byte(`{"tag_name":"v1.4.0"}`), nil
+		},
+		Client: &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			t.Fatal("Latest used its own client")
+			return nil, nil
+		})},
+	}
+	release, err := checker.Latest(context.Background())
+	if err != nil || release.TagName != "v1.4.0" || fetched != checker.URL {
+		t.Fatalf("latest = %+v, %v after fetching %q", release, err, fetched)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }